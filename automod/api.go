@@ -0,0 +1,101 @@
+package automod
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/botlabs-gg/yagpdb/v2/automod/models"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/web"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"goji.io"
+	"goji.io/pat"
+)
+
+// initAPI sets up the REST API, the rule parts use the same format as the control panel forms:
+// {"Type": 1, "Data": {"ListID": ["5"]}}
+func (p *Plugin) initAPI() {
+	web.APIMux.Handle(pat.Get("/automod"), web.APIReadHandler(p, p.apiGetAutomod))
+
+	web.APIMux.Handle(pat.Post("/automod/lists"), web.APIWriteHandler(p, p.apiCreateList, UpdateListData{}))
+	web.APIMux.Handle(pat.Put("/automod/lists/:listID"), web.APIWriteHandler(p, web.APIFromController(p.handlePostAutomodUpdateList), UpdateListData{}))
+	web.APIMux.Handle(pat.Delete("/automod/lists/:listID"), web.APIWriteHandler(p, web.APIFromController(p.handlePostAutomodDeleteList), nil))
+
+	web.APIMux.Handle(pat.Post("/automod/rulesets"), web.APIWriteHandler(p, p.apiCreateRuleset, CreateRulesetData{}))
+
+	rulesetMux := goji.SubMux()
+	web.APIMux.Handle(pat.New("/automod/rulesets/:rulesetID"), rulesetMux)
+	web.APIMux.Handle(pat.New("/automod/rulesets/:rulesetID/*"), rulesetMux)
+
+	rulesetMux.Use(p.currentRulesetMW(web.APIErrorHandler(web.NewAPIError(http.StatusNotFound, "Unknown ruleset"))))
+
+	rulesetMux.Handle(pat.Put(""), web.APIWriteHandler(p, web.APIFromController(p.handlePostAutomodUpdateRuleset), UpdateRulesetData{}))
+	rulesetMux.Handle(pat.Delete(""), web.APIWriteHandler(p, web.APIFromController(p.handlePostAutomodDeleteRuleset), nil))
+
+	rulesetMux.Handle(pat.Post("/rules"), web.APIWriteHandler(p, p.apiCreateRule, CreateRuleData{}))
+	rulesetMux.Handle(pat.Put("/rules/:ruleID"), web.APIWriteHandler(p, web.APIFromController(p.handlePostAutomodUpdateRule), UpdateRuleData{}))
+	rulesetMux.Handle(pat.Delete("/rules/:ruleID"), web.APIWriteHandler(p, web.APIFromController(p.handlePostAutomodDeleteRule), nil))
+}
+
+// apiGetAutomod returns all the lists and rulesets, in the same format as the backups
+func (p *Plugin) apiGetAutomod(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	g := web.ContextGuild(r.Context())
+	return p.ExportBackup(r.Context(), g.ID)
+}
+
+func (p *Plugin) apiCreateList(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	ctx := r.Context()
+	g := web.ContextGuild(ctx)
+	data := ctx.Value(common.ContextKeyParsedForm).(*UpdateListData)
+
+	_, err := web.APIFromController(p.handlePostAutomodCreateList)(w, r.WithContext(context.WithValue(ctx, common.ContextKeyParsedForm, &CreateListData{Name: data.Name})))
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := models.AutomodLists(qm.Where("guild_id = ?", g.ID), qm.OrderBy("id desc")).OneG(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// lists are created empty in the control panel
+	if data.Content != "" {
+		_, err = web.APIFromController(p.handlePostAutomodUpdateList)(w, r.WithContext(web.SetContextParam(ctx, "listID", list.ID)))
+		if err != nil {
+			return nil, err
+		}
+
+		err = list.ReloadG(ctx)
+	}
+
+	return list, err
+}
+
+func (p *Plugin) apiCreateRuleset(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	ctx := r.Context()
+	g := web.ContextGuild(ctx)
+
+	_, err := web.APIFromController(p.handlePostAutomodCreateRuleset)(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.AutomodRulesets(qm.Where("guild_id = ?", g.ID), qm.OrderBy("id desc")).OneG(ctx)
+}
+
+func (p *Plugin) apiCreateRule(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	ruleset := r.Context().Value(CtxKeyCurrentRuleset).(*models.AutomodRuleset)
+	numRules := len(ruleset.R.RulesetAutomodRules)
+
+	_, err := web.APIFromController(p.handlePostAutomodCreateRule)(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	// the new rule is added to the loaded ruleset
+	if len(ruleset.R.RulesetAutomodRules) <= numRules {
+		return nil, web.NewAPIError(http.StatusInternalServerError, "Failed creating rule")
+	}
+
+	return ruleset.R.RulesetAutomodRules[len(ruleset.R.RulesetAutomodRules)-1], nil
+}
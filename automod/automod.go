@@ -11,6 +11,7 @@ import (
 	"github.com/botlabs-gg/yagpdb/v2/automod/models"
 	"github.com/botlabs-gg/yagpdb/v2/common"
 	"github.com/botlabs-gg/yagpdb/v2/common/featureflags"
+	"github.com/botlabs-gg/yagpdb/v2/common/scheduledevents2"
 	"github.com/botlabs-gg/yagpdb/v2/premium"
 	"github.com/karlseguin/ccache"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -41,6 +42,8 @@ func RegisterPlugin() {
 
 	p := &Plugin{}
 	common.RegisterPlugin(p)
+
+	scheduledevents2.RegisterDescriber("amod2_reset_channel_ratelimit", ResetChannelRatelimitData{}, describeResetChannelRatelimit)
 }
 
 type ErrUnknownTypeID struct {
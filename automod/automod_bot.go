@@ -619,6 +619,10 @@ func FindFetchGuildList(guildID int64, listID int64) (*models.AutomodList, error
 	return nil, ErrListNotFound
 }
 
+func describeResetChannelRatelimit(evt *schEventsModels.ScheduledEvent, data interface{}) string {
+	return fmt.Sprintf("Turn off slowmode in channel %d", data.(*ResetChannelRatelimitData).ChannelID)
+}
+
 func handleResetChannelRatelimit(evt *schEventsModels.ScheduledEvent, data interface{}) (retry bool, err error) {
 	dataCast := data.(*ResetChannelRatelimitData)
 
@@ -0,0 +1,271 @@
+package automod
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/botlabs-gg/yagpdb/v2/automod/models"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/featureflags"
+	"github.com/botlabs-gg/yagpdb/v2/common/pubsub"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+type automodBackup struct {
+	Lists    []*models.AutomodList
+	Rulesets []*rulesetBackup
+}
+
+type rulesetBackup struct {
+	*models.AutomodRuleset
+	Conditions []*models.AutomodRulesetCondition
+	Rules      []*ruleBackup
+}
+
+type ruleBackup struct {
+	*models.AutomodRule
+	Data []*models.AutomodRuleDatum
+}
+
+var _ common.PluginWithBackup = (*Plugin)(nil)
+
+func (p *Plugin) BackupVersion() int {
+	return 1
+}
+
+func (p *Plugin) ExportBackup(ctx context.Context, guildID int64) (interface{}, error) {
+	lists, err := models.AutomodLists(qm.Where("guild_id = ?", guildID), qm.OrderBy("id asc")).AllG(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rulesets, err := models.AutomodRulesets(qm.Where("guild_id = ?", guildID),
+		qm.OrderBy("id asc"),
+		qm.Load("RulesetAutomodRulesetConditions"),
+		qm.Load("RulesetAutomodRules.RuleAutomodRuleData")).AllG(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	backup := &automodBackup{
+		Lists: lists,
+	}
+
+	for _, rs := range rulesets {
+		rsBackup := &rulesetBackup{
+			AutomodRuleset: rs,
+			Conditions:     rs.R.RulesetAutomodRulesetConditions,
+		}
+
+		for _, rule := range rs.R.RulesetAutomodRules {
+			rsBackup.Rules = append(rsBackup.Rules, &ruleBackup{
+				AutomodRule: rule,
+				Data:        rule.R.RuleAutomodRuleData,
+			})
+		}
+
+		backup.Rulesets = append(backup.Rulesets, rsBackup)
+	}
+
+	return backup, nil
+}
+
+// RestoreBackup replaces all the lists and rulesets
+func (p *Plugin) RestoreBackup(ctx context.Context, guildID int64, data json.RawMessage, restore *common.BackupRestore) error {
+	var backup automodBackup
+	err := json.Unmarshal(data, &backup)
+	if err != nil {
+		return err
+	}
+
+	if max := GuildMaxLists(guildID); len(backup.Lists) > max {
+		restore.Note("Only the first %d of %d lists were restored", max, len(backup.Lists))
+		backup.Lists = backup.Lists[:max]
+	}
+
+	if max := GuildMaxRulesets(guildID); len(backup.Rulesets) > max {
+		restore.Note("Only the first %d of %d rulesets were restored", max, len(backup.Rulesets))
+		backup.Rulesets = backup.Rulesets[:max]
+	}
+
+	tx, err := common.PQ.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// rules, rule data and conditions are removed along with their ruleset
+	_, err = models.AutomodRulesets(qm.Where("guild_id = ?", guildID)).DeleteAll(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = models.AutomodLists(qm.Where("guild_id = ?", guildID)).DeleteAll(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	listIDs := make(map[int64]int64)
+	for _, v := range backup.Lists {
+		oldID := v.ID
+		v.ID = 0
+		v.GuildID = guildID
+
+		err = v.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		listIDs[oldID] = v.ID
+	}
+
+	maxRules := GuildMaxTotalRules(guildID)
+	numRules := 0
+	for _, rs := range backup.Rulesets {
+		if rs.AutomodRuleset == nil {
+			continue
+		}
+
+		rs.ID = 0
+		rs.GuildID = guildID
+		err = rs.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		for _, cond := range rs.Conditions {
+			cond.ID = 0
+			cond.GuildID = guildID
+			cond.RulesetID = rs.ID
+			cond.Settings, err = remapRulePartSettings(cond.TypeID, cond.Settings, restore, listIDs)
+			if err != nil {
+				tx.Rollback()
+				return err
+			}
+
+			err = cond.Insert(ctx, tx, boil.Infer())
+			if err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+
+		for _, rule := range rs.Rules {
+			if rule.AutomodRule == nil {
+				continue
+			}
+
+			if numRules >= maxRules {
+				restore.Note("Skipped the rule %q as the max of %d rules was reached", rule.Name, maxRules)
+				continue
+			}
+			numRules++
+
+			rule.ID = 0
+			rule.GuildID = guildID
+			rule.RulesetID = rs.ID
+			rule.TriggerCounter = 0
+			err = rule.Insert(ctx, tx, boil.Infer())
+			if err != nil {
+				tx.Rollback()
+				return err
+			}
+
+			for _, datum := range rule.Data {
+				datum.ID = 0
+				datum.GuildID = guildID
+				datum.RuleID = rule.ID
+				datum.Settings, err = remapRulePartSettings(datum.TypeID, datum.Settings, restore, listIDs)
+				if err != nil {
+					tx.Rollback()
+					return err
+				}
+
+				err = datum.Insert(ctx, tx, boil.Infer())
+				if err != nil {
+					tx.Rollback()
+					return err
+				}
+			}
+		}
+	}
+
+	err = restore.Finish(tx)
+	if err != nil || restore.DryRun {
+		return err
+	}
+
+	pubsub.EvictCacheSet(cachedLists, guildID)
+	pubsub.EvictCacheSet(cachedRulesets, guildID)
+	featureflags.MarkGuildDirty(guildID)
+	return nil
+}
+
+// remapRulePartSettings maps the role, channel and list ids in the settings of a rule part using its setting definitions
+func remapRulePartSettings(typeID int, settings []byte, restore *common.BackupRestore, listIDs map[int64]int64) ([]byte, error) {
+	part, ok := RulePartMap[typeID]
+	if !ok || len(settings) == 0 {
+		return settings, nil
+	}
+
+	var decoded map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(settings))
+	dec.UseNumber()
+	err := dec.Decode(&decoded)
+	if err != nil || decoded == nil {
+		return settings, err
+	}
+
+	for _, def := range part.UserSettings() {
+		v, ok := decoded[def.Key]
+		if !ok {
+			continue
+		}
+
+		switch def.Kind {
+		case SettingTypeRole:
+			decoded[def.Key] = restore.Role(jsonInt64(v))
+		case SettingTypeChannel:
+			decoded[def.Key] = restore.Channel(jsonInt64(v))
+		case SettingTypeMultiRole:
+			decoded[def.Key] = restore.Roles(jsonInt64s(v))
+		case SettingTypeMultiChannel, SettingTypeMultiChannelCategories:
+			decoded[def.Key] = restore.Channels(jsonInt64s(v))
+		case SettingTypeList:
+			decoded[def.Key] = listIDs[jsonInt64(v)]
+		}
+	}
+
+	return json.Marshal(decoded)
+}
+
+func jsonInt64(v interface{}) int64 {
+	switch t := v.(type) {
+	case json.Number:
+		i, _ := t.Int64()
+		return i
+	case string:
+		i, _ := strconv.ParseInt(t, 10, 64)
+		return i
+	}
+
+	return 0
+}
+
+func jsonInt64s(v interface{}) []int64 {
+	list, _ := v.([]interface{})
+	result := make([]int64, 0, len(list))
+	for _, e := range list {
+		if i := jsonInt64(e); i != 0 {
+			result = append(result, i)
+		}
+	}
+
+	return result
+}
@@ -1,6 +1,7 @@
 package automod_legacy
 
 import (
+	"context"
 	_ "embed"
 	"fmt"
 	"html/template"
@@ -49,6 +50,33 @@ func (p *Plugin) InitWeb() {
 	// Post handlers
 	autmodMux.Handle(pat.Post("/"), ExtraPostMW(web.SimpleConfigSaverHandler(Config{}, getHandler, panelLogKeyUpdatedSettings)))
 	autmodMux.Handle(pat.Post(""), ExtraPostMW(web.SimpleConfigSaverHandler(Config{}, getHandler, panelLogKeyUpdatedSettings)))
+
+	cplogs.RegisterConfigSource(&cplogs.ConfigSource{
+		Key:  "automod_legacy",
+		Name: "Basic automoderator",
+		Page: "automod_legacy",
+		Get: func(ctx context.Context, guildID int64) (interface{}, error) {
+			return GetConfig(guildID)
+		},
+		Revert: func(ctx context.Context, guildID int64, snapshot string) error {
+			conf, err := GetConfig(guildID)
+			if err != nil {
+				return err
+			}
+
+			if err = cplogs.RestoreSnapshot(conf, snapshot); err != nil {
+				return err
+			}
+
+			if err = conf.Save(guildID); err != nil {
+				return err
+			}
+
+			pubsub.Publish("update_automod_legacy_rules", guildID, nil)
+			featureflags.MarkGuildDirty(guildID)
+			return nil
+		},
+	})
 }
 
 func HandleAutomod(w http.ResponseWriter, r *http.Request) interface{} {
@@ -2,6 +2,7 @@ package autorole
 
 import (
 	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/scheduledevents2"
 	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
 )
 
@@ -30,6 +31,8 @@ func (p *Plugin) PluginInfo() *common.PluginInfo {
 func RegisterPlugin() {
 	p := &Plugin{}
 	common.RegisterPlugin(p)
+
+	scheduledevents2.RegisterDescriber("autorole_assign_role", assignRoleEventdata{}, describeAssignRole)
 }
 
 type GeneralConfig struct {
@@ -32,6 +32,10 @@ type assignRoleEventdata struct {
 	RoleID int64 // currently unused
 }
 
+func describeAssignRole(evt *scheduledEventsModels.ScheduledEvent, data interface{}) string {
+	return fmt.Sprintf("Give the autorole to user %d", data.(*assignRoleEventdata).UserID)
+}
+
 func (p *Plugin) BotInit() {
 	eventsystem.AddHandlerAsyncLast(p, onMemberJoin, eventsystem.EventGuildMemberAdd)
 	// eventsystem.AddHandlerAsyncLast(p, HandlePresenceUpdate, eventsystem.EventPresenceUpdate)
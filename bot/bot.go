@@ -9,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/botlabs-gg/yagpdb/v2/bot/eventcapture"
 	"github.com/botlabs-gg/yagpdb/v2/bot/eventsystem"
 	"github.com/botlabs-gg/yagpdb/v2/bot/shardmemberfetcher"
 	"github.com/botlabs-gg/yagpdb/v2/common"
@@ -91,6 +92,13 @@ func Run(nodeID string) {
 
 	if UsingOrchestrator {
 		NodeConn = node.NewNodeConn(&NodeImpl{}, orcheStratorAddress, common.VERSION, nodeID, nil)
+
+		var err error
+		NodeConn.AuthSecret, NodeConn.TLSConfig, err = common.OrchestratorConnAuth(false)
+		if err != nil {
+			panic("failed loading orchestrator tls config: " + err.Error())
+		}
+
 		NodeConn.Run()
 	} else {
 		ShardManager.Init()
@@ -197,6 +205,15 @@ func botReady() {
 	// register us with the service discovery
 	common.ServiceTracker.RegisterService(common.ServiceTypeBot, "Bot", serviceDetails, botServiceDetailsF)
 
+	initPlugins()
+
+	go runUpdateMetrics()
+	go loopCheckAdmins()
+
+	watchMemusage()
+}
+
+func initPlugins() {
 	// Initialize all plugins
 	for _, plugin := range common.Plugins {
 		if initBot, ok := plugin.(BotInitHandler); ok {
@@ -210,11 +227,6 @@ func botReady() {
 			initBot.LateBotInit()
 		}
 	}
-
-	go runUpdateMetrics()
-	go loopCheckAdmins()
-
-	watchMemusage()
 }
 
 var stopOnce sync.Once
@@ -440,6 +452,8 @@ func setupShardManager() {
 		session.LogLevel = discordgo.LogInformational
 		session.SyncEvents = true
 		session.Intents = gatewayIntentsUsed
+		session.RawDispatchHandler = eventcapture.HandleRawDispatch
+		common.SetupSharedRatelimits(session)
 
 		// Certain discordgo internals expect this to be present
 		// but in case of shard migration it's not, so manually assign it here
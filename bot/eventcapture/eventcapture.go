@@ -0,0 +1,382 @@
+// Package eventcapture records the raw gateway events of selected guilds to gzipped json lines files, so that they can be
+// replayed offline with the replay package to reproduce bugs and write regression tests.
+package eventcapture
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/config"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+)
+
+var (
+	confCaptureDir = config.RegisterOption("yagpdb.eventcapture.dir", "Directory event captures are written to", "event_captures")
+
+	logger = common.GetFixedPrefixLogger("eventcapture")
+)
+
+// MaxCaptureDuration is the longest a capture can run for
+const MaxCaptureDuration = time.Hour
+
+// CapturedEvent is a single dispatch event in a capture file
+type CapturedEvent struct {
+	Type    string          `json:"t"`
+	ShardID int             `json:"shard"`
+	At      time.Time       `json:"at"`
+	Data    json.RawMessage `json:"d"`
+}
+
+// Decode decodes the data of the event into its discordgo struct
+func (c *CapturedEvent) Decode() (interface{}, error) {
+	return discordgo.DecodeEvent(c.Type, c.Data)
+}
+
+// Recorder writes the events of the guilds it captures to a file
+type Recorder struct {
+	Path   string
+	Guilds []int64
+
+	StartedAt time.Time
+	StopsAt   time.Time
+
+	mu      sync.Mutex
+	f       *os.File
+	gz      *gzip.Writer
+	enc     *json.Encoder
+	closed  bool
+	written int64
+}
+
+// NewRecorder creates a recorder writing to path, the file is truncated if it exists
+func NewRecorder(path string, guilds []int64, duration time.Duration) (*Recorder, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	gz := gzip.NewWriter(f)
+	return &Recorder{
+		Path:      path,
+		Guilds:    guilds,
+		StartedAt: time.Now(),
+		StopsAt:   time.Now().Add(duration),
+		f:         f,
+		gz:        gz,
+		enc:       json.NewEncoder(gz),
+	}, nil
+}
+
+// Capturing returns true if the recorder captures the events of the guild
+func (r *Recorder) Capturing(guildID int64) bool {
+	return common.ContainsInt64Slice(r.Guilds, guildID)
+}
+
+// Record writes the event if it belongs to one of the captured guilds
+func (r *Recorder) Record(shardID int, eventType string, data []byte) error {
+	guildID, ok := EventGuildID(eventType, data)
+	if !ok || !r.Capturing(guildID) {
+		return nil
+	}
+
+	// data is reused by the gateway connection, but it's fully written out before we return
+	return r.write(shardID, eventType, data)
+}
+
+// WriteGuildSnapshot writes the guild as a GUILD_CREATE event, so that the replay starts with the state the guild was
+// in when the capture started
+func (r *Recorder) WriteGuildSnapshot(shardID int, guild *discordgo.Guild) error {
+	data, err := json.Marshal(guild)
+	if err != nil {
+		return err
+	}
+
+	return r.write(shardID, "GUILD_CREATE", data)
+}
+
+func (r *Recorder) write(shardID int, eventType string, data []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return nil
+	}
+
+	err := r.enc.Encode(&CapturedEvent{
+		Type:    eventType,
+		ShardID: shardID,
+		At:      time.Now(),
+		Data:    json.RawMessage(data),
+	})
+	if err == nil {
+		r.written++
+	}
+
+	return err
+}
+
+// Written returns the number of events written so far
+func (r *Recorder) Written() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.written
+}
+
+// Close flushes and closes the file
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+
+	err := r.gz.Close()
+	if closeErr := r.f.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// EventGuildID returns the guild the raw event belongs to, events not specific to a guild such as READY are not
+func EventGuildID(eventType string, data []byte) (guildID int64, ok bool) {
+	if eventType == "READY" || eventType == "RESUMED" {
+		return 0, false
+	}
+
+	var dec struct {
+		ID      json.RawMessage `json:"id"`
+		GuildID json.RawMessage `json:"guild_id"`
+	}
+
+	if json.Unmarshal(data, &dec) != nil {
+		return 0, false
+	}
+
+	raw := dec.GuildID
+	switch eventType {
+	case "GUILD_CREATE", "GUILD_UPDATE", "GUILD_DELETE":
+		raw = dec.ID
+	}
+
+	var idStr string
+	if len(raw) == 0 || json.Unmarshal(raw, &idStr) != nil {
+		return 0, false
+	}
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id == 0 {
+		return 0, false
+	}
+
+	return id, true
+}
+
+var active atomic.Pointer[Recorder]
+
+// Active returns the running capture, or nil
+func Active() *Recorder {
+	return active.Load()
+}
+
+// GuildSnapshot is the state of a guild at the start of a capture
+type GuildSnapshot struct {
+	ShardID int
+	Guild   *discordgo.Guild
+}
+
+// SnapshotGuild fetches the guild, its channels, active threads and the bot member over the rest api
+func SnapshotGuild(s *discordgo.Session, guildID, botID int64) (*discordgo.Guild, error) {
+	g, err := s.Guild(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	g.Channels, err = s.GuildChannels(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	threads, err := s.GuildThreadsActive(guildID)
+	if err != nil {
+		return nil, err
+	}
+	g.Threads = threads.Threads
+
+	// needed for permission checks during the replay
+	botMember, err := s.GuildMember(guildID, botID)
+	if err != nil {
+		return nil, err
+	}
+	g.Members = []*discordgo.Member{botMember}
+
+	return g, nil
+}
+
+// Start starts capturing the events of the guilds on this process for the duration, only one capture can run at a time.
+// The snapshots are written before any events so that the replay starts out with the state of the guilds.
+func Start(guilds []int64, duration time.Duration, snapshots ...*GuildSnapshot) (*Recorder, error) {
+	if len(guilds) < 1 {
+		return nil, fmt.Errorf("no guilds to capture")
+	}
+
+	if Active() != nil {
+		return nil, fmt.Errorf("a capture is already running")
+	}
+
+	if duration > MaxCaptureDuration {
+		duration = MaxCaptureDuration
+	}
+
+	dir := confCaptureDir.GetString()
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("capture-%d-%s.jsonl.gz", guilds[0], time.Now().UTC().Format("20060102-150405")))
+	r, err := NewRecorder(path, guilds, duration)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range snapshots {
+		err = r.WriteGuildSnapshot(v.ShardID, v.Guild)
+		if err != nil {
+			r.Close()
+			os.Remove(path)
+			return nil, err
+		}
+	}
+
+	if !active.CompareAndSwap(nil, r) {
+		r.Close()
+		os.Remove(path)
+		return nil, fmt.Errorf("a capture is already running")
+	}
+
+	logger.Infof("Started capturing events of %v to %s", guilds, path)
+	time.AfterFunc(duration, func() {
+		stop(r)
+	})
+
+	return r, nil
+}
+
+// Stop stops the running capture, returning it if there was one
+func Stop() *Recorder {
+	r := Active()
+	if r == nil {
+		return nil
+	}
+
+	stop(r)
+	return r
+}
+
+func stop(r *Recorder) {
+	if !active.CompareAndSwap(r, nil) {
+		return
+	}
+
+	err := r.Close()
+	if err != nil {
+		logger.WithError(err).Error("failed closing event capture")
+	}
+
+	logger.Infof("Stopped capturing events to %s, %d events written", r.Path, r.Written())
+}
+
+// HandleRawDispatch is set as the discordgo.Session.RawDispatchHandler of the shard sessions
+func HandleRawDispatch(s *discordgo.Session, eventType string, data []byte) {
+	r := Active()
+	if r == nil {
+		return
+	}
+
+	err := r.Record(s.ShardID, eventType, data)
+	if err != nil {
+		logger.WithError(err).Error("failed recording event")
+	}
+}
+
+// Reader reads the events of a capture file
+type Reader struct {
+	gz      *gzip.Reader
+	scanner *bufio.Scanner
+}
+
+// NewReader reads a capture from r
+func NewReader(r io.Reader) (*Reader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(gz)
+	// guild creates of large guilds can be several megabytes
+	scanner.Buffer(make([]byte, 0, 64*1024), 256*1024*1024)
+
+	return &Reader{
+		gz:      gz,
+		scanner: scanner,
+	}, nil
+}
+
+// Next returns the next event in the capture, or io.EOF once all of them have been read
+func (r *Reader) Next() (*CapturedEvent, error) {
+	if !r.scanner.Scan() {
+		if err := r.scanner.Err(); err != nil {
+			return nil, err
+		}
+
+		return nil, io.EOF
+	}
+
+	var evt CapturedEvent
+	err := json.Unmarshal(r.scanner.Bytes(), &evt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &evt, nil
+}
+
+// ReadFile reads all the events of the capture file at path
+func ReadFile(path string) ([]*CapturedEvent, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r, err := NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*CapturedEvent
+	for {
+		evt, err := r.Next()
+		if err == io.EOF {
+			return result, nil
+		} else if err != nil {
+			return result, err
+		}
+
+		result = append(result, evt)
+	}
+}
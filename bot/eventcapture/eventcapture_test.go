@@ -0,0 +1,83 @@
+package eventcapture
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+)
+
+func TestEventGuildID(t *testing.T) {
+	cases := []struct {
+		eventType string
+		data      string
+		guildID   int64
+		ok        bool
+	}{
+		{"MESSAGE_CREATE", `{"id":"10","guild_id":"20","channel_id":"30"}`, 20, true},
+		{"GUILD_CREATE", `{"id":"20","name":"test"}`, 20, true},
+		{"GUILD_DELETE", `{"id":"20","unavailable":true}`, 20, true},
+		{"GUILD_MEMBER_ADD", `{"guild_id":"20","user":{"id":"10"}}`, 20, true},
+		{"MESSAGE_CREATE", `{"id":"10","channel_id":"30"}`, 0, false},
+		{"READY", `{"guild_id":"20"}`, 0, false},
+		{"MESSAGE_CREATE", `not json`, 0, false},
+	}
+
+	for _, c := range cases {
+		guildID, ok := EventGuildID(c.eventType, []byte(c.data))
+		if guildID != c.guildID || ok != c.ok {
+			t.Errorf("%s %s: got (%d, %t), expected (%d, %t)", c.eventType, c.data, guildID, ok, c.guildID, c.ok)
+		}
+	}
+}
+
+func TestRecordAndRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "capture.jsonl.gz")
+	r, err := NewRecorder(path, []int64{20}, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.WriteGuildSnapshot(1, &discordgo.Guild{ID: 20, Name: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r.Record(1, "MESSAGE_CREATE", []byte(`{"id":"10","guild_id":"20","channel_id":"30","content":"hello"}`))
+	r.Record(1, "MESSAGE_CREATE", []byte(`{"id":"11","guild_id":"21","channel_id":"31","content":"other guild"}`))
+
+	if r.Written() != 2 {
+		t.Fatalf("expected 2 events written, got %d", r.Written())
+	}
+
+	err = r.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+
+	decoded, err := events[0].Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gc, ok := decoded.(*discordgo.GuildCreate); !ok || gc.ID != 20 || gc.Name != "test" {
+		t.Errorf("unexpected snapshot: %#v", decoded)
+	}
+
+	decoded, err = events[1].Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mc, ok := decoded.(*discordgo.MessageCreate); !ok || mc.Content != "hello" || mc.GuildID != 20 || events[1].ShardID != 1 {
+		t.Errorf("unexpected message: %#v", decoded)
+	}
+}
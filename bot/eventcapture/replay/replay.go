@@ -0,0 +1,254 @@
+// Package replay feeds captured gateway events through the state tracker and the eventsystem with a fake rest api that
+// records the requests made instead of sending them to discord, so that bugs can be reproduced and regression tests for
+// things like automod, custom commands and logging can be written without a connection to discord.
+//
+// Postgres and redis are still used as normal, so point the config at a test database. The replayevents command
+// refuses to run unless -allow-live-db is passed to confirm that.
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/botlabs-gg/yagpdb/v2/bot"
+	"github.com/botlabs-gg/yagpdb/v2/bot/eventcapture"
+	"github.com/botlabs-gg/yagpdb/v2/bot/eventsystem"
+	"github.com/botlabs-gg/yagpdb/v2/commands"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/configstore"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+)
+
+var logger = common.GetFixedPrefixLogger("replay")
+
+// Call is a request made to the rest api during a replay
+type Call struct {
+	// Index of the event that was being handled when the call was made, -1 if it was made before the first event
+	Event int
+
+	Method string
+	Path   string
+	Body   string
+}
+
+func (c *Call) String() string {
+	if c.Body == "" {
+		return fmt.Sprintf("#%d %s %s", c.Event, c.Method, c.Path)
+	}
+
+	return fmt.Sprintf("#%d %s %s %s", c.Event, c.Method, c.Path, c.Body)
+}
+
+// Responder returns the response to a request, if handled is false the default response is used
+type Responder func(method, path string, body []byte) (status int, resp []byte, handled bool)
+
+// RESTRecorder is a http.RoundTripper that records the requests made to it and responds with fake responses
+type RESTRecorder struct {
+	// Responder can be set to respond to specific requests
+	Responder Responder
+
+	mu           sync.Mutex
+	calls        []*Call
+	currentEvent int
+	lastID       int64
+}
+
+var _ http.RoundTripper = (*RESTRecorder)(nil)
+
+// NewRESTRecorder returns a new recorder
+func NewRESTRecorder() *RESTRecorder {
+	return &RESTRecorder{
+		currentEvent: -1,
+		// snowflakes with a timestamp of 2015, so they're valid but don't collide with anything real
+		lastID: 1 << 22,
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *RESTRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	path := strings.TrimPrefix(req.URL.Path, "/api/v"+discordgo.APIVersion)
+
+	r.mu.Lock()
+	r.calls = append(r.calls, &Call{
+		Event:  r.currentEvent,
+		Method: req.Method,
+		Path:   path,
+		Body:   string(body),
+	})
+	responder := r.Responder
+	r.mu.Unlock()
+
+	var status int
+	var resp []byte
+	handled := false
+	if responder != nil {
+		status, resp, handled = responder(req.Method, path, body)
+	}
+
+	if !handled {
+		status, resp = DefaultResponse(req.Method, path, body, r.nextID)
+	}
+
+	return &http.Response{
+		Status:        strconv.Itoa(status) + " " + http.StatusText(status),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(resp)),
+		ContentLength: int64(len(resp)),
+		Request:       req,
+	}, nil
+}
+
+func (r *RESTRecorder) nextID() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID += 1 << 22
+	return r.lastID
+}
+
+func (r *RESTRecorder) setEvent(i int) {
+	r.mu.Lock()
+	r.currentEvent = i
+	r.mu.Unlock()
+}
+
+// Calls returns the calls made so far
+func (r *RESTRecorder) Calls() []*Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]*Call(nil), r.calls...)
+}
+
+// DefaultResponse returns a response good enough for most requests:
+// deletes and puts return no content, gets return an empty object or list depending on the path,
+// and posts and patches echo the json body back with an id added, so that sent messages get an id.
+func DefaultResponse(method, path string, body []byte, nextID func() int64) (status int, resp []byte) {
+	switch method {
+	case http.MethodDelete, http.MethodPut:
+		return http.StatusNoContent, nil
+	case http.MethodGet:
+		if isListPath(path) {
+			return http.StatusOK, []byte("[]")
+		}
+
+		return http.StatusOK, []byte("{}")
+	}
+
+	var obj map[string]interface{}
+	if json.Unmarshal(body, &obj) != nil || obj == nil {
+		// multipart or non object bodies
+		obj = make(map[string]interface{})
+	}
+
+	if _, ok := obj["id"]; !ok {
+		obj["id"] = strconv.FormatInt(nextID(), 10)
+	}
+
+	resp, _ = json.Marshal(obj)
+	return http.StatusOK, resp
+}
+
+// isListPath returns true if the path is of a collection, e.g /channels/1/messages as opposed to /channels/1/messages/2
+func isListPath(path string) bool {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return false
+	}
+
+	last := path[strings.LastIndex(path, "/")+1:]
+	if last == "@me" {
+		return false
+	}
+
+	if _, err := strconv.ParseInt(last, 10, 64); err == nil {
+		return false
+	}
+
+	// top level resources such as /guilds/1 are handled above, these are things like /channels/1/messages
+	return strings.Count(path, "/") > 0
+}
+
+// Init initializes the core without talking to discord, plugins should be registered after this and before SetupBot
+func Init(botUser *discordgo.User) (*RESTRecorder, error) {
+	rest := NewRESTRecorder()
+	err := common.InitOffline(botUser, rest)
+	if err != nil {
+		return nil, err
+	}
+
+	configstore.InitDatabases()
+	return rest, nil
+}
+
+// SetupBot sets up the bot and the registered plugins for replaying events
+func SetupBot(rest *RESTRecorder, shardCount int) error {
+	if shardCount < 1 {
+		shardCount = 1
+	}
+
+	commands.InitCommands()
+	return bot.SetupReplay(shardCount, &http.Client{Transport: rest})
+}
+
+// Result is the outcome of a replay
+type Result struct {
+	Handled int
+	Skipped int
+
+	// Number of events handled by type
+	Counts map[string]int
+
+	Calls []*Call
+}
+
+// Replay handles the events in order, each event is fully handled before moving on to the next one
+func Replay(events []*eventcapture.CapturedEvent, rest *RESTRecorder) *Result {
+	result := &Result{
+		Counts: make(map[string]int),
+	}
+
+	numShards := bot.ShardManager.GetNumShards()
+	for i, v := range events {
+		decoded, err := v.Decode()
+		if err != nil {
+			logger.WithError(err).Warnf("Skipping event #%d (%s)", i, v.Type)
+			result.Skipped++
+			continue
+		}
+
+		shardID := v.ShardID
+		if shardID >= numShards || shardID < 0 {
+			shardID = 0
+		}
+
+		rest.setEvent(i)
+		eventsystem.HandleEventSync(bot.ShardManager.Session(shardID), decoded)
+
+		result.Handled++
+		result.Counts[v.Type]++
+	}
+
+	result.Calls = rest.Calls()
+	return result
+}
@@ -0,0 +1,155 @@
+package replay
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/botlabs-gg/yagpdb/v2/bot/eventcapture"
+	"github.com/botlabs-gg/yagpdb/v2/bot/eventsystem"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+)
+
+func TestRESTRecorder(t *testing.T) {
+	rest := NewRESTRecorder()
+
+	session, err := discordgo.New("Bot test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	session.Client = &http.Client{Transport: rest}
+	session.MaxRestRetries = 1
+
+	msg, err := session.ChannelMessageSend(30, "hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if msg.ID == 0 || msg.Content != "hello" {
+		t.Errorf("unexpected message: %#v", msg)
+	}
+
+	rest.setEvent(0)
+	msgs, err := session.ChannelMessages(30, 10, 0, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(msgs) != 0 {
+		t.Errorf("expected no messages, got %d", len(msgs))
+	}
+
+	calls := rest.Calls()
+	if len(calls) != 2 {
+		t.Fatalf("expected 2 calls, got %d", len(calls))
+	}
+
+	if calls[0].Event != -1 || calls[0].Method != http.MethodPost || calls[0].Path != "/channels/30/messages" || !strings.Contains(calls[0].Body, "hello") {
+		t.Errorf("unexpected call: %s", calls[0])
+	}
+
+	if calls[1].Event != 0 || calls[1].Method != http.MethodGet || calls[1].Path != "/channels/30/messages" {
+		t.Errorf("unexpected call: %s", calls[1])
+	}
+}
+
+func TestIsListPath(t *testing.T) {
+	cases := map[string]bool{
+		"/channels/30/messages":    true,
+		"/channels/30/messages/40": false,
+		"/guilds/20":               false,
+		"/users/@me":               false,
+		"/guilds/20/roles":         true,
+	}
+
+	for path, expected := range cases {
+		if isListPath(path) != expected {
+			t.Errorf("%s: expected %t", path, expected)
+		}
+	}
+}
+
+type pingPlugin struct{}
+
+func (p *pingPlugin) PluginInfo() *common.PluginInfo {
+	return &common.PluginInfo{
+		Name:     "Replay Ping",
+		SysName:  "replay_ping",
+		Category: common.PluginCategoryMisc,
+	}
+}
+
+func (p *pingPlugin) BotInit() {
+	eventsystem.AddHandlerAsyncLastLegacy(p, p.handleMessageCreate, eventsystem.EventMessageCreate)
+}
+
+func (p *pingPlugin) handleMessageCreate(evt *eventsystem.EventData) {
+	m := evt.MessageCreate()
+	if m.Content == "ping" {
+		evt.Session.ChannelMessageSend(m.ChannelID, "pong")
+	}
+}
+
+func capturedEvent(t *testing.T, eventType string, data interface{}) *eventcapture.CapturedEvent {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &eventcapture.CapturedEvent{Type: eventType, Data: encoded}
+}
+
+// TestReplay replays a guild and a message through the bot, this needs a test postgres database (YAGPDB_TEST_DB) and redis
+func TestReplay(t *testing.T) {
+	if os.Getenv("YAGPDB_TEST_DB") == "" {
+		t.Skip("YAGPDB_TEST_DB not set")
+	}
+
+	if err := common.InitTestRedis(); err != nil {
+		t.Skip("no redis: ", err)
+	}
+	common.InitTest()
+
+	rest, err := Init(&discordgo.User{ID: 10, Username: "YAGPDB", Bot: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	common.RegisterPlugin(&pingPlugin{})
+
+	err = SetupBot(rest, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events := []*eventcapture.CapturedEvent{
+		capturedEvent(t, "GUILD_CREATE", &discordgo.Guild{
+			ID:       20,
+			Name:     "test",
+			Channels: []*discordgo.Channel{{ID: 30, GuildID: 20, Name: "general", Type: discordgo.ChannelTypeGuildText}},
+		}),
+		capturedEvent(t, "MESSAGE_CREATE", &discordgo.Message{
+			ID:        40,
+			GuildID:   20,
+			ChannelID: 30,
+			Content:   "ping",
+			Author:    &discordgo.User{ID: 50, Username: "someone"},
+		}),
+	}
+
+	result := Replay(events, rest)
+	if result.Handled != 2 || result.Skipped != 0 {
+		t.Fatalf("expected 2 events handled, got %d handled and %d skipped", result.Handled, result.Skipped)
+	}
+
+	for _, v := range result.Calls {
+		if v.Event == 1 && v.Method == http.MethodPost && v.Path == "/channels/30/messages" && strings.Contains(v.Body, "pong") {
+			return
+		}
+	}
+
+	t.Errorf("the reply to the replayed message wasn't sent, calls made: %v", result.Calls)
+}
@@ -51,45 +51,50 @@ const (
 	EventGuildRoleCreate                     Event = 36
 	EventGuildRoleDelete                     Event = 37
 	EventGuildRoleUpdate                     Event = 38
-	EventGuildStickersUpdate                 Event = 39
-	EventGuildUpdate                         Event = 40
-	EventInteractionCreate                   Event = 41
-	EventInviteCreate                        Event = 42
-	EventInviteDelete                        Event = 43
-	EventMessageAck                          Event = 44
-	EventMessageCreate                       Event = 45
-	EventMessageDelete                       Event = 46
-	EventMessageDeleteBulk                   Event = 47
-	EventMessageReactionAdd                  Event = 48
-	EventMessageReactionRemove               Event = 49
-	EventMessageReactionRemoveAll            Event = 50
-	EventMessageReactionRemoveEmoji          Event = 51
-	EventMessageUpdate                       Event = 52
-	EventPresenceUpdate                      Event = 53
-	EventPresencesReplace                    Event = 54
-	EventRateLimit                           Event = 55
-	EventReady                               Event = 56
-	EventRelationshipAdd                     Event = 57
-	EventRelationshipRemove                  Event = 58
-	EventResumed                             Event = 59
-	EventStageInstanceCreate                 Event = 60
-	EventStageInstanceDelete                 Event = 61
-	EventStageInstanceUpdate                 Event = 62
-	EventThreadCreate                        Event = 63
-	EventThreadDelete                        Event = 64
-	EventThreadListSync                      Event = 65
-	EventThreadMemberUpdate                  Event = 66
-	EventThreadMembersUpdate                 Event = 67
-	EventThreadUpdate                        Event = 68
-	EventTypingStart                         Event = 69
-	EventUserGuildSettingsUpdate             Event = 70
-	EventUserNoteUpdate                      Event = 71
-	EventUserSettingsUpdate                  Event = 72
-	EventUserUpdate                          Event = 73
-	EventVoiceChannelStatusUpdate            Event = 74
-	EventVoiceServerUpdate                   Event = 75
-	EventVoiceStateUpdate                    Event = 76
-	EventWebhooksUpdate                      Event = 77
+	EventGuildScheduledEventCreate           Event = 39
+	EventGuildScheduledEventDelete           Event = 40
+	EventGuildScheduledEventUpdate           Event = 41
+	EventGuildScheduledEventUserAdd          Event = 42
+	EventGuildScheduledEventUserRemove       Event = 43
+	EventGuildStickersUpdate                 Event = 44
+	EventGuildUpdate                         Event = 45
+	EventInteractionCreate                   Event = 46
+	EventInviteCreate                        Event = 47
+	EventInviteDelete                        Event = 48
+	EventMessageAck                          Event = 49
+	EventMessageCreate                       Event = 50
+	EventMessageDelete                       Event = 51
+	EventMessageDeleteBulk                   Event = 52
+	EventMessageReactionAdd                  Event = 53
+	EventMessageReactionRemove               Event = 54
+	EventMessageReactionRemoveAll            Event = 55
+	EventMessageReactionRemoveEmoji          Event = 56
+	EventMessageUpdate                       Event = 57
+	EventPresenceUpdate                      Event = 58
+	EventPresencesReplace                    Event = 59
+	EventRateLimit                           Event = 60
+	EventReady                               Event = 61
+	EventRelationshipAdd                     Event = 62
+	EventRelationshipRemove                  Event = 63
+	EventResumed                             Event = 64
+	EventStageInstanceCreate                 Event = 65
+	EventStageInstanceDelete                 Event = 66
+	EventStageInstanceUpdate                 Event = 67
+	EventThreadCreate                        Event = 68
+	EventThreadDelete                        Event = 69
+	EventThreadListSync                      Event = 70
+	EventThreadMemberUpdate                  Event = 71
+	EventThreadMembersUpdate                 Event = 72
+	EventThreadUpdate                        Event = 73
+	EventTypingStart                         Event = 74
+	EventUserGuildSettingsUpdate             Event = 75
+	EventUserNoteUpdate                      Event = 76
+	EventUserSettingsUpdate                  Event = 77
+	EventUserUpdate                          Event = 78
+	EventVoiceChannelStatusUpdate            Event = 79
+	EventVoiceServerUpdate                   Event = 80
+	EventVoiceStateUpdate                    Event = 81
+	EventWebhooksUpdate                      Event = 82
 )
 
 var EventNames = []string{
@@ -132,6 +137,11 @@ var EventNames = []string{
 	"GuildRoleCreate",
 	"GuildRoleDelete",
 	"GuildRoleUpdate",
+	"GuildScheduledEventCreate",
+	"GuildScheduledEventDelete",
+	"GuildScheduledEventUpdate",
+	"GuildScheduledEventUserAdd",
+	"GuildScheduledEventUserRemove",
 	"GuildStickersUpdate",
 	"GuildUpdate",
 	"InteractionCreate",
@@ -209,6 +219,11 @@ var AllDiscordEvents = []Event{
 	EventGuildRoleCreate,
 	EventGuildRoleDelete,
 	EventGuildRoleUpdate,
+	EventGuildScheduledEventCreate,
+	EventGuildScheduledEventDelete,
+	EventGuildScheduledEventUpdate,
+	EventGuildScheduledEventUserAdd,
+	EventGuildScheduledEventUserRemove,
 	EventGuildStickersUpdate,
 	EventGuildUpdate,
 	EventInteractionCreate,
@@ -290,6 +305,11 @@ var AllEvents = []Event{
 	EventGuildRoleCreate,
 	EventGuildRoleDelete,
 	EventGuildRoleUpdate,
+	EventGuildScheduledEventCreate,
+	EventGuildScheduledEventDelete,
+	EventGuildScheduledEventUpdate,
+	EventGuildScheduledEventUserAdd,
+	EventGuildScheduledEventUserRemove,
 	EventGuildStickersUpdate,
 	EventGuildUpdate,
 	EventInteractionCreate,
@@ -331,7 +351,7 @@ var AllEvents = []Event{
 	EventWebhooksUpdate,
 }
 
-var handlers = make([][][]*Handler, 78)
+var handlers = make([][][]*Handler, 83)
 
 func (data *EventData) ApplicationCommandCreate() *discordgo.ApplicationCommandCreate {
 	return data.EvtInterface.(*discordgo.ApplicationCommandCreate)
@@ -426,6 +446,21 @@ func (data *EventData) GuildRoleDelete() *discordgo.GuildRoleDelete {
 func (data *EventData) GuildRoleUpdate() *discordgo.GuildRoleUpdate {
 	return data.EvtInterface.(*discordgo.GuildRoleUpdate)
 }
+func (data *EventData) GuildScheduledEventCreate() *discordgo.GuildScheduledEventCreate {
+	return data.EvtInterface.(*discordgo.GuildScheduledEventCreate)
+}
+func (data *EventData) GuildScheduledEventDelete() *discordgo.GuildScheduledEventDelete {
+	return data.EvtInterface.(*discordgo.GuildScheduledEventDelete)
+}
+func (data *EventData) GuildScheduledEventUpdate() *discordgo.GuildScheduledEventUpdate {
+	return data.EvtInterface.(*discordgo.GuildScheduledEventUpdate)
+}
+func (data *EventData) GuildScheduledEventUserAdd() *discordgo.GuildScheduledEventUserAdd {
+	return data.EvtInterface.(*discordgo.GuildScheduledEventUserAdd)
+}
+func (data *EventData) GuildScheduledEventUserRemove() *discordgo.GuildScheduledEventUserRemove {
+	return data.EvtInterface.(*discordgo.GuildScheduledEventUserRemove)
+}
 func (data *EventData) GuildStickersUpdate() *discordgo.GuildStickersUpdate {
 	return data.EvtInterface.(*discordgo.GuildStickersUpdate)
 }
@@ -609,84 +644,94 @@ func fillEvent(evtData *EventData) {
 		evtData.Type = Event(37)
 	case *discordgo.GuildRoleUpdate:
 		evtData.Type = Event(38)
-	case *discordgo.GuildStickersUpdate:
+	case *discordgo.GuildScheduledEventCreate:
 		evtData.Type = Event(39)
-	case *discordgo.GuildUpdate:
+	case *discordgo.GuildScheduledEventDelete:
 		evtData.Type = Event(40)
-	case *discordgo.InteractionCreate:
+	case *discordgo.GuildScheduledEventUpdate:
 		evtData.Type = Event(41)
-	case *discordgo.InviteCreate:
+	case *discordgo.GuildScheduledEventUserAdd:
 		evtData.Type = Event(42)
-	case *discordgo.InviteDelete:
+	case *discordgo.GuildScheduledEventUserRemove:
 		evtData.Type = Event(43)
-	case *discordgo.MessageAck:
+	case *discordgo.GuildStickersUpdate:
 		evtData.Type = Event(44)
-	case *discordgo.MessageCreate:
+	case *discordgo.GuildUpdate:
 		evtData.Type = Event(45)
-	case *discordgo.MessageDelete:
+	case *discordgo.InteractionCreate:
 		evtData.Type = Event(46)
-	case *discordgo.MessageDeleteBulk:
+	case *discordgo.InviteCreate:
 		evtData.Type = Event(47)
-	case *discordgo.MessageReactionAdd:
+	case *discordgo.InviteDelete:
 		evtData.Type = Event(48)
-	case *discordgo.MessageReactionRemove:
+	case *discordgo.MessageAck:
 		evtData.Type = Event(49)
-	case *discordgo.MessageReactionRemoveAll:
+	case *discordgo.MessageCreate:
 		evtData.Type = Event(50)
-	case *discordgo.MessageReactionRemoveEmoji:
+	case *discordgo.MessageDelete:
 		evtData.Type = Event(51)
-	case *discordgo.MessageUpdate:
+	case *discordgo.MessageDeleteBulk:
 		evtData.Type = Event(52)
-	case *discordgo.PresenceUpdate:
+	case *discordgo.MessageReactionAdd:
 		evtData.Type = Event(53)
-	case *discordgo.PresencesReplace:
+	case *discordgo.MessageReactionRemove:
 		evtData.Type = Event(54)
-	case *discordgo.RateLimit:
+	case *discordgo.MessageReactionRemoveAll:
 		evtData.Type = Event(55)
-	case *discordgo.Ready:
+	case *discordgo.MessageReactionRemoveEmoji:
 		evtData.Type = Event(56)
-	case *discordgo.RelationshipAdd:
+	case *discordgo.MessageUpdate:
 		evtData.Type = Event(57)
-	case *discordgo.RelationshipRemove:
+	case *discordgo.PresenceUpdate:
 		evtData.Type = Event(58)
-	case *discordgo.Resumed:
+	case *discordgo.PresencesReplace:
 		evtData.Type = Event(59)
-	case *discordgo.StageInstanceCreate:
+	case *discordgo.RateLimit:
 		evtData.Type = Event(60)
-	case *discordgo.StageInstanceDelete:
+	case *discordgo.Ready:
 		evtData.Type = Event(61)
-	case *discordgo.StageInstanceUpdate:
+	case *discordgo.RelationshipAdd:
 		evtData.Type = Event(62)
-	case *discordgo.ThreadCreate:
+	case *discordgo.RelationshipRemove:
 		evtData.Type = Event(63)
-	case *discordgo.ThreadDelete:
+	case *discordgo.Resumed:
 		evtData.Type = Event(64)
-	case *discordgo.ThreadListSync:
+	case *discordgo.StageInstanceCreate:
 		evtData.Type = Event(65)
-	case *discordgo.ThreadMemberUpdate:
+	case *discordgo.StageInstanceDelete:
 		evtData.Type = Event(66)
-	case *discordgo.ThreadMembersUpdate:
+	case *discordgo.StageInstanceUpdate:
 		evtData.Type = Event(67)
-	case *discordgo.ThreadUpdate:
+	case *discordgo.ThreadCreate:
 		evtData.Type = Event(68)
-	case *discordgo.TypingStart:
+	case *discordgo.ThreadDelete:
 		evtData.Type = Event(69)
-	case *discordgo.UserGuildSettingsUpdate:
+	case *discordgo.ThreadListSync:
 		evtData.Type = Event(70)
-	case *discordgo.UserNoteUpdate:
+	case *discordgo.ThreadMemberUpdate:
 		evtData.Type = Event(71)
-	case *discordgo.UserSettingsUpdate:
+	case *discordgo.ThreadMembersUpdate:
 		evtData.Type = Event(72)
-	case *discordgo.UserUpdate:
+	case *discordgo.ThreadUpdate:
 		evtData.Type = Event(73)
-	case *discordgo.VoiceChannelStatusUpdate:
+	case *discordgo.TypingStart:
 		evtData.Type = Event(74)
-	case *discordgo.VoiceServerUpdate:
+	case *discordgo.UserGuildSettingsUpdate:
 		evtData.Type = Event(75)
-	case *discordgo.VoiceStateUpdate:
+	case *discordgo.UserNoteUpdate:
 		evtData.Type = Event(76)
-	case *discordgo.WebhooksUpdate:
+	case *discordgo.UserSettingsUpdate:
 		evtData.Type = Event(77)
+	case *discordgo.UserUpdate:
+		evtData.Type = Event(78)
+	case *discordgo.VoiceChannelStatusUpdate:
+		evtData.Type = Event(79)
+	case *discordgo.VoiceServerUpdate:
+		evtData.Type = Event(80)
+	case *discordgo.VoiceStateUpdate:
+		evtData.Type = Event(81)
+	case *discordgo.WebhooksUpdate:
+		evtData.Type = Event(82)
 	default:
 		return
 	}
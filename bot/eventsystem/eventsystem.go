@@ -117,29 +117,35 @@ func EmitEvent(data *EventData, evt Event) {
 	}
 
 	if len(h[2]) > 0 {
-		go func() {
-			defer func() {
-				if errI := recover(); errI != nil {
-					stack := string(debug.Stack())
-
-					var err error
-					switch t := errI.(type) {
-					case error:
-						err = t
-					case string:
-						err = errors.New(t)
-					default:
-						err = fmt.Errorf("unknown error: %v", t)
-					}
-					logrus.WithError(err).WithField("evt", data.Type.String()).Error("Recovered from panic in event handler\n" + stack)
-				}
-			}()
-
-			runEvents(h[2], data)
-		}()
+		if synchronous {
+			runAsyncEvents(h[2], data)
+		} else {
+			go runAsyncEvents(h[2], data)
+		}
 	}
 }
 
+func runAsyncEvents(h []*Handler, data *EventData) {
+	defer func() {
+		if errI := recover(); errI != nil {
+			stack := string(debug.Stack())
+
+			var err error
+			switch t := errI.(type) {
+			case error:
+				err = t
+			case string:
+				err = errors.New(t)
+			default:
+				err = fmt.Errorf("unknown error: %v", t)
+			}
+			logrus.WithError(err).WithField("evt", data.Type.String()).Error("Recovered from panic in event handler\n" + stack)
+		}
+	}()
+
+	runEvents(h, data)
+}
+
 func runEvents(h []*Handler, data *EventData) {
 
 	retryCount := 0
@@ -309,6 +315,29 @@ func HandleEvent(s *discordgo.Session, evt interface{}) {
 	}
 }
 
+// synchronous makes the async handlers run in the goroutine the event is handled in
+var synchronous bool
+
+// SetSynchronous makes all the handlers run in the goroutine the event is handled in, used to replay captured events
+// deterministically
+func SetSynchronous(enabled bool) {
+	synchronous = enabled
+}
+
+// HandleEventSync handles the event in the calling goroutine instead of queueing it on the shard worker
+func HandleEventSync(s *discordgo.Session, evt interface{}) {
+	var evtData = &EventData{
+		Session:      s,
+		EvtInterface: evt,
+		cancelled:    new(int32),
+	}
+
+	evtData.ctx = context.WithValue(context.Background(), common.ContextKeyDiscordSession, s)
+
+	fillEvent(evtData)
+	handleEvent(evtData)
+}
+
 func QueueEventNonDiscord(evtData *EventData) {
 	if evtData.Session != nil {
 		ctx := context.WithValue(evtData.Context(), common.ContextKeyDiscordSession, evtData.Session)
@@ -212,3 +212,43 @@ func (n *NodeImpl) SendGuilds(shard int) int {
 	logger.Printf("Took %s to transfer %d objects", time.Since(started), atomic.LoadInt32(sentEvents))
 	return int(atomic.LoadInt32(sentEvents)) + pluginSentEvents
 }
+
+var _ node.StatusProvider = (*NodeImpl)(nil)
+
+// NodeStatus implements node.StatusProvider, reporting the load of this node to the orchestrator
+func (n *NodeImpl) NodeStatus() *dshardorchestrator.NodeStatusData {
+	if State == nil || totalShardCount == 0 {
+		return nil
+	}
+
+	status := &dshardorchestrator.NodeStatusData{
+		ShardGuilds:          make(map[int]int),
+		ShardEventsPerSecond: make(map[int]float64),
+	}
+
+	_, perPeriod := EventLogger.GetStats()
+	for _, shard := range ReadyTracker.GetProcessShards() {
+		guilds := len(State.GetShardGuilds(int64(shard)))
+		status.ShardGuilds[shard] = guilds
+		status.Guilds += guilds
+
+		if shard >= len(perPeriod) {
+			continue
+		}
+
+		var events int64
+		for _, v := range perPeriod[shard] {
+			events += v
+		}
+
+		perSecond := float64(events) / EventLoggerPeriodDuration.Seconds()
+		status.ShardEventsPerSecond[shard] = perSecond
+		status.EventsPerSecond += perSecond
+	}
+
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+	status.MemoryUsage = memStats.HeapInuse
+
+	return status
+}
@@ -0,0 +1,54 @@
+package bot
+
+import (
+	"net/http"
+
+	"github.com/botlabs-gg/yagpdb/v2/bot/eventsystem"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+)
+
+// SetupReplay sets up the bot and its plugins to handle replayed events instead of connecting to the gateway.
+// The events are handled through eventsystem.HandleEventSync using the session of their shard, and the requests
+// the sessions make go to client.
+func SetupReplay(shardCount int, client *http.Client) error {
+	setup()
+
+	// these would be posted to using a session that does talk to discord
+	ShardManager.LogChannel = 0
+	ShardManager.StatusMessageChannel = 0
+
+	sessionFunc := ShardManager.SessionFunc
+	ShardManager.SessionFunc = func(token string) (*discordgo.Session, error) {
+		session, err := sessionFunc(token)
+		if err != nil {
+			return nil, err
+		}
+
+		session.Client = client
+		session.MaxRestRetries = 1
+		session.RawDispatchHandler = nil
+		return session, nil
+	}
+
+	totalShardCount = shardCount
+	ShardManager.SetNumShards(shardCount)
+	setupState()
+
+	EventLogger.init(shardCount)
+	ReadyTracker.initTotalShardCount(shardCount)
+
+	err := ShardManager.Init()
+	if err != nil {
+		return err
+	}
+
+	eventsystem.SetSynchronous(true)
+	Enabled = true
+	Running = true
+
+	initPlugins()
+
+	logger.Infof("Set up the bot for replaying events on %d shard(s) as %s", shardCount, common.BotUser.String())
+	return nil
+}
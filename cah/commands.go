@@ -1,12 +1,16 @@
 package cah
 
 import (
+	"context"
+	"fmt"
 	"strings"
 
 	"github.com/botlabs-gg/yagpdb/v2/bot"
+	"github.com/botlabs-gg/yagpdb/v2/bot/paginatedmessages"
 	"github.com/botlabs-gg/yagpdb/v2/commands"
 	"github.com/botlabs-gg/yagpdb/v2/lib/cardsagainstdiscord"
 	"github.com/botlabs-gg/yagpdb/v2/lib/dcmd"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
 	"github.com/botlabs-gg/yagpdb/v2/lib/dstate"
 	"github.com/sirupsen/logrus"
 )
@@ -19,7 +23,7 @@ func (p *Plugin) AddCommands() {
 		Aliases:     []string{"c"},
 		Description: "Creates a Cards Against Humanity game in this channel, add packs after commands, or * for all packs. (-v for vote mode without a card czar).",
 		Arguments: []*dcmd.ArgDef{
-			{Name: "packs", Type: dcmd.String, Default: "main", Help: "Packs separated by space, or * for all of them."},
+			{Name: "packs", Type: dcmd.String, Default: "main", Help: "Packs separated by space, or * for all of them. Packs made for this server in the control panel can be used too."},
 		},
 		ArgSwitches: []*dcmd.ArgDef{
 			{Name: "v", Help: "Vote mode - players vote instead of having a card czar."},
@@ -29,7 +33,12 @@ func (p *Plugin) AddCommands() {
 			pStr := data.Args[0].Str()
 			packs := strings.Fields(pStr)
 
-			_, err := p.Manager.CreateGame(data.GuildData.GS.ID, data.GuildData.CS.ID, data.Author.ID, data.Author.Username, voteMode, packs...)
+			customPacks, err := GuildCardPacks(data.Context(), data.GuildData.GS.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			_, err = p.Manager.CreateGameWithCustomPacks(data.GuildData.GS.ID, data.GuildData.CS.ID, data.Author.ID, data.Author.Username, voteMode, customPacks, packs...)
 			if err == nil {
 				logrus.Info("[cah] Created a new game in ", data.GuildData.CS.ID, ":", data.GuildData.GS.ID)
 				return nil, nil
@@ -94,17 +103,75 @@ func (p *Plugin) AddCommands() {
 		Name:         "Packs",
 		CmdCategory:  commands.CategoryFun,
 		RequiredArgs: 0,
-		Description:  "Lists all available packs.",
+		Description:  "Lists all available packs, including the ones made for this server.",
 		RunFunc: func(data *dcmd.Data) (interface{}, error) {
 			resp := "Available packs: \n\n"
 			for _, v := range cardsagainstdiscord.Packs {
 				resp += "`" + v.Name + "` - " + v.Description + "\n"
 			}
 
+			customPacks, err := GetCustomPacks(data.Context(), data.GuildData.GS.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(customPacks) > 0 {
+				resp += "\nServer packs: \n\n"
+				for _, v := range customPacks {
+					resp += "`" + v.Name + "` - " + v.Description + "\n"
+				}
+			}
+
 			return resp, nil
 		},
 	}
 
+	cmdStats := &commands.YAGCommand{
+		Name:        "Stats",
+		CmdCategory: commands.CategoryFun,
+		Description: "Shows how many Cards Against Humanity games and rounds you or someone else won on this server.",
+		Arguments: []*dcmd.ArgDef{
+			{Name: "user", Type: dcmd.UserID},
+		},
+		RunFunc: func(data *dcmd.Data) (interface{}, error) {
+			userID := data.Author.ID
+			if data.Args[0].Value != nil {
+				userID = data.Args[0].Int64()
+			}
+
+			stats, err := GetPlayerStats(data.Context(), data.GuildData.GS.ID, userID)
+			if err != nil {
+				return nil, err
+			}
+
+			return fmt.Sprintf("<@%d> has won **%d** games and **%d** rounds.", userID, stats.GamesWon, stats.RoundsWon), nil
+		},
+	}
+
+	cmdLeaderboard := &commands.YAGCommand{
+		Name:        "Leaderboard",
+		CmdCategory: commands.CategoryFun,
+		Aliases:     []string{"top", "lb"},
+		Description: "Shows the players on this server with the most won Cards Against Humanity games.",
+		Arguments: []*dcmd.ArgDef{
+			{Name: "page", Type: &dcmd.IntArg{Min: 1, Max: 10000}, Default: 1},
+		},
+		RunFunc: func(data *dcmd.Data) (interface{}, error) {
+			guildID := data.GuildData.GS.ID
+			page := data.Args[0].Int()
+
+			if data.Context().Value(paginatedmessages.CtxKeyNoPagination) != nil {
+				return leaderboardPager(guildID, nil, page)
+			}
+
+			_, err := paginatedmessages.CreatePaginatedMessage(guildID, data.ChannelID, page, 0, func(p *paginatedmessages.PaginatedMessage, page int) (*discordgo.MessageEmbed, error) {
+				return leaderboardPager(guildID, p, page)
+			})
+
+			return nil, err
+		},
+	}
+
 	container, _ := commands.CommandSystem.Root.Sub("cah")
 	container.NotFound = commands.CommonContainerNotFoundHandler(container, "")
 	container.Description = "Play cards against humanity!"
@@ -113,7 +180,37 @@ func (p *Plugin) AddCommands() {
 	container.AddCommand(cmdEnd, cmdEnd.GetTrigger())
 	container.AddCommand(cmdKick, cmdKick.GetTrigger())
 	container.AddCommand(cmdPacks, cmdPacks.GetTrigger())
+	container.AddCommand(cmdStats, cmdStats.GetTrigger())
+	container.AddCommand(cmdLeaderboard, cmdLeaderboard.GetTrigger())
 	commands.RegisterSlashCommandsContainer(container, true, func(gs *dstate.GuildSet) ([]int64, error) {
 		return nil, nil
 	})
 }
+
+const leaderboardPageSize = 10
+
+func leaderboardPager(guildID int64, p *paginatedmessages.PaginatedMessage, page int) (*discordgo.MessageEmbed, error) {
+	offset := (page - 1) * leaderboardPageSize
+	entries, err := TopPlayers(context.Background(), guildID, offset, leaderboardPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entries) < 1 && p != nil && p.LastResponse != nil { //Dont send No Results error on first execution
+		return nil, paginatedmessages.ErrNoResults
+	}
+
+	var out strings.Builder
+	if len(entries) < 1 {
+		out.WriteString("Nobody has won a game yet.")
+	}
+
+	for i, v := range entries {
+		out.WriteString(fmt.Sprintf("#%d: <@%d> - **%d** games, **%d** rounds\n", offset+i+1, v.UserID, v.GamesWon, v.RoundsWon))
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       "Cards Against Humanity leaderboard",
+		Description: out.String(),
+	}, nil
+}
@@ -0,0 +1,263 @@
+package cah
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/botlabs-gg/yagpdb/v2/cah/models"
+	"github.com/botlabs-gg/yagpdb/v2/lib/cardsagainstdiscord"
+	"github.com/botlabs-gg/yagpdb/v2/premium"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+const (
+	MaxCustomPacks        = 10
+	MaxCustomPacksPremium = 50
+
+	MaxPackDescriptionLength = 200
+	MaxPackPrompts           = 500
+	MaxPackResponses         = 1500
+	MaxCardLength            = 200
+)
+
+func MaxCustomPacksForContext(ctx context.Context) int {
+	if premium.ContextPremium(ctx) {
+		return MaxCustomPacksPremium
+	}
+
+	return MaxCustomPacks
+}
+
+var packNameRegex = regexp.MustCompile(`^[a-z0-9_-]{1,32}$`)
+
+// PackError is a problem with a pack made by a user, the message is shown to them
+type PackError string
+
+func (p PackError) Error() string {
+	return string(p)
+}
+
+// ValidatePack checks the pack can be used in games, and returns a PackError if not
+func ValidatePack(p *models.CahPack) error {
+	if !packNameRegex.MatchString(p.Name) {
+		return PackError("The pack name has to be 1-32 lowercase letters, numbers, - or _")
+	}
+
+	if _, ok := cardsagainstdiscord.Packs[p.Name]; ok || p.Name == "*" {
+		return PackError(fmt.Sprintf("There's already a built-in pack called `%s`", p.Name))
+	}
+
+	if utf8.RuneCountInString(p.Description) > MaxPackDescriptionLength {
+		return PackError(fmt.Sprintf("The description can be at most %d characters", MaxPackDescriptionLength))
+	}
+
+	if len(p.Prompts) < 1 && len(p.Responses) < 1 {
+		return PackError("The pack has no cards")
+	}
+
+	if len(p.Prompts) > MaxPackPrompts || len(p.Responses) > MaxPackResponses {
+		return PackError(fmt.Sprintf("Packs can have at most %d prompt cards and %d response cards", MaxPackPrompts, MaxPackResponses))
+	}
+
+	if len(p.Picks) != len(p.Prompts) {
+		return PackError("Every prompt card needs a pick count")
+	}
+
+	for i, v := range p.Prompts {
+		if err := validateCardText(v); err != nil {
+			return PackError(fmt.Sprintf("Prompt card %d: %s", i+1, err))
+		}
+
+		if _, err := cardsagainstdiscord.CustomPromptCard(v, int(p.Picks[i])); err != nil {
+			return PackError(fmt.Sprintf("Prompt card %d: %s", i+1, err))
+		}
+	}
+
+	for i, v := range p.Responses {
+		if err := validateCardText(v); err != nil {
+			return PackError(fmt.Sprintf("Response card %d: %s", i+1, err))
+		}
+	}
+
+	return nil
+}
+
+func validateCardText(text string) error {
+	if strings.TrimSpace(text) == "" {
+		return PackError("empty card")
+	}
+
+	if utf8.RuneCountInString(text) > MaxCardLength {
+		return PackError(fmt.Sprintf("cards can be at most %d characters", MaxCardLength))
+	}
+
+	return nil
+}
+
+// CardPack converts the pack to the one used in games, the pack is assumed to be valid
+func CardPack(p *models.CahPack) *cardsagainstdiscord.CardPack {
+	pack := &cardsagainstdiscord.CardPack{
+		Name:        p.Name,
+		Description: p.Description,
+		Prompts:     make([]*cardsagainstdiscord.PromptCard, 0, len(p.Prompts)),
+		Responses:   make([]cardsagainstdiscord.ResponseCard, 0, len(p.Responses)),
+	}
+
+	for i, v := range p.Prompts {
+		card, err := cardsagainstdiscord.CustomPromptCard(v, int(p.Picks[i]))
+		if err != nil {
+			continue
+		}
+
+		card.Prompt = cardsagainstdiscord.FilterEveryoneMentions(card.Prompt)
+		pack.Prompts = append(pack.Prompts, card)
+	}
+
+	for _, v := range p.Responses {
+		pack.Responses = append(pack.Responses, cardsagainstdiscord.ResponseCard(cardsagainstdiscord.FilterEveryoneMentions(v)))
+	}
+
+	return pack
+}
+
+// GetCustomPacks returns the packs made for the guild ordered by name
+func GetCustomPacks(ctx context.Context, guildID int64) (models.CahPackSlice, error) {
+	return models.CahPacks(models.CahPackWhere.GuildID.EQ(guildID), qm.OrderBy("name asc")).AllG(ctx)
+}
+
+// GuildCardPacks returns the custom packs of the guild that can be used in games, keyed by name
+func GuildCardPacks(ctx context.Context, guildID int64) (map[string]*cardsagainstdiscord.CardPack, error) {
+	packs, err := GetCustomPacks(ctx, guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*cardsagainstdiscord.CardPack, len(packs))
+	for _, v := range packs {
+		if ValidatePack(v) != nil {
+			// the built-in packs have changed since it was created
+			continue
+		}
+
+		result[v.Name] = CardPack(v)
+	}
+
+	return result, nil
+}
+
+// PackJSON is the format packs are imported and exported in
+type PackJSON struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Prompts     []*PromptJSON `json:"prompts"`
+	Responses   []string      `json:"responses"`
+}
+
+// PromptJSON is either just the text of the prompt, or an object with the text and the number of cards to pick,
+// which defaults to the number of blanks
+type PromptJSON struct {
+	Text string `json:"text"`
+	Pick int    `json:"pick,omitempty"`
+}
+
+func (p *PromptJSON) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		p.Text = text
+		return nil
+	}
+
+	type plain PromptJSON
+	return json.Unmarshal(data, (*plain)(p))
+}
+
+// ParsePackJSON parses and validates a pack in the json format
+func ParsePackJSON(data []byte) (*models.CahPack, error) {
+	var parsed PackJSON
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, PackError("Invalid JSON: " + err.Error())
+	}
+
+	pack := &models.CahPack{
+		Name:        strings.ToLower(strings.TrimSpace(parsed.Name)),
+		Description: strings.TrimSpace(parsed.Description),
+		Prompts:     types.StringArray{},
+		Picks:       types.Int64Array{},
+		Responses:   append(types.StringArray{}, parsed.Responses...),
+	}
+
+	for _, v := range parsed.Prompts {
+		if v == nil {
+			return nil, PackError("Prompt cards can't be null")
+		}
+
+		pick := v.Pick
+		if pick == 0 {
+			pick = cardsagainstdiscord.DefaultPick(v.Text)
+		}
+
+		pack.Prompts = append(pack.Prompts, v.Text)
+		pack.Picks = append(pack.Picks, int64(pick))
+	}
+
+	if err := ValidatePack(pack); err != nil {
+		return nil, err
+	}
+
+	return pack, nil
+}
+
+// PackJSONData returns the pack in the format it can be imported in
+func PackJSONData(p *models.CahPack) ([]byte, error) {
+	out := &PackJSON{
+		Name:        p.Name,
+		Description: p.Description,
+		Prompts:     make([]*PromptJSON, len(p.Prompts)),
+		Responses:   []string(p.Responses),
+	}
+
+	for i, v := range p.Prompts {
+		out.Prompts[i] = &PromptJSON{Text: v}
+		if i < len(p.Picks) {
+			out.Prompts[i].Pick = int(p.Picks[i])
+		}
+	}
+
+	if out.Responses == nil {
+		out.Responses = []string{}
+	}
+
+	return json.MarshalIndent(out, "", "  ")
+}
+
+// ParseCardLines parses cards written one per line in the dashboard, skipping empty lines
+func ParseCardLines(text string) []string {
+	result := []string{}
+	for _, v := range strings.Split(text, "\n") {
+		v = strings.TrimSpace(v)
+		if v != "" {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+// PromptLines returns the prompts one per line like they're written in the dashboard,
+// prompts without blanks that pick more than one card get the blanks added so the pick count is kept
+func PromptLines(p *models.CahPack) string {
+	lines := make([]string, len(p.Prompts))
+	for i, v := range p.Prompts {
+		lines[i] = v
+		if i < len(p.Picks) && p.Picks[i] > 1 && !strings.Contains(v, "_") {
+			lines[i] += strings.Repeat(" _", int(p.Picks[i]))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
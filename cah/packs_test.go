@@ -0,0 +1,102 @@
+package cah
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/botlabs-gg/yagpdb/v2/cah/models"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+func TestParsePackJSON(t *testing.T) {
+	pack, err := ParsePackJSON([]byte(`{
+		"name": " MyPack ",
+		"description": "Inside jokes",
+		"prompts": ["Why? _", {"text": "Make a haiku.", "pick": 3}],
+		"responses": ["A bag of cats", "%blank"]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pack.Name != "mypack" || pack.Description != "Inside jokes" {
+		t.Errorf("unexpected name or description: %q %q", pack.Name, pack.Description)
+	}
+
+	if !reflect.DeepEqual(pack.Prompts, types.StringArray{"Why? _", "Make a haiku."}) || !reflect.DeepEqual(pack.Picks, types.Int64Array{1, 3}) {
+		t.Errorf("unexpected prompts: %v %v", pack.Prompts, pack.Picks)
+	}
+
+	cards := CardPack(pack)
+	if len(cards.Prompts) != 2 || cards.Prompts[1].NumPick != 3 || len(cards.Responses) != 2 {
+		t.Errorf("unexpected card pack: %+v", cards)
+	}
+
+	for _, v := range []string{
+		`not json`,
+		`{"name": "main", "responses": ["a"]}`,
+		`{"name": "bad name", "responses": ["a"]}`,
+		`{"name": "empty"}`,
+		`{"name": "blank", "responses": [" "]}`,
+		`{"name": "picks", "prompts": [{"text": "_ and _", "pick": 1}]}`,
+		`{"name": "null", "prompts": [null]}`,
+	} {
+		if _, err := ParsePackJSON([]byte(v)); err == nil {
+			t.Errorf("expected an error for %s", v)
+		} else if _, ok := err.(PackError); !ok {
+			t.Errorf("expected a pack error for %s, got %v", v, err)
+		}
+	}
+}
+
+func TestPackJSONRoundTrip(t *testing.T) {
+	pack := &models.CahPack{
+		Name:      "mypack",
+		Prompts:   types.StringArray{"Why? _", "Make a haiku."},
+		Picks:     types.Int64Array{1, 3},
+		Responses: types.StringArray{"A bag of cats"},
+	}
+
+	data, err := PackJSONData(pack)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := ParsePackJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(parsed.Prompts, pack.Prompts) || !reflect.DeepEqual(parsed.Picks, pack.Picks) || !reflect.DeepEqual(parsed.Responses, pack.Responses) {
+		t.Errorf("got %+v, expected %+v", parsed, pack)
+	}
+}
+
+func TestPromptLines(t *testing.T) {
+	pack := &models.CahPack{
+		Prompts: types.StringArray{"Why? _", "Make a haiku.", "Pick one."},
+		Picks:   types.Int64Array{1, 3, 1},
+	}
+
+	lines := PromptLines(pack)
+	if lines != "Why? _\nMake a haiku. _ _ _\nPick one." {
+		t.Errorf("got %q", lines)
+	}
+
+	form := &PackForm{Name: "mypack", Prompts: lines + "\n\n", Responses: "a\r\nb"}
+	parsed := form.ToDBModel()
+	if err := ValidatePack(parsed); err != nil {
+		t.Fatal(err)
+	}
+
+	cards := CardPack(parsed)
+	if cards.Prompts[1].NumPick != 3 || cards.Prompts[2].NumPick != 1 || !reflect.DeepEqual(parsed.Responses, types.StringArray{"a", "b"}) {
+		t.Errorf("unexpected cards: %+v %v", cards.Prompts, parsed.Responses)
+	}
+}
+
+func TestMergeWinners(t *testing.T) {
+	if result := mergeWinners([]int64{3, 1}, []int64{1, 2}); !reflect.DeepEqual(result, []int64{3, 1, 2}) {
+		t.Errorf("got %v", result)
+	}
+}
@@ -1,6 +1,7 @@
 package cah
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/botlabs-gg/yagpdb/v2/bot"
@@ -11,6 +12,7 @@ import (
 	"github.com/botlabs-gg/yagpdb/v2/lib/cardsagainstdiscord"
 	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
 	"github.com/botlabs-gg/yagpdb/v2/lib/dshardorchestrator"
+	"github.com/sirupsen/logrus"
 )
 
 const ShardMigrationEvtGame = 110
@@ -20,8 +22,11 @@ func init() {
 }
 
 func RegisterPlugin() {
+	common.InitSchemas("cah", DBSchemas...)
+
 	p := &Plugin{}
 	p.Manager = cardsagainstdiscord.NewGameManager(p)
+	p.Manager.WinRecorder = p
 	common.RegisterPlugin(p)
 
 }
@@ -42,6 +47,14 @@ func (p *Plugin) SessionForGuild(guildID int64) *discordgo.Session {
 	return common.BotSession
 }
 
+// RecordWins implements cardsagainstdiscord.WinRecorder
+func (p *Plugin) RecordWins(guildID int64, roundWinners []int64, gameWinners []int64) {
+	err := AddWins(context.Background(), guildID, roundWinners, gameWinners)
+	if err != nil {
+		logrus.WithError(err).WithField("guild", guildID).Error("[cah] failed recording wins")
+	}
+}
+
 var (
 	_ bot.BotInitHandler         = (*Plugin)(nil)
 	_ bot.ShardMigrationReceiver = (*Plugin)(nil)
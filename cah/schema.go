@@ -0,0 +1,33 @@
+package cah
+
+var DBSchemas = []string{`
+CREATE TABLE IF NOT EXISTS cah_packs (
+	id BIGSERIAL PRIMARY KEY,
+	guild_id BIGINT NOT NULL,
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+	updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	name TEXT NOT NULL,
+	description TEXT NOT NULL,
+
+	-- blanks are written as underscores, picks has the number of cards to pick for every prompt
+	prompts TEXT[] NOT NULL,
+	picks SMALLINT[] NOT NULL,
+	responses TEXT[] NOT NULL
+);
+`, `
+CREATE UNIQUE INDEX IF NOT EXISTS cah_packs_guild_name_idx ON cah_packs(guild_id, name);
+`, `
+CREATE TABLE IF NOT EXISTS cah_player_stats (
+	guild_id BIGINT NOT NULL,
+	user_id BIGINT NOT NULL,
+
+	rounds_won INT NOT NULL DEFAULT 0,
+	games_won INT NOT NULL DEFAULT 0,
+	updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	PRIMARY KEY(guild_id, user_id)
+);
+`, `
+CREATE INDEX IF NOT EXISTS cah_player_stats_games_won_idx ON cah_player_stats(guild_id, games_won DESC, rounds_won DESC);
+`}
@@ -0,0 +1,74 @@
+package cah
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/botlabs-gg/yagpdb/v2/cah/models"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// AddWins increments the won rounds of the round winners, and the won games of the game winners
+func AddWins(ctx context.Context, guildID int64, roundWinners []int64, gameWinners []int64) error {
+
+	// upsert query which is too advanced for orms
+	const q = `INSERT INTO cah_player_stats (guild_id, user_id, rounds_won, games_won, updated_at) VALUES ($1, $2, $3, $4, now())
+ON CONFLICT (guild_id, user_id) DO UPDATE SET rounds_won = cah_player_stats.rounds_won + $3, games_won = cah_player_stats.games_won + $4, updated_at = now()`
+
+	tx, err := common.PQ.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, userID := range mergeWinners(roundWinners, gameWinners) {
+		rounds := 0
+		if common.ContainsInt64Slice(roundWinners, userID) {
+			rounds = 1
+		}
+
+		games := 0
+		if common.ContainsInt64Slice(gameWinners, userID) {
+			games = 1
+		}
+
+		if _, err = tx.ExecContext(ctx, q, guildID, userID, rounds, games); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// mergeWinners returns the unique ids of both lists, in the order they first appear
+func mergeWinners(roundWinners []int64, gameWinners []int64) []int64 {
+	var result []int64
+	for _, v := range append(append([]int64{}, roundWinners...), gameWinners...) {
+		if !common.ContainsInt64Slice(result, v) {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+// GetPlayerStats returns the stats of the user, which are all zero if the user never won anything
+func GetPlayerStats(ctx context.Context, guildID, userID int64) (*models.CahPlayerStat, error) {
+	stats, err := models.FindCahPlayerStatG(ctx, guildID, userID)
+	if err == sql.ErrNoRows {
+		return &models.CahPlayerStat{GuildID: guildID, UserID: userID}, nil
+	}
+
+	return stats, err
+}
+
+// TopPlayers returns the players with the most won games, and then rounds
+func TopPlayers(ctx context.Context, guildID int64, offset, limit int) (models.CahPlayerStatSlice, error) {
+	return models.CahPlayerStats(
+		models.CahPlayerStatWhere.GuildID.EQ(guildID),
+		qm.OrderBy("games_won desc, rounds_won desc, user_id asc"),
+		qm.Offset(offset),
+		qm.Limit(limit),
+	).AllG(ctx)
+}
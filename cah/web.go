@@ -0,0 +1,298 @@
+package cah
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/botlabs-gg/yagpdb/v2/cah/models"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/cplogs"
+	"github.com/botlabs-gg/yagpdb/v2/lib/cardsagainstdiscord"
+	"github.com/botlabs-gg/yagpdb/v2/web"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"goji.io"
+	"goji.io/pat"
+)
+
+//go:embed assets/cah.html
+var PageHTML string
+
+// Max size of uploaded json packs
+const maxPackFileSize = 1000000
+
+type PackForm struct {
+	Name        string `valid:",32"`
+	Description string `valid:",200"`
+
+	// One card per line
+	Prompts   string `valid:",200000"`
+	Responses string `valid:",400000"`
+}
+
+// ToDBModel converts the form to a pack, the pick counts come from the blanks in the prompts
+func (pf *PackForm) ToDBModel() *models.CahPack {
+	pack := &models.CahPack{
+		Name:        strings.ToLower(strings.TrimSpace(pf.Name)),
+		Description: strings.TrimSpace(pf.Description),
+		Prompts:     types.StringArray(ParseCardLines(pf.Prompts)),
+		Responses:   types.StringArray(ParseCardLines(pf.Responses)),
+	}
+
+	pack.Picks = make(types.Int64Array, len(pack.Prompts))
+	for i, v := range pack.Prompts {
+		pack.Picks[i] = int64(cardsagainstdiscord.DefaultPick(v))
+	}
+
+	return pack
+}
+
+var (
+	panelLogKeyAddedPack   = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "cah_added_pack", FormatString: "Added cards against humanity pack %s"})
+	panelLogKeyUpdatedPack = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "cah_updated_pack", FormatString: "Updated cards against humanity pack %s"})
+	panelLogKeyRemovedPack = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "cah_removed_pack", FormatString: "Removed cards against humanity pack %s"})
+)
+
+var _ web.Plugin = (*Plugin)(nil)
+
+func (p *Plugin) InitWeb() {
+	web.AddHTMLTemplate("cah/assets/cah.html", PageHTML)
+	web.AddSidebarItem(web.SidebarCategoryFun, &web.SidebarItem{
+		Name: "Cards Against Humanity",
+		URL:  "cah/",
+		Icon: "fas fa-clone",
+	})
+
+	cpMux := goji.SubMux()
+
+	web.CPMux.Handle(pat.New("/cah/*"), cpMux)
+	web.CPMux.Handle(pat.New("/cah"), cpMux)
+
+	cpMux.Use(web.RequireBotMemberMW)
+
+	getHandler := web.ControllerHandler(HandleGetPacks, "cp_cah")
+
+	cpMux.Handle(pat.Get("/"), getHandler)
+	cpMux.Handle(pat.Post("/new"), web.ControllerPostHandler(HandleNewPack, getHandler, PackForm{}))
+	cpMux.Handle(pat.Post("/import"), web.ControllerPostHandler(HandleImportPack, getHandler, nil))
+	cpMux.Handle(pat.Post("/:pack/update"), web.ControllerPostHandler(HandleUpdatePack, getHandler, PackForm{}))
+	cpMux.Handle(pat.Post("/:pack/delete"), web.ControllerPostHandler(HandleDeletePack, getHandler, nil))
+	cpMux.Handle(pat.Get("/:pack/export"), http.HandlerFunc(HandleExportPack))
+}
+
+func HandleGetPacks(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	g, tmpl := web.GetBaseCPContextData(ctx)
+
+	packs, err := GetCustomPacks(ctx, g.ID)
+	if err != nil {
+		return tmpl, err
+	}
+
+	tmpl["CustomPacks"] = packs
+	tmpl["MaxCustomPacks"] = MaxCustomPacksForContext(ctx)
+	tmpl["PromptLines"] = PromptLines
+	return tmpl, nil
+}
+
+// packByName returns the pack with the name, or nil if there's none
+func packByName(ctx context.Context, guildID int64, name string) (*models.CahPack, error) {
+	pack, err := models.CahPacks(models.CahPackWhere.GuildID.EQ(guildID), models.CahPackWhere.Name.EQ(name)).OneG(ctx)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return pack, err
+}
+
+// savePack validates the pack and creates it, or replaces the existing one if id isn't 0
+func savePack(ctx context.Context, tmpl web.TemplateData, pack *models.CahPack, id int64) (bool, error) {
+	if err := ValidatePack(pack); err != nil {
+		if _, ok := err.(PackError); ok {
+			tmpl.AddAlerts(web.ErrorAlert(err.Error()))
+			return false, nil
+		}
+		return false, err
+	}
+
+	existing, err := packByName(ctx, pack.GuildID, pack.Name)
+	if err != nil {
+		return false, err
+	}
+
+	if existing != nil && existing.ID != id {
+		tmpl.AddAlerts(web.ErrorAlert("Name already used"))
+		return false, nil
+	}
+
+	if id != 0 {
+		pack.ID = id
+		_, err = pack.UpdateG(ctx, boil.Infer())
+		return true, err
+	}
+
+	count, err := models.CahPacks(models.CahPackWhere.GuildID.EQ(pack.GuildID)).CountG(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if count >= int64(MaxCustomPacksForContext(ctx)) {
+		tmpl.AddAlerts(web.ErrorAlert(fmt.Sprintf("Max %d packs allowed (%d for premium servers)", MaxCustomPacks, MaxCustomPacksPremium)))
+		return false, nil
+	}
+
+	return true, pack.InsertG(ctx, boil.Infer())
+}
+
+func HandleNewPack(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	g, tmpl := web.GetBaseCPContextData(ctx)
+	data := ctx.Value(common.ContextKeyParsedForm).(*PackForm)
+
+	pack := data.ToDBModel()
+	pack.GuildID = g.ID
+
+	saved, err := savePack(ctx, tmpl, pack, 0)
+	if saved && err == nil {
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(ctx, panelLogKeyAddedPack, &cplogs.Param{Type: cplogs.ParamTypeString, Value: pack.Name}))
+	}
+
+	return tmpl, err
+}
+
+// HandleImportPack creates a pack from a uploaded json file, replacing the pack with the same name if there is one
+func HandleImportPack(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	g, tmpl := web.GetBaseCPContextData(ctx)
+
+	file, _, err := r.FormFile("Pack")
+	if err != nil {
+		return tmpl.AddAlerts(web.ErrorAlert("No file uploaded")), nil
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxPackFileSize+1))
+	if err != nil {
+		return tmpl, err
+	}
+
+	if len(data) > maxPackFileSize {
+		return tmpl.AddAlerts(web.ErrorAlert("Max 1MB files allowed")), nil
+	}
+
+	pack, err := ParsePackJSON(data)
+	if err != nil {
+		if _, ok := err.(PackError); ok {
+			return tmpl.AddAlerts(web.ErrorAlert(err.Error())), nil
+		}
+		return tmpl, err
+	}
+	pack.GuildID = g.ID
+
+	existing, err := packByName(ctx, g.ID, pack.Name)
+	if err != nil {
+		return tmpl, err
+	}
+
+	var id int64
+	logKey := panelLogKeyAddedPack
+	if existing != nil {
+		id = existing.ID
+		logKey = panelLogKeyUpdatedPack
+	}
+
+	saved, err := savePack(ctx, tmpl, pack, id)
+	if saved && err == nil {
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(ctx, logKey, &cplogs.Param{Type: cplogs.ParamTypeString, Value: pack.Name}))
+	}
+
+	return tmpl, err
+}
+
+// packFromRequest returns the pack in the url, or nil if it doesn't exist on the guild
+func packFromRequest(r *http.Request, guildID int64) (*models.CahPack, error) {
+	id, err := strconv.ParseInt(pat.Param(r, "pack"), 10, 64)
+	if err != nil {
+		return nil, nil
+	}
+
+	pack, err := models.CahPacks(models.CahPackWhere.ID.EQ(id), models.CahPackWhere.GuildID.EQ(guildID)).OneG(r.Context())
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return pack, err
+}
+
+func HandleUpdatePack(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	g, tmpl := web.GetBaseCPContextData(ctx)
+	data := ctx.Value(common.ContextKeyParsedForm).(*PackForm)
+
+	existing, err := packFromRequest(r, g.ID)
+	if err != nil || existing == nil {
+		return tmpl.AddAlerts(web.ErrorAlert("Unknown pack")), err
+	}
+
+	pack := data.ToDBModel()
+	pack.GuildID = g.ID
+
+	saved, err := savePack(ctx, tmpl, pack, existing.ID)
+	if saved && err == nil {
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(ctx, panelLogKeyUpdatedPack, &cplogs.Param{Type: cplogs.ParamTypeString, Value: pack.Name}))
+	}
+
+	return tmpl, err
+}
+
+func HandleDeletePack(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	g, tmpl := web.GetBaseCPContextData(ctx)
+
+	pack, err := packFromRequest(r, g.ID)
+	if err != nil || pack == nil {
+		return tmpl.AddAlerts(web.ErrorAlert("Unknown pack")), err
+	}
+
+	_, err = pack.DeleteG(ctx)
+	if err == nil {
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(ctx, panelLogKeyRemovedPack, &cplogs.Param{Type: cplogs.ParamTypeString, Value: pack.Name}))
+	}
+
+	return tmpl, err
+}
+
+// HandleExportPack serves the pack as a json file that can be imported again
+func HandleExportPack(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	g, _ := web.GetBaseCPContextData(ctx)
+
+	pack, err := packFromRequest(r, g.ID)
+	if err != nil {
+		web.CtxLogger(ctx).WithError(err).Error("failed retrieving cah pack")
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if pack == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	data, err := PackJSONData(pack)
+	if err != nil {
+		web.CtxLogger(ctx).WithError(err).Error("failed encoding cah pack")
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+pack.Name+`.json"`)
+	w.Write(data)
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/botlabs-gg/yagpdb/v2/bot/eventcapture"
+	"github.com/botlabs-gg/yagpdb/v2/bot/eventcapture/replay"
+	"github.com/botlabs-gg/yagpdb/v2/bot/paginatedmessages"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/featureflags"
+	"github.com/botlabs-gg/yagpdb/v2/lib/confusables"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+	log "github.com/sirupsen/logrus"
+
+	// Plugins that act on events
+	"github.com/botlabs-gg/yagpdb/v2/automod"
+	"github.com/botlabs-gg/yagpdb/v2/automod_legacy"
+	"github.com/botlabs-gg/yagpdb/v2/autorole"
+	"github.com/botlabs-gg/yagpdb/v2/commands"
+	"github.com/botlabs-gg/yagpdb/v2/customcommands"
+	"github.com/botlabs-gg/yagpdb/v2/logs"
+	"github.com/botlabs-gg/yagpdb/v2/moderation"
+	"github.com/botlabs-gg/yagpdb/v2/notifications"
+	"github.com/botlabs-gg/yagpdb/v2/reputation"
+	"github.com/botlabs-gg/yagpdb/v2/rolecommands"
+	"github.com/botlabs-gg/yagpdb/v2/serverstats"
+	"github.com/botlabs-gg/yagpdb/v2/stdcommands"
+	"github.com/botlabs-gg/yagpdb/v2/streaming"
+	"github.com/botlabs-gg/yagpdb/v2/tickets"
+	"github.com/botlabs-gg/yagpdb/v2/verification"
+)
+
+var (
+	flagFile      string
+	flagBotID     int64
+	flagBotName   string
+	flagShards    int
+	flagJSON      bool
+	flagShowCalls bool
+	flagAllowDB   bool
+)
+
+func init() {
+	flag.StringVar(&flagFile, "file", "", "The capture file to replay")
+	flag.Int64Var(&flagBotID, "botid", 0, "The user id of the bot that made the capture")
+	flag.StringVar(&flagBotName, "botname", "YAGPDB", "The username of the bot that made the capture")
+	flag.IntVar(&flagShards, "shards", 1, "Number of shards to replay with, events from other shards are handled on shard 0")
+	flag.BoolVar(&flagJSON, "json", false, "Print the result as json, useful for diffing against an earlier replay")
+	flag.BoolVar(&flagShowCalls, "calls", true, "Print the rest api calls made")
+	flag.BoolVar(&flagAllowDB, "allow-live-db", false, "Confirms that the configured postgres and redis are a throwaway copy, the plugins write to them while replaying")
+}
+
+func main() {
+	flag.Parse()
+
+	if flagFile == "" || flagBotID == 0 {
+		fmt.Println("-file and -botid are required, see -h for more info")
+		os.Exit(1)
+	}
+
+	if !flagAllowDB {
+		fmt.Println("The replayed events are handled by the plugins as normal, which write to the configured postgres and redis.\n" +
+			"Point YAGPDB_PQDB and YAGPDB_REDIS at a separate database that can be thrown away and pass -allow-live-db to confirm.")
+		os.Exit(1)
+	}
+
+	events, err := eventcapture.ReadFile(flagFile)
+	if err != nil {
+		log.WithError(err).Fatal("Failed reading capture")
+	}
+
+	err = common.CoreInit(true)
+	if err != nil {
+		log.WithError(err).Fatal("Failed running core init")
+	}
+
+	rest, err := replay.Init(&discordgo.User{
+		ID:       flagBotID,
+		Username: flagBotName,
+		Bot:      true,
+	})
+	if err != nil {
+		log.WithError(err).Fatal("Failed initializing")
+	}
+
+	// scheduled events are left out as they run on a timer and would make the replay non deterministic
+	paginatedmessages.RegisterPlugin()
+	featureflags.RegisterPlugin()
+	commands.RegisterPlugin()
+	stdcommands.RegisterPlugin()
+	serverstats.RegisterPlugin()
+	notifications.RegisterPlugin()
+	customcommands.RegisterPlugin()
+	moderation.RegisterPlugin()
+	reputation.RegisterPlugin()
+	streaming.RegisterPlugin()
+	automod_legacy.RegisterPlugin()
+	automod.RegisterPlugin()
+	logs.RegisterPlugin()
+	autorole.RegisterPlugin()
+	rolecommands.RegisterPlugin()
+	tickets.RegisterPlugin()
+	verification.RegisterPlugin()
+
+	confusables.Init()
+
+	err = replay.SetupBot(rest, flagShards)
+	if err != nil {
+		log.WithError(err).Fatal("Failed setting up the bot")
+	}
+
+	result := replay.Replay(events, rest)
+
+	if flagJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(result)
+		return
+	}
+
+	fmt.Printf("Replayed %d events (%d skipped), %d rest calls made\n", result.Handled, result.Skipped, len(result.Calls))
+
+	types := make([]string, 0, len(result.Counts))
+	for k := range result.Counts {
+		types = append(types, k)
+	}
+	sort.Strings(types)
+
+	for _, v := range types {
+		fmt.Printf("  %-32s %d\n", v, result.Counts[v])
+	}
+
+	if flagShowCalls {
+		fmt.Println("\nRest calls:")
+		for _, v := range result.Calls {
+			fmt.Println("  " + v.String())
+		}
+	}
+}
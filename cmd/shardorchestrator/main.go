@@ -72,7 +72,18 @@ func main() {
 	orch.MaxNodeDowntimeBeforeRestart = time.Second * 10
 	orch.EnsureAllShardsRunning = true
 
-	logrus.Infof("%#v+n", *orch)
+	orch.AuthSecret, orch.TLSConfig, err = common.OrchestratorConnAuth(true)
+	if err != nil {
+		log.Fatal("failed loading orchestrator tls config: ", err)
+	}
+
+	if common.ConfOrchestratorRebalance.GetBool() {
+		orch.Rebalancer = orchestrator.NewRebalanceConfig()
+	}
+
+	// the orchestrator holds the auth secret and tls config, so only log what's useful
+	logrus.Infof("starting orchestrator, shard bucket size: %d, buckets per node: %d, max shards per node: %d, tls: %v, rebalance: %v",
+		orch.ShardBucketSize, orch.BucketsPerNode, orch.MaxShardsPerNode, orch.TLSConfig != nil, orch.Rebalancer != nil)
 
 	err = orch.Start("127.0.0.1:7447")
 	if err != nil {
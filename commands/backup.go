@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/botlabs-gg/yagpdb/v2/commands/models"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/featureflags"
+	prfx "github.com/botlabs-gg/yagpdb/v2/common/prefix"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+	"github.com/mediocregopher/radix/v3"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+type commandsBackup struct {
+	Prefix           string
+	ChannelOverrides []*channelOverrideBackup
+}
+
+type channelOverrideBackup struct {
+	*models.CommandsChannelsOverride
+	CommandOverrides []*models.CommandsCommandOverride
+}
+
+var _ common.PluginWithBackup = (*Plugin)(nil)
+
+func (p *Plugin) BackupVersion() int {
+	return 1
+}
+
+func (p *Plugin) ExportBackup(ctx context.Context, guildID int64) (interface{}, error) {
+	prefix, err := prfx.GetCommandPrefixRedis(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	channelOverrides, err := models.CommandsChannelsOverrides(
+		models.CommandsChannelsOverrideWhere.GuildID.EQ(guildID),
+		qm.Load("CommandsCommandOverrides"),
+		qm.OrderBy("id asc")).AllG(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	backup := &commandsBackup{
+		Prefix: prefix,
+	}
+
+	for _, v := range channelOverrides {
+		backup.ChannelOverrides = append(backup.ChannelOverrides, &channelOverrideBackup{
+			CommandsChannelsOverride: v,
+			CommandOverrides:         v.R.CommandsCommandOverrides,
+		})
+	}
+
+	return backup, nil
+}
+
+func (p *Plugin) RestoreBackup(ctx context.Context, guildID int64, data json.RawMessage, restore *common.BackupRestore) error {
+	var backup commandsBackup
+	err := json.Unmarshal(data, &backup)
+	if err != nil {
+		return err
+	}
+
+	tx, err := common.PQ.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// command overrides are removed along with their channel override
+	_, err = models.CommandsChannelsOverrides(models.CommandsChannelsOverrideWhere.GuildID.EQ(guildID)).DeleteAll(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for _, v := range backup.ChannelOverrides {
+		if v.CommandsChannelsOverride == nil {
+			continue
+		}
+
+		override := v.CommandsChannelsOverride
+		override.ID = 0
+		override.GuildID = guildID
+		override.RequireRoles = restore.Roles(override.RequireRoles)
+		override.IgnoreRoles = restore.Roles(override.IgnoreRoles)
+
+		if !override.Global {
+			numChannels := len(override.Channels) + len(override.ChannelCategories)
+			override.Channels = restore.Channels(override.Channels)
+			override.ChannelCategories = restore.Channels(override.ChannelCategories)
+
+			if numChannels > 0 && len(override.Channels)+len(override.ChannelCategories) == 0 {
+				restore.Note("Skipped a channel override as none of its channels were found")
+				continue
+			}
+		}
+
+		err = override.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		for _, cmdOverride := range v.CommandOverrides {
+			cmdOverride.ID = 0
+			cmdOverride.GuildID = guildID
+			cmdOverride.CommandsChannelsOverridesID = override.ID
+			cmdOverride.RequireRoles = restore.Roles(cmdOverride.RequireRoles)
+			cmdOverride.IgnoreRoles = restore.Roles(cmdOverride.IgnoreRoles)
+
+			err = cmdOverride.Insert(ctx, tx, boil.Infer())
+			if err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+	}
+
+	err = restore.Finish(tx)
+	if err != nil || restore.DryRun {
+		return err
+	}
+
+	if backup.Prefix != "" {
+		err = common.RedisPool.Do(radix.Cmd(nil, "SET", "command_prefix:"+discordgo.StrID(guildID), backup.Prefix))
+		if err != nil {
+			return err
+		}
+	}
+
+	featureflags.MarkGuildDirty(guildID)
+	PubsubSendUpdateSlashCommandsPermissions(guildID)
+	return nil
+}
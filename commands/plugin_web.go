@@ -105,6 +105,7 @@ func (p *Plugin) InitWeb() {
 	subMux.Handle(pat.Post("/channel_overrides/:channelOverride/command_overrides/:commandsOverride/delete"),
 		web.ControllerPostHandler(ChannelOverrideMiddleware(HandleDeleteCommandOverride), getHandler, nil))
 
+	cplogs.RegisterConfigSource(cplogs.BackupConfigSource(p, "commands", "Command settings", "commands/settings"))
 }
 
 // Servers the command page with current config
@@ -443,3 +443,37 @@ func (ra *RoleArg) ExtractID(part string, data *dcmd.Data) interface{} {
 func (ra *RoleArg) HelpName() string {
 	return "Role"
 }
+
+// ParseRoleList parses a comma separated list of role mentions, ids or names, "none" returns no roles
+func ParseRoleList(gs *dstate.GuildSet, s string) ([]int64, error) {
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "none") || s == "" {
+		return []int64{}, nil
+	}
+
+	var result []int64
+OUTER:
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		trimmed := strings.TrimSuffix(strings.TrimPrefix(part, "<@&"), ">")
+		if id, err := strconv.ParseInt(trimmed, 10, 64); err == nil && gs.GetRole(id) != nil {
+			result = append(result, id)
+			continue
+		}
+
+		for _, r := range gs.Roles {
+			if strings.EqualFold(r.Name, part) {
+				result = append(result, r.ID)
+				continue OUTER
+			}
+		}
+
+		return nil, NewPublicError("Unknown role: ", part)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,159 @@
+package common
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var APITokensDBSchemas = []string{`
+CREATE TABLE IF NOT EXISTS api_tokens (
+	id BIGSERIAL PRIMARY KEY,
+	guild_id BIGINT NOT NULL,
+
+	name TEXT NOT NULL,
+	token_hash TEXT NOT NULL UNIQUE,
+	scopes TEXT[] NOT NULL,
+	read_only BOOLEAN NOT NULL,
+
+	created_by BIGINT NOT NULL,
+	created_by_username TEXT NOT NULL,
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+	last_used_at TIMESTAMP WITH TIME ZONE
+);
+`, `
+CREATE INDEX IF NOT EXISTS api_tokens_guild_idx ON api_tokens(guild_id);
+`}
+
+// APITokenPrefix is prepended to all api tokens to make them easy to recognize, for example by secret scanners
+const APITokenPrefix = "yagapi_"
+
+// MaxAPITokens is the max number of api tokens per guild
+const MaxAPITokens = 10
+
+// APIToken gives access to the REST API of a single guild, limited to the plugins in Scopes
+type APIToken struct {
+	ID      int64
+	GuildID int64
+
+	Name     string
+	Scopes   []string
+	ReadOnly bool
+
+	CreatedBy         int64
+	CreatedByUsername string
+	CreatedAt         time.Time
+	LastUsedAt        *time.Time
+}
+
+// HasScope returns true if the token has access to the scope, optionally for writing
+func (t *APIToken) HasScope(scope string, write bool) bool {
+	if write && t.ReadOnly {
+		return false
+	}
+
+	return ContainsStringSlice(t.Scopes, scope)
+}
+
+// HashAPIToken returns the hash of the token as it's stored, the tokens themselves are only shown once when created
+func HashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateAPIToken creates a new api token and returns it along with the secret token string
+func CreateAPIToken(ctx context.Context, guildID int64, name string, scopes []string, readOnly bool, createdBy int64, createdByUsername string) (*APIToken, string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return nil, "", err
+	}
+
+	secret := APITokenPrefix + base64.RawURLEncoding.EncodeToString(b)
+
+	token := &APIToken{
+		GuildID:           guildID,
+		Name:              name,
+		Scopes:            scopes,
+		ReadOnly:          readOnly,
+		CreatedBy:         createdBy,
+		CreatedByUsername: createdByUsername,
+		CreatedAt:         time.Now(),
+	}
+
+	const q = `INSERT INTO api_tokens (guild_id, name, token_hash, scopes, read_only, created_by, created_by_username, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id`
+
+	err = PQ.QueryRowContext(ctx, q, guildID, name, HashAPIToken(secret), pq.StringArray(scopes), readOnly, createdBy, createdByUsername, token.CreatedAt).Scan(&token.ID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return token, secret, nil
+}
+
+const apiTokenColumns = "id, guild_id, name, scopes, read_only, created_by, created_by_username, created_at, last_used_at"
+
+func scanAPIToken(row interface{ Scan(...interface{}) error }) (*APIToken, error) {
+	var scopes pq.StringArray
+	var lastUsed sql.NullTime
+
+	token := &APIToken{}
+	err := row.Scan(&token.ID, &token.GuildID, &token.Name, &scopes, &token.ReadOnly, &token.CreatedBy, &token.CreatedByUsername, &token.CreatedAt, &lastUsed)
+	if err != nil {
+		return nil, err
+	}
+
+	token.Scopes = scopes
+	if lastUsed.Valid {
+		token.LastUsedAt = &lastUsed.Time
+	}
+
+	return token, nil
+}
+
+// GetAPITokens returns all the api tokens of the guild
+func GetAPITokens(ctx context.Context, guildID int64) ([]*APIToken, error) {
+	rows, err := PQ.QueryContext(ctx, "SELECT "+apiTokenColumns+" FROM api_tokens WHERE guild_id = $1 ORDER BY id ASC", guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*APIToken, 0)
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, token)
+	}
+
+	return result, rows.Err()
+}
+
+// GetAPITokenBySecret looks up the token from the secret token string, returns sql.ErrNoRows if it doesn't exist
+func GetAPITokenBySecret(ctx context.Context, secret string) (*APIToken, error) {
+	row := PQ.QueryRowContext(ctx, "SELECT "+apiTokenColumns+" FROM api_tokens WHERE token_hash = $1", HashAPIToken(secret))
+	return scanAPIToken(row)
+}
+
+// DeleteAPIToken revokes the token
+func DeleteAPIToken(ctx context.Context, guildID, tokenID int64) error {
+	_, err := PQ.ExecContext(ctx, "DELETE FROM api_tokens WHERE guild_id = $1 AND id = $2", guildID, tokenID)
+	return err
+}
+
+// MarkAPITokenUsed updates the last used time of the token
+func MarkAPITokenUsed(ctx context.Context, tokenID int64) error {
+	_, err := PQ.ExecContext(ctx, "UPDATE api_tokens SET last_used_at = now() WHERE id = $1", tokenID)
+	return err
+}
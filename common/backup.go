@@ -0,0 +1,393 @@
+package common
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BackupArchiveVersion is the version of the archive format, bump this on incompatible changes to BackupArchive
+const BackupArchiveVersion = 1
+
+// BackupArchive holds the settings of all the plugins that support backups for a single server
+type BackupArchive struct {
+	Version   int
+	CreatedAt time.Time
+
+	GuildID   int64
+	GuildName string
+
+	// Roles and channels of the server at the time of the backup, used to map ids by name when restoring on another server
+	Roles    []*BackupEntity
+	Channels []*BackupEntity
+
+	// Plugins is keyed by the plugin's SysName
+	Plugins map[string]*BackupPluginData
+}
+
+// BackupEntity is a role or channel in a backup
+type BackupEntity struct {
+	ID   int64
+	Name string
+	Type int `json:",omitempty"`
+}
+
+type BackupPluginData struct {
+	Version int
+	Data    json.RawMessage
+}
+
+// BackupPlugins returns all the registered plugins that support backups
+func BackupPlugins() []PluginWithBackup {
+	var result []PluginWithBackup
+	for _, v := range Plugins {
+		if cast, ok := v.(PluginWithBackup); ok {
+			result = append(result, cast)
+		}
+	}
+
+	return result
+}
+
+// CreateBackupArchive exports the settings of all the plugins that support backups
+func CreateBackupArchive(ctx context.Context, guildID int64, guildName string, roles, channels []*BackupEntity) (*BackupArchive, error) {
+	archive := &BackupArchive{
+		Version:   BackupArchiveVersion,
+		CreatedAt: time.Now(),
+		GuildID:   guildID,
+		GuildName: guildName,
+		Roles:     roles,
+		Channels:  channels,
+		Plugins:   make(map[string]*BackupPluginData),
+	}
+
+	for _, v := range BackupPlugins() {
+		data, err := v.ExportBackup(ctx, guildID)
+		if err != nil {
+			return nil, fmt.Errorf("failed exporting %s: %w", v.PluginInfo().Name, err)
+		}
+
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed encoding %s: %w", v.PluginInfo().Name, err)
+		}
+
+		archive.Plugins[v.PluginInfo().SysName] = &BackupPluginData{
+			Version: v.BackupVersion(),
+			Data:    encoded,
+		}
+	}
+
+	return archive, nil
+}
+
+// BackupRestoreReport describes the outcome of a restore, or what would happen for a dry run
+type BackupRestoreReport struct {
+	DryRun  bool
+	Plugins []*BackupPluginReport
+
+	// Names of the roles and channels referenced in the backup that have no match on the server
+	MissingRoles    []string
+	MissingChannels []string
+}
+
+type BackupPluginReport struct {
+	Name    string
+	SysName string
+
+	Restored bool
+	Skipped  bool
+	Error    string
+	Notes    []string
+}
+
+// RestoreBackupArchive restores the plugins in the archive, or all of them if onlyPlugins is empty
+func RestoreBackupArchive(ctx context.Context, archive *BackupArchive, restore *BackupRestore, onlyPlugins []string) *BackupRestoreReport {
+	report := &BackupRestoreReport{
+		DryRun: restore.DryRun,
+	}
+
+	for _, v := range BackupPlugins() {
+		info := v.PluginInfo()
+		data, ok := archive.Plugins[info.SysName]
+		if !ok {
+			continue
+		}
+
+		pReport := &BackupPluginReport{
+			Name:    info.Name,
+			SysName: info.SysName,
+		}
+		report.Plugins = append(report.Plugins, pReport)
+
+		if len(onlyPlugins) > 0 && !ContainsStringSlice(onlyPlugins, info.SysName) {
+			pReport.Skipped = true
+			continue
+		}
+
+		if data.Version > v.BackupVersion() {
+			pReport.Error = "The backup was made with a newer version of this plugin"
+			continue
+		}
+
+		restore.notes = nil
+		err := v.RestoreBackup(ctx, restore.TargetGuildID, data.Data, restore)
+		pReport.Notes = restore.notes
+		if err != nil {
+			logger.WithError(err).WithField("guild", restore.TargetGuildID).Errorf("failed restoring backup of %s", info.SysName)
+			pReport.Error = err.Error()
+			continue
+		}
+
+		pReport.Restored = true
+	}
+
+	report.MissingRoles = sortedNames(restore.missingRoles)
+	report.MissingChannels = sortedNames(restore.missingChannels)
+	return report
+}
+
+func sortedNames(m map[int64]string) []string {
+	result := make([]string, 0, len(m))
+	for _, v := range m {
+		result = append(result, v)
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+// BackupRestore is passed to plugins when restoring a backup, it maps the role and channel ids from the
+// server the backup was made on to the server it's restored on
+type BackupRestore struct {
+	// DryRun is set when only checking what would be restored, plugins should not save anything
+	DryRun bool
+
+	SourceGuildID int64
+	TargetGuildID int64
+
+	roles    map[int64]int64
+	channels map[int64]int64
+
+	sourceRoles    map[int64]string
+	sourceChannels map[int64]string
+
+	missingRoles    map[int64]string
+	missingChannels map[int64]string
+
+	// keepIDs is set when restoring the servers own config, every role and channel is kept as is
+	keepIDs bool
+
+	notes []string
+}
+
+// NewBackupRestore sets up the id mappings for restoring archive on the target server. Roles and channels are matched by id when
+// restoring on the same server, otherwise by name (and type for channels)
+func NewBackupRestore(archive *BackupArchive, targetGuildID int64, targetRoles, targetChannels []*BackupEntity, dryRun bool) *BackupRestore {
+	restore := &BackupRestore{
+		DryRun:        dryRun,
+		SourceGuildID: archive.GuildID,
+		TargetGuildID: targetGuildID,
+
+		roles:    mapBackupEntities(archive.Roles, targetRoles, archive.GuildID == targetGuildID),
+		channels: mapBackupEntities(archive.Channels, targetChannels, archive.GuildID == targetGuildID),
+
+		sourceRoles:    make(map[int64]string),
+		sourceChannels: make(map[int64]string),
+
+		missingRoles:    make(map[int64]string),
+		missingChannels: make(map[int64]string),
+	}
+
+	for _, v := range archive.Roles {
+		restore.sourceRoles[v.ID] = v.Name
+	}
+	for _, v := range archive.Channels {
+		restore.sourceChannels[v.ID] = v.Name
+	}
+
+	// the everyone role has the same id as the server
+	restore.roles[archive.GuildID] = targetGuildID
+
+	return restore
+}
+
+// NewRevertRestore returns a restore that keeps all the role and channel ids as they are, used to revert the server to an
+// earlier version of its own config
+func NewRevertRestore(guildID int64) *BackupRestore {
+	return &BackupRestore{
+		SourceGuildID: guildID,
+		TargetGuildID: guildID,
+
+		roles:    make(map[int64]int64),
+		channels: make(map[int64]int64),
+
+		sourceRoles:    make(map[int64]string),
+		sourceChannels: make(map[int64]string),
+
+		missingRoles:    make(map[int64]string),
+		missingChannels: make(map[int64]string),
+
+		keepIDs: true,
+	}
+}
+
+func mapBackupEntities(source, target []*BackupEntity, sameGuild bool) map[int64]int64 {
+	result := make(map[int64]int64)
+
+	targetIDs := make(map[int64]bool)
+	for _, v := range target {
+		targetIDs[v.ID] = true
+	}
+
+	for _, s := range source {
+		if sameGuild && targetIDs[s.ID] {
+			result[s.ID] = s.ID
+			continue
+		}
+
+		// prefer an exact match, then a matching type and lastly only a case insensitive name match
+		var found *BackupEntity
+		for _, t := range target {
+			if t.Name == s.Name && t.Type == s.Type {
+				found = t
+				break
+			}
+		}
+
+		if found == nil {
+			for _, t := range target {
+				if strings.EqualFold(t.Name, s.Name) && t.Type == s.Type {
+					found = t
+					break
+				}
+			}
+		}
+
+		if found == nil {
+			for _, t := range target {
+				if strings.EqualFold(t.Name, s.Name) {
+					found = t
+					break
+				}
+			}
+		}
+
+		if found != nil {
+			result[s.ID] = found.ID
+		}
+	}
+
+	return result
+}
+
+// Role returns the id of the role on the target server, or 0 if there is no match
+func (b *BackupRestore) Role(id int64) int64 {
+	if id == 0 {
+		return 0
+	}
+
+	if b.keepIDs {
+		return id
+	}
+
+	if mapped, ok := b.roles[id]; ok {
+		return mapped
+	}
+
+	b.missingRoles[id] = b.entityName(b.sourceRoles, id)
+	return 0
+}
+
+// Roles maps the roles to the target server, dropping the ones that have no match
+func (b *BackupRestore) Roles(ids []int64) []int64 {
+	result := make([]int64, 0, len(ids))
+	for _, v := range ids {
+		if mapped := b.Role(v); mapped != 0 {
+			result = append(result, mapped)
+		}
+	}
+
+	return result
+}
+
+// RoleStr is the same as Role but for ids stored as strings, returning an empty string if there is no match
+func (b *BackupRestore) RoleStr(id string) string {
+	return b.mapStr(id, b.Role)
+}
+
+// Channel returns the id of the channel on the target server, or 0 if there is no match
+func (b *BackupRestore) Channel(id int64) int64 {
+	if id == 0 {
+		return 0
+	}
+
+	if b.keepIDs {
+		return id
+	}
+
+	if mapped, ok := b.channels[id]; ok {
+		return mapped
+	}
+
+	b.missingChannels[id] = b.entityName(b.sourceChannels, id)
+	return 0
+}
+
+// Channels maps the channels to the target server, dropping the ones that have no match
+func (b *BackupRestore) Channels(ids []int64) []int64 {
+	result := make([]int64, 0, len(ids))
+	for _, v := range ids {
+		if mapped := b.Channel(v); mapped != 0 {
+			result = append(result, mapped)
+		}
+	}
+
+	return result
+}
+
+// ChannelStr is the same as Channel but for ids stored as strings, returning an empty string if there is no match
+func (b *BackupRestore) ChannelStr(id string) string {
+	return b.mapStr(id, b.Channel)
+}
+
+func (b *BackupRestore) mapStr(id string, mapper func(int64) int64) string {
+	parsed, _ := strconv.ParseInt(id, 10, 64)
+	if parsed == 0 {
+		return ""
+	}
+
+	mapped := mapper(parsed)
+	if mapped == 0 {
+		return ""
+	}
+
+	return strconv.FormatInt(mapped, 10)
+}
+
+func (b *BackupRestore) entityName(names map[int64]string, id int64) string {
+	if name, ok := names[id]; ok {
+		return name
+	}
+
+	return "unknown (" + strconv.FormatInt(id, 10) + ")"
+}
+
+// Note adds a note to the report of the plugin currently being restored, for things that could not be restored as is
+func (b *BackupRestore) Note(format string, args ...interface{}) {
+	b.notes = append(b.notes, fmt.Sprintf(format, args...))
+}
+
+// Finish commits the transaction, or rolls it back when this is a dry run
+func (b *BackupRestore) Finish(tx *sql.Tx) error {
+	if b.DryRun {
+		return tx.Rollback()
+	}
+
+	return tx.Commit()
+}
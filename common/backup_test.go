@@ -0,0 +1,125 @@
+package common
+
+import (
+	"testing"
+)
+
+func testBackupArchive() *BackupArchive {
+	return &BackupArchive{
+		GuildID: 1,
+		Roles: []*BackupEntity{
+			{ID: 1, Name: "@everyone"},
+			{ID: 10, Name: "Moderator"},
+			{ID: 11, Name: "Member"},
+			{ID: 12, Name: "Gone"},
+		},
+		Channels: []*BackupEntity{
+			{ID: 20, Name: "general", Type: 0},
+			{ID: 21, Name: "General", Type: 2},
+			{ID: 22, Name: "logs", Type: 0},
+		},
+	}
+}
+
+func TestBackupRestoreByName(t *testing.T) {
+	roles := []*BackupEntity{
+		{ID: 100, Name: "@everyone"},
+		{ID: 110, Name: "moderator"},
+		{ID: 111, Name: "Member"},
+	}
+
+	channels := []*BackupEntity{
+		{ID: 121, Name: "general", Type: 2},
+		{ID: 120, Name: "general", Type: 0},
+		{ID: 122, Name: "LOGS", Type: 4},
+	}
+
+	restore := NewBackupRestore(testBackupArchive(), 100, roles, channels, true)
+
+	roleCases := map[int64]int64{
+		1:  100,
+		10: 110,
+		11: 111,
+		12: 0,
+		0:  0,
+	}
+
+	for source, want := range roleCases {
+		if got := restore.Role(source); got != want {
+			t.Errorf("role %d: got %d, wanted %d", source, got, want)
+		}
+	}
+
+	channelCases := map[int64]int64{
+		20: 120,
+		21: 121,
+		22: 122,
+		99: 0,
+	}
+
+	for source, want := range channelCases {
+		if got := restore.Channel(source); got != want {
+			t.Errorf("channel %d: got %d, wanted %d", source, got, want)
+		}
+	}
+
+	if got := restore.Roles([]int64{10, 12, 11}); len(got) != 2 || got[0] != 110 || got[1] != 111 {
+		t.Errorf("incorrect mapped roles: %v", got)
+	}
+
+	if got := restore.ChannelStr("20"); got != "120" {
+		t.Errorf("incorrect mapped channel string: %q", got)
+	}
+
+	if got := restore.RoleStr("12"); got != "" {
+		t.Errorf("missing role string should be empty, got %q", got)
+	}
+
+	missingRoles := sortedNames(restore.missingRoles)
+	if len(missingRoles) != 1 || missingRoles[0] != "Gone" {
+		t.Errorf("incorrect missing roles: %v", missingRoles)
+	}
+
+	missingChannels := sortedNames(restore.missingChannels)
+	if len(missingChannels) != 1 || missingChannels[0] != "unknown (99)" {
+		t.Errorf("incorrect missing channels: %v", missingChannels)
+	}
+}
+
+func TestBackupRestoreSameGuild(t *testing.T) {
+	roles := []*BackupEntity{
+		{ID: 1, Name: "@everyone"},
+		{ID: 10, Name: "Renamed moderator"},
+		{ID: 13, Name: "Member"},
+	}
+
+	restore := NewBackupRestore(testBackupArchive(), 1, roles, nil, false)
+
+	if got := restore.Role(10); got != 10 {
+		t.Errorf("existing role should keep its id, got %d", got)
+	}
+
+	if got := restore.Role(11); got != 13 {
+		t.Errorf("recreated role should be matched by name, got %d", got)
+	}
+
+	if got := restore.Role(1); got != 1 {
+		t.Errorf("everyone role should map to the guild, got %d", got)
+	}
+}
+
+func TestRevertRestoreKeepsIDs(t *testing.T) {
+	restore := NewRevertRestore(1)
+
+	if got := restore.Roles([]int64{10, 11}); len(got) != 2 || got[0] != 10 || got[1] != 11 {
+		t.Errorf("roles should keep their ids, got %v", got)
+	}
+
+	if got := restore.ChannelStr("20"); got != "20" {
+		t.Errorf("channel should keep its id, got %q", got)
+	}
+
+	if got := restore.Channel(0); got != 0 {
+		t.Errorf("unset channel should stay unset, got %d", got)
+	}
+}
@@ -91,15 +91,7 @@ func Init() error {
 		return err
 	}
 
-	db := "yagpdb"
-	if ConfPQDB.GetString() != "" {
-		db = ConfPQDB.GetString()
-	}
-
-	err = connectDB(ConfPQHost.GetString(), ConfPQUsername.GetString(), ConfPQPassword.GetString(), db, confMaxSQLConns.GetInt())
-	if err != nil {
-		panic(err)
-	}
+	connectConfiguredDB()
 
 	logger.Info("Retrieving bot info....")
 	BotUser, err = BotSession.UserMe()
@@ -124,16 +116,65 @@ func Init() error {
 
 	BotApplication = app
 
-	err = RedisPool.Do(radix.Cmd(&CurrentRunCounter, "INCR", "yagpdb_run_counter"))
+	initCore()
+	return err
+}
+
+// InitOffline initializes the rest of the bot without talking to discord, used when replaying captured events.
+// All the requests to the discord api made through BotSession go to transport instead.
+// The configured database is only connected to if one isn't already, so tests can use the one from InitTest.
+func InitOffline(botUser *discordgo.User, transport http.RoundTripper) error {
+	go CacheSet.RunGCLoop()
+
+	var err error
+	BotSession, err = discordgo.New(GetBotToken())
+	if err != nil {
+		return err
+	}
+
+	BotSession.MaxRestRetries = 1
+	BotSession.Client = &http.Client{Transport: transport}
+
+	if PQ == nil {
+		connectConfiguredDB()
+	}
+
+	BotUser = botUser
+	BotSession.State.User = &discordgo.SelfUser{
+		User: BotUser,
+	}
+
+	BotApplication = &discordgo.Application{
+		ID:   botUser.ID,
+		Name: botUser.Username,
+	}
+
+	initCore()
+	return nil
+}
+
+func connectConfiguredDB() {
+	db := "yagpdb"
+	if ConfPQDB.GetString() != "" {
+		db = ConfPQDB.GetString()
+	}
+
+	err := connectDB(ConfPQHost.GetString(), ConfPQUsername.GetString(), ConfPQPassword.GetString(), db, confMaxSQLConns.GetInt())
+	if err != nil {
+		panic(err)
+	}
+}
+
+func initCore() {
+	err := RedisPool.Do(radix.Cmd(&CurrentRunCounter, "INCR", "yagpdb_run_counter"))
 	if err != nil {
 		panic(err)
 	}
 
 	logger.Info("Initializing core schema")
-	InitSchemas("core_configs", CoreServerConfDBSchema, localIDsSchema)
+	InitSchemas("core_configs", CoreServerConfDBSchema, CorePagePermissionsDBSchema, localIDsSchema)
+	InitSchemas("core_api_tokens", APITokensDBSchemas...)
 	initQueuedSchemas()
-
-	return err
 }
 
 func GetBotToken() string {
@@ -160,6 +201,7 @@ func setupGlobalDGoSession() (err error) {
 
 	BotSession.MaxRestRetries = 10
 	BotSession.Ratelimiter.MaxConcurrentRequests = maxCCReqs
+	SetupSharedRatelimits(BotSession)
 
 	innerTransport := &http.Transport{
 		Proxy: http.ProxyFromEnvironment,
@@ -22,4 +22,6 @@ const (
 	ContextKeyMemberPermissions
 	ContextKeyIsAdmin
 	ContextKeyIsReadOnly
+	ContextKeyAllowedPages
+	ContextKeyAPIToken
 )
@@ -3,10 +3,12 @@ package common
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"time"
 
 	"github.com/botlabs-gg/yagpdb/v2/common/models"
 	"github.com/karlseguin/rcache"
+	"github.com/lib/pq"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 )
 
@@ -23,7 +25,20 @@ CREATE TABLE IF NOT EXISTS core_configs (
 
 `
 
+const CorePagePermissionsDBSchema = `
+CREATE TABLE IF NOT EXISTS core_page_permissions (
+	guild_id BIGINT NOT NULL,
+	page TEXT NOT NULL,
+
+	read_roles BIGINT[] NOT NULL DEFAULT '{}',
+	write_roles BIGINT[] NOT NULL DEFAULT '{}',
+
+	PRIMARY KEY(guild_id, page)
+)
+`
+
 var CoreServerConfigCache = rcache.NewInt(coreServerConfigCacheFetcher, time.Minute)
+var PagePermissionsCache = rcache.NewInt(pagePermissionsCacheFetcher, time.Minute)
 
 func GetCoreServerConfCached(guildID int64) *models.CoreConfig {
 	return CoreServerConfigCache.Get(int(guildID)).(*models.CoreConfig)
@@ -63,3 +78,99 @@ func CoreConfigSave(ctx context.Context, m *models.CoreConfig) error {
 
 	return nil
 }
+
+// PagePermission gives roles access to a single control panel page and its sub pages,
+// on top of the global read and write roles in the core config
+type PagePermission struct {
+	Page       string
+	ReadRoles  []int64
+	WriteRoles []int64
+}
+
+// Matches returns true if path is this page or one of its sub pages
+func (p *PagePermission) Matches(path string) bool {
+	return PageMatches(p.Page, path)
+}
+
+// PageMatches returns true if path is the page or one of its sub pages, e.g "customcommands/database" is a sub page of "customcommands"
+func PageMatches(page, path string) bool {
+	page = strings.Trim(page, "/")
+	path = strings.Trim(path, "/")
+	if page == "" {
+		return false
+	}
+
+	return path == page || strings.HasPrefix(path, page+"/")
+}
+
+func GetPagePermissionsCached(guildID int64) []*PagePermission {
+	return PagePermissionsCache.Get(int(guildID)).([]*PagePermission)
+}
+
+func pagePermissionsCacheFetcher(key int) interface{} {
+	perms, err := GetPagePermissions(context.Background(), int64(key))
+	if err != nil {
+		logger.WithError(err).WithField("guild", key).Error("failed fetching page permissions")
+	}
+
+	return perms
+}
+
+func GetPagePermissions(ctx context.Context, guildID int64) ([]*PagePermission, error) {
+	rows, err := PQ.QueryContext(ctx, "SELECT page, read_roles, write_roles FROM core_page_permissions WHERE guild_id = $1 ORDER BY page", guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*PagePermission, 0)
+	for rows.Next() {
+		var readRoles, writeRoles pq.Int64Array
+		perm := &PagePermission{}
+		err = rows.Scan(&perm.Page, &readRoles, &writeRoles)
+		if err != nil {
+			return nil, err
+		}
+
+		perm.ReadRoles = readRoles
+		perm.WriteRoles = writeRoles
+		result = append(result, perm)
+	}
+
+	return result, rows.Err()
+}
+
+// SavePagePermissions replaces all the page permissions of the guild, pages without any roles are not stored
+func SavePagePermissions(ctx context.Context, guildID int64, perms []*PagePermission) error {
+	tx, err := PQ.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM core_page_permissions WHERE guild_id = $1", guildID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for _, v := range perms {
+		if len(v.ReadRoles) < 1 && len(v.WriteRoles) < 1 {
+			continue
+		}
+
+		_, err = tx.ExecContext(ctx, "INSERT INTO core_page_permissions (guild_id, page, read_roles, write_roles) VALUES ($1, $2, $3, $4)",
+			guildID, strings.Trim(v.Page, "/"), pq.Int64Array(v.ReadRoles), pq.Int64Array(v.WriteRoles))
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	PagePermissionsCache.Delete(int(guildID))
+	return nil
+}
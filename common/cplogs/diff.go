@@ -0,0 +1,134 @@
+package cplogs
+
+import "strings"
+
+type DiffKind uint8
+
+const (
+	DiffKindSame    DiffKind = 0
+	DiffKindAdded   DiffKind = 1
+	DiffKindRemoved DiffKind = 2
+)
+
+// maxDiffLines is the max number of lines on either side before falling back to showing everything as removed and added
+const maxDiffLines = 3000
+
+type DiffLine struct {
+	Kind DiffKind
+	Text string
+}
+
+func (d *DiffLine) Added() bool {
+	return d.Kind == DiffKindAdded
+}
+
+func (d *DiffLine) Removed() bool {
+	return d.Kind == DiffKindRemoved
+}
+
+// DiffLines returns a line by line diff between before and after based on their longest common subsequence
+func DiffLines(before, after string) []*DiffLine {
+	a := splitLines(before)
+	b := splitLines(after)
+
+	if len(a) > maxDiffLines || len(b) > maxDiffLines {
+		result := make([]*DiffLine, 0, len(a)+len(b))
+		for _, v := range a {
+			result = append(result, &DiffLine{Kind: DiffKindRemoved, Text: v})
+		}
+		for _, v := range b {
+			result = append(result, &DiffLine{Kind: DiffKindAdded, Text: v})
+		}
+		return result
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	result := make([]*DiffLine, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			result = append(result, &DiffLine{Kind: DiffKindSame, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			result = append(result, &DiffLine{Kind: DiffKindRemoved, Text: a[i]})
+			i++
+		default:
+			result = append(result, &DiffLine{Kind: DiffKindAdded, Text: b[j]})
+			j++
+		}
+	}
+
+	for ; i < len(a); i++ {
+		result = append(result, &DiffLine{Kind: DiffKindRemoved, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		result = append(result, &DiffLine{Kind: DiffKindAdded, Text: b[j]})
+	}
+
+	return result
+}
+
+// ChangedLines returns only the changed lines of the diff with up to context unchanged lines around them,
+// gaps are marked with a nil entry
+func ChangedLines(diff []*DiffLine, context int) []*DiffLine {
+	keep := make([]bool, len(diff))
+	for i, v := range diff {
+		if v.Kind == DiffKindSame {
+			continue
+		}
+
+		for j := i - context; j <= i+context; j++ {
+			if j >= 0 && j < len(diff) {
+				keep[j] = true
+			}
+		}
+	}
+
+	result := make([]*DiffLine, 0)
+	skipped := false
+	for i, v := range diff {
+		if !keep[i] {
+			skipped = true
+			continue
+		}
+
+		if skipped {
+			result = append(result, nil)
+		}
+		skipped = false
+		result = append(result, v)
+	}
+
+	if skipped && len(result) > 0 {
+		result = append(result, nil)
+	}
+
+	return result
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	return strings.Split(s, "\n")
+}
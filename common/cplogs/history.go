@@ -0,0 +1,347 @@
+package cplogs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/sirupsen/logrus"
+)
+
+const ConfigChangesDBSchema = `
+CREATE TABLE IF NOT EXISTS panel_config_changes (
+	guild_id BIGINT NOT NULL,
+	local_id BIGINT NOT NULL,
+
+	source TEXT NOT NULL,
+
+	author_id BIGINT NOT NULL,
+	author_username TEXT NOT NULL,
+
+	config_before TEXT NOT NULL,
+	config_after TEXT NOT NULL,
+
+	reverted_from BIGINT NOT NULL DEFAULT 0,
+
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	PRIMARY KEY(guild_id, local_id)
+)
+`
+
+const (
+	// MaxConfigChanges is the number of config changes kept per guild, older ones are deleted
+	MaxConfigChanges = 250
+
+	// MaxSnapshotSize is the max size of a single config snapshot, changes to bigger configs are only added to the
+	// action log as there's no copy to revert to
+	MaxSnapshotSize = 100000
+)
+
+var panelLogKeySnapshotTooBig = RegisterActionFormat(&ActionFormat{Key: "config_snapshot_too_big", FormatString: "Changed %s, it's too big to be kept in the config history"})
+
+// ConfigSource is a plugin config that has its history tracked when saved through the control panel
+type ConfigSource struct {
+	Key  string
+	Name string
+
+	// Page is the control panel page the config is edited on, relative to the server, e.g "notifications/general"
+	// saves on this page or any of its sub pages are tracked
+	Page string
+
+	// Get returns the current config of the guild, it's stored as json
+	Get func(ctx context.Context, guildID int64) (interface{}, error)
+
+	// Revert saves a previous version of the config, use RestoreSnapshot to load the snapshot on top of the current config
+	Revert func(ctx context.Context, guildID int64, snapshot string) error
+}
+
+var configSources = make(map[string]*ConfigSource)
+
+// RegisterConfigSource sets up history tracking for a plugin config, call this in your plugins InitWeb.
+// Only the configs registered here are tracked, saves on other pages only show up in the action log.
+func RegisterConfigSource(source *ConfigSource) {
+	configSources[source.Key] = source
+}
+
+// BackupConfigSource returns a config source that snapshots the config with the plugins backup export
+// and reverts it by restoring the snapshot as a backup of the same server
+func BackupConfigSource(plugin common.PluginWithBackup, key, name, page string) *ConfigSource {
+	return &ConfigSource{
+		Key:  key,
+		Name: name,
+		Page: page,
+		Get: func(ctx context.Context, guildID int64) (interface{}, error) {
+			return plugin.ExportBackup(ctx, guildID)
+		},
+		Revert: func(ctx context.Context, guildID int64, snapshot string) error {
+			return plugin.RestoreBackup(ctx, guildID, json.RawMessage(snapshot), common.NewRevertRestore(guildID))
+		},
+	}
+}
+
+// GetConfigSource returns the config source by key, or nil if not found
+func GetConfigSource(key string) *ConfigSource {
+	return configSources[key]
+}
+
+// ConfigSourceForPage returns the config source edited on the control panel page, or nil if there is none
+func ConfigSourceForPage(page string) *ConfigSource {
+	var found *ConfigSource
+	for _, v := range configSources {
+		if !common.PageMatches(v.Page, page) {
+			continue
+		}
+
+		// prefer the most specific page
+		if found == nil || len(v.Page) > len(found.Page) {
+			found = v
+		}
+	}
+
+	return found
+}
+
+// ignoredSnapshotKeys are fields that change on every save and would otherwise show up in every diff
+var ignoredSnapshotKeys = []string{"CreatedAt", "UpdatedAt", "created_at", "updated_at"}
+
+// Snapshot returns the current config of the guild as indented json, with the timestamp fields removed
+func (s *ConfigSource) Snapshot(ctx context.Context, guildID int64) (string, error) {
+	conf, err := s.Get(ctx, guildID)
+	if err != nil {
+		return "", err
+	}
+
+	return MarshalSnapshot(conf)
+}
+
+// MarshalSnapshot encodes conf as indented json with sorted keys, so that the same config always produces the same snapshot
+func MarshalSnapshot(conf interface{}) (string, error) {
+	encoded, err := json.Marshal(conf)
+	if err != nil {
+		return "", err
+	}
+
+	var decoded interface{}
+	dec := json.NewDecoder(bytes.NewReader(encoded))
+	dec.UseNumber()
+	if err = dec.Decode(&decoded); err != nil {
+		return "", err
+	}
+
+	if m, ok := decoded.(map[string]interface{}); ok {
+		for _, k := range ignoredSnapshotKeys {
+			delete(m, k)
+		}
+	}
+
+	out, err := json.MarshalIndent(decoded, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+// RestoreSnapshot replaces dest (a pointer to a struct) with the config from the snapshot,
+// the timestamp fields that are left out of snapshots keep their current values
+func RestoreSnapshot(dest interface{}, snapshot string) error {
+	current := reflect.Indirect(reflect.ValueOf(dest))
+
+	// decode into a fresh value since empty fields may have been omitted from the snapshot
+	restored := reflect.New(current.Type())
+	if err := json.Unmarshal([]byte(snapshot), restored.Interface()); err != nil {
+		return err
+	}
+
+	for _, name := range ignoredSnapshotKeys {
+		field := restored.Elem().FieldByName(name)
+		if field.IsValid() && field.CanSet() {
+			field.Set(current.FieldByName(name))
+		}
+	}
+
+	current.Set(restored.Elem())
+	return nil
+}
+
+type ConfigChange struct {
+	GuildID int64 `db:"guild_id"`
+	LocalID int64 `db:"local_id"`
+
+	Source string `db:"source"`
+
+	AuthorID       int64  `db:"author_id"`
+	AuthorUsername string `db:"author_username"`
+
+	Before string `db:"config_before"`
+	After  string `db:"config_after"`
+
+	// RevertedFrom is the change this change restored the config from, if any
+	RevertedFrom int64 `db:"reverted_from"`
+
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// SourceName returns the display name of the config that was changed
+func (c *ConfigChange) SourceName() string {
+	if source := GetConfigSource(c.Source); source != nil {
+		return source.Name
+	}
+
+	return c.Source
+}
+
+// CanRevert returns true if the config this change is for still supports reverting
+func (c *ConfigChange) CanRevert() bool {
+	source := GetConfigSource(c.Source)
+	return source != nil && source.Revert != nil
+}
+
+// Diff returns the changed lines between the config before and after this change, see ChangedLines
+func (c *ConfigChange) Diff() []*DiffLine {
+	return ChangedLines(DiffLines(c.Before, c.After), 3)
+}
+
+// AddConfigChange stores the change, also generating a "LocalID" for it
+func AddConfigChange(change *ConfigChange) error {
+	localID, err := common.GenLocalIncrIDPQ(nil, change.GuildID, "control_panel_config_changes")
+	if err != nil {
+		return err
+	}
+
+	change.LocalID = localID
+
+	const insertStatement = `INSERT INTO panel_config_changes
+	(guild_id, local_id, source, author_id, author_username, config_before, config_after, reverted_from, created_at)
+	VALUES (:guild_id, :local_id, :source, :author_id, :author_username, :config_before, :config_after, :reverted_from, :created_at);`
+
+	_, err = common.SQLX.NamedExec(insertStatement, change)
+	if err != nil {
+		return err
+	}
+
+	if localID > MaxConfigChanges {
+		_, err = common.PQ.Exec("DELETE FROM panel_config_changes WHERE guild_id = $1 AND local_id <= $2", change.GuildID, localID-MaxConfigChanges)
+	}
+
+	return err
+}
+
+// RetryAddConfigChange will retry AddConfigChange until it suceeds or 60 seconds has elapsed
+func RetryAddConfigChange(change *ConfigChange) {
+	started := time.Now()
+	for {
+		err := AddConfigChange(change)
+		if err == nil {
+			return
+		}
+
+		if time.Since(started) > time.Minute {
+			logrus.WithError(err).Errorf("gave up retrying adding config change, source: %s", change.Source)
+			return
+		}
+		logrus.WithError(err).Errorf("failed saving config change, retrying in a second... source: %s", change.Source)
+
+		time.Sleep(time.Second)
+	}
+}
+
+func GetConfigChanges(guildID int64, limit int) ([]*ConfigChange, error) {
+	result := []*ConfigChange{}
+	err := common.SQLX.Select(&result, "SELECT * FROM panel_config_changes WHERE guild_id=$1 ORDER BY local_id DESC LIMIT $2", guildID, limit)
+	return result, err
+}
+
+func GetConfigChange(guildID int64, localID int64) (*ConfigChange, error) {
+	result := &ConfigChange{}
+	err := common.SQLX.Get(result, "SELECT * FROM panel_config_changes WHERE guild_id=$1 AND local_id=$2", guildID, localID)
+	return result, err
+}
+
+// ConfigSourceNames returns the names of all the tracked configs, sorted
+func ConfigSourceNames() []string {
+	result := make([]string, 0, len(configSources))
+	for _, v := range configSources {
+		result = append(result, v.Name)
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+// TrackedChange is used to record a config change around a save, see BeginConfigChange
+type TrackedChange struct {
+	source  *ConfigSource
+	guildID int64
+	before  string
+}
+
+// BeginConfigChange snapshots the config edited on page before it's saved, returns nil if the page has no tracked config
+func BeginConfigChange(ctx context.Context, guildID int64, page string) *TrackedChange {
+	source := ConfigSourceForPage(strings.Trim(page, "/"))
+	if source == nil {
+		return nil
+	}
+
+	return source.Begin(ctx, guildID)
+}
+
+// Begin snapshots the config before it's saved, returns nil if that failed
+func (s *ConfigSource) Begin(ctx context.Context, guildID int64) *TrackedChange {
+	before, err := s.Snapshot(ctx, guildID)
+	if err != nil {
+		logrus.WithError(err).WithField("guild", guildID).Errorf("failed taking config snapshot, source: %s", s.Key)
+		return nil
+	}
+
+	return &TrackedChange{
+		source:  s,
+		guildID: guildID,
+		before:  before,
+	}
+}
+
+// Finish snapshots the config again after it was saved and stores the change in the background if anything changed
+func (t *TrackedChange) Finish(ctx context.Context, authorID int64, authorUsername string, revertedFrom int64) {
+	if t == nil {
+		return
+	}
+
+	after, err := t.source.Snapshot(ctx, t.guildID)
+	if err != nil {
+		logrus.WithError(err).WithField("guild", t.guildID).Errorf("failed taking config snapshot, source: %s", t.source.Key)
+		return
+	}
+
+	if after == t.before {
+		return
+	}
+
+	if len(after) > MaxSnapshotSize || len(t.before) > MaxSnapshotSize {
+		size := len(after)
+		if len(t.before) > size {
+			size = len(t.before)
+		}
+
+		logrus.WithField("guild", t.guildID).Infof("config snapshot too big for the history (%d bytes), source: %s", size, t.source.Key)
+		go RetryAddEntry(NewEntry(t.guildID, authorID, authorUsername, panelLogKeySnapshotTooBig, &Param{Type: ParamTypeString, Value: t.source.Name}))
+		return
+	}
+
+	go RetryAddConfigChange(&ConfigChange{
+		GuildID:        t.guildID,
+		Source:         t.source.Key,
+		AuthorID:       authorID,
+		AuthorUsername: authorUsername,
+		Before:         t.before,
+		After:          after,
+		RevertedFrom:   revertedFrom,
+		CreatedAt:      time.Now(),
+	})
+}
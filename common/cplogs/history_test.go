@@ -0,0 +1,188 @@
+package cplogs
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+)
+
+func TestDiffLines(t *testing.T) {
+	before := "a\nb\nc\nd"
+	after := "a\nc\nd\ne"
+
+	diff := DiffLines(before, after)
+
+	var out []string
+	for _, v := range diff {
+		switch v.Kind {
+		case DiffKindAdded:
+			out = append(out, "+"+v.Text)
+		case DiffKindRemoved:
+			out = append(out, "-"+v.Text)
+		default:
+			out = append(out, " "+v.Text)
+		}
+	}
+
+	got := strings.Join(out, ",")
+	want := " a,-b, c, d,+e"
+	if got != want {
+		t.Errorf("incorrect diff, got %q, wanted %q", got, want)
+	}
+}
+
+func TestChangedLines(t *testing.T) {
+	lines := make([]string, 20)
+	for i := range lines {
+		lines[i] = strings.Repeat("x", i+1)
+	}
+
+	before := strings.Join(lines, "\n")
+	lines[10] = "changed"
+	after := strings.Join(lines, "\n")
+
+	changed := ChangedLines(DiffLines(before, after), 1)
+
+	// 1 context line, the removed and added line, 1 context line, with gaps marked on both sides
+	if len(changed) != 6 {
+		t.Fatalf("incorrect number of lines, got %d, wanted 6", len(changed))
+	}
+
+	if changed[0] != nil || changed[5] != nil {
+		t.Error("gaps not marked")
+	}
+
+	if !changed[2].Removed() || !changed[3].Added() || changed[3].Text != "changed" {
+		t.Errorf("incorrect changed lines: %+v, %+v", changed[2], changed[3])
+	}
+}
+
+type testModel struct {
+	GuildID   int64
+	CreatedAt time.Time
+}
+
+type testConfig struct {
+	testModel
+	Name  string   `json:"name"`
+	Roles []int64  `json:"roles,omitempty"`
+	Tags  []string `json:"tags"`
+}
+
+func TestSnapshotRoundtrip(t *testing.T) {
+	created := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	conf := &testConfig{Name: "old", Roles: []int64{1234567890123456789}}
+	conf.GuildID = 1
+	conf.CreatedAt = created
+
+	snapshot, err := MarshalSnapshot(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(snapshot, "CreatedAt") {
+		t.Error("snapshot contains timestamp field")
+	}
+
+	if !strings.Contains(snapshot, "1234567890123456789") {
+		t.Error("snapshot lost precision on ids")
+	}
+
+	current := &testConfig{Name: "new", Roles: []int64{5}, Tags: []string{"a"}}
+	current.CreatedAt = created.Add(time.Hour)
+	if err := RestoreSnapshot(current, snapshot); err != nil {
+		t.Fatal(err)
+	}
+
+	if current.Name != "old" || len(current.Roles) != 1 || current.Roles[0] != 1234567890123456789 || len(current.Tags) != 0 {
+		t.Errorf("incorrect restored config: %+v", current)
+	}
+
+	if !current.CreatedAt.Equal(created.Add(time.Hour)) {
+		t.Error("timestamp was not kept")
+	}
+
+	conf.Roles = nil
+	snapshot, _ = MarshalSnapshot(conf)
+	if err := RestoreSnapshot(current, snapshot); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(current.Roles) != 0 {
+		t.Errorf("omitted field was not cleared: %v", current.Roles)
+	}
+}
+
+func TestConfigSourceForPage(t *testing.T) {
+	old := configSources
+	defer func() { configSources = old }()
+
+	configSources = make(map[string]*ConfigSource)
+	RegisterConfigSource(&ConfigSource{Key: "a", Page: "notifications"})
+	RegisterConfigSource(&ConfigSource{Key: "b", Page: "notifications/general"})
+
+	cases := map[string]string{
+		"notifications/general":       "b",
+		"notifications/general/stuff": "b",
+		"notifications/other":         "a",
+		"notificationsfoo":            "",
+		"automod":                     "",
+	}
+
+	for page, want := range cases {
+		got := ""
+		if source := ConfigSourceForPage(page); source != nil {
+			got = source.Key
+		}
+
+		if got != want {
+			t.Errorf("%s: got %q, wanted %q", page, got, want)
+		}
+	}
+}
+
+type fakeBackupPlugin struct {
+	conf     map[string]string
+	restored string
+	keepIDs  bool
+}
+
+func (p *fakeBackupPlugin) PluginInfo() *common.PluginInfo {
+	return &common.PluginInfo{Name: "Fake", SysName: "fake", Category: common.PluginCategoryMisc}
+}
+
+func (p *fakeBackupPlugin) BackupVersion() int { return 1 }
+
+func (p *fakeBackupPlugin) ExportBackup(ctx context.Context, guildID int64) (interface{}, error) {
+	return p.conf, nil
+}
+
+func (p *fakeBackupPlugin) RestoreBackup(ctx context.Context, guildID int64, data json.RawMessage, restore *common.BackupRestore) error {
+	p.restored = string(data)
+	p.keepIDs = restore.Role(123) == 123 && restore.SourceGuildID == guildID
+	return nil
+}
+
+func TestBackupConfigSource(t *testing.T) {
+	plugin := &fakeBackupPlugin{conf: map[string]string{"a": "b"}}
+	source := BackupConfigSource(plugin, "fake", "Fake", "fake")
+
+	snapshot, err := source.Snapshot(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = source.Revert(context.Background(), 1, snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if plugin.restored != snapshot || !plugin.keepIDs {
+		t.Errorf("unexpected restore: %q, kept ids: %t", plugin.restored, plugin.keepIDs)
+	}
+}
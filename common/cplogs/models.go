@@ -32,7 +32,7 @@ CREATE TABLE IF NOT EXISTS panel_logs (
 `
 
 func init() {
-	common.RegisterDBSchemas("cplogs", DBSchema)
+	common.RegisterDBSchemas("cplogs", DBSchema, ConfigChangesDBSchema)
 }
 
 type rawLogEntry struct {
@@ -16,8 +16,19 @@ var _ backgroundworkers.BackgroundWorkerPlugin = (*Plugin)(nil)
 // RunBackgroundWorker implements backgroundworkers.BackgroundWorkerPlugin
 func (p *Plugin) RunBackgroundWorker() {
 	t := time.NewTicker(time.Second * 5)
-	for range t.C {
-		p.updateMetrcis()
+	cleanupTicker := time.NewTicker(time.Hour)
+	for {
+		select {
+		case <-t.C:
+			p.updateMetrcis()
+		case <-cleanupTicker.C:
+			n, err := cleanSentMessages()
+			if err != nil {
+				logger.WithError(err).Error("failed cleaning up tracked mqueue messages")
+			} else if n > 0 {
+				logger.Infof("Stopped tracking %d old mqueue messages", n)
+			}
+		}
 	}
 }
 
@@ -28,15 +39,32 @@ func (p *Plugin) StopBackgroundWorker(wg *sync.WaitGroup) {
 
 func (p *Plugin) updateMetrcis() {
 	var n int64
-	err := common.RedisPool.Do(radix.Cmd(&n, "ZCARD", "mqueue"))
+	var err error
+	if usePostgresBackend() {
+		err = common.PQ.QueryRow("SELECT count(*) FROM mqueue_items").Scan(&n)
+	} else {
+		err = common.RedisPool.Do(radix.Cmd(&n, "ZCARD", "mqueue"))
+	}
 	if err != nil {
 		logger.WithError(err).Error("failed updating mqueue metrics")
 	}
 
 	metricsQueueSize.Set(float64(n))
+
+	dead, err := CountDeadItems()
+	if err != nil {
+		logger.WithError(err).Error("failed updating mqueue dead items metrics")
+	}
+
+	metricsDeadItems.Set(float64(dead))
 }
 
 var metricsQueueSize = promauto.NewGauge(prometheus.GaugeOpts{
 	Name: "yagpdb_mqueue_size_total",
 	Help: "The size of the send message queue",
 })
+
+var metricsDeadItems = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "yagpdb_mqueue_dead_items",
+	Help: "The number of messages that failed too many times to be sent",
+})
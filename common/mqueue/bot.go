@@ -38,6 +38,24 @@ func (p *Plugin) LateBotInit() {
 		pool: common.RedisPool,
 	}
 
+	if usePostgresBackend() {
+		pgBackend := NewPostgresBackend(common.PQ)
+
+		server := NewServer(pgBackend, &DiscordProcessor{})
+		pgPoller := PostgresPushServer{
+			backend:     pgBackend,
+			pushwork:    server.PushWork,
+			fullRefresh: server.refreshWork,
+		}
+		go server.Run()
+		go pgPoller.run()
+		go moveRedisQueue(redisBackend, pgBackend)
+		p.server = server
+
+		logger.Info("Started mqueue server with postgres storage")
+		return
+	}
+
 	server := NewServer(redisBackend, &DiscordProcessor{})
 	redisPubsub := RedisPushServer{
 		pushwork:    server.PushWork,
@@ -19,44 +19,50 @@ func (d *DiscordProcessor) ProcessItem(resp chan *workResult, wi *workItem) {
 	metricsProcessed.With(prometheus.Labels{"source": wi.Elem.Source}).Inc()
 
 	retry := false
+	var err error
 	defer func() {
 		resp <- &workResult{
 			item:  wi,
 			retry: retry,
+			err:   err,
 		}
 	}()
 
 	queueLogger := logger.WithField("mq_id", wi.Elem.ID)
 
-	var err error
-	if wi.Elem.UseWebhook {
+	switch {
+	case wi.Elem.Action == ActionEdit || wi.Elem.Action == ActionDelete:
+		err = tryEditDelete(queueLogger, wi.Elem)
+	case wi.Elem.UseWebhook:
 		err = trySendWebhook(queueLogger, wi.Elem)
-	} else {
+	default:
 		err = trySendNormal(queueLogger, wi.Elem)
 	}
 
-	if err == nil {
+	if err == nil || errors.Is(err, errFileTooLarge) {
 		return
 	}
 
 	if e, ok := errors.Cause(err).(*discordgo.RESTError); ok {
 		if (e.Response != nil && e.Response.StatusCode >= 400 && e.Response.StatusCode < 500) || (e.Message != nil && e.Message.Code != 0) {
-			if source, ok := sources[wi.Elem.Source]; ok {
-				maybeDisableFeed(source, wi.Elem, e)
+			if source, ok := sources[wi.Elem.Source]; ok && maybeDisableFeed(source, wi.Elem, e) {
+				// the feed was disabled, so there's no point in keeping the item around
+				err = nil
 			}
 
 			return
 		}
 	} else {
-		if onGuild, err := common.BotIsOnGuild(wi.Elem.GuildID); !onGuild && err == nil {
+		if onGuild, guildErr := common.BotIsOnGuild(wi.Elem.GuildID); !onGuild && guildErr == nil {
 			if source, ok := sources[wi.Elem.Source]; ok {
 				logger.WithError(err).Warnf("disabling feed item %s from %s to nonexistant guild", wi.Elem.SourceItemID, wi.Elem.Source)
 				source.DisableFeed(wi.Elem, err)
 			}
 
+			err = nil
 			return
-		} else if err != nil {
-			logger.WithError(err).Error("failed checking if bot is on guild")
+		} else if guildErr != nil {
+			logger.WithError(guildErr).Error("failed checking if bot is on guild")
 		}
 	}
 
@@ -78,7 +84,8 @@ var disableOnError = []int{
 	220001, // webhook points to a forum channel
 }
 
-func maybeDisableFeed(source PluginWithSourceDisabler, elem *QueuedElement, err *discordgo.RESTError) {
+// maybeDisableFeed disables the feed if the error means it can't ever be sent to, returns true if it was disabled
+func maybeDisableFeed(source PluginWithSourceDisabler, elem *QueuedElement, err *discordgo.RESTError) bool {
 	// source.HandleMQueueError(elem, errors.Cause(err))
 	if err.Message == nil || !common.ContainsIntSlice(disableOnError, err.Message.Code) {
 		// don't disable
@@ -89,35 +96,62 @@ func maybeDisableFeed(source PluginWithSourceDisabler, elem *QueuedElement, err
 		}
 
 		l.Error("error sending mqueue message")
-		return
+		return false
 	}
 
 	logger.WithError(err).Warnf("disabling feed item %s from %s", elem.SourceItemID, elem.Source)
 	source.DisableFeed(elem, err)
+	return true
 }
 
 func trySendNormal(l *logrus.Entry, elem *QueuedElement) (err error) {
-	if elem.MessageStr == "" && elem.MessageEmbed == nil {
+	if !elem.HasContent() {
 		l.Error("Both MessageEmbed and MessageStr empty")
 		return
 	}
 
-	var msg = &discordgo.MessageSend{}
+	var msg = &discordgo.MessageSend{
+		Embeds:     elem.AllEmbeds(),
+		Components: elem.MessageComponents(),
+	}
 	if elem.MessageStr != "" {
 		msg.Content = elem.MessageStr
 		msg.AllowedMentions = elem.AllowedMentions
 	}
-	if elem.MessageEmbed != nil {
-		msg.Embeds = []*discordgo.MessageEmbed{elem.MessageEmbed}
+	if elem.ReplyTo != 0 {
+		msg.Reference = &discordgo.MessageReference{
+			MessageID: elem.ReplyTo,
+			ChannelID: elem.targetChannel(),
+			GuildID:   elem.GuildID,
+		}
+	}
+	if len(elem.Files) > 0 {
+		msg.Files, err = downloadFiles(elem)
+		if err != nil {
+			return
+		}
 	}
-	m, err := common.BotSession.ChannelMessageSendComplex(elem.ChannelID, msg)
+
+	m, err := common.BotSession.ChannelMessageSendComplex(elem.targetChannel(), msg)
 	if err != nil {
 		logrus.WithError(err).Error("Failed sending mqueue message")
 		return
 	}
 
+	if elem.TrackMessage {
+		if err := trackSentMessage(elem, m, 0); err != nil {
+			l.WithError(err).Error("Failed tracking sent mqueue message")
+		}
+	}
+
+	if source, ok := sources[elem.Source]; ok {
+		if cb, ok := source.(PluginWithMessageSentCallback); ok {
+			cb.MessageSent(elem, m)
+		}
+	}
+
 	if elem.PublishAnnouncement {
-		_, err = common.BotSession.ChannelMessageCrosspost(elem.ChannelID, m.ID)
+		_, err = common.BotSession.ChannelMessageCrosspost(elem.targetChannel(), m.ID)
 	}
 	return
 }
@@ -125,7 +159,7 @@ func trySendNormal(l *logrus.Entry, elem *QueuedElement) (err error) {
 var errGuildNotFound = errors.New("Guild not found")
 
 func trySendWebhook(l *logrus.Entry, elem *QueuedElement) (err error) {
-	if elem.MessageStr == "" && elem.MessageEmbed == nil {
+	if !elem.HasContent() {
 		l.Error("Both MessageEmbed and MessageStr empty")
 		return
 	}
@@ -160,13 +194,25 @@ func trySendWebhook(l *logrus.Entry, elem *QueuedElement) (err error) {
 		Username:        elem.WebhookUsername,
 		Content:         elem.MessageStr,
 		AllowedMentions: &discordgo.AllowedMentions{},
+		Embeds:          elem.AllEmbeds(),
+		Components:      elem.MessageComponents(),
+		ThreadID:        elem.ThreadID,
 	}
 
-	if elem.MessageEmbed != nil {
-		webhookParams.Embeds = []*discordgo.MessageEmbed{elem.MessageEmbed}
+	if len(elem.Files) > 0 {
+		webhookParams.Files, err = downloadFiles(elem)
+		if err != nil {
+			return
+		}
+	}
+
+	m, err := webhookSession.WebhookExecuteComplex(wh.ID, wh.Token, true, webhookParams)
+	if err == nil && elem.TrackMessage {
+		if err := trackSentMessage(elem, m, wh.ID); err != nil {
+			l.WithError(err).Error("Failed tracking sent mqueue message")
+		}
 	}
 
-	err = webhookSession.WebhookExecute(wh.ID, wh.Token, true, webhookParams)
 	if code, _ := common.DiscordError(err); code == discordgo.ErrCodeUnknownWebhook {
 		// if the webhook was deleted, then delete the bad boi from the databse and retry
 		const query = `DELETE FROM mqueue_webhooks WHERE id=$1`
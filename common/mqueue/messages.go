@@ -0,0 +1,201 @@
+package mqueue
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// MaxQueuedFileSize is the max size of a single attached file
+	MaxQueuedFileSize = 8 << 20
+
+	// sent messages are tracked for this long
+	sentMessageTTL = time.Hour * 24 * 30
+)
+
+var (
+	errFileTooLarge = errors.New("attached file too large")
+
+	fileHTTPClient = &http.Client{
+		Timeout: time.Second * 30,
+	}
+)
+
+// downloadFiles fetches the attached files of the element
+func downloadFiles(elem *QueuedElement) ([]*discordgo.File, error) {
+	files := make([]*discordgo.File, 0, len(elem.Files))
+	for _, v := range elem.Files {
+		data, err := downloadFile(v.URL)
+		if err != nil {
+			return nil, errors.WrapIf(err, "download "+v.Name)
+		}
+
+		files = append(files, &discordgo.File{
+			Name:        v.Name,
+			ContentType: v.ContentType,
+			Reader:      bytes.NewReader(data),
+		})
+	}
+
+	return files, nil
+}
+
+func downloadFile(url string) ([]byte, error) {
+	resp, err := fileHTTPClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bad status code: %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, MaxQueuedFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > MaxQueuedFileSize {
+		return nil, errFileTooLarge
+	}
+
+	return data, nil
+}
+
+// sentMessage is a message sent from a queued element with TrackMessage set
+type sentMessage struct {
+	ChannelID int64
+	ThreadID  int64
+	MessageID int64
+	WebhookID int64
+}
+
+func trackSentMessage(elem *QueuedElement, msg *discordgo.Message, webhookID int64) error {
+	const q = `INSERT INTO mqueue_sent_messages (source, source_item_id, guild_id, channel_id, thread_id, message_id, webhook_id, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, now())
+ON CONFLICT (source, source_item_id) DO UPDATE
+SET channel_id = $4, thread_id = $5, message_id = $6, webhook_id = $7, created_at = now()`
+
+	_, err := common.PQ.Exec(q, elem.Source, elem.SourceItemID, elem.GuildID, elem.ChannelID, elem.ThreadID, msg.ID, webhookID)
+	return err
+}
+
+func getSentMessage(source, sourceItemID string) (*sentMessage, error) {
+	const q = `SELECT channel_id, thread_id, message_id, webhook_id FROM mqueue_sent_messages WHERE source = $1 AND source_item_id = $2`
+
+	var msg sentMessage
+	err := common.PQ.QueryRow(q, source, sourceItemID).Scan(&msg.ChannelID, &msg.ThreadID, &msg.MessageID, &msg.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+func deleteSentMessage(source, sourceItemID string) error {
+	_, err := common.PQ.Exec(`DELETE FROM mqueue_sent_messages WHERE source = $1 AND source_item_id = $2`, source, sourceItemID)
+	return err
+}
+
+// cleanSentMessages stops tracking old sent messages
+func cleanSentMessages() (int64, error) {
+	res, err := common.PQ.Exec(`DELETE FROM mqueue_sent_messages WHERE created_at < $1`, time.Now().Add(-sentMessageTTL))
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func getWebhookByID(id int64) (*webhook, error) {
+	const query = `SELECT id, guild_id, channel_id, token, plugin FROM mqueue_webhooks WHERE id=$1`
+
+	var hook webhook
+	err := common.PQ.QueryRow(query, id).Scan(&hook.ID, &hook.GuildID, &hook.ChannelID, &hook.Token, &hook.Plugin)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hook, nil
+}
+
+// tryEditDelete edits or deletes the message sent earlier for the same source item
+func tryEditDelete(l *logrus.Entry, elem *QueuedElement) error {
+	sent, err := getSentMessage(elem.Source, elem.SourceItemID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			l.Warnf("No tracked message to edit or delete for %s from %s", elem.SourceItemID, elem.Source)
+			return nil
+		}
+
+		return err
+	}
+
+	msgChannel := sent.ChannelID
+	if sent.ThreadID != 0 {
+		msgChannel = sent.ThreadID
+	}
+
+	var hook *webhook
+	if sent.WebhookID != 0 {
+		hook, err = getWebhookByID(sent.WebhookID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				// the webhook is gone and so is the message
+				return deleteSentMessage(elem.Source, elem.SourceItemID)
+			}
+
+			return err
+		}
+	}
+
+	if elem.Action == ActionDelete {
+		if hook != nil {
+			err = webhookSession.WebhookMessageDelete(hook.ID, hook.Token, sent.MessageID, sent.ThreadID)
+		} else {
+			err = common.BotSession.ChannelMessageDelete(msgChannel, sent.MessageID)
+		}
+
+		if err != nil && !common.IsDiscordErr(err, discordgo.ErrCodeUnknownMessage) {
+			return err
+		}
+
+		return deleteSentMessage(elem.Source, elem.SourceItemID)
+	}
+
+	// the components are replaced, the content and embeds are only replaced if they're set
+	if hook != nil {
+		_, err = webhookSession.WebhookMessageEdit(hook.ID, hook.Token, sent.MessageID, sent.ThreadID, &discordgo.WebhookParams{
+			Content:         elem.MessageStr,
+			Embeds:          elem.AllEmbeds(),
+			Components:      elem.MessageComponents(),
+			AllowedMentions: &discordgo.AllowedMentions{},
+		})
+	} else {
+		edit := discordgo.NewMessageEdit(msgChannel, sent.MessageID)
+		if elem.MessageStr != "" {
+			edit.SetContent(elem.MessageStr)
+		}
+		edit.Embeds = elem.AllEmbeds()
+		edit.Components = elem.MessageComponents()
+		edit.AllowedMentions = elem.AllowedMentions
+		_, err = common.BotSession.ChannelMessageEditComplex(edit)
+	}
+
+	if common.IsDiscordErr(err, discordgo.ErrCodeUnknownMessage) {
+		// deleted by someone else
+		return deleteSentMessage(elem.Source, elem.SourceItemID)
+	}
+
+	return err
+}
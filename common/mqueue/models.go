@@ -27,6 +27,28 @@ type QueuedElement struct {
 	// The actual message as an embed
 	MessageEmbed *discordgo.MessageEmbed `json:",omitempty"`
 
+	// Additional embeds, sent after MessageEmbed
+	MessageEmbeds []*discordgo.MessageEmbed `json:",omitempty"`
+
+	// Buttons and select menus, only sent with the bot account or with webhooks owned by the bot
+	Components []discordgo.ActionsRow `json:",omitempty"`
+
+	// Files to attach, they're downloaded when the message is sent
+	Files []*QueuedFile `json:",omitempty"`
+
+	// Reply to this message in the channel, not supported with webhooks
+	ReplyTo int64 `json:",omitempty"`
+
+	// Send the message in this thread of the channel instead
+	ThreadID int64 `json:",omitempty"`
+
+	// What to do with the message, by default a new message is sent, edits and deletes target the message
+	// previously sent for the same Source and SourceItemID
+	Action QueuedAction `json:",omitempty"`
+
+	// Keep track of the sent message so it can be edited or deleted later with an edit or delete action
+	TrackMessage bool `json:",omitempty"`
+
 	UseWebhook      bool
 	WebhookUsername string
 
@@ -41,6 +63,56 @@ type QueuedElement struct {
 	CreatedAt time.Time
 }
 
+// QueuedAction is what to do with the message of a queued element
+type QueuedAction int
+
+const (
+	ActionSend QueuedAction = iota
+	ActionEdit
+	ActionDelete
+)
+
+// QueuedFile is a file attached to a queued message, stored by reference so that the queue stays small
+type QueuedFile struct {
+	Name        string
+	ContentType string `json:",omitempty"`
+	URL         string
+}
+
+// HasContent returns true if there's anything to send
+func (elem *QueuedElement) HasContent() bool {
+	return elem.MessageStr != "" || elem.MessageEmbed != nil || len(elem.MessageEmbeds) > 0 || len(elem.Files) > 0
+}
+
+// AllEmbeds returns MessageEmbed followed by MessageEmbeds
+func (elem *QueuedElement) AllEmbeds() []*discordgo.MessageEmbed {
+	var embeds []*discordgo.MessageEmbed
+	if elem.MessageEmbed != nil {
+		embeds = append(embeds, elem.MessageEmbed)
+	}
+
+	return append(embeds, elem.MessageEmbeds...)
+}
+
+// MessageComponents returns the components in the form used by discordgo
+func (elem *QueuedElement) MessageComponents() []discordgo.MessageComponent {
+	components := make([]discordgo.MessageComponent, 0, len(elem.Components))
+	for _, v := range elem.Components {
+		components = append(components, v)
+	}
+
+	return components
+}
+
+// targetChannel returns the channel the message is sent in
+func (elem *QueuedElement) targetChannel() int64 {
+	if elem.ThreadID != 0 {
+		return elem.ThreadID
+	}
+
+	return elem.ChannelID
+}
+
 type webhook struct {
 	ID    int64
 	Token string
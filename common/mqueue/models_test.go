@@ -0,0 +1,50 @@
+package mqueue
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+)
+
+func TestQueuedElementComponentsRoundtrip(t *testing.T) {
+	elem := &QueuedElement{
+		MessageEmbed:  &discordgo.MessageEmbed{Title: "first"},
+		MessageEmbeds: []*discordgo.MessageEmbed{{Title: "second"}},
+		Components: []discordgo.ActionsRow{{Components: []discordgo.MessageComponent{
+			discordgo.Button{Label: "Snooze", Style: discordgo.SecondaryButton, CustomID: "snooze"},
+		}}},
+		Action: ActionEdit,
+	}
+
+	serialized, err := json.Marshal(elem)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var dec QueuedElement
+	err = json.Unmarshal(serialized, &dec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dec.Action != ActionEdit {
+		t.Errorf("incorrect action: %d", dec.Action)
+	}
+
+	embeds := dec.AllEmbeds()
+	if len(embeds) != 2 || embeds[0].Title != "first" || embeds[1].Title != "second" {
+		t.Errorf("incorrect embeds: %#v", embeds)
+	}
+
+	components := dec.MessageComponents()
+	if len(components) != 1 {
+		t.Fatalf("incorrect components: %#v", components)
+	}
+
+	row := components[0].(discordgo.ActionsRow)
+	button, ok := row.Components[0].(*discordgo.Button)
+	if !ok || button.CustomID != "snooze" {
+		t.Errorf("incorrect button: %#v", row.Components[0])
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/botlabs-gg/yagpdb/v2/bot"
 	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/config"
 	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
 	"github.com/sirupsen/logrus"
 )
@@ -27,6 +28,12 @@ type PluginWithWebhookAvatar interface {
 	WebhookAvatar() string
 }
 
+// PluginWithMessageSentCallback can be implemented by plugins that need to know the message that was sent,
+// for example to edit it later on. Only called for messages not sent through webhooks.
+type PluginWithMessageSentCallback interface {
+	MessageSent(elem *QueuedElement, msg *discordgo.Message)
+}
+
 var (
 	_ bot.LateBotInitHandler = (*Plugin)(nil)
 	_ bot.BotStopperHandler  = (*Plugin)(nil)
@@ -91,3 +98,30 @@ type Storage interface {
 	DelItem(elem *workItem) error
 	NextID() (int64, error)
 }
+
+// StorageWithDeadLetters is implemented by storages that back off failing items and keep the ones that failed for good
+type StorageWithDeadLetters interface {
+	Storage
+
+	// RetryItem schedules the next attempt of the item, moving it to the dead letters if it failed too many times
+	RetryItem(elem *workItem, err error) error
+
+	// KillItem moves the item to the dead letters
+	KillItem(elem *workItem, err error) error
+}
+
+var confBackend = config.RegisterOption("yagpdb.mqueue.backend", "Where to store the message queue, redis or postgres", "redis")
+
+// usePostgresBackend returns true if the queue is stored in postgres
+func usePostgresBackend() bool {
+	return confBackend.GetString() == "postgres"
+}
+
+// configuredBackend returns the storage set in the config
+func configuredBackend() Storage {
+	if usePostgresBackend() {
+		return NewPostgresBackend(common.PQ)
+	}
+
+	return NewRedisBackend(common.RedisPool)
+}
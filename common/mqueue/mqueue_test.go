@@ -143,3 +143,21 @@ func (f *FakeProcessor) ProcessItem(resp chan *workResult, wi *workItem) {
 		retry: f.retry,
 	}
 }
+
+func TestRetryBackoff(t *testing.T) {
+	cases := map[int]time.Duration{
+		0:  0,
+		1:  time.Second * 10,
+		2:  time.Second * 20,
+		4:  time.Second * 80,
+		9:  time.Minute*42 + time.Second*40,
+		10: time.Hour,
+		50: time.Hour,
+	}
+
+	for attempts, want := range cases {
+		if got := retryBackoff(attempts); got != want {
+			t.Errorf("%d attempts: got %s, wanted %s", attempts, got, want)
+		}
+	}
+}
@@ -0,0 +1,364 @@
+package mqueue
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/lib/pq"
+	"github.com/mediocregopher/radix/v3"
+)
+
+const (
+	// MaxItemAttempts is the number of times a item is tried before it's moved to the dead letters
+	MaxItemAttempts = 12
+
+	retryBaseDelay = time.Second * 10
+	retryMaxDelay  = time.Hour
+)
+
+var _ StorageWithDeadLetters = (*PostgresBackend)(nil)
+
+// PostgresBackend stores the queue in postgres, items are only removed once they have been sent so they survive restarts
+// of both the bot and redis. Failed items are retried with an exponential backoff and then moved to the dead letters.
+type PostgresBackend struct {
+	db *sql.DB
+}
+
+func NewPostgresBackend(db *sql.DB) *PostgresBackend {
+	return &PostgresBackend{
+		db: db,
+	}
+}
+
+// retryBackoff returns how long to wait before the next attempt after the item failed attempts times
+func retryBackoff(attempts int) time.Duration {
+	if attempts < 1 {
+		return 0
+	}
+
+	delay := retryBaseDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= retryMaxDelay {
+			return retryMaxDelay
+		}
+	}
+
+	return delay
+}
+
+func scanWorkItems(rows *sql.Rows) ([]*workItem, error) {
+	defer rows.Close()
+
+	var result []*workItem
+	for rows.Next() {
+		var raw []byte
+		err := rows.Scan(&raw)
+		if err != nil {
+			return nil, err
+		}
+
+		var dec QueuedElement
+		err = json.Unmarshal(raw, &dec)
+		if err != nil {
+			logger.WithError(err).Error("Failed decoding queued mqueue element from postgres")
+			continue
+		}
+
+		result = append(result, &workItem{
+			Elem: &dec,
+			Raw:  raw,
+		})
+	}
+
+	return result, rows.Err()
+}
+
+// GetFullQueue returns all the items that are due
+func (pb *PostgresBackend) GetFullQueue() ([]*workItem, error) {
+	rows, err := pb.db.Query(`SELECT data FROM mqueue_items WHERE next_attempt_at <= now() ORDER BY priority DESC, id ASC`)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanWorkItems(rows)
+}
+
+// pollNew returns the items added after afterID and the retries that became due after since
+func (pb *PostgresBackend) pollNew(afterID int64, since time.Time) ([]*workItem, error) {
+	const q = `SELECT data FROM mqueue_items
+WHERE id > $1 OR (attempts > 0 AND next_attempt_at > $2 AND next_attempt_at <= now())
+ORDER BY id ASC`
+
+	rows, err := pb.db.Query(q, afterID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanWorkItems(rows)
+}
+
+func (pb *PostgresBackend) AppendItem(elem *QueuedElement) error {
+	serialized, err := json.Marshal(elem)
+	if err != nil {
+		return err
+	}
+
+	const q = `INSERT INTO mqueue_items (id, guild_id, channel_id, source, priority, data, attempts, next_attempt_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, 0, now(), $7)`
+
+	_, err = pb.db.Exec(q, elem.ID, elem.GuildID, elem.ChannelID, elem.Source, elem.Priority, serialized, elem.CreatedAt)
+	return err
+}
+
+func (pb *PostgresBackend) DelItem(item *workItem) error {
+	_, err := pb.db.Exec(`DELETE FROM mqueue_items WHERE id = $1`, item.Elem.ID)
+	return err
+}
+
+func (pb *PostgresBackend) NextID() (next int64, err error) {
+	err = pb.db.QueryRow(`SELECT nextval('mqueue_items_id_seq')`).Scan(&next)
+	return
+}
+
+// RetryItem schedules the next attempt of the item, or moves it to the dead letters if it has been tried too many times
+func (pb *PostgresBackend) RetryItem(item *workItem, itemErr error) error {
+	var attempts int
+	err := pb.db.QueryRow(`UPDATE mqueue_items SET attempts = attempts + 1, last_error = $2 WHERE id = $1 RETURNING attempts`,
+		item.Elem.ID, errorString(itemErr)).Scan(&attempts)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			// already removed
+			return nil
+		}
+
+		return err
+	}
+
+	if attempts >= MaxItemAttempts {
+		return pb.KillItem(item, itemErr)
+	}
+
+	_, err = pb.db.Exec(`UPDATE mqueue_items SET next_attempt_at = now() + $2 * interval '1 millisecond' WHERE id = $1`,
+		item.Elem.ID, retryBackoff(attempts).Milliseconds())
+	return err
+}
+
+// KillItem moves the item to the dead letters
+func (pb *PostgresBackend) KillItem(item *workItem, itemErr error) error {
+	tx, err := pb.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	const q = `INSERT INTO mqueue_dead_items (id, guild_id, channel_id, source, source_item_id, data, attempts, last_error, created_at, died_at)
+SELECT id, guild_id, channel_id, source, $2, data, attempts, $3, created_at, now() FROM mqueue_items WHERE id = $1
+ON CONFLICT (id) DO NOTHING`
+
+	_, err = tx.Exec(q, item.Elem.ID, item.Elem.SourceItemID, errorString(itemErr))
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM mqueue_items WHERE id = $1`, item.Elem.ID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return err.Error()
+}
+
+// PostgresPushServer polls postgres for new items and retries that became due
+type PostgresPushServer struct {
+	backend     *PostgresBackend
+	pushwork    chan *workItem
+	fullRefresh chan bool
+}
+
+func (pp *PostgresPushServer) run() {
+	var lastID int64
+	err := pp.backend.db.QueryRow(`SELECT COALESCE(max(id), 0) FROM mqueue_items`).Scan(&lastID)
+	if err != nil {
+		logger.WithError(err).Error("failed retrieving the last mqueue id")
+	}
+
+	pp.fullRefresh <- true
+
+	lastPoll := time.Now()
+	pollTicker := time.NewTicker(time.Second * 2)
+
+	// ids are allocated before the items are inserted so one could be committed after a later one we already saw,
+	// do a full refresh once in a while to pick those up
+	refreshTicker := time.NewTicker(time.Minute * 5)
+
+	for {
+		select {
+		case <-refreshTicker.C:
+			pp.fullRefresh <- true
+		case <-pollTicker.C:
+			now := time.Now()
+			items, err := pp.backend.pollNew(lastID, lastPoll.Add(-time.Second))
+			if err != nil {
+				logger.WithError(err).Error("failed polling postgres mqueue")
+				continue
+			}
+
+			lastPoll = now
+			for _, v := range items {
+				if v.Elem.ID > lastID {
+					lastID = v.Elem.ID
+				}
+
+				pp.pushwork <- v
+			}
+		}
+	}
+}
+
+// moveRedisQueue moves the items left in the redis queue to postgres, for when switching the backend
+func moveRedisQueue(from *RedisBackend, to *PostgresBackend) {
+	items, err := from.GetFullQueue()
+	if err != nil {
+		logger.WithError(err).Error("failed retrieving the redis mqueue")
+		return
+	}
+
+	moved := 0
+	for _, v := range items {
+		// only the process that removed the item moves it
+		var removed int
+		err = from.pool.Do(radix.Cmd(&removed, "ZREM", "mqueue", string(v.Raw)))
+		if err != nil || removed < 1 {
+			continue
+		}
+
+		v.Elem.ID, err = to.NextID()
+		if err == nil {
+			err = to.AppendItem(v.Elem)
+		}
+
+		if err != nil {
+			logger.WithError(err).Error("failed moving mqueue item to postgres")
+			continue
+		}
+
+		moved++
+	}
+
+	if moved > 0 {
+		logger.Infof("Moved %d mqueue items from redis to postgres", moved)
+	}
+}
+
+// DeadItem is a queued message that failed too many times, or failed in a way that can't be retried
+type DeadItem struct {
+	Elem *QueuedElement
+
+	Attempts  int
+	LastError string
+	DiedAt    time.Time
+}
+
+// GetDeadItems returns the most recent dead items, optionally only those from source
+func GetDeadItems(source string, limit int) ([]*DeadItem, error) {
+	rows, err := common.PQ.Query(`SELECT data, attempts, last_error, died_at FROM mqueue_dead_items
+WHERE $1 = '' OR source = $1
+ORDER BY id DESC LIMIT $2`, source, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*DeadItem
+	for rows.Next() {
+		var raw []byte
+		item := &DeadItem{}
+		err = rows.Scan(&raw, &item.Attempts, &item.LastError, &item.DiedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(raw, &item.Elem)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, item)
+	}
+
+	return result, rows.Err()
+}
+
+// CountDeadItems returns the number of dead items
+func CountDeadItems() (n int64, err error) {
+	err = common.PQ.QueryRow(`SELECT count(*) FROM mqueue_dead_items`).Scan(&n)
+	return
+}
+
+// RequeueDeadItems queues the dead items again with a fresh set of attempts, if id is 0 all the dead items are queued.
+// Only the items that were queued are removed from the dead letters. Returns the number of items queued.
+func RequeueDeadItems(id int64) (int, error) {
+	tx, err := common.PQ.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// lock the items so that they can't be requeued twice at the same time
+	rows, err := tx.Query(`SELECT data FROM mqueue_dead_items WHERE $1 = 0 OR id = $1 ORDER BY id ASC FOR UPDATE SKIP LOCKED`, id)
+	if err != nil {
+		return 0, err
+	}
+
+	items, err := scanWorkItems(rows)
+	if err != nil {
+		return 0, err
+	}
+
+	queued := make([]int64, 0, len(items))
+	var queueErr error
+	for _, v := range items {
+		deadID := v.Elem.ID
+		queueErr = QueueMessage(v.Elem)
+		if queueErr != nil {
+			break
+		}
+
+		queued = append(queued, deadID)
+	}
+
+	if len(queued) > 0 {
+		_, err = tx.Exec(`DELETE FROM mqueue_dead_items WHERE id = ANY($1)`, pq.Int64Array(queued))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return len(queued), queueErr
+}
+
+// PurgeDeadItems removes the dead items, if id is 0 all of them are removed. Returns the number of items removed.
+func PurgeDeadItems(id int64) (int64, error) {
+	res, err := common.PQ.Exec(`DELETE FROM mqueue_dead_items WHERE $1 = 0 OR id = $1`, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
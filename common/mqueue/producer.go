@@ -3,8 +3,6 @@ package mqueue
 import (
 	"sync"
 	"time"
-
-	"github.com/botlabs-gg/yagpdb/v2/common"
 )
 
 type Producer struct {
@@ -33,11 +31,28 @@ var (
 func QueueMessage(elem *QueuedElement) error {
 	producerOnce.Do(func() {
 		standardProducer = &Producer{
-			backend: &RedisBackend{
-				pool: common.RedisPool,
-			},
+			backend: configuredBackend(),
 		}
 	})
 
 	return standardProducer.QueueMessage(elem)
 }
+
+// QueueEdit queues an edit of the message sent earlier for elem.Source and elem.SourceItemID, the message has to have
+// been sent with TrackMessage set
+func QueueEdit(elem *QueuedElement) error {
+	elem.Action = ActionEdit
+	return QueueMessage(elem)
+}
+
+// QueueDelete queues the removal of the message sent earlier for source and sourceItemID, the message has to have
+// been sent with TrackMessage set
+func QueueDelete(guildID, channelID int64, source, sourceItemID string) error {
+	return QueueMessage(&QueuedElement{
+		GuildID:      guildID,
+		ChannelID:    channelID,
+		Source:       source,
+		SourceItemID: sourceItemID,
+		Action:       ActionDelete,
+	})
+}
@@ -12,4 +12,57 @@ CREATE TABLE IF NOT EXISTS mqueue_webhooks (
 );
 
 CREATE INDEX IF NOT EXISTS mqueue_webhooks_channel_id_idx ON mqueue_webhooks(channel_id);
+
+CREATE TABLE IF NOT EXISTS mqueue_sent_messages (
+	source TEXT NOT NULL,
+	source_item_id TEXT NOT NULL,
+
+	guild_id BIGINT NOT NULL,
+	channel_id BIGINT NOT NULL,
+	thread_id BIGINT NOT NULL,
+	message_id BIGINT NOT NULL,
+	webhook_id BIGINT NOT NULL,
+
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	PRIMARY KEY(source, source_item_id)
+);
+
+CREATE INDEX IF NOT EXISTS mqueue_sent_messages_created_at_idx ON mqueue_sent_messages(created_at);
+
+CREATE TABLE IF NOT EXISTS mqueue_items (
+	id BIGSERIAL PRIMARY KEY,
+
+	guild_id BIGINT NOT NULL,
+	channel_id BIGINT NOT NULL,
+	source TEXT NOT NULL,
+	priority INT NOT NULL,
+	data JSONB NOT NULL,
+
+	attempts INT NOT NULL,
+	next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL,
+	last_error TEXT,
+
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS mqueue_items_next_attempt_at_idx ON mqueue_items(next_attempt_at);
+
+CREATE TABLE IF NOT EXISTS mqueue_dead_items (
+	id BIGINT PRIMARY KEY,
+
+	guild_id BIGINT NOT NULL,
+	channel_id BIGINT NOT NULL,
+	source TEXT NOT NULL,
+	source_item_id TEXT NOT NULL,
+	data JSONB NOT NULL,
+
+	attempts INT NOT NULL,
+	last_error TEXT NOT NULL,
+
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+	died_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS mqueue_dead_items_source_idx ON mqueue_dead_items(source);
 `
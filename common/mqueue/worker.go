@@ -18,6 +18,10 @@ type workItem struct {
 type workResult struct {
 	item  *workItem
 	retry bool
+
+	// err is set if the item failed, items that failed without being retried are moved to the dead letters
+	// if the backend supports it
+	err error
 }
 
 // MqueueServer is a worker that processes mqueue items for the current shards on the process
@@ -53,6 +57,9 @@ type MqueueServer struct {
 	forceAllShards bool
 
 	recentSentTimes map[int64]time.Time
+
+	// items finished recently, so that we don't process them again if they're polled before the removal is visible
+	recentlyFinished map[int64]time.Time
 }
 
 func NewServer(backend Storage, processor ItemProcessor) *MqueueServer {
@@ -65,6 +72,8 @@ func NewServer(backend Storage, processor ItemProcessor) *MqueueServer {
 		backend:         backend,
 		processor:       processor,
 		recentSentTimes: make(map[int64]time.Time),
+
+		recentlyFinished: make(map[int64]time.Time),
 	}
 }
 
@@ -99,6 +108,12 @@ func (m *MqueueServer) cleanRecentSentTimes() {
 			delete(m.recentSentTimes, c)
 		}
 	}
+
+	for id, v := range m.recentlyFinished {
+		if now.Sub(v) > time.Minute {
+			delete(m.recentlyFinished, id)
+		}
+	}
 }
 
 // performs a full refresh of the local and total work slice
@@ -136,6 +151,10 @@ func (m *MqueueServer) refreshTotalWork() error {
 func (m *MqueueServer) refreshLocalWorkCached() {
 OUTER:
 	for _, wi := range m.totalWork {
+		if _, ok := m.recentlyFinished[wi.Elem.ID]; ok {
+			continue
+		}
+
 		if !bot.ReadyTracker.IsGuildShardReady(wi.Elem.GuildID) && !m.forceAllShards {
 			continue
 		}
@@ -153,6 +172,10 @@ OUTER:
 }
 
 func (m *MqueueServer) addWork(wi *workItem) {
+	if _, ok := m.recentlyFinished[wi.Elem.ID]; ok {
+		return
+	}
+
 	if !bot.ReadyTracker.IsGuildShardReady(wi.Elem.GuildID) && !m.forceAllShards {
 		// keep tracking totalwork
 		if m.totalWorkPresent {
@@ -190,8 +213,29 @@ func (m *MqueueServer) checkRunNextWork() {
 }
 
 func (m *MqueueServer) finishWork(wr *workResult) {
-	if !wr.retry {
-		m.backend.DelItem(wr.item)
+	deadLetters, hasDeadLetters := m.backend.(StorageWithDeadLetters)
+
+	// without dead letters retries are attempted again right away
+	if !wr.retry || hasDeadLetters {
+		var err error
+		switch {
+		case wr.retry:
+			// it's picked up again once the backoff has passed
+			err = deadLetters.RetryItem(wr.item, wr.err)
+		case wr.err != nil && hasDeadLetters:
+			err = deadLetters.KillItem(wr.item, wr.err)
+		default:
+			err = m.backend.DelItem(wr.item)
+		}
+
+		if err != nil {
+			logger.WithError(err).WithField("mq_id", wr.item.Elem.ID).Error("failed updating finished mqueue item")
+		}
+
+		if !wr.retry {
+			// retries are polled again once their backoff has passed, so they must not be skipped then
+			m.recentlyFinished[wr.item.Elem.ID] = time.Now()
+		}
 		m.localWork = removeFromWorkSlice(m.localWork, wr.item)
 		if m.totalWorkPresent {
 			m.totalWork = removeFromWorkSlice(m.totalWork, wr.item)
@@ -0,0 +1,34 @@
+package common
+
+import (
+	"crypto/tls"
+
+	"github.com/botlabs-gg/yagpdb/v2/common/config"
+	"github.com/botlabs-gg/yagpdb/v2/lib/dshardorchestrator"
+)
+
+var (
+	confOrchestratorSecret        = config.RegisterOption("yagpdb.orchestrator.secret", "Shared secret the orchestrator and nodes use to authenticate each other, required when the orchestrator is reachable by other hosts", "")
+	confOrchestratorTLSCert       = config.RegisterOption("yagpdb.orchestrator.tls_cert", "Certificate file for tls between the orchestrator and nodes, tls is used if this, the key and the ca are set", "")
+	confOrchestratorTLSKey        = config.RegisterOption("yagpdb.orchestrator.tls_key", "Key file for the orchestrator tls certificate", "")
+	confOrchestratorTLSCA         = config.RegisterOption("yagpdb.orchestrator.tls_ca", "CA file the orchestrator and node certificates are verified against", "")
+	confOrchestratorTLSServerName = config.RegisterOption("yagpdb.orchestrator.tls_server_name", "Name in the orchestrators certificate, used by the nodes to verify it", "")
+
+	ConfOrchestratorRebalance = config.RegisterOption("yagpdb.orchestrator.rebalance", "Automatically move shards from the most to the least loaded nodes", false)
+)
+
+// OrchestratorConnAuth returns the auth secret and tls config for connections between the orchestrator and the nodes,
+// both are nil if not configured
+func OrchestratorConnAuth(server bool) (secret []byte, tlsConf *tls.Config, err error) {
+	if s := confOrchestratorSecret.GetString(); s != "" {
+		secret = []byte(s)
+	}
+
+	cert, key, ca := confOrchestratorTLSCert.GetString(), confOrchestratorTLSKey.GetString(), confOrchestratorTLSCA.GetString()
+	if cert == "" || key == "" || ca == "" {
+		return secret, nil, nil
+	}
+
+	tlsConf, err = dshardorchestrator.LoadTLSConfig(cert, key, ca, server, confOrchestratorTLSServerName.GetString())
+	return secret, tlsConf, err
+}
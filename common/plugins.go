@@ -1,5 +1,10 @@
 package common
 
+import (
+	"context"
+	"encoding/json"
+)
+
 var (
 	Plugins []Plugin
 )
@@ -47,3 +52,18 @@ func RunCommonRunPlugins() {
 		}
 	}
 }
+
+// PluginWithBackup is for plugins whose settings can be included in server backups
+type PluginWithBackup interface {
+	Plugin
+
+	// BackupVersion is the version of the data returned by ExportBackup, bump this on incompatible changes
+	BackupVersion() int
+
+	// ExportBackup returns the plugin settings of the guild, it's stored as json
+	ExportBackup(ctx context.Context, guildID int64) (interface{}, error)
+
+	// RestoreBackup replaces the plugin settings of the guild with the ones from the backup, using restore to map role and channel ids.
+	// Nothing should be saved when restore.DryRun is set
+	RestoreBackup(ctx context.Context, guildID int64, data json.RawMessage, restore *BackupRestore) error
+}
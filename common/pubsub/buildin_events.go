@@ -36,6 +36,7 @@ func handleGlobalRatelimtPusub(evt *Event) {
 
 func handleEvictCoreConfigCache(evt *Event) {
 	common.CoreServerConfigCache.Delete(int(evt.TargetGuildInt))
+	common.PagePermissionsCache.Delete(int(evt.TargetGuildInt))
 }
 
 type evictCacheSetData struct {
@@ -0,0 +1,189 @@
+package common
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/common/config"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+	"github.com/mediocregopher/radix/v3"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	ConfSharedRatelimits = config.RegisterOption("yagpdb.ratelimit.shared", "Share the discord rest api ratelimits between all processes through redis, needed when running shards across several nodes", false)
+	confGlobalRatelimit  = config.RegisterOption("yagpdb.ratelimit.global_per_second", "Max requests per second to the discord rest api across all processes when the ratelimits are shared, 0 to only respect the global ratelimits discord tells us about", 50)
+
+	metricsSharedRatelimitWaits = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "yagpdb_shared_ratelimit_waits_total",
+		Help: "Number of times a request waited on the shared ratelimits",
+	}, []string{"type"})
+)
+
+const redisRatelimitPrefix = "yagpdb.ratelimit."
+
+// Returns the ms to wait, the bucket is only consumed from when there's no need to wait.
+// KEYS[1]: bucket, KEYS[2]: global ratelimit, KEYS[3]: prefix of the per second global counters
+// ARGV[1]: max requests per second across all processes, 0 for no limit
+// Result: {wait ms, 1 if the wait is because of the global limit}
+var reserveScript = radix.NewEvalScript(3, `
+redis.replicate_commands()
+
+local gttl = redis.call('PTTL', KEYS[2])
+if gttl > 0 then
+	return {gttl, 1}
+end
+
+local remaining = redis.call('GET', KEYS[1])
+if remaining and tonumber(remaining) < 1 then
+	local ttl = redis.call('PTTL', KEYS[1])
+	if ttl > 0 then
+		return {ttl, 0}
+	end
+
+	-- somehow lost its expiry
+	redis.call('DEL', KEYS[1])
+	remaining = nil
+end
+
+local limit = tonumber(ARGV[1])
+if limit > 0 then
+	local t = redis.call('TIME')
+	local counter = KEYS[3] .. t[1]
+	local n = redis.call('INCR', counter)
+	if n == 1 then
+		redis.call('PEXPIRE', counter, 2000)
+	end
+
+	if n > limit then
+		return {1000 - math.floor(tonumber(t[2]) / 1000), 1}
+	end
+end
+
+if remaining then
+	redis.call('DECR', KEYS[1])
+end
+
+return {0, 0}
+`)
+
+// Requests reserved on other processes may not be reflected in the remaining header of our response yet,
+// so the lower remaining count is kept for the current window.
+// KEYS[1]: bucket
+// ARGV[1]: remaining, ARGV[2]: ms until the bucket resets
+var updateScript = radix.NewEvalScript(1, `
+local remaining = tonumber(ARGV[1])
+local current = redis.call('GET', KEYS[1])
+if current and tonumber(current) < remaining and redis.call('PTTL', KEYS[1]) > 0 then
+	remaining = tonumber(current)
+end
+
+redis.call('SET', KEYS[1], remaining, 'PX', ARGV[2])
+return remaining
+`)
+
+// RedisBucketStore is a discordgo.BucketStore that shares the ratelimits through redis
+type RedisBucketStore struct {
+	// Max requests per second across all processes, 0 for no limit
+	GlobalPerSecond int
+
+	mu           sync.Mutex
+	lastErrorLog time.Time
+}
+
+var _ discordgo.BucketStore = (*RedisBucketStore)(nil)
+
+// NewRedisBucketStore returns a new store using the configured global limit
+func NewRedisBucketStore() *RedisBucketStore {
+	return &RedisBucketStore{
+		GlobalPerSecond: confGlobalRatelimit.GetInt(),
+	}
+}
+
+// Reserve implements discordgo.BucketStore
+func (s *RedisBucketStore) Reserve(bucketKey string) time.Duration {
+	var resp []int64
+	err := RedisPool.Do(reserveScript.Cmd(&resp, redisRatelimitPrefix+"b:"+bucketKey, redisRatelimitPrefix+"global",
+		redisRatelimitPrefix+"s:", strconv.Itoa(s.GlobalPerSecond)))
+	if err != nil || len(resp) < 2 {
+		s.logError(err, "reserve")
+		return 0
+	}
+
+	if resp[0] > 0 {
+		if resp[1] == 1 {
+			metricsSharedRatelimitWaits.With(prometheus.Labels{"type": "global"}).Inc()
+		} else {
+			metricsSharedRatelimitWaits.With(prometheus.Labels{"type": "bucket"}).Inc()
+		}
+	}
+
+	return time.Duration(resp[0]) * time.Millisecond
+}
+
+// Update implements discordgo.BucketStore
+func (s *RedisBucketStore) Update(bucketKey string, remaining int, resetAfter time.Duration) {
+	ms := resetAfter.Milliseconds()
+	if ms < 1 {
+		return
+	}
+
+	err := RedisPool.Do(updateScript.Cmd(nil, redisRatelimitPrefix+"b:"+bucketKey, strconv.Itoa(remaining), strconv.FormatInt(ms, 10)))
+	if err != nil {
+		s.logError(err, "update")
+	}
+}
+
+// SetGlobal implements discordgo.BucketStore
+func (s *RedisBucketStore) SetGlobal(retryAfter time.Duration) {
+	ms := retryAfter.Milliseconds()
+	if ms < 1 {
+		return
+	}
+
+	err := RedisPool.Do(radix.Cmd(nil, "SET", redisRatelimitPrefix+"global", "1", "PX", strconv.FormatInt(ms, 10)))
+	if err != nil {
+		s.logError(err, "set global")
+	}
+}
+
+// the store fails open, so avoid flooding the logs with errors if redis is down
+func (s *RedisBucketStore) logError(err error, action string) {
+	if err == nil {
+		return
+	}
+
+	s.mu.Lock()
+	if time.Since(s.lastErrorLog) < time.Minute {
+		s.mu.Unlock()
+		return
+	}
+	s.lastErrorLog = time.Now()
+	s.mu.Unlock()
+
+	logger.WithError(err).Errorf("failed to %s shared ratelimit, falling back to the local ratelimits", action)
+}
+
+// SetupSharedRatelimits sets up the session to share its ratelimits through redis if enabled in the config
+func SetupSharedRatelimits(session *discordgo.Session) {
+	if !ConfSharedRatelimits.GetBool() {
+		return
+	}
+
+	session.Ratelimiter.Store = sharedBucketStore()
+}
+
+var (
+	sharedStore     *RedisBucketStore
+	sharedStoreOnce sync.Once
+)
+
+func sharedBucketStore() *RedisBucketStore {
+	sharedStoreOnce.Do(func() {
+		sharedStore = NewRedisBucketStore()
+	})
+
+	return sharedStore
+}
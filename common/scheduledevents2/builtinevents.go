@@ -2,6 +2,7 @@ package scheduledevents2
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/botlabs-gg/yagpdb/v2/bot"
@@ -22,6 +23,27 @@ func registerBuiltinEvents() {
 	RegisterHandler("std_add_member_role", AddRoleData{}, handleAddMemberRole)
 }
 
+func registerBuiltinDescribers() {
+	RegisterDescriber("delete_messages", DeleteMessagesEvent{}, describeDeleteMessagesEvent)
+	RegisterDescriber("std_remove_member_role", RmoveRoleData{}, describeRemoveMemberRole)
+	RegisterDescriber("std_add_member_role", AddRoleData{}, describeAddMemberRole)
+}
+
+func describeDeleteMessagesEvent(evt *models.ScheduledEvent, data interface{}) string {
+	dataCast := data.(*DeleteMessagesEvent)
+	return fmt.Sprintf("Delete %d messages in channel %d", len(dataCast.Messages), dataCast.ChannelID)
+}
+
+func describeRemoveMemberRole(evt *models.ScheduledEvent, data interface{}) string {
+	dataCast := data.(*RmoveRoleData)
+	return fmt.Sprintf("Remove role %d from user %d", dataCast.RoleID, dataCast.UserID)
+}
+
+func describeAddMemberRole(evt *models.ScheduledEvent, data interface{}) string {
+	dataCast := data.(*AddRoleData)
+	return fmt.Sprintf("Give role %d to user %d", dataCast.RoleID, dataCast.UserID)
+}
+
 func ScheduleDeleteMessages(guildID, channelID int64, when time.Time, messages ...int64) error {
 	msgs := messages
 
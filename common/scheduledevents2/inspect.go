@@ -0,0 +1,143 @@
+package scheduledevents2
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/scheduledevents2/models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// MaxListedEvents is the max number of upcoming events shown in the inspector
+const MaxListedEvents = 100
+
+var ErrEventNotFound = errors.NewPlain("scheduled event not found")
+
+// GetUpcomingEvents returns the pending events of the guild, soonest first.
+// If eventName is not empty only those events are returned.
+func GetUpcomingEvents(ctx context.Context, guildID int64, eventName string, limit int) ([]*models.ScheduledEvent, error) {
+	qms := []qm.QueryMod{
+		qm.Where("guild_id = ? AND processed = false", guildID),
+		qm.OrderBy("triggers_at asc, id asc"),
+		qm.Limit(limit),
+	}
+
+	if eventName != "" {
+		qms = append(qms, qm.Where("event_name = ?", eventName))
+	}
+
+	return models.ScheduledEvents(qms...).AllG(ctx)
+}
+
+// CountUpcomingEvents returns the number of pending events in the guild by event name
+func CountUpcomingEvents(ctx context.Context, guildID int64) (map[string]int64, error) {
+	rows, err := common.PQ.QueryContext(ctx, `SELECT event_name, count(*) FROM scheduled_events
+WHERE guild_id = $1 AND processed = false GROUP BY event_name`, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[string]int64)
+	for rows.Next() {
+		var name string
+		var n int64
+		err = rows.Scan(&name, &n)
+		if err != nil {
+			return nil, err
+		}
+
+		result[name] = n
+	}
+
+	return result, rows.Err()
+}
+
+// DescribeEvent returns a human readable description of the event using its registered describer,
+// if there is none or the data can't be decoded the event name and data are used.
+func DescribeEvent(evt *models.ScheduledEvent) string {
+	if d, ok := registeredDescribers[evt.EventName]; ok {
+		data, err := decodeEventData(d.DataFormat, evt)
+		if err == nil {
+			return d.Describer(evt, data)
+		}
+	}
+
+	desc := HumanizeEventName(evt.EventName)
+	if data := string(evt.Data); data != "" && data != "{}" && data != "null" {
+		desc += ": " + common.CutStringShort(data, 200)
+	}
+
+	return desc
+}
+
+// HumanizeEventName turns a event name like moderation_unban into "Moderation unban"
+func HumanizeEventName(name string) string {
+	name = strings.ReplaceAll(name, "_", " ")
+	if name == "" {
+		return name
+	}
+
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// CancelEvent removes the pending event with the id from the guild
+func CancelEvent(ctx context.Context, guildID, id int64) error {
+	n, err := models.ScheduledEvents(qm.Where("id = ? AND guild_id = ? AND processed = false", id, guildID)).DeleteAll(ctx, common.PQ)
+	if err != nil {
+		return err
+	}
+
+	if n < 1 {
+		return ErrEventNotFound
+	}
+
+	markDoneRedis(guildID, id)
+	return nil
+}
+
+// CancelEventsByName removes all the pending events with the name from the guild, returns the number of events removed
+func CancelEventsByName(ctx context.Context, guildID int64, eventName string) (int64, error) {
+	events, err := models.ScheduledEvents(qm.Select("id"), qm.Where("guild_id = ? AND event_name = ? AND processed = false", guildID, eventName)).AllG(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := models.ScheduledEvents(qm.Where("guild_id = ? AND event_name = ? AND processed = false", guildID, eventName)).DeleteAll(ctx, common.PQ)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, v := range events {
+		markDoneRedis(guildID, v.ID)
+	}
+
+	return n, nil
+}
+
+// RescheduleEvent changes when the pending event with the id triggers
+func RescheduleEvent(ctx context.Context, guildID, id int64, t time.Time) (*models.ScheduledEvent, error) {
+	evt, err := models.ScheduledEvents(qm.Where("id = ? AND guild_id = ? AND processed = false", id, guildID)).OneG(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrEventNotFound
+		}
+
+		return nil, err
+	}
+
+	evt.TriggersAt = t
+	_, err = evt.UpdateG(ctx, boil.Whitelist("triggers_at"))
+	if err != nil {
+		return nil, err
+	}
+
+	// either moves it in redis or removes it if it's no longer soon, then it's flushed again by the background worker
+	err = UpdateFlushedEvent(time.Now(), common.RedisPool, evt)
+	return evt, err
+}
@@ -54,22 +54,33 @@ func (p *ScheduledEvents) PluginInfo() *common.PluginInfo {
 
 func RegisterPlugin() {
 	common.InitSchemas("scheduledevents2", DBSchemas...)
+	registerBuiltinDescribers()
 
 	common.RegisterPlugin(newScheduledEventsPlugin())
 }
 
 type HandlerFunc func(evt *models.ScheduledEvent, data interface{}) (retry bool, err error)
 
+// DescribeFunc returns a human readable description of the event for the scheduled events inspector,
+// data is decoded the same way as for the handler
+type DescribeFunc func(evt *models.ScheduledEvent, data interface{}) string
+
 type RegisteredHandler struct {
 	EvtName    string
 	DataFormat interface{}
 	Handler    HandlerFunc
 }
 
+type registeredDescriber struct {
+	DataFormat interface{}
+	Describer  DescribeFunc
+}
+
 var (
-	registeredHandlers = make(map[string]*RegisteredHandler)
-	running            bool
-	logger             = common.GetPluginLogger(&ScheduledEvents{})
+	registeredHandlers   = make(map[string]*RegisteredHandler)
+	registeredDescribers = make(map[string]*registeredDescriber)
+	running              bool
+	logger               = common.GetPluginLogger(&ScheduledEvents{})
 )
 
 // RegisterHandler registers a handler for the scpecified event name
@@ -88,6 +99,15 @@ func RegisterHandler(eventName string, dataFormat interface{}, handler HandlerFu
 	logger.Debug("Registered handler for ", eventName)
 }
 
+// RegisterDescriber registers how the event is shown in the scheduled events inspector, dataFormat is the same as for the handler.
+// Call this in RegisterPlugin so that it's also available on the webserver, where the handlers are not registered
+func RegisterDescriber(eventName string, dataFormat interface{}, describer DescribeFunc) {
+	registeredDescribers[eventName] = &registeredDescriber{
+		DataFormat: dataFormat,
+		Describer:  describer,
+	}
+}
+
 func ScheduleEvent(evtName string, guildID int64, runAt time.Time, data interface{}) error {
 	m := &models.ScheduledEvent{
 		TriggersAt: runAt,
@@ -118,6 +138,20 @@ func ScheduleEvent(evtName string, guildID int64, runAt time.Time, data interfac
 	return errors.WithMessage(err, "insert")
 }
 
+// decodeEventData decodes the data of the event into a new dataFormat
+func decodeEventData(dataFormat interface{}, evt *models.ScheduledEvent) (interface{}, error) {
+	if dataFormat == nil {
+		return nil, nil
+	}
+
+	typ := reflect.TypeOf(dataFormat)
+
+	// Decode the form into the destination struct
+	decodedData := reflect.New(typ).Interface()
+	err := json.Unmarshal(evt.Data, decodedData)
+	return decodedData, err
+}
+
 var _ bot.LateBotInitHandler = (*ScheduledEvents)(nil)
 var _ bot.BotStopperHandler = (*ScheduledEvents)(nil)
 
@@ -307,18 +341,11 @@ func (se *ScheduledEvents) processItem(id int64, guildID int64) {
 		return
 	}
 
-	var decodedData interface{}
-	if handler.DataFormat != nil {
-		typ := reflect.TypeOf(handler.DataFormat)
-
-		// Decode the form into the destination struct
-		decodedData = reflect.New(typ).Interface()
-		err := json.Unmarshal(item.Data, decodedData)
-		if err != nil {
-			l.WithError(err).Error("failed decoding event data")
-			se.markDoneFast(item.ID, item.GuildID)
-			return
-		}
+	decodedData, err := decodeEventData(handler.DataFormat, item)
+	if err != nil {
+		l.WithError(err).Error("failed decoding event data")
+		se.markDoneFast(item.ID, item.GuildID)
+		return
 	}
 
 	defer func() {
@@ -1,6 +1,7 @@
 package scheduledevents2
 
 import (
+	"strconv"
 	"sync"
 	"testing"
 	"time"
@@ -170,3 +171,32 @@ func TestScheduleHandleWithData(t *testing.T) {
 		return // Success
 	}
 }
+
+func TestDescribeEvent(t *testing.T) {
+	defer func() {
+		registeredDescribers = make(map[string]*registeredDescriber)
+	}()
+
+	type testData struct {
+		UserID int64
+	}
+
+	RegisterDescriber("test_described", testData{}, func(evt *models.ScheduledEvent, data interface{}) string {
+		return "user " + strconv.FormatInt(data.(*testData).UserID, 10)
+	})
+
+	described := &models.ScheduledEvent{EventName: "test_described", Data: []byte(`{"UserID": 5}`)}
+	if desc := DescribeEvent(described); desc != "user 5" {
+		t.Errorf("incorrect description: %q", desc)
+	}
+
+	fallback := &models.ScheduledEvent{EventName: "moderation_unban", Data: []byte(`{"user_id":5}`)}
+	if desc := DescribeEvent(fallback); desc != `Moderation unban: {"user_id":5}` {
+		t.Errorf("incorrect fallback description: %q", desc)
+	}
+
+	empty := &models.ScheduledEvent{EventName: "premium_guild_added", Data: []byte(`{}`)}
+	if desc := DescribeEvent(empty); desc != "Premium guild added" {
+		t.Errorf("incorrect empty description: %q", desc)
+	}
+}
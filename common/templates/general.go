@@ -218,6 +218,7 @@ func CreateMessageSend(values ...interface{}) (*discordgo.MessageSend, error) {
 
 	// Default filename
 	filename := "attachment_" + time.Now().Format("2006-01-02_15-04-05")
+	generatedFile := false
 	for key, val := range messageSdict {
 
 		switch strings.ToLower(key) {
@@ -245,6 +246,13 @@ func CreateMessageSend(values ...interface{}) (*discordgo.MessageSend, error) {
 				msg.Embeds = []*discordgo.MessageEmbed{embed}
 			}
 		case "file":
+			if f, ok := val.(*discordgo.File); ok {
+				// files generated by other template functions, e.g welcomeCard
+				msg.File = f
+				generatedFile = true
+				continue
+			}
+
 			stringFile := ToString(val)
 			if len(stringFile) > 100000 {
 				return nil, errors.New("file length for send message builder exceeded size limit")
@@ -287,7 +295,7 @@ func CreateMessageSend(values ...interface{}) (*discordgo.MessageSend, error) {
 		}
 
 	}
-	if msg.File != nil {
+	if msg.File != nil && !generatedFile {
 		// We hardcode the extension to .txt to prevent possible abuse via .bat or other possible harmful/easily corruptable file formats
 		msg.File.Name = filename + ".txt"
 	}
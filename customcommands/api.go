@@ -0,0 +1,231 @@
+package customcommands
+
+import (
+	"net/http"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/customcommands/models"
+	"github.com/botlabs-gg/yagpdb/v2/web"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"goji.io/pat"
+)
+
+func (p *Plugin) initAPI() {
+	web.APIMux.Handle(pat.Get("/customcommands/commands"), web.APIReadHandler(p, apiListCommands))
+	web.APIMux.Handle(pat.Post("/customcommands/commands"), web.APIWriteHandler(p, apiCreateCommand, CustomCommand{}))
+	web.APIMux.Handle(pat.Get("/customcommands/commands/:cmd"), web.APIReadHandler(p, apiGetCommand))
+	web.APIMux.Handle(pat.Put("/customcommands/commands/:cmd"), web.APIWriteHandler(p, apiUpdateCommand, CustomCommand{}))
+	web.APIMux.Handle(pat.Delete("/customcommands/commands/:cmd"), web.APIWriteHandler(p, web.APIFromController(handleDeleteCommand), nil))
+
+	web.APIMux.Handle(pat.Get("/customcommands/groups"), web.APIReadHandler(p, apiListGroups))
+	web.APIMux.Handle(pat.Post("/customcommands/groups"), web.APIWriteHandler(p, apiCreateGroup, GroupForm{}))
+	web.APIMux.Handle(pat.Put("/customcommands/groups/:group"), web.APIWriteHandler(p, web.APIFromController(handleUpdateGroup), GroupForm{}))
+	web.APIMux.Handle(pat.Delete("/customcommands/groups/:group"), web.APIWriteHandler(p, web.APIFromController(handleDeleteGroup), nil))
+}
+
+// commandFromDB converts the command to the form used in the control panel, which is also what the api accepts
+func commandFromDB(cc *models.CustomCommand) *CustomCommand {
+	cmd := &CustomCommand{
+		ID:            cc.LocalID,
+		TriggerType:   CommandTriggerType(cc.TriggerType),
+		Trigger:       cc.TextTrigger,
+		CaseSensitive: cc.TextTriggerCaseSensitive,
+		Responses:     cc.Responses,
+		Name:          cc.Name.String,
+		IsEnabled:     !cc.Disabled,
+		Public:        cc.Public,
+		PublicID:      cc.PublicID,
+
+		ContextChannel: cc.ContextChannel,
+
+		TimeTriggerInterval:       cc.TimeTriggerInterval,
+		TimeTriggerExcludingDays:  cc.TimeTriggerExcludingDays,
+		TimeTriggerExcludingHours: cc.TimeTriggerExcludingHours,
+
+		ReactionTriggerMode: int(cc.ReactionTriggerMode),
+
+		RequireChannels: cc.ChannelsWhitelistMode,
+		Channels:        cc.Channels,
+		RequireRoles:    cc.RolesWhitelistMode,
+		Roles:           cc.Roles,
+		TriggerOnEdit:   cc.TriggerOnEdit,
+
+		GroupID:    cc.GroupID.Int64,
+		ShowErrors: cc.ShowErrors,
+	}
+
+	cmd.TriggerTypeForm = triggerTypeToForm(cmd.TriggerType)
+	if tmplGetCCIntervalTriggerType(cc) == 1 {
+		cmd.TriggerTypeForm = "interval_hours"
+		cmd.TimeTriggerInterval /= 60
+	}
+
+	return cmd
+}
+
+// triggerTypeToForm is the reverse of triggerTypeFromForm, intervals are returned in minutes
+func triggerTypeToForm(t CommandTriggerType) string {
+	switch t {
+	case CommandTriggerNone:
+		return "none"
+	case CommandTriggerStartsWith:
+		return "prefix"
+	case CommandTriggerRegex:
+		return "regex"
+	case CommandTriggerContains:
+		return "contains"
+	case CommandTriggerExact:
+		return "exact"
+	case CommandTriggerReaction:
+		return "reaction"
+	case CommandTriggerInterval:
+		return "interval_minutes"
+	default:
+		return "command"
+	}
+}
+
+func apiListCommands(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	ctx := r.Context()
+	activeGuild, _ := web.GetBaseCPContextData(ctx)
+
+	commands, err := models.CustomCommands(qm.Where("guild_id = ?", activeGuild.ID), qm.OrderBy("local_id asc")).AllG(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*CustomCommand, 0, len(commands))
+	for _, v := range commands {
+		result = append(result, commandFromDB(v))
+	}
+
+	return result, nil
+}
+
+func apiGetCommand(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	ctx := r.Context()
+	activeGuild, _ := web.GetBaseCPContextData(ctx)
+
+	cmdID, err := web.APIParamInt64(r, "cmd")
+	if err != nil {
+		return nil, err
+	}
+
+	cmd, err := models.FindCustomCommandG(ctx, activeGuild.ID, cmdID)
+	if err != nil {
+		return nil, err
+	}
+
+	return commandFromDB(cmd), nil
+}
+
+// apiCreateCommand creates a placeholder command the same way the control panel does and then updates it with the provided
+// settings, the placeholder is removed again if they're invalid
+func apiCreateCommand(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	ctx := r.Context()
+	activeGuild, _ := web.GetBaseCPContextData(ctx)
+
+	c, err := models.CustomCommands(qm.Where("guild_id = ?", activeGuild.ID)).CountG(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if int(c) >= MaxCommandsForContext(ctx) {
+		return nil, web.NewPublicError("Max ", MaxCommandsForContext(ctx), " custom commands allowed")
+	}
+
+	localID, err := common.GenLocalIncrID(activeGuild.ID, "custom_command")
+	if err != nil {
+		return nil, err
+	}
+
+	placeholder := &models.CustomCommand{
+		GuildID:                   activeGuild.ID,
+		LocalID:                   localID,
+		Disabled:                  true,
+		TimeTriggerExcludingDays:  []int64{},
+		TimeTriggerExcludingHours: []int64{},
+		Responses:                 []string{},
+	}
+
+	err = placeholder.InsertG(ctx, boil.Infer())
+	if err != nil {
+		return nil, err
+	}
+
+	form := ctx.Value(common.ContextKeyParsedForm).(*CustomCommand)
+	form.ID = localID
+
+	_, err = web.APIFromController(handleUpdateCommand)(w, r)
+	if err != nil {
+		placeholder.DeleteG(ctx)
+		return nil, err
+	}
+
+	return apiGetCommand(w, r.WithContext(web.SetContextParam(ctx, "cmd", localID)))
+}
+
+func apiUpdateCommand(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	cmdID, err := web.APIParamInt64(r, "cmd")
+	if err != nil {
+		return nil, err
+	}
+
+	form := r.Context().Value(common.ContextKeyParsedForm).(*CustomCommand)
+	form.ID = cmdID
+
+	_, err = web.APIFromController(handleUpdateCommand)(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiGetCommand(w, r)
+}
+
+func apiListGroups(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	ctx := r.Context()
+	activeGuild, _ := web.GetBaseCPContextData(ctx)
+
+	groups, err := models.CustomCommandGroups(qm.Where("guild_id = ?", activeGuild.ID), qm.OrderBy("id asc")).AllG(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*GroupForm, 0, len(groups))
+	for _, v := range groups {
+		result = append(result, &GroupForm{
+			ID:                v.ID,
+			Name:              v.Name,
+			WhitelistChannels: v.WhitelistChannels,
+			BlacklistChannels: v.IgnoreChannels,
+			WhitelistRoles:    v.WhitelistRoles,
+			BlacklistRoles:    v.IgnoreRoles,
+		})
+	}
+
+	return result, nil
+}
+
+func apiCreateGroup(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	ctx := r.Context()
+	_, templateData := web.GetBaseCPContextData(ctx)
+
+	_, err := web.APIFromController(handleNewGroup)(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	form := ctx.Value(common.ContextKeyParsedForm).(*GroupForm)
+	form.ID, _ = templateData["CurrentGroupID"].(int64)
+
+	// the control panel only sets the name when creating groups
+	if len(form.WhitelistChannels)+len(form.BlacklistChannels)+len(form.WhitelistRoles)+len(form.BlacklistRoles) > 0 {
+		_, err = web.APIFromController(handleUpdateGroup)(w, r.WithContext(web.SetContextParam(ctx, "group", form.ID)))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return form, nil
+}
@@ -0,0 +1,235 @@
+package customcommands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/featureflags"
+	"github.com/botlabs-gg/yagpdb/v2/common/pubsub"
+	schEventsModels "github.com/botlabs-gg/yagpdb/v2/common/scheduledevents2/models"
+	"github.com/botlabs-gg/yagpdb/v2/customcommands/models"
+	"github.com/botlabs-gg/yagpdb/v2/premium"
+	"github.com/botlabs-gg/yagpdb/v2/web"
+	"github.com/mediocregopher/radix/v3"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+type customCommandsBackup struct {
+	Groups   []*models.CustomCommandGroup
+	Commands []*models.CustomCommand
+}
+
+var _ common.PluginWithBackup = (*Plugin)(nil)
+
+func (p *Plugin) BackupVersion() int {
+	return 1
+}
+
+func (p *Plugin) ExportBackup(ctx context.Context, guildID int64) (interface{}, error) {
+	groups, err := models.CustomCommandGroups(qm.Where("guild_id = ?", guildID), qm.OrderBy("id asc")).AllG(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	commands, err := models.CustomCommands(qm.Where("guild_id = ?", guildID), qm.OrderBy("local_id asc")).AllG(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &customCommandsBackup{
+		Groups:   groups,
+		Commands: commands,
+	}, nil
+}
+
+// RestoreBackup replaces all the custom commands and groups, the commands keep their ids as they're often referenced in other commands
+func (p *Plugin) RestoreBackup(ctx context.Context, guildID int64, data json.RawMessage, restore *common.BackupRestore) error {
+	var backup customCommandsBackup
+	err := json.Unmarshal(data, &backup)
+	if err != nil {
+		return err
+	}
+
+	if len(backup.Groups) > MaxGroups {
+		restore.Note("Only the first %d of %d groups were restored", MaxGroups, len(backup.Groups))
+		backup.Groups = backup.Groups[:MaxGroups]
+	}
+
+	maxCommands := MaxCommandsForContext(ctx)
+	if len(backup.Commands) > maxCommands {
+		restore.Note("Only the first %d of %d custom commands were restored", maxCommands, len(backup.Commands))
+		backup.Commands = backup.Commands[:maxCommands]
+	}
+
+	tx, err := common.PQ.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = models.CustomCommands(qm.Where("guild_id = ?", guildID)).DeleteAll(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = models.CustomCommandGroups(qm.Where("guild_id = ?", guildID)).DeleteAll(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = schEventsModels.ScheduledEvents(qm.Where("event_name='cc_next_run' AND guild_id = ?", guildID)).DeleteAll(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	groupIDs := make(map[int64]int64)
+	for _, v := range backup.Groups {
+		oldID := v.ID
+
+		v.ID = 0
+		v.GuildID = guildID
+		v.IgnoreRoles = restore.Roles(v.IgnoreRoles)
+		v.WhitelistRoles = restore.Roles(v.WhitelistRoles)
+		v.IgnoreChannels = restore.Channels(v.IgnoreChannels)
+		v.WhitelistChannels = restore.Channels(v.WhitelistChannels)
+
+		err = v.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		groupIDs[oldID] = v.ID
+	}
+
+	sameGuild := restore.SourceGuildID == guildID
+	var maxLocalID int64
+	for _, v := range backup.Commands {
+		if reason := validateRestoredCommand(v); reason != "" {
+			restore.Note("Skipped the custom command #%d: %s", v.LocalID, reason)
+			continue
+		}
+
+		v.GuildID = guildID
+		if v.GroupID.Valid {
+			if newID, ok := groupIDs[v.GroupID.Int64]; ok {
+				v.GroupID = null.Int64From(newID)
+			} else {
+				v.GroupID = null.Int64{}
+			}
+		}
+
+		v.Roles = restore.Roles(v.Roles)
+		v.Channels = restore.Channels(v.Channels)
+		v.ContextChannel = restore.Channel(v.ContextChannel)
+		v.TriggerOnEdit = v.TriggerOnEdit && premium.ContextPremium(ctx)
+
+		v.LastRun = null.Time{}
+		v.NextRun = null.Time{}
+		v.LastError = ""
+		v.LastErrorTime = null.Time{}
+		v.RunCount = 0
+
+		if !sameGuild {
+			// the public link belongs to the original server
+			v.Public = false
+			v.PublicID = ""
+			v.ImportCount = 0
+		}
+
+		if v.TriggerType == int(CommandTriggerInterval) && v.ContextChannel == 0 && !v.Disabled {
+			restore.Note("The channel of the interval command #%d was not found, it will not run until one is set", v.LocalID)
+		}
+
+		err = v.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if v.LocalID > maxLocalID {
+			maxLocalID = v.LocalID
+		}
+	}
+
+	err = restore.Finish(tx)
+	if err != nil || restore.DryRun {
+		return err
+	}
+
+	err = ensureLocalIDAbove(guildID, maxLocalID)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range backup.Commands {
+		if v.TriggerType != int(CommandTriggerInterval) || v.Disabled {
+			continue
+		}
+
+		err = UpdateCommandNextRunTime(v, false, false)
+		if err != nil {
+			logger.WithError(err).WithField("guild", guildID).Error("failed scheduling restored interval command")
+		}
+	}
+
+	featureflags.MarkGuildDirty(guildID)
+	pubsub.EvictCacheSet(cachedCommandsMessage, guildID)
+	return nil
+}
+
+// validateRestoredCommand runs the command through the same checks as when it's saved in the control panel,
+// returning why it's invalid or an empty string if it's fine
+func validateRestoredCommand(cc *models.CustomCommand) string {
+	form := commandFromDB(cc)
+
+	tmpl := web.TemplateData{}
+	if !form.Validate(tmpl) {
+		if alerts := tmpl.Alerts(); len(alerts) > 0 {
+			return alerts[0].Message
+		}
+		return "invalid command"
+	}
+
+	if err := web.ValidateNormalStringField(form.Name, 0, 100); err != nil {
+		return "name " + err.Error()
+	}
+
+	if err := web.ValidateNormalStringField(form.Trigger, 0, 1000); err != nil {
+		return "trigger " + err.Error()
+	}
+
+	if cc.TriggerType == int(CommandTriggerRegex) {
+		if err := web.ValidateRegexField(form.Trigger, 1000); err != nil {
+			return "invalid regex trigger: " + err.Error()
+		}
+	}
+
+	for i, v := range form.Responses {
+		if err := web.ValidateTemplateField(v, 10000); err != nil {
+			return fmt.Sprintf("response %d: %s", i+1, err)
+		}
+	}
+
+	return ""
+}
+
+// ensureLocalIDAbove makes sure new commands won't reuse the ids of restored commands
+func ensureLocalIDAbove(guildID int64, id int64) error {
+	key := "local_ids:" + strconv.FormatInt(guildID, 10)
+
+	var current int64
+	err := common.RedisPool.Do(radix.Cmd(&current, "HGET", key, "custom_command"))
+	if err != nil || current >= id {
+		return err
+	}
+
+	return common.RedisPool.Do(radix.Cmd(nil, "HSET", key, "custom_command", strconv.FormatInt(id, 10)))
+}
@@ -75,6 +75,21 @@ func (p *Plugin) BotInit() {
 	scheduledevents2.RegisterHandler("cc_delayed_run", DelayedRunCCData{}, handleDelayedRunCC)
 }
 
+func describeNextRunScheduledEvent(evt *schEventsModels.ScheduledEvent, data interface{}) string {
+	return fmt.Sprintf("Interval run of custom command #%d", data.(*NextRunScheduledEvent).CmdID)
+}
+
+func describeDelayedRunCC(evt *schEventsModels.ScheduledEvent, data interface{}) string {
+	dataCast := data.(*DelayedRunCCData)
+
+	desc := fmt.Sprintf("Delayed run of custom command #%d in channel %d", dataCast.CmdID, dataCast.ChannelID)
+	if dataCast.UserKey != nil {
+		desc += fmt.Sprintf(" with the key %v", dataCast.UserKey)
+	}
+
+	return desc
+}
+
 func handleCustomCommandsRunNow(event *pubsub.Event) {
 	dataCast := event.Data.(*models.CustomCommand)
 	f := logger.WithFields(logrus.Fields{
@@ -14,6 +14,7 @@ import (
 	"emperror.dev/errors"
 	"github.com/botlabs-gg/yagpdb/v2/common"
 	"github.com/botlabs-gg/yagpdb/v2/common/featureflags"
+	"github.com/botlabs-gg/yagpdb/v2/common/scheduledevents2"
 	"github.com/botlabs-gg/yagpdb/v2/customcommands/models"
 	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
 	"github.com/botlabs-gg/yagpdb/v2/lib/dstate"
@@ -49,6 +50,9 @@ func RegisterPlugin() {
 
 	plugin := &Plugin{}
 	common.RegisterPlugin(plugin)
+
+	scheduledevents2.RegisterDescriber("cc_next_run", NextRunScheduledEvent{}, describeNextRunScheduledEvent)
+	scheduledevents2.RegisterDescriber("cc_delayed_run", DelayedRunCCData{}, describeDelayedRunCC)
 }
 
 func (p *Plugin) PluginInfo() *common.PluginInfo {
@@ -112,7 +116,7 @@ func (t CommandTriggerType) String() string {
 
 type CustomCommand struct {
 	TriggerType     CommandTriggerType `json:"trigger_type"`
-	TriggerTypeForm string             `json:"-" schema:"type"`
+	TriggerTypeForm string             `json:"type,omitempty" schema:"type"`
 	Trigger         string             `json:"trigger" schema:"trigger" valid:",0,1000"`
 	// TODO: Retire the legacy Response field.
 	Response      string   `json:"response,omitempty" schema:"response" valid:"template,10000"`
@@ -144,6 +144,10 @@ func (p *Plugin) InitWeb() {
 	subMux.Handle(pat.Post("/groups/:group/update"), web.ControllerPostHandler(handleUpdateGroup, getGroupHandler, GroupForm{}))
 	subMux.Handle(pat.Post("/groups/:group/delete"), web.ControllerPostHandler(handleDeleteGroup, getHandler, nil))
 
+	p.initAPI()
+
+	cplogs.RegisterConfigSource(cplogs.BackupConfigSource(p, "customcommands", "Custom commands", "customcommands"))
+
 	// shortlink-specific mux
 	shortlinkSubMux := goji.SubMux()
 	web.RootMux.Handle(pat.New("/cc/*"), shortlinkSubMux)
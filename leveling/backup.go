@@ -0,0 +1,92 @@
+package leveling
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+)
+
+var _ common.PluginWithBackup = (*Plugin)(nil)
+
+func (p *Plugin) BackupVersion() int {
+	return 1
+}
+
+// ExportBackup exports the settings, role rewards and multipliers, the xp of members is not included
+func (p *Plugin) ExportBackup(ctx context.Context, guildID int64) (interface{}, error) {
+	return GetConfig(ctx, guildID)
+}
+
+func (p *Plugin) RestoreBackup(ctx context.Context, guildID int64, data json.RawMessage, restore *common.BackupRestore) error {
+	conf := DefaultConfig(guildID)
+	err := json.Unmarshal(data, conf)
+	if err != nil {
+		return err
+	}
+
+	conf.GuildID = guildID
+	conf.NoXPChannels = restore.Channels(conf.NoXPChannels)
+	conf.NoXPRoles = restore.Roles(conf.NoXPRoles)
+	if conf.AnnounceChannel != 0 {
+		conf.AnnounceChannel = restore.Channel(conf.AnnounceChannel)
+	}
+
+	rewards := make([]*RoleReward, 0, len(conf.RoleRewards))
+	for _, v := range conf.RoleRewards {
+		v.ID = 0
+		v.RoleID = restore.Role(v.RoleID)
+		if v.RoleID == 0 {
+			restore.Note("The role rewarded at level %d was not found, it was skipped", v.Level)
+			continue
+		}
+
+		rewards = append(rewards, v)
+	}
+
+	multipliers := make([]*Multiplier, 0, len(conf.Multipliers))
+	for _, v := range conf.Multipliers {
+		v.ID = 0
+		if v.TargetType == MultiplierTargetRole {
+			v.TargetID = restore.Role(v.TargetID)
+		} else {
+			v.TargetID = restore.Channel(v.TargetID)
+		}
+
+		if v.TargetID == 0 {
+			restore.Note("The channel or role of a x%g multiplier was not found, it was skipped", v.Multiplier)
+			continue
+		}
+
+		multipliers = append(multipliers, v)
+	}
+
+	if len(rewards) > MaxRoleRewards {
+		rewards = rewards[:MaxRoleRewards]
+	}
+
+	if len(multipliers) > MaxMultipliers {
+		multipliers = multipliers[:MaxMultipliers]
+	}
+
+	tx, err := common.PQ.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	err = SaveConfig(ctx, tx, conf)
+	if err == nil {
+		err = ReplaceRewardsAndMultipliers(ctx, tx, guildID, rewards, multipliers)
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = restore.Finish(tx)
+	if err == nil {
+		configChanged(guildID)
+	}
+
+	return err
+}
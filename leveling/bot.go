@@ -0,0 +1,281 @@
+package leveling
+
+import (
+	"context"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/analytics"
+	"github.com/botlabs-gg/yagpdb/v2/bot"
+	"github.com/botlabs-gg/yagpdb/v2/bot/eventsystem"
+	"github.com/botlabs-gg/yagpdb/v2/commands"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/templates"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+	"github.com/botlabs-gg/yagpdb/v2/lib/dstate"
+	"github.com/mediocregopher/radix/v3"
+)
+
+var _ bot.BotInitHandler = (*Plugin)(nil)
+var _ commands.CommandProvider = (*Plugin)(nil)
+
+func (p *Plugin) AddCommands() {
+	commands.AddRootCommands(p, cmds...)
+}
+
+func (p *Plugin) BotInit() {
+	eventsystem.AddHandlerAsyncLast(p, handleMessageCreate, eventsystem.EventMessageCreate)
+	eventsystem.AddHandlerAsyncLast(p, handleVoiceStateUpdate, eventsystem.EventVoiceStateUpdate)
+}
+
+var cachedConfig = common.CacheSet.RegisterSlot("leveling_config", nil, int64(0))
+
+func BotCachedGetConfig(ctx context.Context, guildID int64) (*Config, error) {
+	v, err := cachedConfig.GetCustomFetch(guildID, func(key interface{}) (interface{}, error) {
+		return GetConfig(ctx, guildID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*Config), nil
+}
+
+func KeyCooldown(guildID, userID int64) string {
+	return "leveling_cooldown:" + discordgo.StrID(guildID) + ":" + discordgo.StrID(userID)
+}
+
+func handleMessageCreate(evt *eventsystem.EventData) (retry bool, err error) {
+	msg := evt.MessageCreate()
+	if msg.GuildID == 0 || msg.Author == nil || msg.Author.Bot || msg.Member == nil {
+		return false, nil
+	}
+
+	if !bot.IsNormalUserMessage(msg.Message) || !evt.HasFeatureFlag(featureFlagEnabled) {
+		return false, nil
+	}
+
+	cs := evt.CSOrThread()
+	if cs == nil {
+		return false, nil
+	}
+
+	conf, err := BotCachedGetConfig(evt.Context(), msg.GuildID)
+	if err != nil || !conf.Enabled {
+		return bot.CheckDiscordErrRetry(err), err
+	}
+
+	if conf.IsBlacklisted(cs.ID, cs.ParentID, msg.Member.Roles) {
+		return false, nil
+	}
+
+	if conf.MessageCooldown > 0 {
+		var resp string
+		err = common.RedisPool.Do(radix.FlatCmd(&resp, "SET", KeyCooldown(msg.GuildID, msg.Author.ID), true, "EX", conf.MessageCooldown, "NX"))
+		if err != nil || resp != "OK" {
+			return false, err
+		}
+	}
+
+	amount := conf.MessageXPMin
+	if conf.MessageXPMax > conf.MessageXPMin {
+		amount += rand.Int63n(conf.MessageXPMax - conf.MessageXPMin + 1)
+	}
+	amount = ApplyMultiplier(amount, conf.XPMultiplier(cs.ID, cs.ParentID, msg.Member.Roles))
+	if amount < 1 {
+		return false, nil
+	}
+
+	change, err := AddXP(evt.Context(), conf, msg.Author.ID, amount, 1, 0)
+	if err != nil {
+		return false, err
+	}
+
+	if change.Changed() {
+		ms := dstate.MemberStateFromMember(msg.Member)
+		handleLevelChange(conf, evt.GS, ms, msg.ChannelID, change)
+	}
+
+	return false, nil
+}
+
+// voice sessions are stored in a redis hash per guild, the value is the unix time the session started and the channel
+func keyVoiceSessions(guildID int64) string {
+	return "leveling_voice_sessions:" + discordgo.StrID(guildID)
+}
+
+// Sessions longer than this are cut short, in case a leave was missed while the bot was down
+const maxVoiceSession = time.Hour * 12
+
+func handleVoiceStateUpdate(evt *eventsystem.EventData) (retry bool, err error) {
+	vs := evt.VoiceStateUpdate()
+	if vs.GuildID == 0 || !evt.HasFeatureFlag(featureFlagVoiceEnabled) {
+		return false, nil
+	}
+
+	conf, err := BotCachedGetConfig(evt.Context(), vs.GuildID)
+	if err != nil {
+		return bot.CheckDiscordErrRetry(err), err
+	}
+
+	key := keyVoiceSessions(vs.GuildID)
+	field := discordgo.StrID(vs.UserID)
+
+	var current string
+	err = common.RedisPool.Do(radix.Cmd(&current, "HGET", key, field))
+	if err != nil {
+		return false, err
+	}
+
+	sessionStart, sessionChannel := parseVoiceSession(current)
+
+	eligible := conf.Enabled && conf.VoiceXPPerMinute > 0 && vs.ChannelID != 0 && !vs.SelfDeaf && !vs.Deaf &&
+		(evt.GS == nil || vs.ChannelID != evt.GS.AfkChannelID)
+
+	if sessionChannel != 0 && eligible && sessionChannel == vs.ChannelID {
+		// still in the same session, e.g a mute toggle
+		return false, nil
+	}
+
+	if eligible {
+		err = common.RedisPool.Do(radix.FlatCmd(nil, "HSET", key, field, strconv.FormatInt(time.Now().Unix(), 10)+":"+discordgo.StrID(vs.ChannelID)))
+	} else if sessionChannel != 0 {
+		err = common.RedisPool.Do(radix.Cmd(nil, "HDEL", key, field))
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if sessionChannel == 0 {
+		return false, nil
+	}
+
+	duration := time.Since(sessionStart)
+	if duration > maxVoiceSession {
+		duration = maxVoiceSession
+	}
+
+	minutes := int64(duration / time.Minute)
+	if minutes < 1 || !conf.Enabled || conf.VoiceXPPerMinute < 1 {
+		return false, nil
+	}
+
+	ms, err := bot.GetMember(vs.GuildID, vs.UserID)
+	if err != nil || ms.User.Bot {
+		return false, nil
+	}
+
+	var parentID int64
+	if evt.GS != nil {
+		if cs := evt.GS.GetChannel(sessionChannel); cs != nil {
+			parentID = cs.ParentID
+		}
+	}
+
+	if conf.IsBlacklisted(sessionChannel, parentID, ms.Member.Roles) {
+		return false, nil
+	}
+
+	amount := ApplyMultiplier(minutes*conf.VoiceXPPerMinute, conf.XPMultiplier(sessionChannel, parentID, ms.Member.Roles))
+	if amount < 1 {
+		return false, nil
+	}
+
+	change, err := AddXP(evt.Context(), conf, vs.UserID, amount, 0, minutes)
+	if err != nil {
+		return false, err
+	}
+
+	if change.Changed() {
+		handleLevelChange(conf, evt.GS, ms, 0, change)
+	}
+
+	return false, nil
+}
+
+func parseVoiceSession(s string) (start time.Time, channelID int64) {
+	split := strings.SplitN(s, ":", 2)
+	if len(split) < 2 {
+		return
+	}
+
+	unix, _ := strconv.ParseInt(split[0], 10, 64)
+	channelID, _ = strconv.ParseInt(split[1], 10, 64)
+	return time.Unix(unix, 0), channelID
+}
+
+// handleLevelChange updates the reward roles of the member and announces level ups.
+// channelID is where the level up happened, 0 if it didn't happen in a text channel.
+func handleLevelChange(conf *Config, gs *dstate.GuildSet, ms *dstate.MemberState, channelID int64, change *LevelChange) {
+	if ms == nil {
+		return
+	}
+
+	updateRewardRoles(conf, ms, change.NewLevel)
+
+	if change.NewLevel <= change.OldLevel || !conf.AnnounceLevelUps || gs == nil {
+		return
+	}
+
+	announceChannel := conf.AnnounceChannel
+	if announceChannel == 0 {
+		announceChannel = channelID
+	}
+
+	cs := gs.GetChannelOrThread(announceChannel)
+	if cs == nil {
+		return
+	}
+
+	go analytics.RecordActiveUnit(gs.ID, &Plugin{}, "announced_level_up")
+
+	tmpl := conf.LevelUpMessage
+	if tmpl == "" {
+		tmpl = DefaultLevelUpMessage
+	}
+
+	ctx := templates.NewContext(gs, cs, ms)
+	ctx.Data["Level"] = change.NewLevel
+	ctx.Data["OldLevel"] = change.OldLevel
+	ctx.Data["XP"] = change.XP
+
+	out, err := ctx.Execute(tmpl)
+	if err != nil {
+		logger.WithError(err).WithField("guild", gs.ID).Warn("Failed executing level up template")
+		return
+	}
+
+	if strings.TrimSpace(out) == "" {
+		return
+	}
+
+	m, err := common.BotSession.ChannelMessageSendComplex(cs.ID, ctx.MessageSend(out))
+	if err != nil {
+		logger.WithError(err).WithField("guild", gs.ID).Debug("Failed sending level up message")
+		return
+	}
+
+	if ctx.CurrentFrame.DelResponse {
+		templates.MaybeScheduledDeleteMessage(gs.ID, cs.ID, m.ID, ctx.CurrentFrame.DelResponseDelay)
+	}
+}
+
+func updateRewardRoles(conf *Config, ms *dstate.MemberState, level int) {
+	add, remove := RewardRoleChanges(conf.RoleRewards, conf.StackRoleRewards, level, ms.Member.Roles)
+
+	for _, v := range add {
+		err := common.BotSession.GuildMemberRoleAdd(ms.GuildID, ms.User.ID, v)
+		if err != nil && !common.IsDiscordErr(err, discordgo.ErrCodeMissingPermissions, discordgo.ErrCodeUnknownRole) {
+			logger.WithError(err).WithField("guild", ms.GuildID).Error("Failed giving level reward role")
+		}
+	}
+
+	for _, v := range remove {
+		err := common.BotSession.GuildMemberRoleRemove(ms.GuildID, ms.User.ID, v)
+		if err != nil && !common.IsDiscordErr(err, discordgo.ErrCodeMissingPermissions, discordgo.ErrCodeUnknownRole) {
+			logger.WithError(err).WithField("guild", ms.GuildID).Error("Failed removing level reward role")
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package leveling
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/botlabs-gg/yagpdb/v2/bot"
+	"github.com/botlabs-gg/yagpdb/v2/bot/paginatedmessages"
+	"github.com/botlabs-gg/yagpdb/v2/commands"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/lib/dcmd"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+	"github.com/botlabs-gg/yagpdb/v2/web"
+)
+
+func levelingDisabledMessage(guild *dcmd.GuildContextData) string {
+	return fmt.Sprintf("**Leveling is disabled on this server.** Enable it at: <%s/leveling>.", web.ManageServerURL(guild))
+}
+
+const leaderboardPageSize = 15
+
+var cmds = []*commands.YAGCommand{
+	{
+		CmdCategory: commands.CategoryFun,
+		Name:        "Rank",
+		Aliases:     []string{"level", "xp"},
+		Description: "Shows yours or the specified users level, xp and rank",
+		Arguments: []*dcmd.ArgDef{
+			{Name: "User", Type: dcmd.User},
+		},
+		SlashCommandEnabled: true,
+		DefaultEnabled:      true,
+		RunFunc: func(parsed *dcmd.Data) (interface{}, error) {
+			conf, err := GetConfig(parsed.Context(), parsed.GuildData.GS.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			if !conf.Enabled {
+				return levelingDisabledMessage(parsed.GuildData), nil
+			}
+
+			target := parsed.Author
+			if parsed.Args[0].Value != nil {
+				target = parsed.Args[0].Value.(*discordgo.User)
+			}
+
+			user, rank, err := GetUserStats(parsed.Context(), parsed.GuildData.GS.ID, target.ID)
+			if err != nil && err != ErrUserNotFound {
+				return nil, err
+			}
+
+			rankStr := "#ω"
+			if err == nil {
+				rankStr = "#" + strconv.Itoa(rank)
+			}
+
+			level := conf.LevelForXP(user.XP)
+			embed := &discordgo.MessageEmbed{
+				Author: &discordgo.MessageEmbedAuthor{
+					Name:    target.String(),
+					IconURL: target.AvatarURL("256"),
+				},
+				Color: 0x4cb7e4,
+				Fields: []*discordgo.MessageEmbedField{
+					{Name: "Level", Value: strconv.Itoa(level), Inline: true},
+					{Name: "Rank", Value: rankStr, Inline: true},
+					{Name: "Total XP", Value: strconv.FormatInt(user.XP, 10), Inline: true},
+				},
+			}
+
+			if level < MaxLevel {
+				progress := user.XP - conf.XPForLevel(level)
+				needed := conf.XPToNextLevel(level)
+				embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+					Name:  fmt.Sprintf("Progress to level %d", level+1),
+					Value: fmt.Sprintf("%s `%d/%d`", progressBar(progress, needed, 12), progress, needed),
+				})
+			}
+
+			if user.Messages > 0 || user.VoiceMinutes > 0 {
+				embed.Footer = &discordgo.MessageEmbedFooter{
+					Text: fmt.Sprintf("%d messages, %d minutes in voice", user.Messages, user.VoiceMinutes),
+				}
+			}
+
+			return embed, nil
+		},
+	},
+	{
+		CmdCategory: commands.CategoryFun,
+		Name:        "Levels",
+		Aliases:     []string{"leaderboard", "toplevels"},
+		Description: "Shows the xp leaderboard on the server",
+		Arguments: []*dcmd.ArgDef{
+			{Name: "Page", Type: dcmd.Int, Default: 0},
+		},
+		ArgSwitches: []*dcmd.ArgDef{
+			{Name: "user", Help: "User to search for in the leaderboard", Type: dcmd.UserID},
+		},
+		SlashCommandEnabled: true,
+		DefaultEnabled:      true,
+		RunFunc: func(parsed *dcmd.Data) (interface{}, error) {
+			conf, err := GetConfig(parsed.Context(), parsed.GuildData.GS.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			if !conf.Enabled {
+				return levelingDisabledMessage(parsed.GuildData), nil
+			}
+
+			page := parsed.Args[0].Int()
+			if id := parsed.Switch("user").Int64(); id != 0 {
+				const query = `
+					SELECT pos
+					FROM (
+						SELECT ROW_NUMBER() OVER (ORDER BY xp DESC, user_id ASC) AS pos, user_id
+						FROM leveling_users
+						WHERE guild_id = $1
+					) as ordered_users
+					WHERE user_id = $2
+				`
+
+				var pos int
+				err := common.PQ.QueryRow(query, parsed.GuildData.GS.ID, id).Scan(&pos)
+				if err != nil {
+					if err == sql.ErrNoRows {
+						return "Could not find that user on the leaderboard", nil
+					}
+					return "Failed finding that user on the leaderboard, try again", err
+				}
+
+				page = (pos-1)/leaderboardPageSize + 1
+			}
+
+			if page < 1 {
+				page = 1
+			}
+
+			if parsed.Context().Value(paginatedmessages.CtxKeyNoPagination) != nil {
+				return leaderboardPager(conf, nil, page)
+			}
+
+			_, err = paginatedmessages.CreatePaginatedMessage(parsed.GuildData.GS.ID, parsed.ChannelID, page, 0, func(p *paginatedmessages.PaginatedMessage, page int) (*discordgo.MessageEmbed, error) {
+				return leaderboardPager(conf, p, page)
+			})
+
+			return nil, err
+		},
+	},
+	{
+		CmdCategory:         commands.CategoryFun,
+		Name:                "GiveXP",
+		Description:         "Gives xp to someone, use a negative amount to take xp away. Updates their level and reward roles.",
+		RequiredArgs:        2,
+		RequireDiscordPerms: []int64{discordgo.PermissionManageServer},
+		SlashCommandEnabled: true,
+		DefaultEnabled:      false,
+		Arguments: []*dcmd.ArgDef{
+			{Name: "User", Type: dcmd.UserID},
+			{Name: "Amount", Type: &dcmd.IntArg{Min: -MaxXP, Max: MaxXP}},
+		},
+		RunFunc: func(parsed *dcmd.Data) (interface{}, error) {
+			return cmdAdjustXP(parsed, parsed.Args[0].Int64(), func(conf *Config) (*LevelChange, error) {
+				return AddXP(parsed.Context(), conf, parsed.Args[0].Int64(), parsed.Args[1].Int64(), 0, 0)
+			})
+		},
+	},
+	{
+		CmdCategory:         commands.CategoryFun,
+		Name:                "SetXP",
+		Description:         "Sets someones xp. Updates their level and reward roles.",
+		RequiredArgs:        2,
+		RequireDiscordPerms: []int64{discordgo.PermissionManageServer},
+		SlashCommandEnabled: true,
+		DefaultEnabled:      false,
+		Arguments: []*dcmd.ArgDef{
+			{Name: "User", Type: dcmd.UserID},
+			{Name: "XP", Type: &dcmd.IntArg{Min: 0, Max: MaxXP}},
+		},
+		RunFunc: func(parsed *dcmd.Data) (interface{}, error) {
+			return cmdAdjustXP(parsed, parsed.Args[0].Int64(), func(conf *Config) (*LevelChange, error) {
+				return SetXP(parsed.Context(), conf, parsed.Args[0].Int64(), parsed.Args[1].Int64())
+			})
+		},
+	},
+	{
+		CmdCategory:         commands.CategoryFun,
+		Name:                "SetLevel",
+		Description:         "Sets someones xp to the start of the level. Updates their reward roles.",
+		RequiredArgs:        2,
+		RequireDiscordPerms: []int64{discordgo.PermissionManageServer},
+		SlashCommandEnabled: true,
+		DefaultEnabled:      false,
+		Arguments: []*dcmd.ArgDef{
+			{Name: "User", Type: dcmd.UserID},
+			{Name: "Level", Type: &dcmd.IntArg{Min: 0, Max: MaxLevel}},
+		},
+		RunFunc: func(parsed *dcmd.Data) (interface{}, error) {
+			return cmdAdjustXP(parsed, parsed.Args[0].Int64(), func(conf *Config) (*LevelChange, error) {
+				return SetXP(parsed.Context(), conf, parsed.Args[0].Int64(), conf.XPForLevel(parsed.Args[1].Int()))
+			})
+		},
+	},
+	{
+		CmdCategory:         commands.CategoryFun,
+		Name:                "ResetXP",
+		Description:         "Resets someones xp to 0, removing their reward roles. Resetting everyone is done in the control panel.",
+		RequiredArgs:        1,
+		RequireDiscordPerms: []int64{discordgo.PermissionManageServer},
+		SlashCommandEnabled: true,
+		DefaultEnabled:      false,
+		Arguments: []*dcmd.ArgDef{
+			{Name: "User", Type: dcmd.UserID},
+		},
+		RunFunc: func(parsed *dcmd.Data) (interface{}, error) {
+			return cmdAdjustXP(parsed, parsed.Args[0].Int64(), func(conf *Config) (*LevelChange, error) {
+				return SetXP(parsed.Context(), conf, parsed.Args[0].Int64(), 0)
+			})
+		},
+	},
+}
+
+// cmdAdjustXP runs the xp change and updates the reward roles of the target
+func cmdAdjustXP(parsed *dcmd.Data, targetID int64, f func(conf *Config) (*LevelChange, error)) (interface{}, error) {
+	conf, err := GetConfig(parsed.Context(), parsed.GuildData.GS.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !conf.Enabled {
+		return levelingDisabledMessage(parsed.GuildData), nil
+	}
+
+	change, err := f(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	targetName := strconv.FormatInt(targetID, 10)
+	ms, _ := bot.GetMember(parsed.GuildData.GS.ID, targetID)
+	if ms != nil {
+		targetName = ms.User.String()
+		// don't announce level ups given by admins, only update the roles
+		updateRewardRoles(conf, ms, change.NewLevel)
+	}
+
+	return fmt.Sprintf("**%s** now has `%d` xp and is level `%d`", targetName, change.XP, change.NewLevel), nil
+}
+
+func leaderboardPager(conf *Config, p *paginatedmessages.PaginatedMessage, page int) (*discordgo.MessageEmbed, error) {
+	offset := (page - 1) * leaderboardPageSize
+	entries, err := TopUsers(context.Background(), conf.GuildID, offset, leaderboardPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entries) < 1 && p != nil && p.LastResponse != nil { //Dont send No Results error on first execution
+		return nil, paginatedmessages.ErrNoResults
+	}
+
+	detailed, err := DetailedLeaderboardEntries(conf.GuildID, entries)
+	if err != nil {
+		return nil, err
+	}
+
+	var out strings.Builder
+	out.WriteString("```\n# -- Level --     XP -- User\n")
+	for _, v := range detailed {
+		out.WriteString(fmt.Sprintf("#%02d: %5d - %8d - %s\n", v.Rank, v.Level, v.XP, v.Username))
+	}
+	out.WriteString("```")
+
+	if conf.PublicLeaderboard {
+		out.WriteString("\nFull leaderboard: <" + web.BaseURL() + "/public/" + discordgo.StrID(conf.GuildID) + "/leveling/leaderboard>")
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       "XP leaderboard",
+		Description: out.String(),
+	}, nil
+}
+
+func progressBar(current, total int64, width int) string {
+	filled := 0
+	if total > 0 {
+		filled = int(current * int64(width) / total)
+	}
+
+	if filled > width {
+		filled = width
+	} else if filled < 0 {
+		filled = 0
+	}
+
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
@@ -0,0 +1,301 @@
+package leveling
+
+import (
+	"context"
+	"math"
+	"strconv"
+
+	"emperror.dev/errors"
+	"github.com/botlabs-gg/yagpdb/v2/bot"
+	"github.com/botlabs-gg/yagpdb/v2/bot/botrest"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/featureflags"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+	"github.com/botlabs-gg/yagpdb/v2/lib/dstate"
+)
+
+var logger = common.GetPluginLogger(&Plugin{})
+
+const (
+	// Levels above this are not reached no matter the xp
+	MaxLevel = 1000
+
+	MaxRoleRewards = 50
+	MaxMultipliers = 50
+
+	// Max xp that can be given or set through the commands
+	MaxXP = 1000000000
+)
+
+type Plugin struct{}
+
+func RegisterPlugin() {
+	common.InitSchemas("leveling", DBSchemas...)
+
+	common.RegisterPlugin(&Plugin{})
+}
+
+func (p *Plugin) PluginInfo() *common.PluginInfo {
+	return &common.PluginInfo{
+		Name:     "Leveling",
+		SysName:  "leveling",
+		Category: common.PluginCategoryMisc,
+	}
+}
+
+// XPToNextLevel returns the xp needed to go from level to level+1
+func (c *Config) XPToNextLevel(level int) int64 {
+	l := int64(level)
+	xp := c.CurveQuadratic*l*l + c.CurveLinear*l + c.CurveBase
+	if xp < 1 {
+		return 1
+	}
+
+	return xp
+}
+
+// XPForLevel returns the total xp needed to reach the level
+func (c *Config) XPForLevel(level int) int64 {
+	if level > MaxLevel {
+		level = MaxLevel
+	}
+
+	var total int64
+	for i := 0; i < level; i++ {
+		total += c.XPToNextLevel(i)
+	}
+
+	return total
+}
+
+// LevelForXP returns the level reached with the total xp
+func (c *Config) LevelForXP(xp int64) int {
+	level := 0
+	for level < MaxLevel {
+		needed := c.XPToNextLevel(level)
+		if xp < needed {
+			break
+		}
+
+		xp -= needed
+		level++
+	}
+
+	return level
+}
+
+// XPMultiplier returns the multiplier for xp gained in the channel by a member with the roles.
+// A multiplier set on a thread or channel takes priority over one set on its parent,
+// and the highest role multiplier the member has is used.
+func (c *Config) XPMultiplier(channelID, parentID int64, roles []int64) float64 {
+	channelMultiplier := 1.0
+	foundChannel := false
+	roleMultiplier := 0.0
+
+	for _, v := range c.Multipliers {
+		switch v.TargetType {
+		case MultiplierTargetChannel:
+			if v.TargetID == channelID {
+				channelMultiplier = v.Multiplier
+				foundChannel = true
+			} else if v.TargetID == parentID && parentID != 0 && !foundChannel {
+				channelMultiplier = v.Multiplier
+			}
+		case MultiplierTargetRole:
+			if common.ContainsInt64Slice(roles, v.TargetID) && v.Multiplier > roleMultiplier {
+				roleMultiplier = v.Multiplier
+			}
+		}
+	}
+
+	if roleMultiplier == 0 {
+		roleMultiplier = 1
+	}
+
+	return channelMultiplier * roleMultiplier
+}
+
+// ApplyMultiplier returns the xp multiplied, rounded to the nearest whole number
+func ApplyMultiplier(xp int64, multiplier float64) int64 {
+	return int64(math.Round(float64(xp) * multiplier))
+}
+
+// IsBlacklisted returns true if no xp should be given in the channel or to a member with the roles
+func (c *Config) IsBlacklisted(channelID, parentID int64, roles []int64) bool {
+	if common.ContainsInt64Slice(c.NoXPChannels, channelID) || (parentID != 0 && common.ContainsInt64Slice(c.NoXPChannels, parentID)) {
+		return true
+	}
+
+	return common.ContainsInt64SliceOneOf(roles, c.NoXPRoles)
+}
+
+// RewardRoleChanges returns the reward roles to give and take away from a member with the current roles at the level
+func RewardRoleChanges(rewards []*RoleReward, stack bool, level int, currentRoles []int64) (add, remove []int64) {
+	highest := -1
+	for _, v := range rewards {
+		if v.Level <= level && v.Level > highest {
+			highest = v.Level
+		}
+	}
+
+	for _, v := range rewards {
+		keep := v.Level <= level
+		if !stack && v.Level != highest {
+			keep = false
+		}
+
+		has := common.ContainsInt64Slice(currentRoles, v.RoleID)
+		if keep && !has && !common.ContainsInt64Slice(add, v.RoleID) {
+			add = append(add, v.RoleID)
+		} else if !keep && has && !common.ContainsInt64Slice(remove, v.RoleID) {
+			remove = append(remove, v.RoleID)
+		}
+	}
+
+	return
+}
+
+// LevelChange is the outcome of changing the xp of a member
+type LevelChange struct {
+	XP       int64
+	OldLevel int
+	NewLevel int
+}
+
+// Changed returns true if the level changed
+func (l *LevelChange) Changed() bool {
+	return l.OldLevel != l.NewLevel
+}
+
+// AddXP adds xp to the user, negative amounts take away xp.
+// messages and voiceMinutes are added to the activity counters of the user.
+func AddXP(ctx context.Context, conf *Config, userID, amount, messages, voiceMinutes int64) (*LevelChange, error) {
+	xp, storedLevel, err := addXP(ctx, conf.GuildID, userID, amount, messages, voiceMinutes)
+	if err != nil {
+		return nil, errors.WrapIf(err, "leveling.AddXP")
+	}
+
+	return storeLevel(ctx, conf, userID, xp, storedLevel)
+}
+
+// SetXP sets the xp of the user
+func SetXP(ctx context.Context, conf *Config, userID, xp int64) (*LevelChange, error) {
+	storedLevel, err := setXP(ctx, conf.GuildID, userID, xp)
+	if err != nil {
+		return nil, errors.WrapIf(err, "leveling.SetXP")
+	}
+
+	return storeLevel(ctx, conf, userID, xp, storedLevel)
+}
+
+func storeLevel(ctx context.Context, conf *Config, userID, xp int64, storedLevel int) (*LevelChange, error) {
+	change := &LevelChange{
+		XP:       xp,
+		OldLevel: storedLevel,
+		NewLevel: conf.LevelForXP(xp),
+	}
+
+	if !change.Changed() {
+		return change, nil
+	}
+
+	updated, err := updateLevel(ctx, conf.GuildID, userID, storedLevel, change.NewLevel)
+	if err != nil {
+		return nil, errors.WrapIf(err, "leveling.updateLevel")
+	}
+
+	if !updated {
+		// a concurrent change already handled it
+		change.NewLevel = storedLevel
+	}
+
+	return change, nil
+}
+
+type LeaderboardEntry struct {
+	*RankEntry
+	Username string `json:"username"`
+	Bot      bool   `json:"bot"`
+	Avatar   string `json:"avatar"`
+}
+
+// DetailedLeaderboardEntries adds the usernames and avatars to the leaderboard entries
+func DetailedLeaderboardEntries(guildID int64, ranks []*RankEntry) ([]*LeaderboardEntry, error) {
+	if len(ranks) < 1 {
+		return []*LeaderboardEntry{}, nil
+	}
+
+	userIDs := make([]int64, len(ranks))
+	for i, v := range ranks {
+		userIDs[i] = v.UserID
+	}
+
+	var members []*discordgo.Member
+	var err error
+	if bot.Running {
+		var tmp []*dstate.MemberState
+		tmp, err = bot.GetMembers(guildID, userIDs...)
+		for _, v := range tmp {
+			members = append(members, v.DgoMember())
+		}
+	} else {
+		members, err = botrest.GetMembers(guildID, userIDs...)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*LeaderboardEntry, len(ranks))
+	for i, v := range ranks {
+		entry := &LeaderboardEntry{
+			RankEntry: v,
+			Username:  "unknown ID:" + strconv.FormatInt(v.UserID, 10),
+		}
+
+		for _, m := range members {
+			if m.User.ID == v.UserID {
+				entry.Username = m.User.String()
+				entry.Avatar = m.User.AvatarURL("256")
+				entry.Bot = m.User.Bot
+				break
+			}
+		}
+
+		result[i] = entry
+	}
+
+	return result, nil
+}
+
+var _ featureflags.PluginWithFeatureFlags = (*Plugin)(nil)
+
+const (
+	featureFlagEnabled      = "leveling_enabled"
+	featureFlagVoiceEnabled = "leveling_voice_enabled"
+)
+
+func (p *Plugin) UpdateFeatureFlags(guildID int64) ([]string, error) {
+	conf, err := GetConfig(context.Background(), guildID)
+	if err != nil {
+		return nil, errors.WithStackIf(err)
+	}
+
+	var flags []string
+	if conf.Enabled {
+		flags = append(flags, featureFlagEnabled)
+
+		if conf.VoiceXPPerMinute > 0 {
+			flags = append(flags, featureFlagVoiceEnabled)
+		}
+	}
+
+	return flags, nil
+}
+
+func (p *Plugin) AllFeatureFlags() []string {
+	return []string{
+		featureFlagEnabled,      // set if leveling is enabled on this server
+		featureFlagVoiceEnabled, // set if leveling is enabled and voice xp is given
+	}
+}
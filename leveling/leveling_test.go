@@ -0,0 +1,95 @@
+package leveling
+
+import (
+	"testing"
+)
+
+func TestLevelCurve(t *testing.T) {
+	conf := DefaultConfig(1)
+
+	// 100, 155, 220
+	cases := []struct {
+		xp    int64
+		level int
+	}{
+		{0, 0},
+		{99, 0},
+		{100, 1},
+		{254, 1},
+		{255, 2},
+		{474, 2},
+		{475, 3},
+	}
+
+	for _, c := range cases {
+		if got := conf.LevelForXP(c.xp); got != c.level {
+			t.Errorf("LevelForXP(%d) = %d, expected %d", c.xp, got, c.level)
+		}
+	}
+
+	for level := 0; level < 50; level++ {
+		xp := conf.XPForLevel(level)
+		if got := conf.LevelForXP(xp); got != level {
+			t.Errorf("LevelForXP(XPForLevel(%d)) = %d", level, got)
+		}
+	}
+
+	if got := conf.LevelForXP(1 << 62); got != MaxLevel {
+		t.Errorf("LevelForXP(huge) = %d, expected %d", got, MaxLevel)
+	}
+}
+
+func TestXPMultiplier(t *testing.T) {
+	conf := &Config{
+		Multipliers: []*Multiplier{
+			{TargetType: MultiplierTargetChannel, TargetID: 10, Multiplier: 2},
+			{TargetType: MultiplierTargetChannel, TargetID: 11, Multiplier: 0.5},
+			{TargetType: MultiplierTargetRole, TargetID: 20, Multiplier: 1.5},
+			{TargetType: MultiplierTargetRole, TargetID: 21, Multiplier: 3},
+		},
+	}
+
+	cases := []struct {
+		name      string
+		channelID int64
+		parentID  int64
+		roles     []int64
+		expected  float64
+	}{
+		{"none", 1, 0, nil, 1},
+		{"channel", 10, 0, nil, 2},
+		{"parent", 1, 10, nil, 2},
+		{"thread over parent", 11, 10, nil, 0.5},
+		{"highest role", 1, 0, []int64{20, 21}, 3},
+		{"channel and role", 10, 0, []int64{20}, 3},
+	}
+
+	for _, c := range cases {
+		if got := conf.XPMultiplier(c.channelID, c.parentID, c.roles); got != c.expected {
+			t.Errorf("%s: got %v, expected %v", c.name, got, c.expected)
+		}
+	}
+}
+
+func TestRewardRoleChanges(t *testing.T) {
+	rewards := []*RoleReward{
+		{Level: 5, RoleID: 1},
+		{Level: 10, RoleID: 2},
+		{Level: 20, RoleID: 3},
+	}
+
+	add, remove := RewardRoleChanges(rewards, true, 12, []int64{1})
+	if len(add) != 1 || add[0] != 2 || len(remove) != 0 {
+		t.Errorf("stacking: got add %v remove %v", add, remove)
+	}
+
+	add, remove = RewardRoleChanges(rewards, false, 12, []int64{1})
+	if len(add) != 1 || add[0] != 2 || len(remove) != 1 || remove[0] != 1 {
+		t.Errorf("replacing: got add %v remove %v", add, remove)
+	}
+
+	add, remove = RewardRoleChanges(rewards, true, 0, []int64{1, 2, 99})
+	if len(add) != 0 || len(remove) != 2 {
+		t.Errorf("reset: got add %v remove %v", add, remove)
+	}
+}
@@ -0,0 +1,344 @@
+package leveling
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/lib/pq"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// Config is the leveling config of a guild
+type Config struct {
+	GuildID int64
+	Enabled bool
+
+	// Random amount of xp given per message, at most once per cooldown
+	MessageXPMin    int64
+	MessageXPMax    int64
+	MessageCooldown int
+
+	// 0 to disable voice xp
+	VoiceXPPerMinute int64
+
+	// The xp needed to go from level n to n+1 is CurveQuadratic*n^2 + CurveLinear*n + CurveBase
+	CurveBase      int64
+	CurveLinear    int64
+	CurveQuadratic int64
+
+	NoXPChannels pq.Int64Array
+	NoXPRoles    pq.Int64Array
+
+	AnnounceLevelUps bool
+	// 0 to announce in the channel the message that caused the level up was sent in
+	AnnounceChannel int64
+	LevelUpMessage  string
+
+	// If set members keep the rewards of lower levels, otherwise only the rewards of the highest level reached are kept
+	StackRoleRewards  bool
+	PublicLeaderboard bool
+
+	RoleRewards []*RoleReward
+	Multipliers []*Multiplier
+}
+
+const DefaultLevelUpMessage = "Congratulations {{.User.Mention}}, you reached level **{{.Level}}**!"
+
+func DefaultConfig(guildID int64) *Config {
+	return &Config{
+		GuildID:          guildID,
+		MessageXPMin:     15,
+		MessageXPMax:     25,
+		MessageCooldown:  60,
+		VoiceXPPerMinute: 0,
+		CurveBase:        100,
+		CurveLinear:      50,
+		CurveQuadratic:   5,
+		AnnounceLevelUps: true,
+		LevelUpMessage:   DefaultLevelUpMessage,
+		StackRoleRewards: true,
+	}
+}
+
+// RoleReward is a role given to members when they reach a level
+type RoleReward struct {
+	ID      int64
+	GuildID int64
+	Level   int
+	RoleID  int64
+}
+
+type MultiplierTarget int
+
+const (
+	MultiplierTargetChannel MultiplierTarget = 1
+	MultiplierTargetRole    MultiplierTarget = 2
+)
+
+// Multiplier multiplies the xp gained in a channel or by members with a role
+type Multiplier struct {
+	ID         int64
+	GuildID    int64
+	TargetType MultiplierTarget
+	TargetID   int64
+	Multiplier float64
+}
+
+const configColumns = `guild_id, enabled, message_xp_min, message_xp_max, message_cooldown, voice_xp_per_minute,
+curve_base, curve_linear, curve_quadratic, no_xp_channels, no_xp_roles,
+announce_level_ups, announce_channel, level_up_message, stack_role_rewards, public_leaderboard`
+
+// GetConfig returns the config of the guild including the role rewards and multipliers, or the default config if it has none
+func GetConfig(ctx context.Context, guildID int64) (*Config, error) {
+	c := &Config{}
+	err := common.PQ.QueryRowContext(ctx, `SELECT `+configColumns+` FROM leveling_configs WHERE guild_id = $1`, guildID).Scan(
+		&c.GuildID, &c.Enabled, &c.MessageXPMin, &c.MessageXPMax, &c.MessageCooldown, &c.VoiceXPPerMinute,
+		&c.CurveBase, &c.CurveLinear, &c.CurveQuadratic, &c.NoXPChannels, &c.NoXPRoles,
+		&c.AnnounceLevelUps, &c.AnnounceChannel, &c.LevelUpMessage, &c.StackRoleRewards, &c.PublicLeaderboard)
+	if err == sql.ErrNoRows {
+		c = DefaultConfig(guildID)
+	} else if err != nil {
+		return nil, errors.WrapIf(err, "leveling.GetConfig")
+	}
+
+	c.RoleRewards, err = GuildRoleRewards(ctx, guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Multipliers, err = GuildMultipliers(ctx, guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// SaveConfig saves the config, the role rewards and multipliers are saved separately
+func SaveConfig(ctx context.Context, exec boil.ContextExecutor, c *Config) error {
+	const q = `INSERT INTO leveling_configs (` + configColumns + `)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+ON CONFLICT (guild_id) DO UPDATE SET
+enabled = $2, message_xp_min = $3, message_xp_max = $4, message_cooldown = $5, voice_xp_per_minute = $6,
+curve_base = $7, curve_linear = $8, curve_quadratic = $9, no_xp_channels = $10, no_xp_roles = $11,
+announce_level_ups = $12, announce_channel = $13, level_up_message = $14, stack_role_rewards = $15, public_leaderboard = $16`
+
+	_, err := exec.ExecContext(ctx, q, c.GuildID, c.Enabled, c.MessageXPMin, c.MessageXPMax, c.MessageCooldown, c.VoiceXPPerMinute,
+		c.CurveBase, c.CurveLinear, c.CurveQuadratic, c.NoXPChannels, c.NoXPRoles,
+		c.AnnounceLevelUps, c.AnnounceChannel, c.LevelUpMessage, c.StackRoleRewards, c.PublicLeaderboard)
+	return err
+}
+
+// GuildRoleRewards returns the role rewards of the guild ordered by level
+func GuildRoleRewards(ctx context.Context, guildID int64) ([]*RoleReward, error) {
+	rows, err := common.PQ.QueryContext(ctx, `SELECT id, guild_id, level, role_id FROM leveling_role_rewards WHERE guild_id = $1 ORDER BY level ASC, id ASC`, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*RoleReward, 0)
+	for rows.Next() {
+		r := &RoleReward{}
+		err = rows.Scan(&r.ID, &r.GuildID, &r.Level, &r.RoleID)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, r)
+	}
+
+	return result, rows.Err()
+}
+
+// GuildMultipliers returns the xp multipliers of the guild
+func GuildMultipliers(ctx context.Context, guildID int64) ([]*Multiplier, error) {
+	rows, err := common.PQ.QueryContext(ctx, `SELECT id, guild_id, target_type, target_id, multiplier FROM leveling_multipliers WHERE guild_id = $1 ORDER BY id ASC`, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*Multiplier, 0)
+	for rows.Next() {
+		m := &Multiplier{}
+		err = rows.Scan(&m.ID, &m.GuildID, &m.TargetType, &m.TargetID, &m.Multiplier)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, m)
+	}
+
+	return result, rows.Err()
+}
+
+// InsertExec inserts the reward, replacing the level of a existing reward for the same role
+func (r *RoleReward) InsertExec(ctx context.Context, exec boil.ContextExecutor) error {
+	const q = `INSERT INTO leveling_role_rewards (guild_id, level, role_id) VALUES ($1, $2, $3)
+ON CONFLICT (guild_id, role_id) DO UPDATE SET level = $2
+RETURNING id`
+
+	return exec.QueryRowContext(ctx, q, r.GuildID, r.Level, r.RoleID).Scan(&r.ID)
+}
+
+// InsertExec inserts the multiplier, replacing the value of a existing multiplier for the same target
+func (m *Multiplier) InsertExec(ctx context.Context, exec boil.ContextExecutor) error {
+	const q = `INSERT INTO leveling_multipliers (guild_id, target_type, target_id, multiplier) VALUES ($1, $2, $3, $4)
+ON CONFLICT (guild_id, target_type, target_id) DO UPDATE SET multiplier = $4
+RETURNING id`
+
+	return exec.QueryRowContext(ctx, q, m.GuildID, m.TargetType, m.TargetID, m.Multiplier).Scan(&m.ID)
+}
+
+func DeleteRoleReward(ctx context.Context, guildID, id int64) error {
+	_, err := common.PQ.ExecContext(ctx, `DELETE FROM leveling_role_rewards WHERE guild_id = $1 AND id = $2`, guildID, id)
+	return err
+}
+
+func DeleteMultiplier(ctx context.Context, guildID, id int64) error {
+	_, err := common.PQ.ExecContext(ctx, `DELETE FROM leveling_multipliers WHERE guild_id = $1 AND id = $2`, guildID, id)
+	return err
+}
+
+// ReplaceRewardsAndMultipliers replaces all the role rewards and multipliers of the guild
+func ReplaceRewardsAndMultipliers(ctx context.Context, tx *sql.Tx, guildID int64, rewards []*RoleReward, multipliers []*Multiplier) error {
+	_, err := tx.ExecContext(ctx, `DELETE FROM leveling_role_rewards WHERE guild_id = $1`, guildID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM leveling_multipliers WHERE guild_id = $1`, guildID)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range rewards {
+		v.GuildID = guildID
+		if err = v.InsertExec(ctx, tx); err != nil {
+			return err
+		}
+	}
+
+	for _, v := range multipliers {
+		v.GuildID = guildID
+		if err = v.InsertExec(ctx, tx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UserXP is the xp of a member
+type UserXP struct {
+	GuildID int64
+	UserID  int64
+
+	XP           int64
+	Level        int
+	Messages     int64
+	VoiceMinutes int64
+	UpdatedAt    time.Time
+}
+
+var ErrUserNotFound = errors.New("User not found")
+
+// GetUserStats returns the xp of the user and their position on the leaderboard
+func GetUserStats(ctx context.Context, guildID, userID int64) (user *UserXP, rank int, err error) {
+	const q = `SELECT xp, level, messages, voice_minutes, updated_at, position FROM
+(
+	SELECT user_id, xp, level, messages, voice_minutes, updated_at,
+	RANK() OVER(ORDER BY xp DESC) AS position
+	FROM leveling_users WHERE guild_id = $1
+) AS w
+WHERE user_id = $2`
+
+	user = &UserXP{GuildID: guildID, UserID: userID}
+	err = common.PQ.QueryRowContext(ctx, q, guildID, userID).Scan(&user.XP, &user.Level, &user.Messages, &user.VoiceMinutes, &user.UpdatedAt, &rank)
+	if err == sql.ErrNoRows {
+		err = ErrUserNotFound
+	}
+
+	return
+}
+
+type RankEntry struct {
+	Rank   int   `json:"rank"`
+	UserID int64 `json:"user_id"`
+	XP     int64 `json:"xp"`
+	Level  int   `json:"level"`
+}
+
+// TopUsers returns a page of the leaderboard
+func TopUsers(ctx context.Context, guildID int64, offset, limit int) ([]*RankEntry, error) {
+	const q = `SELECT user_id, xp, level, RANK() OVER(ORDER BY xp DESC) AS position
+FROM leveling_users WHERE guild_id = $1
+ORDER BY xp DESC, user_id ASC
+LIMIT $2 OFFSET $3`
+
+	rows, err := common.PQ.QueryContext(ctx, q, guildID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*RankEntry, 0, limit)
+	for rows.Next() {
+		e := &RankEntry{}
+		err = rows.Scan(&e.UserID, &e.XP, &e.Level, &e.Rank)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, e)
+	}
+
+	return result, rows.Err()
+}
+
+// addXP adds xp to the user, the xp can't go below 0. Returns the new xp and the level stored before this change.
+func addXP(ctx context.Context, guildID, userID, amount, messages, voiceMinutes int64) (xp int64, storedLevel int, err error) {
+	const q = `INSERT INTO leveling_users (guild_id, user_id, xp, level, messages, voice_minutes, updated_at)
+VALUES ($1, $2, GREATEST($3, 0), 0, $4, $5, now())
+ON CONFLICT (guild_id, user_id) DO UPDATE SET
+xp = GREATEST(leveling_users.xp + $3, 0),
+messages = leveling_users.messages + $4,
+voice_minutes = leveling_users.voice_minutes + $5,
+updated_at = now()
+RETURNING xp, level`
+
+	err = common.PQ.QueryRowContext(ctx, q, guildID, userID, amount, messages, voiceMinutes).Scan(&xp, &storedLevel)
+	return
+}
+
+// setXP sets the xp of the user, returning the level stored before this change
+func setXP(ctx context.Context, guildID, userID, xp int64) (storedLevel int, err error) {
+	const q = `INSERT INTO leveling_users (guild_id, user_id, xp, level, messages, voice_minutes, updated_at)
+VALUES ($1, $2, $3, 0, 0, 0, now())
+ON CONFLICT (guild_id, user_id) DO UPDATE SET xp = $3, updated_at = now()
+RETURNING level`
+
+	err = common.PQ.QueryRowContext(ctx, q, guildID, userID, xp).Scan(&storedLevel)
+	return
+}
+
+// updateLevel stores the new level if the stored one is still the old level, returning false if something else changed it first
+func updateLevel(ctx context.Context, guildID, userID int64, oldLevel, newLevel int) (bool, error) {
+	res, err := common.PQ.ExecContext(ctx, `UPDATE leveling_users SET level = $4 WHERE guild_id = $1 AND user_id = $2 AND level = $3`, guildID, userID, oldLevel, newLevel)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// ResetGuildXP deletes the xp of all members in the guild
+func ResetGuildXP(ctx context.Context, guildID int64) error {
+	_, err := common.PQ.ExecContext(ctx, `DELETE FROM leveling_users WHERE guild_id = $1`, guildID)
+	return err
+}
@@ -0,0 +1,61 @@
+package leveling
+
+var DBSchemas = []string{`
+CREATE TABLE IF NOT EXISTS leveling_configs (
+	guild_id BIGINT PRIMARY KEY,
+	enabled BOOLEAN NOT NULL,
+
+	message_xp_min BIGINT NOT NULL,
+	message_xp_max BIGINT NOT NULL,
+	message_cooldown INT NOT NULL,
+	voice_xp_per_minute BIGINT NOT NULL,
+
+	curve_base BIGINT NOT NULL,
+	curve_linear BIGINT NOT NULL,
+	curve_quadratic BIGINT NOT NULL,
+
+	no_xp_channels BIGINT[],
+	no_xp_roles BIGINT[],
+
+	announce_level_ups BOOLEAN NOT NULL,
+	announce_channel BIGINT NOT NULL,
+	level_up_message TEXT NOT NULL,
+
+	stack_role_rewards BOOLEAN NOT NULL,
+	public_leaderboard BOOLEAN NOT NULL
+);
+`, `
+CREATE TABLE IF NOT EXISTS leveling_role_rewards (
+	id BIGSERIAL PRIMARY KEY,
+	guild_id BIGINT NOT NULL,
+	level INT NOT NULL,
+	role_id BIGINT NOT NULL,
+
+	UNIQUE(guild_id, role_id)
+);
+`, `
+CREATE TABLE IF NOT EXISTS leveling_multipliers (
+	id BIGSERIAL PRIMARY KEY,
+	guild_id BIGINT NOT NULL,
+	target_type SMALLINT NOT NULL,
+	target_id BIGINT NOT NULL,
+	multiplier DOUBLE PRECISION NOT NULL,
+
+	UNIQUE(guild_id, target_type, target_id)
+);
+`, `
+CREATE TABLE IF NOT EXISTS leveling_users (
+	guild_id BIGINT NOT NULL,
+	user_id BIGINT NOT NULL,
+
+	xp BIGINT NOT NULL,
+	level INT NOT NULL,
+	messages BIGINT NOT NULL,
+	voice_minutes BIGINT NOT NULL,
+	updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	PRIMARY KEY(guild_id, user_id)
+);
+`, `
+CREATE INDEX IF NOT EXISTS leveling_users_guild_xp_idx ON leveling_users(guild_id, xp DESC);
+`}
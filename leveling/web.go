@@ -0,0 +1,393 @@
+package leveling
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/cplogs"
+	"github.com/botlabs-gg/yagpdb/v2/common/featureflags"
+	"github.com/botlabs-gg/yagpdb/v2/common/pubsub"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+	"github.com/botlabs-gg/yagpdb/v2/web"
+	"goji.io"
+	"goji.io/pat"
+)
+
+//go:embed assets/leveling.html
+var PageHTMLSettings string
+
+//go:embed assets/leveling_leaderboard.html
+var PageHTMLLeaderboard string
+
+type SettingsForm struct {
+	Enabled           bool
+	MessageXPMin      int64   `valid:"0,1000"`
+	MessageXPMax      int64   `valid:"0,1000"`
+	MessageCooldown   int     `valid:"0,86400"`
+	VoiceXPPerMinute  int64   `valid:"0,1000"`
+	CurveBase         int64   `valid:"1,1000000"`
+	CurveLinear       int64   `valid:"0,1000000"`
+	CurveQuadratic    int64   `valid:"0,100000"`
+	NoXPChannels      []int64 `valid:"channel,true"`
+	NoXPRoles         []int64 `valid:"role,true"`
+	AnnounceLevelUps  bool
+	AnnounceChannel   int64  `valid:"channel,true"`
+	LevelUpMessage    string `valid:"template,2000"`
+	StackRoleRewards  bool
+	PublicLeaderboard bool
+}
+
+type RoleRewardForm struct {
+	Level int   `valid:"1,1000"`
+	Role  int64 `valid:"role,false"`
+}
+
+type MultiplierForm struct {
+	TargetType MultiplierTarget
+	Channel    int64   `valid:"channel,true"`
+	Role       int64   `valid:"role,true"`
+	Multiplier float64 `valid:"0,10"`
+}
+
+var (
+	panelLogKeyUpdatedSettings  = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "leveling_settings_updated", FormatString: "Updated leveling settings"})
+	panelLogKeyAddedReward      = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "leveling_added_reward", FormatString: "Added level %d role reward"})
+	panelLogKeyRemovedReward    = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "leveling_removed_reward", FormatString: "Removed level role reward"})
+	panelLogKeyAddedMultiplier  = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "leveling_added_multiplier", FormatString: "Added xp multiplier"})
+	panelLogKeyRemovedMultipler = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "leveling_removed_multiplier", FormatString: "Removed xp multiplier"})
+	panelLogKeyResetXP          = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "leveling_reset_xp", FormatString: "Reset the xp of all members"})
+)
+
+func (p *Plugin) InitWeb() {
+	web.AddHTMLTemplate("leveling/assets/leveling.html", PageHTMLSettings)
+	web.AddHTMLTemplate("leveling/assets/leveling_leaderboard.html", PageHTMLLeaderboard)
+	web.AddSidebarItem(web.SidebarCategoryFun, &web.SidebarItem{
+		Name: "Leveling",
+		URL:  "leveling",
+		Icon: "fas fa-level-up-alt",
+	})
+
+	subMux := goji.SubMux()
+	web.CPMux.Handle(pat.New("/leveling"), subMux)
+	web.CPMux.Handle(pat.New("/leveling/*"), subMux)
+
+	mainGetHandler := web.RenderHandler(HandleGetSettings, "cp_leveling")
+
+	subMux.Handle(pat.Get(""), mainGetHandler)
+	subMux.Handle(pat.Get("/"), mainGetHandler)
+	subMux.Handle(pat.Post(""), web.ControllerPostHandler(HandlePostSettings, mainGetHandler, SettingsForm{}))
+	subMux.Handle(pat.Post("/"), web.ControllerPostHandler(HandlePostSettings, mainGetHandler, SettingsForm{}))
+	subMux.Handle(pat.Post("/rewards"), web.ControllerPostHandler(HandleAddRoleReward, mainGetHandler, RoleRewardForm{}))
+	subMux.Handle(pat.Post("/rewards/:item/delete"), web.ControllerPostHandler(HandleRemoveRoleReward, mainGetHandler, nil))
+	subMux.Handle(pat.Post("/multipliers"), web.ControllerPostHandler(HandleAddMultiplier, mainGetHandler, MultiplierForm{}))
+	subMux.Handle(pat.Post("/multipliers/:item/delete"), web.ControllerPostHandler(HandleRemoveMultiplier, mainGetHandler, nil))
+	subMux.Handle(pat.Post("/reset_users"), web.ControllerPostHandler(HandleResetXP, mainGetHandler, nil))
+
+	web.APIMux.Handle(pat.Get("/leveling"), web.APIConfigHandler(p, "leveling"))
+	web.APIMux.Handle(pat.Put("/leveling"), web.APIWriteHandler(p, web.APIFromController(HandlePostSettings), SettingsForm{}))
+
+	cplogs.RegisterConfigSource(&cplogs.ConfigSource{
+		Key:  "leveling",
+		Name: "Leveling",
+		Page: "leveling",
+		Get: func(ctx context.Context, guildID int64) (interface{}, error) {
+			return GetConfig(ctx, guildID)
+		},
+		Revert: func(ctx context.Context, guildID int64, snapshot string) error {
+			conf, err := GetConfig(ctx, guildID)
+			if err != nil {
+				return err
+			}
+
+			if err = cplogs.RestoreSnapshot(conf, snapshot); err != nil {
+				return err
+			}
+
+			conf.GuildID = guildID
+
+			tx, err := common.PQ.BeginTx(ctx, nil)
+			if err != nil {
+				return err
+			}
+
+			err = SaveConfig(ctx, tx, conf)
+			if err == nil {
+				err = ReplaceRewardsAndMultipliers(ctx, tx, guildID, conf.RoleRewards, conf.Multipliers)
+			}
+			if err != nil {
+				tx.Rollback()
+				return err
+			}
+
+			if err = tx.Commit(); err != nil {
+				return err
+			}
+
+			configChanged(guildID)
+			return nil
+		},
+	})
+
+	web.ServerPublicMux.Handle(pat.Get("/leveling/leaderboard"), web.RenderHandler(HandleGetSettings, "cp_leveling_leaderboard"))
+	web.ServerPublicAPIMux.Handle(pat.Get("/leveling/leaderboard"), web.APIHandler(HandleLeaderboardJson))
+}
+
+// configChanged makes the bot pick up the new config
+func configChanged(guildID int64) {
+	featureflags.MarkGuildDirty(guildID)
+	pubsub.EvictCacheSet(cachedConfig, guildID)
+}
+
+func HandleGetSettings(w http.ResponseWriter, r *http.Request) interface{} {
+	activeGuild, templateData := web.GetBaseCPContextData(r.Context())
+
+	templateData["MaxRoleRewards"] = MaxRoleRewards
+	templateData["MaxMultipliers"] = MaxMultipliers
+
+	if _, ok := templateData["LevelingConfig"]; !ok {
+		conf, err := GetConfig(r.Context(), activeGuild.ID)
+		if !web.CheckErr(templateData, err, "Failed retrieving settings", web.CtxLogger(r.Context()).Error) {
+			templateData["LevelingConfig"] = conf
+		}
+	}
+
+	return templateData
+}
+
+func HandlePostSettings(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+	templateData["VisibleURL"] = "/manage/" + discordgo.StrID(activeGuild.ID) + "/leveling"
+
+	form := ctx.Value(common.ContextKeyParsedForm).(*SettingsForm)
+	if form.MessageXPMax < form.MessageXPMin {
+		return templateData.AddAlerts(web.ErrorAlert("Max xp per message can't be lower than the min xp per message")), nil
+	}
+
+	conf, err := GetConfig(ctx, activeGuild.ID)
+	if err != nil {
+		return templateData, err
+	}
+
+	conf.Enabled = form.Enabled
+	conf.MessageXPMin = form.MessageXPMin
+	conf.MessageXPMax = form.MessageXPMax
+	conf.MessageCooldown = form.MessageCooldown
+	conf.VoiceXPPerMinute = form.VoiceXPPerMinute
+	conf.CurveBase = form.CurveBase
+	conf.CurveLinear = form.CurveLinear
+	conf.CurveQuadratic = form.CurveQuadratic
+	conf.NoXPChannels = form.NoXPChannels
+	conf.NoXPRoles = form.NoXPRoles
+	conf.AnnounceLevelUps = form.AnnounceLevelUps
+	conf.AnnounceChannel = form.AnnounceChannel
+	conf.LevelUpMessage = form.LevelUpMessage
+	conf.StackRoleRewards = form.StackRoleRewards
+	conf.PublicLeaderboard = form.PublicLeaderboard
+
+	templateData["LevelingConfig"] = conf
+
+	err = SaveConfig(ctx, common.PQ, conf)
+	if err == nil {
+		configChanged(activeGuild.ID)
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(ctx, panelLogKeyUpdatedSettings))
+	}
+
+	return templateData, err
+}
+
+func HandleAddRoleReward(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+	templateData["VisibleURL"] = "/manage/" + discordgo.StrID(activeGuild.ID) + "/leveling"
+
+	form := ctx.Value(common.ContextKeyParsedForm).(*RoleRewardForm)
+
+	current, err := GuildRoleRewards(ctx, activeGuild.ID)
+	if err != nil {
+		return templateData, err
+	}
+
+	if len(current) >= MaxRoleRewards {
+		return templateData.AddAlerts(web.ErrorAlert(fmt.Sprintf("Max %d role rewards allowed", MaxRoleRewards))), nil
+	}
+
+	reward := &RoleReward{
+		GuildID: activeGuild.ID,
+		Level:   form.Level,
+		RoleID:  form.Role,
+	}
+
+	err = reward.InsertExec(ctx, common.PQ)
+	if err == nil {
+		configChanged(activeGuild.ID)
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(ctx, panelLogKeyAddedReward, &cplogs.Param{Type: cplogs.ParamTypeInt, Value: int64(form.Level)}))
+	}
+
+	return templateData, err
+}
+
+func HandleRemoveRoleReward(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+	templateData["VisibleURL"] = "/manage/" + discordgo.StrID(activeGuild.ID) + "/leveling"
+
+	id, err := strconv.ParseInt(pat.Param(r, "item"), 10, 64)
+	if err != nil {
+		return templateData.AddAlerts(web.ErrorAlert("Unknown id")), nil
+	}
+
+	err = DeleteRoleReward(ctx, activeGuild.ID, id)
+	if err == nil {
+		configChanged(activeGuild.ID)
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(ctx, panelLogKeyRemovedReward))
+	}
+
+	return templateData, err
+}
+
+func HandleAddMultiplier(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+	templateData["VisibleURL"] = "/manage/" + discordgo.StrID(activeGuild.ID) + "/leveling"
+
+	form := ctx.Value(common.ContextKeyParsedForm).(*MultiplierForm)
+
+	multiplier := &Multiplier{
+		GuildID:    activeGuild.ID,
+		TargetType: form.TargetType,
+		Multiplier: form.Multiplier,
+	}
+
+	switch form.TargetType {
+	case MultiplierTargetChannel:
+		multiplier.TargetID = form.Channel
+	case MultiplierTargetRole:
+		multiplier.TargetID = form.Role
+	default:
+		return templateData.AddAlerts(web.ErrorAlert("Unknown multiplier type")), nil
+	}
+
+	if multiplier.TargetID == 0 {
+		return templateData.AddAlerts(web.ErrorAlert("No channel or role selected")), nil
+	}
+
+	current, err := GuildMultipliers(ctx, activeGuild.ID)
+	if err != nil {
+		return templateData, err
+	}
+
+	if len(current) >= MaxMultipliers {
+		return templateData.AddAlerts(web.ErrorAlert(fmt.Sprintf("Max %d multipliers allowed", MaxMultipliers))), nil
+	}
+
+	err = multiplier.InsertExec(ctx, common.PQ)
+	if err == nil {
+		configChanged(activeGuild.ID)
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(ctx, panelLogKeyAddedMultiplier))
+	}
+
+	return templateData, err
+}
+
+func HandleRemoveMultiplier(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+	templateData["VisibleURL"] = "/manage/" + discordgo.StrID(activeGuild.ID) + "/leveling"
+
+	id, err := strconv.ParseInt(pat.Param(r, "item"), 10, 64)
+	if err != nil {
+		return templateData.AddAlerts(web.ErrorAlert("Unknown id")), nil
+	}
+
+	err = DeleteMultiplier(ctx, activeGuild.ID, id)
+	if err == nil {
+		configChanged(activeGuild.ID)
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(ctx, panelLogKeyRemovedMultipler))
+	}
+
+	return templateData, err
+}
+
+func HandleResetXP(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	activeGuild, templateData := web.GetBaseCPContextData(r.Context())
+	templateData["VisibleURL"] = "/manage/" + discordgo.StrID(activeGuild.ID) + "/leveling"
+
+	err := ResetGuildXP(r.Context(), activeGuild.ID)
+	if err == nil {
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyResetXP))
+	}
+
+	return templateData, err
+}
+
+func HandleLeaderboardJson(w http.ResponseWriter, r *http.Request) interface{} {
+	activeGuild, _ := web.GetBaseCPContextData(r.Context())
+
+	conf, err := GetConfig(r.Context(), activeGuild.ID)
+	if err != nil {
+		return err
+	}
+
+	if !conf.Enabled || !conf.PublicLeaderboard {
+		return web.NewPublicError("The leveling leaderboard is not public on this server")
+	}
+
+	query := r.URL.Query()
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	if offset < 0 {
+		offset = 0
+	}
+
+	if limit > 100 || limit < 1 {
+		limit = 10
+	}
+
+	top, err := TopUsers(r.Context(), activeGuild.ID, offset, limit)
+	if err != nil {
+		return err
+	}
+
+	entries, err := DetailedLeaderboardEntries(activeGuild.ID, top)
+	if err != nil {
+		return err
+	}
+
+	return entries
+}
+
+var _ web.PluginWithServerHomeWidget = (*Plugin)(nil)
+
+func (p *Plugin) LoadServerHomeWidget(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ag, templateData := web.GetBaseCPContextData(r.Context())
+
+	templateData["WidgetTitle"] = "Leveling"
+	templateData["SettingsPath"] = "/leveling"
+
+	conf, err := GetConfig(r.Context(), ag.ID)
+	if err != nil {
+		return templateData, err
+	}
+
+	if conf.Enabled {
+		templateData["WidgetEnabled"] = true
+	} else {
+		templateData["WidgetDisabled"] = true
+	}
+
+	const format = `<ul>
+	<li>Leveling is: %s</li>
+	<li>Role rewards: <code>%d</code></li>
+	<li>Voice xp: %s</li>
+</ul>`
+
+	templateData["WidgetBody"] = template.HTML(fmt.Sprintf(format, web.EnabledDisabledSpanStatus(conf.Enabled), len(conf.RoleRewards), web.EnabledDisabledSpanStatus(conf.VoiceXPPerMinute > 0)))
+
+	return templateData, nil
+}
@@ -2,6 +2,7 @@ package cardsagainstdiscord
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/botlabs-gg/yagpdb/v2/common"
@@ -26,6 +27,45 @@ func AddPack(pack *CardPack) {
 	Packs[pack.Name] = pack
 }
 
+// MaxPick is the max number of cards a custom prompt can ask for
+const MaxPick = 3
+
+var blankRegex = regexp.MustCompile(`_+`)
+
+// CustomPromptCard creates a prompt card from text written by users, where blanks are one or more underscores.
+// If the prompt has no blanks then pick blanks are added to the end, otherwise pick has to match the number of blanks.
+func CustomPromptCard(text string, pick int) (*PromptCard, error) {
+	if pick < 1 || pick > MaxPick {
+		return nil, fmt.Errorf("Pick has to be between 1 and %d", MaxPick)
+	}
+
+	prompt := strings.Replace(text, "%", "%%", -1)
+	numBlanks := len(blankRegex.FindAllStringIndex(prompt, -1))
+	if numBlanks == 0 {
+		return &PromptCard{Prompt: prompt + strings.Repeat(" %s", pick), NumPick: pick}, nil
+	}
+
+	if numBlanks > MaxPick {
+		return nil, fmt.Errorf("Prompts can have at most %d blanks", MaxPick)
+	}
+
+	if pick != numBlanks {
+		return nil, fmt.Errorf("Pick is %d but the prompt has %d blanks", pick, numBlanks)
+	}
+
+	return &PromptCard{Prompt: blankRegex.ReplaceAllString(prompt, "%s"), NumPick: numBlanks}, nil
+}
+
+// DefaultPick returns the number of cards to pick for a prompt when it's not given, which is the number of blanks or 1 without blanks
+func DefaultPick(text string) int {
+	numBlanks := len(blankRegex.FindAllStringIndex(text, -1))
+	if numBlanks == 0 {
+		return 1
+	}
+
+	return numBlanks
+}
+
 type CardPack struct {
 	Name        string
 	Description string
@@ -74,6 +114,12 @@ type SessionProvider interface {
 	SessionForGuild(guildID int64) *discordgo.Session
 }
 
+// WinRecorder is told about the winners of every round, to keep track of wins across games.
+// The game winners are the players that reached the win limit with that round.
+type WinRecorder interface {
+	RecordWins(guildID int64, roundWinners []int64, gameWinners []int64)
+}
+
 type StaticSessionProvider struct {
 	Session *discordgo.Session
 }
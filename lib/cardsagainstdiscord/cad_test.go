@@ -0,0 +1,61 @@
+package cardsagainstdiscord
+
+import "testing"
+
+func TestCustomPromptCard(t *testing.T) {
+	cases := []struct {
+		text     string
+		pick     int
+		expected string
+		numPick  int
+		err      bool
+	}{
+		{"Why can't I sleep at night?", 1, "Why can't I sleep at night? %s", 1, false},
+		{"Make a haiku.", 3, "Make a haiku. %s %s %s", 3, false},
+		{"Step 1: ___ Step 2: _", 2, "Step 1: %s Step 2: %s", 2, false},
+		{"100% _", 1, "100%% %s", 1, false},
+		{"Step 1: _ Step 2: _", 1, "", 0, true},
+		{"_ _ _ _", 4, "", 0, true},
+		{"Why can't I sleep at night?", 0, "", 0, true},
+		{"Step 1: ___ Step 2: _", 0, "", 0, true},
+		{"Too many", 4, "", 0, true},
+	}
+
+	for _, c := range cases {
+		card, err := CustomPromptCard(c.text, c.pick)
+		if c.err {
+			if err == nil {
+				t.Errorf("%q pick %d: expected an error", c.text, c.pick)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%q pick %d: unexpected error: %v", c.text, c.pick, err)
+			continue
+		}
+
+		if card.Prompt != c.expected || card.NumPick != c.numPick {
+			t.Errorf("%q pick %d: got %q pick %d, expected %q pick %d", c.text, c.pick, card.Prompt, card.NumPick, c.expected, c.numPick)
+		}
+	}
+
+	card, _ := CustomPromptCard("100% _", 1)
+	if s := card.WithCards([]string{"sure"}); s != "100% **sure**" {
+		t.Errorf("got %q", s)
+	}
+}
+
+func TestDefaultPick(t *testing.T) {
+	cases := map[string]int{
+		"Why can't I sleep at night?": 1,
+		"Step 1: ___ Step 2: _":       2,
+		"Make a haiku. _ _ _":         3,
+	}
+
+	for text, expected := range cases {
+		if pick := DefaultPick(text); pick != expected {
+			t.Errorf("%q: got %d, expected %d", text, pick, expected)
+		}
+	}
+}
@@ -98,6 +98,7 @@ type Game struct {
 	WinLimit           int
 	VoteMode           bool
 	Packs              []string
+	CustomPacks        map[string]*CardPack
 	availablePrompts   []*PromptCard
 	availableResponses []ResponseCard
 
@@ -178,16 +179,27 @@ func (g *Game) Created() error {
 	return nil
 }
 
+// findPack returns the built-in pack with the name, or the custom one if there's no built-in pack with it
+func findPack(customPacks map[string]*CardPack, name string) *CardPack {
+	if pack, ok := Packs[name]; ok {
+		return pack
+	}
+
+	return customPacks[name]
+}
+
 func (g *Game) loadPackResponses() {
 	for _, v := range g.Packs {
-		pack := Packs[v]
-		g.availableResponses = append(g.availableResponses, pack.Responses...)
+		if pack := findPack(g.CustomPacks, v); pack != nil {
+			g.availableResponses = append(g.availableResponses, pack.Responses...)
+		}
 	}
 }
 func (g *Game) loadPackPrompts() {
 	for _, v := range g.Packs {
-		pack := Packs[v]
-		g.availablePrompts = append(g.availablePrompts, pack.Prompts...)
+		if pack := findPack(g.CustomPacks, v); pack != nil {
+			g.availablePrompts = append(g.availablePrompts, pack.Prompts...)
+		}
 	}
 }
 
@@ -1145,6 +1157,8 @@ func (g *Game) presentWinners(winningPicks []*PickedResonse) {
 		wonFullGame = true
 	}
 
+	g.recordWins(winningPicks)
+
 	wonFullGamePlayers := ""
 	if wonFullGame {
 		// There can be multiple people winning at the same time in vote mode
@@ -1326,6 +1340,27 @@ func (g *Game) loadFromSerializedState() {
 	go g.sendAnnouncment("Game has been fully loaded, it will still take another seconds before the game has resumed.", false)
 }
 
+// recordWins passes the winners of the round on to the win recorder of the manager, if there is one
+func (g *Game) recordWins(winningPicks []*PickedResonse) {
+	if g.Manager == nil || g.Manager.WinRecorder == nil {
+		return
+	}
+
+	roundWinners := make([]int64, 0, len(winningPicks))
+	for _, v := range winningPicks {
+		roundWinners = append(roundWinners, v.Player.ID)
+	}
+
+	var gameWinners []int64
+	for _, v := range g.Players {
+		if v.Wins >= g.WinLimit {
+			gameWinners = append(gameWinners, v.ID)
+		}
+	}
+
+	go g.Manager.WinRecorder.RecordWins(g.GuildID, roundWinners, gameWinners)
+}
+
 type Player struct {
 	ID              int64
 	Username        string
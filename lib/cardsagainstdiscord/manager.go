@@ -12,6 +12,9 @@ type GameManager struct {
 	SessionProvider SessionProvider
 	ActiveGames     map[int64]*Game
 	NumActiveGames  int
+
+	// Optional, if set it's told about the winners of every round
+	WinRecorder WinRecorder
 }
 
 func NewGameManager(sessionProvider SessionProvider) *GameManager {
@@ -22,6 +25,12 @@ func NewGameManager(sessionProvider SessionProvider) *GameManager {
 }
 
 func (gm *GameManager) CreateGame(guildID int64, channelID int64, userID int64, username string, voteMode bool, packs ...string) (*Game, error) {
+	return gm.CreateGameWithCustomPacks(guildID, channelID, userID, username, voteMode, nil, packs...)
+}
+
+// CreateGameWithCustomPacks creates a game that can also use the custom packs passed, which are keyed by name.
+// Custom packs can't replace the built-in ones, * selects both.
+func (gm *GameManager) CreateGameWithCustomPacks(guildID int64, channelID int64, userID int64, username string, voteMode bool, customPacks map[string]*CardPack, packs ...string) (*Game, error) {
 	allPacks := false
 	allResponseOnly := true
 	for _, v := range packs {
@@ -31,12 +40,15 @@ func (gm *GameManager) CreateGame(guildID int64, channelID int64, userID int64,
 			break
 		}
 
-		p, ok := Packs[v]
-		if !ok {
-			validPacks := make([]string, 0, len(Packs))
+		p := findPack(customPacks, v)
+		if p == nil {
+			validPacks := make([]string, 0, len(Packs)+len(customPacks))
 			for k := range Packs {
 				validPacks = append(validPacks, k)
 			}
+			for k := range customPacks {
+				validPacks = append(validPacks, k)
+			}
 			return nil, &ErrUnknownPack{
 				PassedPack:  v,
 				Suggestions: jarowinkler.Select(validPacks, v, jarowinkler.WithLimit(3)),
@@ -55,10 +67,28 @@ func (gm *GameManager) CreateGame(guildID int64, channelID int64, userID int64,
 	}
 
 	if allPacks {
-		packs = make([]string, 0, len(Packs))
+		packs = make([]string, 0, len(Packs)+len(customPacks))
 		for k := range Packs {
 			packs = append(packs, k)
 		}
+		for k := range customPacks {
+			if _, ok := Packs[k]; !ok {
+				packs = append(packs, k)
+			}
+		}
+	}
+
+	// Only keep the custom packs in use, they're sent along with the game when it's migrated to another shard
+	var usedCustomPacks map[string]*CardPack
+	for _, v := range packs {
+		if _, ok := Packs[v]; ok {
+			continue
+		}
+
+		if usedCustomPacks == nil {
+			usedCustomPacks = make(map[string]*CardPack)
+		}
+		usedCustomPacks[v] = customPacks[v]
 	}
 
 	gm.Lock()
@@ -77,6 +107,7 @@ func (gm *GameManager) CreateGame(guildID int64, channelID int64, userID int64,
 		Manager:       gm,
 		GuildID:       guildID,
 		Packs:         packs,
+		CustomPacks:   usedCustomPacks,
 		GameMaster:    userID,
 		VoteMode:      voteMode,
 		PlayerLimit:   10,
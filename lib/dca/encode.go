@@ -55,6 +55,10 @@ type EncodeOptions struct {
 	// Leave empty to use no filters.
 	AudioFilter string
 
+	// Normalizes the loudness before the other audio filters, leave nil to keep the loudness as is.
+	// Files are measured in a separate pass first for a linear normalization, pipes are normalized dynamically.
+	Loudnorm *LoudnormOptions
+
 	Comment string // Leave a comment in the metadata
 }
 
@@ -89,6 +93,12 @@ func (opts *EncodeOptions) Validate() error {
 		return errors.New("Number of threads can't be less than 0")
 	}
 
+	if opts.Loudnorm != nil {
+		if err := opts.Loudnorm.Validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -182,6 +192,12 @@ func (e *EncodeSession) run() {
 		e.Unlock()
 	}()
 
+	// Measuring the loudness is a full pass over the input, so it's done before taking the lock
+	loudnormFilter := ""
+	if e.options != nil && e.options.Loudnorm != nil {
+		loudnormFilter = e.loudnormFilter()
+	}
+
 	e.Lock()
 	e.running = true
 
@@ -223,9 +239,17 @@ func (e *EncodeSession) run() {
 		"-ss", strconv.Itoa(e.options.StartTime),
 	}
 
+	filters := make([]string, 0, 2)
+	if loudnormFilter != "" {
+		filters = append(filters, loudnormFilter)
+	}
 	if e.options.AudioFilter != "" {
+		filters = append(filters, e.options.AudioFilter)
+	}
+
+	if len(filters) > 0 {
 		// Lit af
-		args = append(args, "-af", e.options.AudioFilter)
+		args = append(args, "-af", strings.Join(filters, ","))
 	}
 
 	args = append(args, "pipe:1")
@@ -289,6 +313,22 @@ func (e *EncodeSession) run() {
 	}
 }
 
+func (e *EncodeSession) loudnormFilter() string {
+	if e.filePath == "" {
+		return e.options.Loudnorm.Filter(nil)
+	}
+
+	stats, err := MeasureLoudness(e.filePath, e.options.Loudnorm)
+	if err != nil {
+		if err != ErrSilentInput {
+			logln("Loudness measurement failed, normalizing dynamically:", err)
+		}
+		return e.options.Loudnorm.Filter(nil)
+	}
+
+	return e.options.Loudnorm.Filter(stats)
+}
+
 func (e *EncodeSession) writeMetadataFrame() {
 	// Setup the metadata
 	metadata := Metadata{
@@ -0,0 +1,146 @@
+package dca
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrNoLoudnessStats = errors.New("No loudness stats in the ffmpeg output")
+	ErrSilentInput     = errors.New("Input is silent, can't normalize it")
+)
+
+// LoudnormOptions are the targets for EBU R128 loudness normalization, done with the ffmpeg loudnorm filter.
+// See https://ffmpeg.org/ffmpeg-filters.html#loudnorm for more info
+type LoudnormOptions struct {
+	IntegratedLoudness float64 // target integrated loudness in LUFS (-70 to -5)
+	TruePeak           float64 // max true peak in dBTP (-9 to 0)
+	LoudnessRange      float64 // target loudness range in LU (1 to 20)
+}
+
+// StdLoudnormOptions are the loudnorm filter defaults, which is also what most streaming platforms aim for
+var StdLoudnormOptions = &LoudnormOptions{
+	IntegratedLoudness: -16,
+	TruePeak:           -1.5,
+	LoudnessRange:      11,
+}
+
+// Validate returns an error if the options are not correct
+func (l *LoudnormOptions) Validate() error {
+	if l.IntegratedLoudness < -70 || l.IntegratedLoudness > -5 {
+		return errors.New("Out of bounds integrated loudness (-70 - -5)")
+	}
+
+	if l.TruePeak < -9 || l.TruePeak > 0 {
+		return errors.New("Out of bounds true peak (-9 - 0)")
+	}
+
+	if l.LoudnessRange < 1 || l.LoudnessRange > 20 {
+		return errors.New("Out of bounds loudness range (1 - 20)")
+	}
+
+	return nil
+}
+
+// LoudnessStats is the loudness of the input as measured by a first pass of the loudnorm filter
+type LoudnessStats struct {
+	InputI       float64 // integrated loudness
+	InputTP      float64 // true peak
+	InputLRA     float64 // loudness range
+	InputThresh  float64
+	TargetOffset float64
+}
+
+// Filter returns the loudnorm filter, if stats from a previous measurement is given it does a linear normalization
+// with those, otherwise it normalizes dynamically in a single pass
+func (l *LoudnormOptions) Filter(stats *LoudnessStats) string {
+	filter := fmt.Sprintf("loudnorm=I=%s:TP=%s:LRA=%s", formatFilterFloat(l.IntegratedLoudness), formatFilterFloat(l.TruePeak), formatFilterFloat(l.LoudnessRange))
+	if stats == nil {
+		return filter
+	}
+
+	return filter + fmt.Sprintf(":measured_I=%s:measured_TP=%s:measured_LRA=%s:measured_thresh=%s:offset=%s:linear=true",
+		formatFilterFloat(stats.InputI), formatFilterFloat(stats.InputTP), formatFilterFloat(stats.InputLRA),
+		formatFilterFloat(stats.InputThresh), formatFilterFloat(stats.TargetOffset))
+}
+
+func formatFilterFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', 2, 64)
+}
+
+// MeasureLoudness runs the file through the loudnorm filter without encoding it, to get the stats for linear normalization
+func MeasureLoudness(path string, options *LoudnormOptions) (*LoudnessStats, error) {
+	var stderr bytes.Buffer
+	ffmpeg := exec.Command("ffmpeg",
+		"-hide_banner",
+		"-nostats",
+		"-i", path,
+		"-map", "0:a",
+		"-af", options.Filter(nil)+":print_format=json",
+		"-f", "null",
+		"-",
+	)
+	ffmpeg.Stderr = &stderr
+
+	err := ffmpeg.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	return parseLoudnessStats(stderr.String())
+}
+
+// parseLoudnessStats parses the json the loudnorm filter prints at the end of the ffmpeg output
+func parseLoudnessStats(output string) (*LoudnessStats, error) {
+	start := strings.LastIndex(output, "{")
+	if start == -1 {
+		return nil, ErrNoLoudnessStats
+	}
+
+	end := strings.Index(output[start:], "}")
+	if end == -1 {
+		return nil, ErrNoLoudnessStats
+	}
+
+	var raw struct {
+		InputI       string `json:"input_i"`
+		InputTP      string `json:"input_tp"`
+		InputLRA     string `json:"input_lra"`
+		InputThresh  string `json:"input_thresh"`
+		TargetOffset string `json:"target_offset"`
+	}
+
+	err := json.Unmarshal([]byte(output[start:start+end+1]), &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &LoudnessStats{}
+	for _, v := range []struct {
+		dst *float64
+		src string
+	}{
+		{&stats.InputI, raw.InputI},
+		{&stats.InputTP, raw.InputTP},
+		{&stats.InputLRA, raw.InputLRA},
+		{&stats.InputThresh, raw.InputThresh},
+		{&stats.TargetOffset, raw.TargetOffset},
+	} {
+		*v.dst, err = strconv.ParseFloat(strings.TrimSpace(v.src), 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if math.IsInf(stats.InputI, 0) || math.IsInf(stats.InputThresh, 0) {
+		return nil, ErrSilentInput
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,77 @@
+package dca
+
+import (
+	"testing"
+)
+
+const loudnormOutput = `Input #0, wav, from 'loud.wav':
+  Duration: 00:00:04.00, bitrate: 1536 kb/s
+  Stream #0:0: Audio: pcm_s16le ([1][0][0][0] / 0x0001), 48000 Hz, 2 channels, s16, 1536 kb/s
+[Parsed_loudnorm_0 @ 0x5581c1d0a2c0] 
+{
+	"input_i" : "-5.21",
+	"input_tp" : "0.42",
+	"input_lra" : "1.30",
+	"input_thresh" : "-15.22",
+	"output_i" : "-16.05",
+	"output_tp" : "-1.50",
+	"output_lra" : "1.10",
+	"output_thresh" : "-26.06",
+	"normalization_type" : "dynamic",
+	"target_offset" : "0.05"
+}
+`
+
+func TestParseLoudnessStats(t *testing.T) {
+	stats, err := parseLoudnessStats(loudnormOutput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := LoudnessStats{InputI: -5.21, InputTP: 0.42, InputLRA: 1.3, InputThresh: -15.22, TargetOffset: 0.05}
+	if *stats != expected {
+		t.Errorf("got %+v, expected %+v", *stats, expected)
+	}
+
+	_, err = parseLoudnessStats("Input #0, wav, from 'loud.wav':\n")
+	if err != ErrNoLoudnessStats {
+		t.Errorf("expected ErrNoLoudnessStats, got %v", err)
+	}
+
+	silent := `{
+	"input_i" : "-inf",
+	"input_tp" : "-inf",
+	"input_lra" : "0.00",
+	"input_thresh" : "-inf",
+	"target_offset" : "inf"
+}`
+	_, err = parseLoudnessStats(silent)
+	if err != ErrSilentInput {
+		t.Errorf("expected ErrSilentInput, got %v", err)
+	}
+}
+
+func TestLoudnormFilter(t *testing.T) {
+	filter := StdLoudnormOptions.Filter(nil)
+	if filter != "loudnorm=I=-16.00:TP=-1.50:LRA=11.00" {
+		t.Errorf("unexpected single pass filter %q", filter)
+	}
+
+	filter = StdLoudnormOptions.Filter(&LoudnessStats{InputI: -5.21, InputTP: 0.42, InputLRA: 1.3, InputThresh: -15.22, TargetOffset: 0.05})
+	expected := "loudnorm=I=-16.00:TP=-1.50:LRA=11.00:measured_I=-5.21:measured_TP=0.42:measured_LRA=1.30:measured_thresh=-15.22:offset=0.05:linear=true"
+	if filter != expected {
+		t.Errorf("got %q, expected %q", filter, expected)
+	}
+}
+
+func TestLoudnormValidate(t *testing.T) {
+	if err := StdLoudnormOptions.Validate(); err != nil {
+		t.Errorf("standard options should be valid: %v", err)
+	}
+
+	opts := *StdEncodeOptions
+	opts.Loudnorm = &LoudnormOptions{IntegratedLoudness: 0, TruePeak: -1.5, LoudnessRange: 11}
+	if err := opts.Validate(); err == nil {
+		t.Error("expected an error for out of bounds integrated loudness")
+	}
+}
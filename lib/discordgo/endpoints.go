@@ -108,6 +108,10 @@ var (
 	EndpointGuildThreads        = func(gID int64) string { return "" }
 	EndpointGuildActiveThreads  = func(gID int64) string { return "" }
 
+	EndpointGuildScheduledEvents     = func(gID int64) string { return "" }
+	EndpointGuildScheduledEvent      = func(gID, eID int64) string { return "" }
+	EndpointGuildScheduledEventUsers = func(gID, eID int64) string { return "" }
+
 	EndpointChannel                             = func(cID int64) string { return "" }
 	EndpointChannelThreads                      = func(cID int64) string { return "" }
 	EndpointChannelActiveThreads                = func(cID int64) string { return "" }
@@ -134,6 +138,7 @@ var (
 	EndpointChannelWebhooks = func(cID int64) string { return "" }
 	EndpointWebhook         = func(wID int64) string { return "" }
 	EndpointWebhookToken    = func(wID int64, token string) string { return "" }
+	EndpointWebhookMessage  = func(wID int64, token string, mID int64) string { return "" }
 
 	EndpointDefaultUserAvatar = func(index int) string { return "" }
 
@@ -295,6 +300,9 @@ func CreateEndpoints(base string) {
 	EndpointGuildBannerAnimated = func(gID int64, hash string) string { return EndpointCDNBanners + StrID(gID) + "/" + hash + ".gif" }
 	EndpointGuildThreads = func(gID int64) string { return EndpointGuild(gID) + "/threads" }
 	EndpointGuildActiveThreads = func(gID int64) string { return EndpointGuildThreads(gID) + "/active" }
+	EndpointGuildScheduledEvents = func(gID int64) string { return EndpointGuild(gID) + "/scheduled-events" }
+	EndpointGuildScheduledEvent = func(gID, eID int64) string { return EndpointGuildScheduledEvents(gID) + "/" + StrID(eID) }
+	EndpointGuildScheduledEventUsers = func(gID, eID int64) string { return EndpointGuildScheduledEvent(gID, eID) + "/users" }
 
 	EndpointChannel = func(cID int64) string { return EndpointChannels + StrID(cID) }
 	EndpointChannelThreads = func(cID int64) string { return EndpointChannel(cID) + "/threads" }
@@ -322,6 +330,9 @@ func CreateEndpoints(base string) {
 	EndpointChannelWebhooks = func(cID int64) string { return EndpointChannel(cID) + "/webhooks" }
 	EndpointWebhook = func(wID int64) string { return EndpointWebhooks + StrID(wID) }
 	EndpointWebhookToken = func(wID int64, token string) string { return EndpointWebhooks + StrID(wID) + "/" + token }
+	EndpointWebhookMessage = func(wID int64, token string, mID int64) string {
+		return EndpointWebhookToken(wID, token) + "/messages/" + StrID(mID)
+	}
 
 	EndpointDefaultUserAvatar = func(index int) string {
 		return EndpointCDN + "embed/avatars/" + strconv.Itoa(index) + ".png"
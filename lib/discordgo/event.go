@@ -1,5 +1,12 @@
 package discordgo
 
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/botlabs-gg/yagpdb/v2/lib/gojay"
+)
+
 // EventHandler is an interface for Discord events.
 type EventHandler interface {
 	// Type returns the type of event this handler belongs to.
@@ -41,6 +48,21 @@ func (eh interfaceEventHandler) Handle(s *Session, i interface{}) {
 
 var registeredInterfaceProviders = map[string]EventInterfaceProvider{}
 
+// DecodeEvent decodes the raw data of a dispatch event into its struct, the same way events from the gateway are decoded
+func DecodeEvent(eventType string, data []byte) (interface{}, error) {
+	eh, ok := registeredInterfaceProviders[eventType]
+	if !ok {
+		return nil, fmt.Errorf("unknown event type: %s", eventType)
+	}
+
+	evt := eh.New()
+	if gojayDec, ok := evt.(gojay.UnmarshalerJSONObject); ok {
+		return evt, gojay.UnmarshalJSONObject(data, gojayDec)
+	}
+
+	return evt, json.Unmarshal(data, evt)
+}
+
 // registerInterfaceProvider registers a provider so that DiscordGo can
 // access it's New() method.
 func registerInterfaceProvider(eh EventInterfaceProvider) {
@@ -558,6 +558,43 @@ type GuildAuditLogEntryCreate struct {
 	*AuditLogEntry
 }
 
+// GuildScheduledEventCreate is the data for a GuildScheduledEventCreate event.
+type GuildScheduledEventCreate struct {
+	*GuildScheduledEvent
+}
+
+// GuildScheduledEventUpdate is the data for a GuildScheduledEventUpdate event.
+type GuildScheduledEventUpdate struct {
+	*GuildScheduledEvent
+}
+
+// GuildScheduledEventDelete is the data for a GuildScheduledEventDelete event.
+type GuildScheduledEventDelete struct {
+	*GuildScheduledEvent
+}
+
+// GuildScheduledEventUserAdd is sent when a user subscribes to a scheduled event
+type GuildScheduledEventUserAdd struct {
+	GuildScheduledEventID int64 `json:"guild_scheduled_event_id,string"`
+	UserID                int64 `json:"user_id,string"`
+	GuildID               int64 `json:"guild_id,string"`
+}
+
+func (e *GuildScheduledEventUserAdd) GetGuildID() int64 {
+	return e.GuildID
+}
+
+// GuildScheduledEventUserRemove is sent when a user unsubscribes from a scheduled event
+type GuildScheduledEventUserRemove struct {
+	GuildScheduledEventID int64 `json:"guild_scheduled_event_id,string"`
+	UserID                int64 `json:"user_id,string"`
+	GuildID               int64 `json:"guild_id,string"`
+}
+
+func (e *GuildScheduledEventUserRemove) GetGuildID() int64 {
+	return e.GuildID
+}
+
 type GuildJoinRequestUpdate struct{}
 type GuildJoinRequestDelete struct{}
 type VoiceChannelStatusUpdate struct{}
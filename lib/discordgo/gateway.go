@@ -1220,6 +1220,10 @@ func (g *GatewayConnection) handleDispatch(e *Event) error {
 
 	size := len(e.RawData)
 
+	if h := g.manager.session.RawDispatchHandler; h != nil {
+		h(g.manager.session, e.Type, e.RawData)
+	}
+
 	// Map event to registered event handlers and pass it along to any registered handlers.
 	if eh, ok := registeredInterfaceProviders[e.Type]; ok {
 		e.Struct = eh.New()
@@ -16,6 +16,21 @@ type customRateLimit struct {
 	reset    time.Duration
 }
 
+// BucketStore shares ratelimit state between processes using the same token, for example when shards run across
+// several nodes. The buckets are still tracked in memory as well, so requests made within the process are serialized
+// like normal. Implementations should fail open if the store is unavailable.
+type BucketStore interface {
+	// Reserve reserves a request in the bucket, returning how long to wait before trying again if the bucket is
+	// exhausted or a global ratelimit is in effect
+	Reserve(bucketKey string) (wait time.Duration)
+
+	// Update sets the remaining requests of the bucket and when it resets, from the headers of a response
+	Update(bucketKey string, remaining int, resetAfter time.Duration)
+
+	// SetGlobal makes all buckets wait for the duration
+	SetGlobal(retryAfter time.Duration)
+}
+
 // RateLimiter holds all ratelimit buckets
 type RateLimiter struct {
 	sync.Mutex
@@ -25,6 +40,10 @@ type RateLimiter struct {
 
 	MaxConcurrentRequests int
 	numConcurrentLocks    *int32
+
+	// Store, if set, is used in addition to the in memory buckets to coordinate with other processes.
+	// Needs to be set before any requests are made.
+	Store BucketStore
 }
 
 // NewRatelimiter returns a new RateLimiter
@@ -64,6 +83,7 @@ func (r *RateLimiter) GetBucket(key string) *Bucket {
 		Key:         key,
 		global:      r.global,
 		lockCounter: new(int64),
+		store:       r.Store,
 	}
 
 	if r.MaxConcurrentRequests > 0 {
@@ -128,6 +148,17 @@ func (r *RateLimiter) LockBucketObject(b *Bucket) (lockID int64) {
 		time.Sleep(wait)
 	}
 
+	if r.Store != nil {
+		for {
+			wait := r.Store.Reserve(b.Key)
+			if wait <= 0 {
+				break
+			}
+
+			time.Sleep(wait)
+		}
+	}
+
 	didWaitForMaxCCR := false
 	if r.MaxConcurrentRequests > 0 {
 		// sleep until were below the maximum
@@ -158,6 +189,9 @@ func (r *RateLimiter) LockBucketObject(b *Bucket) (lockID int64) {
 
 func (r *RateLimiter) SetGlobalTriggered(to time.Time) {
 	atomic.StoreInt64(r.global, to.UnixNano())
+	if r.Store != nil {
+		r.Store.SetGlobal(time.Until(to))
+	}
 }
 
 // Bucket represents a ratelimit bucket, each bucket gets ratelimited individually (-global ratelimits)
@@ -174,6 +208,7 @@ type Bucket struct {
 	Userdata        interface{}
 
 	lockCounter *int64
+	store       BucketStore
 }
 
 // Release unlocks the bucket and reads the headers to update the buckets ratelimit info
@@ -228,6 +263,7 @@ func (b *Bucket) Release(headers http.Header, lockCounter int64) error {
 	// If global is set, then it will block all buckets until after Retry-After
 	// If Retry-After without global is provided it will use that for the new reset
 	// time since it's more accurate than X-RateLimit-Reset.
+	var resetDur time.Duration
 	retryAfter := headers.Get("Retry-After")
 	if retryAfter != "" {
 
@@ -242,8 +278,14 @@ func (b *Bucket) Release(headers http.Header, lockCounter int64) error {
 		global := headers.Get("X-RateLimit-Global")
 		if global != "" {
 			atomic.StoreInt64(b.global, resetAt.UnixNano())
+			if b.store != nil {
+				b.store.SetGlobal(dur)
+			}
 		} else {
 			b.reset = resetAt
+			if b.store != nil {
+				b.store.Update(b.Key, 0, dur)
+			}
 		}
 	} else if resetAfter != "" {
 		dur, err := parseResetAfterDur(resetAfter)
@@ -251,6 +293,7 @@ func (b *Bucket) Release(headers http.Header, lockCounter int64) error {
 			return err
 		}
 
+		resetDur = dur
 		b.reset = time.Now().Add(dur)
 	}
 
@@ -262,6 +305,10 @@ func (b *Bucket) Release(headers http.Header, lockCounter int64) error {
 			return err
 		}
 		b.Remaining = int(parsedRemaining)
+
+		if b.store != nil && resetDur > 0 {
+			b.store.Update(b.Key, b.Remaining, resetDur)
+		}
 	}
 
 	return nil
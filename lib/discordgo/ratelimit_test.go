@@ -114,3 +114,65 @@ func sendBenchReq(endpoint string, rl *RateLimiter) {
 
 	bucket.Release(headers, id)
 }
+
+type testBucketStore struct {
+	waits   []time.Duration
+	updates map[string]int
+	global  time.Duration
+}
+
+func (s *testBucketStore) Reserve(bucketKey string) time.Duration {
+	if len(s.waits) < 1 {
+		return 0
+	}
+
+	wait := s.waits[0]
+	s.waits = s.waits[1:]
+	return wait
+}
+
+func (s *testBucketStore) Update(bucketKey string, remaining int, resetAfter time.Duration) {
+	s.updates[bucketKey] = remaining
+}
+
+func (s *testBucketStore) SetGlobal(retryAfter time.Duration) {
+	s.global = retryAfter
+}
+
+func TestRatelimitStore(t *testing.T) {
+	store := &testBucketStore{
+		waits:   []time.Duration{time.Millisecond * 100, time.Millisecond * 100},
+		updates: make(map[string]int),
+	}
+
+	rl := NewRatelimiter()
+	rl.Store = store
+
+	sent := time.Now()
+	bucket, id := rl.LockBucket("/guilds/99/channels")
+	if time.Since(sent) < time.Millisecond*200 {
+		t.Error("Did not wait for the store, got:", time.Since(sent))
+	}
+
+	headers := http.Header(make(map[string][]string))
+	headers.Set("X-RateLimit-Remaining", "3")
+	headers.Set("X-RateLimit-Reset-After", "1")
+	err := bucket.Release(headers, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if store.updates["/guilds/99/channels"] != 3 {
+		t.Errorf("store not updated: %v", store.updates)
+	}
+
+	bucket, id = rl.LockBucket("/guilds/55/channels")
+	headers = http.Header(make(map[string][]string))
+	headers.Set("X-RateLimit-Global", "1")
+	headers.Set("Retry-After", "0.1")
+	bucket.Release(headers, id)
+
+	if store.global != time.Millisecond*100 {
+		t.Errorf("global ratelimit not passed to the store: %s", store.global)
+	}
+}
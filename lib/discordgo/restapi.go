@@ -1471,6 +1471,111 @@ func (s *Session) GuildEmojiDelete(guildID, emojiID int64) (err error) {
 	return
 }
 
+// GuildScheduledEvents returns the scheduled events of a guild
+// guildID       : The ID of a Guild.
+// withUserCount : Whether to include the number of subscribed users of each event.
+func (s *Session) GuildScheduledEvents(guildID int64, withUserCount bool) (st []*GuildScheduledEvent, err error) {
+	uri := EndpointGuildScheduledEvents(guildID)
+	if withUserCount {
+		uri += "?with_user_count=true"
+	}
+
+	body, err := s.RequestWithBucketID("GET", uri, nil, nil, EndpointGuildScheduledEvents(guildID))
+	if err != nil {
+		return
+	}
+
+	err = unmarshal(body, &st)
+	return
+}
+
+// GuildScheduledEvent returns a single scheduled event
+// guildID       : The ID of a Guild.
+// eventID       : The ID of the scheduled event.
+// withUserCount : Whether to include the number of subscribed users.
+func (s *Session) GuildScheduledEvent(guildID, eventID int64, withUserCount bool) (st *GuildScheduledEvent, err error) {
+	uri := EndpointGuildScheduledEvent(guildID, eventID)
+	if withUserCount {
+		uri += "?with_user_count=true"
+	}
+
+	body, err := s.RequestWithBucketID("GET", uri, nil, nil, EndpointGuildScheduledEvents(guildID))
+	if err != nil {
+		return
+	}
+
+	err = unmarshal(body, &st)
+	return
+}
+
+// GuildScheduledEventCreate creates a scheduled event
+// guildID : The ID of a Guild.
+// params  : The event, external events need a location and a end time.
+func (s *Session) GuildScheduledEventCreate(guildID int64, params *GuildScheduledEventParams) (st *GuildScheduledEvent, err error) {
+	body, err := s.RequestWithBucketID("POST", EndpointGuildScheduledEvents(guildID), params, nil, EndpointGuildScheduledEvents(guildID))
+	if err != nil {
+		return
+	}
+
+	err = unmarshal(body, &st)
+	return
+}
+
+// GuildScheduledEventEdit modifies a scheduled event, also used to start, end or cancel it by changing the status
+// guildID : The ID of a Guild.
+// eventID : The ID of the scheduled event.
+// params  : The fields to change.
+func (s *Session) GuildScheduledEventEdit(guildID, eventID int64, params *GuildScheduledEventParams) (st *GuildScheduledEvent, err error) {
+	body, err := s.RequestWithBucketID("PATCH", EndpointGuildScheduledEvent(guildID, eventID), params, nil, EndpointGuildScheduledEvents(guildID))
+	if err != nil {
+		return
+	}
+
+	err = unmarshal(body, &st)
+	return
+}
+
+// GuildScheduledEventDelete deletes a scheduled event
+// guildID : The ID of a Guild.
+// eventID : The ID of the scheduled event.
+func (s *Session) GuildScheduledEventDelete(guildID, eventID int64) (err error) {
+	_, err = s.RequestWithBucketID("DELETE", EndpointGuildScheduledEvent(guildID, eventID), nil, nil, EndpointGuildScheduledEvents(guildID))
+	return
+}
+
+// GuildScheduledEventUsers returns the users subscribed to a scheduled event
+// guildID    : The ID of a Guild.
+// eventID    : The ID of the scheduled event.
+// limit      : Max number of users to return (max 100).
+// withMember : Whether to include the guild member of each user.
+// after      : Only return users after this user ID, for pagination.
+func (s *Session) GuildScheduledEventUsers(guildID, eventID int64, limit int, withMember bool, after int64) (st []*GuildScheduledEventUser, err error) {
+	uri := EndpointGuildScheduledEventUsers(guildID, eventID)
+
+	v := url.Values{}
+	if limit > 0 {
+		v.Set("limit", strconv.Itoa(limit))
+	}
+	if withMember {
+		v.Set("with_member", "true")
+	}
+	if after != 0 {
+		v.Set("after", StrID(after))
+	}
+
+	if len(v) > 0 {
+		uri += "?" + v.Encode()
+	}
+
+	body, err := s.RequestWithBucketID("GET", uri, nil, nil, EndpointGuildScheduledEventUsers(guildID, 0))
+	if err != nil {
+		return
+	}
+
+	err = unmarshal(body, &st)
+	return
+}
+
 // ------------------------------------------------------------------------------------------------
 // Functions specific to Discord Channels
 // ------------------------------------------------------------------------------------------------
@@ -2246,8 +2351,15 @@ func (s *Session) WebhookExecute(webhookID int64, token string, wait bool, data
 func (s *Session) WebhookExecuteComplex(webhookID int64, token string, wait bool, data *WebhookParams) (m *Message, err error) {
 	uri := EndpointWebhookToken(webhookID, token)
 
+	query := url.Values{}
 	if wait {
-		uri += "?wait=true"
+		query.Set("wait", "true")
+	}
+	if data.ThreadID != 0 {
+		query.Set("thread_id", StrID(data.ThreadID))
+	}
+	if len(query) > 0 {
+		uri += "?" + query.Encode()
 	}
 
 	endpoint := uri
@@ -2257,6 +2369,7 @@ func (s *Session) WebhookExecuteComplex(webhookID int64, token string, wait bool
 	if data.File != nil {
 		files = []*File{data.File}
 	}
+	files = append(files, data.Files...)
 
 	var response []byte
 	if len(files) > 0 {
@@ -2327,6 +2440,39 @@ func (s *Session) WebhookExecuteComplex(webhookID int64, token string, wait bool
 	// return
 }
 
+// WebhookMessageEdit edits a message previously sent by the webhook.
+// webhookID: The ID of a webhook.
+// token    : The auth token for the webhook
+// threadID : The thread the message is in, 0 if it's not in a thread
+func (s *Session) WebhookMessageEdit(webhookID int64, token string, messageID int64, threadID int64, data *WebhookParams) (st *Message, err error) {
+	uri := EndpointWebhookMessage(webhookID, token, messageID)
+	if threadID != 0 {
+		uri += "?thread_id=" + StrID(threadID)
+	}
+
+	body, err := s.RequestWithBucketID("PATCH", uri, data, nil, EndpointWebhookToken(webhookID, ""))
+	if err != nil {
+		return
+	}
+
+	err = unmarshal(body, &st)
+	return
+}
+
+// WebhookMessageDelete deletes a message previously sent by the webhook.
+// webhookID: The ID of a webhook.
+// token    : The auth token for the webhook
+// threadID : The thread the message is in, 0 if it's not in a thread
+func (s *Session) WebhookMessageDelete(webhookID int64, token string, messageID int64, threadID int64) (err error) {
+	uri := EndpointWebhookMessage(webhookID, token, messageID)
+	if threadID != 0 {
+		uri += "?thread_id=" + StrID(threadID)
+	}
+
+	_, err = s.RequestWithBucketID("DELETE", uri, nil, nil, EndpointWebhookToken(webhookID, ""))
+	return
+}
+
 // MessageReactionAdd creates an emoji reaction to a message.
 // channelID : The channel ID.
 // messageID : The message ID.
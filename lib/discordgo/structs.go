@@ -57,6 +57,10 @@ type Session struct {
 	// e.g false = launch event handlers in their own goroutines.
 	SyncEvents bool
 
+	// RawDispatchHandler is called with the raw data of every dispatch event before it's decoded,
+	// data is reused after it returns so it has to be copied if it's kept around
+	RawDispatchHandler func(s *Session, eventType string, data []byte)
+
 	// Max number of REST API retries
 	MaxRestRetries int
 
@@ -1343,6 +1347,11 @@ type WebhookParams struct {
 	Embeds          []*MessageEmbed    `json:"embeds,omitempty"`
 	Flags           int64              `json:"flags,omitempty"`
 	AllowedMentions *AllowedMentions   `json:"allowed_mentions,omitempty"`
+
+	Files []*File `json:"-"`
+
+	// ThreadID sends the message in the thread with the given ID, the thread has to be in the webhook's channel
+	ThreadID int64 `json:"-"`
 }
 
 // MessageReaction stores the data for a message reaction.
@@ -1393,6 +1402,9 @@ const (
 	ErrCodeUnknownEmoji       = 10014
 	ErrCodeUnknownWebhook     = 10015
 
+	ErrCodeUnknownGuildScheduledEvent     = 10070
+	ErrCodeUnknownGuildScheduledEventUser = 10071
+
 	ErrCodeBotsCannotUseEndpoint  = 20001
 	ErrCodeOnlyBotsCanUseEndpoint = 20002
 
@@ -1669,3 +1681,84 @@ type AutoModerationAction struct {
 	Type     AutoModerationActionType      `json:"type"`
 	Metadata *AutoModerationActionMetadata `json:"metadata,omitempty"`
 }
+
+// GuildScheduledEvent is a representation of a scheduled event in a guild, shown in the events list of the guild.
+// https://discord.com/developers/docs/resources/guild-scheduled-event
+type GuildScheduledEvent struct {
+	ID                 int64                              `json:"id,string"`
+	GuildID            int64                              `json:"guild_id,string"`
+	ChannelID          int64                              `json:"channel_id,string"` // null if the entity type is external
+	CreatorID          int64                              `json:"creator_id,string"`
+	Name               string                             `json:"name"`
+	Description        string                             `json:"description"`
+	ScheduledStartTime time.Time                          `json:"scheduled_start_time"`
+	ScheduledEndTime   *time.Time                         `json:"scheduled_end_time"` // required if the entity type is external
+	PrivacyLevel       GuildScheduledEventPrivacyLevel    `json:"privacy_level"`
+	Status             GuildScheduledEventStatus          `json:"status"`
+	EntityType         GuildScheduledEventEntityType      `json:"entity_type"`
+	EntityID           int64                              `json:"entity_id,string"`
+	EntityMetadata     *GuildScheduledEventEntityMetadata `json:"entity_metadata"`
+	Creator            *User                              `json:"creator"`
+	UserCount          int                                `json:"user_count"` // only included when requested with withUserCount
+	Image              string                             `json:"image"`
+}
+
+func (e *GuildScheduledEvent) GetGuildID() int64 {
+	return e.GuildID
+}
+
+// GuildScheduledEventParams are the fields used when creating or editing a scheduled event,
+// empty fields are left unchanged when editing.
+type GuildScheduledEventParams struct {
+	ChannelID          int64                              `json:"channel_id,string,omitempty"`
+	EntityMetadata     *GuildScheduledEventEntityMetadata `json:"entity_metadata,omitempty"`
+	Name               string                             `json:"name,omitempty"`
+	PrivacyLevel       GuildScheduledEventPrivacyLevel    `json:"privacy_level,omitempty"`
+	ScheduledStartTime *time.Time                         `json:"scheduled_start_time,omitempty"`
+	ScheduledEndTime   *time.Time                         `json:"scheduled_end_time,omitempty"`
+	Description        string                             `json:"description,omitempty"`
+	EntityType         GuildScheduledEventEntityType      `json:"entity_type,omitempty"`
+	Status             GuildScheduledEventStatus          `json:"status,omitempty"`
+	Image              string                             `json:"image,omitempty"` // base64 encoded cover image
+}
+
+// GuildScheduledEventEntityMetadata holds additional data for the entity of a scheduled event
+type GuildScheduledEventEntityMetadata struct {
+	// location of the event, required if the entity type is external (1-100 characters)
+	Location string `json:"location,omitempty"`
+}
+
+// GuildScheduledEventPrivacyLevel is the privacy level of a scheduled event
+type GuildScheduledEventPrivacyLevel int
+
+const (
+	// GuildScheduledEventPrivacyLevelGuildOnly makes the scheduled event only accessible to guild members
+	GuildScheduledEventPrivacyLevelGuildOnly GuildScheduledEventPrivacyLevel = 2
+)
+
+// GuildScheduledEventStatus is the status of a scheduled event.
+// Scheduled events can go from scheduled to active or canceled, and from active to completed.
+type GuildScheduledEventStatus int
+
+const (
+	GuildScheduledEventStatusScheduled GuildScheduledEventStatus = 1
+	GuildScheduledEventStatusActive    GuildScheduledEventStatus = 2
+	GuildScheduledEventStatusCompleted GuildScheduledEventStatus = 3
+	GuildScheduledEventStatusCanceled  GuildScheduledEventStatus = 4
+)
+
+// GuildScheduledEventEntityType is where a scheduled event takes place
+type GuildScheduledEventEntityType int
+
+const (
+	GuildScheduledEventEntityTypeStageInstance GuildScheduledEventEntityType = 1
+	GuildScheduledEventEntityTypeVoice         GuildScheduledEventEntityType = 2
+	GuildScheduledEventEntityTypeExternal      GuildScheduledEventEntityType = 3
+)
+
+// GuildScheduledEventUser is a user that subscribed to a scheduled event
+type GuildScheduledEventUser struct {
+	GuildScheduledEventID int64   `json:"guild_scheduled_event_id,string"`
+	User                  *User   `json:"user"`
+	Member                *Member `json:"member"` // only included when requested with withMember
+}
@@ -0,0 +1,80 @@
+package dshardorchestrator
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// Roles used in the auth macs, so that a mac can't be reflected back to the side that sent the nonce
+const (
+	AuthRoleNode         = "node"
+	AuthRoleOrchestrator = "orchestrator"
+)
+
+// MaxUnauthenticatedMessageSize is the max size of messages accepted from connections that haven't authenticated yet
+const MaxUnauthenticatedMessageSize = 1024
+
+// NewAuthNonce returns a new random nonce for a auth challenge
+func NewAuthNonce() ([]byte, error) {
+	nonce := make([]byte, 32)
+	_, err := rand.Read(nonce)
+	return nonce, err
+}
+
+// AuthMAC returns the mac of the nonce, proving that the role knows the shared secret
+func AuthMAC(secret []byte, role string, nonce []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("dshardorchestrator:" + role + ":"))
+	mac.Write(nonce)
+	return mac.Sum(nil)
+}
+
+// CheckAuthMAC returns true if mac is the mac of the nonce for the role
+func CheckAuthMAC(secret []byte, role string, nonce []byte, mac []byte) bool {
+	if len(nonce) < 16 {
+		return false
+	}
+
+	return hmac.Equal(AuthMAC(secret, role, nonce), mac)
+}
+
+// LoadTLSConfig returns a tls config for mutual tls authentication between the orchestrator and the nodes,
+// both sides present their certificate and only accept certificates signed by the ca.
+// serverName is the name in the orchestrators certificate, only used by nodes.
+func LoadTLSConfig(certFile, keyFile, caFile string, server bool, serverName string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, errors.WithMessage(err, "LoadX509KeyPair")
+	}
+
+	caPEM, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, errors.WithMessage(err, "ReadFile")
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, errors.New("no certificates found in " + caFile)
+	}
+
+	conf := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if server {
+		conf.ClientCAs = pool
+		conf.ClientAuth = tls.RequireAndVerifyClientCert
+	} else {
+		conf.RootCAs = pool
+		conf.ServerName = serverName
+	}
+
+	return conf, nil
+}
@@ -20,6 +20,9 @@ type Conn struct {
 
 	ID atomic.Value
 
+	// messages larger than this close the connection, 0 for no limit
+	maxMessageSize uint32
+
 	// called on incoming messages
 	MessageHandler func(*Message)
 
@@ -42,6 +45,11 @@ func (c *Conn) Close() {
 	c.netConn.Close()
 }
 
+// SetMaxMessageSize sets the max size of incoming messages, larger messages close the connection. 0 for no limit
+func (c *Conn) SetMaxMessageSize(size uint32) {
+	atomic.StoreUint32(&c.maxMessageSize, size)
+}
+
 // Listen starts listening for events on the connection
 func (c *Conn) Listen() {
 	c.Log(LogInfo, nil, "started listening for events...")
@@ -65,14 +73,14 @@ func (c *Conn) Listen() {
 	for {
 
 		// Read the event id
-		_, err = c.netConn.Read(idBuf)
+		_, err = io.ReadFull(c.netConn, idBuf)
 		if err != nil {
 			c.Log(LogError, err, "failed reading event id")
 			return
 		}
 
 		// Read the body length
-		_, err = c.netConn.Read(lenBuf)
+		_, err = io.ReadFull(c.netConn, lenBuf)
 		if err != nil {
 			c.Log(LogError, err, "failed reading event length")
 			return
@@ -81,6 +89,11 @@ func (c *Conn) Listen() {
 		id := EventType(binary.LittleEndian.Uint32(idBuf))
 		l := binary.LittleEndian.Uint32(lenBuf)
 
+		if max := atomic.LoadUint32(&c.maxMessageSize); max > 0 && l > max {
+			err = fmt.Errorf("message too large: %d > %d", l, max)
+			return
+		}
+
 		c.Log(LogDebug, err, fmt.Sprintf("inc message evt: %s, payload lenght: %d", id.String(), l))
 
 		body := make([]byte, int(l))
@@ -29,6 +29,22 @@ const (
 	// orchestrator -> node: shut down the node completely
 	EvtShutdown EventType = 3
 
+	// EvtAuthChallenge is sent to new connections when the orchestrator requires authentication
+	// orchestrator -> node: prove that you know the shared secret, the node should respond with EvtAuthResponse before identifying
+	EvtAuthChallenge EventType = 4
+
+	// EvtAuthResponse is the response to EvtAuthChallenge
+	// orchestrator <- node: includes the mac of the challenge nonce and a nonce for the orchestrator to prove itself with
+	EvtAuthResponse EventType = 5
+
+	// EvtAuthResult is sent when the node has been authenticated
+	// orchestrator -> node: includes the mac of the nodes nonce, the node should verify it and then identify
+	EvtAuthResult EventType = 6
+
+	// EvtNodeStatus is sent periodically by nodes with extended status
+	// orchestrator <- node: guild counts, memory usage and event rates of the node and its shards
+	EvtNodeStatus EventType = 7
+
 	// 1x: Shard control codes
 
 	// EvtStartShards assigns the following shards to the node, going through the full identify flow
@@ -76,6 +92,10 @@ var EventsToStringMap = map[EventType]string{
 	1: "Identify",
 	2: "Identified",
 	3: "Shutdown",
+	4: "AuthChallenge",
+	5: "AuthResponse",
+	6: "AuthResult",
+	7: "NodeStatus",
 
 	// 1x: Shard control codes
 	10: "StartShards",
@@ -99,6 +119,10 @@ func (evt EventType) String() string {
 var EvtDataMap = map[EventType]interface{}{
 	EvtIdentify:              IdentifyData{},
 	EvtIdentified:            IdentifiedData{},
+	EvtAuthChallenge:         AuthChallengeData{},
+	EvtAuthResponse:          AuthResponseData{},
+	EvtAuthResult:            AuthResultData{},
+	EvtNodeStatus:            NodeStatusData{},
 	EvtStartShards:           StartShardsData{},
 	EvtStopShard:             StopShardData{},
 	EvtPrepareShardmigration: PrepareShardmigrationData{},
@@ -40,3 +40,31 @@ type StartshardMigrationData struct {
 type AllUserDataSentData struct {
 	NumEvents int
 }
+
+type AuthChallengeData struct {
+	Nonce []byte
+}
+
+type AuthResponseData struct {
+	// MAC of the orchestrators nonce, proving the node knows the secret
+	MAC []byte
+
+	// Nonce the orchestrator has to prove it knows the secret with
+	Nonce []byte
+}
+
+type AuthResultData struct {
+	// MAC of the nodes nonce
+	MAC []byte
+}
+
+// NodeStatusData is the extended status of a node, used by the orchestrator to balance the load between nodes
+type NodeStatusData struct {
+	Guilds          int
+	MemoryUsage     uint64
+	EventsPerSecond float64
+
+	// per shard versions of the above, used to pick what shards to move
+	ShardGuilds          map[int]int
+	ShardEventsPerSecond map[int]float64
+}
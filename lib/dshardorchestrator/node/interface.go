@@ -33,3 +33,10 @@ type Interface interface {
 	// HandleUserEvent should handle a user event, most commonly used for migrating data between shards during transfers
 	HandleUserEvent(evt dshardorchestrator.EventType, data interface{})
 }
+
+// StatusProvider can optionally be implemented by the bot to report its load to the orchestrator,
+// which is used for things like automatic rebalancing
+type StatusProvider interface {
+	// NodeStatus returns the current status of the node, or nil if it's not available
+	NodeStatus() *dshardorchestrator.NodeStatusData
+}
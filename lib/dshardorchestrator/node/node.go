@@ -1,6 +1,7 @@
 package node
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
 	"sync"
@@ -17,6 +18,12 @@ var (
 type Conn struct {
 	NodeID string
 
+	// if set, the node proves it knows the secret to the orchestrator before identifying, and requires the same from it
+	AuthSecret []byte
+
+	// if set, the connection to the orchestrator uses tls
+	TLSConfig *tls.Config
+
 	baseConn *dshardorchestrator.Conn
 
 	bot                 Interface
@@ -33,6 +40,10 @@ type Conn struct {
 	reconnecting bool
 	sendQueue    [][]byte
 
+	authenticated     bool
+	authNonce         []byte
+	statusLoopStarted bool
+
 	shardMigrationMode          dshardorchestrator.ShardMigrationMode
 	shardMigrationShard         int
 	processedUserEvents         int
@@ -68,8 +79,20 @@ func (c *Conn) Run() {
 	go c.reconnectLoop(false)
 }
 
+// how long the orchestrator has to complete the authentication when a auth secret is set
+const authTimeout = time.Second * 10
+
+// how often the extended status is sent to the orchestrator, if the bot implements StatusProvider
+const statusInterval = time.Second * 10
+
 func (c *Conn) connect() error {
-	netConn, err := net.Dial("tcp", c.orchestratorAddress)
+	var netConn net.Conn
+	var err error
+	if c.TLSConfig != nil {
+		netConn, err = tls.Dial("tcp", c.orchestratorAddress, c.TLSConfig)
+	} else {
+		netConn, err = net.Dial("tcp", c.orchestratorAddress)
+	}
 	if err != nil {
 		return err
 	}
@@ -81,21 +104,88 @@ func (c *Conn) connect() error {
 	c.baseConn.ConnClosedHanlder = c.onClosedConn
 	c.reconnecting = false
 	c.baseConn.ID.Store(c.NodeID)
+	c.authNonce = nil
 
 	go c.baseConn.Listen()
 
-	go c.SendLogErr(dshardorchestrator.EvtIdentify, &dshardorchestrator.IdentifyData{
+	if len(c.AuthSecret) > 0 {
+		// identify once the orchestrator has authenticated
+		c.authenticated = false
+		baseConn := c.baseConn
+		time.AfterFunc(authTimeout, func() {
+			c.mu.Lock()
+			timedOut := c.baseConn == baseConn && !c.authenticated
+			c.mu.Unlock()
+
+			if timedOut {
+				baseConn.Log(dshardorchestrator.LogWarning, nil, "orchestrator did not authenticate in time, closing connection")
+				baseConn.Close()
+			}
+		})
+
+		c.mu.Unlock()
+		return nil
+	}
+
+	c.authenticated = true
+	c.mu.Unlock()
+
+	go c.sendIdentify()
+	return nil
+}
+
+func (c *Conn) sendIdentify() {
+	c.mu.Lock()
+	data := &dshardorchestrator.IdentifyData{
 		NodeID:                   c.baseConn.GetID(),
 		RunningShards:            c.nodeShards,
 		TotalShards:              c.totalShards,
 		Version:                  c.nodeVersion,
 		OrchestratorLogicVersion: 2,
+	}
+	c.mu.Unlock()
+
+	c.SendLogErr(dshardorchestrator.EvtIdentify, data, false)
+	c.LogLock(dshardorchestrator.LogInfo, nil, "sent identify")
+}
+
+func (c *Conn) handleAuthChallenge(data *dshardorchestrator.AuthChallengeData) {
+	if len(c.AuthSecret) < 1 {
+		c.LogLock(dshardorchestrator.LogError, nil, "orchestrator requires authentication but no auth secret is set")
+		return
+	}
+
+	nonce, err := dshardorchestrator.NewAuthNonce()
+	if err != nil {
+		c.LogLock(dshardorchestrator.LogError, err, "failed generating auth nonce")
+		return
+	}
+
+	c.mu.Lock()
+	c.authNonce = nonce
+	c.mu.Unlock()
+
+	go c.SendLogErr(dshardorchestrator.EvtAuthResponse, &dshardorchestrator.AuthResponseData{
+		MAC:   dshardorchestrator.AuthMAC(c.AuthSecret, dshardorchestrator.AuthRoleNode, data.Nonce),
+		Nonce: nonce,
 	}, false)
+}
 
-	c.baseConn.Log(dshardorchestrator.LogInfo, nil, "sent identify")
+func (c *Conn) handleAuthResult(data *dshardorchestrator.AuthResultData) {
+	c.mu.Lock()
+	valid := c.authNonce != nil && dshardorchestrator.CheckAuthMAC(c.AuthSecret, dshardorchestrator.AuthRoleOrchestrator, c.authNonce, data.MAC)
+	c.authNonce = nil
+	c.authenticated = valid
+	baseConn := c.baseConn
 	c.mu.Unlock()
 
-	return nil
+	if !valid {
+		baseConn.Log(dshardorchestrator.LogError, nil, "orchestrator failed authentication, closing connection")
+		baseConn.Close()
+		return
+	}
+
+	go c.sendIdentify()
 }
 
 func (c *Conn) Close() {
@@ -140,6 +230,23 @@ func (c *Conn) tryReconnect(running bool) bool {
 }
 
 func (c *Conn) handleMessage(m *dshardorchestrator.Message) {
+	switch m.EvtID {
+	case dshardorchestrator.EvtAuthChallenge:
+		c.handleAuthChallenge(m.DecodedBody.(*dshardorchestrator.AuthChallengeData))
+		return
+	case dshardorchestrator.EvtAuthResult:
+		c.handleAuthResult(m.DecodedBody.(*dshardorchestrator.AuthResultData))
+		return
+	}
+
+	c.mu.Lock()
+	authenticated := c.authenticated
+	c.mu.Unlock()
+	if !authenticated {
+		c.LogLock(dshardorchestrator.LogWarning, nil, "ignoring "+m.EvtID.String()+" from unauthenticated orchestrator")
+		return
+	}
+
 	switch m.EvtID {
 	case dshardorchestrator.EvtIdentified:
 		c.handleIdentified(m.DecodedBody.(*dshardorchestrator.IdentifiedData))
@@ -168,13 +275,40 @@ func (c *Conn) handleIdentified(data *dshardorchestrator.IdentifiedData) {
 	c.baseConn.ID.Store(data.NodeID)
 
 	c.baseConn.Log(dshardorchestrator.LogInfo, nil, "Session established")
+
+	startStatusLoop := false
+	if _, ok := c.bot.(StatusProvider); ok && !c.statusLoopStarted {
+		c.statusLoopStarted = true
+		startStatusLoop = true
+	}
 	c.mu.Unlock()
 
+	if startStatusLoop {
+		go c.statusLoop()
+	}
+
 	c.bot.SessionEstablished(SessionInfo{
 		TotalShards: data.TotalShards,
 	})
 }
 
+// statusLoop periodically sends the extended status of the node to the orchestrator
+func (c *Conn) statusLoop() {
+	provider := c.bot.(StatusProvider)
+
+	ticker := time.NewTicker(statusInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		status := provider.NodeStatus()
+		if status == nil {
+			continue
+		}
+
+		c.SendLogErr(dshardorchestrator.EvtNodeStatus, status, false)
+	}
+}
+
 func (c *Conn) handleStartShard(data *dshardorchestrator.StartShardsData) {
 	c.bot.AddNewShards(data.ShardIDs...)
 
@@ -16,6 +16,8 @@ type monitor struct {
 	lastTimeLaunchedNode       time.Time
 	lastTimeStartedShardBucket time.Time
 	shardsLastSeenTimes        []time.Time
+
+	rebalancer rebalancer
 }
 
 func (mon *monitor) run() {
@@ -68,6 +70,8 @@ func (mon *monitor) ensureTotalShards() int {
 }
 
 func (mon *monitor) tick() {
+	mon.maybeRebalance()
+
 	if !mon.orchestrator.EnsureAllShardsRunning {
 		// currently this is the only purpose of the monitor, it may be extended to perform more as it could be a reliable way of handling a bunch of things
 		return
@@ -31,8 +31,17 @@ type NodeConn struct {
 	shardmigrationTotalUserEvts int
 
 	shuttingDown bool
+
+	authenticated bool
+	authNonce     []byte
+
+	status          *dshardorchestrator.NodeStatusData
+	statusUpdatedAt time.Time
 }
 
+// how long new connections have to authenticate when the orchestrator has a auth secret set
+const authTimeout = time.Second * 10
+
 // NewNodeConn creates a new NodeConn (connection from master to slave) from a net.Conn
 func (o *Orchestrator) NewNodeConn(netConn net.Conn) *NodeConn {
 	sc := &NodeConn{
@@ -41,6 +50,12 @@ func (o *Orchestrator) NewNodeConn(netConn net.Conn) *NodeConn {
 		connected:    true,
 	}
 
+	if len(o.AuthSecret) > 0 {
+		sc.Conn.SetMaxMessageSize(dshardorchestrator.MaxUnauthenticatedMessageSize)
+	} else {
+		sc.authenticated = true
+	}
+
 	sc.Conn.MessageHandler = sc.handleMessage
 	sc.Conn.ConnClosedHanlder = func() {
 		// TODO
@@ -65,9 +80,80 @@ func (nc *NodeConn) removeShard(shardID int) {
 	}
 }
 
+// sendAuthChallenge sends the auth challenge to the node, closing the connection if it doesn't authenticate in time
+func (nc *NodeConn) sendAuthChallenge() {
+	nonce, err := dshardorchestrator.NewAuthNonce()
+	if err != nil {
+		nc.Conn.Log(dshardorchestrator.LogError, err, "failed generating auth nonce")
+		nc.Conn.Close()
+		return
+	}
+
+	nc.mu.Lock()
+	nc.authNonce = nonce
+	nc.mu.Unlock()
+
+	time.AfterFunc(authTimeout, func() {
+		nc.mu.Lock()
+		authenticated := nc.authenticated
+		nc.mu.Unlock()
+
+		if !authenticated {
+			nc.Conn.Log(dshardorchestrator.LogWarning, nil, "node did not authenticate in time, closing connection")
+			nc.Conn.Close()
+		}
+	})
+
+	nc.Conn.SendLogErr(dshardorchestrator.EvtAuthChallenge, &dshardorchestrator.AuthChallengeData{
+		Nonce: nonce,
+	})
+}
+
+func (nc *NodeConn) handleAuthResponse(data *dshardorchestrator.AuthResponseData) {
+	secret := nc.Orchestrator.AuthSecret
+
+	nc.mu.Lock()
+	valid := nc.authNonce != nil && dshardorchestrator.CheckAuthMAC(secret, dshardorchestrator.AuthRoleNode, nc.authNonce, data.MAC)
+	nc.authNonce = nil
+	nc.authenticated = valid
+	nc.mu.Unlock()
+
+	if !valid {
+		nc.Conn.Log(dshardorchestrator.LogWarning, nil, "node failed authentication, closing connection")
+		nc.Conn.Close()
+		return
+	}
+
+	nc.Conn.SetMaxMessageSize(0)
+	go nc.Conn.SendLogErr(dshardorchestrator.EvtAuthResult, &dshardorchestrator.AuthResultData{
+		MAC: dshardorchestrator.AuthMAC(secret, dshardorchestrator.AuthRoleOrchestrator, data.Nonce),
+	})
+}
+
 // Handle incoming messages
 func (nc *NodeConn) handleMessage(msg *dshardorchestrator.Message) {
+	nc.mu.Lock()
+	authenticated := nc.authenticated
+	nc.mu.Unlock()
+
+	if !authenticated {
+		if data, ok := msg.DecodedBody.(*dshardorchestrator.AuthResponseData); ok && msg.EvtID == dshardorchestrator.EvtAuthResponse {
+			nc.handleAuthResponse(data)
+			return
+		}
+
+		nc.Conn.Log(dshardorchestrator.LogWarning, nil, "unauthenticated node sent "+msg.EvtID.String()+", closing connection")
+		nc.Conn.Close()
+		return
+	}
+
 	switch msg.EvtID {
+	case dshardorchestrator.EvtNodeStatus:
+		nc.mu.Lock()
+		nc.status = msg.DecodedBody.(*dshardorchestrator.NodeStatusData)
+		nc.statusUpdatedAt = time.Now()
+		nc.mu.Unlock()
+
 	case dshardorchestrator.EvtIdentify:
 		nc.handleIdentify(msg.DecodedBody.(*dshardorchestrator.IdentifyData))
 
@@ -272,6 +358,15 @@ func (nc *NodeConn) GetFullStatus() *NodeStatus {
 	status.Shards = make([]int, len(nc.runningShards))
 	copy(status.Shards, nc.runningShards)
 
+	if nc.status != nil {
+		status.Guilds = nc.status.Guilds
+		status.MemoryUsage = nc.status.MemoryUsage
+		status.EventsPerSecond = nc.status.EventsPerSecond
+		status.ShardGuilds = nc.status.ShardGuilds
+		status.ShardEventsPerSecond = nc.status.ShardEventsPerSecond
+		status.StatusUpdatedAt = nc.statusUpdatedAt
+	}
+
 	if nc.shardMigrationMode == dshardorchestrator.ShardMigrationModeFrom {
 		status.MigratingTo = nc.shardMigrationOtherNodeID
 	} else if nc.shardMigrationMode == dshardorchestrator.ShardMigrationModeTo {
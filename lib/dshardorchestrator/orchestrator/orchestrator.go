@@ -1,6 +1,7 @@
 package orchestrator
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
 	"sync"
@@ -79,6 +80,15 @@ type Orchestrator struct {
 	// in case we are intiailizing max shards from nodes, we wait 10 seconds when we start before we decide we need to fetch a fresh shard count
 	SkipSafeStartupDelayMaxShards bool
 
+	// if set, nodes have to prove they know this secret before they can identify, and the orchestrator proves it to them
+	AuthSecret []byte
+
+	// if set, node connections use tls, use dshardorchestrator.LoadTLSConfig for mutual authentication with certificates
+	TLSConfig *tls.Config
+
+	// if set, shards are automatically moved from the most to the least loaded nodes, see RebalanceConfig
+	Rebalancer *RebalanceConfig
+
 	monitor *monitor
 
 	// below fields are protected by the following mutex
@@ -94,6 +104,7 @@ type Orchestrator struct {
 	blacklistedNodes []string
 
 	performingFullMigration bool
+	performingRebalance     bool
 }
 
 func NewStandardOrchestrator(session *discordgo.Session) *Orchestrator {
@@ -103,7 +114,7 @@ func NewStandardOrchestrator(session *discordgo.Session) *Orchestrator {
 }
 
 // Start will start the orchestrator, and start to listen for clients on the specified address
-// IMPORTANT: opening this up to the outer internet is bad because there's no authentication.
+// IMPORTANT: set AuthSecret or TLSConfig if this is reachable by anything other than your nodes, otherwise anyone can connect.
 func (o *Orchestrator) Start(listenAddr string) error {
 	o.mu.Lock()
 	defer o.mu.Unlock()
@@ -134,7 +145,13 @@ func (o *Orchestrator) Stop() {
 // openListen starts listening for slave connections on the specified address
 func (o *Orchestrator) openListen(addr string) error {
 
-	listener, err := net.Listen("tcp", addr)
+	var listener net.Listener
+	var err error
+	if o.TLSConfig != nil {
+		listener, err = tls.Listen("tcp", addr, o.TLSConfig)
+	} else {
+		listener, err = net.Listen("tcp", addr)
+	}
 	if err != nil {
 		return errors.WithMessage(err, "net.Listen")
 	}
@@ -164,6 +181,9 @@ func (o *Orchestrator) listenForNodes(listener net.Listener) {
 		o.mu.Unlock()
 
 		go client.listen()
+		if len(o.AuthSecret) > 0 {
+			go client.sendAuthChallenge()
+		}
 	}
 }
 
@@ -192,6 +212,14 @@ type NodeStatus struct {
 	MigratingFrom  string
 	MigratingTo    string
 	MigratingShard int
+
+	// extended status reported by the node, StatusUpdatedAt is zero if the node hasn't reported any
+	Guilds               int
+	MemoryUsage          uint64
+	EventsPerSecond      float64
+	ShardGuilds          map[int]int
+	ShardEventsPerSecond map[int]float64
+	StatusUpdatedAt      time.Time
 }
 
 // GetFullNodesStatus returns the full status of all nodes
@@ -217,6 +245,7 @@ var (
 	ErrUnknownToNode           = errors.New("unknown 'to' node")
 	ErrFromNodeNotRunningShard = errors.New("'from' node not running shard")
 	ErrNodeBusy                = errors.New("node is busy")
+	ErrMigrationInProgress     = errors.New("a full migration or rebalance is in progress")
 )
 
 // StartShardMigration attempts to start a shard migration, moving shardID from a origin node to a destination node
@@ -421,13 +450,7 @@ func (o *Orchestrator) MigrateFullNode(fromNode string, toNodeID string, shutdow
 		o.WaitForShardMigration(nodeFrom, toNode, s)
 
 		// reset here in case something went wrong
-		toNode.mu.Lock()
-		toNode.shardMigrationMode = dshardorchestrator.ShardMigrationModeNone
-		toNode.mu.Unlock()
-
-		nodeFrom.mu.Lock()
-		nodeFrom.shardMigrationMode = dshardorchestrator.ShardMigrationModeNone
-		nodeFrom.mu.Unlock()
+		resetMigrationState(nodeFrom, toNode)
 
 		// wait a bit extra to allow for some time ot catch up on events processing
 		time.Sleep(time.Second)
@@ -440,13 +463,57 @@ func (o *Orchestrator) MigrateFullNode(fromNode string, toNodeID string, shutdow
 	return nil
 }
 
-// ShutdownNode shuts down the specified node
+// MigrateShard migrates the shard to the destination node, returning when the migration is complete
+func (o *Orchestrator) MigrateShard(toNodeID string, shardID int) error {
+	var fromNode *NodeConn
+	for _, v := range o.GetFullNodesStatus() {
+		if v.Connected && dshardorchestrator.ContainsInt(v.Shards, shardID) {
+			fromNode = o.FindNodeByID(v.ID)
+			break
+		}
+	}
+
+	if fromNode == nil {
+		return ErrUnknownFromNode
+	}
+
+	toNode := o.FindNodeByID(toNodeID)
+	if toNode == nil {
+		return ErrUnknownToNode
+	}
+
+	err := o.StartShardMigration(toNodeID, shardID)
+	if err != nil {
+		return err
+	}
+
+	o.WaitForShardMigration(fromNode, toNode, shardID)
+	resetMigrationState(fromNode, toNode)
+	return nil
+}
+
+func resetMigrationState(nodes ...*NodeConn) {
+	for _, v := range nodes {
+		v.mu.Lock()
+		v.shardMigrationMode = dshardorchestrator.ShardMigrationModeNone
+		v.mu.Unlock()
+	}
+}
+
+// ShutdownNode shuts down the specified node, nodes in the middle of a shard migration can't be shut down
 func (o *Orchestrator) ShutdownNode(nodeID string) error {
 	node := o.FindNodeByID(nodeID)
 	if node == nil {
 		return ErrUnknownNode
 	}
 
+	node.mu.Lock()
+	migrating := node.shardMigrationMode != dshardorchestrator.ShardMigrationModeNone
+	node.mu.Unlock()
+	if migrating {
+		return ErrNodeBusy
+	}
+
 	node.Shutdown()
 	return nil
 }
@@ -550,6 +617,10 @@ func (o *Orchestrator) MigrateAllNodesToNewNodes(returnOnError bool) error {
 		o.mu.Unlock()
 		return errors.New("Already performing a full migration")
 	}
+	if o.performingRebalance {
+		o.mu.Unlock()
+		return ErrMigrationInProgress
+	}
 	o.performingFullMigration = true
 	o.mu.Unlock()
 
@@ -0,0 +1,265 @@
+package orchestrator
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/lib/dshardorchestrator"
+)
+
+// RebalanceConfig configures the automatic rebalancing of shards between nodes.
+//
+// The load of a node is its event rate, or its guild count if not all nodes report event rates.
+// When the difference between the most and least loaded node is above the threshold, the shard on the most loaded node
+// that evens them out the most is migrated, one shard at a time.
+//
+// The rebalancer only uses nodes that report their extended status, and respects MaxShardsPerNode and the
+// shard buckets of the orchestrator.
+type RebalanceConfig struct {
+	// How often the load of the nodes is checked
+	Interval time.Duration
+
+	// Shards are moved when the difference in load between the most and least loaded node is larger than
+	// this fraction of the average load, e.g 0.5 for 50%
+	Threshold float64
+
+	// Minimum time between automatic migrations
+	MigrationCooldown time.Duration
+
+	// A migrated shard won't be moved again within this time
+	ShardCooldown time.Duration
+
+	// Max number of automatic migrations per hour, 0 for no limit
+	MaxMigrationsPerHour int
+
+	// Nodes that haven't reported their status within this time are left alone
+	MaxStatusAge time.Duration
+
+	// Nodes using more memory than this in bytes don't get shards moved to them, 0 for no limit
+	MaxNodeMemory uint64
+}
+
+// NewRebalanceConfig returns a conservative rebalance config
+func NewRebalanceConfig() *RebalanceConfig {
+	return &RebalanceConfig{
+		Interval:             time.Minute,
+		Threshold:            0.5,
+		MigrationCooldown:    time.Minute * 5,
+		ShardCooldown:        time.Hour,
+		MaxMigrationsPerHour: 6,
+		MaxStatusAge:         time.Minute,
+	}
+}
+
+type plannedMigration struct {
+	Shard int
+	From  string
+	To    string
+}
+
+// planRebalance returns the migration that evens out the load between the nodes the most, or nil if they're balanced.
+// slotFor returns the node slot of a shard, a node only runs shards of a single slot.
+// movable returns false for shards that shouldn't be moved right now.
+func planRebalance(conf *RebalanceConfig, nodes []*NodeStatus, maxShardsPerNode int, slotFor func(shard int) int, movable func(shard int) bool) *plannedMigration {
+	candidates := make([]*NodeStatus, 0, len(nodes))
+	for _, v := range nodes {
+		if !v.Connected || !v.SessionEstablished || v.MigratingFrom != "" || v.MigratingTo != "" {
+			continue
+		}
+
+		if v.StatusUpdatedAt.IsZero() || (conf.MaxStatusAge > 0 && time.Since(v.StatusUpdatedAt) > conf.MaxStatusAge) {
+			continue
+		}
+
+		candidates = append(candidates, v)
+	}
+
+	if len(candidates) < 2 {
+		return nil
+	}
+
+	// use the event rates if all nodes with shards report them
+	useEvents := true
+	for _, v := range candidates {
+		if len(v.Shards) > 0 && v.EventsPerSecond <= 0 {
+			useEvents = false
+			break
+		}
+	}
+
+	nodeLoad := func(n *NodeStatus) float64 {
+		if useEvents {
+			return n.EventsPerSecond
+		}
+
+		return float64(n.Guilds)
+	}
+
+	shardLoad := func(n *NodeStatus, shard int) float64 {
+		if useEvents {
+			if l, ok := n.ShardEventsPerSecond[shard]; ok {
+				return l
+			}
+		} else if l, ok := n.ShardGuilds[shard]; ok {
+			return float64(l)
+		}
+
+		// no per shard info, assume the shards are even
+		return nodeLoad(n) / float64(len(n.Shards))
+	}
+
+	totalLoad := 0.0
+	for _, v := range candidates {
+		totalLoad += nodeLoad(v)
+	}
+
+	avg := totalLoad / float64(len(candidates))
+	if avg <= 0 {
+		return nil
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return nodeLoad(candidates[i]) > nodeLoad(candidates[j])
+	})
+
+	from := candidates[0]
+	if len(from.Shards) < 1 {
+		return nil
+	}
+
+	for i := len(candidates) - 1; i > 0; i-- {
+		to := candidates[i]
+		diff := nodeLoad(from) - nodeLoad(to)
+		if diff <= conf.Threshold*avg {
+			// the rest are even closer to the most loaded node
+			return nil
+		}
+
+		if to.Blacklisted || (maxShardsPerNode > 0 && len(to.Shards) >= maxShardsPerNode) {
+			continue
+		}
+
+		if conf.MaxNodeMemory > 0 && to.MemoryUsage > conf.MaxNodeMemory {
+			continue
+		}
+
+		bestShard := -1
+		bestResult := diff
+		for _, s := range from.Shards {
+			if !movable(s) || !slotMatches(to.Shards, s, slotFor) {
+				continue
+			}
+
+			// the difference between the two after moving the shard
+			result := math.Abs(diff - 2*shardLoad(from, s))
+			if result < bestResult {
+				bestResult = result
+				bestShard = s
+			}
+		}
+
+		if bestShard != -1 {
+			return &plannedMigration{
+				Shard: bestShard,
+				From:  from.ID,
+				To:    to.ID,
+			}
+		}
+	}
+
+	return nil
+}
+
+func slotMatches(nodeShards []int, shard int, slotFor func(shard int) int) bool {
+	slot := slotFor(shard)
+	for _, v := range nodeShards {
+		if slotFor(v) != slot {
+			return false
+		}
+	}
+
+	return true
+}
+
+type rebalancer struct {
+	lastCheck     time.Time
+	lastMigration time.Time
+	migrations    []time.Time
+	shardsMovedAt map[int]time.Time
+}
+
+func (mon *monitor) maybeRebalance() {
+	o := mon.orchestrator
+	conf := o.Rebalancer
+	if conf == nil {
+		return
+	}
+
+	if time.Since(mon.rebalancer.lastCheck) < conf.Interval || time.Since(mon.rebalancer.lastMigration) < conf.MigrationCooldown {
+		return
+	}
+	mon.rebalancer.lastCheck = time.Now()
+
+	// let things settle after shards have been started or nodes launched
+	if time.Since(mon.lastTimeStartedShardBucket) < time.Minute || time.Since(mon.lastTimeLaunchedNode) < time.Minute {
+		return
+	}
+
+	// forget migrations older than an hour
+	recent := mon.rebalancer.migrations[:0]
+	for _, v := range mon.rebalancer.migrations {
+		if time.Since(v) < time.Hour {
+			recent = append(recent, v)
+		}
+	}
+	mon.rebalancer.migrations = recent
+
+	if conf.MaxMigrationsPerHour > 0 && len(mon.rebalancer.migrations) >= conf.MaxMigrationsPerHour {
+		return
+	}
+
+	nodes := o.GetFullNodesStatus()
+	for _, v := range nodes {
+		if v.MigratingFrom != "" || v.MigratingTo != "" {
+			// a migration is already going on
+			return
+		}
+	}
+
+	plan := planRebalance(conf, nodes, o.MaxShardsPerNode, mon.nodeSlotForShard, func(shard int) bool {
+		return time.Since(mon.rebalancer.shardsMovedAt[shard]) > conf.ShardCooldown
+	})
+	if plan == nil {
+		return
+	}
+
+	o.mu.Lock()
+	if o.performingFullMigration || o.performingRebalance {
+		o.mu.Unlock()
+		return
+	}
+	o.performingRebalance = true
+	o.mu.Unlock()
+
+	if mon.rebalancer.shardsMovedAt == nil {
+		mon.rebalancer.shardsMovedAt = make(map[int]time.Time)
+	}
+	mon.rebalancer.shardsMovedAt[plan.Shard] = time.Now()
+	mon.rebalancer.lastMigration = time.Now()
+	mon.rebalancer.migrations = append(mon.rebalancer.migrations, time.Now())
+
+	o.Log(dshardorchestrator.LogInfo, nil, fmt.Sprintf("rebalancer: moving shard %d from %s to %s", plan.Shard, plan.From, plan.To))
+
+	go func() {
+		err := o.MigrateShard(plan.To, plan.Shard)
+		if err != nil {
+			o.Log(dshardorchestrator.LogError, err, "rebalancer: failed migrating shard")
+		}
+
+		o.mu.Lock()
+		o.performingRebalance = false
+		o.mu.Unlock()
+	}()
+}
@@ -0,0 +1,70 @@
+package orchestrator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPlanRebalance(t *testing.T) {
+	conf := NewRebalanceConfig()
+	noSlots := func(shard int) int { return 0 }
+	allMovable := func(shard int) bool { return true }
+
+	node := func(id string, guilds map[int]int) *NodeStatus {
+		n := &NodeStatus{
+			ID:                 id,
+			Connected:          true,
+			SessionEstablished: true,
+			ShardGuilds:        guilds,
+			StatusUpdatedAt:    time.Now(),
+		}
+		for shard, g := range guilds {
+			n.Shards = append(n.Shards, shard)
+			n.Guilds += g
+		}
+		return n
+	}
+
+	balanced := []*NodeStatus{
+		node("a", map[int]int{0: 100, 1: 100}),
+		node("b", map[int]int{2: 100, 3: 90}),
+	}
+	if plan := planRebalance(conf, balanced, 0, noSlots, allMovable); plan != nil {
+		t.Errorf("expected no migration for balanced nodes, got %#v", plan)
+	}
+
+	unbalanced := []*NodeStatus{
+		node("a", map[int]int{0: 100, 1: 300, 2: 50}),
+		node("b", map[int]int{3: 50}),
+	}
+	plan := planRebalance(conf, unbalanced, 0, noSlots, allMovable)
+	if plan == nil || plan.Shard != 0 || plan.From != "a" || plan.To != "b" {
+		t.Fatalf("expected shard 0 to move from a to b, got %#v", plan)
+	}
+
+	// shard 0 is on cooldown, 1 is the next best
+	plan = planRebalance(conf, unbalanced, 0, noSlots, func(shard int) bool { return shard != 0 })
+	if plan == nil || plan.Shard != 1 {
+		t.Fatalf("expected shard 1 to move, got %#v", plan)
+	}
+
+	// b is full
+	if plan := planRebalance(conf, unbalanced, 1, noSlots, allMovable); plan != nil {
+		t.Errorf("expected no migration to a full node, got %#v", plan)
+	}
+
+	// b only runs odd shards
+	plan = planRebalance(conf, []*NodeStatus{
+		node("a", map[int]int{0: 100, 1: 300, 2: 50}),
+		node("b", map[int]int{5: 50}),
+	}, 0, func(shard int) int { return shard % 2 }, allMovable)
+	if plan == nil || plan.Shard != 1 {
+		t.Fatalf("expected shard 1 to move, got %#v", plan)
+	}
+
+	// stale status
+	unbalanced[1].StatusUpdatedAt = time.Now().Add(-time.Hour)
+	if plan := planRebalance(conf, unbalanced, 0, noSlots, allMovable); plan != nil {
+		t.Errorf("expected no migration with a stale status, got %#v", plan)
+	}
+}
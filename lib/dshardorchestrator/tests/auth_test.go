@@ -0,0 +1,52 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/lib/dshardorchestrator/node"
+)
+
+func TestAuthenticatedSession(t *testing.T) {
+	orchestrator := CreateMockOrchestrator(10)
+	orchestrator.AuthSecret = []byte("secret")
+	err := orchestrator.Start(testServerAddr)
+	if err != nil {
+		t.Fatal("failed starting orchestrator: ", err)
+		return
+	}
+	defer orchestrator.Stop()
+
+	waitChan := make(chan node.SessionInfo, 1)
+	bot := &MockBot{
+		SessionEstablishedFunc: func(info node.SessionInfo) {
+			waitChan <- info
+		},
+	}
+
+	// wrong secret
+	bad := node.NewNodeConn(bot, testServerAddr, "testing", generateID(), testLoggerNode)
+	bad.AuthSecret = []byte("wrong")
+	bad.Run()
+
+	select {
+	case <-waitChan:
+		t.Fatal("session established with the wrong secret")
+	case <-time.After(time.Second * 2):
+	}
+	bad.Close()
+
+	n := node.NewNodeConn(bot, testServerAddr, "testing", generateID(), testLoggerNode)
+	n.AuthSecret = []byte("secret")
+	n.Run()
+	defer n.Close()
+
+	select {
+	case info := <-waitChan:
+		if info.TotalShards != 10 {
+			t.Error("mismatched total shards: ", info.TotalShards)
+		}
+	case <-time.After(time.Second * 15):
+		t.Fatal("timed out waiting for session to be established")
+	}
+}
@@ -0,0 +1,63 @@
+package de
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules"
+)
+
+func CasualDate(s rules.Strategy) rules.Rule {
+	overwrite := s == rules.Override
+
+	return &rules.F{
+		RegExp: regexp.MustCompile("(?i)(?:\\W|^)(jetzt|heute\\s+(?:abend|nacht|morgen|früh)|heute|gestern\\s+(?:abend|nacht)|übermorgen|vorgestern|morgen|gestern)(?:\\W|$)"),
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			lower := strings.ToLower(strings.TrimSpace(m.String()))
+
+			switch {
+			case strings.HasPrefix(lower, "heute") && (strings.Contains(lower, "abend") || strings.Contains(lower, "nacht")):
+				if c.Hour == nil && c.Minute == nil || overwrite {
+					c.Hour = pointer.ToInt(23)
+					c.Minute = pointer.ToInt(0)
+				}
+			case strings.HasPrefix(lower, "heute") && (strings.Contains(lower, "morgen") || strings.Contains(lower, "früh")):
+				if c.Hour == nil && c.Minute == nil || overwrite {
+					if o.Morning != 0 {
+						c.Hour = &o.Morning
+					} else {
+						c.Hour = pointer.ToInt(8)
+					}
+					c.Minute = pointer.ToInt(0)
+				}
+			case strings.Contains(lower, "heute"):
+				// c.Hour = pointer.ToInt(18)
+			case strings.Contains(lower, "übermorgen"):
+				if c.Duration == 0 || overwrite {
+					c.Duration += time.Hour * 48
+				}
+			case strings.Contains(lower, "vorgestern"):
+				if c.Duration == 0 || overwrite {
+					c.Duration -= time.Hour * 48
+				}
+			case strings.HasPrefix(lower, "gestern") && lower != "gestern":
+				if (c.Hour == nil && c.Duration == 0) || overwrite {
+					c.Hour = pointer.ToInt(23)
+					c.Duration -= time.Hour * 24
+				}
+			case strings.Contains(lower, "morgen"):
+				if c.Duration == 0 || overwrite {
+					c.Duration += time.Hour * 24
+				}
+			case strings.Contains(lower, "gestern"):
+				if c.Duration == 0 || overwrite {
+					c.Duration -= time.Hour * 24
+				}
+			}
+
+			return true, nil
+		},
+	}
+}
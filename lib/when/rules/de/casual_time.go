@@ -0,0 +1,56 @@
+package de
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules"
+)
+
+// "morgen" alone means tomorrow, the morning needs "am morgen", "morgens" or "früh"
+func CasualTime(s rules.Strategy) rules.Rule {
+	overwrite := s == rules.Override
+
+	return &rules.F{
+		RegExp: regexp.MustCompile(`(?i)(?:\W|^)((?:am\s+|zu\s+)(?:morgen|vormittag|mittag|nachmittag|abend)|morgens|vormittags|mittags|nachmittags|abends|früh)(?:\W|$)`),
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			lower := strings.ToLower(strings.TrimSpace(m.String()))
+
+			if (c.Hour != nil || c.Minute != nil) && !overwrite {
+				return false, nil
+			}
+
+			switch {
+			case strings.Contains(lower, "nachmittag"):
+				if o.Afternoon != 0 {
+					c.Hour = &o.Afternoon
+				} else {
+					c.Hour = pointer.ToInt(15)
+				}
+			case strings.Contains(lower, "abend"):
+				if o.Evening != 0 {
+					c.Hour = &o.Evening
+				} else {
+					c.Hour = pointer.ToInt(18)
+				}
+			case strings.Contains(lower, "morgen"), strings.Contains(lower, "vormittag"), strings.Contains(lower, "früh"):
+				if o.Morning != 0 {
+					c.Hour = &o.Morning
+				} else {
+					c.Hour = pointer.ToInt(8)
+				}
+			case strings.Contains(lower, "mittag"):
+				if o.Noon != 0 {
+					c.Hour = &o.Noon
+				} else {
+					c.Hour = pointer.ToInt(12)
+				}
+			}
+
+			c.Minute = pointer.ToInt(0)
+			return true, nil
+		},
+	}
+}
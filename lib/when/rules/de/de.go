@@ -0,0 +1,89 @@
+package de
+
+import "github.com/botlabs-gg/yagpdb/v2/lib/when/rules"
+
+var All = []rules.Rule{
+	Weekday(rules.Override),
+	CasualDate(rules.Override),
+	CasualTime(rules.Override),
+	Hour(rules.Override),
+	HourMinute(rules.Override),
+	Deadline(rules.Override),
+	ExactMonthDate(rules.Override),
+}
+
+var WEEKDAY_OFFSET = map[string]int{
+	"sonntag":    0,
+	"montag":     1,
+	"dienstag":   2,
+	"mittwoch":   3,
+	"donnerstag": 4,
+	"freitag":    5,
+	"samstag":    6,
+	"sonnabend":  6,
+}
+
+var WEEKDAY_OFFSET_PATTERN = "(?:sonntag|montag|dienstag|mittwoch|donnerstag|freitag|samstag|sonnabend)"
+
+var MONTH_OFFSET = map[string]int{
+	"januar":    1,
+	"jänner":    1,
+	"jan":       1,
+	"jan.":      1,
+	"februar":   2,
+	"feb":       2,
+	"feb.":      2,
+	"märz":      3,
+	"mär":       3,
+	"mär.":      3,
+	"april":     4,
+	"apr":       4,
+	"apr.":      4,
+	"mai":       5,
+	"juni":      6,
+	"jun":       6,
+	"jun.":      6,
+	"juli":      7,
+	"jul":       7,
+	"jul.":      7,
+	"august":    8,
+	"aug":       8,
+	"aug.":      8,
+	"september": 9,
+	"sep":       9,
+	"sep.":      9,
+	"sept":      9,
+	"sept.":     9,
+	"oktober":   10,
+	"okt":       10,
+	"okt.":      10,
+	"november":  11,
+	"nov":       11,
+	"nov.":      11,
+	"dezember":  12,
+	"dez":       12,
+	"dez.":      12,
+}
+
+var MONTH_OFFSET_PATTERN = `(?:januar|jänner|jan\.?|februar|feb\.?|märz|mär\.?|april|apr\.?|mai|juni|jun\.?|juli|jul\.?|august|aug\.?|september|sept?\.?|oktober|okt\.?|november|nov\.?|dezember|dez\.?)`
+
+var INTEGER_WORDS = map[string]int{
+	"ein":    1,
+	"eine":   1,
+	"einer":  1,
+	"einem":  1,
+	"einen":  1,
+	"zwei":   2,
+	"drei":   3,
+	"vier":   4,
+	"fünf":   5,
+	"sechs":  6,
+	"sieben": 7,
+	"acht":   8,
+	"neun":   9,
+	"zehn":   10,
+	"elf":    11,
+	"zwölf":  12,
+}
+
+var INTEGER_WORDS_PATTERN = `(?:einer|einem|einen|eine|ein|zwei|drei|vier|fünf|sechs|sieben|acht|neun|zehn|elf|zwölf)`
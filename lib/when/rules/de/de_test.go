@@ -0,0 +1,182 @@
+package de_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/lib/when"
+	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules"
+	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules/de"
+	"github.com/stretchr/testify/require"
+)
+
+// Wednesday
+var null = time.Date(2016, time.January, 6, 0, 0, 0, 0, time.UTC)
+
+type Fixture struct {
+	Text   string
+	Index  int
+	Phrase string
+	Diff   time.Duration
+}
+
+func ApplyFixtures(t *testing.T, name string, w *when.Parser, fixt []Fixture) {
+	for i, f := range fixt {
+		res, err := w.Parse(f.Text, null)
+		require.Nil(t, err, "[%s] err #%d", name, i)
+		require.NotNil(t, res, "[%s] res #%d", name, i)
+		require.Equal(t, f.Index, res.Index, "[%s] index #%d", name, i)
+		require.Equal(t, f.Phrase, res.Text, "[%s] text #%d", name, i)
+		require.Equal(t, f.Diff, res.Time.Sub(null), "[%s] diff #%d", name, i)
+	}
+}
+
+func ApplyFixturesNil(t *testing.T, name string, w *when.Parser, fixt []Fixture) {
+	for i, f := range fixt {
+		res, err := w.Parse(f.Text, null)
+		require.Nil(t, err, "[%s] err #%d", name, i)
+		require.Nil(t, res, "[%s] res #%d", name, i)
+	}
+}
+
+func TestCasualDate(t *testing.T) {
+	fixt := []Fixture{
+		{"morgen", 0, "morgen", 24 * time.Hour},
+		{"erinnere mich morgen", 14, "morgen", 24 * time.Hour},
+		{"übermorgen", 0, "übermorgen", 48 * time.Hour},
+		{"heute Abend", 0, "heute Abend", 23 * time.Hour},
+		{"heute Morgen", 0, "heute Morgen", 8 * time.Hour},
+		{"gestern", 0, "gestern", -24 * time.Hour},
+		{"vorgestern", 0, "vorgestern", -48 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(de.CasualDate(rules.Override))
+
+	ApplyFixtures(t, "de.CasualDate", w, fixt)
+}
+
+func TestCasualTime(t *testing.T) {
+	fixt := []Fixture{
+		{"morgens", 0, "morgens", 8 * time.Hour},
+		{"am Nachmittag", 0, "am Nachmittag", 15 * time.Hour},
+		{"abends", 0, "abends", 18 * time.Hour},
+		{"mittags", 0, "mittags", 12 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(de.CasualTime(rules.Override))
+
+	ApplyFixtures(t, "de.CasualTime", w, fixt)
+
+	// "morgen" alone is tomorrow, not the morning
+	ApplyFixturesNil(t, "de.CasualTime nil", w, []Fixture{{"morgen", 0, "", 0}})
+}
+
+func TestWeekday(t *testing.T) {
+	fixt := []Fixture{
+		{"am Freitag", 3, "Freitag", 2 * 24 * time.Hour},
+		{"nächsten Montag", 0, "nächsten Montag", 5 * 24 * time.Hour},
+		{"Mittwoch", 0, "Mittwoch", 7 * 24 * time.Hour},
+		{"letzten Montag", 0, "letzten Montag", -2 * 24 * time.Hour},
+		{"diesen Freitag", 0, "diesen Freitag", 2 * 24 * time.Hour},
+		{"Dienstag letzte Woche", 0, "Dienstag letzte Woche", -24 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(de.Weekday(rules.Override))
+
+	ApplyFixtures(t, "de.Weekday", w, fixt)
+}
+
+func TestHour(t *testing.T) {
+	fixt := []Fixture{
+		{"um 17 Uhr", 3, "17 Uhr", 17 * time.Hour},
+		{"9uhr", 0, "9uhr", 9 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(de.Hour(rules.Override), de.HourMinute(rules.Override))
+
+	ApplyFixtures(t, "de.Hour", w, fixt)
+
+	ApplyFixturesNil(t, "de.Hour nil", w, []Fixture{{"25 Uhr", 0, "", 0}, {"um 17", 0, "", 0}})
+}
+
+func TestHourMinute(t *testing.T) {
+	fixt := []Fixture{
+		{"17:30", 0, "17:30", 17*time.Hour + 30*time.Minute},
+		{"um 17.30 Uhr", 3, "17.30 Uhr", 17*time.Hour + 30*time.Minute},
+		{"17 Uhr 30", 0, "17 Uhr 30", 17*time.Hour + 30*time.Minute},
+		{"5:05", 0, "5:05", 5*time.Hour + 5*time.Minute},
+	}
+
+	w := when.New(nil)
+	w.Add(de.HourMinute(rules.Override))
+
+	ApplyFixtures(t, "de.HourMinute", w, fixt)
+}
+
+func TestDeadline(t *testing.T) {
+	fixt := []Fixture{
+		{"in 2 Wochen", 0, "in 2 Wochen", 14 * 24 * time.Hour},
+		{"in drei Tagen", 0, "in drei Tagen", 3 * 24 * time.Hour},
+		{"in einer Stunde", 0, "in einer Stunde", time.Hour},
+		{"in einer halben Stunde", 0, "in einer halben Stunde", 30 * time.Minute},
+		{"in 10 Minuten", 0, "in 10 Minuten", 10 * time.Minute},
+		{"innerhalb von 5 Sekunden", 0, "innerhalb von 5 Sekunden", 5 * time.Second},
+		{"in einem Monat", 0, "in einem Monat", 31 * 24 * time.Hour},
+		{"in einem Jahr", 0, "in einem Jahr", 366 * 24 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(de.Deadline(rules.Override))
+
+	ApplyFixtures(t, "de.Deadline", w, fixt)
+}
+
+func TestExactMonthDate(t *testing.T) {
+	fixt := []Fixture{
+		{"am 24. Dezember", 3, "24. Dezember", 353 * 24 * time.Hour},
+		{"5 Mai", 0, "5 Mai", 120 * 24 * time.Hour},
+		{"März", 0, "März", 60 * 24 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(de.ExactMonthDate(rules.Override))
+
+	ApplyFixtures(t, "de.ExactMonthDate", w, fixt)
+}
+
+func TestAll(t *testing.T) {
+	w := when.New(nil)
+	w.Add(de.All...)
+
+	// complex cases
+	fixt := []Fixture{
+		{"morgen um 17 Uhr", 0, "morgen um 17 Uhr", 41 * time.Hour},
+		{"nächsten Freitag um 18:00", 0, "nächsten Freitag um 18:00", ((2 * 24) + 18) * time.Hour},
+		{"am 24. Dezember um 20 Uhr", 3, "24. Dezember um 20 Uhr", ((353 * 24) + 20) * time.Hour},
+		{"morgen früh", 0, "morgen früh", 32 * time.Hour},
+		{"übermorgen abends", 0, "übermorgen abends", 66 * time.Hour},
+		{"letzten Montag um 9:30", 0, "letzten Montag um 9:30", -(2 * 24 * time.Hour) + 9*time.Hour + 30*time.Minute},
+		{"Samstag nachmittags", 0, "Samstag nachmittags", ((3 * 24) + 15) * time.Hour},
+	}
+
+	ApplyFixtures(t, "de.All...", w, fixt)
+}
+
+func TestHoursFromNow(t *testing.T) {
+	w := when.New(nil)
+	w.Add(de.All...)
+
+	// a base that isn't midnight, so a clock time of 2:00 can't pass for two hours from now
+	base := null.Add(10 * time.Hour)
+	for _, text := range []string{"in 2 Stunden"} {
+		res, err := w.Parse(text, base)
+		require.Nil(t, err, text)
+		require.NotNil(t, res, text)
+		require.Equal(t, text, res.Text)
+		require.Equal(t, 2*time.Hour, res.Time.Sub(base), text)
+	}
+}
@@ -0,0 +1,113 @@
+package de
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules"
+	"github.com/pkg/errors"
+)
+
+func Deadline(s rules.Strategy) rules.Rule {
+	overwrite := s == rules.Override
+
+	return &rules.F{
+		RegExp: regexp.MustCompile(
+			"(?i)(?:\\W|^)(in|innerhalb\\s+(?:von\\s+)?)\\s*" +
+				"(" + INTEGER_WORDS_PATTERN + "|[0-9]+|einigen|ein\\s+paar|(?:einer\\s+|einem\\s+|einen\\s+)?halben?)\\s*" +
+				"(sekunden?|min(?:ute)?n?|stunden?|tag(?:en|e)?|wochen?|monat(?:en|e)?|jahr(?:en|e)?)" +
+				"(?:\\W|$)"),
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			numStr := strings.ToLower(strings.TrimSpace(m.Captures[1]))
+
+			var num int
+			var err error
+
+			half := strings.Contains(numStr, "halb")
+			if n, ok := INTEGER_WORDS[numStr]; ok {
+				num = n
+			} else if strings.Contains(numStr, "einig") || strings.Contains(numStr, "paar") {
+				num = 3
+			} else if half {
+				// pass
+			} else {
+				num, err = strconv.Atoi(numStr)
+				if err != nil {
+					return false, errors.Wrapf(err, "convert '%s' to int", numStr)
+				}
+			}
+
+			exponent := strings.ToLower(strings.TrimSpace(m.Captures[2]))
+
+			if !half {
+				switch {
+				case strings.Contains(exponent, "sekunde"):
+					if c.Duration == 0 || overwrite {
+						c.Duration = time.Duration(num) * time.Second
+					}
+				case strings.Contains(exponent, "min"):
+					if c.Duration == 0 || overwrite {
+						c.Duration = time.Duration(num) * time.Minute
+					}
+				case strings.Contains(exponent, "stunde"):
+					if c.Duration == 0 || overwrite {
+						c.Duration = time.Duration(num) * time.Hour
+					}
+				case strings.Contains(exponent, "tag"):
+					if c.Duration == 0 || overwrite {
+						c.Duration = time.Duration(num) * 24 * time.Hour
+					}
+				case strings.Contains(exponent, "woche"):
+					if c.Duration == 0 || overwrite {
+						c.Duration = time.Duration(num) * 7 * 24 * time.Hour
+					}
+				case strings.Contains(exponent, "monat"):
+					if c.Month == nil || overwrite {
+						t := ref.AddDate(0, num, 0)
+						c.Year = pointer.ToInt(t.Year())
+						c.Month = pointer.ToInt(int(t.Month()))
+					}
+				case strings.Contains(exponent, "jahr"):
+					if c.Year == nil || overwrite {
+						c.Year = pointer.ToInt(ref.Year() + num)
+					}
+				}
+			} else {
+				switch {
+				case strings.Contains(exponent, "min"):
+					if c.Duration == 0 || overwrite {
+						c.Duration = 30 * time.Second
+					}
+				case strings.Contains(exponent, "stunde"):
+					if c.Duration == 0 || overwrite {
+						c.Duration = 30 * time.Minute
+					}
+				case strings.Contains(exponent, "tag"):
+					if c.Duration == 0 || overwrite {
+						c.Duration = 12 * time.Hour
+					}
+				case strings.Contains(exponent, "woche"):
+					if c.Duration == 0 || overwrite {
+						c.Duration = 7 * 12 * time.Hour
+					}
+				case strings.Contains(exponent, "monat"):
+					if c.Duration == 0 || overwrite {
+						// 2 weeks
+						c.Duration = 14 * 24 * time.Hour
+					}
+				case strings.Contains(exponent, "jahr"):
+					if c.Month == nil || overwrite {
+						t := ref.AddDate(0, 6, 0)
+						c.Year = pointer.ToInt(t.Year())
+						c.Month = pointer.ToInt(int(t.Month()))
+					}
+				}
+			}
+
+			return true, nil
+		},
+	}
+}
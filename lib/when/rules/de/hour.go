@@ -0,0 +1,46 @@
+package de
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules"
+)
+
+/*
+	"17 Uhr"
+	"um 5 Uhr"
+	"9uhr"
+*/
+
+func Hour(s rules.Strategy) rules.Rule {
+
+	return &rules.F{
+		RegExp: regexp.MustCompile("(?i)(?:\\W|^)" +
+			"(\\d{1,2})" +
+			"\\s*(uhr)" +
+			"(?:\\W|$)"),
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			if c.Hour != nil && s != rules.Override {
+				return false, nil
+			}
+
+			hour, err := strconv.Atoi(m.Captures[0])
+			if err != nil {
+				return false, errors.Wrap(err, "hour rule")
+			}
+
+			if hour > 23 {
+				return false, nil
+			}
+
+			zero := 0
+			c.Hour = &hour
+			c.Minute = &zero
+			return true, nil
+		},
+	}
+}
@@ -0,0 +1,66 @@
+package es
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules"
+)
+
+// "mañana" alone is tomorrow, "esta mañana" is this morning and "por la mañana" is the morning of whatever day
+func CasualDate(s rules.Strategy) rules.Rule {
+	overwrite := s == rules.Override
+
+	return &rules.F{
+		RegExp: regexp.MustCompile("(?i)(?:\\W|^)(ahora|hoy|(?:de|por|en)\\s+la\\s+mañana|esta\\s+noche|esta\\s+mañana|anoche|pasado\\s+mañana|anteayer|antier|mañana|ayer)(?:\\W|$)"),
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			lower := strings.ToLower(strings.TrimSpace(m.String()))
+
+			switch {
+			case strings.Contains(lower, "la mañana"):
+				// the morning, not tomorrow, handled by CasualTime
+			case strings.Contains(lower, "esta noche"):
+				if c.Hour == nil && c.Minute == nil || overwrite {
+					c.Hour = pointer.ToInt(23)
+					c.Minute = pointer.ToInt(0)
+				}
+			case strings.Contains(lower, "esta mañana"):
+				if c.Hour == nil && c.Minute == nil || overwrite {
+					if o.Morning != 0 {
+						c.Hour = &o.Morning
+					} else {
+						c.Hour = pointer.ToInt(8)
+					}
+					c.Minute = pointer.ToInt(0)
+				}
+			case strings.Contains(lower, "hoy"):
+				// c.Hour = pointer.ToInt(18)
+			case strings.Contains(lower, "pasado"):
+				if c.Duration == 0 || overwrite {
+					c.Duration += time.Hour * 48
+				}
+			case strings.Contains(lower, "anteayer"), strings.Contains(lower, "antier"):
+				if c.Duration == 0 || overwrite {
+					c.Duration -= time.Hour * 48
+				}
+			case strings.Contains(lower, "anoche"):
+				if (c.Hour == nil && c.Duration == 0) || overwrite {
+					c.Hour = pointer.ToInt(23)
+					c.Duration -= time.Hour * 24
+				}
+			case strings.Contains(lower, "mañana"):
+				if c.Duration == 0 || overwrite {
+					c.Duration += time.Hour * 24
+				}
+			case strings.Contains(lower, "ayer"):
+				if c.Duration == 0 || overwrite {
+					c.Duration -= time.Hour * 24
+				}
+			}
+
+			return true, nil
+		},
+	}
+}
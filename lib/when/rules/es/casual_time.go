@@ -0,0 +1,56 @@
+package es
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules"
+)
+
+// The hour after a time like "a las 5 de la tarde" is handled by the Hour rule
+func CasualTime(s rules.Strategy) rules.Rule {
+	overwrite := s == rules.Override
+
+	return &rules.F{
+		RegExp: regexp.MustCompile(`(?i)(?:\W|^)((?:por|en)\s+la\s+(mañana|tarde|noche)|esta\s+tarde|al\s+mediodía|mediodía|mediodia)`),
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			lower := strings.ToLower(strings.TrimSpace(m.String()))
+
+			if (c.Hour != nil || c.Minute != nil) && !overwrite {
+				return false, nil
+			}
+
+			switch {
+			case strings.Contains(lower, "tarde"):
+				if o.Afternoon != 0 {
+					c.Hour = &o.Afternoon
+				} else {
+					c.Hour = pointer.ToInt(15)
+				}
+			case strings.Contains(lower, "noche"):
+				if o.Evening != 0 {
+					c.Hour = &o.Evening
+				} else {
+					c.Hour = pointer.ToInt(18)
+				}
+			case strings.Contains(lower, "mañana"):
+				if o.Morning != 0 {
+					c.Hour = &o.Morning
+				} else {
+					c.Hour = pointer.ToInt(8)
+				}
+			case strings.Contains(lower, "mediod"):
+				if o.Noon != 0 {
+					c.Hour = &o.Noon
+				} else {
+					c.Hour = pointer.ToInt(12)
+				}
+			}
+
+			c.Minute = pointer.ToInt(0)
+			return true, nil
+		},
+	}
+}
@@ -0,0 +1,183 @@
+package es_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/lib/when"
+	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules"
+	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules/es"
+	"github.com/stretchr/testify/require"
+)
+
+// Wednesday
+var null = time.Date(2016, time.January, 6, 0, 0, 0, 0, time.UTC)
+
+type Fixture struct {
+	Text   string
+	Index  int
+	Phrase string
+	Diff   time.Duration
+}
+
+func ApplyFixtures(t *testing.T, name string, w *when.Parser, fixt []Fixture) {
+	for i, f := range fixt {
+		res, err := w.Parse(f.Text, null)
+		require.Nil(t, err, "[%s] err #%d", name, i)
+		require.NotNil(t, res, "[%s] res #%d", name, i)
+		require.Equal(t, f.Index, res.Index, "[%s] index #%d", name, i)
+		require.Equal(t, f.Phrase, res.Text, "[%s] text #%d", name, i)
+		require.Equal(t, f.Diff, res.Time.Sub(null), "[%s] diff #%d", name, i)
+	}
+}
+
+func ApplyFixturesNil(t *testing.T, name string, w *when.Parser, fixt []Fixture) {
+	for i, f := range fixt {
+		res, err := w.Parse(f.Text, null)
+		require.Nil(t, err, "[%s] err #%d", name, i)
+		require.Nil(t, res, "[%s] res #%d", name, i)
+	}
+}
+
+func TestCasualDate(t *testing.T) {
+	fixt := []Fixture{
+		{"mañana", 0, "mañana", 24 * time.Hour},
+		{"recuérdame mañana", 12, "mañana", 24 * time.Hour},
+		{"pasado mañana", 0, "pasado mañana", 48 * time.Hour},
+		{"esta noche", 0, "esta noche", 23 * time.Hour},
+		{"esta mañana", 0, "esta mañana", 8 * time.Hour},
+		{"ayer", 0, "ayer", -24 * time.Hour},
+		{"anteayer", 0, "anteayer", -48 * time.Hour},
+		// the morning, not tomorrow
+		{"de la mañana", 0, "de la mañana", 0},
+	}
+
+	w := when.New(nil)
+	w.Add(es.CasualDate(rules.Override))
+
+	ApplyFixtures(t, "es.CasualDate", w, fixt)
+}
+
+func TestCasualTime(t *testing.T) {
+	fixt := []Fixture{
+		{"por la mañana", 0, "por la mañana", 8 * time.Hour},
+		{"esta tarde", 0, "esta tarde", 15 * time.Hour},
+		{"en la noche", 0, "en la noche", 18 * time.Hour},
+		{"al mediodía", 0, "al mediodía", 12 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(es.CasualTime(rules.Override))
+
+	ApplyFixtures(t, "es.CasualTime", w, fixt)
+}
+
+func TestWeekday(t *testing.T) {
+	fixt := []Fixture{
+		{"el viernes", 3, "viernes", 2 * 24 * time.Hour},
+		{"el próximo lunes", 3, "próximo lunes", 5 * 24 * time.Hour},
+		{"el lunes que viene", 3, "lunes que viene", 5 * 24 * time.Hour},
+		{"miércoles", 0, "miércoles", 7 * 24 * time.Hour},
+		{"el martes pasado", 3, "martes pasado", -24 * time.Hour},
+		{"este viernes", 0, "este viernes", 2 * 24 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(es.Weekday(rules.Override))
+
+	ApplyFixtures(t, "es.Weekday", w, fixt)
+}
+
+func TestHour(t *testing.T) {
+	fixt := []Fixture{
+		{"a las 5", 0, "a las 5", 5 * time.Hour},
+		{"a las 5 de la tarde", 0, "a las 5 de la tarde", 17 * time.Hour},
+		{"a la 1 de la noche", 0, "a la 1 de la noche", 13 * time.Hour},
+		{"17h", 0, "17h", 17 * time.Hour},
+		{"20 horas", 0, "20 horas", 20 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(es.Hour(rules.Override))
+
+	ApplyFixtures(t, "es.Hour", w, fixt)
+
+	ApplyFixturesNil(t, "es.Hour nil", w, []Fixture{{"a las 17 de la tarde", 0, "", 0}, {"5", 0, "", 0}})
+}
+
+func TestHourMinute(t *testing.T) {
+	fixt := []Fixture{
+		{"17:30", 0, "17:30", 17*time.Hour + 30*time.Minute},
+		{"5:30 de la tarde", 0, "5:30 de la tarde", 17*time.Hour + 30*time.Minute},
+		{"9h15", 0, "9h15", 9*time.Hour + 15*time.Minute},
+		{"12:10 de la madrugada", 0, "12:10 de la madrugada", 10 * time.Minute},
+	}
+
+	w := when.New(nil)
+	w.Add(es.HourMinute(rules.Override))
+
+	ApplyFixtures(t, "es.HourMinute", w, fixt)
+}
+
+func TestDeadline(t *testing.T) {
+	fixt := []Fixture{
+		{"en 2 semanas", 0, "en 2 semanas", 14 * 24 * time.Hour},
+		{"en tres días", 0, "en tres días", 3 * 24 * time.Hour},
+		{"en una hora", 0, "en una hora", time.Hour},
+		{"en media hora", 0, "en media hora", 30 * time.Minute},
+		{"dentro de 10 minutos", 0, "dentro de 10 minutos", 10 * time.Minute},
+		{"en unos minutos", 0, "en unos minutos", 3 * time.Minute},
+		{"en un mes", 0, "en un mes", 31 * 24 * time.Hour},
+		{"en un año", 0, "en un año", 366 * 24 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(es.Deadline(rules.Override))
+
+	ApplyFixtures(t, "es.Deadline", w, fixt)
+}
+
+func TestExactMonthDate(t *testing.T) {
+	fixt := []Fixture{
+		{"el 24 de diciembre", 3, "24 de diciembre", 353 * 24 * time.Hour},
+		{"5 de mayo", 0, "5 de mayo", 120 * 24 * time.Hour},
+		{"marzo", 0, "marzo", 60 * 24 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(es.ExactMonthDate(rules.Override))
+
+	ApplyFixtures(t, "es.ExactMonthDate", w, fixt)
+}
+
+func TestAll(t *testing.T) {
+	w := when.New(nil)
+	w.Add(es.All...)
+
+	// complex cases
+	fixt := []Fixture{
+		{"mañana a las 5 de la tarde", 0, "mañana a las 5 de la tarde", 41 * time.Hour},
+		{"el próximo viernes a las 18:00", 3, "próximo viernes a las 18:00", ((2 * 24) + 18) * time.Hour},
+		{"el 24 de diciembre a las 20h", 3, "24 de diciembre a las 20h", ((353 * 24) + 20) * time.Hour},
+		{"pasado mañana por la mañana", 0, "pasado mañana por la mañana", 56 * time.Hour},
+		{"el sábado por la tarde", 3, "sábado por la tarde", ((3 * 24) + 15) * time.Hour},
+		{"a las 9:30 de la mañana", 0, "a las 9:30 de la mañana", 9*time.Hour + 30*time.Minute},
+	}
+
+	ApplyFixtures(t, "es.All...", w, fixt)
+}
+
+func TestHoursFromNow(t *testing.T) {
+	w := when.New(nil)
+	w.Add(es.All...)
+
+	// a base that isn't midnight, so a clock time of 2:00 can't pass for two hours from now
+	base := null.Add(10 * time.Hour)
+	for _, text := range []string{"en 2 horas", "dentro de 2 horas"} {
+		res, err := w.Parse(text, base)
+		require.Nil(t, err, text)
+		require.NotNil(t, res, text)
+		require.Equal(t, text, res.Text)
+		require.Equal(t, 2*time.Hour, res.Time.Sub(base), text)
+	}
+}
@@ -0,0 +1,55 @@
+package es
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules"
+)
+
+/*
+	"5 de mayo"
+	"el 24 de diciembre"
+	"1 ene."
+	"marzo"
+*/
+
+func ExactMonthDate(s rules.Strategy) rules.Rule {
+	overwrite := s == rules.Override
+
+	return &rules.F{
+		RegExp: regexp.MustCompile("(?i)" +
+			"(?:\\W|^)" +
+			"(?:(\\d{1,2})\\s+(?:de\\s+)?)?" +
+			"(" + MONTH_OFFSET_PATTERN[3:] + // skip '(?:'
+			"(?:\\W|$)",
+		),
+
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			_ = overwrite
+
+			num := strings.TrimSpace(m.Captures[0])
+			mon := strings.ToLower(strings.TrimSpace(m.Captures[1]))
+
+			monInt, ok := MONTH_OFFSET[mon]
+			if !ok {
+				return false, nil
+			}
+
+			c.Month = &monInt
+
+			if num != "" {
+				n, err := strconv.Atoi(num)
+				if err != nil || n < 1 || n > 31 {
+					return false, nil
+				}
+
+				c.Day = &n
+			}
+
+			return true, nil
+		},
+	}
+}
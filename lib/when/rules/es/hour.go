@@ -0,0 +1,81 @@
+package es
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules"
+)
+
+/*
+	"a las 5"
+	"a las 20h"
+	"a la 1"
+	"a las 5 de la tarde"
+	"17h"
+	"17 horas"
+
+	"en 2 horas" and "dentro de 2 horas" are left to the deadline rule
+*/
+
+var digitsRegex = regexp.MustCompile(`\d+`)
+
+func Hour(s rules.Strategy) rules.Rule {
+
+	return &rules.F{
+		RegExp: regexp.MustCompile("(?i)(?:\\W|^)" +
+			"(?:(en|dentro\\s+de)\\s+)?" +
+			"(a\\s+las?\\s+\\d{1,2}(?:\\s*(?:h|horas))?|\\d{1,2}\\s*(?:h|horas))" +
+			"(?:\\s+de\\s+la\\s+(mañana|tarde|noche|madrugada))?" +
+			"(?:\\W|$)"),
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			if m.Captures[0] != "" || (c.Hour != nil && s != rules.Override) {
+				return false, nil
+			}
+
+			hour, err := strconv.Atoi(digitsRegex.FindString(m.Captures[1]))
+			if err != nil {
+				return false, errors.Wrap(err, "hour rule")
+			}
+
+			hour, ok := applyDayPeriod(hour, m.Captures[2])
+			if !ok {
+				return false, nil
+			}
+
+			zero := 0
+			c.Hour = &hour
+			c.Minute = &zero
+			return true, nil
+		},
+	}
+}
+
+// applyDayPeriod turns a 12 hour clock time followed by "de la tarde" or "de la noche" into 24 hour time
+func applyDayPeriod(hour int, period string) (int, bool) {
+	period = strings.ToLower(period)
+	if period == "" {
+		return hour, hour < 24
+	}
+
+	if hour > 12 {
+		return 0, false
+	}
+
+	switch period {
+	case "tarde", "noche":
+		if hour < 12 {
+			hour += 12
+		}
+	default:
+		if hour == 12 {
+			hour = 0
+		}
+	}
+
+	return hour, true
+}
@@ -0,0 +1,84 @@
+package fr
+
+import "github.com/botlabs-gg/yagpdb/v2/lib/when/rules"
+
+var All = []rules.Rule{
+	Weekday(rules.Override),
+	CasualDate(rules.Override),
+	CasualTime(rules.Override),
+	Hour(rules.Override),
+	HourMinute(rules.Override),
+	Deadline(rules.Override),
+	ExactMonthDate(rules.Override),
+}
+
+var WEEKDAY_OFFSET = map[string]int{
+	"dimanche": 0,
+	"dim.":     0,
+	"lundi":    1,
+	"lun.":     1,
+	"mardi":    2,
+	"mar.":     2,
+	"mercredi": 3,
+	"mer.":     3,
+	"jeudi":    4,
+	"jeu.":     4,
+	"vendredi": 5,
+	"ven.":     5,
+	"samedi":   6,
+	"sam.":     6,
+}
+
+var WEEKDAY_OFFSET_PATTERN = `(?:dimanche|dim\.|lundi|lun\.|mardi|mar\.|mercredi|mer\.|jeudi|jeu\.|vendredi|ven\.|samedi|sam\.)`
+
+var MONTH_OFFSET = map[string]int{
+	"janvier":   1,
+	"janv":      1,
+	"janv.":     1,
+	"février":   2,
+	"fevrier":   2,
+	"févr":      2,
+	"févr.":     2,
+	"mars":      3,
+	"avril":     4,
+	"avr":       4,
+	"avr.":      4,
+	"mai":       5,
+	"juin":      6,
+	"juillet":   7,
+	"juil":      7,
+	"juil.":     7,
+	"août":      8,
+	"aout":      8,
+	"septembre": 9,
+	"octobre":   10,
+	"oct":       10,
+	"oct.":      10,
+	"novembre":  11,
+	"nov":       11,
+	"nov.":      11,
+	"décembre":  12,
+	"decembre":  12,
+	"déc":       12,
+	"déc.":      12,
+}
+
+var MONTH_OFFSET_PATTERN = `(?:janvier|janv\.?|février|fevrier|févr\.?|mars|avril|avr\.?|mai|juin|juillet|juil\.?|août|aout|septembre|octobre|oct\.?|novembre|nov\.?|décembre|decembre|déc\.?)`
+
+var INTEGER_WORDS = map[string]int{
+	"un":     1,
+	"une":    1,
+	"deux":   2,
+	"trois":  3,
+	"quatre": 4,
+	"cinq":   5,
+	"six":    6,
+	"sept":   7,
+	"huit":   8,
+	"neuf":   9,
+	"dix":    10,
+	"onze":   11,
+	"douze":  12,
+}
+
+var INTEGER_WORDS_PATTERN = `(?:une|un|deux|trois|quatre|cinq|six|sept|huit|neuf|dix|onze|douze)`
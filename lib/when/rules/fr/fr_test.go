@@ -0,0 +1,166 @@
+package fr_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/lib/when"
+	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules"
+	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules/fr"
+	"github.com/stretchr/testify/require"
+)
+
+// Wednesday
+var null = time.Date(2016, time.January, 6, 0, 0, 0, 0, time.UTC)
+
+type Fixture struct {
+	Text   string
+	Index  int
+	Phrase string
+	Diff   time.Duration
+}
+
+func ApplyFixtures(t *testing.T, name string, w *when.Parser, fixt []Fixture) {
+	for i, f := range fixt {
+		res, err := w.Parse(f.Text, null)
+		require.Nil(t, err, "[%s] err #%d", name, i)
+		require.NotNil(t, res, "[%s] res #%d", name, i)
+		require.Equal(t, f.Index, res.Index, "[%s] index #%d", name, i)
+		require.Equal(t, f.Phrase, res.Text, "[%s] text #%d", name, i)
+		require.Equal(t, f.Diff, res.Time.Sub(null), "[%s] diff #%d", name, i)
+	}
+}
+
+func ApplyFixturesNil(t *testing.T, name string, w *when.Parser, fixt []Fixture) {
+	for i, f := range fixt {
+		res, err := w.Parse(f.Text, null)
+		require.Nil(t, err, "[%s] err #%d", name, i)
+		require.Nil(t, res, "[%s] res #%d", name, i)
+	}
+}
+
+func TestCasualDate(t *testing.T) {
+	fixt := []Fixture{
+		{"demain", 0, "demain", 24 * time.Hour},
+		{"rappelle-moi demain", 13, "demain", 24 * time.Hour},
+		{"après-demain", 0, "après-demain", 48 * time.Hour},
+		{"cette nuit", 0, "cette nuit", 23 * time.Hour},
+		{"hier", 0, "hier", -24 * time.Hour},
+		{"avant-hier", 0, "avant-hier", -48 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(fr.CasualDate(rules.Override))
+
+	ApplyFixtures(t, "fr.CasualDate", w, fixt)
+}
+
+func TestCasualTime(t *testing.T) {
+	fixt := []Fixture{
+		{"ce matin", 0, "ce matin", 8 * time.Hour},
+		{"cet après-midi", 0, "cet après-midi", 15 * time.Hour},
+		{"ce soir", 0, "ce soir", 18 * time.Hour},
+		{"à midi", 3, "midi", 12 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(fr.CasualTime(rules.Override))
+
+	ApplyFixtures(t, "fr.CasualTime", w, fixt)
+}
+
+func TestWeekday(t *testing.T) {
+	fixt := []Fixture{
+		{"vendredi", 0, "vendredi", 2 * 24 * time.Hour},
+		{"lundi prochain", 0, "lundi prochain", 5 * 24 * time.Hour},
+		{"mercredi", 0, "mercredi", 7 * 24 * time.Hour},
+		{"mardi dernier", 0, "mardi dernier", -24 * time.Hour},
+		{"ce vendredi", 0, "ce vendredi", 2 * 24 * time.Hour},
+		{"ce lundi", 0, "ce lundi", -2 * 24 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(fr.Weekday(rules.Override))
+
+	ApplyFixtures(t, "fr.Weekday", w, fixt)
+}
+
+func TestHour(t *testing.T) {
+	fixt := []Fixture{
+		{"à 17h", 3, "17h", 17 * time.Hour},
+		{"9 heures", 0, "9 heures", 9 * time.Hour},
+		{"17h30", 0, "17h30", 17*time.Hour + 30*time.Minute},
+		{"17:30", 0, "17:30", 17*time.Hour + 30*time.Minute},
+		{"9 heures 15", 0, "9 heures 15", 9*time.Hour + 15*time.Minute},
+	}
+
+	w := when.New(nil)
+	w.Add(fr.Hour(rules.Override), fr.HourMinute(rules.Override))
+
+	ApplyFixtures(t, "fr.Hour", w, fixt)
+
+	ApplyFixturesNil(t, "fr.Hour nil", w, []Fixture{{"25h", 0, "", 0}, {"à 17", 0, "", 0}})
+}
+
+func TestDeadline(t *testing.T) {
+	fixt := []Fixture{
+		{"dans 2 semaines", 0, "dans 2 semaines", 14 * 24 * time.Hour},
+		{"dans trois jours", 0, "dans trois jours", 3 * 24 * time.Hour},
+		{"dans une heure", 0, "dans une heure", time.Hour},
+		{"dans une demi-heure", 0, "dans une demi-heure", 30 * time.Minute},
+		{"dans 10 minutes", 0, "dans 10 minutes", 10 * time.Minute},
+		{"dans sept jours", 0, "dans sept jours", 7 * 24 * time.Hour},
+		{"d'ici 2 mois", 0, "d'ici 2 mois", 60 * 24 * time.Hour},
+		{"dans un an", 0, "dans un an", 366 * 24 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(fr.Deadline(rules.Override))
+
+	ApplyFixtures(t, "fr.Deadline", w, fixt)
+}
+
+func TestExactMonthDate(t *testing.T) {
+	fixt := []Fixture{
+		{"le 24 décembre", 3, "24 décembre", 353 * 24 * time.Hour},
+		{"1er mars", 0, "1er mars", 55 * 24 * time.Hour},
+		{"mai", 0, "mai", 121 * 24 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(fr.ExactMonthDate(rules.Override))
+
+	ApplyFixtures(t, "fr.ExactMonthDate", w, fixt)
+}
+
+func TestAll(t *testing.T) {
+	w := when.New(nil)
+	w.Add(fr.All...)
+
+	// complex cases
+	fixt := []Fixture{
+		{"demain à 17h", 0, "demain à 17h", 41 * time.Hour},
+		{"lundi prochain à 9h30", 0, "lundi prochain à 9h30", ((5*24)+9)*time.Hour + 30*time.Minute},
+		{"le 24 décembre à 20h", 3, "24 décembre à 20h", ((353 * 24) + 20) * time.Hour},
+		{"après-demain matin", 0, "après-demain matin", 56 * time.Hour},
+		{"samedi après-midi", 0, "samedi après-midi", ((3 * 24) + 15) * time.Hour},
+		{"hier soir", 0, "hier soir", -6 * time.Hour},
+	}
+
+	ApplyFixtures(t, "fr.All...", w, fixt)
+}
+
+func TestHoursFromNow(t *testing.T) {
+	w := when.New(nil)
+	w.Add(fr.All...)
+
+	// a base that isn't midnight, so a clock time of 2:00 can't pass for two hours from now
+	base := null.Add(10 * time.Hour)
+	for _, text := range []string{"dans 2 heures", "d'ici 2 heures"} {
+		res, err := w.Parse(text, base)
+		require.Nil(t, err, text)
+		require.NotNil(t, res, text)
+		require.Equal(t, text, res.Text)
+		require.Equal(t, 2*time.Hour, res.Time.Sub(base), text)
+	}
+}
@@ -0,0 +1,49 @@
+package fr
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules"
+)
+
+/*
+	"17h"
+	"à 9 heures"
+	"17 h"
+
+	"dans 2 heures" and "d'ici 2 heures" are left to the deadline rule
+*/
+
+func Hour(s rules.Strategy) rules.Rule {
+
+	return &rules.F{
+		RegExp: regexp.MustCompile("(?i)(?:\\W|^)" +
+			"(?:(dans|d'ici|d’ici)\\s+)?" +
+			"(\\d{1,2})" +
+			"\\s*(h|heures?)" +
+			"(?:\\W|$)"),
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			if m.Captures[0] != "" || (c.Hour != nil && s != rules.Override) {
+				return false, nil
+			}
+
+			hour, err := strconv.Atoi(m.Captures[1])
+			if err != nil {
+				return false, errors.Wrap(err, "hour rule")
+			}
+
+			if hour > 23 {
+				return false, nil
+			}
+
+			zero := 0
+			c.Hour = &hour
+			c.Minute = &zero
+			return true, nil
+		},
+	}
+}
@@ -0,0 +1,50 @@
+package fr
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules"
+	"github.com/pkg/errors"
+)
+
+/*
+	"17h30"
+	"17:30"
+	"17 h 30"
+	"9 heures 15"
+*/
+
+func HourMinute(s rules.Strategy) rules.Rule {
+	return &rules.F{
+		RegExp: regexp.MustCompile("(?i)(?:\\W|^)" +
+			"((?:[0-1]{0,1}[0-9])|(?:2[0-3]))" +
+			"(?:\\:|\\s*h\\s*|\\s*heures?\\s*)" +
+			"((?:[0-5][0-9]))" +
+			"(?:\\W|$)"),
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			if (c.Hour != nil || c.Minute != nil) && s != rules.Override {
+				return false, nil
+			}
+
+			hour, err := strconv.Atoi(m.Captures[0])
+			if err != nil {
+				return false, errors.Wrap(err, "hour minute rule")
+			}
+
+			minutes, err := strconv.Atoi(m.Captures[1])
+			if err != nil {
+				return false, errors.Wrap(err, "hour minute rule")
+			}
+
+			if minutes > 59 || hour > 23 {
+				return false, nil
+			}
+
+			c.Hour = &hour
+			c.Minute = &minutes
+			return true, nil
+		},
+	}
+}
@@ -0,0 +1,49 @@
+package pt
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules"
+)
+
+/*
+	"17h"
+	"às 9 horas"
+	"17 h"
+
+	"em 2 horas" and "dentro de 2 horas" are left to the deadline rule
+*/
+
+func Hour(s rules.Strategy) rules.Rule {
+
+	return &rules.F{
+		RegExp: regexp.MustCompile("(?i)(?:\\W|^)" +
+			"(?:(em|dentro\\s+de)\\s+)?" +
+			"(\\d{1,2})" +
+			"\\s*(h|horas?)" +
+			"(?:\\W|$)"),
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			if m.Captures[0] != "" || (c.Hour != nil && s != rules.Override) {
+				return false, nil
+			}
+
+			hour, err := strconv.Atoi(m.Captures[1])
+			if err != nil {
+				return false, errors.Wrap(err, "hour rule")
+			}
+
+			if hour > 23 {
+				return false, nil
+			}
+
+			zero := 0
+			c.Hour = &hour
+			c.Minute = &zero
+			return true, nil
+		},
+	}
+}
@@ -0,0 +1,11 @@
+package pt
+
+import (
+	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules"
+	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules/br"
+)
+
+// All shares the rules of br, with the 24-hour clock formats used in Portugal added on top
+var All = append(append([]rules.Rule{}, br.All...),
+	Hour(rules.Override),
+)
@@ -0,0 +1,92 @@
+package pt_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/lib/when"
+	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules"
+	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules/pt"
+	"github.com/stretchr/testify/require"
+)
+
+// Wednesday
+var null = time.Date(2016, time.January, 6, 0, 0, 0, 0, time.UTC)
+
+type Fixture struct {
+	Text   string
+	Index  int
+	Phrase string
+	Diff   time.Duration
+}
+
+func ApplyFixtures(t *testing.T, name string, w *when.Parser, fixt []Fixture) {
+	for i, f := range fixt {
+		res, err := w.Parse(f.Text, null)
+		require.Nil(t, err, "[%s] err #%d", name, i)
+		require.NotNil(t, res, "[%s] res #%d", name, i)
+		require.Equal(t, f.Index, res.Index, "[%s] index #%d", name, i)
+		require.Equal(t, f.Phrase, res.Text, "[%s] text #%d", name, i)
+		require.Equal(t, f.Diff, res.Time.Sub(null), "[%s] diff #%d", name, i)
+	}
+}
+
+func ApplyFixturesNil(t *testing.T, name string, w *when.Parser, fixt []Fixture) {
+	for i, f := range fixt {
+		res, err := w.Parse(f.Text, null)
+		require.Nil(t, err, "[%s] err #%d", name, i)
+		require.Nil(t, res, "[%s] res #%d", name, i)
+	}
+}
+
+func TestHour(t *testing.T) {
+	w := when.New(nil)
+	w.Add(pt.Hour(rules.Override))
+
+	fixt := []Fixture{
+		{"às 17h", 4, "17h", 17 * time.Hour},
+		{"às 9 horas", 4, "9 horas", 9 * time.Hour},
+		{"17 h", 0, "17 h", 17 * time.Hour},
+		{"1 hora", 0, "1 hora", 1 * time.Hour},
+	}
+
+	ApplyFixtures(t, "pt.Hour", w, fixt)
+
+	nilFixt := []Fixture{
+		{"25h", 0, "", 0},
+		{"17 horários", 0, "", 0},
+	}
+
+	ApplyFixturesNil(t, "pt.Hour nil", w, nilFixt)
+}
+
+func TestAll(t *testing.T) {
+	w := when.New(&rules.Options{
+		Distance:     5,
+		MatchByOrder: true,
+	})
+	w.Add(pt.All...)
+
+	fixt := []Fixture{
+		{"amanhã às 17h", 0, "amanhã às 17h", 41 * time.Hour},
+		{"ligar às 9h30 de sexta-feira", 10, "9h30 de sexta-feira", (2*24+9)*time.Hour + 30*time.Minute},
+		{"em 2 semanas", 0, "em 2 semanas", 14 * 24 * time.Hour},
+	}
+
+	ApplyFixtures(t, "pt.All", w, fixt)
+}
+
+func TestHoursFromNow(t *testing.T) {
+	w := when.New(nil)
+	w.Add(pt.All...)
+
+	// a base that isn't midnight, so a clock time of 2:00 can't pass for two hours from now
+	base := null.Add(10 * time.Hour)
+	for _, text := range []string{"em 2 horas", "dentro de 2 horas"} {
+		res, err := w.Parse(text, base)
+		require.Nil(t, err, text)
+		require.NotNil(t, res, text)
+		require.Equal(t, text, res.Text)
+		require.Equal(t, 2*time.Hour, res.Time.Sub(base), text)
+	}
+}
@@ -7,7 +7,11 @@ import (
 	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules"
 	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules/br"
 	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules/common"
+	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules/de"
 	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules/en"
+	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules/es"
+	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules/fr"
+	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules/pt"
 	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules/ru"
 	"github.com/pkg/errors"
 )
@@ -153,6 +157,18 @@ var RU *Parser
 // BR is a parser for Brazilian Portuguese language
 var BR *Parser
 
+// PT is a parser for Portuguese language
+var PT *Parser
+
+// DE is a parser for German language
+var DE *Parser
+
+// FR is a parser for French language
+var FR *Parser
+
+// ES is a parser for Spanish language
+var ES *Parser
+
 func init() {
 	EN = New(nil)
 	EN.Add(en.All...)
@@ -165,4 +181,20 @@ func init() {
 	BR = New(nil)
 	BR.Add(br.All...)
 	BR.Add(common.All...)
+
+	PT = New(nil)
+	PT.Add(pt.All...)
+	PT.Add(common.All...)
+
+	DE = New(nil)
+	DE.Add(de.All...)
+	DE.Add(common.All...)
+
+	FR = New(nil)
+	FR.Add(fr.All...)
+	FR.Add(common.All...)
+
+	ES = New(nil)
+	ES.Add(es.All...)
+	ES.Add(common.All...)
 }
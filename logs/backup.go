@@ -0,0 +1,67 @@
+package logs
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/pubsub"
+	"github.com/botlabs-gg/yagpdb/v2/logs/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+var _ common.PluginWithBackup = (*Plugin)(nil)
+
+func (p *Plugin) BackupVersion() int {
+	return 1
+}
+
+func (p *Plugin) ExportBackup(ctx context.Context, guildID int64) (interface{}, error) {
+	return GetConfig(common.PQ, ctx, guildID)
+}
+
+func (p *Plugin) RestoreBackup(ctx context.Context, guildID int64, data json.RawMessage, restore *common.BackupRestore) error {
+	var conf models.GuildLoggingConfig
+	err := json.Unmarshal(data, &conf)
+	if err != nil {
+		return err
+	}
+
+	conf.GuildID = guildID
+	conf.MessageLogsAllowedRoles = restore.Roles(conf.MessageLogsAllowedRoles)
+
+	var blacklisted []string
+	for _, v := range strings.Split(conf.BlacklistedChannels.String, ",") {
+		if mapped := restore.ChannelStr(strings.TrimSpace(v)); mapped != "" {
+			blacklisted = append(blacklisted, mapped)
+		}
+	}
+	conf.BlacklistedChannels = null.StringFrom(strings.Join(blacklisted, ","))
+
+	tx, err := common.PQ.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = models.GuildLoggingConfigs(qm.Where("guild_id = ?", guildID)).DeleteAll(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = conf.Insert(ctx, tx, boil.Infer())
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = restore.Finish(tx)
+	if err == nil && !restore.DryRun {
+		pubsub.EvictCacheSet(configCache, guildID)
+	}
+
+	return err
+}
@@ -97,6 +97,35 @@ func (lp *Plugin) InitWeb() {
 	logCPMux.Handle(pat.Post("/fulldelete2"), fullDeleteHandler)
 	logCPMux.Handle(pat.Post("/msgdelete2"), msgDeleteHandler)
 	logCPMux.Handle(pat.Post("/delete_all"), clearMessageLogs)
+
+	web.APIMux.Handle(pat.Get("/logging"), web.APIConfigHandler(lp, "logging"))
+	web.APIMux.Handle(pat.Put("/logging"), web.APIWriteHandler(lp, web.APIFromController(HandleLogsCPSaveGeneral), ConfigFormData{}))
+
+	cplogs.RegisterConfigSource(&cplogs.ConfigSource{
+		Key:  "logging",
+		Name: "Logging",
+		Page: "logging",
+		Get: func(ctx context.Context, guildID int64) (interface{}, error) {
+			return GetConfig(common.PQ, ctx, guildID)
+		},
+		Revert: func(ctx context.Context, guildID int64, snapshot string) error {
+			conf, err := GetConfig(common.PQ, ctx, guildID)
+			if err != nil {
+				return err
+			}
+
+			if err = cplogs.RestoreSnapshot(conf, snapshot); err != nil {
+				return err
+			}
+
+			conf.GuildID = guildID
+			err = conf.UpsertG(ctx, true, []string{"guild_id"}, boil.Infer(), boil.Infer())
+			if err == nil {
+				pubsub.EvictCacheSet(configCache, guildID)
+			}
+			return err
+		},
+	})
 }
 
 func HandleLogsCP(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
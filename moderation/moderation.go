@@ -5,6 +5,7 @@ import (
 	"github.com/botlabs-gg/yagpdb/v2/common"
 	"github.com/botlabs-gg/yagpdb/v2/common/configstore"
 	"github.com/botlabs-gg/yagpdb/v2/common/featureflags"
+	"github.com/botlabs-gg/yagpdb/v2/common/scheduledevents2"
 	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
 	"golang.org/x/net/context"
 )
@@ -51,6 +52,9 @@ func RegisterPlugin() {
 
 	common.RegisterPlugin(plugin)
 
+	scheduledevents2.RegisterDescriber("moderation_unmute", ScheduledUnmuteData{}, describeScheduledUnmute)
+	scheduledevents2.RegisterDescriber("moderation_unban", ScheduledUnbanData{}, describeScheduledUnban)
+
 	configstore.RegisterConfig(configstore.SQL, &Config{})
 	common.GORM.AutoMigrate(&Config{}, &WarningModel{}, &MuteModel{})
 }
@@ -1,6 +1,7 @@
 package moderation
 
 import (
+	"fmt"
 	"math/rand"
 	"strconv"
 	"strings"
@@ -71,6 +72,14 @@ type ScheduledUnbanData struct {
 	UserID int64 `json:"user_id"`
 }
 
+func describeScheduledUnmute(evt *seventsmodels.ScheduledEvent, data interface{}) string {
+	return fmt.Sprintf("Unmute user %d", data.(*ScheduledUnmuteData).UserID)
+}
+
+func describeScheduledUnban(evt *seventsmodels.ScheduledEvent, data interface{}) string {
+	return fmt.Sprintf("Unban user %d", data.(*ScheduledUnbanData).UserID)
+}
+
 func (p *Plugin) ShardMigrationReceive(evt dshardorchestrator.EventType, data interface{}) {
 	if evt == bot.EvtMember {
 		ms := data.(*dstate.MemberState)
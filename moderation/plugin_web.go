@@ -1,6 +1,7 @@
 package moderation
 
 import (
+	"context"
 	_ "embed"
 	"fmt"
 	"html/template"
@@ -47,6 +48,30 @@ func (p *Plugin) InitWeb() {
 	subMux.Handle(pat.Post(""), postHandler)
 	subMux.Handle(pat.Post("/"), postHandler)
 	subMux.Handle(pat.Post("/clear_server_warnings"), clearServerWarnings)
+
+	web.APIMux.Handle(pat.Get("/moderation"), web.APIConfigHandler(p, "moderation"))
+	web.APIMux.Handle(pat.Put("/moderation"), web.APIWriteHandler(p, web.APIFromController(HandlePostModeration), Config{}))
+
+	cplogs.RegisterConfigSource(&cplogs.ConfigSource{
+		Key:  "moderation",
+		Name: "Moderation",
+		Page: "moderation",
+		Get: func(ctx context.Context, guildID int64) (interface{}, error) {
+			return GetConfig(guildID)
+		},
+		Revert: func(ctx context.Context, guildID int64, snapshot string) error {
+			conf, err := GetConfig(guildID)
+			if err != nil {
+				return err
+			}
+
+			if err = cplogs.RestoreSnapshot(conf, snapshot); err != nil {
+				return err
+			}
+
+			return conf.Save(guildID)
+		},
+	})
 }
 
 // HandleModeration servers the moderation page itself
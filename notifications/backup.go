@@ -0,0 +1,98 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/configstore"
+	"github.com/botlabs-gg/yagpdb/v2/web"
+)
+
+var _ common.PluginWithBackup = (*Plugin)(nil)
+
+func (p *Plugin) BackupVersion() int {
+	return 1
+}
+
+func (p *Plugin) ExportBackup(ctx context.Context, guildID int64) (interface{}, error) {
+	return GetConfig(guildID)
+}
+
+func (p *Plugin) RestoreBackup(ctx context.Context, guildID int64, data json.RawMessage, restore *common.BackupRestore) error {
+	var conf Config
+	err := json.Unmarshal(data, &conf)
+	if err != nil {
+		return err
+	}
+
+	conf.GuildID = guildID
+	conf.JoinServerChannel = restore.ChannelStr(conf.JoinServerChannel)
+	conf.LeaveChannel = restore.ChannelStr(conf.LeaveChannel)
+	conf.TopicChannel = restore.ChannelStr(conf.TopicChannel)
+
+	if conf.JoinServerEnabled && conf.JoinServerChannel == "" {
+		restore.Note("Join messages are enabled but the channel was not found")
+	}
+
+	if conf.LeaveEnabled && conf.LeaveChannel == "" {
+		restore.Note("Leave messages are enabled but the channel was not found")
+	}
+
+	validateRestoredConfig(&conf, restore)
+
+	if restore.DryRun {
+		return nil
+	}
+
+	return configstore.SQL.SetGuildConfig(ctx, &conf)
+}
+
+// validateRestoredConfig runs the messages and card settings through the same checks as the control panel form,
+// invalid messages are left out and the rest are cleared
+func validateRestoredConfig(conf *Config, restore *common.BackupRestore) {
+	conf.JoinServerMsg = restoredTemplate(restore, "join message", conf.JoinServerMsg, 5000)
+	conf.JoinServerMsgs = restoredTemplates(restore, "join message", conf.JoinServerMsgs, 5000)
+	conf.JoinDMMsg = restoredTemplate(restore, "join DM", conf.JoinDMMsg, 5000)
+	conf.LeaveMsg = restoredTemplate(restore, "leave message", conf.LeaveMsg, 5000)
+	conf.LeaveMsgs = restoredTemplates(restore, "leave message", conf.LeaveMsgs, 5000)
+	conf.JoinCardText = restoredTemplate(restore, "join card text", conf.JoinCardText, 500)
+	conf.LeaveCardText = restoredTemplate(restore, "leave card text", conf.LeaveCardText, 500)
+
+	conf.CardBackgroundURL = restoredString(restore, "card background url", conf.CardBackgroundURL, 500)
+	conf.CardBackgroundColor = restoredString(restore, "card background color", conf.CardBackgroundColor, 7)
+	conf.CardTextColor = restoredString(restore, "card text color", conf.CardTextColor, 7)
+	conf.CardFont = restoredString(restore, "card font", conf.CardFont, 20)
+}
+
+func restoredTemplate(restore *common.BackupRestore, name string, s string, max int) string {
+	if err := web.ValidateTemplateField(s, max); err != nil {
+		restore.Note("Skipped the %s: %s", name, err)
+		return ""
+	}
+
+	return s
+}
+
+func restoredTemplates(restore *common.BackupRestore, name string, msgs []string, max int) []string {
+	valid := make([]string, 0, len(msgs))
+	for i, v := range msgs {
+		if err := web.ValidateTemplateField(v, max); err != nil {
+			restore.Note("Skipped %s %d: %s", name, i+1, err)
+			continue
+		}
+
+		valid = append(valid, v)
+	}
+
+	return valid
+}
+
+func restoredString(restore *common.BackupRestore, name string, s string, max int) string {
+	if err := web.ValidateNormalStringField(s, 0, max); err != nil {
+		restore.Note("Skipped the %s: %s", name, err)
+		return ""
+	}
+
+	return s
+}
@@ -13,6 +13,9 @@ import (
 const (
 	RecordSeparator = "\x1e"
 	MaxUserMessages = 10
+
+	DefaultJoinCardText  = "Welcome to {{.Guild.Name}}!"
+	DefaultLeaveCardText = "Goodbye!"
 )
 
 var logger = common.GetPluginLogger(&Plugin{})
@@ -68,6 +71,17 @@ type Config struct {
 	TopicChannel string `json:"topic_channel" schema:"topic_channel" valid:"channel,true"`
 
 	CensorInvites bool `schema:"censor_invites"`
+
+	// Welcome cards are images attached to the join and leave messages in the server channels
+	JoinCardEnabled     bool   `json:"join_card_enabled" schema:"join_card_enabled"`
+	JoinCardText        string `json:"join_card_text" schema:"join_card_text" valid:"template,500"`
+	LeaveCardEnabled    bool   `json:"leave_card_enabled" schema:"leave_card_enabled"`
+	LeaveCardText       string `json:"leave_card_text" schema:"leave_card_text" valid:"template,500"`
+	CardBackgroundURL   string `json:"card_background_url" schema:"card_background_url" valid:",0,500"`
+	CardBackgroundColor string `json:"card_background_color" schema:"card_background_color" valid:",0,7"`
+	CardTextColor       string `json:"card_text_color" schema:"card_text_color" valid:",0,7"`
+	CardFont            string `json:"card_font" schema:"card_font" valid:",0,20"`
+	CardShowMemberCount bool   `json:"card_show_member_count" schema:"card_show_member_count"`
 }
 
 func (c *Config) JoinServerChannelInt() (i int64) {
@@ -144,6 +158,16 @@ func (c *Config) AfterFind() (err error) {
 	return nil
 }
 
+// CardOptions returns the welcome card styling of this config, the per member fields are left empty
+func (c *Config) CardOptions() *CardOptions {
+	return &CardOptions{
+		BackgroundURL:   c.CardBackgroundURL,
+		BackgroundColor: c.CardBackgroundColor,
+		TextColor:       c.CardTextColor,
+		Font:            c.CardFont,
+	}
+}
+
 var DefaultConfig = &Config{}
 
 func GetConfig(guildID int64) (*Config, error) {
@@ -160,6 +184,12 @@ func GetConfig(guildID int64) (*Config, error) {
 		return &Config{
 			JoinServerMsgs: []string{"<@{{.User.ID}}> Joined!"},
 			LeaveMsgs:      []string{"**{{.User.Username}}** Left... :'("},
+
+			JoinCardText:        DefaultJoinCardText,
+			LeaveCardText:       DefaultLeaveCardText,
+			CardBackgroundColor: "#23272a",
+			CardTextColor:       "#ffffff",
+			CardFont:            DefaultCardFont,
 		}, nil
 	}
 
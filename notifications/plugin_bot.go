@@ -1,9 +1,12 @@
 package notifications
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"math/rand"
 	"strings"
+	"time"
 
 	"emperror.dev/errors"
 	"github.com/botlabs-gg/yagpdb/v2/analytics"
@@ -62,7 +65,7 @@ func HandleGuildMemberAdd(evtData *eventsystem.EventData) (retry bool, err error
 
 			go analytics.RecordActiveUnit(gs.ID, &Plugin{}, "posted_join_server_msg")
 
-			if sendTemplate(gs, thinCState, config.JoinDMMsg, ms, "join dm", false, templates.ExecutedFromJoin) {
+			if sendTemplate(gs, thinCState, config.JoinDMMsg, ms, "join dm", false, templates.ExecutedFromJoin, nil) {
 				return true, nil
 			}
 		}
@@ -76,8 +79,13 @@ func HandleGuildMemberAdd(evtData *eventsystem.EventData) (retry bool, err error
 
 		go analytics.RecordActiveUnit(gs.ID, &Plugin{}, "posted_join_server_dm")
 
+		var card *discordgo.File
+		if config.JoinCardEnabled {
+			card = renderMemberCard(gs, channel, ms, config, config.JoinCardText, templates.ExecutedFromJoin)
+		}
+
 		chanMsg := config.JoinServerMsgs[rand.Intn(len(config.JoinServerMsgs))]
-		if sendTemplate(gs, channel, chanMsg, ms, "join server msg", config.CensorInvites, templates.ExecutedFromJoin) {
+		if sendTemplate(gs, channel, chanMsg, ms, "join server msg", config.CensorInvites, templates.ExecutedFromJoin, card) {
 			return true, nil
 		}
 	}
@@ -113,7 +121,12 @@ func HandleGuildMemberRemove(evt *eventsystem.EventData) (retry bool, err error)
 
 	go analytics.RecordActiveUnit(gs.ID, &Plugin{}, "posted_leave_server_msg")
 
-	if sendTemplate(gs, channel, chanMsg, ms, "leave", config.CensorInvites, templates.ExecutedFromLeave) {
+	var card *discordgo.File
+	if config.LeaveCardEnabled {
+		card = renderMemberCard(gs, channel, ms, config, config.LeaveCardText, templates.ExecutedFromLeave)
+	}
+
+	if sendTemplate(gs, channel, chanMsg, ms, "leave", config.CensorInvites, templates.ExecutedFromLeave, card) {
 		return true, nil
 	}
 
@@ -121,7 +134,8 @@ func HandleGuildMemberRemove(evt *eventsystem.EventData) (retry bool, err error)
 }
 
 // sendTemplate parses and executes the provided template, returns wether an error occured that we can retry from (temporary network failures and the like)
-func sendTemplate(gs *dstate.GuildSet, cs *dstate.ChannelState, tmpl string, ms *dstate.MemberState, name string, censorInvites bool, executedFrom templates.ExecutedFromType) bool {
+// card is an optional welcome card image attached to the message
+func sendTemplate(gs *dstate.GuildSet, cs *dstate.ChannelState, tmpl string, ms *dstate.MemberState, name string, censorInvites bool, executedFrom templates.ExecutedFromType, card *discordgo.File) bool {
 	ctx := templates.NewContext(gs, cs, ms)
 	ctx.CurrentFrame.SendResponseInDM = cs.Type == discordgo.ChannelTypeDM
 	ctx.ExecutedFrom = executedFrom
@@ -153,7 +167,7 @@ func sendTemplate(gs *dstate.GuildSet, cs *dstate.ChannelState, tmpl string, ms
 	}
 
 	msg = strings.TrimSpace(msg)
-	if msg == "" {
+	if msg == "" && card == nil {
 		return false
 	}
 
@@ -174,8 +188,10 @@ func sendTemplate(gs *dstate.GuildSet, cs *dstate.ChannelState, tmpl string, ms
 		}
 		m, err = common.BotSession.ChannelMessageSendComplex(cs.ID, msgSend)
 	} else {
-		if len(ctx.CurrentFrame.AddResponseReactionNames) > 0 || ctx.CurrentFrame.DelResponse || ctx.CurrentFrame.PublishResponse {
-			m, err = common.BotSession.ChannelMessageSendComplex(cs.ID, ctx.MessageSend(msg))
+		if len(ctx.CurrentFrame.AddResponseReactionNames) > 0 || ctx.CurrentFrame.DelResponse || ctx.CurrentFrame.PublishResponse || card != nil {
+			msgSend := ctx.MessageSend(msg)
+			msgSend.File = card
+			m, err = common.BotSession.ChannelMessageSendComplex(cs.ID, msgSend)
 			if err == nil && ctx.CurrentFrame.DelResponse {
 				templates.MaybeScheduledDeleteMessage(gs.ID, cs.ID, m.ID, ctx.CurrentFrame.DelResponseDelay)
 			}
@@ -211,6 +227,59 @@ func sendTemplate(gs *dstate.GuildSet, cs *dstate.ChannelState, tmpl string, ms
 	return bot.CheckDiscordErrRetry(err)
 }
 
+// renderMemberCard renders the welcome card for the member, returns nil if it could not be rendered
+func renderMemberCard(gs *dstate.GuildSet, cs *dstate.ChannelState, ms *dstate.MemberState, config *Config, textTmpl string, executedFrom templates.ExecutedFromType) *discordgo.File {
+	username := ms.User.Username
+	if config.CensorInvites {
+		username = common.ReplaceServerInvites(username, gs.ID, "[removed-server-invite]")
+	}
+
+	if textTmpl == "" {
+		textTmpl = DefaultJoinCardText
+		if executedFrom == templates.ExecutedFromLeave {
+			textTmpl = DefaultLeaveCardText
+		}
+	}
+
+	text := ""
+	{
+		ctx := templates.NewContext(gs, cs, ms)
+		ctx.ExecutedFrom = executedFrom
+		// only the text is used, so don't allow sending messages from it
+		ctx.DisabledContextFuncs = []string{"sendDM", "sendMessage", "sendMessageRetID", "sendMessageNoEscape", "sendMessageNoEscapeRetID", "sendTemplate", "sendTemplateDM"}
+		ctx.Data["RealUsername"] = ms.User.Username
+
+		var err error
+		text, err = ctx.Execute(textTmpl)
+		if err != nil {
+			logger.WithError(err).WithField("guild", gs.ID).Warn("Failed parsing/executing welcome card text template")
+		}
+	}
+
+	opts := config.CardOptions()
+	opts.Text = text
+	opts.Username = username
+	opts.AvatarURL = ms.User.AvatarURL("256")
+	if config.CardShowMemberCount && executedFrom == templates.ExecutedFromJoin {
+		opts.MemberCount = gs.MemberCount
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	img, err := RenderCard(ctx, CardHTTPClient, opts)
+	if err != nil {
+		logger.WithError(err).WithField("guild", gs.ID).Error("Failed rendering welcome card")
+		return nil
+	}
+
+	return &discordgo.File{
+		Name:        "welcome.png",
+		ContentType: "image/png",
+		Reader:      bytes.NewReader(img),
+	}
+}
+
 func HandleChannelUpdate(evt *eventsystem.EventData) (retry bool, err error) {
 	cu := evt.ChannelUpdate()
 
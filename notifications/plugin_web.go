@@ -1,10 +1,13 @@
 package notifications
 
 import (
+	"context"
 	_ "embed"
 	"fmt"
 	"html/template"
 	"net/http"
+	"sort"
+	"strings"
 
 	"github.com/botlabs-gg/yagpdb/v2/common"
 	"github.com/botlabs-gg/yagpdb/v2/common/configstore"
@@ -35,6 +38,33 @@ func (p *Plugin) InitWeb() {
 
 	web.CPMux.Handle(pat.Post("/notifications/general"), postHandler)
 	web.CPMux.Handle(pat.Post("/notifications/general/"), postHandler)
+
+	web.CPMux.Handle(pat.Get("/notifications/general/card_preview.png"), http.HandlerFunc(HandleCardPreview))
+
+	web.APIMux.Handle(pat.Get("/notifications/general"), web.APIConfigHandler(p, "notifications"))
+	web.APIMux.Handle(pat.Put("/notifications/general"), web.APIWriteHandler(p, web.APIFromController(HandleNotificationsPost), Config{}))
+
+	cplogs.RegisterConfigSource(&cplogs.ConfigSource{
+		Key:  "notifications",
+		Name: "General notifications",
+		Page: "notifications/general",
+		Get: func(ctx context.Context, guildID int64) (interface{}, error) {
+			return GetConfig(guildID)
+		},
+		Revert: func(ctx context.Context, guildID int64, snapshot string) error {
+			conf, err := GetConfig(guildID)
+			if err != nil {
+				return err
+			}
+
+			if err = cplogs.RestoreSnapshot(conf, snapshot); err != nil {
+				return err
+			}
+
+			conf.GuildID = guildID
+			return configstore.SQL.SetGuildConfig(ctx, conf)
+		},
+	})
 }
 
 func HandleNotificationsGet(w http.ResponseWriter, r *http.Request) interface{} {
@@ -53,6 +83,13 @@ func HandleNotificationsGet(w http.ResponseWriter, r *http.Request) interface{}
 		templateData["NotifyConfig"] = conf
 	}
 
+	fonts := make([]string, 0, len(CardFonts))
+	for k := range CardFonts {
+		fonts = append(fonts, k)
+	}
+	sort.Strings(fonts)
+	templateData["CardFonts"] = fonts
+
 	return templateData
 }
 
@@ -65,6 +102,17 @@ func HandleNotificationsPost(w http.ResponseWriter, r *http.Request) (web.Templa
 
 	newConfig.GuildID = activeGuild.ID
 
+	newConfig.CardBackgroundURL = strings.TrimSpace(newConfig.CardBackgroundURL)
+	if newConfig.CardBackgroundURL != "" {
+		if err := ValidateCardImageURL(newConfig.CardBackgroundURL); err != nil {
+			return templateData.AddAlerts(web.ErrorAlert(err.Error())), nil
+		}
+	}
+
+	if _, ok := CardFonts[newConfig.CardFont]; !ok {
+		newConfig.CardFont = DefaultCardFont
+	}
+
 	err := configstore.SQL.SetGuildConfig(ctx, newConfig)
 	if err != nil {
 		return templateData, nil
@@ -75,6 +123,46 @@ func HandleNotificationsPost(w http.ResponseWriter, r *http.Request) (web.Templa
 	return templateData, nil
 }
 
+// HandleCardPreview renders the saved welcome card style using the current user
+func HandleCardPreview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	activeGuild, _ := web.GetBaseCPContextData(ctx)
+	user := web.ContextUser(ctx)
+
+	config, err := GetConfig(activeGuild.ID)
+	if err != nil {
+		web.CtxLogger(ctx).WithError(err).Error("failed retrieving config")
+		http.Error(w, "Failed retrieving config", http.StatusInternalServerError)
+		return
+	}
+
+	leave := r.URL.Query().Get("leave") != ""
+
+	opts := config.CardOptions()
+	opts.Text = "Welcome to " + activeGuild.Name + "!"
+	if leave {
+		opts.Text = "Goodbye!"
+	} else if config.CardShowMemberCount {
+		opts.MemberCount = int64(activeGuild.MemberCount)
+	}
+
+	if user != nil {
+		opts.Username = user.Username
+		opts.AvatarURL = user.AvatarURL("256")
+	}
+
+	img, err := RenderCard(ctx, CardHTTPClient, opts)
+	if err != nil {
+		web.CtxLogger(ctx).WithError(err).Error("failed rendering welcome card preview")
+		http.Error(w, "Failed rendering card", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write(img)
+}
+
 var _ web.PluginWithServerHomeWidget = (*Plugin)(nil)
 
 func (p *Plugin) LoadServerHomeWidget(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
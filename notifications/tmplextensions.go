@@ -0,0 +1,107 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/botlabs-gg/yagpdb/v2/bot"
+	"github.com/botlabs-gg/yagpdb/v2/common/templates"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+)
+
+func init() {
+	templates.RegisterSetupFunc(func(ctx *templates.Context) {
+		ctx.ContextFuncs["welcomeCard"] = tmplWelcomeCard(ctx)
+	})
+}
+
+// tmplWelcomeCard renders a welcome card for the user using the server's card style,
+// the result can be attached with the "file" key of complexMessage
+func tmplWelcomeCard(tmplCtx *templates.Context) interface{} {
+	return func(user interface{}, options ...interface{}) (*discordgo.File, error) {
+		if tmplCtx.IncreaseCheckCallCounterPremium("welcomeCard", 1, 2) {
+			return nil, templates.ErrTooManyCalls
+		}
+
+		if tmplCtx.IncreaseCheckGenericAPICall() {
+			return nil, templates.ErrTooManyAPICalls
+		}
+
+		targetID := templates.TargetUserID(user)
+		if targetID == 0 {
+			return nil, errors.New("invalid user")
+		}
+
+		ms, err := bot.GetMember(tmplCtx.GS.ID, targetID)
+		if err != nil {
+			return nil, err
+		}
+
+		config, err := GetConfig(tmplCtx.GS.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		opts := config.CardOptions()
+		opts.Text = "Welcome!"
+		opts.Username = ms.User.Username
+		opts.AvatarURL = ms.User.AvatarURL("256")
+
+		if len(options) > 0 {
+			dict, err := templates.StringKeyDictionary(options...)
+			if err != nil {
+				return nil, err
+			}
+
+			for k, v := range dict {
+				switch strings.ToLower(k) {
+				case "text":
+					opts.Text = templates.ToString(v)
+				case "username":
+					opts.Username = templates.ToString(v)
+				case "background":
+					opts.BackgroundURL = templates.ToString(v)
+				case "color":
+					opts.BackgroundColor = templates.ToString(v)
+				case "textcolor":
+					opts.TextColor = templates.ToString(v)
+				case "font":
+					opts.Font = templates.ToString(v)
+				case "membercount":
+					if b, ok := v.(bool); ok {
+						if b {
+							opts.MemberCount = tmplCtx.GS.MemberCount
+						}
+					} else {
+						opts.MemberCount = templates.ToInt64(v)
+					}
+				default:
+					return nil, errors.New(`invalid key "` + k + `" passed to welcomeCard`)
+				}
+			}
+		}
+
+		if opts.BackgroundURL != "" {
+			if err := ValidateCardImageURL(opts.BackgroundURL); err != nil {
+				return nil, err
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+		defer cancel()
+
+		img, err := RenderCard(ctx, CardHTTPClient, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		return &discordgo.File{
+			Name:        "welcome.png",
+			ContentType: "image/png",
+			Reader:      bytes.NewReader(img),
+		}, nil
+	}
+}
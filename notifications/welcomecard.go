@@ -0,0 +1,414 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/karlseguin/ccache"
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/gomedium"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/gofont/gosmallcaps"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	CardWidth  = 1024
+	CardHeight = 320
+
+	cardAvatarSize   = 200
+	cardAvatarBorder = 6
+	cardPadding      = 20
+
+	// Max size of downloaded avatars and backgrounds
+	maxCardImageBytes = 8 << 20
+	// Max dimensions of downloaded images, to avoid decompression bombs
+	maxCardImageDimension = 4096
+)
+
+// HTTPClient is what's used to download avatars and backgrounds for welcome cards,
+// *http.Client satisfies it and tests can swap in a local stand-in.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// CardHTTPClient is the client used for downloading images for welcome cards
+var CardHTTPClient HTTPClient = &http.Client{Timeout: time.Second * 10}
+
+// Only images from these hosts are downloaded for card backgrounds and avatars
+var allowedCardImageHosts = []string{
+	"cdn.discordapp.com",
+	"media.discordapp.net",
+	"i.imgur.com",
+}
+
+// Fonts bundled for welcome cards, the key is what's stored in the config
+var CardFonts = map[string][]byte{
+	"regular":   goregular.TTF,
+	"medium":    gomedium.TTF,
+	"bold":      gobold.TTF,
+	"italic":    goitalic.TTF,
+	"mono":      gomono.TTF,
+	"smallcaps": gosmallcaps.TTF,
+}
+
+const DefaultCardFont = "bold"
+
+var (
+	parsedFonts   = make(map[string]*opentype.Font)
+	parsedFontsMu sync.Mutex
+)
+
+// Backgrounds are usually the same for every card in a guild, so keep them around for a bit
+var cardBackgroundCache = ccache.New(ccache.Configure().MaxSize(500).ItemsToPrune(50))
+
+// CardOptions describes what's drawn on a welcome card
+type CardOptions struct {
+	Text        string
+	Username    string
+	MemberCount int64 // hidden if 0
+
+	AvatarURL       string
+	BackgroundURL   string
+	BackgroundColor string // hex, e.g #23272a
+	TextColor       string // hex
+	Font            string // key in CardFonts
+}
+
+// RenderCard renders a welcome card as a png, a failure to fetch the avatar or background is not fatal,
+// a placeholder is drawn instead
+func RenderCard(ctx context.Context, client HTTPClient, opts *CardOptions) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, CardWidth, CardHeight))
+
+	bgColor := parseHexColor(opts.BackgroundColor, color.RGBA{0x23, 0x27, 0x2a, 0xff})
+	draw.Draw(img, img.Bounds(), image.NewUniform(bgColor), image.Point{}, draw.Src)
+
+	if opts.BackgroundURL != "" {
+		bg, err := fetchCardBackground(ctx, client, opts.BackgroundURL)
+		if err != nil {
+			logger.WithError(err).WithField("url", opts.BackgroundURL).Debug("failed fetching welcome card background")
+		} else {
+			drawCover(img, bg)
+		}
+	}
+
+	// darken the area behind the avatar and text so it's readable on any background
+	panel := image.Rect(cardPadding, cardPadding, CardWidth-cardPadding, CardHeight-cardPadding)
+	draw.Draw(img, panel, image.NewUniform(color.RGBA{0, 0, 0, 0x8c}), image.Point{}, draw.Over)
+
+	avatarRect := image.Rect(0, 0, cardAvatarSize, cardAvatarSize).Add(image.Pt(cardPadding*3, (CardHeight-cardAvatarSize)/2))
+	textColor := parseHexColor(opts.TextColor, color.RGBA{0xff, 0xff, 0xff, 0xff})
+	drawAvatar(ctx, client, img, avatarRect, opts.AvatarURL, textColor)
+
+	fnt, err := cardFont(opts.Font)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := []cardLine{
+		{Text: opts.Text, Size: 52},
+		{Text: opts.Username, Size: 40},
+	}
+	if opts.MemberCount > 0 {
+		lines = append(lines, cardLine{Text: "Member #" + strconv.FormatInt(opts.MemberCount, 10), Size: 28})
+	}
+
+	textLeft := avatarRect.Max.X + cardPadding*2
+	err = drawCardLines(img, fnt, lines, textLeft, CardWidth-cardPadding*3, textColor)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = png.Encode(&buf, img)
+	if err != nil {
+		return nil, errors.WithStackIf(err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+type cardLine struct {
+	Text string
+	Size float64
+}
+
+// drawCardLines draws the non-empty lines vertically centered between left and right,
+// shrinking and eventually truncating lines that don't fit
+func drawCardLines(dst draw.Image, fnt *opentype.Font, lines []cardLine, left, right int, clr color.Color) error {
+	const lineSpacing = 14
+
+	type preparedLine struct {
+		text string
+		face font.Face
+	}
+
+	maxWidth := fixed.I(right - left)
+	prepared := make([]preparedLine, 0, len(lines))
+	totalHeight := 0
+
+	for _, l := range lines {
+		text := strings.TrimSpace(strings.ReplaceAll(l.Text, "\n", " "))
+		if text == "" {
+			continue
+		}
+
+		size := l.Size
+		face, err := newCardFace(fnt, size)
+		if err != nil {
+			return err
+		}
+
+		for font.MeasureString(face, text) > maxWidth && size > 20 {
+			face.Close()
+			size -= 4
+			face, err = newCardFace(fnt, size)
+			if err != nil {
+				return err
+			}
+		}
+
+		text = truncateToWidth(face, text, maxWidth)
+		prepared = append(prepared, preparedLine{text: text, face: face})
+		totalHeight += face.Metrics().Height.Ceil()
+	}
+
+	if len(prepared) > 0 {
+		totalHeight += lineSpacing * (len(prepared) - 1)
+	}
+
+	y := (CardHeight - totalHeight) / 2
+	for _, l := range prepared {
+		metrics := l.face.Metrics()
+		drawer := &font.Drawer{
+			Dst:  dst,
+			Src:  image.NewUniform(clr),
+			Face: l.face,
+			Dot:  fixed.P(left, y+metrics.Ascent.Ceil()),
+		}
+		drawer.DrawString(l.text)
+
+		y += metrics.Height.Ceil() + lineSpacing
+		l.face.Close()
+	}
+
+	return nil
+}
+
+func truncateToWidth(face font.Face, text string, maxWidth fixed.Int26_6) string {
+	if font.MeasureString(face, text) <= maxWidth {
+		return text
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		candidate := strings.TrimSpace(string(runes)) + "…"
+		if font.MeasureString(face, candidate) <= maxWidth {
+			return candidate
+		}
+	}
+
+	return ""
+}
+
+func newCardFace(fnt *opentype.Font, size float64) (font.Face, error) {
+	face, err := opentype.NewFace(fnt, &opentype.FaceOptions{
+		Size:    size,
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+	return face, errors.WithStackIf(err)
+}
+
+func cardFont(name string) (*opentype.Font, error) {
+	if _, ok := CardFonts[name]; !ok {
+		name = DefaultCardFont
+	}
+
+	parsedFontsMu.Lock()
+	defer parsedFontsMu.Unlock()
+
+	if f, ok := parsedFonts[name]; ok {
+		return f, nil
+	}
+
+	f, err := opentype.Parse(CardFonts[name])
+	if err != nil {
+		return nil, errors.WithStackIf(err)
+	}
+
+	parsedFonts[name] = f
+	return f, nil
+}
+
+// drawAvatar draws the avatar as a circle with a border, falls back to a plain circle if it couldn't be fetched
+func drawAvatar(ctx context.Context, client HTTPClient, dst draw.Image, rect image.Rectangle, avatarURL string, borderColor color.Color) {
+	border := rect.Inset(-cardAvatarBorder)
+	draw.DrawMask(dst, border, image.NewUniform(borderColor), image.Point{}, &circleMask{size: border.Dx()}, image.Point{}, draw.Over)
+
+	var avatar image.Image
+	if avatarURL != "" {
+		var err error
+		avatar, err = fetchCardImage(ctx, client, avatarURL)
+		if err != nil {
+			logger.WithError(err).WithField("url", avatarURL).Debug("failed fetching welcome card avatar")
+		}
+	}
+
+	scaled := image.NewRGBA(image.Rect(0, 0, rect.Dx(), rect.Dy()))
+	if avatar != nil {
+		xdraw.CatmullRom.Scale(scaled, scaled.Bounds(), avatar, avatar.Bounds(), xdraw.Src, nil)
+	} else {
+		draw.Draw(scaled, scaled.Bounds(), image.NewUniform(color.RGBA{0x74, 0x7f, 0x8d, 0xff}), image.Point{}, draw.Src)
+	}
+
+	draw.DrawMask(dst, rect, scaled, image.Point{}, &circleMask{size: rect.Dx()}, image.Point{}, draw.Over)
+}
+
+// drawCover scales src to cover the whole of dst, cropping the overflow
+func drawCover(dst *image.RGBA, src image.Image) {
+	sb := src.Bounds()
+	db := dst.Bounds()
+	if sb.Dx() < 1 || sb.Dy() < 1 {
+		return
+	}
+
+	// crop the source to the aspect ratio of the destination
+	crop := sb
+	if sb.Dx()*db.Dy() > sb.Dy()*db.Dx() {
+		w := sb.Dy() * db.Dx() / db.Dy()
+		crop.Min.X = sb.Min.X + (sb.Dx()-w)/2
+		crop.Max.X = crop.Min.X + w
+	} else {
+		h := sb.Dx() * db.Dy() / db.Dx()
+		crop.Min.Y = sb.Min.Y + (sb.Dy()-h)/2
+		crop.Max.Y = crop.Min.Y + h
+	}
+
+	xdraw.ApproxBiLinear.Scale(dst, db, src, crop, xdraw.Src, nil)
+}
+
+// circleMask is an alpha mask for a circle filling a size*size square
+type circleMask struct {
+	size int
+}
+
+func (c *circleMask) ColorModel() color.Model { return color.AlphaModel }
+func (c *circleMask) Bounds() image.Rectangle { return image.Rect(0, 0, c.size, c.size) }
+func (c *circleMask) At(x, y int) color.Color {
+	r := float64(c.size) / 2
+	dx := float64(x) + 0.5 - r
+	dy := float64(y) + 0.5 - r
+	if dx*dx+dy*dy <= r*r {
+		return color.Alpha{A: 0xff}
+	}
+
+	return color.Alpha{}
+}
+
+func fetchCardBackground(ctx context.Context, client HTTPClient, imageURL string) (image.Image, error) {
+	item, err := cardBackgroundCache.Fetch(imageURL, time.Minute*10, func() (interface{}, error) {
+		return fetchCardImage(ctx, client, imageURL)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return item.Value().(image.Image), nil
+}
+
+// ValidateCardImageURL returns an error if images can't be downloaded from the url
+func ValidateCardImageURL(imageURL string) error {
+	parsed, err := url.Parse(imageURL)
+	if err != nil || parsed.Scheme != "https" {
+		return errors.New("Image URL has to be a https link")
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	for _, v := range allowedCardImageHosts {
+		if host == v {
+			return nil
+		}
+	}
+
+	return errors.New("Images can only be loaded from " + strings.Join(allowedCardImageHosts, ", "))
+}
+
+func fetchCardImage(ctx context.Context, client HTTPClient, imageURL string) (image.Image, error) {
+	err := ValidateCardImageURL(imageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return nil, errors.WithStackIf(err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.WithStackIf(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxCardImageBytes+1))
+	if err != nil {
+		return nil, errors.WithStackIf(err)
+	}
+
+	if len(data) > maxCardImageBytes {
+		return nil, errors.New("image too big")
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.WithStackIf(err)
+	}
+
+	if cfg.Width > maxCardImageDimension || cfg.Height > maxCardImageDimension {
+		return nil, errors.New("image dimensions too big")
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	return img, errors.WithStackIf(err)
+}
+
+// parseHexColor parses colors in the #rrggbb format, returning def if invalid
+func parseHexColor(s string, def color.RGBA) color.RGBA {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if len(s) != 6 {
+		return def
+	}
+
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return def
+	}
+
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}
+}
@@ -0,0 +1,146 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"net/http"
+	"testing"
+)
+
+// fakeImageClient serves a solid color png for every request, standing in for the discord cdn
+type fakeImageClient struct {
+	color     color.RGBA
+	status    int
+	requested []string
+}
+
+func (f *fakeImageClient) Do(req *http.Request) (*http.Response, error) {
+	f.requested = append(f.requested, req.URL.String())
+
+	img := image.NewRGBA(image.Rect(0, 0, 64, 64))
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = f.color.R, f.color.G, f.color.B, f.color.A
+	}
+
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+
+	status := f.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(&buf),
+		Header:     make(http.Header),
+		Request:    req,
+	}, nil
+}
+
+func renderTestCard(t *testing.T, client HTTPClient, opts *CardOptions) image.Image {
+	data, err := RenderCard(context.Background(), client, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if img.Bounds().Dx() != CardWidth || img.Bounds().Dy() != CardHeight {
+		t.Fatalf("unexpected card size %v", img.Bounds())
+	}
+
+	return img
+}
+
+func avatarCenter() image.Point {
+	return image.Pt(cardPadding*3+cardAvatarSize/2, CardHeight/2)
+}
+
+func TestRenderCard(t *testing.T) {
+	client := &fakeImageClient{color: color.RGBA{0xff, 0, 0, 0xff}}
+	img := renderTestCard(t, client, &CardOptions{
+		Text:            "Welcome to the server with a very long name that will not fit on a single line at all!",
+		Username:        "botlabs",
+		MemberCount:     1337,
+		AvatarURL:       "https://cdn.discordapp.com/avatars/1/abc.png?size=256",
+		BackgroundColor: "#00ff00",
+		Font:            "unknown font falls back",
+	})
+
+	if len(client.requested) != 1 {
+		t.Errorf("expected 1 request, got %v", client.requested)
+	}
+
+	r, g, b, _ := img.At(avatarCenter().X, avatarCenter().Y).RGBA()
+	if r>>8 != 0xff || g>>8 != 0 || b>>8 != 0 {
+		t.Errorf("expected the avatar to be drawn, got %d %d %d", r>>8, g>>8, b>>8)
+	}
+
+	// corners are outside the darkened panel
+	r, g, b, _ = img.At(2, 2).RGBA()
+	if r>>8 != 0 || g>>8 != 0xff || b>>8 != 0 {
+		t.Errorf("expected the background color in the corner, got %d %d %d", r>>8, g>>8, b>>8)
+	}
+}
+
+func TestRenderCardFallbacks(t *testing.T) {
+	client := &fakeImageClient{status: http.StatusNotFound}
+	img := renderTestCard(t, client, &CardOptions{
+		Username:      "botlabs",
+		AvatarURL:     "https://cdn.discordapp.com/avatars/1/abc.png",
+		BackgroundURL: "http://127.0.0.1/internal.png",
+	})
+
+	// the background is not on an allowed host so only the avatar should have been requested
+	if len(client.requested) != 1 {
+		t.Errorf("expected 1 request, got %v", client.requested)
+	}
+
+	// placeholder avatar
+	r, g, b, _ := img.At(avatarCenter().X, avatarCenter().Y).RGBA()
+	if r>>8 != 0x74 || g>>8 != 0x7f || b>>8 != 0x8d {
+		t.Errorf("expected the placeholder avatar, got %d %d %d", r>>8, g>>8, b>>8)
+	}
+}
+
+func TestValidateCardImageURL(t *testing.T) {
+	cases := map[string]bool{
+		"https://cdn.discordapp.com/attachments/1/2/bg.png": true,
+		"https://i.imgur.com/abc.jpg":                       true,
+		"http://cdn.discordapp.com/attachments/1/2/bg.png":  false,
+		"https://example.com/bg.png":                        false,
+		"https://cdn.discordapp.com.evil.com/bg.png":        false,
+		"not a url": false,
+	}
+
+	for in, valid := range cases {
+		if err := ValidateCardImageURL(in); (err == nil) != valid {
+			t.Errorf("%q: expected valid=%v, got err %v", in, valid, err)
+		}
+	}
+}
+
+func TestParseHexColor(t *testing.T) {
+	def := color.RGBA{1, 2, 3, 0xff}
+	cases := map[string]color.RGBA{
+		"#ff8000": {0xff, 0x80, 0x00, 0xff},
+		"00ff00":  {0x00, 0xff, 0x00, 0xff},
+		"":        def,
+		"#fff":    def,
+		"#zzzzzz": def,
+	}
+
+	for in, expected := range cases {
+		if got := parseHexColor(in, def); got != expected {
+			t.Errorf("%q: got %v, expected %v", in, got, expected)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package polls
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strings"
+	"sync"
+
+	"emperror.dev/errors"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	chartWidth       = 800
+	chartPadding     = 24
+	chartTitleHeight = 56
+	chartRowHeight   = 56
+	chartBarHeight   = 18
+)
+
+var (
+	chartBackground = color.RGBA{0x2b, 0x2d, 0x31, 0xff}
+	chartText       = color.RGBA{0xf2, 0xf3, 0xf5, 0xff}
+	chartMutedText  = color.RGBA{0xb5, 0xba, 0xc1, 0xff}
+	chartTrack      = color.RGBA{0x3f, 0x41, 0x47, 0xff}
+	chartBar        = color.RGBA{0x58, 0x65, 0xf2, 0xff}
+	chartWinnerBar  = color.RGBA{0x23, 0xa5, 0x5a, 0xff}
+)
+
+var (
+	chartFonts     map[string]*opentype.Font
+	chartFontsOnce sync.Once
+	chartFontsErr  error
+)
+
+func loadChartFonts() (regular, bold *opentype.Font, err error) {
+	chartFontsOnce.Do(func() {
+		chartFonts = make(map[string]*opentype.Font)
+		for name, data := range map[string][]byte{"regular": goregular.TTF, "bold": gobold.TTF} {
+			f, err := opentype.Parse(data)
+			if err != nil {
+				chartFontsErr = errors.WithStackIf(err)
+				return
+			}
+
+			chartFonts[name] = f
+		}
+	})
+
+	return chartFonts["regular"], chartFonts["bold"], chartFontsErr
+}
+
+// RenderChart draws the results as a horizontal bar chart, returns a png image
+func RenderChart(question string, options []string, results *Results) ([]byte, error) {
+	regular, bold, err := loadChartFonts()
+	if err != nil {
+		return nil, err
+	}
+
+	titleFace, err := opentype.NewFace(bold, &opentype.FaceOptions{Size: 24, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return nil, errors.WithStackIf(err)
+	}
+	defer titleFace.Close()
+
+	labelFace, err := opentype.NewFace(regular, &opentype.FaceOptions{Size: 17, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return nil, errors.WithStackIf(err)
+	}
+	defer labelFace.Close()
+
+	height := chartPadding*2 + chartTitleHeight + chartRowHeight*len(options)
+	img := image.NewRGBA(image.Rect(0, 0, chartWidth, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(chartBackground), image.Point{}, draw.Src)
+
+	maxWidth := fixed.I(chartWidth - chartPadding*2)
+	drawText(img, titleFace, truncateToWidth(titleFace, question, maxWidth), chartPadding, chartPadding+28, chartText)
+
+	most := 0
+	for _, c := range results.Counts {
+		if c > most {
+			most = c
+		}
+	}
+
+	barWidth := chartWidth - chartPadding*2
+	for i, option := range options {
+		count := 0
+		if i < len(results.Counts) {
+			count = results.Counts[i]
+		}
+
+		top := chartPadding + chartTitleHeight + i*chartRowHeight
+
+		stats := fmt.Sprintf("%d (%d%%)", count, percentage(count, results.Total))
+		statsWidth := font.MeasureString(labelFace, stats)
+		label := truncateToWidth(labelFace, fmt.Sprintf("%d. %s", i+1, option), maxWidth-statsWidth-fixed.I(16))
+
+		drawText(img, labelFace, label, chartPadding, top+18, chartText)
+		drawText(img, labelFace, stats, chartWidth-chartPadding-statsWidth.Ceil(), top+18, chartMutedText)
+
+		barTop := top + 28
+		draw.Draw(img, image.Rect(chartPadding, barTop, chartPadding+barWidth, barTop+chartBarHeight), image.NewUniform(chartTrack), image.Point{}, draw.Src)
+
+		if count > 0 && most > 0 {
+			clr := chartBar
+			if containsInt(results.Winners, i) {
+				clr = chartWinnerBar
+			}
+
+			w := barWidth * count / most
+			draw.Draw(img, image.Rect(chartPadding, barTop, chartPadding+w, barTop+chartBarHeight), image.NewUniform(clr), image.Point{}, draw.Src)
+		}
+	}
+
+	var buf bytes.Buffer
+	err = png.Encode(&buf, img)
+	return buf.Bytes(), errors.WithStackIf(err)
+}
+
+func drawText(dst draw.Image, face font.Face, text string, x, y int, clr color.Color) {
+	drawer := &font.Drawer{
+		Dst:  dst,
+		Src:  image.NewUniform(clr),
+		Face: face,
+		Dot:  fixed.P(x, y),
+	}
+	drawer.DrawString(text)
+}
+
+func truncateToWidth(face font.Face, text string, maxWidth fixed.Int26_6) string {
+	text = strings.ReplaceAll(text, "\n", " ")
+	if font.MeasureString(face, text) <= maxWidth {
+		return text
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		candidate := strings.TrimSpace(string(runes)) + "…"
+		if font.MeasureString(face, candidate) <= maxWidth {
+			return candidate
+		}
+	}
+
+	return ""
+}
+
+func percentage(count, total int) int {
+	if total < 1 {
+		return 0
+	}
+
+	return (count*100 + total/2) / total
+}
@@ -0,0 +1,341 @@
+package polls
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+)
+
+const (
+	pollColor   = 0x65f442
+	closedColor = 0x7289da
+
+	barLength = 12
+
+	// Max voters listed per option in the results of public polls
+	maxVotersShown = 10
+)
+
+// Custom ids of the poll components, followed by the poll id and the option for vote buttons
+const (
+	customIDVote   = "polls_vote"
+	customIDSelect = "polls_select"
+	customIDClear  = "polls_clear"
+	customIDMyVote = "polls_myvote"
+)
+
+// parseCustomID parses the custom id of a poll component, option is -1 for everything but vote buttons
+func parseCustomID(customID string) (action string, pollID int64, option int, ok bool) {
+	parts := strings.Split(customID, ":")
+	if len(parts) < 2 {
+		return "", 0, -1, false
+	}
+
+	action = parts[0]
+	pollID, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", 0, -1, false
+	}
+
+	option = -1
+	switch action {
+	case customIDVote:
+		if len(parts) != 3 {
+			return "", 0, -1, false
+		}
+
+		option, err = strconv.Atoi(parts[2])
+		if err != nil || option < 0 {
+			return "", 0, -1, false
+		}
+	case customIDSelect, customIDClear, customIDMyVote:
+		if len(parts) != 2 {
+			return "", 0, -1, false
+		}
+	default:
+		return "", 0, -1, false
+	}
+
+	return action, pollID, option, true
+}
+
+func customID(action string, pollID int64, extra ...int) string {
+	id := action + ":" + strconv.FormatInt(pollID, 10)
+	for _, v := range extra {
+		id += ":" + strconv.Itoa(v)
+	}
+
+	return id
+}
+
+func optionLabel(options []string, i int) string {
+	return strconv.Itoa(i+1) + ". " + options[i]
+}
+
+// pollComponents returns the components members vote with
+func pollComponents(p *Poll) []discordgo.MessageComponent {
+	var rows []discordgo.MessageComponent
+
+	if p.Input == InputSelect && p.Mode != ModeRanked {
+		maxValues := 1
+		if p.Mode == ModeMulti {
+			maxValues = len(p.Options)
+			if p.MaxChoices > 0 && p.MaxChoices < maxValues {
+				maxValues = p.MaxChoices
+			}
+		}
+
+		minValues := 1
+		menu := discordgo.SelectMenu{
+			CustomID:    customID(customIDSelect, p.ID),
+			Placeholder: "Pick an option",
+			MinValues:   &minValues,
+			MaxValues:   maxValues,
+		}
+		if maxValues > 1 {
+			menu.Placeholder = fmt.Sprintf("Pick up to %d options", maxValues)
+		}
+
+		for i := range p.Options {
+			menu.Options = append(menu.Options, &discordgo.SelectMenuOption{
+				Label: common.CutStringShort(optionLabel(p.Options, i), 100),
+				Value: strconv.Itoa(i),
+			})
+		}
+
+		rows = append(rows, discordgo.ActionsRow{Components: []discordgo.MessageComponent{menu}})
+	} else {
+		var row []discordgo.MessageComponent
+		for i := range p.Options {
+			row = append(row, discordgo.Button{
+				Label:    common.CutStringShort(optionLabel(p.Options, i), 80),
+				Style:    discordgo.SecondaryButton,
+				CustomID: customID(customIDVote, p.ID, i),
+			})
+
+			if len(row) == 5 {
+				rows = append(rows, discordgo.ActionsRow{Components: row})
+				row = nil
+			}
+		}
+
+		if len(row) > 0 {
+			rows = append(rows, discordgo.ActionsRow{Components: row})
+		}
+	}
+
+	rows = append(rows, discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+		discordgo.Button{
+			Label:    "My vote",
+			Style:    discordgo.PrimaryButton,
+			CustomID: customID(customIDMyVote, p.ID),
+		},
+		discordgo.Button{
+			Label:    "Remove my vote",
+			Style:    discordgo.DangerButton,
+			CustomID: customID(customIDClear, p.ID),
+		},
+	}})
+
+	return rows
+}
+
+// pollEmbed shows the current votes, or the final results with the voters of public polls once it's closed
+func pollEmbed(p *Poll, results *Results, votes []*Vote) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title: p.Question,
+		Color: pollColor,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Poll #%d • %s • %s", p.ID, p.Mode, visibilityString(p)),
+		},
+	}
+
+	if p.Closed {
+		embed.Color = closedColor
+	}
+
+	if p.ClosesAt.Valid {
+		embed.Timestamp = p.ClosesAt.Time.Format("2006-01-02T15:04:05Z07:00")
+		if p.Closed {
+			embed.Footer.Text += " • Closed"
+		} else {
+			embed.Footer.Text += " • Closes"
+		}
+	} else if p.Closed {
+		embed.Footer.Text += " • Closed"
+	}
+
+	var desc strings.Builder
+	if !p.Closed {
+		switch p.Mode {
+		case ModeMulti:
+			if p.MaxChoices > 0 {
+				desc.WriteString(fmt.Sprintf("*Pick up to %d options*\n\n", p.MaxChoices))
+			} else {
+				desc.WriteString("*Pick as many options as you like*\n\n")
+			}
+		case ModeRanked:
+			desc.WriteString("*Click the options in order of preference, the counts are first choices*\n\n")
+		}
+	}
+
+	for i := range p.Options {
+		count := results.Counts[i]
+		prefix := ""
+		if p.Closed && containsInt(results.Winners, i) {
+			prefix = "🏆 "
+		}
+
+		desc.WriteString(fmt.Sprintf("%s**%s**\n`%s` %d %s (%d%%)\n", prefix, optionLabel(p.Options, i), bar(count, results.Total), count, plural(count, "vote"), percentage(count, results.Total)))
+
+		if p.Closed && !p.Anonymous {
+			if voters := votersOf(p, votes, i); voters != "" {
+				desc.WriteString(voters + "\n")
+			}
+		}
+	}
+
+	embed.Description = common.CutStringShort(desc.String(), 4000)
+
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "Voters",
+		Value:  strconv.Itoa(results.Voters),
+		Inline: true,
+	})
+
+	if len(p.RequiredRoles) > 0 {
+		mentions := make([]string, len(p.RequiredRoles))
+		for i, v := range p.RequiredRoles {
+			mentions[i] = "<@&" + strconv.FormatInt(v, 10) + ">"
+		}
+
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "Open to",
+			Value:  strings.Join(mentions, ", "),
+			Inline: true,
+		})
+	}
+
+	if p.Closed && p.Mode == ModeRanked && len(results.Eliminated) > 0 {
+		var rounds strings.Builder
+		for i, eliminated := range results.Eliminated {
+			names := make([]string, len(eliminated))
+			for j, v := range eliminated {
+				names[j] = strconv.Itoa(v + 1)
+			}
+
+			rounds.WriteString(fmt.Sprintf("Round %d: eliminated %s\n", i+1, strings.Join(names, ", ")))
+		}
+
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  "Runoff rounds",
+			Value: common.CutStringShort(rounds.String(), 1000),
+		})
+	}
+
+	return embed
+}
+
+// resultsEmbed is posted when the poll closes, along with the chart
+func resultsEmbed(p *Poll, results *Results) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title: "Results: " + p.Question,
+		Color: closedColor,
+		URL:   fmt.Sprintf("https://discord.com/channels/%d/%d/%d", p.GuildID, p.ChannelID, p.MessageID),
+		Image: &discordgo.MessageEmbedImage{
+			URL: "attachment://results.png",
+		},
+	}
+
+	switch len(results.Winners) {
+	case 0:
+		embed.Description = "No one voted."
+	case 1:
+		embed.Description = fmt.Sprintf("**%s** won with %d of %d voters.", optionLabel(p.Options, results.Winners[0]), results.Counts[results.Winners[0]], results.Voters)
+	default:
+		names := make([]string, len(results.Winners))
+		for i, v := range results.Winners {
+			names[i] = "**" + optionLabel(p.Options, v) + "**"
+		}
+
+		embed.Description = "Tie between " + strings.Join(names, ", ") + "."
+	}
+
+	return embed
+}
+
+// votersOf lists the members that voted for the option, their first choice in ranked polls
+func votersOf(p *Poll, votes []*Vote, option int) string {
+	var mentions []string
+	count := 0
+	for _, v := range votes {
+		voted := containsInt(v.Choices, option)
+		if p.Mode == ModeRanked {
+			voted = len(v.Choices) > 0 && v.Choices[0] == option
+		}
+
+		if !voted {
+			continue
+		}
+
+		count++
+		if len(mentions) < maxVotersShown {
+			mentions = append(mentions, "<@"+strconv.FormatInt(v.UserID, 10)+">")
+		}
+	}
+
+	result := strings.Join(mentions, " ")
+	if count > len(mentions) {
+		result += fmt.Sprintf(" +%d", count-len(mentions))
+	}
+
+	return result
+}
+
+// describeVote is the reply to the member after voting
+func describeVote(p *Poll, choices []int) string {
+	if len(choices) < 1 {
+		return "You haven't voted in this poll."
+	}
+
+	names := make([]string, len(choices))
+	for i, v := range choices {
+		if v < len(p.Options) {
+			names[i] = "**" + optionLabel(p.Options, v) + "**"
+		}
+	}
+
+	if p.Mode == ModeRanked {
+		return "Your ranking: " + strings.Join(names, " > ")
+	}
+
+	return "Your vote: " + strings.Join(names, ", ")
+}
+
+func visibilityString(p *Poll) string {
+	if p.Anonymous {
+		return "Anonymous"
+	}
+
+	return "Public"
+}
+
+func bar(count, total int) string {
+	filled := 0
+	if total > 0 {
+		filled = (count*barLength + total/2) / total
+	}
+
+	return strings.Repeat("█", filled) + strings.Repeat("░", barLength-filled)
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+
+	return word + "s"
+}
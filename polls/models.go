@@ -0,0 +1,264 @@
+package polls
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/lib/pq"
+	"github.com/volatiletech/null/v8"
+)
+
+var ErrPollNotFound = errors.New("poll not found")
+
+// Mode is how many options members can vote for, and how the votes are counted
+type Mode int16
+
+const (
+	ModeSingle Mode = 0
+	ModeMulti  Mode = 1
+	// Ranked choice polls are counted with instant runoff voting
+	ModeRanked Mode = 2
+)
+
+func (m Mode) String() string {
+	switch m {
+	case ModeMulti:
+		return "Multiple choice"
+	case ModeRanked:
+		return "Ranked choice"
+	}
+
+	return "Single choice"
+}
+
+func ParseMode(s string) (Mode, bool) {
+	switch s {
+	case "", "single", "one":
+		return ModeSingle, true
+	case "multi", "multiple":
+		return ModeMulti, true
+	case "ranked", "rank":
+		return ModeRanked, true
+	}
+
+	return ModeSingle, false
+}
+
+// Input is the kind of components members vote with
+type Input int16
+
+const (
+	InputButtons Input = 0
+	InputSelect  Input = 1
+)
+
+type Poll struct {
+	ID        int64
+	GuildID   int64
+	ChannelID int64
+	MessageID int64
+	AuthorID  int64
+	CreatedAt time.Time
+
+	Question string
+	Options  pq.StringArray
+
+	Mode      Mode
+	Input     Input
+	Anonymous bool
+	// Max options a member can pick in multiple choice polls, 0 for no limit
+	MaxChoices    int
+	RequiredRoles pq.Int64Array
+
+	ClosesAt null.Time
+	Closed   bool
+}
+
+const pollColumns = `id, guild_id, channel_id, message_id, author_id, created_at, question, options, mode, input, anonymous, max_choices, required_roles, closes_at, closed`
+
+func (p *Poll) scanFields() []interface{} {
+	return []interface{}{&p.ID, &p.GuildID, &p.ChannelID, &p.MessageID, &p.AuthorID, &p.CreatedAt, &p.Question, &p.Options,
+		&p.Mode, &p.Input, &p.Anonymous, &p.MaxChoices, &p.RequiredRoles, &p.ClosesAt, &p.Closed}
+}
+
+// Insert creates the poll and sets its ID
+func (p *Poll) Insert(ctx context.Context) error {
+	const q = `INSERT INTO polls (guild_id, channel_id, message_id, author_id, created_at, question, options, mode, input, anonymous, max_choices, required_roles, closes_at, closed)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) RETURNING id`
+
+	if p.RequiredRoles == nil {
+		p.RequiredRoles = pq.Int64Array{}
+	}
+
+	return common.PQ.QueryRowContext(ctx, q, p.GuildID, p.ChannelID, p.MessageID, p.AuthorID, p.CreatedAt, p.Question, p.Options,
+		p.Mode, p.Input, p.Anonymous, p.MaxChoices, p.RequiredRoles, p.ClosesAt, p.Closed).Scan(&p.ID)
+}
+
+func (p *Poll) UpdateMessageID(ctx context.Context) error {
+	_, err := common.PQ.ExecContext(ctx, `UPDATE polls SET message_id = $2 WHERE id = $1`, p.ID, p.MessageID)
+	return err
+}
+
+// Delete removes the poll, used when its message couldn't be sent
+func (p *Poll) Delete(ctx context.Context) error {
+	_, err := common.PQ.ExecContext(ctx, `DELETE FROM polls WHERE id = $1`, p.ID)
+	return err
+}
+
+func getPoll(ctx context.Context, q string, args ...interface{}) (*Poll, error) {
+	p := &Poll{}
+	err := common.PQ.QueryRowContext(ctx, q, args...).Scan(p.scanFields()...)
+	if err == sql.ErrNoRows {
+		return nil, ErrPollNotFound
+	}
+
+	return p, err
+}
+
+func GetPoll(ctx context.Context, guildID, id int64) (*Poll, error) {
+	return getPoll(ctx, `SELECT `+pollColumns+` FROM polls WHERE guild_id = $1 AND id = $2`, guildID, id)
+}
+
+// GetPollByID returns a poll without checking the guild, only for polls referenced by the bot itself
+func GetPollByID(ctx context.Context, id int64) (*Poll, error) {
+	return getPoll(ctx, `SELECT `+pollColumns+` FROM polls WHERE id = $1`, id)
+}
+
+// ActivePolls returns the polls on the guild that haven't been closed yet
+func ActivePolls(ctx context.Context, guildID int64) ([]*Poll, error) {
+	rows, err := common.PQ.QueryContext(ctx, `SELECT `+pollColumns+` FROM polls WHERE guild_id = $1 AND NOT closed ORDER BY id ASC`, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*Poll
+	for rows.Next() {
+		p := &Poll{}
+		if err = rows.Scan(p.scanFields()...); err != nil {
+			return nil, err
+		}
+
+		result = append(result, p)
+	}
+
+	return result, rows.Err()
+}
+
+func CountActivePolls(ctx context.Context, guildID int64) (int, error) {
+	var count int
+	err := common.PQ.QueryRowContext(ctx, `SELECT count(*) FROM polls WHERE guild_id = $1 AND NOT closed`, guildID).Scan(&count)
+	return count, err
+}
+
+// MarkClosed closes the poll, returns false if it was already closed so it's only closed once
+func MarkClosed(ctx context.Context, id int64) (bool, error) {
+	res, err := common.PQ.ExecContext(ctx, `UPDATE polls SET closed = true WHERE id = $1 AND NOT closed`, id)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	return affected > 0, err
+}
+
+type Vote struct {
+	UserID  int64
+	Choices []int
+}
+
+// PollVotes returns all votes of the poll, in the order they were last changed
+func PollVotes(ctx context.Context, pollID int64) ([]*Vote, error) {
+	rows, err := common.PQ.QueryContext(ctx, `SELECT user_id, choices FROM poll_votes WHERE poll_id = $1 ORDER BY updated_at ASC`, pollID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*Vote
+	for rows.Next() {
+		var choices pq.Int64Array
+		v := &Vote{}
+		if err = rows.Scan(&v.UserID, &choices); err != nil {
+			return nil, err
+		}
+
+		v.Choices = toInts(choices)
+		result = append(result, v)
+	}
+
+	return result, rows.Err()
+}
+
+// UserVote returns the choices of the user, or nil if the user hasn't voted
+func UserVote(ctx context.Context, pollID, userID int64) ([]int, error) {
+	var choices pq.Int64Array
+	err := common.PQ.QueryRowContext(ctx, `SELECT choices FROM poll_votes WHERE poll_id = $1 AND user_id = $2`, pollID, userID).Scan(&choices)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return toInts(choices), err
+}
+
+// UpdateVote changes the vote of the user, fn returns the new choices from the current ones.
+// The vote is locked in the meantime so quick clicks on multiple buttons don't overwrite each other,
+// no choices removes the vote.
+func UpdateVote(ctx context.Context, pollID, userID int64, fn func(current []int) ([]int, error)) ([]int, error) {
+	tx, err := common.PQ.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var current pq.Int64Array
+	err = tx.QueryRowContext(ctx, `SELECT choices FROM poll_votes WHERE poll_id = $1 AND user_id = $2 FOR UPDATE`, pollID, userID).Scan(&current)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	choices, err := fn(toInts(current))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(choices) < 1 {
+		_, err = tx.ExecContext(ctx, `DELETE FROM poll_votes WHERE poll_id = $1 AND user_id = $2`, pollID, userID)
+	} else {
+		const q = `INSERT INTO poll_votes (poll_id, user_id, choices, updated_at) VALUES ($1, $2, $3, now())
+ON CONFLICT (poll_id, user_id) DO UPDATE SET choices = $3, updated_at = now()`
+
+		_, err = tx.ExecContext(ctx, q, pollID, userID, toInt64s(choices))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return choices, tx.Commit()
+}
+
+func toInts(a pq.Int64Array) []int {
+	if len(a) < 1 {
+		return nil
+	}
+
+	result := make([]int, len(a))
+	for i, v := range a {
+		result[i] = int(v)
+	}
+
+	return result
+}
+
+func toInt64s(a []int) pq.Int64Array {
+	result := make(pq.Int64Array, len(a))
+	for i, v := range a {
+		result[i] = int64(v)
+	}
+
+	return result
+}
@@ -0,0 +1,551 @@
+package polls
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/bot"
+	"github.com/botlabs-gg/yagpdb/v2/bot/eventsystem"
+	"github.com/botlabs-gg/yagpdb/v2/commands"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/scheduledevents2"
+	eventModels "github.com/botlabs-gg/yagpdb/v2/common/scheduledevents2/models"
+	"github.com/botlabs-gg/yagpdb/v2/lib/dcmd"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+	"github.com/botlabs-gg/yagpdb/v2/lib/dstate"
+	"github.com/volatiletech/null/v8"
+)
+
+const (
+	MaxActivePolls = 25
+
+	// Votes are shown on the poll message at most this often
+	updateDelay = time.Second * 3
+)
+
+var _ bot.BotInitHandler = (*Plugin)(nil)
+
+func (p *Plugin) BotInit() {
+	eventsystem.AddHandlerAsyncLastLegacy(p, p.handleInteractionCreate, eventsystem.EventInteractionCreate)
+	scheduledevents2.RegisterHandler("polls_close", int64(0), handleCloseEvent)
+}
+
+var _ commands.CommandProvider = (*Plugin)(nil)
+
+func (p *Plugin) AddCommands() {
+	catPolls := &dcmd.Category{
+		Name:        "Polls",
+		Description: "Poll commands",
+		HelpEmoji:   "📊",
+		EmbedColor:  pollColor,
+	}
+	container, _ := commands.CommandSystem.Root.Sub("polls")
+	container.NotFound = commands.CommonContainerNotFoundHandler(container, "")
+
+	args := []*dcmd.ArgDef{
+		{Name: "Question", Type: dcmd.String},
+	}
+	for i := 1; i <= MaxOptions; i++ {
+		args = append(args, &dcmd.ArgDef{Name: "Option" + strconv.Itoa(i), Type: dcmd.String})
+	}
+
+	cmdCreate := &commands.YAGCommand{
+		CmdCategory:  catPolls,
+		Name:         "Create",
+		Aliases:      []string{"new", "make"},
+		Description:  "Creates a poll members vote on with buttons. Example: `polls create \"favorite color?\" blue red pink -mode multi -duration 1d`",
+		Plugin:       p,
+		Arguments:    args,
+		RequiredArgs: 1 + MinOptions,
+		ArgSwitches: []*dcmd.ArgDef{
+			{Name: "mode", Help: "single, multi or ranked", Type: dcmd.String},
+			{Name: "select", Help: "Vote with a select menu instead of buttons, not for ranked polls"},
+			{Name: "anonymous", Help: "Don't show who voted for what when the poll closes"},
+			{Name: "max", Help: "Max options members can pick in multiple choice polls", Type: &dcmd.IntArg{Min: 1, Max: MaxOptions}},
+			{Name: "roles", Help: "Comma separated roles that can vote", Type: dcmd.String},
+			{Name: "duration", Help: "Closes the poll and posts the results after this long", Type: &commands.DurationArg{Min: time.Minute, Max: time.Hour * 24 * 30}},
+		},
+		RunFunc: p.cmdCreate,
+	}
+
+	cmdClose := &commands.YAGCommand{
+		CmdCategory:  catPolls,
+		Name:         "Close",
+		Aliases:      []string{"end"},
+		Description:  "Closes a poll and posts the results, only the author and members with manage messages can close polls",
+		Plugin:       p,
+		Arguments:    []*dcmd.ArgDef{{Name: "ID", Type: dcmd.Int}},
+		RequiredArgs: 1,
+		RunFunc: func(parsed *dcmd.Data) (interface{}, error) {
+			poll, err := GetPoll(parsed.Context(), parsed.GuildData.GS.ID, parsed.Args[0].Int64())
+			if err != nil {
+				if err == ErrPollNotFound {
+					return "Unknown poll", nil
+				}
+
+				return nil, err
+			}
+
+			if poll.AuthorID != parsed.Author.ID {
+				ok, err := bot.AdminOrPermMS(parsed.GuildData.GS.ID, poll.ChannelID, parsed.GuildData.MS, discordgo.PermissionManageMessages)
+				if err != nil {
+					return nil, err
+				}
+
+				if !ok {
+					return "Only the author of the poll and members with manage messages can close it", nil
+				}
+			}
+
+			if poll.Closed {
+				return "That poll is already closed", nil
+			}
+
+			closed, err := closePoll(poll)
+			if err != nil {
+				return nil, err
+			}
+
+			if !closed {
+				return "That poll is already closed", nil
+			}
+
+			return "Closed the poll", nil
+		},
+	}
+
+	cmdResults := &commands.YAGCommand{
+		CmdCategory:  catPolls,
+		Name:         "Results",
+		Description:  "Shows the current results of a poll",
+		Plugin:       p,
+		Arguments:    []*dcmd.ArgDef{{Name: "ID", Type: dcmd.Int}},
+		RequiredArgs: 1,
+		RunFunc: func(parsed *dcmd.Data) (interface{}, error) {
+			poll, err := GetPoll(parsed.Context(), parsed.GuildData.GS.ID, parsed.Args[0].Int64())
+			if err != nil {
+				if err == ErrPollNotFound {
+					return "Unknown poll", nil
+				}
+
+				return nil, err
+			}
+
+			results, _, err := tallyPoll(parsed.Context(), poll)
+			if err != nil {
+				return nil, err
+			}
+
+			msg, err := resultsMessage(poll, results)
+			if err != nil {
+				return nil, err
+			}
+
+			if !poll.Closed {
+				msg.Embeds[0].Title = "Current results: " + poll.Question
+			}
+
+			return msg, nil
+		},
+	}
+
+	cmdList := &commands.YAGCommand{
+		CmdCategory: catPolls,
+		Name:        "List",
+		Aliases:     []string{"ls"},
+		Description: "Lists the open polls on this server",
+		Plugin:      p,
+		RunFunc: func(parsed *dcmd.Data) (interface{}, error) {
+			polls, err := ActivePolls(parsed.Context(), parsed.GuildData.GS.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(polls) < 1 {
+				return "No open polls on this server, create one with `polls create`", nil
+			}
+
+			var out strings.Builder
+			for _, v := range polls {
+				out.WriteString(fmt.Sprintf("#%d: [%s](https://discord.com/channels/%d/%d/%d)", v.ID, common.CutStringShort(v.Question, 100), v.GuildID, v.ChannelID, v.MessageID))
+				if v.ClosesAt.Valid {
+					out.WriteString(fmt.Sprintf(" - closes <t:%d:R>", v.ClosesAt.Time.Unix()))
+				}
+				out.WriteString("\n")
+			}
+
+			return &discordgo.MessageEmbed{
+				Title:       "Open polls",
+				Description: out.String(),
+				Color:       pollColor,
+			}, nil
+		},
+	}
+
+	container.AddCommand(cmdCreate, cmdCreate.GetTrigger())
+	container.AddCommand(cmdClose, cmdClose.GetTrigger())
+	container.AddCommand(cmdResults, cmdResults.GetTrigger())
+	container.AddCommand(cmdList, cmdList.GetTrigger())
+	container.Description = "Create and manage polls"
+	commands.RegisterSlashCommandsContainer(container, true, func(gs *dstate.GuildSet) ([]int64, error) {
+		return nil, nil
+	})
+}
+
+func (p *Plugin) cmdCreate(parsed *dcmd.Data) (interface{}, error) {
+	count, err := CountActivePolls(parsed.Context(), parsed.GuildData.GS.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if count >= MaxActivePolls {
+		return fmt.Sprintf("Max %d open polls at a time, close some with `polls close`", MaxActivePolls), nil
+	}
+
+	var options []string
+	for _, v := range parsed.Args[1:] {
+		if s := strings.TrimSpace(v.Str()); s != "" {
+			options = append(options, s)
+		}
+	}
+
+	if len(options) < MinOptions {
+		return fmt.Sprintf("A poll needs at least %d options", MinOptions), nil
+	}
+
+	mode, ok := ParseMode(strings.ToLower(parsed.Switch("mode").Str()))
+	if !ok {
+		return "Unknown mode, use single, multi or ranked", nil
+	}
+
+	poll := &Poll{
+		GuildID:   parsed.GuildData.GS.ID,
+		ChannelID: parsed.ChannelID,
+		AuthorID:  parsed.Author.ID,
+		CreatedAt: time.Now(),
+		Question:  common.CutStringShort(parsed.Args[0].Str(), 256),
+		Options:   options,
+		Mode:      mode,
+		Anonymous: parsed.Switch("anonymous").Bool(),
+	}
+
+	if parsed.Switch("select").Bool() && mode != ModeRanked {
+		// ranked votes need the order of the picks, which select menus don't keep
+		poll.Input = InputSelect
+	}
+
+	if mode == ModeMulti && parsed.Switch("max").Value != nil {
+		poll.MaxChoices = parsed.Switch("max").Int()
+	}
+
+	if parsed.Switch("roles").Value != nil {
+		roles, err := commands.ParseRoleList(parsed.GuildData.GS, parsed.Switch("roles").Str())
+		if err != nil {
+			return nil, err
+		}
+
+		poll.RequiredRoles = roles
+	}
+
+	if parsed.Switch("duration").Value != nil {
+		poll.ClosesAt = null.TimeFrom(time.Now().Add(parsed.Switch("duration").Value.(time.Duration)))
+	}
+
+	// the buttons need the id of the poll, so it's created first and removed again if the message can't be sent
+	err = poll.Insert(parsed.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := common.BotSession.ChannelMessageSendComplex(parsed.ChannelID, &discordgo.MessageSend{
+		Embeds:          []*discordgo.MessageEmbed{pollEmbed(poll, Tally(poll.Mode, len(poll.Options), nil), nil)},
+		Components:      pollComponents(poll),
+		AllowedMentions: discordgo.AllowedMentions{},
+	})
+	if err != nil {
+		if delErr := poll.Delete(context.Background()); delErr != nil {
+			logger.WithError(delErr).WithField("guild", poll.GuildID).Error("failed removing poll that couldn't be sent")
+		}
+		return nil, err
+	}
+
+	poll.MessageID = msg.ID
+	err = poll.UpdateMessageID(parsed.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	if poll.ClosesAt.Valid {
+		err = scheduledevents2.ScheduleEvent("polls_close", poll.GuildID, poll.ClosesAt.Time, poll.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if parsed.TraditionalTriggerData != nil {
+		common.BotSession.ChannelMessageDelete(parsed.ChannelID, parsed.TraditionalTriggerData.Message.ID)
+	}
+
+	return nil, nil
+}
+
+// tallyPoll counts the current votes of the poll
+func tallyPoll(ctx context.Context, poll *Poll) (*Results, []*Vote, error) {
+	votes, err := PollVotes(ctx, poll.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ballots := make([][]int, len(votes))
+	for i, v := range votes {
+		ballots[i] = v.Choices
+	}
+
+	return Tally(poll.Mode, len(poll.Options), ballots), votes, nil
+}
+
+// resultsMessage is the results embed along with the chart
+func resultsMessage(poll *Poll, results *Results) (*discordgo.MessageSend, error) {
+	chart, err := RenderChart(poll.Question, poll.Options, results)
+	if err != nil {
+		return nil, err
+	}
+
+	return &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{resultsEmbed(poll, results)},
+		Files: []*discordgo.File{{
+			Name:        "results.png",
+			ContentType: "image/png",
+			Reader:      bytes.NewReader(chart),
+		}},
+		AllowedMentions: discordgo.AllowedMentions{},
+	}, nil
+}
+
+// closePoll closes the poll, shows the final results on the poll message and posts the chart.
+// Returns false if the poll was already closed.
+func closePoll(poll *Poll) (bool, error) {
+	closed, err := MarkClosed(context.Background(), poll.ID)
+	if err != nil || !closed {
+		return false, err
+	}
+
+	poll.Closed = true
+	results, votes, err := tallyPoll(context.Background(), poll)
+	if err != nil {
+		return true, err
+	}
+
+	_, err = common.BotSession.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		ID:              poll.MessageID,
+		Channel:         poll.ChannelID,
+		Embeds:          []*discordgo.MessageEmbed{pollEmbed(poll, results, votes)},
+		Components:      []discordgo.MessageComponent{},
+		AllowedMentions: discordgo.AllowedMentions{},
+	})
+	if err != nil {
+		logger.WithError(err).WithField("guild", poll.GuildID).Error("failed updating closed poll message")
+	}
+
+	msg, err := resultsMessage(poll, results)
+	if err != nil {
+		return true, err
+	}
+
+	msg.Reference = &discordgo.MessageReference{
+		ChannelID: poll.ChannelID,
+		MessageID: poll.MessageID,
+	}
+
+	_, err = common.BotSession.ChannelMessageSendComplex(poll.ChannelID, msg)
+	if code, _ := common.DiscordError(err); code == discordgo.ErrCodeUnknownMessage {
+		// the poll message was deleted, post the results without replying to it
+		msg, err = resultsMessage(poll, results)
+		if err != nil {
+			return true, err
+		}
+
+		_, err = common.BotSession.ChannelMessageSendComplex(poll.ChannelID, msg)
+	}
+
+	return true, err
+}
+
+func describeCloseEvent(evt *eventModels.ScheduledEvent, data interface{}) string {
+	return fmt.Sprintf("Close poll #%d", *data.(*int64))
+}
+
+func handleCloseEvent(evt *eventModels.ScheduledEvent, data interface{}) (retry bool, err error) {
+	pollID := *data.(*int64)
+
+	poll, err := GetPoll(context.Background(), evt.GuildID, pollID)
+	if err != nil {
+		if err == ErrPollNotFound {
+			return false, nil
+		}
+
+		return true, err
+	}
+
+	if poll.Closed {
+		return false, nil
+	}
+
+	_, err = closePoll(poll)
+	return bot.CheckDiscordErrRetry(err), err
+}
+
+func (p *Plugin) handleInteractionCreate(evt *eventsystem.EventData) {
+	ic := evt.InteractionCreate()
+	if ic.Type != discordgo.InteractionMessageComponent || ic.GuildID == 0 || ic.Member == nil {
+		return
+	}
+
+	data := ic.MessageComponentData()
+	action, pollID, option, ok := parseCustomID(data.CustomID)
+	if !ok {
+		return
+	}
+
+	poll, err := GetPoll(context.Background(), ic.GuildID, pollID)
+	if err != nil {
+		if err != ErrPollNotFound {
+			logger.WithError(err).WithField("guild", ic.GuildID).Error("failed retrieving poll")
+		}
+		return
+	}
+
+	userID := ic.Member.User.ID
+	reply := func(content string) {
+		common.BotSession.CreateInteractionResponse(ic.ID, ic.Token, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: content,
+				Flags:   uint64(discordgo.MessageFlagsEphemeral),
+			},
+		})
+	}
+
+	if action == customIDMyVote {
+		choices, err := UserVote(context.Background(), poll.ID, userID)
+		if err != nil {
+			logger.WithError(err).WithField("guild", ic.GuildID).Error("failed retrieving poll vote")
+			reply("Something went wrong, try again later.")
+			return
+		}
+
+		reply(describeVote(poll, choices))
+		return
+	}
+
+	if poll.Closed {
+		reply("This poll is closed.")
+		return
+	}
+
+	if len(poll.RequiredRoles) > 0 && !common.ContainsInt64SliceOneOf(ic.Member.Roles, poll.RequiredRoles) {
+		reply("This poll is only open to some roles, you can't vote in it.")
+		return
+	}
+
+	choices, err := UpdateVote(context.Background(), poll.ID, userID, func(current []int) ([]int, error) {
+		switch action {
+		case customIDVote:
+			if option >= len(poll.Options) {
+				return nil, VoteError("Invalid option.")
+			}
+
+			return ApplyChoice(poll.Mode, poll.MaxChoices, current, option)
+		case customIDSelect:
+			return ParseSelectChoices(poll.Mode, poll.MaxChoices, len(poll.Options), data.Values)
+		}
+
+		// remove the vote
+		return nil, nil
+	})
+
+	if err != nil {
+		if voteErr, ok := err.(VoteError); ok {
+			reply(string(voteErr))
+			return
+		}
+
+		logger.WithError(err).WithField("guild", ic.GuildID).Error("failed updating poll vote")
+		reply("Something went wrong, try again later.")
+		return
+	}
+
+	if len(choices) < 1 {
+		reply("Removed your vote.")
+	} else {
+		reply(describeVote(poll, choices))
+	}
+
+	queueMessageUpdate(poll.ID, poll.GuildID)
+}
+
+var (
+	pendingUpdates   = make(map[int64]bool)
+	pendingUpdatesMU sync.Mutex
+)
+
+// queueMessageUpdate shows the new votes on the poll message, at most once every few seconds
+func queueMessageUpdate(pollID, guildID int64) {
+	pendingUpdatesMU.Lock()
+	defer pendingUpdatesMU.Unlock()
+
+	if pendingUpdates[pollID] {
+		return
+	}
+
+	pendingUpdates[pollID] = true
+	time.AfterFunc(updateDelay, func() {
+		pendingUpdatesMU.Lock()
+		delete(pendingUpdates, pollID)
+		pendingUpdatesMU.Unlock()
+
+		err := updatePollMessage(pollID, guildID)
+		if err != nil {
+			logger.WithError(err).WithField("guild", guildID).Error("failed updating poll message")
+		}
+	})
+}
+
+func updatePollMessage(pollID, guildID int64) error {
+	poll, err := GetPoll(context.Background(), guildID, pollID)
+	if err != nil {
+		if err == ErrPollNotFound {
+			return nil
+		}
+
+		return err
+	}
+
+	if poll.Closed {
+		// closePoll shows the final results
+		return nil
+	}
+
+	results, votes, err := tallyPoll(context.Background(), poll)
+	if err != nil {
+		return err
+	}
+
+	_, err = common.BotSession.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		ID:              poll.MessageID,
+		Channel:         poll.ChannelID,
+		Embeds:          []*discordgo.MessageEmbed{pollEmbed(poll, results, votes)},
+		Components:      pollComponents(poll),
+		AllowedMentions: discordgo.AllowedMentions{},
+	})
+	if code, _ := common.DiscordError(err); code == discordgo.ErrCodeUnknownMessage {
+		err = nil
+	}
+
+	return err
+}
@@ -0,0 +1,26 @@
+package polls
+
+import (
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/scheduledevents2"
+)
+
+type Plugin struct{}
+
+func (p *Plugin) PluginInfo() *common.PluginInfo {
+	return &common.PluginInfo{
+		Name:     "Polls",
+		SysName:  "polls",
+		Category: common.PluginCategoryMisc,
+	}
+}
+
+var logger = common.GetPluginLogger(&Plugin{})
+
+func RegisterPlugin() {
+	common.InitSchemas("polls", DBSchemas...)
+
+	common.RegisterPlugin(&Plugin{})
+
+	scheduledevents2.RegisterDescriber("polls_close", int64(0), describeCloseEvent)
+}
@@ -0,0 +1,183 @@
+package polls
+
+import (
+	"bytes"
+	"image/png"
+	"reflect"
+	"testing"
+)
+
+func TestApplyChoice(t *testing.T) {
+	cases := []struct {
+		name       string
+		mode       Mode
+		maxChoices int
+		current    []int
+		option     int
+		expected   []int
+		err        bool
+	}{
+		{"single first vote", ModeSingle, 0, nil, 1, []int{1}, false},
+		{"single change", ModeSingle, 0, []int{1}, 2, []int{2}, false},
+		{"single unvote", ModeSingle, 0, []int{1}, 1, []int{}, false},
+		{"multi add", ModeMulti, 0, []int{0}, 2, []int{0, 2}, false},
+		{"multi remove", ModeMulti, 0, []int{0, 2}, 0, []int{2}, false},
+		{"multi limit", ModeMulti, 2, []int{0, 2}, 1, nil, true},
+		{"multi remove at limit", ModeMulti, 2, []int{0, 2}, 2, []int{0}, false},
+		{"ranked append", ModeRanked, 0, []int{2, 0}, 1, []int{2, 0, 1}, false},
+		{"ranked remove keeps order", ModeRanked, 0, []int{2, 0, 1}, 0, []int{2, 1}, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result, err := ApplyChoice(c.mode, c.maxChoices, c.current, c.option)
+			if c.err {
+				if _, ok := err.(VoteError); !ok {
+					t.Fatalf("expected a vote error, got %v", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(result, c.expected) {
+				t.Errorf("got %v, expected %v", result, c.expected)
+			}
+		})
+	}
+}
+
+func TestApplyChoiceDoesntModifyCurrent(t *testing.T) {
+	current := []int{0, 1, 2}
+	ApplyChoice(ModeRanked, 0, current, 1)
+	if !reflect.DeepEqual(current, []int{0, 1, 2}) {
+		t.Errorf("current choices were modified: %v", current)
+	}
+}
+
+func TestParseSelectChoices(t *testing.T) {
+	result, err := ParseSelectChoices(ModeMulti, 0, 4, []string{"3", "0", "3"})
+	if err != nil || !reflect.DeepEqual(result, []int{3, 0}) {
+		t.Errorf("got %v, %v, expected [3 0]", result, err)
+	}
+
+	for _, c := range []struct {
+		mode       Mode
+		maxChoices int
+		values     []string
+	}{
+		{ModeMulti, 0, []string{"4"}},
+		{ModeMulti, 0, []string{"-1"}},
+		{ModeMulti, 0, []string{"abc"}},
+		{ModeSingle, 0, []string{"0", "1"}},
+		{ModeMulti, 1, []string{"0", "1"}},
+	} {
+		if _, err := ParseSelectChoices(c.mode, c.maxChoices, 4, c.values); err == nil {
+			t.Errorf("expected an error for %v", c.values)
+		}
+	}
+}
+
+func TestTally(t *testing.T) {
+	results := Tally(ModeSingle, 3, [][]int{{0}, {1}, {1}, {}})
+	if !reflect.DeepEqual(results.Counts, []int{1, 2, 0}) || results.Voters != 3 || results.Total != 3 {
+		t.Errorf("unexpected single choice results: %+v", results)
+	}
+	if !reflect.DeepEqual(results.Winners, []int{1}) {
+		t.Errorf("got winners %v, expected [1]", results.Winners)
+	}
+
+	results = Tally(ModeMulti, 3, [][]int{{0, 1}, {1, 2}, {1}})
+	if !reflect.DeepEqual(results.Counts, []int{1, 3, 1}) || results.Voters != 3 || results.Total != 3 {
+		t.Errorf("unexpected multiple choice results: %+v", results)
+	}
+	if percentage(results.Counts[1], results.Total) != 100 {
+		t.Errorf("option picked by every voter should be at 100%%")
+	}
+
+	results = Tally(ModeSingle, 2, [][]int{{0}, {1}})
+	if !reflect.DeepEqual(results.Winners, []int{0, 1}) {
+		t.Errorf("got winners %v, expected a tie between [0 1]", results.Winners)
+	}
+
+	results = Tally(ModeSingle, 2, nil)
+	if len(results.Winners) != 0 || results.Voters != 0 {
+		t.Errorf("expected no winners without votes: %+v", results)
+	}
+}
+
+func TestTallyRanked(t *testing.T) {
+	// first round: 0 has 2, 1 has 2, 2 has 1, 2 is eliminated and its ballot goes to 1
+	ballots := [][]int{{0}, {0, 1}, {1}, {1, 0}, {2, 1}}
+	results := Tally(ModeRanked, 3, ballots)
+
+	if !reflect.DeepEqual(results.Winners, []int{1}) {
+		t.Fatalf("got winners %v, expected [1]", results.Winners)
+	}
+
+	if len(results.Rounds) != 2 || !reflect.DeepEqual(results.Rounds[0], []int{2, 2, 1}) || !reflect.DeepEqual(results.Counts, []int{2, 3, 0}) {
+		t.Errorf("unexpected rounds: %v", results.Rounds)
+	}
+
+	if !reflect.DeepEqual(results.Eliminated, [][]int{{2}}) {
+		t.Errorf("got eliminated %v, expected [[2]]", results.Eliminated)
+	}
+
+	if results.Voters != 5 {
+		t.Errorf("got %d voters, expected 5", results.Voters)
+	}
+
+	// outright majority in the first round
+	results = Tally(ModeRanked, 3, [][]int{{0, 1}, {0}, {2}})
+	if !reflect.DeepEqual(results.Winners, []int{0}) || len(results.Rounds) != 1 {
+		t.Errorf("expected 0 to win in the first round: %+v", results)
+	}
+
+	// exhausted ballots don't count in later rounds, which ends in a tie
+	results = Tally(ModeRanked, 3, [][]int{{0}, {0}, {1}, {1}, {2}})
+	if !reflect.DeepEqual(results.Winners, []int{0, 1}) || results.Total != 4 {
+		t.Errorf("expected a tie between 0 and 1 out of 4 votes: %+v", results)
+	}
+
+	results = Tally(ModeRanked, 3, nil)
+	if len(results.Winners) != 0 {
+		t.Errorf("expected no winners without votes: %+v", results)
+	}
+}
+
+func TestParseCustomID(t *testing.T) {
+	action, pollID, option, ok := parseCustomID(customID(customIDVote, 12, 3))
+	if !ok || action != customIDVote || pollID != 12 || option != 3 {
+		t.Errorf("got %s %d %d %v", action, pollID, option, ok)
+	}
+
+	action, pollID, option, ok = parseCustomID(customID(customIDSelect, 5))
+	if !ok || action != customIDSelect || pollID != 5 || option != -1 {
+		t.Errorf("got %s %d %d %v", action, pollID, option, ok)
+	}
+
+	for _, v := range []string{"", "polls_vote:1", "polls_vote:1:x", "polls_clear:1:2", "rsvp_accept:1", "polls_myvote:abc"} {
+		if _, _, _, ok := parseCustomID(v); ok {
+			t.Errorf("expected %q to be invalid", v)
+		}
+	}
+}
+
+func TestRenderChart(t *testing.T) {
+	options := []string{"Red", "A very long option that doesn't fit on the chart and has to be cut off somewhere along the way", "Blue"}
+	data, err := RenderChart("Favorite color?", options, Tally(ModeSingle, len(options), [][]int{{0}, {1}, {1}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if img.Bounds().Dx() != chartWidth || img.Bounds().Dy() != chartPadding*2+chartTitleHeight+chartRowHeight*len(options) {
+		t.Errorf("unexpected chart size %v", img.Bounds())
+	}
+}
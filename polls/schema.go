@@ -0,0 +1,39 @@
+package polls
+
+var DBSchemas = []string{`
+CREATE TABLE IF NOT EXISTS polls (
+	id BIGSERIAL PRIMARY KEY,
+	guild_id BIGINT NOT NULL,
+	channel_id BIGINT NOT NULL,
+	message_id BIGINT NOT NULL,
+	author_id BIGINT NOT NULL,
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	question TEXT NOT NULL,
+	options TEXT[] NOT NULL,
+
+	mode SMALLINT NOT NULL,
+	input SMALLINT NOT NULL,
+	anonymous BOOLEAN NOT NULL,
+	max_choices INT NOT NULL,
+	required_roles BIGINT[] NOT NULL,
+
+	closes_at TIMESTAMP WITH TIME ZONE,
+	closed BOOLEAN NOT NULL DEFAULT false
+);
+`, `
+CREATE INDEX IF NOT EXISTS polls_guild_idx ON polls(guild_id, closed);
+`, `
+CREATE INDEX IF NOT EXISTS polls_message_idx ON polls(message_id);
+`, `
+CREATE TABLE IF NOT EXISTS poll_votes (
+	poll_id BIGINT NOT NULL REFERENCES polls(id) ON DELETE CASCADE,
+	user_id BIGINT NOT NULL,
+
+	-- the chosen options, in order of preference for ranked choice polls
+	choices SMALLINT[] NOT NULL,
+	updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	PRIMARY KEY(poll_id, user_id)
+);
+`}
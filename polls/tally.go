@@ -0,0 +1,231 @@
+package polls
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// VoteError is shown to the member when a vote couldn't be applied
+type VoteError string
+
+func (v VoteError) Error() string {
+	return string(v)
+}
+
+const (
+	MinOptions = 2
+	MaxOptions = 10
+)
+
+// ApplyChoice returns the choices of a member after they clicked the button of the option.
+// Clicking a option that is already picked removes it, in ranked polls new options are added as the next preference.
+func ApplyChoice(mode Mode, maxChoices int, current []int, option int) ([]int, error) {
+	for i, v := range current {
+		if v == option {
+			return append(append([]int{}, current[:i]...), current[i+1:]...), nil
+		}
+	}
+
+	switch mode {
+	case ModeSingle:
+		return []int{option}, nil
+	case ModeMulti:
+		if maxChoices > 0 && len(current) >= maxChoices {
+			return nil, VoteError(fmt.Sprintf("You can pick at most %d options, remove one first.", maxChoices))
+		}
+	}
+
+	return append(append([]int{}, current...), option), nil
+}
+
+// ParseSelectChoices returns the choices from the values of a select menu
+func ParseSelectChoices(mode Mode, maxChoices, numOptions int, values []string) ([]int, error) {
+	result := make([]int, 0, len(values))
+	for _, v := range values {
+		option, err := strconv.Atoi(v)
+		if err != nil || option < 0 || option >= numOptions {
+			return nil, VoteError(fmt.Sprintf("Invalid option %q.", v))
+		}
+
+		if containsInt(result, option) {
+			continue
+		}
+
+		result = append(result, option)
+	}
+
+	if mode == ModeSingle && len(result) > 1 {
+		return nil, VoteError("You can only pick one option.")
+	}
+
+	if maxChoices > 0 && len(result) > maxChoices {
+		return nil, VoteError(fmt.Sprintf("You can pick at most %d options.", maxChoices))
+	}
+
+	return result, nil
+}
+
+// Results are the counted votes of a poll
+type Results struct {
+	// Votes per option, for ranked choice polls the votes in the last round
+	Counts []int
+	Voters int
+	// What the percentages are of, the voters in multiple choice polls and the counted votes otherwise
+	Total int
+
+	// Options with the most votes, more than one on a tie and none without any votes
+	Winners []int
+
+	// Ranked choice only, the votes per option in each round and the options eliminated in each round
+	Rounds     [][]int
+	Eliminated [][]int
+}
+
+// Tally counts the votes, each ballot is the choices of a member
+func Tally(mode Mode, numOptions int, ballots [][]int) *Results {
+	if mode == ModeRanked {
+		return tallyRanked(numOptions, ballots)
+	}
+
+	results := &Results{
+		Counts: make([]int, numOptions),
+	}
+
+	for _, b := range ballots {
+		counted := false
+		for _, option := range b {
+			if option < 0 || option >= numOptions {
+				continue
+			}
+
+			results.Counts[option]++
+			counted = true
+		}
+
+		if counted {
+			results.Voters++
+		}
+	}
+
+	results.Winners = mostVotes(results.Counts, nil)
+	if mode == ModeMulti {
+		results.Total = results.Voters
+	} else {
+		results.Total = sumInts(results.Counts)
+	}
+
+	return results
+}
+
+// tallyRanked counts the votes with instant runoff voting: each round every ballot counts for its most preferred option that's
+// still in the running, a option with more than half of the votes wins, otherwise the options with the least votes are eliminated.
+func tallyRanked(numOptions int, ballots [][]int) *Results {
+	results := &Results{}
+	eliminated := make([]bool, numOptions)
+
+	for {
+		counts := make([]int, numOptions)
+		active := 0
+		for _, b := range ballots {
+			for _, option := range b {
+				if option < 0 || option >= numOptions || eliminated[option] {
+					continue
+				}
+
+				counts[option]++
+				active++
+				break
+			}
+		}
+
+		if len(results.Rounds) == 0 {
+			results.Voters = active
+		}
+
+		results.Rounds = append(results.Rounds, counts)
+		results.Counts = counts
+		results.Total = active
+		if active == 0 {
+			return results
+		}
+
+		leaders := mostVotes(counts, eliminated)
+		if len(leaders) == 1 && counts[leaders[0]]*2 > active {
+			results.Winners = leaders
+			return results
+		}
+
+		// eliminate every option tied for the least votes
+		least := -1
+		remaining := 0
+		for i, c := range counts {
+			if eliminated[i] {
+				continue
+			}
+
+			remaining++
+			if least == -1 || c < least {
+				least = c
+			}
+		}
+
+		var toEliminate []int
+		for i, c := range counts {
+			if !eliminated[i] && c == least {
+				toEliminate = append(toEliminate, i)
+			}
+		}
+
+		if len(toEliminate) == remaining {
+			// everyone left is tied
+			results.Winners = toEliminate
+			return results
+		}
+
+		for _, v := range toEliminate {
+			eliminated[v] = true
+		}
+		results.Eliminated = append(results.Eliminated, toEliminate)
+	}
+}
+
+// mostVotes returns the options with the most votes, skipping the excluded ones
+func mostVotes(counts []int, excluded []bool) []int {
+	most := 0
+	var result []int
+	for i, c := range counts {
+		if (excluded != nil && excluded[i]) || c < 1 {
+			continue
+		}
+
+		if c > most {
+			most = c
+			result = result[:0]
+		}
+
+		if c == most {
+			result = append(result, i)
+		}
+	}
+
+	return result
+}
+
+func containsInt(s []int, v int) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sumInts(s []int) int {
+	sum := 0
+	for _, v := range s {
+		sum += v
+	}
+
+	return sum
+}
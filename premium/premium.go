@@ -66,6 +66,8 @@ func RegisterPlugin() {
 
 	scheduledevents2.RegisterHandler("premium_guild_added", nil, handleNewPremiumGuild)
 	scheduledevents2.RegisterHandler("premium_guild_removed", nil, handleRemovedPremiumGuild)
+	scheduledevents2.RegisterDescriber("premium_guild_added", nil, describeNewPremiumGuild)
+	scheduledevents2.RegisterDescriber("premium_guild_removed", nil, describeRemovedPremiumGuild)
 
 	for _, v := range PremiumSources {
 		v.Init()
@@ -204,6 +206,10 @@ func FindSource(sourceID string) PremiumSource {
 	return nil
 }
 
+func describeNewPremiumGuild(evt *schEventsModels.ScheduledEvent, data interface{}) string {
+	return "Enable the premium features of the server"
+}
+
 func handleNewPremiumGuild(evt *schEventsModels.ScheduledEvent, data interface{}) (retry bool, err error) {
 	for _, v := range common.Plugins {
 		if cast, ok := v.(NewPremiumGuildListener); ok {
@@ -217,6 +223,10 @@ func handleNewPremiumGuild(evt *schEventsModels.ScheduledEvent, data interface{}
 	return false, nil
 }
 
+func describeRemovedPremiumGuild(evt *schEventsModels.ScheduledEvent, data interface{}) string {
+	return "Disable the premium features of the server"
+}
+
 func handleRemovedPremiumGuild(evt *schEventsModels.ScheduledEvent, data interface{}) (retry bool, err error) {
 	for _, v := range common.Plugins {
 		if cast, ok := v.(RemovedPremiumGuildListener); ok {
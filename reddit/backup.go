@@ -0,0 +1,84 @@
+package reddit
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/pubsub"
+	"github.com/botlabs-gg/yagpdb/v2/reddit/models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+var _ common.PluginWithBackup = (*Plugin)(nil)
+
+func (p *Plugin) BackupVersion() int {
+	return 1
+}
+
+func (p *Plugin) ExportBackup(ctx context.Context, guildID int64) (interface{}, error) {
+	return models.RedditFeeds(models.RedditFeedWhere.GuildID.EQ(guildID), qm.OrderBy("id asc")).AllG(ctx)
+}
+
+func (p *Plugin) RestoreBackup(ctx context.Context, guildID int64, data json.RawMessage, restore *common.BackupRestore) error {
+	var feeds []*models.RedditFeed
+	err := json.Unmarshal(data, &feeds)
+	if err != nil {
+		return err
+	}
+
+	maxFeeds := MaxFeedForCtx(ctx)
+	if len(feeds) > maxFeeds {
+		restore.Note("Only the first %d of %d feeds were restored", maxFeeds, len(feeds))
+		feeds = feeds[:maxFeeds]
+	}
+
+	tx, err := common.PQ.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	oldFeeds, err := models.RedditFeeds(models.RedditFeedWhere.GuildID.EQ(guildID)).All(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = oldFeeds.DeleteAll(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for _, v := range feeds {
+		v.ID = 0
+		v.GuildID = guildID
+		v.ChannelID = restore.Channel(v.ChannelID)
+
+		if v.ChannelID == 0 && !v.Disabled {
+			restore.Note("The channel of the /r/%s feed was not found, it was disabled", v.Subreddit)
+			v.Disabled = true
+		}
+
+		err = v.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	err = restore.Finish(tx)
+	if err != nil || restore.DryRun {
+		return err
+	}
+
+	for _, v := range append(oldFeeds, feeds...) {
+		go pubsub.Publish("reddit_clear_subreddit_cache", -1, PubSubSubredditEventData{
+			Subreddit: v.Subreddit,
+			Slow:      v.Slow,
+		})
+	}
+
+	return nil
+}
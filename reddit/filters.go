@@ -0,0 +1,121 @@
+package reddit
+
+import (
+	"html"
+	"strings"
+
+	"github.com/botlabs-gg/yagpdb/v2/lib/go-reddit"
+	"github.com/botlabs-gg/yagpdb/v2/reddit/models"
+)
+
+const (
+	// Max entries in each of the include/exclude filter lists
+	MaxFilterEntries = 50
+	// Max length of a single filter entry
+	MaxFilterEntryLength = 100
+)
+
+// PostMatchesFilters returns true if the post passes the nsfw, spoiler, flair, keyword and author filters of the feed
+func PostMatchesFilters(feed *models.RedditFeed, post *reddit.Link) bool {
+	if post.Over18 && feed.FilterNSFW == FilterNSFWIgnore {
+		// NSFW and we ignore nsfw posts
+		return false
+	} else if !post.Over18 && feed.FilterNSFW == FilterNSFWRequire {
+		// Not NSFW and we only care about nsfw posts
+		return false
+	}
+
+	if post.Spoiler && feed.FilterSpoilers == FilterSpoilersIgnore {
+		return false
+	} else if !post.Spoiler && feed.FilterSpoilers == FilterSpoilersRequire {
+		return false
+	}
+
+	flair := strings.TrimSpace(html.UnescapeString(post.LinkFlairText))
+	if len(feed.IncludeFlairs) > 0 && !equalsAny(flair, feed.IncludeFlairs) {
+		return false
+	}
+	if flair != "" && equalsAny(flair, feed.ExcludeFlairs) {
+		return false
+	}
+
+	if len(feed.IncludeAuthors) > 0 && !equalsAny(post.Author, feed.IncludeAuthors) {
+		return false
+	}
+	if equalsAny(post.Author, feed.ExcludeAuthors) {
+		return false
+	}
+
+	if len(feed.IncludeKeywords) > 0 || len(feed.ExcludeKeywords) > 0 {
+		text := strings.ToLower(postSearchText(post))
+		if len(feed.IncludeKeywords) > 0 && !containsAny(text, feed.IncludeKeywords) {
+			return false
+		}
+		if containsAny(text, feed.ExcludeKeywords) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// postSearchText returns the text keyword filters are matched against
+func postSearchText(post *reddit.Link) string {
+	text := html.UnescapeString(post.Title) + "\n" + html.UnescapeString(post.Selftext)
+	if len(post.CrosspostParentList) > 0 {
+		parent := post.CrosspostParentList[0]
+		text += "\n" + html.UnescapeString(parent.Title) + "\n" + html.UnescapeString(parent.Selftext)
+	}
+
+	return text
+}
+
+func equalsAny(s string, list []string) bool {
+	for _, v := range list {
+		if strings.EqualFold(s, v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// containsAny expects text to already be lower case
+func containsAny(text string, keywords []string) bool {
+	for _, v := range keywords {
+		if v != "" && strings.Contains(text, strings.ToLower(v)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseFilterList splits a comma or newline separated list of filter entries, removing empty and duplicate entries
+func ParseFilterList(input string, authors bool) []string {
+	fields := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || r == '\n' || r == '\r'
+	})
+
+	result := make([]string, 0, len(fields))
+	for _, v := range fields {
+		v = strings.TrimSpace(v)
+		if authors {
+			v = strings.TrimPrefix(v, "/")
+			if len(v) > 2 && strings.EqualFold(v[:2], "u/") {
+				v = v[2:]
+			}
+		}
+
+		if v == "" || len(v) > MaxFilterEntryLength || equalsAny(v, result) {
+			continue
+		}
+
+		result = append(result, v)
+		if len(result) >= MaxFilterEntries {
+			break
+		}
+	}
+
+	return result
+}
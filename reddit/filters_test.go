@@ -0,0 +1,84 @@
+package reddit
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/botlabs-gg/yagpdb/v2/lib/go-reddit"
+	"github.com/botlabs-gg/yagpdb/v2/reddit/models"
+)
+
+func TestPostMatchesFilters(t *testing.T) {
+	post := &reddit.Link{
+		Title:         "New patch notes released",
+		Selftext:      "Balance changes and bug fixes",
+		Author:        "GameDev",
+		LinkFlairText: "Official",
+	}
+
+	nsfwPost := &reddit.Link{Title: "something", Author: "someone", Over18: true, Spoiler: true}
+
+	cases := []struct {
+		name     string
+		feed     *models.RedditFeed
+		post     *reddit.Link
+		expected bool
+	}{
+		{"no filters", &models.RedditFeed{}, post, true},
+		{"include flair", &models.RedditFeed{IncludeFlairs: []string{"official"}}, post, true},
+		{"include other flair", &models.RedditFeed{IncludeFlairs: []string{"meme"}}, post, false},
+		{"exclude flair", &models.RedditFeed{ExcludeFlairs: []string{"OFFICIAL"}}, post, false},
+		{"include flair without flair", &models.RedditFeed{IncludeFlairs: []string{"official"}}, nsfwPost, false},
+		{"include keyword title", &models.RedditFeed{IncludeKeywords: []string{"patch"}}, post, true},
+		{"include keyword selftext", &models.RedditFeed{IncludeKeywords: []string{"BUG FIX"}}, post, true},
+		{"include keyword missing", &models.RedditFeed{IncludeKeywords: []string{"leak"}}, post, false},
+		{"exclude keyword", &models.RedditFeed{ExcludeKeywords: []string{"leak", "balance"}}, post, false},
+		{"include author", &models.RedditFeed{IncludeAuthors: []string{"gamedev"}}, post, true},
+		{"include other author", &models.RedditFeed{IncludeAuthors: []string{"someone"}}, post, false},
+		{"exclude author", &models.RedditFeed{ExcludeAuthors: []string{"GameDev"}}, post, false},
+		{"ignore nsfw", &models.RedditFeed{FilterNSFW: FilterNSFWIgnore}, nsfwPost, false},
+		{"require nsfw", &models.RedditFeed{FilterNSFW: FilterNSFWRequire}, post, false},
+		{"ignore spoilers", &models.RedditFeed{FilterSpoilers: FilterSpoilersIgnore}, nsfwPost, false},
+		{"require spoilers", &models.RedditFeed{FilterSpoilers: FilterSpoilersRequire}, post, false},
+		{"require spoilers match", &models.RedditFeed{FilterSpoilers: FilterSpoilersRequire}, nsfwPost, true},
+	}
+
+	for _, c := range cases {
+		if got := PostMatchesFilters(c.feed, c.post); got != c.expected {
+			t.Errorf("%s: got %v, expected %v", c.name, got, c.expected)
+		}
+	}
+}
+
+func TestParseFilterList(t *testing.T) {
+	cases := []struct {
+		input    string
+		authors  bool
+		expected []string
+	}{
+		{"", false, []string{}},
+		{"a, b,,c\nd", false, []string{"a", "b", "c", "d"}},
+		{"News, news, NEWS", false, []string{"News"}},
+		{"u/spez, /u/kn0thing, AutoModerator", true, []string{"spez", "kn0thing", "AutoModerator"}},
+		{"u/spez", false, []string{"u/spez"}},
+	}
+
+	for _, c := range cases {
+		if got := ParseFilterList(c.input, c.authors); !reflect.DeepEqual(got, c.expected) {
+			t.Errorf("%q: got %#v, expected %#v", c.input, got, c.expected)
+		}
+	}
+}
+
+func TestParsePendingScoreMember(t *testing.T) {
+	feedID, postID, ok := parsePendingScoreMember(pendingScoreMember(123, "abc12"))
+	if !ok || feedID != 123 || postID != "abc12" {
+		t.Errorf("unexpected result: %d %q %v", feedID, postID, ok)
+	}
+
+	for _, v := range []string{"", "123", "123:", "abc:def"} {
+		if _, _, ok := parsePendingScoreMember(v); ok {
+			t.Errorf("%q should not parse", v)
+		}
+	}
+}
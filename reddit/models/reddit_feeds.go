@@ -18,21 +18,33 @@ import (
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
 	"github.com/volatiletech/strmangle"
 )
 
 // RedditFeed is an object representing the database table.
 type RedditFeed struct {
-	ID              int64  `boil:"id" json:"id" toml:"id" yaml:"id"`
-	GuildID         int64  `boil:"guild_id" json:"guild_id" toml:"guild_id" yaml:"guild_id"`
-	ChannelID       int64  `boil:"channel_id" json:"channel_id" toml:"channel_id" yaml:"channel_id"`
-	Subreddit       string `boil:"subreddit" json:"subreddit" toml:"subreddit" yaml:"subreddit"`
-	FilterNSFW      int    `boil:"filter_nsfw" json:"filter_nsfw" toml:"filter_nsfw" yaml:"filter_nsfw"`
-	MinUpvotes      int    `boil:"min_upvotes" json:"min_upvotes" toml:"min_upvotes" yaml:"min_upvotes"`
-	UseEmbeds       bool   `boil:"use_embeds" json:"use_embeds" toml:"use_embeds" yaml:"use_embeds"`
-	Slow            bool   `boil:"slow" json:"slow" toml:"slow" yaml:"slow"`
-	Disabled        bool   `boil:"disabled" json:"disabled" toml:"disabled" yaml:"disabled"`
-	SpoilersEnabled bool   `boil:"spoilers_enabled" json:"spoilers_enabled" toml:"spoilers_enabled" yaml:"spoilers_enabled"`
+	ID              int64             `boil:"id" json:"id" toml:"id" yaml:"id"`
+	GuildID         int64             `boil:"guild_id" json:"guild_id" toml:"guild_id" yaml:"guild_id"`
+	ChannelID       int64             `boil:"channel_id" json:"channel_id" toml:"channel_id" yaml:"channel_id"`
+	Subreddit       string            `boil:"subreddit" json:"subreddit" toml:"subreddit" yaml:"subreddit"`
+	FilterNSFW      int               `boil:"filter_nsfw" json:"filter_nsfw" toml:"filter_nsfw" yaml:"filter_nsfw"`
+	MinUpvotes      int               `boil:"min_upvotes" json:"min_upvotes" toml:"min_upvotes" yaml:"min_upvotes"`
+	UseEmbeds       bool              `boil:"use_embeds" json:"use_embeds" toml:"use_embeds" yaml:"use_embeds"`
+	Slow            bool              `boil:"slow" json:"slow" toml:"slow" yaml:"slow"`
+	Disabled        bool              `boil:"disabled" json:"disabled" toml:"disabled" yaml:"disabled"`
+	SpoilersEnabled bool              `boil:"spoilers_enabled" json:"spoilers_enabled" toml:"spoilers_enabled" yaml:"spoilers_enabled"`
+	MessageTemplate string            `boil:"message_template" json:"message_template" toml:"message_template" yaml:"message_template"`
+	IncludeFlairs   types.StringArray `boil:"include_flairs" json:"include_flairs" toml:"include_flairs" yaml:"include_flairs"`
+	ExcludeFlairs   types.StringArray `boil:"exclude_flairs" json:"exclude_flairs" toml:"exclude_flairs" yaml:"exclude_flairs"`
+	IncludeKeywords types.StringArray `boil:"include_keywords" json:"include_keywords" toml:"include_keywords" yaml:"include_keywords"`
+	ExcludeKeywords types.StringArray `boil:"exclude_keywords" json:"exclude_keywords" toml:"exclude_keywords" yaml:"exclude_keywords"`
+	IncludeAuthors  types.StringArray `boil:"include_authors" json:"include_authors" toml:"include_authors" yaml:"include_authors"`
+	ExcludeAuthors  types.StringArray `boil:"exclude_authors" json:"exclude_authors" toml:"exclude_authors" yaml:"exclude_authors"`
+	FilterSpoilers  int               `boil:"filter_spoilers" json:"filter_spoilers" toml:"filter_spoilers" yaml:"filter_spoilers"`
+	NSFWHideMedia   bool              `boil:"nsfw_hide_media" json:"nsfw_hide_media" toml:"nsfw_hide_media" yaml:"nsfw_hide_media"`
+	ScoreWaitHours  int               `boil:"score_wait_hours" json:"score_wait_hours" toml:"score_wait_hours" yaml:"score_wait_hours"`
+	TemplateError   string            `boil:"template_error" json:"template_error" toml:"template_error" yaml:"template_error"`
 
 	R *redditFeedR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L redditFeedL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -49,6 +61,17 @@ var RedditFeedColumns = struct {
 	Slow            string
 	Disabled        string
 	SpoilersEnabled string
+	MessageTemplate string
+	IncludeFlairs   string
+	ExcludeFlairs   string
+	IncludeKeywords string
+	ExcludeKeywords string
+	IncludeAuthors  string
+	ExcludeAuthors  string
+	FilterSpoilers  string
+	NSFWHideMedia   string
+	ScoreWaitHours  string
+	TemplateError   string
 }{
 	ID:              "id",
 	GuildID:         "guild_id",
@@ -60,6 +83,17 @@ var RedditFeedColumns = struct {
 	Slow:            "slow",
 	Disabled:        "disabled",
 	SpoilersEnabled: "spoilers_enabled",
+	MessageTemplate: "message_template",
+	IncludeFlairs:   "include_flairs",
+	ExcludeFlairs:   "exclude_flairs",
+	IncludeKeywords: "include_keywords",
+	ExcludeKeywords: "exclude_keywords",
+	IncludeAuthors:  "include_authors",
+	ExcludeAuthors:  "exclude_authors",
+	FilterSpoilers:  "filter_spoilers",
+	NSFWHideMedia:   "nsfw_hide_media",
+	ScoreWaitHours:  "score_wait_hours",
+	TemplateError:   "template_error",
 }
 
 var RedditFeedTableColumns = struct {
@@ -73,6 +107,17 @@ var RedditFeedTableColumns = struct {
 	Slow            string
 	Disabled        string
 	SpoilersEnabled string
+	MessageTemplate string
+	IncludeFlairs   string
+	ExcludeFlairs   string
+	IncludeKeywords string
+	ExcludeKeywords string
+	IncludeAuthors  string
+	ExcludeAuthors  string
+	FilterSpoilers  string
+	NSFWHideMedia   string
+	ScoreWaitHours  string
+	TemplateError   string
 }{
 	ID:              "reddit_feeds.id",
 	GuildID:         "reddit_feeds.guild_id",
@@ -84,6 +129,17 @@ var RedditFeedTableColumns = struct {
 	Slow:            "reddit_feeds.slow",
 	Disabled:        "reddit_feeds.disabled",
 	SpoilersEnabled: "reddit_feeds.spoilers_enabled",
+	MessageTemplate: "reddit_feeds.message_template",
+	IncludeFlairs:   "reddit_feeds.include_flairs",
+	ExcludeFlairs:   "reddit_feeds.exclude_flairs",
+	IncludeKeywords: "reddit_feeds.include_keywords",
+	ExcludeKeywords: "reddit_feeds.exclude_keywords",
+	IncludeAuthors:  "reddit_feeds.include_authors",
+	ExcludeAuthors:  "reddit_feeds.exclude_authors",
+	FilterSpoilers:  "reddit_feeds.filter_spoilers",
+	NSFWHideMedia:   "reddit_feeds.nsfw_hide_media",
+	ScoreWaitHours:  "reddit_feeds.score_wait_hours",
+	TemplateError:   "reddit_feeds.template_error",
 }
 
 // Generated where
@@ -170,6 +226,27 @@ func (w whereHelperbool) LTE(x bool) qm.QueryMod { return qmhelper.Where(w.field
 func (w whereHelperbool) GT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
 func (w whereHelperbool) GTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
 
+type whereHelpertypes_StringArray struct{ field string }
+
+func (w whereHelpertypes_StringArray) EQ(x types.StringArray) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.EQ, x)
+}
+func (w whereHelpertypes_StringArray) NEQ(x types.StringArray) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+}
+func (w whereHelpertypes_StringArray) LT(x types.StringArray) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpertypes_StringArray) LTE(x types.StringArray) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpertypes_StringArray) GT(x types.StringArray) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpertypes_StringArray) GTE(x types.StringArray) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
 var RedditFeedWhere = struct {
 	ID              whereHelperint64
 	GuildID         whereHelperint64
@@ -181,6 +258,17 @@ var RedditFeedWhere = struct {
 	Slow            whereHelperbool
 	Disabled        whereHelperbool
 	SpoilersEnabled whereHelperbool
+	MessageTemplate whereHelperstring
+	IncludeFlairs   whereHelpertypes_StringArray
+	ExcludeFlairs   whereHelpertypes_StringArray
+	IncludeKeywords whereHelpertypes_StringArray
+	ExcludeKeywords whereHelpertypes_StringArray
+	IncludeAuthors  whereHelpertypes_StringArray
+	ExcludeAuthors  whereHelpertypes_StringArray
+	FilterSpoilers  whereHelperint
+	NSFWHideMedia   whereHelperbool
+	ScoreWaitHours  whereHelperint
+	TemplateError   whereHelperstring
 }{
 	ID:              whereHelperint64{field: "\"reddit_feeds\".\"id\""},
 	GuildID:         whereHelperint64{field: "\"reddit_feeds\".\"guild_id\""},
@@ -192,6 +280,17 @@ var RedditFeedWhere = struct {
 	Slow:            whereHelperbool{field: "\"reddit_feeds\".\"slow\""},
 	Disabled:        whereHelperbool{field: "\"reddit_feeds\".\"disabled\""},
 	SpoilersEnabled: whereHelperbool{field: "\"reddit_feeds\".\"spoilers_enabled\""},
+	MessageTemplate: whereHelperstring{field: "\"reddit_feeds\".\"message_template\""},
+	IncludeFlairs:   whereHelpertypes_StringArray{field: "\"reddit_feeds\".\"include_flairs\""},
+	ExcludeFlairs:   whereHelpertypes_StringArray{field: "\"reddit_feeds\".\"exclude_flairs\""},
+	IncludeKeywords: whereHelpertypes_StringArray{field: "\"reddit_feeds\".\"include_keywords\""},
+	ExcludeKeywords: whereHelpertypes_StringArray{field: "\"reddit_feeds\".\"exclude_keywords\""},
+	IncludeAuthors:  whereHelpertypes_StringArray{field: "\"reddit_feeds\".\"include_authors\""},
+	ExcludeAuthors:  whereHelpertypes_StringArray{field: "\"reddit_feeds\".\"exclude_authors\""},
+	FilterSpoilers:  whereHelperint{field: "\"reddit_feeds\".\"filter_spoilers\""},
+	NSFWHideMedia:   whereHelperbool{field: "\"reddit_feeds\".\"nsfw_hide_media\""},
+	ScoreWaitHours:  whereHelperint{field: "\"reddit_feeds\".\"score_wait_hours\""},
+	TemplateError:   whereHelperstring{field: "\"reddit_feeds\".\"template_error\""},
 }
 
 // RedditFeedRels is where relationship names are stored.
@@ -211,9 +310,9 @@ func (*redditFeedR) NewStruct() *redditFeedR {
 type redditFeedL struct{}
 
 var (
-	redditFeedAllColumns            = []string{"id", "guild_id", "channel_id", "subreddit", "filter_nsfw", "min_upvotes", "use_embeds", "slow", "disabled", "spoilers_enabled"}
-	redditFeedColumnsWithoutDefault = []string{"guild_id", "channel_id", "subreddit", "filter_nsfw", "min_upvotes", "use_embeds", "slow"}
-	redditFeedColumnsWithDefault    = []string{"id", "disabled", "spoilers_enabled"}
+	redditFeedAllColumns            = []string{"id", "guild_id", "channel_id", "subreddit", "filter_nsfw", "min_upvotes", "use_embeds", "slow", "disabled", "spoilers_enabled", "message_template", "include_flairs", "exclude_flairs", "include_keywords", "exclude_keywords", "include_authors", "exclude_authors", "filter_spoilers", "nsfw_hide_media", "score_wait_hours", "template_error"}
+	redditFeedColumnsWithoutDefault = []string{"guild_id", "channel_id", "subreddit", "filter_nsfw", "min_upvotes", "use_embeds", "slow", "message_template", "include_flairs", "exclude_flairs", "include_keywords", "exclude_keywords", "include_authors", "exclude_authors", "filter_spoilers", "nsfw_hide_media", "score_wait_hours"}
+	redditFeedColumnsWithDefault    = []string{"id", "disabled", "spoilers_enabled", "template_error"}
 	redditFeedPrimaryKeyColumns     = []string{"id"}
 	redditFeedGeneratedColumns      = []string{}
 )
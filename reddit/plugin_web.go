@@ -40,16 +40,36 @@ type CreateForm struct {
 	NSFWMode        int    `schema:"nsfw_filter"`
 	SpoilersEnabled bool   `schema:"spoilers_enabled"`
 	MinUpvotes      int    `schema:"min_upvotes" valid:"0,"`
+	MessageTemplate string `schema:"message_template" valid:"template,5000"`
+	IncludeFlairs   string `schema:"include_flairs" valid:",0,2000"`
+	ExcludeFlairs   string `schema:"exclude_flairs" valid:",0,2000"`
+	IncludeKeywords string `schema:"include_keywords" valid:",0,2000"`
+	ExcludeKeywords string `schema:"exclude_keywords" valid:",0,2000"`
+	IncludeAuthors  string `schema:"include_authors" valid:",0,2000"`
+	ExcludeAuthors  string `schema:"exclude_authors" valid:",0,2000"`
+	SpoilerMode     int    `schema:"spoiler_filter" valid:"0,2"`
+	NSFWHideMedia   bool   `schema:"nsfw_hide_media"`
+	ScoreWaitHours  int    `schema:"score_wait_hours" valid:"0,24"`
 }
 
 type UpdateForm struct {
-	Channel         int64 `schema:"channel" valid:"channel,true"`
-	ID              int64 `schema:"id"`
-	UseEmbeds       bool  `schema:"use_embeds"`
-	NSFWMode        int   `schema:"nsfw_filter"`
-	SpoilersEnabled bool  `schema:"spoilers_enabled"`
-	MinUpvotes      int   `schema:"min_upvotes" valid:"0,"`
-	FeedEnabled     bool  `schema:"feed_enabled"`
+	Channel         int64  `schema:"channel" valid:"channel,true"`
+	ID              int64  `schema:"id"`
+	UseEmbeds       bool   `schema:"use_embeds"`
+	NSFWMode        int    `schema:"nsfw_filter"`
+	SpoilersEnabled bool   `schema:"spoilers_enabled"`
+	MinUpvotes      int    `schema:"min_upvotes" valid:"0,"`
+	FeedEnabled     bool   `schema:"feed_enabled"`
+	MessageTemplate string `schema:"message_template" valid:"template,5000"`
+	IncludeFlairs   string `schema:"include_flairs" valid:",0,2000"`
+	ExcludeFlairs   string `schema:"exclude_flairs" valid:",0,2000"`
+	IncludeKeywords string `schema:"include_keywords" valid:",0,2000"`
+	ExcludeKeywords string `schema:"exclude_keywords" valid:",0,2000"`
+	IncludeAuthors  string `schema:"include_authors" valid:",0,2000"`
+	ExcludeAuthors  string `schema:"exclude_authors" valid:",0,2000"`
+	SpoilerMode     int    `schema:"spoiler_filter" valid:"0,2"`
+	NSFWHideMedia   bool   `schema:"nsfw_hide_media"`
+	ScoreWaitHours  int    `schema:"score_wait_hours" valid:"0,24"`
 }
 
 var (
@@ -144,11 +164,21 @@ func HandleNew(w http.ResponseWriter, r *http.Request) interface{} {
 		FilterNSFW:      newElem.NSFWMode,
 		SpoilersEnabled: newElem.SpoilersEnabled,
 		Disabled:        false,
+		MessageTemplate: strings.TrimSpace(newElem.MessageTemplate),
+		IncludeFlairs:   ParseFilterList(newElem.IncludeFlairs, false),
+		ExcludeFlairs:   ParseFilterList(newElem.ExcludeFlairs, false),
+		IncludeKeywords: ParseFilterList(newElem.IncludeKeywords, false),
+		ExcludeKeywords: ParseFilterList(newElem.ExcludeKeywords, false),
+		IncludeAuthors:  ParseFilterList(newElem.IncludeAuthors, true),
+		ExcludeAuthors:  ParseFilterList(newElem.ExcludeAuthors, true),
+		FilterSpoilers:  newElem.SpoilerMode,
+		NSFWHideMedia:   newElem.NSFWHideMedia,
 	}
 
 	if newElem.Slow {
 		watchItem.Slow = true
 		watchItem.MinUpvotes = newElem.MinUpvotes
+		watchItem.ScoreWaitHours = newElem.ScoreWaitHours
 	}
 
 	err := watchItem.InsertG(ctx, boil.Infer())
@@ -196,11 +226,26 @@ func HandleModify(w http.ResponseWriter, r *http.Request) interface{} {
 	item.FilterNSFW = updated.NSFWMode
 	item.SpoilersEnabled = updated.SpoilersEnabled
 	item.Disabled = !updated.FeedEnabled
+	if newTemplate := strings.TrimSpace(updated.MessageTemplate); newTemplate != item.MessageTemplate {
+		item.MessageTemplate = newTemplate
+		item.TemplateError = ""
+	}
+	item.IncludeFlairs = ParseFilterList(updated.IncludeFlairs, false)
+	item.ExcludeFlairs = ParseFilterList(updated.ExcludeFlairs, false)
+	item.IncludeKeywords = ParseFilterList(updated.IncludeKeywords, false)
+	item.ExcludeKeywords = ParseFilterList(updated.ExcludeKeywords, false)
+	item.IncludeAuthors = ParseFilterList(updated.IncludeAuthors, true)
+	item.ExcludeAuthors = ParseFilterList(updated.ExcludeAuthors, true)
+	item.FilterSpoilers = updated.SpoilerMode
+	item.NSFWHideMedia = updated.NSFWHideMedia
 	if item.Slow {
 		item.MinUpvotes = updated.MinUpvotes
+		item.ScoreWaitHours = updated.ScoreWaitHours
 	}
 
-	_, err := item.UpdateG(ctx, boil.Whitelist("channel_id", "use_embeds", "filter_nsfw", "min_upvotes", "disabled", "spoilers_enabled"))
+	_, err := item.UpdateG(ctx, boil.Whitelist("channel_id", "use_embeds", "filter_nsfw", "min_upvotes", "disabled", "spoilers_enabled",
+		"message_template", "include_flairs", "exclude_flairs", "include_keywords", "exclude_keywords", "include_authors", "exclude_authors",
+		"filter_spoilers", "nsfw_hide_media", "score_wait_hours", "template_error"))
 	if web.CheckErr(templateData, err, "Failed saving item :'(", web.CtxLogger(ctx).Error) {
 		return templateData
 	}
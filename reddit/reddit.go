@@ -22,6 +22,12 @@ const (
 	FilterNSFWRequire = 2 // only allow nsfw content
 )
 
+const (
+	FilterSpoilersNone    = 0 // allow both spoiler and non spoiler posts
+	FilterSpoilersIgnore  = 1 // only allow posts not marked as spoilers
+	FilterSpoilersRequire = 2 // only allow posts marked as spoilers
+)
+
 var logger = common.GetPluginLogger(&Plugin{})
 
 type Plugin struct {
@@ -14,10 +14,12 @@ import (
 	"github.com/botlabs-gg/yagpdb/v2/common"
 	"github.com/botlabs-gg/yagpdb/v2/common/config"
 	"github.com/botlabs-gg/yagpdb/v2/common/mqueue"
+	"github.com/botlabs-gg/yagpdb/v2/common/templates"
 	"github.com/botlabs-gg/yagpdb/v2/feeds"
 	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
 	"github.com/botlabs-gg/yagpdb/v2/lib/go-reddit"
 	"github.com/botlabs-gg/yagpdb/v2/reddit/models"
+	"github.com/botlabs-gg/yagpdb/v2/web/discorddata"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -36,6 +38,8 @@ var (
 	feedLock sync.Mutex
 	fastFeed *PostFetcher
 	slowFeed *PostFetcher
+
+	scoreChecker *ScoreChecker
 )
 
 func (p *Plugin) StartFeed() {
@@ -67,6 +71,15 @@ func (p *Plugin) StopFeed(wg *sync.WaitGroup) {
 		wg.Done()
 	}
 
+	if scoreChecker != nil {
+		wg.Add(1)
+		sc := scoreChecker
+		go func() {
+			sc.StopChan <- wg
+		}()
+		scoreChecker = nil
+	}
+
 	feedLock.Unlock()
 }
 
@@ -89,9 +102,13 @@ func (p *Plugin) runBot() {
 		go fastFeed.Run()
 	}
 
-	slowFeed = NewPostFetcher(p.redditClient, true, NewPostHandler(true))
+	slowHandler := NewPostHandler(true)
+	slowFeed = NewPostFetcher(p.redditClient, true, slowHandler)
 	go slowFeed.Run()
 
+	scoreChecker = NewScoreChecker(p.redditClient, slowHandler.(*PostHandlerImpl))
+	go scoreChecker.Run()
+
 	feedLock.Unlock()
 }
 
@@ -117,7 +134,7 @@ func NewPostHandler(slow bool) PostHandler {
 
 func (p *PostHandlerImpl) HandleRedditPosts(links []*reddit.Link) {
 	for _, v := range links {
-		if strings.EqualFold(v.Selftext, "[removed]") || strings.EqualFold(v.Selftext, "[deleted]") {
+		if isRemovedPost(v) {
 			continue
 		}
 
@@ -195,11 +212,12 @@ func (p *PostHandlerImpl) handlePost(post *reddit.Link, filterGuild int64) error
 		"subreddit":    post.Subreddit,
 	}).Debug("Found matched reddit post")
 
-	// Create messages with and without spoilers
-	messageSpoilersEnabled, embedSpoilersEnabled := p.createPostMessage(post, true)
-	messageSpoilersDisabled, embedSpoilersDisabled := p.createPostMessage(post, false)
+	p.postToFeeds(post, filteredItems)
+	return nil
+}
 
-	for _, item := range filteredItems {
+func (p *PostHandlerImpl) postToFeeds(post *reddit.Link, items []*models.RedditFeed) {
+	for _, item := range items {
 		idStr := strconv.FormatInt(item.ID, 10)
 
 		webhookUsername := "Reddit • YAGPDB"
@@ -216,15 +234,21 @@ func (p *PostHandlerImpl) handlePost(post *reddit.Link, filterGuild int64) error
 			},
 		}
 
-		message, embed := messageSpoilersDisabled, embedSpoilersDisabled
-		if item.SpoilersEnabled {
-			message, embed = messageSpoilersEnabled, embedSpoilersEnabled
-		}
+		if item.MessageTemplate != "" {
+			// the custom message replaces both the default message and embed, the post is skipped if it fails
+			content, ok := p.executeTemplate(item, post)
+			if !ok || strings.TrimSpace(content) == "" {
+				continue
+			}
 
-		if item.UseEmbeds {
-			qm.MessageEmbed = embed
+			qm.MessageStr = content
 		} else {
-			qm.MessageStr = message
+			message, embed := p.createPostMessage(post, item.SpoilersEnabled, post.Over18 && item.NSFWHideMedia)
+			if item.UseEmbeds {
+				qm.MessageEmbed = embed
+			} else {
+				qm.MessageStr = message
+			}
 		}
 
 		mqueue.QueueMessage(qm)
@@ -232,8 +256,82 @@ func (p *PostHandlerImpl) handlePost(post *reddit.Link, filterGuild int64) error
 		feeds.MetricPostedMessages.With(prometheus.Labels{"source": "reddit"}).Inc()
 		go analytics.RecordActiveUnit(item.GuildID, &Plugin{}, "posted_reddit_message")
 	}
+}
 
-	return nil
+// executeTemplate runs the custom message template of the feed, returning false if it could not be executed.
+// Execution errors are saved on the feed so they show up in the control panel.
+func (p *PostHandlerImpl) executeTemplate(feed *models.RedditFeed, post *reddit.Link) (string, bool) {
+	guildState, err := discorddata.GetFullGuild(feed.GuildID)
+	if err != nil {
+		logger.WithError(err).WithField("guild", feed.GuildID).Error("failed retrieving guild state for reddit feed")
+		return "", false
+	}
+
+	if guildState == nil {
+		return "", false
+	}
+
+	channelState := guildState.GetChannel(feed.ChannelID)
+	if channelState == nil {
+		return "", false
+	}
+
+	ctx := templates.NewContext(guildState, channelState, nil)
+	for k, v := range templateData(post, p.Slow) {
+		ctx.Data[k] = v
+	}
+
+	content, err := ctx.Execute(feed.MessageTemplate)
+	if err != nil {
+		logger.WithError(err).WithField("guild", feed.GuildID).WithField("feed", feed.ID).Info("reddit feed template execution failed")
+		setTemplateError(feed, common.CutStringShort(err.Error(), 1000))
+		return "", false
+	}
+
+	if feed.TemplateError != "" {
+		setTemplateError(feed, "")
+	}
+
+	return content, true
+}
+
+func setTemplateError(feed *models.RedditFeed, msg string) {
+	feed.TemplateError = msg
+	_, err := models.RedditFeeds(models.RedditFeedWhere.ID.EQ(feed.ID)).UpdateAllG(context.Background(), models.M{"template_error": msg})
+	if err != nil {
+		logger.WithError(err).WithField("feed", feed.ID).Error("failed saving reddit feed template error")
+	}
+}
+
+// templateData returns the data made available to custom feed message templates
+func templateData(post *reddit.Link, slow bool) map[string]interface{} {
+	mediaURL := ""
+	if !post.IsSelf {
+		mediaURL = post.URL
+	}
+
+	thumbnail := ""
+	if strings.HasPrefix(post.Thumbnail, "http") {
+		thumbnail = post.Thumbnail
+	}
+
+	return map[string]interface{}{
+		"Title":     html.UnescapeString(post.Title),
+		"Author":    post.Author,
+		"Flair":     html.UnescapeString(post.LinkFlairText),
+		"Score":     post.Score,
+		"Subreddit": post.Subreddit,
+		"Permalink": "https://reddit.com" + post.Permalink,
+		"ShortURL":  "https://redd.it/" + post.ID,
+		"MediaURL":  mediaURL,
+		"Thumbnail": thumbnail,
+		"SelfText":  common.CutStringShort(html.UnescapeString(post.Selftext), 1000),
+		"IsSelf":    post.IsSelf,
+		"IsNSFW":    post.Over18,
+		"IsSpoiler": post.Spoiler,
+		"SlowFeed":  slow,
+		"Post":      post,
+	}
 }
 
 func (p *PostHandlerImpl) FilterFeeds(feeds []*models.RedditFeed, post *reddit.Link) []*models.RedditFeed {
@@ -248,38 +346,43 @@ OUTER:
 			}
 		}
 
-		limit := confMaxPostsHourFast.GetInt()
-		if p.Slow {
-			limit = confMaxPostsHourSlow.GetInt()
-		}
-
-		// apply ratelimiting
-		if !p.ratelimiter.CheckIncrement(time.Now(), c.GuildID, limit) {
-			continue
-		}
-
-		if post.Over18 && c.FilterNSFW == FilterNSFWIgnore {
-			// NSFW and we ignore nsfw posts
-			continue
-		} else if !post.Over18 && c.FilterNSFW == FilterNSFWRequire {
-			// Not NSFW and we only care about nsfw posts
+		if !PostMatchesFilters(c, post) {
 			continue
 		}
 
 		if p.Slow {
 			if post.Score < c.MinUpvotes {
-				// less than required upvotes
+				// less than required upvotes, keep checking on it for a while if the feed wants to
+				if c.ScoreWaitHours > 0 {
+					schedulePendingScoreCheck(c, post)
+				}
 				continue
 			}
 		}
 
+		// apply ratelimiting
+		if !p.checkRatelimit(c) {
+			continue
+		}
+
 		filteredItems = append(filteredItems, c)
 	}
 
 	return filteredItems
 }
 
-func (p *PostHandlerImpl) createPostMessage(post *reddit.Link, allowSpoilers bool) (string, *discordgo.MessageEmbed) {
+func (p *PostHandlerImpl) checkRatelimit(feed *models.RedditFeed) bool {
+	limit := confMaxPostsHourFast.GetInt()
+	if p.Slow {
+		limit = confMaxPostsHourSlow.GetInt()
+	}
+
+	return p.ratelimiter.CheckIncrement(time.Now(), feed.GuildID, limit)
+}
+
+// createPostMessage creates the default plain and embed messages for the post,
+// hideMedia hides all links and images behind spoilers, used for nsfw posts
+func (p *PostHandlerImpl) createPostMessage(post *reddit.Link, allowSpoilers, hideMedia bool) (string, *discordgo.MessageEmbed) {
 	plainMessage := fmt.Sprintf("**%s**\n*by %s (<%s>)*\n",
 		html.UnescapeString(post.Title), post.Author, "https://redd.it/"+post.ID)
 
@@ -295,17 +398,17 @@ func (p *PostHandlerImpl) createPostMessage(post *reddit.Link, allowSpoilers boo
 		if parent.IsSelf {
 			plainBody += common.CutStringShort(html.UnescapeString(parent.Selftext), 250)
 		} else {
-			plainBody += parent.URL
+			plainBody += maybeSuppressEmbed(parent.URL, hideMedia)
 		}
 
 		if parent.Spoiler {
 			parentSpoiler = true
 		}
 	} else {
-		plainBody = post.URL
+		plainBody = maybeSuppressEmbed(post.URL, hideMedia)
 	}
 
-	if (post.Spoiler || parentSpoiler) && allowSpoilers {
+	if ((post.Spoiler || parentSpoiler) && allowSpoilers) || hideMedia {
 		plainMessage += "|| " + plainBody + " ||"
 	} else {
 		plainMessage += plainBody
@@ -361,7 +464,7 @@ func (p *PostHandlerImpl) createPostMessage(post *reddit.Link, allowSpoilers boo
 			// cross post was a link most likely
 			embed.Color = 0x718aed
 			embed.Description += parent.URL
-			if parent.Media.Type == "" && !parent.Spoiler && parent.PostHint == "image" {
+			if parent.Media.Type == "" && !parent.Spoiler && parent.PostHint == "image" && !hideMedia {
 				embed.Image = &discordgo.MessageEmbedImage{
 					URL: parent.URL,
 				}
@@ -373,7 +476,7 @@ func (p *PostHandlerImpl) createPostMessage(post *reddit.Link, allowSpoilers boo
 		embed.Title = "New link post"
 		embed.Description += post.URL
 
-		if post.Media.Type == "" && !post.Spoiler && post.PostHint == "image" {
+		if post.Media.Type == "" && !post.Spoiler && post.PostHint == "image" && !hideMedia {
 			embed.Image = &discordgo.MessageEmbedImage{
 				URL: post.URL,
 			}
@@ -384,9 +487,21 @@ func (p *PostHandlerImpl) createPostMessage(post *reddit.Link, allowSpoilers boo
 		embed.Title += " [spoiler]"
 	}
 
+	if hideMedia {
+		embed.Title += " [nsfw]"
+	}
+
 	return plainMessage, embed
 }
 
+func maybeSuppressEmbed(link string, suppress bool) string {
+	if suppress {
+		return "<" + link + ">"
+	}
+
+	return link
+}
+
 type RedditIdSlice []string
 
 // Len is the number of elements in the collection.
@@ -24,4 +24,29 @@ ALTER TABLE reddit_feeds ADD COLUMN IF NOT EXISTS disabled BOOLEAN NOT NULL DEFA
 
 `, `
 ALTER TABLE reddit_feeds ADD COLUMN IF NOT EXISTS spoilers_enabled BOOLEAN NOT NULL DEFAULT TRUE;
+`, `
+ALTER TABLE reddit_feeds ADD COLUMN IF NOT EXISTS message_template TEXT NOT NULL DEFAULT '';
+`, `
+ALTER TABLE reddit_feeds ADD COLUMN IF NOT EXISTS include_flairs TEXT[] NOT NULL DEFAULT '{}';
+`, `
+ALTER TABLE reddit_feeds ADD COLUMN IF NOT EXISTS exclude_flairs TEXT[] NOT NULL DEFAULT '{}';
+`, `
+ALTER TABLE reddit_feeds ADD COLUMN IF NOT EXISTS include_keywords TEXT[] NOT NULL DEFAULT '{}';
+`, `
+ALTER TABLE reddit_feeds ADD COLUMN IF NOT EXISTS exclude_keywords TEXT[] NOT NULL DEFAULT '{}';
+`, `
+ALTER TABLE reddit_feeds ADD COLUMN IF NOT EXISTS include_authors TEXT[] NOT NULL DEFAULT '{}';
+`, `
+ALTER TABLE reddit_feeds ADD COLUMN IF NOT EXISTS exclude_authors TEXT[] NOT NULL DEFAULT '{}';
+`, `
+-- 0 = none, 1 = ignore, 2 = only spoilers
+ALTER TABLE reddit_feeds ADD COLUMN IF NOT EXISTS filter_spoilers INT NOT NULL DEFAULT 0;
+`, `
+ALTER TABLE reddit_feeds ADD COLUMN IF NOT EXISTS nsfw_hide_media BOOLEAN NOT NULL DEFAULT FALSE;
+`, `
+-- slow feeds only, keep checking posts below min_upvotes for up to this many hours
+ALTER TABLE reddit_feeds ADD COLUMN IF NOT EXISTS score_wait_hours INT NOT NULL DEFAULT 0;
+`, `
+-- the error of the last failed execution of the message template, shown in the control panel
+ALTER TABLE reddit_feeds ADD COLUMN IF NOT EXISTS template_error TEXT NOT NULL DEFAULT '';
 `}
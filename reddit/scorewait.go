@@ -0,0 +1,239 @@
+package reddit
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	greddit "github.com/botlabs-gg/yagpdb/v2/lib/go-reddit"
+	"github.com/botlabs-gg/yagpdb/v2/reddit/models"
+	"github.com/mediocregopher/radix/v3"
+)
+
+// Posts in slow feeds that did not have enough upvotes yet are put in this sorted set, scored by when they should next be checked,
+// members are in the format "feedID:postID"
+const KeyPendingScoreChecks = "reddit_pending_score_checks"
+
+const (
+	// How often pending posts are re-checked
+	PendingScoreRecheckInterval = time.Minute * 15
+	// Max pending posts in total, new ones are dropped above this
+	MaxPendingScoreChecks = 50000
+	// Max posts checked each tick
+	maxScoreChecksPerRun = 1000
+)
+
+func pendingScoreMember(feedID int64, postID string) string {
+	return strconv.FormatInt(feedID, 10) + ":" + postID
+}
+
+func parsePendingScoreMember(member string) (feedID int64, postID string, ok bool) {
+	split := strings.SplitN(member, ":", 2)
+	if len(split) != 2 || split[1] == "" {
+		return 0, "", false
+	}
+
+	feedID, err := strconv.ParseInt(split[0], 10, 64)
+	if err != nil {
+		return 0, "", false
+	}
+
+	return feedID, split[1], true
+}
+
+// schedulePendingScoreCheck schedules the post to be checked again later on if it's still within the feeds score wait window
+func schedulePendingScoreCheck(feed *models.RedditFeed, post *greddit.Link) {
+	created := time.Unix(int64(post.CreatedUtc), 0)
+	if time.Since(created) > time.Duration(feed.ScoreWaitHours)*time.Hour {
+		return
+	}
+
+	var count int
+	err := common.RedisPool.Do(radix.Cmd(&count, "ZCARD", KeyPendingScoreChecks))
+	if err != nil {
+		logger.WithError(err).Error("failed retrieving number of pending reddit score checks")
+		return
+	}
+
+	if count >= MaxPendingScoreChecks {
+		return
+	}
+
+	next := time.Now().Add(PendingScoreRecheckInterval).Unix()
+	err = common.RedisPool.Do(radix.FlatCmd(nil, "ZADD", KeyPendingScoreChecks, "NX", next, pendingScoreMember(feed.ID, post.ID)))
+	if err != nil {
+		logger.WithError(err).Error("failed scheduling reddit score check")
+	}
+}
+
+// ScoreChecker periodically re-checks posts in slow feeds that did not have enough upvotes when they were first seen
+type ScoreChecker struct {
+	StopChan chan *sync.WaitGroup
+
+	redditClient *greddit.Client
+	handler      *PostHandlerImpl
+}
+
+func NewScoreChecker(redditClient *greddit.Client, handler *PostHandlerImpl) *ScoreChecker {
+	return &ScoreChecker{
+		StopChan:     make(chan *sync.WaitGroup),
+		redditClient: redditClient,
+		handler:      handler,
+	}
+}
+
+func (s *ScoreChecker) Run() {
+	ticker := time.NewTicker(time.Minute)
+	for {
+		select {
+		case wg := <-s.StopChan:
+			wg.Done()
+			return
+		case <-ticker.C:
+		}
+
+		err := s.check()
+		if err != nil {
+			logger.WithError(err).Error("failed checking pending reddit posts")
+		}
+	}
+}
+
+func (s *ScoreChecker) check() error {
+	var due []string
+	err := common.RedisPool.Do(radix.FlatCmd(&due, "ZRANGEBYSCORE", KeyPendingScoreChecks, "-inf", time.Now().Unix(), "LIMIT", 0, maxScoreChecksPerRun))
+	if err != nil {
+		return errors.WrapIf(err, "zrangebyscore")
+	}
+
+	if len(due) < 1 {
+		return nil
+	}
+
+	posts, err := s.fetchPosts(due)
+	if err != nil {
+		return err
+	}
+
+	feeds := make(map[int64]*models.RedditFeed)
+	toRemove := make([]string, 0, len(due))
+	toReschedule := make([]string, 0, len(due))
+
+	for _, member := range due {
+		feedID, postID, ok := parsePendingScoreMember(member)
+		if !ok {
+			toRemove = append(toRemove, member)
+			continue
+		}
+
+		feed, ok := feeds[feedID]
+		if !ok {
+			feed, err = models.FindRedditFeedG(context.Background(), feedID)
+			if err != nil && errors.Cause(err) != sql.ErrNoRows {
+				return errors.WrapIf(err, "find feed")
+			}
+			feeds[feedID] = feed
+		}
+
+		post := posts[postID]
+		if feed == nil || feed.Disabled || !feed.Slow || feed.ScoreWaitHours < 1 || post == nil || isRemovedPost(post) {
+			toRemove = append(toRemove, member)
+			continue
+		}
+
+		// the feed settings could have changed since
+		if !PostMatchesFilters(feed, post) {
+			toRemove = append(toRemove, member)
+			continue
+		}
+
+		if post.Score >= feed.MinUpvotes {
+			// remove it first so it's only posted once, even if another checker picked it up too
+			var removed int
+			err = common.RedisPool.Do(radix.Cmd(&removed, "ZREM", KeyPendingScoreChecks, member))
+			if err != nil {
+				return errors.WrapIf(err, "zrem")
+			}
+
+			if removed == 1 && s.handler.checkRatelimit(feed) {
+				s.handler.postToFeeds(post, []*models.RedditFeed{feed})
+			}
+			continue
+		}
+
+		created := time.Unix(int64(post.CreatedUtc), 0)
+		if time.Since(created) > time.Duration(feed.ScoreWaitHours)*time.Hour {
+			toRemove = append(toRemove, member)
+			continue
+		}
+
+		toReschedule = append(toReschedule, member)
+	}
+
+	if len(toRemove) > 0 {
+		err = common.RedisPool.Do(radix.Cmd(nil, "ZREM", append([]string{KeyPendingScoreChecks}, toRemove...)...))
+		if err != nil {
+			return errors.WrapIf(err, "zrem")
+		}
+	}
+
+	if len(toReschedule) > 0 {
+		next := strconv.FormatInt(time.Now().Add(PendingScoreRecheckInterval).Unix(), 10)
+		args := make([]string, 0, len(toReschedule)*2+2)
+		args = append(args, KeyPendingScoreChecks, "XX")
+		for _, v := range toReschedule {
+			args = append(args, next, v)
+		}
+
+		err = common.RedisPool.Do(radix.Cmd(nil, "ZADD", args...))
+		if err != nil {
+			return errors.WrapIf(err, "zadd")
+		}
+	}
+
+	return nil
+}
+
+// fetchPosts retrieves the current state of the posts in the pending members, in batches of 100
+func (s *ScoreChecker) fetchPosts(members []string) (map[string]*greddit.Link, error) {
+	fullnames := make([]string, 0, len(members))
+	seen := make(map[string]bool)
+	for _, v := range members {
+		_, postID, ok := parsePendingScoreMember(v)
+		if !ok || seen[postID] {
+			continue
+		}
+
+		seen[postID] = true
+		fullnames = append(fullnames, "t3_"+postID)
+	}
+
+	result := make(map[string]*greddit.Link)
+	for len(fullnames) > 0 {
+		batch := fullnames
+		if len(batch) > 100 {
+			batch = batch[:100]
+		}
+		fullnames = fullnames[len(batch):]
+
+		links, err := s.redditClient.LinksInfo(batch)
+		if err != nil {
+			return nil, errors.WrapIf(err, "links info")
+		}
+
+		for _, v := range links {
+			result[v.ID] = v
+		}
+	}
+
+	return result, nil
+}
+
+func isRemovedPost(post *greddit.Link) bool {
+	return strings.EqualFold(post.Selftext, "[removed]") || strings.EqualFold(post.Selftext, "[deleted]")
+}
@@ -0,0 +1,168 @@
+package reminders
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/lib/dcmd"
+	"github.com/botlabs-gg/yagpdb/v2/lib/when"
+	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules/br"
+	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules/de"
+	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules/en"
+	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules/es"
+	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules/fr"
+	"github.com/botlabs-gg/yagpdb/v2/timezonecompanion"
+)
+
+// naturalTimeParsers are all tried, the one that understood the longest phrase wins and ties go to the first one
+var naturalTimeParsers = []*when.Parser{when.EN, when.DE, when.FR, when.ES, when.PT}
+
+// Words that can be left over between the time and the message, like "at" in "call mom at 5pm"
+var naturalTimeConnectors = map[string]bool{
+	"at": true, "on": true, "in": true,
+	"um": true, "am": true,
+	"à": true, "le": true,
+	"a": true, "el": true,
+	"às": true,
+}
+
+// Month and weekday names that are also ordinary words ("may", "march", "sat"), these are only taken as the time
+// at the start of a message when a day or a time comes with them
+var bareDateWords = map[string]bool{}
+
+func init() {
+	for _, m := range []map[string]int{
+		en.WEEKDAY_OFFSET, en.MONTH_OFFSET,
+		de.WEEKDAY_OFFSET, de.MONTH_OFFSET,
+		fr.WEEKDAY_OFFSET, fr.MONTH_OFFSET,
+		es.WEEKDAY_OFFSET, es.MONTH_OFFSET,
+		br.WEEKDAY_OFFSET, br.MONTH_OFFSET,
+	} {
+		for word := range m {
+			bareDateWords[strings.ToLower(word)] = true
+		}
+	}
+}
+
+// usesNaturalTime returns true if the reminder time should be parsed from the when switch or the message,
+// which is the case when none of the other time switches are used
+func usesNaturalTime(parsed *dcmd.Data) (bool, error) {
+	natural := parsed.Switch("when").Value != nil
+	if parsed.Switch("time").Value != nil {
+		if natural {
+			return false, fmt.Errorf("Exclusive fields \"when\" and \"time\" cannot be used together.")
+		}
+		return false, nil
+	}
+
+	for _, field := range absoluteTimeFields {
+		if field == "zone" || parsed.Switch(field).Value == nil {
+			continue
+		}
+
+		if natural {
+			return false, fmt.Errorf("Exclusive fields \"when\" and \"%s\" cannot be used together.", field)
+		}
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// parseNaturalReminderTime parses the when switch, or a time at the start or end of the message, in the zone given
+// or the one the user registered with the timezone commands. Returns the message with the time removed.
+func parseNaturalReminderTime(parsed *dcmd.Data) (time.Time, string, string, error) {
+	location := timezonecompanion.GetUserTimezone(parsed.Author.ID)
+	if raw := parsed.Switch("zone"); raw.Value != nil {
+		tz := raw.Value.(string)
+
+		var err error
+		location, err = time.LoadLocation(tz)
+		if err != nil {
+			return time.Time{}, "", "", fmt.Errorf("Invalid timezone: %s", tz)
+		}
+	}
+	if location == nil {
+		location = time.UTC
+	}
+
+	now := time.Now().In(location)
+	message := parsed.Args[0].Str()
+
+	var t time.Time
+	if raw := parsed.Switch("when"); raw.Value != nil {
+		res := parseNaturalTime(raw.Str(), now)
+		if res == nil {
+			return time.Time{}, "", "", fmt.Errorf("Couldn't understand the time %q, try something like \"tomorrow at 5pm\", \"next friday 18:00\" or \"in 2 weeks\".", raw.Str())
+		}
+		t = res.Time
+	} else {
+		var ok bool
+		t, message, ok = splitNaturalTime(message, now)
+		if !ok {
+			return time.Time{}, "", "", fmt.Errorf("No time given, start the message with something like \"tomorrow at 5pm\" or \"in 2 weeks\", or use the when, time or absolute time switches.")
+		}
+	}
+
+	if !t.After(now) {
+		return time.Time{}, "", "", fmt.Errorf("%s is in the past", t.Format("2006-01-02 15:04 MST"))
+	}
+
+	durString := common.HumanizeDuration(common.DurationPrecisionSeconds, t.Sub(now))
+	return t, durString, message, nil
+}
+
+// parseNaturalTime parses phrases like "tomorrow at 5pm", "next friday 18:00" or "in 2 weeks" in any of the supported languages
+func parseNaturalTime(text string, now time.Time) *when.Result {
+	var best *when.Result
+	for _, p := range naturalTimeParsers {
+		res, err := p.Parse(text, now)
+		if err != nil || res == nil {
+			continue
+		}
+
+		if best == nil || utf8.RuneCountInString(res.Text) > utf8.RuneCountInString(best.Text) {
+			best = res
+		}
+	}
+
+	return best
+}
+
+// splitNaturalTime looks for a time at the start or the end of the message, and returns it along with the rest of the message.
+// If the message is nothing but the time it's returned as is.
+func splitNaturalTime(message string, now time.Time) (time.Time, string, bool) {
+	res := parseNaturalTime(message, now)
+	if res == nil {
+		return time.Time{}, "", false
+	}
+
+	before := strings.TrimSpace(message[:res.Index])
+	after := strings.TrimSpace(message[res.Index+len(res.Text):])
+
+	var rest string
+	switch {
+	case before == "" && after != "" && bareDateWords[strings.ToLower(strings.TrimSpace(res.Text))]:
+		// "may the force be with you", "march on" or "lunes 9am" where the time wasn't understood
+		return time.Time{}, "", false
+	case before == "" || naturalTimeConnectors[strings.ToLower(before)]:
+		rest = after
+	case after == "":
+		rest = before
+		if i := strings.LastIndexAny(before, " \t\n"); i != -1 && naturalTimeConnectors[strings.ToLower(before[i+1:])] {
+			rest = strings.TrimSpace(before[:i])
+		}
+	default:
+		// the time is somewhere in the middle of the message, most likely part of it and not meant as the reminder time
+		return time.Time{}, "", false
+	}
+
+	if rest == "" {
+		rest = message
+	}
+
+	return res.Time, rest, true
+}
@@ -0,0 +1,58 @@
+package reminders
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSplitNaturalTime(t *testing.T) {
+	// Wednesday
+	now := time.Date(2016, time.January, 6, 10, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		message  string
+		expected time.Time
+		rest     string
+	}{
+		{"tomorrow at 5pm call mom", time.Date(2016, time.January, 7, 17, 0, 0, 0, time.UTC), "call mom"},
+		{"call mom tomorrow at 5pm", time.Date(2016, time.January, 7, 17, 0, 0, 0, time.UTC), "call mom"},
+		{"next friday 18:00 team meeting", time.Date(2016, time.January, 8, 18, 0, 0, 0, time.UTC), "team meeting"},
+		{"in 2 weeks check the oven", time.Date(2016, time.January, 20, 10, 0, 0, 0, time.UTC), "check the oven"},
+		{"water the plants on friday", time.Date(2016, time.January, 8, 10, 0, 0, 0, time.UTC), "water the plants"},
+		{"morgen um 17 Uhr Mama anrufen", time.Date(2016, time.January, 7, 17, 0, 0, 0, time.UTC), "Mama anrufen"},
+		{"demain à 17h appeler maman", time.Date(2016, time.January, 7, 17, 0, 0, 0, time.UTC), "appeler maman"},
+		{"mañana a las 5 de la tarde llamar a mamá", time.Date(2016, time.January, 7, 17, 0, 0, 0, time.UTC), "llamar a mamá"},
+		{"amanhã às 17h ligar para a mãe", time.Date(2016, time.January, 7, 17, 0, 0, 0, time.UTC), "ligar para a mãe"},
+		{"in 2 weeks", time.Date(2016, time.January, 20, 10, 0, 0, 0, time.UTC), "in 2 weeks"},
+		{"in 90 minutes are you still alive?", time.Date(2016, time.January, 6, 11, 30, 0, 0, time.UTC), "are you still alive?"},
+		{"en 2 horas llamar a mamá", time.Date(2016, time.January, 6, 12, 0, 0, 0, time.UTC), "llamar a mamá"},
+		{"dans 2 heures appeler maman", time.Date(2016, time.January, 6, 12, 0, 0, 0, time.UTC), "appeler maman"},
+		{"em 2 horas ligar para a mãe", time.Date(2016, time.January, 6, 12, 0, 0, 0, time.UTC), "ligar para a mãe"},
+		{"in 2 Stunden Mama anrufen", time.Date(2016, time.January, 6, 12, 0, 0, 0, time.UTC), "Mama anrufen"},
+		{"may 5 do the taxes", time.Date(2016, time.May, 5, 10, 0, 0, 0, time.UTC), "do the taxes"},
+		{"friday at 5pm call mom", time.Date(2016, time.January, 8, 17, 0, 0, 0, time.UTC), "call mom"},
+		{"on friday call mom", time.Date(2016, time.January, 8, 10, 0, 0, 0, time.UTC), "call mom"},
+		{"friday", time.Date(2016, time.January, 8, 10, 0, 0, 0, time.UTC), "friday"},
+	}
+
+	for _, c := range cases {
+		result, rest, ok := splitNaturalTime(c.message, now)
+		if !ok {
+			t.Errorf("%q: no time found", c.message)
+			continue
+		}
+
+		if !result.Equal(c.expected) || rest != c.rest {
+			t.Errorf("%q: got %s %q, expected %s %q", c.message, result, rest, c.expected, c.rest)
+		}
+	}
+
+	for _, v := range []string{
+		"buy 5 apples", "are you still alive?", "ask about tomorrow at 5pm meeting notes",
+		"may the force be with you", "march on the capital", "august rush", "sat down", "wed the bride", "lunes 9am",
+	} {
+		if _, _, ok := splitNaturalTime(v, now); ok {
+			t.Errorf("%q: expected no time", v)
+		}
+	}
+}
@@ -39,15 +39,19 @@ func (p *Plugin) BotInit() {
 // Reminder management commands
 var cmds = []*commands.YAGCommand{
 	{
-		CmdCategory:  commands.CategoryTool,
-		Name:         "Remindme",
-		Description:  "Schedules a reminder, example: 'remindme 1h30min are you still alive?'",
+		CmdCategory: commands.CategoryTool,
+		Name:        "Remindme",
+		Description: "Schedules a reminder, example: 'remindme in 90 minutes are you still alive?'",
+		LongDescription: "The time can be given at the start or end of the message, or with the `when` switch, " +
+			"in English, German, French, Spanish or Portuguese, e.g. \"tomorrow at 5pm\", \"next friday 18:00\" or \"in 2 weeks\". " +
+			"Times are in the timezone you set with the `settimezone` command unless the `zone` switch is used.",
 		Aliases:      []string{"remind", "reminder"},
 		RequiredArgs: 1,
 		Arguments: []*dcmd.ArgDef{
 			{Name: "Message", Type: dcmd.String, Help: "Message to display"},
 		},
 		ArgSwitches: []*dcmd.ArgDef{
+			{Name: "when", Type: dcmd.String, Help: "When to remind you, e.g. \"tomorrow at 5pm\" or \"in 2 weeks\". Exclusive with time and absolute time fields."},
 			{Name: "time", Type: &commands.DurationArg{}, Help: "Relative reminder delay e.g. 90s for \"in 1 minute and 30s\". Exclusive with absolute time fields."},
 			{Name: "year", Type: &dcmd.IntArg{}, Help: "Year of the reminder. Defaults to current year. Exclusive with time."},
 			{Name: "month", Type: &dcmd.IntArg{}, Help: "Month of the reminder (1-12). Defaults to current month. Exclusive with time."},
@@ -57,7 +61,7 @@ var cmds = []*commands.YAGCommand{
 			{Name: "second", Type: &dcmd.IntArg{}, Help: "Second of the reminder (0-59). Defaults to 0. Exclusive with time."},
 			{
 				Name: "zone", Type: &dcmd.StringArg{},
-				Help: "Timezone of the reminder date & time. Defaults to GMT. Exclusive with time.",
+				Help: "Timezone of the reminder date & time. Defaults to the one set with settimezone for natural language times and GMT otherwise. Exclusive with time.",
 				// Default: "GMT",
 				// Choices: GetTimeZoneChoices(),
 				AutocompleteFunc: func(data *dcmd.Data, arg *dcmd.ParsedArg) (choices []*discordgo.ApplicationCommandOptionChoice, err error) {
@@ -99,19 +103,30 @@ var cmds = []*commands.YAGCommand{
 				return nil, errors.New("cannot create reminder for Bots, you're most likely trying to use `execAdmin` to create a reminder, use `exec` instead")
 			}
 
-			logger.Info("relative or absolute?")
-			rel, err := usesRelativeTime(parsed)
+			logger.Info("natural, relative or absolute?")
+			natural, err := usesNaturalTime(parsed)
 			if err != nil {
 				return err.Error(), nil
 			}
 			var when time.Time
 			var durString string
-			if rel {
-				logger.Info("parsing relative")
-				when, durString, err = parseRelativeTime(parsed)
+			message := parsed.Args[0].Str()
+			if natural {
+				when, durString, message, err = parseNaturalReminderTime(parsed)
 			} else {
-				logger.Info("parsing absolute")
-				when, durString, err = parseAbsoluteTime(parsed)
+				var rel bool
+				rel, err = usesRelativeTime(parsed)
+				if err != nil {
+					return err.Error(), nil
+				}
+
+				if rel {
+					logger.Info("parsing relative")
+					when, durString, err = parseRelativeTime(parsed)
+				} else {
+					logger.Info("parsing absolute")
+					when, durString, err = parseAbsoluteTime(parsed)
+				}
 			}
 			if err != nil {
 				return err.Error(), nil
@@ -142,7 +157,7 @@ var cmds = []*commands.YAGCommand{
 			if parsed.GuildData != nil {
 				gid = parsed.GuildData.GS.ID
 			}
-			_, err = NewReminder(parsed.Author.ID, gid, id, parsed.Args[0].Str(), when)
+			_, err = NewReminder(parsed.Author.ID, gid, id, message, when)
 			if err != nil {
 				return nil, err
 			}
@@ -429,6 +444,10 @@ func stringReminders(reminders []*Reminder, displayUsernames bool) string {
 	return out
 }
 
+func describeCheckUserScheduledEvent(evt *seventsmodels.ScheduledEvent, data interface{}) string {
+	return fmt.Sprintf("Send the due reminders of user %d", *data.(*int64))
+}
+
 func checkUserScheduledEvent(evt *seventsmodels.ScheduledEvent, data interface{}) (retry bool, err error) {
 	// !important! the evt.GuildID can be 1 in cases where it was migrated from the legacy scheduled event system
 
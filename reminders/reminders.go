@@ -23,6 +23,8 @@ func RegisterPlugin() {
 
 	p := &Plugin{}
 	common.RegisterPlugin(p)
+
+	scheduledevents2.RegisterDescriber("reminders_check_user", int64(0), describeCheckUserScheduledEvent)
 }
 
 func (p *Plugin) PluginInfo() *common.PluginInfo {
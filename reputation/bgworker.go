@@ -0,0 +1,217 @@
+package reputation
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/backgroundworkers"
+	"github.com/botlabs-gg/yagpdb/v2/common/mqueue"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+	"github.com/mediocregopher/radix/v3"
+)
+
+var _ backgroundworkers.BackgroundWorkerPlugin = (*Plugin)(nil)
+
+func (p *Plugin) RunBackgroundWorker() {
+	ticker := time.NewTicker(time.Minute * 10)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case wg := <-p.stopWorker:
+			wg.Done()
+			return
+		}
+
+		ctx := context.Background()
+
+		err := runDecay(ctx)
+		if err != nil {
+			logger.WithError(err).Error("Failed running reputation decay")
+		}
+
+		err = announceLeaderboards(ctx)
+		if err != nil {
+			logger.WithError(err).Error("Failed announcing reputation leaderboards")
+		}
+	}
+}
+
+func (p *Plugin) StopBackgroundWorker(wg *sync.WaitGroup) {
+	p.stopWorker <- wg
+}
+
+// runDecay takes away the decay percent of the points from everyone that haven't given or received reputation
+// for the configured amount of days, this repeats every time that amount of days passes without activity.
+func runDecay(ctx context.Context) error {
+	rows, err := common.PQ.QueryContext(ctx, `SELECT guild_id, decay_percent, decay_inactive_days FROM reputation_configs WHERE enabled AND decay_percent > 0 AND decay_inactive_days > 0`)
+	if err != nil {
+		return err
+	}
+
+	type decayConf struct {
+		guildID int64
+		percent int
+		days    int
+	}
+
+	var confs []*decayConf
+	for rows.Next() {
+		c := &decayConf{}
+		if err = rows.Scan(&c.guildID, &c.percent, &c.days); err != nil {
+			rows.Close()
+			return err
+		}
+		confs = append(confs, c)
+	}
+	rows.Close()
+
+	for _, v := range confs {
+		err = decayGuild(ctx, v.guildID, v.percent, v.days)
+		if err != nil {
+			logger.WithError(err).WithField("guild", v.guildID).Error("Failed decaying reputation")
+		}
+	}
+
+	return nil
+}
+
+func decayGuild(ctx context.Context, guildID int64, percent, days int) error {
+	if percent > 100 {
+		percent = 100
+	}
+
+	// the joined row holds the points before the update
+	const q = `UPDATE reputation_users u SET points = u.points * (100 - $2) / 100, inactive_since = now()
+FROM reputation_users old
+WHERE u.guild_id = $1 AND old.guild_id = u.guild_id AND old.user_id = u.user_id
+AND u.points != 0 AND u.inactive_since < now() - $3 * interval '1 day'
+RETURNING u.user_id, old.points, u.points`
+
+	rows, err := common.PQ.QueryContext(ctx, q, guildID, percent, days)
+	if err != nil {
+		return err
+	}
+
+	type decayed struct {
+		userID    int64
+		oldPoints int64
+		newPoints int64
+	}
+
+	var users []*decayed
+	for rows.Next() {
+		d := &decayed{}
+		if err = rows.Scan(&d.userID, &d.oldPoints, &d.newPoints); err != nil {
+			rows.Close()
+			return err
+		}
+		users = append(users, d)
+	}
+	rows.Close()
+
+	if len(users) < 1 {
+		return nil
+	}
+
+	logger.WithField("guild", guildID).Infof("Decayed the reputation of %d users", len(users))
+
+	rewards, err := GuildRoleRewards(ctx, guildID)
+	if err != nil || len(rewards) < 1 {
+		return err
+	}
+
+	for _, v := range users {
+		for _, role := range RewardsLost(rewards, v.oldPoints, v.newPoints) {
+			removeRewardRole(guildID, v.userID, role)
+		}
+	}
+
+	return nil
+}
+
+func keyLeaderboardAnnounced(guildID int64, periodStart time.Time) string {
+	return "reputation_leaderboard_announced:" + discordgo.StrID(guildID) + ":" + periodStart.Format("2006-01-02")
+}
+
+// announceLeaderboards posts the leaderboard of the previous period during the first day of a new one
+func announceLeaderboards(ctx context.Context) error {
+	rows, err := common.PQ.QueryContext(ctx, `SELECT guild_id, leaderboard_channel, leaderboard_period, points_name FROM reputation_configs WHERE enabled AND leaderboard_period > 0 AND leaderboard_channel != 0`)
+	if err != nil {
+		return err
+	}
+
+	type announceConf struct {
+		guildID    int64
+		channelID  int64
+		period     int
+		pointsName string
+	}
+
+	var confs []*announceConf
+	for rows.Next() {
+		c := &announceConf{}
+		if err = rows.Scan(&c.guildID, &c.channelID, &c.period, &c.pointsName); err != nil {
+			rows.Close()
+			return err
+		}
+		confs = append(confs, c)
+	}
+	rows.Close()
+
+	now := time.Now()
+	for _, v := range confs {
+		currentStart, _ := LeaderboardWindow(v.period, now)
+		if now.Sub(currentStart) > time.Hour*24 {
+			continue
+		}
+
+		start, end := LeaderboardWindow(v.period, currentStart.Add(-time.Second))
+
+		var resp string
+		err = common.RedisPool.Do(radix.FlatCmd(&resp, "SET", keyLeaderboardAnnounced(v.guildID, start), true, "EX", 60*60*24*7, "NX"))
+		if err != nil {
+			return err
+		}
+
+		if resp != "OK" {
+			// already announced
+			continue
+		}
+
+		embed, err := periodLeaderboardEmbed(ctx, v.guildID, v.pointsName, "Reputation leaderboard of the last "+periodName(v.period), start, end, 5)
+		if err != nil {
+			logger.WithError(err).WithField("guild", v.guildID).Error("Failed creating reputation leaderboard")
+			continue
+		}
+
+		mqueue.QueueMessage(&mqueue.QueuedElement{
+			Source:       "reputation",
+			SourceItemID: strconv.FormatInt(v.guildID, 10),
+
+			GuildID:      v.guildID,
+			ChannelID:    v.channelID,
+			MessageEmbed: embed,
+			Priority:     2,
+		})
+	}
+
+	return nil
+}
+
+var _ mqueue.PluginWithSourceDisabler = (*Plugin)(nil)
+
+// DisableFeed turns off the leaderboard announcements if the channel is gone
+func (p *Plugin) DisableFeed(elem *mqueue.QueuedElement, err error) {
+	_, err = common.PQ.Exec(`UPDATE reputation_configs SET leaderboard_channel = 0 WHERE guild_id = $1 AND leaderboard_channel = $2`, elem.GuildID, elem.ChannelID)
+	if err != nil {
+		logger.WithError(err).WithField("guild", elem.GuildID).Error("Failed disabling reputation leaderboard announcements")
+		return
+	}
+
+	logger.WithField("guild", elem.GuildID).WithField("channel", elem.ChannelID).Info("Disabled reputation leaderboard announcements to non-existant channel")
+}
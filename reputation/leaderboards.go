@@ -0,0 +1,119 @@
+package reputation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+)
+
+const (
+	LeaderboardPeriodNone    = 0
+	LeaderboardPeriodWeekly  = 1
+	LeaderboardPeriodMonthly = 2
+)
+
+// LeaderboardWindow returns the start and end of the weekly or monthly period t is in, weeks start on monday UTC
+func LeaderboardWindow(period int, t time.Time) (start, end time.Time) {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	if period == LeaderboardPeriodMonthly {
+		start = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, 0)
+	}
+
+	// time.Weekday has sunday as 0
+	offset := (int(day.Weekday()) + 6) % 7
+	start = day.AddDate(0, 0, -offset)
+	return start, start.AddDate(0, 0, 7)
+}
+
+func periodName(period int) string {
+	if period == LeaderboardPeriodMonthly {
+		return "month"
+	}
+
+	return "week"
+}
+
+// PeriodTopUsers returns the users that received the most reputation between since and until,
+// or the ones that gave the most if givers is set. Fixed amounts set by admins are not counted.
+func PeriodTopUsers(ctx context.Context, guildID int64, since, until time.Time, givers bool, limit int) ([]*RankEntry, error) {
+	const receiversQuery = `SELECT receiver_id, SUM(amount) AS total FROM reputation_log
+WHERE guild_id = $1 AND created_at >= $2 AND created_at < $3 AND NOT set_fixed_amount
+GROUP BY receiver_id HAVING SUM(amount) > 0
+ORDER BY total DESC, receiver_id ASC
+LIMIT $4`
+
+	const giversQuery = `SELECT sender_id, SUM(amount) AS total FROM reputation_log
+WHERE guild_id = $1 AND created_at >= $2 AND created_at < $3 AND NOT set_fixed_amount AND amount > 0
+GROUP BY sender_id
+ORDER BY total DESC, sender_id ASC
+LIMIT $4`
+
+	q := receiversQuery
+	if givers {
+		q = giversQuery
+	}
+
+	rows, err := common.PQ.QueryContext(ctx, q, guildID, since, until, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*RankEntry, 0, limit)
+	for rows.Next() {
+		entry := &RankEntry{Rank: len(result) + 1}
+		err = rows.Scan(&entry.UserID, &entry.Points)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, entry)
+	}
+
+	return result, rows.Err()
+}
+
+func formatPeriodRanks(entries []*RankEntry) string {
+	if len(entries) < 1 {
+		return "Nobody"
+	}
+
+	var out strings.Builder
+	for _, v := range entries {
+		out.WriteString(fmt.Sprintf("`#%d` <@%d> - **%d**\n", v.Rank, v.UserID, v.Points))
+	}
+
+	return out.String()
+}
+
+// periodLeaderboardEmbed creates the embed with the top receivers and givers between start and end
+func periodLeaderboardEmbed(ctx context.Context, guildID int64, pointsName string, title string, start, end time.Time, limit int) (*discordgo.MessageEmbed, error) {
+	receivers, err := PeriodTopUsers(ctx, guildID, start, end, false, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	givers, err := PeriodTopUsers(ctx, guildID, start, end, true, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &discordgo.MessageEmbed{
+		Title: title,
+		Color: 0x4cb7e4,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "Top receivers", Value: formatPeriodRanks(receivers), Inline: true},
+			{Name: "Top givers", Value: formatPeriodRanks(givers), Inline: true},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("%s given from %s to %s UTC", pointsName, start.Format("Jan 02"), end.Add(-time.Second).Format("Jan 02")),
+		},
+	}, nil
+}
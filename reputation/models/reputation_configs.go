@@ -44,6 +44,10 @@ type ReputationConfig struct {
 	RequiredReceiveRoles      types.Int64Array `boil:"required_receive_roles" json:"required_receive_roles,omitempty" toml:"required_receive_roles" yaml:"required_receive_roles,omitempty"`
 	BlacklistedGiveRoles      types.Int64Array `boil:"blacklisted_give_roles" json:"blacklisted_give_roles,omitempty" toml:"blacklisted_give_roles" yaml:"blacklisted_give_roles,omitempty"`
 	BlacklistedReceiveRoles   types.Int64Array `boil:"blacklisted_receive_roles" json:"blacklisted_receive_roles,omitempty" toml:"blacklisted_receive_roles" yaml:"blacklisted_receive_roles,omitempty"`
+	DecayPercent              int              `boil:"decay_percent" json:"decay_percent" toml:"decay_percent" yaml:"decay_percent"`
+	DecayInactiveDays         int              `boil:"decay_inactive_days" json:"decay_inactive_days" toml:"decay_inactive_days" yaml:"decay_inactive_days"`
+	LeaderboardChannel        int64            `boil:"leaderboard_channel" json:"leaderboard_channel" toml:"leaderboard_channel" yaml:"leaderboard_channel"`
+	LeaderboardPeriod         int              `boil:"leaderboard_period" json:"leaderboard_period" toml:"leaderboard_period" yaml:"leaderboard_period"`
 
 	R *reputationConfigR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L reputationConfigL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -69,6 +73,10 @@ var ReputationConfigColumns = struct {
 	RequiredReceiveRoles      string
 	BlacklistedGiveRoles      string
 	BlacklistedReceiveRoles   string
+	DecayPercent              string
+	DecayInactiveDays         string
+	LeaderboardChannel        string
+	LeaderboardPeriod         string
 }{
 	GuildID:                   "guild_id",
 	PointsName:                "points_name",
@@ -89,6 +97,10 @@ var ReputationConfigColumns = struct {
 	RequiredReceiveRoles:      "required_receive_roles",
 	BlacklistedGiveRoles:      "blacklisted_give_roles",
 	BlacklistedReceiveRoles:   "blacklisted_receive_roles",
+	DecayPercent:              "decay_percent",
+	DecayInactiveDays:         "decay_inactive_days",
+	LeaderboardChannel:        "leaderboard_channel",
+	LeaderboardPeriod:         "leaderboard_period",
 }
 
 var ReputationConfigTableColumns = struct {
@@ -111,6 +123,10 @@ var ReputationConfigTableColumns = struct {
 	RequiredReceiveRoles      string
 	BlacklistedGiveRoles      string
 	BlacklistedReceiveRoles   string
+	DecayPercent              string
+	DecayInactiveDays         string
+	LeaderboardChannel        string
+	LeaderboardPeriod         string
 }{
 	GuildID:                   "reputation_configs.guild_id",
 	PointsName:                "reputation_configs.points_name",
@@ -131,6 +147,10 @@ var ReputationConfigTableColumns = struct {
 	RequiredReceiveRoles:      "reputation_configs.required_receive_roles",
 	BlacklistedGiveRoles:      "reputation_configs.blacklisted_give_roles",
 	BlacklistedReceiveRoles:   "reputation_configs.blacklisted_receive_roles",
+	DecayPercent:              "reputation_configs.decay_percent",
+	DecayInactiveDays:         "reputation_configs.decay_inactive_days",
+	LeaderboardChannel:        "reputation_configs.leaderboard_channel",
+	LeaderboardPeriod:         "reputation_configs.leaderboard_period",
 }
 
 // Generated where
@@ -311,6 +331,10 @@ var ReputationConfigWhere = struct {
 	RequiredReceiveRoles      whereHelpertypes_Int64Array
 	BlacklistedGiveRoles      whereHelpertypes_Int64Array
 	BlacklistedReceiveRoles   whereHelpertypes_Int64Array
+	DecayPercent              whereHelperint
+	DecayInactiveDays         whereHelperint
+	LeaderboardChannel        whereHelperint64
+	LeaderboardPeriod         whereHelperint
 }{
 	GuildID:                   whereHelperint64{field: "\"reputation_configs\".\"guild_id\""},
 	PointsName:                whereHelperstring{field: "\"reputation_configs\".\"points_name\""},
@@ -331,6 +355,10 @@ var ReputationConfigWhere = struct {
 	RequiredReceiveRoles:      whereHelpertypes_Int64Array{field: "\"reputation_configs\".\"required_receive_roles\""},
 	BlacklistedGiveRoles:      whereHelpertypes_Int64Array{field: "\"reputation_configs\".\"blacklisted_give_roles\""},
 	BlacklistedReceiveRoles:   whereHelpertypes_Int64Array{field: "\"reputation_configs\".\"blacklisted_receive_roles\""},
+	DecayPercent:              whereHelperint{field: "\"reputation_configs\".\"decay_percent\""},
+	DecayInactiveDays:         whereHelperint{field: "\"reputation_configs\".\"decay_inactive_days\""},
+	LeaderboardChannel:        whereHelperint64{field: "\"reputation_configs\".\"leaderboard_channel\""},
+	LeaderboardPeriod:         whereHelperint{field: "\"reputation_configs\".\"leaderboard_period\""},
 }
 
 // ReputationConfigRels is where relationship names are stored.
@@ -350,9 +378,9 @@ func (*reputationConfigR) NewStruct() *reputationConfigR {
 type reputationConfigL struct{}
 
 var (
-	reputationConfigAllColumns            = []string{"guild_id", "points_name", "enabled", "cooldown", "max_give_amount", "required_give_role", "required_receive_role", "blacklisted_give_role", "blacklisted_receive_role", "admin_role", "disable_thanks_detection", "whitelisted_thanks_channels", "blacklisted_thanks_channels", "max_remove_amount", "admin_roles", "required_give_roles", "required_receive_roles", "blacklisted_give_roles", "blacklisted_receive_roles"}
+	reputationConfigAllColumns            = []string{"guild_id", "points_name", "enabled", "cooldown", "max_give_amount", "required_give_role", "required_receive_role", "blacklisted_give_role", "blacklisted_receive_role", "admin_role", "disable_thanks_detection", "whitelisted_thanks_channels", "blacklisted_thanks_channels", "max_remove_amount", "admin_roles", "required_give_roles", "required_receive_roles", "blacklisted_give_roles", "blacklisted_receive_roles", "decay_percent", "decay_inactive_days", "leaderboard_channel", "leaderboard_period"}
 	reputationConfigColumnsWithoutDefault = []string{"guild_id", "points_name", "enabled", "cooldown", "max_give_amount"}
-	reputationConfigColumnsWithDefault    = []string{"required_give_role", "required_receive_role", "blacklisted_give_role", "blacklisted_receive_role", "admin_role", "disable_thanks_detection", "whitelisted_thanks_channels", "blacklisted_thanks_channels", "max_remove_amount", "admin_roles", "required_give_roles", "required_receive_roles", "blacklisted_give_roles", "blacklisted_receive_roles"}
+	reputationConfigColumnsWithDefault    = []string{"required_give_role", "required_receive_role", "blacklisted_give_role", "blacklisted_receive_role", "admin_role", "disable_thanks_detection", "whitelisted_thanks_channels", "blacklisted_thanks_channels", "max_remove_amount", "admin_roles", "required_give_roles", "required_receive_roles", "blacklisted_give_roles", "blacklisted_receive_roles", "decay_percent", "decay_inactive_days", "leaderboard_channel", "leaderboard_period"}
 	reputationConfigPrimaryKeyColumns     = []string{"guild_id"}
 	reputationConfigGeneratedColumns      = []string{}
 )
@@ -23,51 +23,58 @@ import (
 
 // ReputationUser is an object representing the database table.
 type ReputationUser struct {
-	UserID    int64     `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
-	GuildID   int64     `boil:"guild_id" json:"guild_id" toml:"guild_id" yaml:"guild_id"`
-	CreatedAt time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	Points    int64     `boil:"points" json:"points" toml:"points" yaml:"points"`
+	UserID        int64     `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	GuildID       int64     `boil:"guild_id" json:"guild_id" toml:"guild_id" yaml:"guild_id"`
+	CreatedAt     time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	Points        int64     `boil:"points" json:"points" toml:"points" yaml:"points"`
+	InactiveSince time.Time `boil:"inactive_since" json:"inactive_since" toml:"inactive_since" yaml:"inactive_since"`
 
 	R *reputationUserR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L reputationUserL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var ReputationUserColumns = struct {
-	UserID    string
-	GuildID   string
-	CreatedAt string
-	Points    string
+	UserID        string
+	GuildID       string
+	CreatedAt     string
+	Points        string
+	InactiveSince string
 }{
-	UserID:    "user_id",
-	GuildID:   "guild_id",
-	CreatedAt: "created_at",
-	Points:    "points",
+	UserID:        "user_id",
+	GuildID:       "guild_id",
+	CreatedAt:     "created_at",
+	Points:        "points",
+	InactiveSince: "inactive_since",
 }
 
 var ReputationUserTableColumns = struct {
-	UserID    string
-	GuildID   string
-	CreatedAt string
-	Points    string
+	UserID        string
+	GuildID       string
+	CreatedAt     string
+	Points        string
+	InactiveSince string
 }{
-	UserID:    "reputation_users.user_id",
-	GuildID:   "reputation_users.guild_id",
-	CreatedAt: "reputation_users.created_at",
-	Points:    "reputation_users.points",
+	UserID:        "reputation_users.user_id",
+	GuildID:       "reputation_users.guild_id",
+	CreatedAt:     "reputation_users.created_at",
+	Points:        "reputation_users.points",
+	InactiveSince: "reputation_users.inactive_since",
 }
 
 // Generated where
 
 var ReputationUserWhere = struct {
-	UserID    whereHelperint64
-	GuildID   whereHelperint64
-	CreatedAt whereHelpertime_Time
-	Points    whereHelperint64
+	UserID        whereHelperint64
+	GuildID       whereHelperint64
+	CreatedAt     whereHelpertime_Time
+	Points        whereHelperint64
+	InactiveSince whereHelpertime_Time
 }{
-	UserID:    whereHelperint64{field: "\"reputation_users\".\"user_id\""},
-	GuildID:   whereHelperint64{field: "\"reputation_users\".\"guild_id\""},
-	CreatedAt: whereHelpertime_Time{field: "\"reputation_users\".\"created_at\""},
-	Points:    whereHelperint64{field: "\"reputation_users\".\"points\""},
+	UserID:        whereHelperint64{field: "\"reputation_users\".\"user_id\""},
+	GuildID:       whereHelperint64{field: "\"reputation_users\".\"guild_id\""},
+	CreatedAt:     whereHelpertime_Time{field: "\"reputation_users\".\"created_at\""},
+	Points:        whereHelperint64{field: "\"reputation_users\".\"points\""},
+	InactiveSince: whereHelpertime_Time{field: "\"reputation_users\".\"inactive_since\""},
 }
 
 // ReputationUserRels is where relationship names are stored.
@@ -87,9 +94,9 @@ func (*reputationUserR) NewStruct() *reputationUserR {
 type reputationUserL struct{}
 
 var (
-	reputationUserAllColumns            = []string{"user_id", "guild_id", "created_at", "points"}
+	reputationUserAllColumns            = []string{"user_id", "guild_id", "created_at", "points", "inactive_since"}
 	reputationUserColumnsWithoutDefault = []string{"user_id", "guild_id", "created_at", "points"}
-	reputationUserColumnsWithDefault    = []string{}
+	reputationUserColumnsWithDefault    = []string{"inactive_since"}
 	reputationUserPrimaryKeyColumns     = []string{"guild_id", "user_id"}
 	reputationUserGeneratedColumns      = []string{}
 )
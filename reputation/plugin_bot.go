@@ -1,11 +1,13 @@
 package reputation
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/botlabs-gg/yagpdb/v2/analytics"
 	"github.com/botlabs-gg/yagpdb/v2/bot/paginatedmessages"
@@ -203,6 +205,10 @@ var cmds = []*commands.YAGCommand{
 				return nil, err
 			}
 
+			if targetMember != nil {
+				go UpdateRewardRoles(context.Background(), targetMember, int64(parsed.Args[1].Int()))
+			}
+
 			return fmt.Sprintf("Set **%s** %s to `%d`", targetUsername, conf.PointsName, parsed.Args[1].Int()), nil
 		},
 	},
@@ -237,6 +243,10 @@ var cmds = []*commands.YAGCommand{
 				return nil, err
 			}
 
+			if targetMember, _ := bot.GetMember(parsed.GuildData.GS.ID, target); targetMember != nil {
+				go UpdateRewardRoles(context.Background(), targetMember, 0)
+			}
+
 			return fmt.Sprintf("Deleted all of %d's %s.", target, conf.PointsName), nil
 		},
 	},
@@ -391,10 +401,27 @@ var cmds = []*commands.YAGCommand{
 		},
 		ArgSwitches: []*dcmd.ArgDef{
 			{Name: "user", Help: "User to search for in the leaderboard", Type: dcmd.UserID},
+			{Name: "week", Help: "Show who received and gave the most this week"},
+			{Name: "month", Help: "Show who received and gave the most this month"},
 		},
 		SlashCommandEnabled: true,
 		DefaultEnabled:      true,
 		RunFunc: func(parsed *dcmd.Data) (interface{}, error) {
+			if parsed.Switch("week").Bool() || parsed.Switch("month").Bool() {
+				period := LeaderboardPeriodWeekly
+				if parsed.Switch("month").Bool() {
+					period = LeaderboardPeriodMonthly
+				}
+
+				conf, err := GetConfig(parsed.Context(), parsed.GuildData.GS.ID)
+				if err != nil {
+					return nil, err
+				}
+
+				start, end := LeaderboardWindow(period, time.Now())
+				return periodLeaderboardEmbed(parsed.Context(), parsed.GuildData.GS.ID, conf.PointsName, "Reputation leaderboard of this "+periodName(period), start, end, 10)
+			}
+
 			page := parsed.Args[0].Int()
 			if id := parsed.Switch("user").Int64(); id != 0 {
 				const query = `
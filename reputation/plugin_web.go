@@ -1,6 +1,7 @@
 package reputation
 
 import (
+	"context"
 	_ "embed"
 	"fmt"
 	"html"
@@ -40,6 +41,15 @@ type PostConfigForm struct {
 	AdminRoles                []int64 `valid:"role,true"`
 	WhitelistedThanksChannels []int64 `valid:"channel,true"`
 	BlacklistedThanksChannels []int64 `valid:"channel,true"`
+	DecayPercent              int     `valid:"0,100"`
+	DecayInactiveDays         int     `valid:"1,365"`
+	LeaderboardChannel        int64   `valid:"channel,true"`
+	LeaderboardPeriod         int     `valid:"0,2"`
+}
+
+type RoleRewardForm struct {
+	Points int64 `valid:"1,"`
+	Role   int64 `valid:"role,false"`
 }
 
 func (p PostConfigForm) RepConfig() *models.ReputationConfig {
@@ -57,12 +67,18 @@ func (p PostConfigForm) RepConfig() *models.ReputationConfig {
 		DisableThanksDetection:    !p.EnableThanksDetection,
 		WhitelistedThanksChannels: p.WhitelistedThanksChannels,
 		BlacklistedThanksChannels: p.BlacklistedThanksChannels,
+		DecayPercent:              p.DecayPercent,
+		DecayInactiveDays:         p.DecayInactiveDays,
+		LeaderboardChannel:        p.LeaderboardChannel,
+		LeaderboardPeriod:         p.LeaderboardPeriod,
 	}
 }
 
 var (
 	panelLogKeyUpdatedSettings = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "reputation_settings_updated", FormatString: "Updated reputation settings"})
 	panelLogKeyResetReputation = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "reputation_reset_reputation", FormatString: "Reset reputation"})
+	panelLogKeyAddedReward     = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "reputation_added_reward", FormatString: "Added reputation role reward at %d points"})
+	panelLogKeyRemovedReward   = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "reputation_removed_reward", FormatString: "Removed reputation role reward"})
 )
 
 func (p *Plugin) InitWeb() {
@@ -86,8 +102,41 @@ func (p *Plugin) InitWeb() {
 	subMux.Handle(pat.Post(""), web.ControllerPostHandler(HandlePostReputation, mainGetHandler, PostConfigForm{}))
 	subMux.Handle(pat.Post("/"), web.ControllerPostHandler(HandlePostReputation, mainGetHandler, PostConfigForm{}))
 	subMux.Handle(pat.Post("/reset_users"), web.ControllerPostHandler(HandleResetReputation, mainGetHandler, nil))
+	subMux.Handle(pat.Post("/rewards"), web.ControllerPostHandler(HandleAddRoleReward, mainGetHandler, RoleRewardForm{}))
+	subMux.Handle(pat.Post("/rewards/:item/delete"), web.ControllerPostHandler(HandleRemoveRoleReward, mainGetHandler, nil))
 	subMux.Handle(pat.Get("/logs"), web.APIHandler(HandleLogsJson))
 
+	web.APIMux.Handle(pat.Get("/reputation"), web.APIConfigHandler(p, "reputation"))
+	web.APIMux.Handle(pat.Put("/reputation"), web.APIWriteHandler(p, web.APIFromController(HandlePostReputation), PostConfigForm{}))
+
+	cplogs.RegisterConfigSource(&cplogs.ConfigSource{
+		Key:  "reputation",
+		Name: "Reputation",
+		Page: "reputation",
+		Get: func(ctx context.Context, guildID int64) (interface{}, error) {
+			return GetConfig(ctx, guildID)
+		},
+		Revert: func(ctx context.Context, guildID int64, snapshot string) error {
+			conf, err := GetConfig(ctx, guildID)
+			if err != nil {
+				return err
+			}
+
+			if err = cplogs.RestoreSnapshot(conf, snapshot); err != nil {
+				return err
+			}
+
+			conf.GuildID = guildID
+			err = conf.UpsertG(ctx, true, []string{"guild_id"}, boil.Infer(), boil.Infer())
+			if err != nil {
+				return err
+			}
+
+			featureflags.MarkGuildDirty(guildID)
+			return nil
+		},
+	})
+
 	web.ServerPublicMux.Handle(pat.Get("/reputation/leaderboard"), web.RenderHandler(HandleGetReputation, "cp_reputation_leaderboard"))
 	web.ServerPublicAPIMux.Handle(pat.Get("/reputation/leaderboard"), web.APIHandler(HandleLeaderboardJson))
 }
@@ -102,6 +151,12 @@ func HandleGetReputation(w http.ResponseWriter, r *http.Request) interface{} {
 		}
 	}
 
+	rewards, err := GuildRoleRewards(r.Context(), activeGuild.ID)
+	if !web.CheckErr(templateData, err, "Failed retrieving role rewards", web.CtxLogger(r.Context()).Error) {
+		templateData["RoleRewards"] = rewards
+	}
+	templateData["MaxRoleRewards"] = MaxRoleRewards
+
 	return templateData
 }
 
@@ -129,6 +184,10 @@ func HandlePostReputation(w http.ResponseWriter, r *http.Request) (templateData
 		"disable_thanks_detection",
 		"whitelisted_thanks_channels",
 		"blacklisted_thanks_channels",
+		"decay_percent",
+		"decay_inactive_days",
+		"leaderboard_channel",
+		"leaderboard_period",
 	), boil.Infer())
 
 	if err == nil {
@@ -150,6 +209,52 @@ func HandleResetReputation(w http.ResponseWriter, r *http.Request) (templateData
 	return templateData, err
 }
 
+func HandleAddRoleReward(w http.ResponseWriter, r *http.Request) (templateData web.TemplateData, err error) {
+	activeGuild, templateData := web.GetBaseCPContextData(r.Context())
+	templateData["VisibleURL"] = "/manage/" + discordgo.StrID(activeGuild.ID) + "/reputation"
+
+	form := r.Context().Value(common.ContextKeyParsedForm).(*RoleRewardForm)
+
+	current, err := GuildRoleRewards(r.Context(), activeGuild.ID)
+	if err != nil {
+		return templateData, err
+	}
+
+	if len(current) >= MaxRoleRewards {
+		return templateData.AddAlerts(web.ErrorAlert(fmt.Sprintf("Max %d role rewards allowed", MaxRoleRewards))), nil
+	}
+
+	reward := &RoleReward{
+		GuildID: activeGuild.ID,
+		Points:  form.Points,
+		RoleID:  form.Role,
+	}
+
+	err = reward.Insert(r.Context())
+	if err == nil {
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyAddedReward, &cplogs.Param{Type: cplogs.ParamTypeInt, Value: form.Points}))
+	}
+
+	return templateData, err
+}
+
+func HandleRemoveRoleReward(w http.ResponseWriter, r *http.Request) (templateData web.TemplateData, err error) {
+	activeGuild, templateData := web.GetBaseCPContextData(r.Context())
+	templateData["VisibleURL"] = "/manage/" + discordgo.StrID(activeGuild.ID) + "/reputation"
+
+	id, err := strconv.ParseInt(pat.Param(r, "item"), 10, 64)
+	if err != nil {
+		return templateData.AddAlerts(web.ErrorAlert("Unknown id")), nil
+	}
+
+	err = DeleteRoleReward(r.Context(), activeGuild.ID, id)
+	if err == nil {
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyRemovedReward))
+	}
+
+	return templateData, err
+}
+
 func HandleLeaderboardJson(w http.ResponseWriter, r *http.Request) interface{} {
 	activeGuild, _ := web.GetBaseCPContextData(r.Context())
 
@@ -7,6 +7,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	"emperror.dev/errors"
@@ -14,6 +15,7 @@ import (
 	"github.com/botlabs-gg/yagpdb/v2/bot/botrest"
 	"github.com/botlabs-gg/yagpdb/v2/common"
 	"github.com/botlabs-gg/yagpdb/v2/common/featureflags"
+	"github.com/botlabs-gg/yagpdb/v2/common/mqueue"
 	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
 	"github.com/botlabs-gg/yagpdb/v2/lib/dstate"
 	"github.com/botlabs-gg/yagpdb/v2/reputation/models"
@@ -26,14 +28,19 @@ var logger = common.GetPluginLogger(&Plugin{})
 
 func RegisterPlugin() {
 
-	plugin := &Plugin{}
+	plugin := &Plugin{
+		stopWorker: make(chan *sync.WaitGroup),
+	}
 
 	common.InitSchemas("reputation", DBSchemas...)
 
 	common.RegisterPlugin(plugin)
+	mqueue.RegisterSource("reputation", plugin)
 }
 
-type Plugin struct{}
+type Plugin struct {
+	stopWorker chan *sync.WaitGroup
+}
 
 func (p *Plugin) PluginInfo() *common.PluginInfo {
 	return &common.PluginInfo{
@@ -51,6 +58,8 @@ func DefaultConfig(guildID int64) *models.ReputationConfig {
 		Cooldown:        120,
 		MaxGiveAmount:   1,
 		MaxRemoveAmount: 1,
+
+		DecayInactiveDays: 30,
 	}
 }
 
@@ -172,13 +181,21 @@ func ModifyRep(ctx context.Context, conf *models.ReputationConfig, guildID int64
 		return
 	}
 
-	err = insertUpdateUserRep(ctx, guildID, receiver.User.ID, amount)
+	newPoints, err := insertUpdateUserRep(ctx, guildID, receiver.User.ID, amount)
 	if err != nil {
 		// Clear the cooldown since it failed updating the rep
 		ClearCooldown(guildID, sender.User.ID)
 		return
 	}
 
+	// giving rep also counts as activity for the decay
+	_, err = common.PQ.ExecContext(ctx, `UPDATE reputation_users SET inactive_since = now() WHERE guild_id = $1 AND user_id = $2`, guildID, sender.User.ID)
+	if err != nil {
+		logger.WithError(err).WithField("guild", guildID).Error("Failed updating reputation activity")
+	}
+
+	go UpdateRewardRoles(context.Background(), receiver, newPoints)
+
 	receiverUsername := receiver.User.String()
 	senderUsername := sender.User.String()
 
@@ -201,16 +218,17 @@ func ModifyRep(ctx context.Context, conf *models.ReputationConfig, guildID int64
 	return
 }
 
-func insertUpdateUserRep(ctx context.Context, guildID, userID int64, amount int64) (err error) {
+func insertUpdateUserRep(ctx context.Context, guildID, userID int64, amount int64) (points int64, err error) {
 
 	// upsert query which is too advanced for orms
 	const query = `
-INSERT INTO reputation_users (created_at, guild_id, user_id, points)
-VALUES ($1, $2, $3, $4)
+INSERT INTO reputation_users (created_at, guild_id, user_id, points, inactive_since)
+VALUES ($1, $2, $3, $4, $1)
 ON CONFLICT (guild_id, user_id)
-DO UPDATE SET points = reputation_users.points + $4;
+DO UPDATE SET points = reputation_users.points + $4, inactive_since = $1
+RETURNING points;
 `
-	_, err = common.PQ.ExecContext(ctx, query, time.Now(), guildID, userID, amount)
+	err = common.PQ.QueryRowContext(ctx, query, time.Now(), guildID, userID, amount).Scan(&points)
 	return
 }
 
@@ -257,12 +275,13 @@ func IsAdmin(gs *dstate.GuildSet, member *dstate.MemberState, config *models.Rep
 
 func SetRep(ctx context.Context, gid int64, senderID, userID int64, points int64) error {
 	user := &models.ReputationUser{
-		GuildID: gid,
-		UserID:  userID,
-		Points:  points,
+		GuildID:       gid,
+		UserID:        userID,
+		Points:        points,
+		InactiveSince: time.Now(),
 	}
 
-	err := user.UpsertG(ctx, true, []string{"guild_id", "user_id"}, boil.Whitelist("points"), boil.Infer())
+	err := user.UpsertG(ctx, true, []string{"guild_id", "user_id"}, boil.Whitelist("points", "inactive_since"), boil.Infer())
 	if err != nil {
 		return err
 	}
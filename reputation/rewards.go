@@ -0,0 +1,110 @@
+package reputation
+
+import (
+	"context"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+	"github.com/botlabs-gg/yagpdb/v2/lib/dstate"
+)
+
+const MaxRoleRewards = 25
+
+// RoleReward is a role given to members once they have atleast Points reputation,
+// it's taken away again if they drop below it
+type RoleReward struct {
+	ID      int64
+	GuildID int64
+	Points  int64
+	RoleID  int64
+}
+
+// GuildRoleRewards returns the role rewards of the guild ordered by points
+func GuildRoleRewards(ctx context.Context, guildID int64) ([]*RoleReward, error) {
+	rows, err := common.PQ.QueryContext(ctx, `SELECT id, guild_id, points, role_id FROM reputation_role_rewards WHERE guild_id = $1 ORDER BY points ASC, id ASC`, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*RoleReward, 0)
+	for rows.Next() {
+		r := &RoleReward{}
+		err = rows.Scan(&r.ID, &r.GuildID, &r.Points, &r.RoleID)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, r)
+	}
+
+	return result, rows.Err()
+}
+
+// Insert adds the reward, or updates the points needed if the role is already a reward
+func (r *RoleReward) Insert(ctx context.Context) error {
+	const q = `INSERT INTO reputation_role_rewards (guild_id, points, role_id) VALUES ($1, $2, $3)
+ON CONFLICT (guild_id, role_id) DO UPDATE SET points = $2
+RETURNING id`
+
+	return common.PQ.QueryRowContext(ctx, q, r.GuildID, r.Points, r.RoleID).Scan(&r.ID)
+}
+
+func DeleteRoleReward(ctx context.Context, guildID, id int64) error {
+	_, err := common.PQ.ExecContext(ctx, `DELETE FROM reputation_role_rewards WHERE guild_id = $1 AND id = $2`, guildID, id)
+	return err
+}
+
+// RewardRoleChanges returns the reward roles to give and take away from a member with the current roles and points
+func RewardRoleChanges(rewards []*RoleReward, points int64, currentRoles []int64) (add, remove []int64) {
+	for _, v := range rewards {
+		has := common.ContainsInt64Slice(currentRoles, v.RoleID)
+		if v.Points <= points && !has {
+			add = append(add, v.RoleID)
+		} else if v.Points > points && has {
+			remove = append(remove, v.RoleID)
+		}
+	}
+
+	return
+}
+
+// RewardsLost returns the reward roles a member went below the points of when going from oldPoints to newPoints
+func RewardsLost(rewards []*RoleReward, oldPoints, newPoints int64) []int64 {
+	var result []int64
+	for _, v := range rewards {
+		if v.Points <= oldPoints && v.Points > newPoints {
+			result = append(result, v.RoleID)
+		}
+	}
+
+	return result
+}
+
+// UpdateRewardRoles gives and takes away the reward roles of the member based on their points
+func UpdateRewardRoles(ctx context.Context, ms *dstate.MemberState, points int64) {
+	rewards, err := GuildRoleRewards(ctx, ms.GuildID)
+	if err != nil {
+		logger.WithError(err).WithField("guild", ms.GuildID).Error("Failed retrieving reputation role rewards")
+		return
+	}
+
+	add, remove := RewardRoleChanges(rewards, points, ms.Member.Roles)
+	for _, v := range add {
+		err := common.BotSession.GuildMemberRoleAdd(ms.GuildID, ms.User.ID, v)
+		if err != nil && !common.IsDiscordErr(err, discordgo.ErrCodeMissingPermissions, discordgo.ErrCodeUnknownRole) {
+			logger.WithError(err).WithField("guild", ms.GuildID).Error("Failed giving reputation reward role")
+		}
+	}
+
+	for _, v := range remove {
+		removeRewardRole(ms.GuildID, ms.User.ID, v)
+	}
+}
+
+func removeRewardRole(guildID, userID, roleID int64) {
+	err := common.BotSession.GuildMemberRoleRemove(guildID, userID, roleID)
+	if err != nil && !common.IsDiscordErr(err, discordgo.ErrCodeMissingPermissions, discordgo.ErrCodeUnknownRole, discordgo.ErrCodeUnknownMember) {
+		logger.WithError(err).WithField("guild", guildID).Error("Failed removing reputation reward role")
+	}
+}
@@ -0,0 +1,53 @@
+package reputation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRewardRoleChanges(t *testing.T) {
+	rewards := []*RoleReward{
+		{Points: 10, RoleID: 1},
+		{Points: 50, RoleID: 2},
+		{Points: 100, RoleID: 3},
+	}
+
+	add, remove := RewardRoleChanges(rewards, 60, []int64{1, 3})
+	if len(add) != 1 || add[0] != 2 || len(remove) != 1 || remove[0] != 3 {
+		t.Errorf("got add %v remove %v", add, remove)
+	}
+
+	add, remove = RewardRoleChanges(rewards, -5, []int64{1})
+	if len(add) != 0 || len(remove) != 1 || remove[0] != 1 {
+		t.Errorf("negative points: got add %v remove %v", add, remove)
+	}
+
+	lost := RewardsLost(rewards, 120, 45)
+	if len(lost) != 2 || lost[0] != 2 || lost[1] != 3 {
+		t.Errorf("RewardsLost: got %v", lost)
+	}
+}
+
+func TestLeaderboardWindow(t *testing.T) {
+	cases := []struct {
+		period int
+		t      time.Time
+		start  time.Time
+		end    time.Time
+	}{
+		// a wednesday
+		{LeaderboardPeriodWeekly, time.Date(2026, 10, 14, 15, 0, 0, 0, time.UTC), time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		// a sunday
+		{LeaderboardPeriodWeekly, time.Date(2026, 10, 18, 23, 59, 0, 0, time.UTC), time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		// a monday
+		{LeaderboardPeriodWeekly, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 26, 0, 0, 0, 0, time.UTC)},
+		{LeaderboardPeriodMonthly, time.Date(2026, 12, 31, 12, 0, 0, 0, time.UTC), time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, c := range cases {
+		start, end := LeaderboardWindow(c.period, c.t)
+		if !start.Equal(c.start) || !end.Equal(c.end) {
+			t.Errorf("LeaderboardWindow(%d, %s) = %s - %s, expected %s - %s", c.period, c.t, start, end, c.start, c.end)
+		}
+	}
+}
@@ -90,4 +90,25 @@ CREATE INDEX IF NOT EXISTS reputation_log_guild_idx ON reputation_log (guild_id)
 CREATE INDEX IF NOT EXISTS reputation_log_sender_idx ON reputation_log (sender_id);
 `, `
 CREATE INDEX IF NOT EXISTS reputation_log_receiver_idx ON reputation_log (receiver_id);	
+`, `
+ALTER TABLE reputation_configs ADD COLUMN IF NOT EXISTS decay_percent INT NOT NULL DEFAULT 0;
+`, `
+ALTER TABLE reputation_configs ADD COLUMN IF NOT EXISTS decay_inactive_days INT NOT NULL DEFAULT 30;
+`, `
+ALTER TABLE reputation_configs ADD COLUMN IF NOT EXISTS leaderboard_channel BIGINT NOT NULL DEFAULT 0;
+`, `
+ALTER TABLE reputation_configs ADD COLUMN IF NOT EXISTS leaderboard_period INT NOT NULL DEFAULT 0;
+`, `
+ALTER TABLE reputation_users ADD COLUMN IF NOT EXISTS inactive_since TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now();
+`, `
+CREATE INDEX IF NOT EXISTS reputation_log_guild_created_idx ON reputation_log (guild_id, created_at);
+`, `
+CREATE TABLE IF NOT EXISTS reputation_role_rewards (
+	id       BIGSERIAL PRIMARY KEY,
+	guild_id BIGINT NOT NULL,
+	points   BIGINT NOT NULL,
+	role_id  BIGINT NOT NULL,
+
+	UNIQUE(guild_id, role_id)
+);
 `}
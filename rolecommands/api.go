@@ -0,0 +1,124 @@
+package rolecommands
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/rolecommands/models"
+	"github.com/botlabs-gg/yagpdb/v2/web"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"goji.io/pat"
+)
+
+func (p *Plugin) initAPI() {
+	web.APIMux.Handle(pat.Get("/rolecommands"), web.APIReadHandler(p, p.apiGetRoleCommands))
+
+	web.APIMux.Handle(pat.Post("/rolecommands/commands"), web.APIWriteHandler(p, apiCreateCommand, FormCommand{}))
+	web.APIMux.Handle(pat.Put("/rolecommands/commands/:id"), web.APIWriteHandler(p, apiUpdateCommand, FormCommand{}))
+	web.APIMux.Handle(pat.Delete("/rolecommands/commands/:id"), web.APIWriteHandler(p, apiFormIDHandler(HandleRemoveCommand), nil))
+
+	web.APIMux.Handle(pat.Post("/rolecommands/groups"), web.APIWriteHandler(p, apiCreateGroup, FormGroup{}))
+	web.APIMux.Handle(pat.Put("/rolecommands/groups/:id"), web.APIWriteHandler(p, apiUpdateGroup, FormGroup{}))
+	web.APIMux.Handle(pat.Delete("/rolecommands/groups/:id"), web.APIWriteHandler(p, apiFormIDHandler(HandleRemoveGroup), nil))
+}
+
+// apiGetRoleCommands returns all the groups and commands, in the same format as the backups
+func (p *Plugin) apiGetRoleCommands(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	g := web.ContextGuild(r.Context())
+	return p.ExportBackup(r.Context(), g.ID)
+}
+
+// apiFormIDHandler runs a control panel handler that reads the id from the form values with the id from the url
+func apiFormIDHandler(inner web.ControllerHandlerFunc) web.ControllerHandlerFuncJson {
+	return func(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+		id, err := web.APIParamInt64(r, "id")
+		if err != nil {
+			return nil, err
+		}
+
+		r.Form = url.Values{"ID": []string{strconv.FormatInt(id, 10)}}
+		return web.APIFromController(inner)(w, r)
+	}
+}
+
+func apiCreateCommand(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	ctx := r.Context()
+	g := web.ContextGuild(ctx)
+
+	form := ctx.Value(common.ContextKeyParsedForm).(*FormCommand)
+	if form.Group == 0 {
+		// ungrouped is -1 in the control panel
+		form.Group = -1
+	}
+
+	_, err := web.APIFromController(HandleNewCommand)(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.RoleCommands(qm.Where("guild_id = ?", g.ID), qm.Where("name ILIKE ?", form.Name)).OneG(ctx)
+}
+
+func apiUpdateCommand(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	ctx := r.Context()
+
+	id, err := web.APIParamInt64(r, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	form := ctx.Value(common.ContextKeyParsedForm).(*FormCommand)
+	form.ID = id
+	if form.Group == 0 {
+		form.Group = -1
+	}
+
+	_, err = web.APIFromController(HandleUpdateCommand)(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.FindRoleCommandG(ctx, id)
+}
+
+func apiCreateGroup(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	ctx := r.Context()
+	_, tmpl := web.GetBaseCPContextData(ctx)
+
+	_, err := web.APIFromController(HandleNewGroup)(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	form := ctx.Value(common.ContextKeyParsedForm).(*FormGroup)
+	form.ID, _ = tmpl["GroupID"].(int64)
+
+	// the temporary role duration is only set when updating groups in the control panel
+	_, err = web.APIFromController(HandleUpdateGroup)(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.FindRoleGroupG(ctx, form.ID)
+}
+
+func apiUpdateGroup(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	ctx := r.Context()
+
+	id, err := web.APIParamInt64(r, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	form := ctx.Value(common.ContextKeyParsedForm).(*FormGroup)
+	form.ID = id
+
+	_, err = web.APIFromController(HandleUpdateGroup)(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.FindRoleGroupG(ctx, id)
+}
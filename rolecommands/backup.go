@@ -0,0 +1,176 @@
+package rolecommands
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/rolecommands/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+type roleCommandsBackup struct {
+	Groups   []*models.RoleGroup
+	Commands []*models.RoleCommand
+}
+
+var _ common.PluginWithBackup = (*Plugin)(nil)
+
+func (p *Plugin) BackupVersion() int {
+	return 1
+}
+
+func (p *Plugin) ExportBackup(ctx context.Context, guildID int64) (interface{}, error) {
+	groups, err := models.RoleGroups(qm.Where("guild_id = ?", guildID), qm.OrderBy("id asc")).AllG(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	commands, err := models.RoleCommands(qm.Where("guild_id = ?", guildID), qm.OrderBy("id asc")).AllG(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &roleCommandsBackup{
+		Groups:   groups,
+		Commands: commands,
+	}, nil
+}
+
+// RestoreBackup updates the groups and commands with matching names and creates the rest. Nothing is deleted as
+// role menus are tied to messages and can't be restored, removing their groups or commands would break them.
+func (p *Plugin) RestoreBackup(ctx context.Context, guildID int64, data json.RawMessage, restore *common.BackupRestore) error {
+	var backup roleCommandsBackup
+	err := json.Unmarshal(data, &backup)
+	if err != nil {
+		return err
+	}
+
+	tx, err := common.PQ.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	currentGroups, err := models.RoleGroups(qm.Where("guild_id = ?", guildID)).All(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	groupIDs := make(map[int64]int64)
+	numGroups := len(currentGroups)
+	skippedGroups := 0
+	for _, v := range backup.Groups {
+		oldID := v.ID
+
+		v.GuildID = guildID
+		v.RequireRoles = restore.Roles(v.RequireRoles)
+		v.IgnoreRoles = restore.Roles(v.IgnoreRoles)
+
+		if existing := findRoleGroupByName(currentGroups, v.Name); existing != nil {
+			v.ID = existing.ID
+			_, err = v.Update(ctx, tx, boil.Infer())
+		} else if numGroups >= MaxGroups {
+			skippedGroups++
+			continue
+		} else {
+			v.ID = 0
+			err = v.Insert(ctx, tx, boil.Infer())
+			numGroups++
+		}
+
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		groupIDs[oldID] = v.ID
+	}
+
+	if skippedGroups > 0 {
+		restore.Note("Skipped %d role groups as there can be at most %d, their commands were restored without a group", skippedGroups, MaxGroups)
+	}
+
+	currentCommands, err := models.RoleCommands(qm.Where("guild_id = ?", guildID)).All(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	numCommands := len(currentCommands)
+	skippedCommands := 0
+	for _, v := range backup.Commands {
+		v.GuildID = guildID
+		v.Role = restore.Role(v.Role)
+		v.RequireRoles = restore.Roles(v.RequireRoles)
+		v.IgnoreRoles = restore.Roles(v.IgnoreRoles)
+
+		if v.Role == 0 {
+			restore.Note("Skipped the role command %q as its role was not found", v.Name)
+			continue
+		}
+
+		if v.RoleGroupID.Valid {
+			if newID, ok := groupIDs[v.RoleGroupID.Int64]; ok {
+				v.RoleGroupID = null.Int64From(newID)
+			} else {
+				v.RoleGroupID = null.Int64{}
+			}
+		}
+
+		if existing := findRoleCommandByName(currentCommands, v.Name); existing != nil {
+			v.ID = existing.ID
+			_, err = v.Update(ctx, tx, boil.Infer())
+		} else if numCommands >= MaxCommands {
+			skippedCommands++
+			continue
+		} else {
+			v.ID = 0
+			err = v.Insert(ctx, tx, boil.Infer())
+			numCommands++
+		}
+
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if skippedCommands > 0 {
+		restore.Note("Skipped %d role commands as there can be at most %d", skippedCommands, MaxCommands)
+	}
+
+	if len(backup.Commands) > 0 {
+		restore.Note("Role menus are tied to their messages and have to be set up again")
+	}
+
+	err = restore.Finish(tx)
+	if err == nil && !restore.DryRun {
+		sendEvictMenuCachePubSub(guildID)
+	}
+
+	return err
+}
+
+func findRoleGroupByName(groups []*models.RoleGroup, name string) *models.RoleGroup {
+	for _, v := range groups {
+		if strings.EqualFold(v.Name, name) {
+			return v
+		}
+	}
+
+	return nil
+}
+
+func findRoleCommandByName(commands []*models.RoleCommand, name string) *models.RoleCommand {
+	for _, v := range commands {
+		if strings.EqualFold(v.Name, name) {
+			return v
+		}
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package rolecommands
 import (
 	"context"
 	"database/sql"
+	"fmt"
 
 	"github.com/botlabs-gg/yagpdb/v2/analytics"
 	"github.com/botlabs-gg/yagpdb/v2/bot/eventsystem"
@@ -173,6 +174,11 @@ type ScheduledMemberRoleRemoveData struct {
 	RoleID  int64 `json:"role_id"`
 }
 
+func describeRemoveMemberRole(evt *schEvtsModels.ScheduledEvent, data interface{}) string {
+	dataCast := data.(*ScheduledMemberRoleRemoveData)
+	return fmt.Sprintf("Remove the temporary role %d of role group #%d from user %d", dataCast.RoleID, dataCast.GroupID, dataCast.UserID)
+}
+
 type ScheduledEventUpdateMenuMessageData struct {
 	GuildID   int64 `json:"guild_id"`
 	MessageID int64 `json:"message_id"`
@@ -302,6 +308,10 @@ func StringCommands(cmds []*models.RoleCommand) string {
 	return output + "```\n"
 }
 
+func describeUpdateRolemenuMessage(evt *schEvtsModels.ScheduledEvent, data interface{}) string {
+	return fmt.Sprintf("Update the role menu on message %d", data.(*ScheduledEventUpdateMenuMessageData).MessageID)
+}
+
 func handleUpdateRolemenuMessage(evt *schEvtsModels.ScheduledEvent, data interface{}) (retry bool, err error) {
 	dataCast := data.(*ScheduledEventUpdateMenuMessageData)
 
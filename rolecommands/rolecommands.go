@@ -12,6 +12,7 @@ import (
 	"github.com/botlabs-gg/yagpdb/v2/bot"
 	"github.com/botlabs-gg/yagpdb/v2/commands"
 	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/scheduledevents2"
 	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
 	"github.com/botlabs-gg/yagpdb/v2/lib/dstate"
 	"github.com/botlabs-gg/yagpdb/v2/rolecommands/models"
@@ -45,6 +46,11 @@ const (
 	RoleMenuStateEditingOptionReplacing = 3
 )
 
+const (
+	MaxCommands = 1000
+	MaxGroups   = 1000
+)
+
 var (
 	_ common.Plugin            = (*Plugin)(nil)
 	_ web.Plugin               = (*Plugin)(nil)
@@ -61,6 +67,9 @@ func RegisterPlugin() {
 	common.RegisterPlugin(p)
 
 	common.InitSchemas("rolecommands", DBSchemas...)
+
+	scheduledevents2.RegisterDescriber("remove_member_role", ScheduledMemberRoleRemoveData{}, describeRemoveMemberRole)
+	scheduledevents2.RegisterDescriber("rolemenu_update_message", ScheduledEventUpdateMenuMessageData{}, describeUpdateRolemenuMessage)
 }
 
 func FindToggleRole(ctx context.Context, ms *dstate.MemberState, name string) (gaveRole bool, err error) {
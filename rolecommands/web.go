@@ -121,6 +121,8 @@ func (p *Plugin) InitWeb() {
 	subMux.Handle(pat.Post("/new_group"), web.ControllerPostHandler(HandleNewGroup, getIndexpPostHandler, FormGroup{}))
 	subMux.Handle(pat.Post("/update_group"), web.ControllerPostHandler(HandleUpdateGroup, getIndexpPostHandler, FormGroup{}))
 	subMux.Handle(pat.Post("/remove_group"), web.ControllerPostHandler(HandleRemoveGroup, getIndexpPostHandler, nil))
+
+	p.initAPI()
 }
 
 func HandleGetIndex(w http.ResponseWriter, r *http.Request) (tmpl web.TemplateData, err error) {
@@ -184,8 +186,8 @@ func HandleNewCommand(w http.ResponseWriter, r *http.Request) (web.TemplateData,
 	form := r.Context().Value(common.ContextKeyParsedForm).(*FormCommand)
 	form.Name = strings.TrimSpace(form.Name)
 
-	if c, _ := models.RoleCommands(qm.Where(models.RoleCommandColumns.GuildID+"=?", g.ID)).CountG(r.Context()); c >= 1000 {
-		tmpl.AddAlerts(web.ErrorAlert("Max 1000 role commands allowed"))
+	if c, _ := models.RoleCommands(qm.Where(models.RoleCommandColumns.GuildID+"=?", g.ID)).CountG(r.Context()); c >= MaxCommands {
+		tmpl.AddAlerts(web.ErrorAlert(fmt.Sprintf("Max %d role commands allowed", MaxCommands)))
 		return tmpl, nil
 	}
 
@@ -420,8 +422,8 @@ func HandleNewGroup(w http.ResponseWriter, r *http.Request) (web.TemplateData, e
 	form := r.Context().Value(common.ContextKeyParsedForm).(*FormGroup)
 	form.Name = strings.TrimSpace(form.Name)
 
-	if c, _ := models.RoleGroups(qm.Where("guild_id=?", g.ID)).CountG(r.Context()); c >= 1000 {
-		tmpl.AddAlerts(web.ErrorAlert("Max 1000 role groups allowed"))
+	if c, _ := models.RoleGroups(qm.Where("guild_id=?", g.ID)).CountG(r.Context()); c >= MaxGroups {
+		tmpl.AddAlerts(web.ErrorAlert(fmt.Sprintf("Max %d role groups allowed", MaxGroups)))
 		return tmpl, nil
 	}
 
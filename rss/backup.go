@@ -0,0 +1,66 @@
+package rss
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/rss/models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+var _ common.PluginWithBackup = (*Plugin)(nil)
+
+func (p *Plugin) BackupVersion() int {
+	return 1
+}
+
+func (p *Plugin) ExportBackup(ctx context.Context, guildID int64) (interface{}, error) {
+	return models.RssFeeds(models.RssFeedWhere.GuildID.EQ(guildID), qm.OrderBy("id asc")).AllG(ctx)
+}
+
+func (p *Plugin) RestoreBackup(ctx context.Context, guildID int64, data json.RawMessage, restore *common.BackupRestore) error {
+	var feeds []*models.RssFeed
+	err := json.Unmarshal(data, &feeds)
+	if err != nil {
+		return err
+	}
+
+	maxFeeds := MaxFeedsForCtx(ctx)
+	if len(feeds) > maxFeeds {
+		restore.Note("Only the first %d of %d feeds were restored", maxFeeds, len(feeds))
+		feeds = feeds[:maxFeeds]
+	}
+
+	tx, err := common.PQ.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = models.RssFeeds(models.RssFeedWhere.GuildID.EQ(guildID)).DeleteAll(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for _, v := range feeds {
+		v.ID = 0
+		v.GuildID = guildID
+		v.ChannelID = restore.Channel(v.ChannelID)
+		v.MentionRoles = restore.Roles(v.MentionRoles)
+
+		if v.ChannelID == 0 && v.Enabled {
+			restore.Note("The channel of the %s feed was not found, it was disabled", v.FeedURL)
+			v.Enabled = false
+		}
+
+		err = v.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return restore.Finish(tx)
+}
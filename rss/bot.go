@@ -0,0 +1,47 @@
+package rss
+
+import (
+	"context"
+	"fmt"
+
+	"emperror.dev/errors"
+	"github.com/botlabs-gg/yagpdb/v2/bot"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/rss/models"
+)
+
+var _ bot.RemoveGuildHandler = (*Plugin)(nil)
+
+func (p *Plugin) RemoveGuild(g int64) error {
+	_, err := models.RssFeeds(models.RssFeedWhere.GuildID.EQ(g)).UpdateAllG(context.Background(), models.M{"enabled": false})
+	if err != nil {
+		return errors.WrapIf(err, "failed removing rss feeds")
+	}
+
+	return nil
+}
+
+func (p *Plugin) Status() (string, string) {
+	var unique, total int
+	err := common.PQ.QueryRow(`SELECT count(DISTINCT feed_url), count(*) FROM rss_feeds WHERE enabled = true`).Scan(&unique, &total)
+	if err != nil {
+		logger.WithError(err).Error("failed counting rss feeds")
+	}
+
+	return "Unique/Total", fmt.Sprintf("%d/%d", unique, total)
+}
+
+func (p *Plugin) OnRemovedPremiumGuild(guildID int64) error {
+	logger.WithField("guild_id", guildID).Infof("Removed Excess RSS Feeds")
+
+	const q = `UPDATE rss_feeds SET enabled = false WHERE id IN (
+	SELECT id FROM rss_feeds WHERE guild_id = $1 AND enabled = true ORDER BY id ASC OFFSET $2
+)`
+
+	_, err := common.PQ.Exec(q, guildID, GuildMaxFeedsNormal)
+	if err != nil {
+		return errors.WrapIf(err, "failed disabling rss feeds on premium removal")
+	}
+
+	return nil
+}
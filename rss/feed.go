@@ -0,0 +1,496 @@
+package rss
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/analytics"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/mqueue"
+	"github.com/botlabs-gg/yagpdb/v2/common/templates"
+	"github.com/botlabs-gg/yagpdb/v2/feeds"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+	"github.com/botlabs-gg/yagpdb/v2/rss/models"
+	"github.com/botlabs-gg/yagpdb/v2/web/discorddata"
+	"github.com/mediocregopher/radix/v3"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Max size of a feed body we're willing to download
+	MaxFeedSize = 5 * 1024 * 1024
+
+	// Number of item guids we remember per feed url
+	MaxSeenItems = 1000
+
+	// Expire the state of feeds nobody has polled for a while
+	stateTTL = 60 * 60 * 24 * 30
+)
+
+var (
+	ErrFeedGone     = errors.New("feed is gone")
+	ErrPrivateHost  = errors.New("feed url points to a private address")
+	ErrFeedTooLarge = errors.New("feed is too large")
+)
+
+var _ feeds.Plugin = (*Plugin)(nil)
+
+// the state is stored per url so multiple guilds following the same feed only fetch it once
+func urlHash(feedURL string) string {
+	h := sha1.Sum([]byte(feedURL))
+	return hex.EncodeToString(h[:])
+}
+
+func KeyFeedState(feedURL string) string { return "rss_feed_state:" + urlHash(feedURL) }
+func KeySeenItems(feedURL string) string { return "rss_seen_items:" + urlHash(feedURL) }
+
+func (p *Plugin) StartFeed() {
+	p.Stop = make(chan *sync.WaitGroup)
+	go p.runFeedLoop()
+}
+
+func (p *Plugin) StopFeed(wg *sync.WaitGroup) {
+	if p.Stop != nil {
+		p.Stop <- wg
+	} else {
+		wg.Done()
+	}
+}
+
+func (p *Plugin) runFeedLoop() {
+	ticker := time.NewTicker(time.Minute * time.Duration(confPollInterval.GetInt()))
+	defer ticker.Stop()
+
+	startDelay := time.After(time.Second * 10)
+	for {
+		select {
+		case <-startDelay:
+			p.pollAll()
+		case <-ticker.C:
+			p.pollAll()
+		case wg := <-p.Stop:
+			wg.Done()
+			return
+		}
+	}
+}
+
+func (p *Plugin) pollAll() {
+	started := time.Now()
+
+	all, err := models.RssFeeds(models.RssFeedWhere.Enabled.EQ(true)).AllG(context.Background())
+	if err != nil {
+		logger.WithError(err).Error("failed retrieving rss feeds")
+		return
+	}
+
+	byURL := make(map[string][]*models.RssFeed)
+	for _, v := range all {
+		byURL[v.FeedURL] = append(byURL[v.FeedURL], v)
+	}
+
+	work := make(chan string)
+	var wg sync.WaitGroup
+
+	workers := confPollWorkers.GetInt()
+	if workers < 1 {
+		workers = 1
+	}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for feedURL := range work {
+				err := p.pollFeed(feedURL, byURL[feedURL])
+				if err != nil {
+					logger.WithError(err).WithField("url", feedURL).Debug("failed polling rss feed")
+				}
+			}
+		}()
+	}
+
+	for k := range byURL {
+		work <- k
+	}
+	close(work)
+	wg.Wait()
+
+	logger.Debugf("polled %d rss feeds (%d subscriptions) in %s", len(byURL), len(all), time.Since(started))
+}
+
+func (p *Plugin) pollFeed(feedURL string, subs []*models.RssFeed) error {
+	var state map[string]string
+	err := common.RedisPool.Do(radix.Cmd(&state, "HGETALL", KeyFeedState(feedURL)))
+	if err != nil {
+		return err
+	}
+
+	result, err := FetchFeed(context.Background(), feedURL, state["etag"], state["last_modified"])
+	if err != nil {
+		if errors.Is(err, ErrFeedGone) {
+			logger.WithField("url", feedURL).Info("rss feed is gone, disabling all subscriptions to it")
+			_, err = models.RssFeeds(models.RssFeedWhere.FeedURL.EQ(feedURL)).UpdateAllG(context.Background(), models.M{"enabled": false})
+			return err
+		}
+		return err
+	}
+
+	if result.NotModified {
+		return nil
+	}
+
+	newItems, err := filterSeenItems(feedURL, result.Feed.Items)
+	if err != nil {
+		return err
+	}
+
+	p.postNewItems(subs, result.Feed, newItems)
+
+	// only save the validators once the items have been handled, otherwise a failed run would skip them for good
+	return common.RedisPool.Do(radix.Pipeline(
+		radix.Cmd(nil, "HSET", KeyFeedState(feedURL), "etag", result.ETag, "last_modified", result.LastModified),
+		radix.Cmd(nil, "EXPIRE", KeyFeedState(feedURL), fmt.Sprint(stateTTL)),
+	))
+}
+
+func (p *Plugin) postNewItems(subs []*models.RssFeed, parsed *ParsedFeed, newItems []*Item) {
+	if len(newItems) < 1 {
+		return
+	}
+
+	// post the oldest of the new items first, and at most MaxItemsPerPoll of the newest ones
+	sort.SliceStable(newItems, func(i, j int) bool {
+		return newItems[i].Published.Before(newItems[j].Published)
+	})
+
+	if len(newItems) > MaxItemsPerPoll {
+		newItems = newItems[len(newItems)-MaxItemsPerPoll:]
+	}
+
+	for _, item := range newItems {
+		for _, sub := range subs {
+			if !MatchesKeywords(item, sub.IncludeKeywords, sub.ExcludeKeywords) {
+				continue
+			}
+
+			p.sendItem(sub, parsed, item)
+		}
+	}
+}
+
+// filterSeenItems marks all the items as seen and returns the ones that were not seen before,
+// the first time a feed is polled nothing is returned so we don't flood the channel with the backlog
+func filterSeenItems(feedURL string, items []*Item) ([]*Item, error) {
+	key := KeySeenItems(feedURL)
+
+	var exists bool
+	err := common.RedisPool.Do(radix.Cmd(&exists, "EXISTS", key))
+	if err != nil {
+		return nil, err
+	}
+
+	guids := make([]string, len(items))
+	for i, v := range items {
+		guids[i] = ItemGUID(v)
+	}
+
+	// look up all the items in one round trip
+	scores := make([]string, len(items))
+	if exists && len(items) > 0 {
+		lookups := make([]radix.CmdAction, len(items))
+		for i, guid := range guids {
+			lookups[i] = radix.Cmd(&scores[i], "ZSCORE", key, guid)
+		}
+
+		err = common.RedisPool.Do(radix.Pipeline(lookups...))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var newItems []*Item
+	now := fmt.Sprint(time.Now().Unix())
+	cmds := make([]radix.CmdAction, 0, len(items)+2)
+
+	for i, v := range items {
+		if exists && scores[i] == "" {
+			newItems = append(newItems, v)
+		}
+
+		cmds = append(cmds, radix.Cmd(nil, "ZADD", key, now, guids[i]))
+	}
+
+	if len(items) < 1 {
+		// still mark the feed as seeded
+		cmds = append(cmds, radix.Cmd(nil, "ZADD", key, "0", ""))
+	}
+
+	cmds = append(cmds,
+		radix.Cmd(nil, "ZREMRANGEBYRANK", key, "0", fmt.Sprint(-MaxSeenItems-1)),
+		radix.Cmd(nil, "EXPIRE", key, fmt.Sprint(stateTTL)))
+
+	err = common.RedisPool.Do(radix.Pipeline(cmds...))
+	return newItems, err
+}
+
+// MatchesKeywords returns true if the item contains at least one of the include keywords (if any)
+// and none of the exclude keywords, matching is case insensitive
+func MatchesKeywords(item *Item, include, exclude []string) bool {
+	haystack := strings.ToLower(item.Title + "\n" + item.Description + "\n" + strings.Join(item.Categories, "\n"))
+
+	for _, v := range exclude {
+		if v = strings.ToLower(strings.TrimSpace(v)); v != "" && strings.Contains(haystack, v) {
+			return false
+		}
+	}
+
+	matchedInclude := false
+	hasInclude := false
+	for _, v := range include {
+		if v = strings.ToLower(strings.TrimSpace(v)); v == "" {
+			continue
+		}
+
+		hasInclude = true
+		if strings.Contains(haystack, v) {
+			matchedInclude = true
+			break
+		}
+	}
+
+	return !hasInclude || matchedInclude
+}
+
+func (p *Plugin) sendItem(sub *models.RssFeed, parsed *ParsedFeed, item *Item) {
+	qm := &mqueue.QueuedElement{
+		GuildID:      sub.GuildID,
+		ChannelID:    sub.ChannelID,
+		Source:       "rss",
+		SourceItemID: fmt.Sprint(sub.ID),
+		Priority:     2,
+	}
+
+	if sub.MessageTemplate != "" {
+		guildState, err := discorddata.GetFullGuild(sub.GuildID)
+		if err != nil {
+			logger.WithError(err).WithField("guild", sub.GuildID).Error("failed retrieving guild state for rss feed")
+			return
+		}
+
+		if guildState == nil {
+			logger.WithField("guild", sub.GuildID).Info("guild not found in state for rss feed, disabling feeds")
+			models.RssFeeds(models.RssFeedWhere.GuildID.EQ(sub.GuildID)).UpdateAllG(context.Background(), models.M{"enabled": false})
+			return
+		}
+
+		channelState := guildState.GetChannel(sub.ChannelID)
+		if channelState == nil {
+			logger.WithField("guild", sub.GuildID).WithField("channel", sub.ChannelID).Info("channel not found in state for rss feed, disabling feed")
+			models.RssFeeds(models.RssFeedWhere.ID.EQ(sub.ID)).UpdateAllG(context.Background(), models.M{"enabled": false})
+			return
+		}
+
+		ctx := templates.NewContext(guildState, channelState, nil)
+		ctx.Data["FeedTitle"] = parsed.Title
+		ctx.Data["FeedURL"] = sub.FeedURL
+		ctx.Data["FeedLink"] = parsed.Link
+		ctx.Data["Title"] = item.Title
+		ctx.Data["Link"] = item.Link
+		ctx.Data["Description"] = item.Description
+		ctx.Data["Author"] = item.Author
+		ctx.Data["Categories"] = item.Categories
+		ctx.Data["Image"] = item.Image
+		ctx.Data["Published"] = item.Published
+		ctx.Data["Item"] = item
+
+		content, err := ctx.Execute(sub.MessageTemplate)
+		if err != nil {
+			logger.WithError(err).WithField("guild", sub.GuildID).Warn("rss message template execution failed")
+			return
+		}
+
+		if strings.TrimSpace(content) == "" {
+			return
+		}
+
+		qm.MessageStr = content
+		qm.PublishAnnouncement = ctx.CurrentFrame.PublishResponse
+		qm.AllowedMentions = discordgo.AllowedMentions{
+			Parse: []discordgo.AllowedMentionType{discordgo.AllowedMentionTypeRoles, discordgo.AllowedMentionTypeEveryone},
+		}
+	} else {
+		qm.MessageEmbed = defaultEmbed(parsed, item)
+
+		if len(sub.MentionRoles) > 0 {
+			mentions := ""
+			for _, v := range sub.MentionRoles {
+				mentions += fmt.Sprintf("<@&%d> ", v)
+			}
+			qm.MessageStr = strings.TrimSpace(mentions)
+			qm.AllowedMentions = discordgo.AllowedMentions{
+				Roles: discordgo.IDSlice(sub.MentionRoles),
+			}
+		}
+	}
+
+	go analytics.RecordActiveUnit(sub.GuildID, p, "posted_rss_message")
+	feeds.MetricPostedMessages.With(prometheus.Labels{"source": "rss"}).Inc()
+	mqueue.QueueMessage(qm)
+}
+
+func defaultEmbed(parsed *ParsedFeed, item *Item) *discordgo.MessageEmbed {
+	title := item.Title
+	if title == "" {
+		title = "New post"
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       common.CutStringShort(title, 250),
+		URL:         item.Link,
+		Description: common.CutStringShort(item.Description, 500),
+		Color:       0xf26522,
+	}
+
+	if parsed.Title != "" {
+		embed.Footer = &discordgo.MessageEmbedFooter{
+			Text: common.CutStringShort(parsed.Title, 200),
+		}
+	}
+
+	if item.Author != "" {
+		embed.Author = &discordgo.MessageEmbedAuthor{
+			Name: common.CutStringShort(item.Author, 200),
+		}
+	}
+
+	if !item.Published.IsZero() {
+		embed.Timestamp = item.Published.Format(time.RFC3339)
+	}
+
+	if item.Image != "" {
+		embed.Image = &discordgo.MessageEmbedImage{
+			URL: item.Image,
+		}
+	}
+
+	return embed
+}
+
+// FetchResult is the result of fetching a feed
+type FetchResult struct {
+	Feed         *ParsedFeed
+	NotModified  bool
+	ETag         string
+	LastModified string
+}
+
+var httpClient = &http.Client{
+	Timeout: time.Second * 15,
+	Transport: &http.Transport{
+		Proxy:                 nil,
+		DialContext:           (&net.Dialer{Timeout: time.Second * 10, Control: denyPrivateAddresses}).DialContext,
+		TLSHandshakeTimeout:   time.Second * 10,
+		ResponseHeaderTimeout: time.Second * 10,
+		MaxIdleConnsPerHost:   2,
+	},
+}
+
+// denyPrivateAddresses prevents feed urls from reaching into our internal network
+func denyPrivateAddresses(network, address string, c syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return ErrPrivateHost
+	}
+
+	return nil
+}
+
+// ValidateFeedURL checks that the url is a absolute http(s) url
+func ValidateFeedURL(feedURL string) (string, error) {
+	parsed, err := url.Parse(strings.TrimSpace(feedURL))
+	if err != nil {
+		return "", err
+	}
+
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "", errors.New("only http and https feed urls are supported")
+	}
+
+	if parsed.Host == "" {
+		return "", errors.New("missing host in feed url")
+	}
+
+	return parsed.String(), nil
+}
+
+// FetchFeed fetches and parses the feed, if etag or lastModified is provided
+// a conditional request is made and NotModified is set if nothing changed
+func FetchFeed(ctx context.Context, feedURL, etag, lastModified string) (*FetchResult, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", feedURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", "YAGPDB RSS (https://"+common.ConfHost.GetString()+")")
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml;q=0.9, text/xml;q=0.8, */*;q=0.5")
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified:
+		return &FetchResult{NotModified: true, ETag: etag, LastModified: lastModified}, nil
+	case resp.StatusCode == http.StatusGone:
+		return nil, ErrFeedGone
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return nil, fmt.Errorf("bad status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxFeedSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(body) > MaxFeedSize {
+		return nil, ErrFeedTooLarge
+	}
+
+	parsed, err := ParseFeed(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FetchResult{
+		Feed:         parsed,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}, nil
+}
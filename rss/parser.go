@@ -0,0 +1,338 @@
+package rss
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/microcosm-cc/bluemonday"
+	"golang.org/x/net/html/charset"
+)
+
+var (
+	ErrNotAFeed = errors.New("not a valid RSS or Atom feed")
+
+	stripHTMLPolicy = bluemonday.StrictPolicy()
+)
+
+// ParsedFeed is the format independent representation of a RSS or Atom feed
+type ParsedFeed struct {
+	Title string
+	Link  string
+	Items []*Item
+}
+
+// Item is a single entry in a feed
+type Item struct {
+	GUID        string
+	Title       string
+	Link        string
+	Description string
+	Author      string
+	Categories  []string
+	Image       string
+	Published   time.Time
+}
+
+// rss 2.0 and rss 1.0 (rdf)
+type rssDocument struct {
+	XMLName xml.Name
+	Channel struct {
+		Title string    `xml:"title"`
+		Links []string  `xml:"link"`
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+
+	// RSS 1.0 puts the items outside of the channel
+	Items []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	GUID        string   `xml:"guid"`
+	Title       string   `xml:"title"`
+	Links       []string `xml:"link"`
+	Description string   `xml:"description"`
+	Content     string   `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	Author      string   `xml:"author"`
+	Creator     string   `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	Categories  []string `xml:"category"`
+	PubDate     string   `xml:"pubDate"`
+	Date        string   `xml:"http://purl.org/dc/elements/1.1/ date"`
+	Enclosures  []struct {
+		URL  string `xml:"url,attr"`
+		Type string `xml:"type,attr"`
+	} `xml:"enclosure"`
+	MediaThumbnail struct {
+		URL string `xml:"url,attr"`
+	} `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+	MediaContent []struct {
+		URL    string `xml:"url,attr"`
+		Medium string `xml:"medium,attr"`
+		Type   string `xml:"type,attr"`
+	} `xml:"http://search.yahoo.com/mrss/ content"`
+}
+
+type atomDocument struct {
+	Title   string      `xml:"title"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type atomEntry struct {
+	ID        string     `xml:"id"`
+	Title     string     `xml:"title"`
+	Links     []atomLink `xml:"link"`
+	Summary   string     `xml:"summary"`
+	Content   string     `xml:"content"`
+	Published string     `xml:"published"`
+	Updated   string     `xml:"updated"`
+	Authors   []struct {
+		Name string `xml:"name"`
+	} `xml:"author"`
+	Categories []struct {
+		Term  string `xml:"term,attr"`
+		Label string `xml:"label,attr"`
+	} `xml:"category"`
+	MediaThumbnail struct {
+		URL string `xml:"url,attr"`
+	} `xml:"http://search.yahoo.com/mrss/ group>thumbnail"`
+}
+
+// ParseFeed parses a RSS 2.0, RSS 1.0 or Atom feed
+func ParseFeed(data []byte) (*ParsedFeed, error) {
+	root, err := rootElement(data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(root) {
+	case "rss", "rdf":
+		return parseRSS(data)
+	case "feed":
+		return parseAtom(data)
+	}
+
+	return nil, ErrNotAFeed
+}
+
+func newDecoder(data []byte) *xml.Decoder {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+	decoder.CharsetReader = charset.NewReaderLabel
+	return decoder
+}
+
+func rootElement(data []byte) (string, error) {
+	decoder := newDecoder(data)
+	for {
+		t, err := decoder.Token()
+		if err != nil {
+			return "", ErrNotAFeed
+		}
+
+		if se, ok := t.(xml.StartElement); ok {
+			return se.Name.Local, nil
+		}
+	}
+}
+
+func parseRSS(data []byte) (*ParsedFeed, error) {
+	var doc rssDocument
+	err := newDecoder(data).Decode(&doc)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &ParsedFeed{
+		Title: strings.TrimSpace(doc.Channel.Title),
+		Link:  firstNonEmpty(doc.Channel.Links),
+	}
+
+	items := doc.Channel.Items
+	if len(items) < 1 {
+		items = doc.Items
+	}
+
+	for _, v := range items {
+		item := &Item{
+			GUID:        strings.TrimSpace(v.GUID),
+			Title:       StripHTML(v.Title),
+			Link:        firstNonEmpty(v.Links),
+			Description: v.Description,
+			Author:      strings.TrimSpace(v.Author),
+			Published:   parseTime(v.PubDate, v.Date),
+		}
+
+		if item.Description == "" {
+			item.Description = v.Content
+		}
+		item.Description = StripHTML(item.Description)
+
+		if item.Author == "" {
+			item.Author = strings.TrimSpace(v.Creator)
+		}
+
+		for _, c := range v.Categories {
+			if c = strings.TrimSpace(c); c != "" {
+				item.Categories = append(item.Categories, c)
+			}
+		}
+
+		item.Image = v.MediaThumbnail.URL
+		for _, m := range v.MediaContent {
+			if item.Image == "" && (m.Medium == "image" || strings.HasPrefix(m.Type, "image/")) {
+				item.Image = m.URL
+			}
+		}
+		for _, e := range v.Enclosures {
+			if item.Image == "" && strings.HasPrefix(e.Type, "image/") {
+				item.Image = e.URL
+			}
+		}
+
+		feed.Items = append(feed.Items, item)
+	}
+
+	return feed, nil
+}
+
+func parseAtom(data []byte) (*ParsedFeed, error) {
+	var doc atomDocument
+	err := newDecoder(data).Decode(&doc)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &ParsedFeed{
+		Title: StripHTML(doc.Title),
+		Link:  atomAlternateLink(doc.Links),
+	}
+
+	for _, v := range doc.Entries {
+		item := &Item{
+			GUID:      strings.TrimSpace(v.ID),
+			Title:     StripHTML(v.Title),
+			Link:      atomAlternateLink(v.Links),
+			Published: parseTime(v.Published, v.Updated),
+			Image:     v.MediaThumbnail.URL,
+		}
+
+		item.Description = v.Summary
+		if item.Description == "" {
+			item.Description = v.Content
+		}
+		item.Description = StripHTML(item.Description)
+
+		if len(v.Authors) > 0 {
+			item.Author = strings.TrimSpace(v.Authors[0].Name)
+		}
+
+		for _, c := range v.Categories {
+			name := c.Label
+			if name == "" {
+				name = c.Term
+			}
+			if name = strings.TrimSpace(name); name != "" {
+				item.Categories = append(item.Categories, name)
+			}
+		}
+
+		for _, l := range v.Links {
+			if item.Image == "" && l.Rel == "enclosure" && strings.HasPrefix(l.Type, "image/") {
+				item.Image = l.Href
+			}
+		}
+
+		feed.Items = append(feed.Items, item)
+	}
+
+	return feed, nil
+}
+
+func atomAlternateLink(links []atomLink) string {
+	for _, v := range links {
+		if v.Rel == "" || v.Rel == "alternate" {
+			return strings.TrimSpace(v.Href)
+		}
+	}
+
+	if len(links) > 0 {
+		return strings.TrimSpace(links[0].Href)
+	}
+
+	return ""
+}
+
+// firstNonEmpty is used for rss links as atom:link elements in rss feeds
+// also end up in there, and those keep the url in a attribute instead
+func firstNonEmpty(values []string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+var timeFormats = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC3339,
+	time.RFC3339Nano,
+	time.RFC822Z,
+	time.RFC822,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"Mon, 02 Jan 2006 15:04 -0700",
+	"2 Jan 2006 15:04:05 -0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// parseTime returns the first of the provided values that could be parsed
+func parseTime(values ...string) time.Time {
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+
+		for _, f := range timeFormats {
+			if t, err := time.Parse(f, v); err == nil {
+				return t
+			}
+		}
+	}
+
+	return time.Time{}
+}
+
+// StripHTML removes all html tags from the input and unescapes html entities
+func StripHTML(s string) string {
+	return strings.TrimSpace(html.UnescapeString(stripHTMLPolicy.Sanitize(s)))
+}
+
+// ItemGUID returns a identifier for the item that's stable across polls
+func ItemGUID(item *Item) string {
+	if item.GUID != "" {
+		return item.GUID
+	}
+
+	if item.Link != "" {
+		return item.Link
+	}
+
+	return item.Title + "|" + item.Published.String()
+}
@@ -0,0 +1,180 @@
+package rss
+
+import (
+	"testing"
+	"time"
+)
+
+const testRSS = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:media="http://search.yahoo.com/mrss/">
+<channel>
+	<title>Example blog</title>
+	<atom:link href="https://example.com/feed.xml" rel="self" type="application/rss+xml" />
+	<link>https://example.com/</link>
+	<item>
+		<title>Release &amp; notes</title>
+		<link>https://example.com/posts/2</link>
+		<guid isPermaLink="false">post-2</guid>
+		<description><![CDATA[<p>We <b>released</b> version 2</p>]]></description>
+		<dc:creator>Jonas</dc:creator>
+		<category>releases</category>
+		<pubDate>Tue, 06 Jun 2023 10:00:00 +0000</pubDate>
+		<media:thumbnail url="https://example.com/2.png" />
+	</item>
+	<item>
+		<title>First post</title>
+		<link>https://example.com/posts/1</link>
+		<pubDate>Mon, 5 Jun 2023 10:00:00 GMT</pubDate>
+	</item>
+</channel>
+</rss>`
+
+const testAtom = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+	<title>Status page</title>
+	<link href="https://status.example.com/feed.atom" rel="self" />
+	<link href="https://status.example.com/" />
+	<entry>
+		<id>tag:status.example.com,2023:incident-5</id>
+		<title type="html">Degraded &lt;b&gt;performance&lt;/b&gt;</title>
+		<link rel="alternate" href="https://status.example.com/incidents/5" />
+		<updated>2023-06-07T12:30:00Z</updated>
+		<summary>API latency is elevated</summary>
+		<author><name>Ops</name></author>
+		<category term="api" />
+	</entry>
+</feed>`
+
+const testRDF = `<?xml version="1.0"?>
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/" xmlns:dc="http://purl.org/dc/elements/1.1/">
+	<channel>
+		<title>Old school</title>
+		<link>https://old.example.com/</link>
+	</channel>
+	<item>
+		<title>Hello</title>
+		<link>https://old.example.com/hello</link>
+		<dc:date>2023-06-01T08:00:00+02:00</dc:date>
+	</item>
+</rdf:RDF>`
+
+func TestParseRSS(t *testing.T) {
+	feed, err := ParseFeed([]byte(testRSS))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Title != "Example blog" || feed.Link != "https://example.com/" {
+		t.Errorf("unexpected feed info: %q %q", feed.Title, feed.Link)
+	}
+
+	if len(feed.Items) != 2 {
+		t.Fatalf("got %d items, expected 2", len(feed.Items))
+	}
+
+	item := feed.Items[0]
+	if item.Title != "Release & notes" {
+		t.Errorf("unexpected title: %q", item.Title)
+	}
+	if item.Description != "We released version 2" {
+		t.Errorf("unexpected description: %q", item.Description)
+	}
+	if item.Author != "Jonas" || item.Image != "https://example.com/2.png" || ItemGUID(item) != "post-2" {
+		t.Errorf("unexpected item: %#v", item)
+	}
+	if len(item.Categories) != 1 || item.Categories[0] != "releases" {
+		t.Errorf("unexpected categories: %v", item.Categories)
+	}
+	if !item.Published.Equal(time.Date(2023, 6, 6, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected published time: %s", item.Published)
+	}
+
+	// no guid, falls back to the link
+	if ItemGUID(feed.Items[1]) != "https://example.com/posts/1" {
+		t.Errorf("unexpected guid fallback: %q", ItemGUID(feed.Items[1]))
+	}
+	if feed.Items[1].Published.IsZero() {
+		t.Error("failed parsing published time of second item")
+	}
+}
+
+func TestParseAtom(t *testing.T) {
+	feed, err := ParseFeed([]byte(testAtom))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Title != "Status page" || feed.Link != "https://status.example.com/" {
+		t.Errorf("unexpected feed info: %q %q", feed.Title, feed.Link)
+	}
+
+	if len(feed.Items) != 1 {
+		t.Fatalf("got %d items, expected 1", len(feed.Items))
+	}
+
+	item := feed.Items[0]
+	if item.Title != "Degraded performance" || item.Link != "https://status.example.com/incidents/5" {
+		t.Errorf("unexpected item: %q %q", item.Title, item.Link)
+	}
+	if item.GUID != "tag:status.example.com,2023:incident-5" || item.Author != "Ops" || item.Description != "API latency is elevated" {
+		t.Errorf("unexpected item: %#v", item)
+	}
+	if len(item.Categories) != 1 || item.Categories[0] != "api" {
+		t.Errorf("unexpected categories: %v", item.Categories)
+	}
+	if item.Published.IsZero() {
+		t.Error("failed parsing updated time")
+	}
+}
+
+func TestParseRDF(t *testing.T) {
+	feed, err := ParseFeed([]byte(testRDF))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Title != "Old school" || len(feed.Items) != 1 {
+		t.Fatalf("unexpected feed: %#v", feed)
+	}
+
+	if feed.Items[0].Link != "https://old.example.com/hello" || feed.Items[0].Published.IsZero() {
+		t.Errorf("unexpected item: %#v", feed.Items[0])
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, v := range []string{"", "not xml at all", "<html><body>hello</body></html>"} {
+		if _, err := ParseFeed([]byte(v)); err == nil {
+			t.Errorf("%q should fail to parse", v)
+		}
+	}
+}
+
+func TestMatchesKeywords(t *testing.T) {
+	item := &Item{
+		Title:       "Version 2.0 released",
+		Description: "Lots of security fixes",
+		Categories:  []string{"Changelog"},
+	}
+
+	cases := []struct {
+		include []string
+		exclude []string
+		match   bool
+	}{
+		{nil, nil, true},
+		{[]string{"RELEASED"}, nil, true},
+		{[]string{"beta", "changelog"}, nil, true},
+		{[]string{"beta"}, nil, false},
+		{[]string{" ", ""}, nil, true},
+		{nil, []string{"security"}, false},
+		{[]string{"version"}, []string{"security"}, false},
+		{[]string{"version"}, []string{"beta"}, true},
+	}
+
+	for i, c := range cases {
+		if got := MatchesKeywords(item, c.include, c.exclude); got != c.match {
+			t.Errorf("case %d: got %v, expected %v", i, got, c.match)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package rss
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/config"
+	"github.com/botlabs-gg/yagpdb/v2/common/mqueue"
+	"github.com/botlabs-gg/yagpdb/v2/premium"
+	"github.com/botlabs-gg/yagpdb/v2/rss/models"
+)
+
+var (
+	confPollInterval = config.RegisterOption("yagpdb.rss.poll_interval", "Minutes between polling each rss feed", 10)
+	confPollWorkers  = config.RegisterOption("yagpdb.rss.poll_workers", "Number of rss feeds fetched concurrently", 5)
+
+	logger = common.GetPluginLogger(&Plugin{})
+)
+
+const (
+	// Max feeds per guild
+	GuildMaxFeedsNormal  = 10
+	GuildMaxFeedsPremium = 100
+
+	// Max number of items posted from a single feed per poll, anything more is marked as seen
+	MaxItemsPerPoll = 5
+)
+
+type Plugin struct {
+	Stop chan *sync.WaitGroup
+}
+
+func (p *Plugin) PluginInfo() *common.PluginInfo {
+	return &common.PluginInfo{
+		Name:     "RSS",
+		SysName:  "rss",
+		Category: common.PluginCategoryFeeds,
+	}
+}
+
+func RegisterPlugin() {
+	common.InitSchemas("rss", DBSchemas...)
+
+	p := &Plugin{}
+	common.RegisterPlugin(p)
+	mqueue.RegisterSource("rss", p)
+}
+
+var _ mqueue.PluginWithSourceDisabler = (*Plugin)(nil)
+
+// Disable feeds if they don't point to a proper channel
+func (p *Plugin) DisableFeed(elem *mqueue.QueuedElement, err error) {
+	feedID, err := strconv.ParseInt(elem.SourceItemID, 10, 64)
+	if err != nil {
+		logger.WithError(err).WithField("source_id", elem.SourceItemID).Error("failed parsing sourceID")
+		return
+	}
+
+	_, err = models.RssFeeds(models.RssFeedWhere.ID.EQ(feedID)).UpdateAllG(context.Background(), models.M{"enabled": false})
+	if err != nil {
+		logger.WithError(err).WithField("feed_id", feedID).Error("failed disabling rss feed")
+		return
+	}
+
+	logger.WithField("feed_id", feedID).WithField("channel", elem.ChannelID).Info("Disabled rss feed to non-existant channel")
+}
+
+func MaxFeedsForCtx(ctx context.Context) int {
+	if premium.ContextPremium(ctx) {
+		return GuildMaxFeedsPremium
+	}
+
+	return GuildMaxFeedsNormal
+}
@@ -0,0 +1,23 @@
+package rss
+
+var DBSchemas = []string{`
+CREATE TABLE IF NOT EXISTS rss_feeds (
+	id BIGSERIAL PRIMARY KEY,
+	guild_id BIGINT NOT NULL,
+	channel_id BIGINT NOT NULL,
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	feed_url TEXT NOT NULL,
+	feed_title TEXT NOT NULL,
+	enabled BOOLEAN NOT NULL,
+
+	mention_roles BIGINT[],
+	include_keywords TEXT[],
+	exclude_keywords TEXT[],
+	message_template TEXT NOT NULL DEFAULT ''
+);
+`, `
+CREATE INDEX IF NOT EXISTS rss_feeds_guild_id_idx ON rss_feeds(guild_id);
+`, `
+CREATE INDEX IF NOT EXISTS rss_feeds_feed_url_idx ON rss_feeds(feed_url);
+`}
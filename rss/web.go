@@ -0,0 +1,337 @@
+package rss
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/cplogs"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+	"github.com/botlabs-gg/yagpdb/v2/rss/models"
+	"github.com/botlabs-gg/yagpdb/v2/web"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"goji.io"
+	"goji.io/pat"
+)
+
+//go:embed assets/rss.html
+var PageHTML string
+
+type CtxKey int
+
+const (
+	CurrentConfig CtxKey = iota
+)
+
+// Max number of keywords in each of the include and exclude lists
+const MaxKeywords = 50
+
+type CreateForm struct {
+	FeedURL         string  `schema:"feed_url" valid:",1,500"`
+	Channel         int64   `schema:"channel" valid:"channel,false"`
+	MentionRoles    []int64 `schema:"mention_roles" valid:"role,true"`
+	IncludeKeywords string  `schema:"include_keywords" valid:",0,1000"`
+	ExcludeKeywords string  `schema:"exclude_keywords" valid:",0,1000"`
+	MessageTemplate string  `schema:"message_template" valid:"template,5000"`
+}
+
+type UpdateForm struct {
+	Channel         int64   `schema:"channel" valid:"channel,false"`
+	MentionRoles    []int64 `schema:"mention_roles" valid:"role,true"`
+	IncludeKeywords string  `schema:"include_keywords" valid:",0,1000"`
+	ExcludeKeywords string  `schema:"exclude_keywords" valid:",0,1000"`
+	MessageTemplate string  `schema:"message_template" valid:"template,5000"`
+	FeedEnabled     bool    `schema:"feed_enabled"`
+}
+
+var (
+	panelLogKeyAddedFeed   = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "rss_added_feed", FormatString: "Added rss feed from %s"})
+	panelLogKeyUpdatedFeed = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "rss_updated_feed", FormatString: "Updated rss feed from %s"})
+	panelLogKeyRemovedFeed = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "rss_removed_feed", FormatString: "Removed rss feed from %s"})
+)
+
+func (p *Plugin) InitWeb() {
+	web.AddHTMLTemplate("rss/assets/rss.html", PageHTML)
+	web.AddSidebarItem(web.SidebarCategoryFeeds, &web.SidebarItem{
+		Name: "RSS",
+		URL:  "rss",
+		Icon: "fas fa-rss",
+	})
+
+	rssMux := goji.SubMux()
+	web.CPMux.Handle(pat.New("/rss/*"), rssMux)
+	web.CPMux.Handle(pat.New("/rss"), rssMux)
+
+	rssMux.Use(web.RequireBotMemberMW)
+	rssMux.Use(web.RequirePermMW(discordgo.PermissionManageWebhooks))
+	rssMux.Use(baseData)
+
+	rssMux.Handle(pat.Get("/"), web.RenderHandler(HandleRSS, "cp_rss"))
+	rssMux.Handle(pat.Get(""), web.RenderHandler(HandleRSS, "cp_rss"))
+
+	rssMux.Handle(pat.Post(""), web.FormParserMW(web.RenderHandler(HandleNew, "cp_rss"), CreateForm{}))
+	rssMux.Handle(pat.Post("/"), web.FormParserMW(web.RenderHandler(HandleNew, "cp_rss"), CreateForm{}))
+	rssMux.Handle(pat.Post("/:item/update"), web.FormParserMW(web.RenderHandler(HandleModify, "cp_rss"), UpdateForm{}))
+	rssMux.Handle(pat.Post("/:item/delete"), web.RenderHandler(HandleRemove, "cp_rss"))
+}
+
+// Adds the current config to the context
+func baseData(inner http.Handler) http.Handler {
+	mw := func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		activeGuild, templateData := web.GetBaseCPContextData(ctx)
+		templateData["VisibleURL"] = "/manage/" + discordgo.StrID(activeGuild.ID) + "/rss/"
+		templateData["MaxFeeds"] = MaxFeedsForCtx(ctx)
+		templateData["KeywordsString"] = keywordsString
+
+		feeds, err := models.RssFeeds(models.RssFeedWhere.GuildID.EQ(activeGuild.ID), qm.OrderBy("id asc")).AllG(ctx)
+		if web.CheckErr(templateData, err, "Failed retrieving config, message support in the yagpdb server", web.CtxLogger(ctx).Error) {
+			web.LogIgnoreErr(web.Templates.ExecuteTemplate(w, "cp_rss", templateData))
+			return
+		}
+
+		inner.ServeHTTP(w, r.WithContext(context.WithValue(ctx, CurrentConfig, feeds)))
+	}
+
+	return http.HandlerFunc(mw)
+}
+
+func HandleRSS(w http.ResponseWriter, r *http.Request) interface{} {
+	ctx := r.Context()
+	_, templateData := web.GetBaseCPContextData(ctx)
+
+	templateData["RSSFeeds"] = ctx.Value(CurrentConfig).(models.RssFeedSlice)
+
+	return templateData
+}
+
+func HandleNew(w http.ResponseWriter, r *http.Request) interface{} {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+
+	currentConfig := ctx.Value(CurrentConfig).(models.RssFeedSlice)
+	templateData["RSSFeeds"] = currentConfig
+
+	form := ctx.Value(common.ContextKeyParsedForm).(*CreateForm)
+	ok := ctx.Value(common.ContextKeyFormOk).(bool)
+	if !ok {
+		return templateData
+	}
+
+	maxFeeds := MaxFeedsForCtx(ctx)
+	if len(currentConfig) >= maxFeeds {
+		return templateData.AddAlerts(web.ErrorAlert(fmt.Sprintf("Max %d feeds allowed (or %d for premium servers)", GuildMaxFeedsNormal, GuildMaxFeedsPremium)))
+	}
+
+	feedURL, err := ValidateFeedURL(form.FeedURL)
+	if err != nil {
+		return templateData.AddAlerts(web.ErrorAlert("Invalid feed url: ", err.Error()))
+	}
+
+	for _, v := range currentConfig {
+		if v.FeedURL == feedURL && v.ChannelID == form.Channel {
+			return templateData.AddAlerts(web.ErrorAlert("That feed is already being posted in that channel"))
+		}
+	}
+
+	// make sure it's actually a feed before saving it
+	fetched, err := FetchFeed(ctx, feedURL, "", "")
+	if err != nil {
+		return templateData.AddAlerts(web.ErrorAlert("Failed fetching the feed: ", err.Error()))
+	}
+
+	include, err := parseKeywords(form.IncludeKeywords)
+	if err != nil {
+		return templateData.AddAlerts(web.ErrorAlert(err.Error()))
+	}
+
+	exclude, err := parseKeywords(form.ExcludeKeywords)
+	if err != nil {
+		return templateData.AddAlerts(web.ErrorAlert(err.Error()))
+	}
+
+	title := fetched.Feed.Title
+	if title == "" {
+		title = feedURL
+	}
+
+	feed := &models.RssFeed{
+		GuildID:         activeGuild.ID,
+		ChannelID:       form.Channel,
+		FeedURL:         feedURL,
+		FeedTitle:       common.CutStringShort(title, 100),
+		Enabled:         true,
+		MentionRoles:    form.MentionRoles,
+		IncludeKeywords: include,
+		ExcludeKeywords: exclude,
+		MessageTemplate: form.MessageTemplate,
+	}
+
+	err = feed.InsertG(ctx, boil.Infer())
+	if web.CheckErr(templateData, err, "Failed saving item :'(", web.CtxLogger(ctx).Error) {
+		return templateData
+	}
+
+	templateData["RSSFeeds"] = append(currentConfig, feed)
+	templateData.AddAlerts(web.SucessAlert("Sucessfully added rss feed for " + feed.FeedTitle))
+
+	go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyAddedFeed, &cplogs.Param{Type: cplogs.ParamTypeString, Value: feed.FeedURL}))
+
+	return templateData
+}
+
+func HandleModify(w http.ResponseWriter, r *http.Request) interface{} {
+	ctx := r.Context()
+	_, templateData := web.GetBaseCPContextData(ctx)
+
+	currentConfig := ctx.Value(CurrentConfig).(models.RssFeedSlice)
+	templateData["RSSFeeds"] = currentConfig
+
+	form := ctx.Value(common.ContextKeyParsedForm).(*UpdateForm)
+	ok := ctx.Value(common.ContextKeyFormOk).(bool)
+	if !ok {
+		return templateData
+	}
+
+	item := findFeedFromParam(r, currentConfig)
+	if item == nil {
+		return templateData.AddAlerts(web.ErrorAlert("Unknown id"))
+	}
+
+	include, err := parseKeywords(form.IncludeKeywords)
+	if err != nil {
+		return templateData.AddAlerts(web.ErrorAlert(err.Error()))
+	}
+
+	exclude, err := parseKeywords(form.ExcludeKeywords)
+	if err != nil {
+		return templateData.AddAlerts(web.ErrorAlert(err.Error()))
+	}
+
+	item.ChannelID = form.Channel
+	item.MentionRoles = form.MentionRoles
+	item.IncludeKeywords = include
+	item.ExcludeKeywords = exclude
+	item.MessageTemplate = form.MessageTemplate
+	item.Enabled = form.FeedEnabled && form.Channel != 0
+
+	_, err = item.UpdateG(ctx, boil.Whitelist("channel_id", "enabled", "mention_roles", "include_keywords", "exclude_keywords", "message_template"))
+	if web.CheckErr(templateData, err, "Failed saving item :'(", web.CtxLogger(ctx).Error) {
+		return templateData
+	}
+
+	templateData.AddAlerts(web.SucessAlert("Sucessfully updated rss feed! :D"))
+
+	go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyUpdatedFeed, &cplogs.Param{Type: cplogs.ParamTypeString, Value: item.FeedURL}))
+
+	return templateData
+}
+
+func HandleRemove(w http.ResponseWriter, r *http.Request) interface{} {
+	ctx := r.Context()
+	_, templateData := web.GetBaseCPContextData(ctx)
+
+	currentConfig := ctx.Value(CurrentConfig).(models.RssFeedSlice)
+	templateData["RSSFeeds"] = currentConfig
+
+	item := findFeedFromParam(r, currentConfig)
+	if item == nil {
+		return templateData.AddAlerts(web.ErrorAlert("Unknown id"))
+	}
+
+	_, err := item.DeleteG(ctx)
+	if web.CheckErr(templateData, err, "Failed removing item :'(", web.CtxLogger(ctx).Error) {
+		return templateData
+	}
+
+	templateData.AddAlerts(web.SucessAlert("Sucessfully removed rss feed for " + item.FeedTitle))
+
+	// Remove it form the displayed list
+	for k, c := range currentConfig {
+		if c.ID == item.ID {
+			currentConfig = append(currentConfig[:k], currentConfig[k+1:]...)
+			break
+		}
+	}
+
+	templateData["RSSFeeds"] = currentConfig
+
+	go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyRemovedFeed, &cplogs.Param{Type: cplogs.ParamTypeString, Value: item.FeedURL}))
+
+	return templateData
+}
+
+func findFeedFromParam(r *http.Request, feeds models.RssFeedSlice) *models.RssFeed {
+	id, err := strconv.ParseInt(pat.Param(r, "item"), 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	for _, v := range feeds {
+		if v.ID == id {
+			return v
+		}
+	}
+
+	return nil
+}
+
+// keywordsString returns the keywords as displayed in the control panel
+func keywordsString(keywords []string) string {
+	return strings.Join(keywords, ", ")
+}
+
+// parseKeywords parses a comma separated list of keywords
+func parseKeywords(s string) ([]string, error) {
+	result := make([]string, 0)
+	for _, v := range strings.Split(s, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+
+		result = append(result, v)
+	}
+
+	if len(result) > MaxKeywords {
+		return nil, fmt.Errorf("Max %d keywords allowed per filter", MaxKeywords)
+	}
+
+	return result, nil
+}
+
+var _ web.PluginWithServerHomeWidget = (*Plugin)(nil)
+
+func (p *Plugin) LoadServerHomeWidget(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ag, templateData := web.GetBaseCPContextData(r.Context())
+
+	templateData["WidgetTitle"] = "RSS feeds"
+	templateData["SettingsPath"] = "/rss"
+
+	var enabled, total int
+	err := common.PQ.QueryRowContext(r.Context(), `SELECT count(*) FILTER (WHERE enabled), count(*) FROM rss_feeds WHERE guild_id = $1`, ag.ID).Scan(&enabled, &total)
+	if err != nil {
+		return templateData, err
+	}
+
+	if enabled > 0 {
+		templateData["WidgetEnabled"] = true
+	} else {
+		templateData["WidgetDisabled"] = true
+	}
+
+	format := `<ul>
+	<li>Active feeds: <code>%d</code></li>
+	<li>Total feeds: <code>%d</code></li>
+</ul>`
+
+	templateData["WidgetBody"] = template.HTML(fmt.Sprintf(format, enabled, total))
+
+	return templateData, nil
+}
@@ -0,0 +1,123 @@
+package rsvp
+
+import (
+	"context"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/rsvp/models"
+)
+
+// AttendanceEntry is how a member responded to a event that has started
+type AttendanceEntry struct {
+	GuildID      int64
+	UserID       int64
+	SeriesID     int64
+	EventLocalID int64
+	Title        string
+	StartsAt     time.Time
+	JoinState    ParticipantState
+}
+
+// FinalStates returns the state of each participant when the event starts, people that joined after
+// the max participants was reached are put on the waiting list like in the event embed.
+// The participants should be ordered by when they responded.
+func FinalStates(maxParticipants int, participants []*models.RSVPParticipant) []ParticipantState {
+	result := make([]ParticipantState, len(participants))
+	joined := 0
+	for i, v := range participants {
+		state := ParticipantState(v.JoinState)
+		if state == ParticipantStateJoining {
+			if maxParticipants > 0 && joined >= maxParticipants {
+				state = ParticipantStateWaitlist
+			} else {
+				joined++
+			}
+		}
+
+		result[i] = state
+	}
+
+	return result
+}
+
+// saveAttendance stores how everyone responded to the event
+func saveAttendance(ctx context.Context, m *models.RSVPSession) error {
+	if m.R == nil || len(m.R.RSVPSessionsMessageRSVPParticipants) < 1 {
+		return nil
+	}
+
+	seriesID := m.SeriesID
+	if seriesID == 0 {
+		seriesID = m.LocalID
+	}
+
+	tx, err := common.PQ.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	const q = `INSERT INTO rsvp_attendance (guild_id, user_id, series_id, event_local_id, title, starts_at, join_state)
+VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	participants := m.R.RSVPSessionsMessageRSVPParticipants
+	states := FinalStates(m.MaxParticipants, participants)
+	for i, v := range participants {
+		_, err = tx.ExecContext(ctx, q, m.GuildID, v.UserID, seriesID, m.LocalID, m.Title, m.StartsAt, int16(states[i]))
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// UserAttendance returns the latest events the user responded to
+func UserAttendance(ctx context.Context, guildID, userID int64, limit int) ([]*AttendanceEntry, error) {
+	const q = `SELECT guild_id, user_id, series_id, event_local_id, title, starts_at, join_state FROM rsvp_attendance
+WHERE guild_id = $1 AND user_id = $2
+ORDER BY starts_at DESC
+LIMIT $3`
+
+	rows, err := common.PQ.QueryContext(ctx, q, guildID, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*AttendanceEntry, 0, limit)
+	for rows.Next() {
+		e := &AttendanceEntry{}
+		err = rows.Scan(&e.GuildID, &e.UserID, &e.SeriesID, &e.EventLocalID, &e.Title, &e.StartsAt, &e.JoinState)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, e)
+	}
+
+	return result, rows.Err()
+}
+
+// UserAttendanceCounts returns how many times the user responded with each state
+func UserAttendanceCounts(ctx context.Context, guildID, userID int64) (map[ParticipantState]int, error) {
+	rows, err := common.PQ.QueryContext(ctx, `SELECT join_state, count(*) FROM rsvp_attendance WHERE guild_id = $1 AND user_id = $2 GROUP BY join_state`, guildID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[ParticipantState]int)
+	for rows.Next() {
+		var state ParticipantState
+		var count int
+		if err = rows.Scan(&state, &count); err != nil {
+			return nil, err
+		}
+
+		result[state] = count
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,157 @@
+package rsvp
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+	"github.com/botlabs-gg/yagpdb/v2/rsvp/models"
+	"github.com/botlabs-gg/yagpdb/v2/web"
+)
+
+// Events don't have a end time, calendars show them as lasting this long
+const DefaultEventDuration = time.Hour
+
+const icsTimeFormat = "20060102T150405Z"
+
+// GetCalendarToken returns the secret token in the calendar feed url of the guild, a new one is generated if create is set
+// and it doesn't have one yet. Returns a empty string if the guild has none and create isn't set.
+func GetCalendarToken(ctx context.Context, guildID int64, create bool) (string, error) {
+	var token string
+	err := common.PQ.QueryRowContext(ctx, `SELECT calendar_token FROM rsvp_configs WHERE guild_id = $1`, guildID).Scan(&token)
+	if err != nil && err != sql.ErrNoRows {
+		return "", err
+	}
+
+	if token == "" && create {
+		return ResetCalendarToken(ctx, guildID)
+	}
+
+	return token, nil
+}
+
+// ResetCalendarToken generates a new calendar feed token, the old feed url stops working
+func ResetCalendarToken(ctx context.Context, guildID int64) (string, error) {
+	b := make([]byte, 20)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	token := hex.EncodeToString(b)
+
+	const q = `INSERT INTO rsvp_configs (guild_id, calendar_token) VALUES ($1, $2)
+ON CONFLICT (guild_id) DO UPDATE SET calendar_token = $2`
+
+	_, err = common.PQ.ExecContext(ctx, q, guildID, token)
+	return token, err
+}
+
+func CalendarFeedURL(guildID int64, token string) string {
+	return fmt.Sprintf("%s/public/%d/rsvp/calendar/%s.ics", web.BaseURL(), guildID, token)
+}
+
+func EventICSURL(m *models.RSVPSession) string {
+	return fmt.Sprintf("%s/public/%d/rsvp/events/%d.ics", web.BaseURL(), m.GuildID, m.MessageID)
+}
+
+// WriteICS writes the events as a iCalendar file. Every occurrence of a repeating event is its own session,
+// so they're written as separate events instead of with a recurrence rule
+func WriteICS(w io.Writer, calendarName string, events []*models.RSVPSession, now time.Time) error {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//YAGPDB//RSVP Events//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:" + icsEscape(calendarName),
+	}
+
+	for _, v := range events {
+		lines = append(lines, icsEvent(v, now)...)
+	}
+
+	lines = append(lines, "END:VCALENDAR")
+
+	for _, v := range lines {
+		_, err := io.WriteString(w, icsFold(v)+"\r\n")
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func icsEvent(m *models.RSVPSession, now time.Time) []string {
+	link := fmt.Sprintf("https://discord.com/channels/%d/%d/%d", m.GuildID, m.ChannelID, m.MessageID)
+	description := link
+	if m.Description != "" {
+		description = m.Description + "\n\n" + link
+	}
+
+	lines := []string{
+		"BEGIN:VEVENT",
+		fmt.Sprintf("UID:rsvp-%d-%d@yagpdb", m.GuildID, m.MessageID),
+		"DTSTAMP:" + now.UTC().Format(icsTimeFormat),
+		"DTSTART:" + m.StartsAt.UTC().Format(icsTimeFormat),
+		"DTEND:" + m.StartsAt.Add(DefaultEventDuration).UTC().Format(icsTimeFormat),
+		"SUMMARY:" + icsEscape(m.Title),
+		"DESCRIPTION:" + icsEscape(description),
+		"URL:" + link,
+		"END:VEVENT",
+	}
+
+	return lines
+}
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func icsEscape(s string) string {
+	return icsEscaper.Replace(s)
+}
+
+// icsFold splits lines longer than 75 bytes, continuation lines start with a space
+func icsFold(line string) string {
+	const maxLen = 75
+	if len(line) <= maxLen {
+		return line
+	}
+
+	var out strings.Builder
+	lineLen := 0
+	for _, r := range line {
+		size := utf8.RuneLen(r)
+		if lineLen+size > maxLen {
+			out.WriteString("\r\n ")
+			// the space counts towards the length
+			lineLen = 1
+		}
+
+		out.WriteRune(r)
+		lineLen += size
+	}
+
+	return out.String()
+}
+
+// eventButtons returns the response buttons of the event, and a link to the calendar file of it
+func eventButtons(m *models.RSVPSession) []discordgo.MessageComponent {
+	buttons := createInteractionButtons()
+	row := buttons[0].(discordgo.ActionsRow)
+	row.Components = append(row.Components, discordgo.Button{
+		Label: "Add to calendar",
+		Style: discordgo.LinkButton,
+		URL:   EventICSURL(m),
+	})
+
+	return []discordgo.MessageComponent{row}
+}
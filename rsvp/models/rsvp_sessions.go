@@ -18,23 +18,29 @@ import (
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
 	"github.com/volatiletech/strmangle"
 )
 
 // RSVPSession is an object representing the database table.
 type RSVPSession struct {
-	MessageID       int64     `boil:"message_id" json:"message_id" toml:"message_id" yaml:"message_id"`
-	GuildID         int64     `boil:"guild_id" json:"guild_id" toml:"guild_id" yaml:"guild_id"`
-	ChannelID       int64     `boil:"channel_id" json:"channel_id" toml:"channel_id" yaml:"channel_id"`
-	LocalID         int64     `boil:"local_id" json:"local_id" toml:"local_id" yaml:"local_id"`
-	AuthorID        int64     `boil:"author_id" json:"author_id" toml:"author_id" yaml:"author_id"`
-	CreatedAt       time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	StartsAt        time.Time `boil:"starts_at" json:"starts_at" toml:"starts_at" yaml:"starts_at"`
-	Title           string    `boil:"title" json:"title" toml:"title" yaml:"title"`
-	Description     string    `boil:"description" json:"description" toml:"description" yaml:"description"`
-	MaxParticipants int       `boil:"max_participants" json:"max_participants" toml:"max_participants" yaml:"max_participants"`
-	SendReminders   bool      `boil:"send_reminders" json:"send_reminders" toml:"send_reminders" yaml:"send_reminders"`
-	SentReminders   bool      `boil:"sent_reminders" json:"sent_reminders" toml:"sent_reminders" yaml:"sent_reminders"`
+	MessageID       int64            `boil:"message_id" json:"message_id" toml:"message_id" yaml:"message_id"`
+	GuildID         int64            `boil:"guild_id" json:"guild_id" toml:"guild_id" yaml:"guild_id"`
+	ChannelID       int64            `boil:"channel_id" json:"channel_id" toml:"channel_id" yaml:"channel_id"`
+	LocalID         int64            `boil:"local_id" json:"local_id" toml:"local_id" yaml:"local_id"`
+	AuthorID        int64            `boil:"author_id" json:"author_id" toml:"author_id" yaml:"author_id"`
+	CreatedAt       time.Time        `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	StartsAt        time.Time        `boil:"starts_at" json:"starts_at" toml:"starts_at" yaml:"starts_at"`
+	Title           string           `boil:"title" json:"title" toml:"title" yaml:"title"`
+	Description     string           `boil:"description" json:"description" toml:"description" yaml:"description"`
+	MaxParticipants int              `boil:"max_participants" json:"max_participants" toml:"max_participants" yaml:"max_participants"`
+	SendReminders   bool             `boil:"send_reminders" json:"send_reminders" toml:"send_reminders" yaml:"send_reminders"`
+	SentReminders   bool             `boil:"sent_reminders" json:"sent_reminders" toml:"sent_reminders" yaml:"sent_reminders"`
+	RepeatType      int16            `boil:"repeat_type" json:"repeat_type" toml:"repeat_type" yaml:"repeat_type"`
+	RepeatEvery     int              `boil:"repeat_every" json:"repeat_every" toml:"repeat_every" yaml:"repeat_every"`
+	SeriesID        int64            `boil:"series_id" json:"series_id" toml:"series_id" yaml:"series_id"`
+	RequiredRoles   types.Int64Array `boil:"required_roles" json:"required_roles" toml:"required_roles" yaml:"required_roles"`
+	NativeEventID   int64            `boil:"native_event_id" json:"native_event_id" toml:"native_event_id" yaml:"native_event_id"`
 
 	R *rsvpSessionR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L rsvpSessionL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -53,6 +59,11 @@ var RSVPSessionColumns = struct {
 	MaxParticipants string
 	SendReminders   string
 	SentReminders   string
+	RepeatType      string
+	RepeatEvery     string
+	SeriesID        string
+	RequiredRoles   string
+	NativeEventID   string
 }{
 	MessageID:       "message_id",
 	GuildID:         "guild_id",
@@ -66,6 +77,11 @@ var RSVPSessionColumns = struct {
 	MaxParticipants: "max_participants",
 	SendReminders:   "send_reminders",
 	SentReminders:   "sent_reminders",
+	RepeatType:      "repeat_type",
+	RepeatEvery:     "repeat_every",
+	SeriesID:        "series_id",
+	RequiredRoles:   "required_roles",
+	NativeEventID:   "native_event_id",
 }
 
 var RSVPSessionTableColumns = struct {
@@ -81,6 +97,11 @@ var RSVPSessionTableColumns = struct {
 	MaxParticipants string
 	SendReminders   string
 	SentReminders   string
+	RepeatType      string
+	RepeatEvery     string
+	SeriesID        string
+	RequiredRoles   string
+	NativeEventID   string
 }{
 	MessageID:       "rsvp_sessions.message_id",
 	GuildID:         "rsvp_sessions.guild_id",
@@ -94,6 +115,11 @@ var RSVPSessionTableColumns = struct {
 	MaxParticipants: "rsvp_sessions.max_participants",
 	SendReminders:   "rsvp_sessions.send_reminders",
 	SentReminders:   "rsvp_sessions.sent_reminders",
+	RepeatType:      "rsvp_sessions.repeat_type",
+	RepeatEvery:     "rsvp_sessions.repeat_every",
+	SeriesID:        "rsvp_sessions.series_id",
+	RequiredRoles:   "rsvp_sessions.required_roles",
+	NativeEventID:   "rsvp_sessions.native_event_id",
 }
 
 // Generated where
@@ -148,6 +174,27 @@ func (w whereHelperint) NIN(slice []int) qm.QueryMod {
 	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
 }
 
+type whereHelpertypes_Int64Array struct{ field string }
+
+func (w whereHelpertypes_Int64Array) EQ(x types.Int64Array) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.EQ, x)
+}
+func (w whereHelpertypes_Int64Array) NEQ(x types.Int64Array) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+}
+func (w whereHelpertypes_Int64Array) LT(x types.Int64Array) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpertypes_Int64Array) LTE(x types.Int64Array) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpertypes_Int64Array) GT(x types.Int64Array) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpertypes_Int64Array) GTE(x types.Int64Array) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
 var RSVPSessionWhere = struct {
 	MessageID       whereHelperint64
 	GuildID         whereHelperint64
@@ -161,6 +208,11 @@ var RSVPSessionWhere = struct {
 	MaxParticipants whereHelperint
 	SendReminders   whereHelperbool
 	SentReminders   whereHelperbool
+	RepeatType      whereHelperint16
+	RepeatEvery     whereHelperint
+	SeriesID        whereHelperint64
+	RequiredRoles   whereHelpertypes_Int64Array
+	NativeEventID   whereHelperint64
 }{
 	MessageID:       whereHelperint64{field: "\"rsvp_sessions\".\"message_id\""},
 	GuildID:         whereHelperint64{field: "\"rsvp_sessions\".\"guild_id\""},
@@ -174,6 +226,11 @@ var RSVPSessionWhere = struct {
 	MaxParticipants: whereHelperint{field: "\"rsvp_sessions\".\"max_participants\""},
 	SendReminders:   whereHelperbool{field: "\"rsvp_sessions\".\"send_reminders\""},
 	SentReminders:   whereHelperbool{field: "\"rsvp_sessions\".\"sent_reminders\""},
+	RepeatType:      whereHelperint16{field: "\"rsvp_sessions\".\"repeat_type\""},
+	RepeatEvery:     whereHelperint{field: "\"rsvp_sessions\".\"repeat_every\""},
+	SeriesID:        whereHelperint64{field: "\"rsvp_sessions\".\"series_id\""},
+	RequiredRoles:   whereHelpertypes_Int64Array{field: "\"rsvp_sessions\".\"required_roles\""},
+	NativeEventID:   whereHelperint64{field: "\"rsvp_sessions\".\"native_event_id\""},
 }
 
 // RSVPSessionRels is where relationship names are stored.
@@ -204,9 +261,9 @@ func (r *rsvpSessionR) GetRSVPSessionsMessageRSVPParticipants() RSVPParticipantS
 type rsvpSessionL struct{}
 
 var (
-	rsvpSessionAllColumns            = []string{"message_id", "guild_id", "channel_id", "local_id", "author_id", "created_at", "starts_at", "title", "description", "max_participants", "send_reminders", "sent_reminders"}
+	rsvpSessionAllColumns            = []string{"message_id", "guild_id", "channel_id", "local_id", "author_id", "created_at", "starts_at", "title", "description", "max_participants", "send_reminders", "sent_reminders", "repeat_type", "repeat_every", "series_id", "required_roles", "native_event_id"}
 	rsvpSessionColumnsWithoutDefault = []string{"message_id", "guild_id", "channel_id", "local_id", "author_id", "created_at", "starts_at", "title", "description", "max_participants", "send_reminders", "sent_reminders"}
-	rsvpSessionColumnsWithDefault    = []string{}
+	rsvpSessionColumnsWithDefault    = []string{"repeat_type", "repeat_every", "series_id", "required_roles", "native_event_id"}
 	rsvpSessionPrimaryKeyColumns     = []string{"message_id"}
 	rsvpSessionGeneratedColumns      = []string{}
 )
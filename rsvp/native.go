@@ -0,0 +1,353 @@
+package rsvp
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/bot"
+	"github.com/botlabs-gg/yagpdb/v2/bot/eventsystem"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/scheduledevents2"
+	eventModels "github.com/botlabs-gg/yagpdb/v2/common/scheduledevents2/models"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+	"github.com/botlabs-gg/yagpdb/v2/rsvp/models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// Limits of discord scheduled events
+const (
+	MaxNativeNameLength        = 100
+	MaxNativeDescriptionLength = 1000
+	MaxNativeLocationLength    = 100
+)
+
+// NativeEventsEnabled returns whether new events on the guild are mirrored as discord scheduled events
+func NativeEventsEnabled(ctx context.Context, guildID int64) (bool, error) {
+	var enabled bool
+	err := common.PQ.QueryRowContext(ctx, `SELECT native_events FROM rsvp_configs WHERE guild_id = $1`, guildID).Scan(&enabled)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+
+	return enabled, err
+}
+
+func SetNativeEventsEnabled(ctx context.Context, guildID int64, enabled bool) error {
+	const q = `INSERT INTO rsvp_configs (guild_id, calendar_token, native_events) VALUES ($1, '', $2)
+ON CONFLICT (guild_id) DO UPDATE SET native_events = $2`
+
+	_, err := common.PQ.ExecContext(ctx, q, guildID, enabled)
+	return err
+}
+
+// NativeEventURL returns the link to the discord scheduled event mirroring the session
+func NativeEventURL(m *models.RSVPSession) string {
+	return fmt.Sprintf("https://discord.com/events/%d/%d", m.GuildID, m.NativeEventID)
+}
+
+// NativeEventParams returns the discord scheduled event for the session, it's a external event
+// located in the channel of the event message since the rsvp events aren't tied to a voice channel
+func NativeEventParams(m *models.RSVPSession, channelName string) *discordgo.GuildScheduledEventParams {
+	start := m.StartsAt
+	end := m.StartsAt.Add(DefaultEventDuration)
+
+	link := fmt.Sprintf("https://discord.com/channels/%d/%d/%d", m.GuildID, m.ChannelID, m.MessageID)
+	description := "Sign up on the event message: " + link
+	if m.Description != "" {
+		description = m.Description + "\n\n" + description
+	}
+
+	location := "#" + channelName
+	if channelName == "" {
+		location = link
+	}
+
+	return &discordgo.GuildScheduledEventParams{
+		Name:               common.CutStringShort(m.Title, MaxNativeNameLength),
+		Description:        common.CutStringShort(description, MaxNativeDescriptionLength),
+		EntityType:         discordgo.GuildScheduledEventEntityTypeExternal,
+		EntityMetadata:     &discordgo.GuildScheduledEventEntityMetadata{Location: common.CutStringShort(location, MaxNativeLocationLength)},
+		PrivacyLevel:       discordgo.GuildScheduledEventPrivacyLevelGuildOnly,
+		ScheduledStartTime: &start,
+		ScheduledEndTime:   &end,
+	}
+}
+
+func nativeEventParams(m *models.RSVPSession) *discordgo.GuildScheduledEventParams {
+	channelName := ""
+	if gs := bot.State.GetGuild(m.GuildID); gs != nil {
+		if cs := gs.GetChannel(m.ChannelID); cs != nil {
+			channelName = cs.Name
+		}
+	}
+
+	return NativeEventParams(m, channelName)
+}
+
+// createNativeEvent mirrors the session as a discord scheduled event
+func createNativeEvent(m *models.RSVPSession) error {
+	if m.NativeEventID != 0 || !m.StartsAt.After(time.Now()) {
+		return nil
+	}
+
+	evt, err := common.BotSession.GuildScheduledEventCreate(m.GuildID, nativeEventParams(m))
+	if err != nil {
+		return err
+	}
+
+	m.NativeEventID = evt.ID
+	_, err = m.UpdateG(context.Background(), boil.Whitelist("native_event_id"))
+	if err != nil {
+		common.BotSession.GuildScheduledEventDelete(m.GuildID, evt.ID)
+		m.NativeEventID = 0
+	}
+
+	return err
+}
+
+// updateNativeEvent updates the discord scheduled event after the session was edited
+func updateNativeEvent(m *models.RSVPSession) error {
+	if m.NativeEventID == 0 {
+		return nil
+	}
+
+	_, err := common.BotSession.GuildScheduledEventEdit(m.GuildID, m.NativeEventID, nativeEventParams(m))
+	if code, _ := common.DiscordError(err); code == discordgo.ErrCodeUnknownGuildScheduledEvent {
+		// deleted on discord's side
+		m.NativeEventID = 0
+		_, err = m.UpdateG(context.Background(), boil.Whitelist("native_event_id"))
+	}
+
+	return err
+}
+
+// removeNativeEvent deletes the discord scheduled event of a session that has been removed, see unlinkNativeEvent for sessions that are kept
+func removeNativeEvent(m *models.RSVPSession) error {
+	if m.NativeEventID == 0 {
+		return nil
+	}
+
+	err := common.BotSession.GuildScheduledEventDelete(m.GuildID, m.NativeEventID)
+	if code, _ := common.DiscordError(err); code == discordgo.ErrCodeUnknownGuildScheduledEvent {
+		err = nil
+	}
+
+	m.NativeEventID = 0
+	return err
+}
+
+// unlinkNativeEvent deletes the discord scheduled event of a session that's kept. The session is saved without it first,
+// otherwise the delete event from discord would cancel the session along with it
+func unlinkNativeEvent(ctx context.Context, m *models.RSVPSession) error {
+	nativeEventID := m.NativeEventID
+	if nativeEventID == 0 {
+		return nil
+	}
+
+	m.NativeEventID = 0
+	_, err := m.UpdateG(ctx, boil.Whitelist("native_event_id"))
+	if err != nil {
+		m.NativeEventID = nativeEventID
+		return err
+	}
+
+	err = common.BotSession.GuildScheduledEventDelete(m.GuildID, nativeEventID)
+	if code, _ := common.DiscordError(err); code == discordgo.ErrCodeUnknownGuildScheduledEvent {
+		err = nil
+	}
+
+	return err
+}
+
+// startNativeEvent marks the discord scheduled event as active, discord ends it at the end time
+func startNativeEvent(m *models.RSVPSession) error {
+	if m.NativeEventID == 0 {
+		return nil
+	}
+
+	_, err := common.BotSession.GuildScheduledEventEdit(m.GuildID, m.NativeEventID, &discordgo.GuildScheduledEventParams{
+		Status: discordgo.GuildScheduledEventStatusActive,
+	})
+	if code, _ := common.DiscordError(err); code == discordgo.ErrCodeUnknownGuildScheduledEvent {
+		err = nil
+	}
+
+	return err
+}
+
+func sessionByNativeEvent(guildID, nativeEventID int64) (*models.RSVPSession, error) {
+	m, err := models.RSVPSessions(
+		models.RSVPSessionWhere.GuildID.EQ(guildID),
+		models.RSVPSessionWhere.NativeEventID.EQ(nativeEventID),
+		qm.Load("RSVPSessionsMessageRSVPParticipants", qm.OrderBy("marked_as_participating_at asc")),
+	).OneG(context.Background())
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return m, err
+}
+
+func (p *Plugin) handleNativeEventUpdate(evt *eventsystem.EventData) (retry bool, err error) {
+	var native *discordgo.GuildScheduledEvent
+	switch evt.Type {
+	case eventsystem.EventGuildScheduledEventUpdate:
+		native = evt.GuildScheduledEventUpdate().GuildScheduledEvent
+	case eventsystem.EventGuildScheduledEventDelete:
+		native = evt.GuildScheduledEventDelete().GuildScheduledEvent
+	}
+
+	m, err := sessionByNativeEvent(native.GuildID, native.ID)
+	if err != nil || m == nil {
+		return bot.CheckDiscordErrRetry(err), err
+	}
+
+	if evt.Type == eventsystem.EventGuildScheduledEventDelete || native.Status == discordgo.GuildScheduledEventStatusCanceled {
+		// canceled in discord, cancel the rsvp event along with it
+		_, err = m.DeleteG(context.Background())
+		if err != nil {
+			return true, err
+		}
+
+		content := "This event was canceled."
+		common.BotSession.ChannelMessageEditComplex(&discordgo.MessageEdit{
+			ID:         m.MessageID,
+			Channel:    m.ChannelID,
+			Content:    &content,
+			Components: []discordgo.MessageComponent{},
+		})
+		return false, nil
+	}
+
+	if native.Status != discordgo.GuildScheduledEventStatusScheduled {
+		return false, nil
+	}
+
+	// our own edits are sent back as updates too, only apply actual changes
+	timeChanged := !native.ScheduledStartTime.Truncate(time.Second).Equal(m.StartsAt.Truncate(time.Second))
+	if !timeChanged && native.Name == common.CutStringShort(m.Title, MaxNativeNameLength) {
+		return false, nil
+	}
+
+	if native.Name != common.CutStringShort(m.Title, MaxNativeNameLength) {
+		m.Title = native.Name
+	}
+	m.StartsAt = native.ScheduledStartTime
+
+	_, err = m.UpdateG(context.Background(), boil.Whitelist("title", "starts_at"))
+	if err != nil {
+		return true, err
+	}
+
+	if timeChanged {
+		err = rescheduleUpdate(context.Background(), m)
+		if err != nil {
+			return true, err
+		}
+	}
+
+	queueEmbedUpdate(m)
+	return false, nil
+}
+
+// handleNativeEventUser imports the interest in discord scheduled events into the participant list
+func (p *Plugin) handleNativeEventUser(evt *eventsystem.EventData) (retry bool, err error) {
+	var guildID, nativeEventID, userID int64
+	added := evt.Type == eventsystem.EventGuildScheduledEventUserAdd
+	if added {
+		e := evt.GuildScheduledEventUserAdd()
+		guildID, nativeEventID, userID = e.GuildID, e.GuildScheduledEventID, e.UserID
+	} else {
+		e := evt.GuildScheduledEventUserRemove()
+		guildID, nativeEventID, userID = e.GuildID, e.GuildScheduledEventID, e.UserID
+	}
+
+	if userID == common.BotUser.ID {
+		return false, nil
+	}
+
+	m, err := sessionByNativeEvent(guildID, nativeEventID)
+	if err != nil || m == nil {
+		return bot.CheckDiscordErrRetry(err), err
+	}
+
+	var participant *models.RSVPParticipant
+	for _, v := range m.R.RSVPSessionsMessageRSVPParticipants {
+		if v.UserID == userID {
+			participant = v
+			break
+		}
+	}
+
+	if !added {
+		if participant == nil || participant.JoinState != int16(ParticipantStateJoining) {
+			return false, nil
+		}
+
+		participant.JoinState = int16(ParticipantStateNotJoining)
+		_, err = participant.UpdateG(context.Background(), boil.Whitelist("join_state"))
+		if err != nil {
+			return true, err
+		}
+
+		queueEmbedUpdate(m)
+		return false, nil
+	}
+
+	if participant != nil && (participant.JoinState == int16(ParticipantStateJoining) || participant.JoinState == int16(ParticipantStateWaitlist)) {
+		return false, nil
+	}
+
+	if len(m.RequiredRoles) > 0 {
+		ms, err := bot.GetMember(guildID, userID)
+		if err != nil {
+			return bot.CheckDiscordErrRetry(err), err
+		}
+
+		if !common.ContainsInt64SliceOneOf(ms.Member.Roles, m.RequiredRoles) {
+			return false, nil
+		}
+	}
+
+	if participant != nil {
+		participant.JoinState = int16(ParticipantStateJoining)
+		participant.MarkedAsParticipatingAt = time.Now()
+		_, err = participant.UpdateG(context.Background(), boil.Whitelist("join_state", "marked_as_participating_at"))
+	} else {
+		err = m.AddRSVPSessionsMessageRSVPParticipantsG(context.Background(), true, &models.RSVPParticipant{
+			RSVPSessionsMessageID:   m.MessageID,
+			UserID:                  userID,
+			GuildID:                 guildID,
+			JoinState:               int16(ParticipantStateJoining),
+			MarkedAsParticipatingAt: time.Now(),
+		})
+	}
+
+	if err != nil {
+		return true, err
+	}
+
+	queueEmbedUpdate(m)
+	return false, nil
+}
+
+// createNativeEventLogged is used when creating events in the background, where there's no one to show the error to
+func createNativeEventLogged(m *models.RSVPSession) {
+	err := createNativeEvent(m)
+	if code, _ := common.DiscordError(err); err != nil && code != discordgo.ErrCodeMissingPermissions && code != discordgo.ErrCodeMissingAccess {
+		logger.WithError(err).WithField("guild", m.GuildID).Error("failed creating the discord event of a rsvp event")
+	}
+}
+
+// rescheduleUpdate replaces the scheduled embed update of the session after its start time changed
+func rescheduleUpdate(ctx context.Context, m *models.RSVPSession) error {
+	_, err := eventModels.ScheduledEvents(qm.Where("event_name='rsvp_update_session' AND  guild_id = ? AND data::text::bigint = ? AND processed = false", m.GuildID, m.MessageID)).DeleteAll(ctx, common.PQ)
+	if err != nil {
+		return err
+	}
+
+	return scheduledevents2.ScheduleEvent("rsvp_update_session", m.GuildID, NextUpdateTime(m), m.MessageID)
+}
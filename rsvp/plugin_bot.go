@@ -30,6 +30,8 @@ var _ bot.BotInitHandler = (*Plugin)(nil)
 func (p *Plugin) BotInit() {
 	eventsystem.AddHandlerAsyncLastLegacy(p, p.handleMessageCreate, eventsystem.EventMessageCreate)
 	eventsystem.AddHandlerAsyncLastLegacy(p, p.handleInteractionCreate, eventsystem.EventInteractionCreate)
+	eventsystem.AddHandlerAsyncLast(p, p.handleNativeEventUpdate, eventsystem.EventGuildScheduledEventUpdate, eventsystem.EventGuildScheduledEventDelete)
+	eventsystem.AddHandlerAsyncLast(p, p.handleNativeEventUser, eventsystem.EventGuildScheduledEventUserAdd, eventsystem.EventGuildScheduledEventUserRemove)
 	scheduledevents2.RegisterHandler("rsvp_update_session", int64(0), p.handleScheduledUpdate)
 }
 
@@ -113,6 +115,9 @@ func (p *Plugin) AddCommands() {
 			{Name: "title", Help: "Change the title of the event", Type: dcmd.String},
 			{Name: "time", Help: "Change the start time of the event", Type: dcmd.String},
 			{Name: "max", Help: "Change max participants", Type: dcmd.Int},
+			{Name: "repeat", Help: "How often the event repeats, e.g. no, weekly or every 2 weeks", Type: dcmd.String},
+			{Name: "roles", Help: "Comma separated roles that can respond, or none", Type: dcmd.String},
+			{Name: "native", Help: "Mirror the event as a Discord event, on or off", Type: dcmd.String},
 		},
 		RunFunc: func(parsed *dcmd.Data) (interface{}, error) {
 			m, err := models.RSVPSessions(
@@ -137,7 +142,42 @@ func (p *Plugin) AddCommands() {
 				m.MaxParticipants = parsed.Switch("max").Int()
 			}
 
+			if parsed.Switch("repeat").Value != nil {
+				repeat, every, ok := ParseRepeat(parsed.Switch("repeat").Str())
+				if !ok {
+					return "Couldn't understand the repeat, use `no`, `daily`, `weekly`, `monthly` or for example `every 2 weeks`", nil
+				}
+
+				m.RepeatType = int16(repeat)
+				m.RepeatEvery = every
+				if m.SeriesID == 0 {
+					m.SeriesID = m.LocalID
+				}
+			}
+
+			if parsed.Switch("roles").Value != nil {
+				roles, err := commands.ParseRoleList(parsed.GuildData.GS, parsed.Switch("roles").Str())
+				if err != nil {
+					return err.Error(), nil
+				}
+
+				m.RequiredRoles = roles
+			}
+
+			native := m.NativeEventID != 0
+			if parsed.Switch("native").Value != nil {
+				switch strings.ToLower(parsed.Switch("native").Str()) {
+				case "on", "yes", "true", "enable":
+					native = true
+				case "off", "no", "false", "disable":
+					native = false
+				default:
+					return "The native switch should be `on` or `off`", nil
+				}
+			}
+
 			timeChanged := false
+			titleChanged := parsed.Switch("title").Value != nil
 			if parsed.Switch("time").Value != nil {
 				registeredTimezone := timezonecompanion.GetUserTimezone(parsed.Author.ID)
 				if registeredTimezone == nil || UTCRegex.MatchString(parsed.Switch("time").Str()) {
@@ -159,20 +199,31 @@ func (p *Plugin) AddCommands() {
 			}
 
 			if timeChanged {
-				_, err := eventModels.ScheduledEvents(qm.Where("event_name='rsvp_update_session' AND  guild_id = ? AND data::text::bigint = ? AND processed = false", parsed.GuildData.GS.ID, m.MessageID)).DeleteAll(parsed.Context(), common.PQ)
+				err = rescheduleUpdate(parsed.Context(), m)
 				if err != nil {
 					return nil, err
 				}
+			}
 
-				err = scheduledevents2.ScheduleEvent("rsvp_update_session", m.GuildID, NextUpdateTime(m), m.MessageID)
-				if err != nil {
-					return nil, err
-				}
+			if native && m.NativeEventID == 0 {
+				err = createNativeEvent(m)
+			} else if !native && m.NativeEventID != 0 {
+				err = unlinkNativeEvent(parsed.Context(), m)
+			} else if timeChanged || titleChanged {
+				err = updateNativeEvent(m)
 			}
 
 			UpdateEventEmbed(m)
 
-			return fmt.Sprintf("Updated #%d to '%s' - with max %d participants, starting at: %s", m.LocalID, m.Title, m.MaxParticipants, m.StartsAt.Format("02 Jan 2006 15:04 MST")), nil
+			if err != nil {
+				if code, _ := common.DiscordError(err); code == discordgo.ErrCodeMissingPermissions || code == discordgo.ErrCodeMissingAccess {
+					return "Updated the event, but failed updating the Discord event, make sure the bot has the Manage Events permission", nil
+				}
+
+				return nil, err
+			}
+
+			return fmt.Sprintf("Updated #%d to '%s' - with max %d participants, starting at: %s, repeating %s", m.LocalID, m.Title, m.MaxParticipants, m.StartsAt.Format("02 Jan 2006 15:04 MST"), RepeatDescription(RepeatType(m.RepeatType), m.RepeatEvery)), nil
 		},
 	}
 
@@ -237,10 +288,92 @@ func (p *Plugin) AddCommands() {
 				return nil, err
 			}
 
+			err = removeNativeEvent(m)
+			if err != nil {
+				logger.WithError(err).WithField("guild", m.GuildID).Error("failed deleting the discord event of a rsvp event")
+			}
+
+			if m.RepeatType != int16(RepeatNone) {
+				return "Deleted `" + m.Title + "`, it won't repeat anymore", nil
+			}
+
 			return "Deleted `" + m.Title + "`", nil
 		},
 	}
 
+	cmdHistory := &commands.YAGCommand{
+		CmdCategory: catEvents,
+		Name:        "History",
+		Aliases:     []string{"attendance"},
+		Description: "Shows the events you or the specified user responded to",
+		Plugin:      p,
+		Arguments: []*dcmd.ArgDef{
+			{Name: "User", Type: dcmd.User},
+		},
+		RunFunc: func(parsed *dcmd.Data) (interface{}, error) {
+			target := parsed.Author
+			if parsed.Args[0].Value != nil {
+				target = parsed.Args[0].User()
+			}
+
+			counts, err := UserAttendanceCounts(parsed.Context(), parsed.GuildData.GS.ID, target.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			entries, err := UserAttendance(parsed.Context(), parsed.GuildData.GS.ID, target.ID, 10)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(entries) < 1 {
+				return target.Username + " hasn't responded to any past events", nil
+			}
+
+			var output strings.Builder
+			output.WriteString(fmt.Sprintf("Joined **%d**, waiting list **%d**, undecided **%d**, not joining **%d**\n\n",
+				counts[ParticipantStateJoining], counts[ParticipantStateWaitlist], counts[ParticipantStateMaybe], counts[ParticipantStateNotJoining]))
+
+			for _, v := range entries {
+				output.WriteString(fmt.Sprintf("<t:%d:d> #%d **%s** - %s\n", v.StartsAt.Unix(), v.EventLocalID, v.Title, v.JoinState.String()))
+			}
+
+			return &discordgo.MessageEmbed{
+				Title:       "Event history of " + target.Username,
+				Description: output.String(),
+				Color:       0x518eef,
+			}, nil
+		},
+	}
+
+	cmdCalendar := &commands.YAGCommand{
+		CmdCategory:         catEvents,
+		Name:                "Calendar",
+		Aliases:             []string{"ics"},
+		Description:         "Gives the url of the calendar feed with all events on this server",
+		RequireDiscordPerms: []int64{discordgo.PermissionManageServer},
+		Plugin:              p,
+		ArgSwitches: []*dcmd.ArgDef{
+			{Name: "reset", Help: "Create a new url, the old one stops working"},
+		},
+		RunFunc: func(parsed *dcmd.Data) (interface{}, error) {
+			var token string
+			var err error
+			if parsed.Switch("reset").Bool() {
+				token, err = ResetCalendarToken(parsed.Context(), parsed.GuildData.GS.ID)
+			} else {
+				token, err = GetCalendarToken(parsed.Context(), parsed.GuildData.GS.ID, true)
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			return "Subscribe to this url in your calendar app to see all events on this server: <" + CalendarFeedURL(parsed.GuildData.GS.ID, token) + ">\n" +
+				"Anyone with the url can see the events, create a new url with `events calendar -reset`", nil
+		},
+	}
+
 	cmdStopSetup := &commands.YAGCommand{
 		CmdCategory:         catEvents,
 		Name:                "StopSetup",
@@ -264,11 +397,40 @@ func (p *Plugin) AddCommands() {
 		},
 	}
 
+	cmdNative := &commands.YAGCommand{
+		CmdCategory:         catEvents,
+		Name:                "NativeEvents",
+		Aliases:             []string{"native"},
+		Description:         "Toggles mirroring new events as Discord events, shown in the events list of the server. Interest in the Discord event marks members as joining",
+		RequireDiscordPerms: []int64{discordgo.PermissionManageServer},
+		Plugin:              p,
+		RunFunc: func(parsed *dcmd.Data) (interface{}, error) {
+			enabled, err := NativeEventsEnabled(parsed.Context(), parsed.GuildData.GS.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			err = SetNativeEventsEnabled(parsed.Context(), parsed.GuildData.GS.ID, !enabled)
+			if err != nil {
+				return nil, err
+			}
+
+			if enabled {
+				return "New events are no longer mirrored as Discord events", nil
+			}
+
+			return "New events are now mirrored as Discord events, the bot needs the Manage Events permission. Use `events edit <id> -native on` for existing events", nil
+		},
+	}
+
 	container.AddCommand(cmdCreateEvent, cmdCreateEvent.GetTrigger())
 	container.AddCommand(cmdEdit, cmdEdit.GetTrigger())
 	container.AddCommand(cmdList, cmdList.GetTrigger())
 	container.AddCommand(cmdDel, cmdDel.GetTrigger())
 	container.AddCommand(cmdStopSetup, cmdStopSetup.GetTrigger())
+	container.AddCommand(cmdHistory, cmdHistory.GetTrigger())
+	container.AddCommand(cmdCalendar, cmdCalendar.GetTrigger())
+	container.AddCommand(cmdNative, cmdNative.GetTrigger())
 	container.Description = "Manage events"
 	commands.RegisterSlashCommandsContainer(container, true, func(gs *dstate.GuildSet) ([]int64, error) {
 		return nil, nil
@@ -376,6 +538,35 @@ func UpdateEventEmbed(m *models.RSVPSession) error {
 		Value: "React to mark you as a participant, undecided, or not joining",
 	})
 
+	if m.RepeatType != int16(RepeatNone) {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "Repeats",
+			Value:  strings.Title(RepeatDescription(RepeatType(m.RepeatType), m.RepeatEvery)),
+			Inline: true,
+		})
+	}
+
+	if m.NativeEventID != 0 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "Discord event",
+			Value:  "[Show](" + NativeEventURL(m) + ")",
+			Inline: true,
+		})
+	}
+
+	if len(m.RequiredRoles) > 0 {
+		mentions := make([]string, len(m.RequiredRoles))
+		for i, v := range m.RequiredRoles {
+			mentions[i] = "<@&" + strconv.FormatInt(v, 10) + ">"
+		}
+
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "Open to",
+			Value:  strings.Join(mentions, ", "),
+			Inline: true,
+		})
+	}
+
 	participantsEmbed := &discordgo.MessageEmbedField{
 		Name:   "Participants",
 		Inline: false,
@@ -476,6 +667,8 @@ func UpdateEventEmbed(m *models.RSVPSession) error {
 	if m.StartsAt.Before(time.Now()) {
 		// Remove the buttons if event has started
 		editMessage.Components = []discordgo.MessageComponent{}
+	} else {
+		editMessage.Components = eventButtons(m)
 	}
 
 	_, err := common.BotSession.ChannelMessageEditComplex(&editMessage)
@@ -552,6 +745,10 @@ func NextUpdateTime(m *models.RSVPSession) time.Time {
 	}
 }
 
+func describeScheduledUpdate(evt *eventModels.ScheduledEvent, data interface{}) string {
+	return fmt.Sprintf("Update the event on message %d", *data.(*int64))
+}
+
 func (p *Plugin) handleScheduledUpdate(evt *eventModels.ScheduledEvent, data interface{}) (retry bool, err error) {
 	mID := *(data.(*int64))
 
@@ -565,6 +762,7 @@ func (p *Plugin) handleScheduledUpdate(evt *eventModels.ScheduledEvent, data int
 		code, _ := common.DiscordError(err)
 		if code == discordgo.ErrCodeUnknownMessage || code == discordgo.ErrCodeUnknownChannel {
 			m.DeleteG(context.Background())
+			removeNativeEvent(m)
 			return false, nil
 		}
 
@@ -597,11 +795,43 @@ const (
 	ParticipantStateWaitlist   ParticipantState = 4
 )
 
+func (s ParticipantState) String() string {
+	switch s {
+	case ParticipantStateJoining:
+		return "Joined"
+	case ParticipantStateMaybe:
+		return "Undecided"
+	case ParticipantStateNotJoining:
+		return "Not joining"
+	case ParticipantStateWaitlist:
+		return "Waiting list"
+	}
+
+	return "Unknown"
+}
+
 func (p *Plugin) startEvent(m *models.RSVPSession) error {
 
 	p.sendReminders(m, "Event starting now!", "The event you signed up for: **"+m.Title+"** is starting now!")
 
-	_, err := m.DeleteG(context.Background())
+	err := startNativeEvent(m)
+	if err != nil {
+		logger.WithError(err).WithField("guild", m.GuildID).Error("failed starting the discord event of a rsvp event")
+	}
+
+	err = saveAttendance(context.Background(), m)
+	if err != nil {
+		logger.WithError(err).WithField("guild", m.GuildID).Error("failed saving rsvp attendance")
+	}
+
+	if m.RepeatType != int16(RepeatNone) {
+		err = spawnNextOccurrence(m)
+		if err != nil {
+			logger.WithError(err).WithField("guild", m.GuildID).Error("failed creating the next occurrence of a repeating event")
+		}
+	}
+
+	_, err = m.DeleteG(context.Background())
 	return err
 }
 
@@ -650,14 +880,6 @@ func (p *Plugin) handleInteractionCreate(evt *eventsystem.EventData) {
 		return
 	}
 
-	// Pong the interaction
-	err := common.BotSession.CreateInteractionResponse(ic.ID, ic.Token, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseDeferredMessageUpdate,
-	})
-	if err != nil {
-		return
-	}
-
 	m, err := models.RSVPSessions(models.RSVPSessionWhere.MessageID.EQ(ic.Message.ID), qm.Load("RSVPSessionsMessageRSVPParticipants", qm.OrderBy("marked_as_participating_at asc"))).OneG(context.Background())
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -667,6 +889,25 @@ func (p *Plugin) handleInteractionCreate(evt *eventsystem.EventData) {
 		return
 	}
 
+	if len(m.RequiredRoles) > 0 && !common.ContainsInt64SliceOneOf(ic.Member.Roles, m.RequiredRoles) {
+		common.BotSession.CreateInteractionResponse(ic.ID, ic.Token, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: "This event is only open to some roles, you can't respond to it.",
+				Flags:   uint64(discordgo.MessageFlagsEphemeral),
+			},
+		})
+		return
+	}
+
+	// Pong the interaction
+	err = common.BotSession.CreateInteractionResponse(ic.ID, ic.Token, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredMessageUpdate,
+	})
+	if err != nil {
+		return
+	}
+
 	foundExisting := false
 	var participant *models.RSVPParticipant
 	for _, v := range m.R.RSVPSessionsMessageRSVPParticipants {
@@ -723,6 +964,11 @@ func (p *Plugin) handleInteractionCreate(evt *eventsystem.EventData) {
 		logger.WithError(err).WithField("guild", ic.GuildID).Error("failed updating rsvp participant")
 	}
 
+	queueEmbedUpdate(m)
+}
+
+// queueEmbedUpdate updates the embed of the session, at most once every 5 seconds
+func queueEmbedUpdate(m *models.RSVPSession) {
 	updatingSessiosMU.Lock()
 	for _, v := range updatingSessionEmbeds {
 		if v.ID == m.MessageID {
@@ -0,0 +1,192 @@
+package rsvp
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/scheduledevents2"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+	"github.com/botlabs-gg/yagpdb/v2/rsvp/models"
+	"github.com/botlabs-gg/yagpdb/v2/timezonecompanion"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+type RepeatType int16
+
+const (
+	RepeatNone    RepeatType = 0
+	RepeatDaily   RepeatType = 1
+	RepeatWeekly  RepeatType = 2
+	RepeatMonthly RepeatType = 3
+)
+
+// Max value of "every n days/weeks/months"
+const MaxRepeatEvery = 52
+
+var repeatUnits = map[string]RepeatType{
+	"day":    RepeatDaily,
+	"days":   RepeatDaily,
+	"week":   RepeatWeekly,
+	"weeks":  RepeatWeekly,
+	"month":  RepeatMonthly,
+	"months": RepeatMonthly,
+}
+
+// ParseRepeat parses how often a event repeats, e.g. "no", "weekly", "biweekly" or "every 2 weeks"
+func ParseRepeat(s string) (repeat RepeatType, every int, ok bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	switch s {
+	case "no", "n", "none", "never", "once":
+		return RepeatNone, 1, true
+	case "daily", "every day":
+		return RepeatDaily, 1, true
+	case "weekly", "every week":
+		return RepeatWeekly, 1, true
+	case "biweekly", "fortnightly":
+		return RepeatWeekly, 2, true
+	case "monthly", "every month":
+		return RepeatMonthly, 1, true
+	}
+
+	fields := strings.Fields(strings.TrimPrefix(s, "every "))
+	if len(fields) != 2 {
+		return RepeatNone, 0, false
+	}
+
+	every, err := strconv.Atoi(fields[0])
+	if err != nil || every < 1 || every > MaxRepeatEvery {
+		return RepeatNone, 0, false
+	}
+
+	repeat, ok = repeatUnits[fields[1]]
+	return repeat, every, ok
+}
+
+// RepeatDescription returns a human readable description of how often a event repeats
+func RepeatDescription(repeat RepeatType, every int) string {
+	var unit string
+	switch repeat {
+	case RepeatDaily:
+		unit = "day"
+	case RepeatWeekly:
+		unit = "week"
+	case RepeatMonthly:
+		unit = "month"
+	default:
+		return "never"
+	}
+
+	if every <= 1 {
+		return "every " + unit
+	}
+
+	return fmt.Sprintf("every %d %ss", every, unit)
+}
+
+// NextOccurrence returns the next start of a repeating event that started at t, or the zero time if it doesn't repeat.
+// The wall clock time in loc is kept so the event doesn't move around with daylight saving time,
+// monthly events on days that don't exist in the next month are moved to the last day of that month.
+func NextOccurrence(t time.Time, repeat RepeatType, every int, loc *time.Location) time.Time {
+	if every < 1 {
+		every = 1
+	}
+
+	t = t.In(loc)
+	switch repeat {
+	case RepeatDaily:
+		return t.AddDate(0, 0, every)
+	case RepeatWeekly:
+		return t.AddDate(0, 0, 7*every)
+	case RepeatMonthly:
+		firstOfMonth := time.Date(t.Year(), t.Month()+time.Month(every), 1, t.Hour(), t.Minute(), t.Second(), 0, loc)
+		daysInMonth := firstOfMonth.AddDate(0, 1, -1).Day()
+
+		day := t.Day()
+		if day > daysInMonth {
+			day = daysInMonth
+		}
+
+		return firstOfMonth.AddDate(0, 0, day-1)
+	}
+
+	return time.Time{}
+}
+
+// spawnNextOccurrence creates the session for the next time a repeating event starts
+func spawnNextOccurrence(m *models.RSVPSession) error {
+	loc := timezonecompanion.GetUserTimezone(m.AuthorID)
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	next := NextOccurrence(m.StartsAt, RepeatType(m.RepeatType), m.RepeatEvery, loc)
+	for !next.IsZero() && next.Before(time.Now()) {
+		// skip the ones that were missed while the bot was down
+		next = NextOccurrence(next, RepeatType(m.RepeatType), m.RepeatEvery, loc)
+	}
+
+	if next.IsZero() {
+		return nil
+	}
+
+	msg, err := common.BotSession.ChannelMessageSendComplex(m.ChannelID, &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{{Description: "Setting up RSVP Event..."}},
+	})
+	if err != nil {
+		return err
+	}
+
+	localID, err := common.GenLocalIncrID(m.GuildID, "rsvp_session")
+	if err != nil {
+		return err
+	}
+
+	seriesID := m.SeriesID
+	if seriesID == 0 {
+		seriesID = m.LocalID
+	}
+
+	nextSession := &models.RSVPSession{
+		MessageID: msg.ID,
+
+		AuthorID:  m.AuthorID,
+		ChannelID: m.ChannelID,
+		GuildID:   m.GuildID,
+		LocalID:   localID,
+
+		CreatedAt: time.Now(),
+		StartsAt:  next,
+
+		Title:           m.Title,
+		Description:     m.Description,
+		MaxParticipants: m.MaxParticipants,
+		SendReminders:   m.SendReminders,
+
+		RepeatType:    m.RepeatType,
+		RepeatEvery:   m.RepeatEvery,
+		SeriesID:      seriesID,
+		RequiredRoles: m.RequiredRoles,
+	}
+
+	err = nextSession.InsertG(context.Background(), boil.Infer())
+	if err != nil {
+		common.BotSession.ChannelMessageDelete(m.ChannelID, msg.ID)
+		return err
+	}
+
+	if m.NativeEventID != 0 {
+		createNativeEventLogged(nextSession)
+	}
+
+	err = UpdateEventEmbed(nextSession)
+	if err != nil {
+		return err
+	}
+
+	return scheduledevents2.ScheduleEvent("rsvp_update_session", nextSession.GuildID, NextUpdateTime(nextSession), nextSession.MessageID)
+}
@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/scheduledevents2"
 	"github.com/botlabs-gg/yagpdb/v2/lib/when"
 	"github.com/botlabs-gg/yagpdb/v2/lib/when/rules"
 	wcommon "github.com/botlabs-gg/yagpdb/v2/lib/when/rules/common"
@@ -61,4 +62,6 @@ func RegisterPlugin() {
 
 	common.InitSchemas("rsvp", DBSchemas...)
 	common.RegisterPlugin(p)
+
+	scheduledevents2.RegisterDescriber("rsvp_update_session", int64(0), describeScheduledUpdate)
 }
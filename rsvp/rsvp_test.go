@@ -0,0 +1,144 @@
+package rsvp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/rsvp/models"
+)
+
+func TestParseRepeat(t *testing.T) {
+	cases := []struct {
+		input  string
+		repeat RepeatType
+		every  int
+		ok     bool
+	}{
+		{"no", RepeatNone, 1, true},
+		{"Weekly", RepeatWeekly, 1, true},
+		{"biweekly", RepeatWeekly, 2, true},
+		{"every 3 days", RepeatDaily, 3, true},
+		{"2 months", RepeatMonthly, 2, true},
+		{"every 0 weeks", RepeatNone, 0, false},
+		{"every 100 weeks", RepeatNone, 0, false},
+		{"sometimes", RepeatNone, 0, false},
+	}
+
+	for _, c := range cases {
+		repeat, every, ok := ParseRepeat(c.input)
+		if repeat != c.repeat || every != c.every || ok != c.ok {
+			t.Errorf("ParseRepeat(%q) = %d, %d, %t, expected %d, %d, %t", c.input, repeat, every, ok, c.repeat, c.every, c.ok)
+		}
+	}
+}
+
+func TestNextOccurrence(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("timezone data not available:", err)
+	}
+
+	// keeps the wall clock time over the daylight saving time change
+	start := time.Date(2023, 3, 20, 20, 0, 0, 0, loc)
+	next := NextOccurrence(start, RepeatWeekly, 1, loc)
+	if !next.Equal(time.Date(2023, 3, 27, 20, 0, 0, 0, loc)) {
+		t.Errorf("unexpected weekly occurrence: %s", next)
+	}
+
+	next = NextOccurrence(time.Date(2023, 1, 31, 18, 0, 0, 0, time.UTC), RepeatMonthly, 1, time.UTC)
+	if !next.Equal(time.Date(2023, 2, 28, 18, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected monthly event to move to the end of february, got %s", next)
+	}
+
+	if !NextOccurrence(start, RepeatNone, 1, loc).IsZero() {
+		t.Error("expected no next occurrence for a non repeating event")
+	}
+}
+
+func TestFinalStates(t *testing.T) {
+	participants := []*models.RSVPParticipant{
+		{JoinState: int16(ParticipantStateJoining)},
+		{JoinState: int16(ParticipantStateMaybe)},
+		{JoinState: int16(ParticipantStateJoining)},
+		{JoinState: int16(ParticipantStateJoining)},
+	}
+
+	states := FinalStates(2, participants)
+	expected := []ParticipantState{ParticipantStateJoining, ParticipantStateMaybe, ParticipantStateJoining, ParticipantStateWaitlist}
+	for i, v := range expected {
+		if states[i] != v {
+			t.Errorf("participant %d: expected state %d, got %d", i, v, states[i])
+		}
+	}
+}
+
+func TestWriteICS(t *testing.T) {
+	event := &models.RSVPSession{
+		GuildID:     1,
+		ChannelID:   2,
+		MessageID:   3,
+		LocalID:     4,
+		SeriesID:    4,
+		Title:       "Raid night; bring potions, " + strings.Repeat("long title ", 10),
+		StartsAt:    time.Date(2023, 5, 1, 19, 0, 0, 0, time.UTC),
+		RepeatType:  int16(RepeatWeekly),
+		RepeatEvery: 2,
+	}
+
+	var out strings.Builder
+	err := WriteICS(&out, "Test events", []*models.RSVPSession{event}, time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ics := out.String()
+	for _, v := range []string{"UID:rsvp-1-3@yagpdb", "DTSTART:20230501T190000Z", "DTEND:20230501T200000Z", `SUMMARY:Raid night\; bring potions\,`} {
+		if !strings.Contains(ics, v) {
+			t.Errorf("expected %q in the calendar:\n%s", v, ics)
+		}
+	}
+
+	// the next occurrence is a separate session with its own message
+	if strings.Contains(ics, "RRULE:") {
+		t.Errorf("occurrences of repeating events should not have a recurrence rule:\n%s", ics)
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line longer than 75 bytes: %q", line)
+		}
+	}
+}
+
+func TestNativeEventParams(t *testing.T) {
+	event := &models.RSVPSession{
+		GuildID:   1,
+		ChannelID: 2,
+		MessageID: 3,
+		Title:     strings.Repeat("a", 150),
+		StartsAt:  time.Date(2023, 5, 1, 19, 0, 0, 0, time.UTC),
+	}
+
+	params := NativeEventParams(event, "raids")
+	if params.EntityMetadata.Location != "#raids" {
+		t.Errorf("unexpected location: %q", params.EntityMetadata.Location)
+	}
+
+	if len([]rune(params.Name)) > MaxNativeNameLength {
+		t.Errorf("name longer than %d characters: %d", MaxNativeNameLength, len([]rune(params.Name)))
+	}
+
+	if !params.ScheduledEndTime.Equal(event.StartsAt.Add(DefaultEventDuration)) {
+		t.Errorf("unexpected end time: %s", params.ScheduledEndTime)
+	}
+
+	if !strings.Contains(params.Description, "https://discord.com/channels/1/2/3") {
+		t.Errorf("expected a link to the event message in the description: %q", params.Description)
+	}
+
+	params = NativeEventParams(event, "")
+	if params.EntityMetadata.Location != "https://discord.com/channels/1/2/3" {
+		t.Errorf("expected the message link as location without a channel name, got %q", params.EntityMetadata.Location)
+	}
+}
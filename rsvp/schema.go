@@ -32,4 +32,38 @@ CREATE TABLE IF NOT EXISTS rsvp_participants (
 
 	PRIMARY KEY(rsvp_sessions_message_id, user_id)
 );
+`, `
+ALTER TABLE rsvp_sessions ADD COLUMN IF NOT EXISTS repeat_type SMALLINT NOT NULL DEFAULT 0;
+`, `
+ALTER TABLE rsvp_sessions ADD COLUMN IF NOT EXISTS repeat_every INT NOT NULL DEFAULT 1;
+`, `
+ALTER TABLE rsvp_sessions ADD COLUMN IF NOT EXISTS series_id BIGINT NOT NULL DEFAULT 0;
+`, `
+ALTER TABLE rsvp_sessions ADD COLUMN IF NOT EXISTS required_roles BIGINT[] NOT NULL DEFAULT '{}';
+`, `
+CREATE TABLE IF NOT EXISTS rsvp_attendance (
+	id BIGSERIAL PRIMARY KEY,
+	guild_id BIGINT NOT NULL,
+	user_id BIGINT NOT NULL,
+
+	series_id BIGINT NOT NULL,
+	event_local_id BIGINT NOT NULL,
+	title TEXT NOT NULL,
+	starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	join_state SMALLINT NOT NULL
+);
+`, `
+CREATE INDEX IF NOT EXISTS rsvp_attendance_guild_user_idx ON rsvp_attendance(guild_id, user_id, starts_at DESC);
+`, `
+CREATE TABLE IF NOT EXISTS rsvp_configs (
+	guild_id BIGINT PRIMARY KEY,
+	calendar_token TEXT NOT NULL
+);
+`, `
+ALTER TABLE rsvp_configs ADD COLUMN IF NOT EXISTS native_events BOOLEAN NOT NULL DEFAULT false;
+`, `
+ALTER TABLE rsvp_sessions ADD COLUMN IF NOT EXISTS native_event_id BIGINT NOT NULL DEFAULT 0;
+`, `
+CREATE INDEX IF NOT EXISTS rsvp_sessions_native_event_id_idx ON rsvp_sessions(native_event_id) WHERE native_event_id != 0;
 `}
@@ -29,6 +29,7 @@ const (
 	SetupStateMaxParticipants
 	SetupStateWhen
 	SetupStateWhenConfirm
+	SetupStateRepeat
 )
 
 type SetupSession struct {
@@ -48,6 +49,8 @@ type SetupSession struct {
 	Title           string
 	Channel         int64
 	When            time.Time
+	Repeat          RepeatType
+	RepeatEvery     int
 
 	LastAction time.Time
 	stopCH     chan bool
@@ -90,6 +93,8 @@ func (s *SetupSession) handleMessage(m *discordgo.Message) {
 		s.handleMessageSetupStateWhen(m)
 	case SetupStateWhenConfirm:
 		s.handleMessageSetupStateWhenConfirm(m)
+	case SetupStateRepeat:
+		s.handleMessageSetupStateRepeat(m)
 	}
 }
 
@@ -211,13 +216,26 @@ func (s *SetupSession) handleMessageSetupStateWhenConfirm(m *discordgo.Message)
 	}
 
 	if lower[0] == 'y' {
-		s.Finish()
+		s.State = SetupStateRepeat
+		s.sendMessage("Should this event repeat? A new event is created automatically when it starts. (`no`, `daily`, `weekly`, `monthly` or for example `every 2 weeks`)")
 	} else {
 		s.State = SetupStateWhen
 		s.sendMessage("Please enter when this event starts. (example: `tomorrow 10pm`, `10 may 2pm`)")
 	}
 }
 
+func (s *SetupSession) handleMessageSetupStateRepeat(m *discordgo.Message) {
+	repeat, every, ok := ParseRepeat(m.Content)
+	if !ok {
+		s.sendMessage("Couldn't understand that, answer with `no`, `daily`, `weekly`, `monthly` or for example `every 2 weeks`")
+		return
+	}
+
+	s.Repeat = repeat
+	s.RepeatEvery = every
+	s.Finish()
+}
+
 func (s *SetupSession) Finish() {
 
 	// reserve the message
@@ -260,6 +278,10 @@ func (s *SetupSession) Finish() {
 		Title:           s.Title,
 		MaxParticipants: s.MaxParticipants,
 		SendReminders:   true,
+
+		RepeatType:  int16(s.Repeat),
+		RepeatEvery: s.RepeatEvery,
+		SeriesID:    localID,
 	}
 
 	err = m.InsertG(context.Background(), boil.Infer())
@@ -268,6 +290,12 @@ func (s *SetupSession) Finish() {
 		return
 	}
 
+	if enabled, err := NativeEventsEnabled(context.Background(), s.GuildID); err != nil {
+		logger.WithError(err).WithField("guild", s.GuildID).Error("failed retrieving rsvp config")
+	} else if enabled {
+		createNativeEventLogged(m)
+	}
+
 	// set up the proper message
 	err = UpdateEventEmbed(m)
 	if err != nil {
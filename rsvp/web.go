@@ -0,0 +1,81 @@
+package rsvp
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/rsvp/models"
+	"github.com/botlabs-gg/yagpdb/v2/web"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"goji.io/pat"
+)
+
+var _ web.Plugin = (*Plugin)(nil)
+
+func (p *Plugin) InitWeb() {
+	web.ServerPublicMux.Handle(pat.Get("/rsvp/calendar/:token"), http.HandlerFunc(HandleCalendarFeed))
+	web.ServerPublicMux.Handle(pat.Get("/rsvp/events/:event"), http.HandlerFunc(HandleEventICS))
+}
+
+// HandleCalendarFeed serves all upcoming events of the guild as a iCalendar feed, the url contains a secret token
+// created with the events calendar command
+func HandleCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	activeGuild, _ := web.GetBaseCPContextData(ctx)
+
+	token, err := GetCalendarToken(ctx, activeGuild.ID, false)
+	if err != nil {
+		web.CtxLogger(ctx).WithError(err).Error("failed retrieving rsvp calendar token")
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	given := strings.TrimSuffix(pat.Param(r, "token"), ".ics")
+	if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(given)) != 1 {
+		http.NotFound(w, r)
+		return
+	}
+
+	events, err := models.RSVPSessions(models.RSVPSessionWhere.GuildID.EQ(activeGuild.ID), qm.OrderBy("starts_at asc")).AllG(ctx)
+	if err != nil {
+		web.CtxLogger(ctx).WithError(err).Error("failed retrieving rsvp events")
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	writeICSResponse(w, activeGuild.Name+" events", "events.ics", events)
+}
+
+// HandleEventICS serves a single event as a iCalendar file, for the add to calendar button on the event
+func HandleEventICS(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	activeGuild, _ := web.GetBaseCPContextData(ctx)
+
+	messageID, err := strconv.ParseInt(strings.TrimSuffix(pat.Param(r, "event"), ".ics"), 10, 64)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	event, err := models.RSVPSessions(models.RSVPSessionWhere.GuildID.EQ(activeGuild.ID), models.RSVPSessionWhere.MessageID.EQ(messageID)).OneG(ctx)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	writeICSResponse(w, activeGuild.Name+" events", "event-"+strconv.FormatInt(event.LocalID, 10)+".ics", []*models.RSVPSession{event})
+}
+
+func writeICSResponse(w http.ResponseWriter, calendarName, fileName string, events []*models.RSVPSession) {
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+fileName+`"`)
+	w.Header().Set("Cache-Control", "max-age=300")
+
+	err := WriteICS(w, calendarName, events, time.Now())
+	if err != nil {
+		logger.WithError(err).Error("failed writing ics response")
+	}
+}
@@ -1,12 +1,15 @@
 package soundboard
 
 import (
+	"fmt"
 	"strings"
 
 	"emperror.dev/errors"
 	"github.com/botlabs-gg/yagpdb/v2/analytics"
 	"github.com/botlabs-gg/yagpdb/v2/commands"
+	"github.com/botlabs-gg/yagpdb/v2/common"
 	"github.com/botlabs-gg/yagpdb/v2/lib/dcmd"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
 	"github.com/botlabs-gg/yagpdb/v2/lib/dstate"
 	"github.com/botlabs-gg/yagpdb/v2/soundboard/models"
 )
@@ -46,41 +49,9 @@ func (p *Plugin) AddCommands() {
 
 				if sound == nil {
 					return "Sound not found, " + ListSounds(sounds, member), nil
-				} else if !CanPlaySound(sound, member.Member.Roles) {
-					return "You can't play that sound, either you have a blacklisted role or missing a required role for this sound", nil
 				}
 
-				status := TranscodingStatus(sound.Status)
-				if status != TranscodingStatusReady {
-					switch status {
-					case TranscodingStatusQueued:
-						return "This sound has yet to be transcoded, if it appear to be stuck in this state then contact support", nil
-					case TranscodingStatusFailedLong:
-						return "This sound is too long", nil
-					case TranscodingStatusFailedOther:
-						return "This sound failed transcoding, which means you linked or uploaded a invalid media file. You cannot link youtube videos or web pages, has to be direct links to a media file.", nil
-					case TranscodingStatusTranscoding:
-						return "This sound is in the process of being converted, please try again in a couple seconds...", nil
-					}
-				}
-
-				var voiceChannel int64
-				vs := data.GuildData.GS.GetVoiceState(data.Author.ID)
-				if vs != nil {
-					voiceChannel = vs.ChannelID
-				}
-
-				if voiceChannel == 0 {
-					return "You're not in a voice channel", nil
-				}
-
-				go analytics.RecordActiveUnit(data.GuildData.GS.ID, p, "playing sound")
-
-				if RequestPlaySound(data.GuildData.GS.ID, voiceChannel, data.ChannelID, sound.ID) {
-					return "Queued up", nil
-				}
-
-				return "Playing it now", nil
+				return playSoundFor(data.GuildData.GS, member.User.ID, member.Member.Roles, data.ChannelID, sound)
 			},
 		},
 
@@ -98,9 +69,169 @@ func (p *Plugin) AddCommands() {
 				}
 				return "Reset Complete!", nil
 			},
+		},
+
+		&commands.YAGCommand{
+			CmdCategory:         commands.CategoryFun,
+			Name:                "SoundboardQueue",
+			Aliases:             []string{"sbqueue", "sbq"},
+			Description:         "Shows the sound playing and the ones queued up after it",
+			SlashCommandEnabled: true,
+			DefaultEnabled:      true,
+			RunFunc: func(data *dcmd.Data) (interface{}, error) {
+				return describeQueue(GetQueue(data.GuildData.GS.ID)), nil
+			},
+		},
+
+		&commands.YAGCommand{
+			CmdCategory:         commands.CategoryFun,
+			Name:                "SoundboardSkip",
+			Aliases:             []string{"sbskip", "sbs"},
+			Description:         "Skips the sound playing",
+			SlashCommandEnabled: true,
+			DefaultEnabled:      true,
+			RunFunc: func(data *dcmd.Data) (interface{}, error) {
+				return skipResponse(skipSound(data.GuildData.GS.ID)), nil
+			},
+		},
+
+		&commands.YAGCommand{
+			CmdCategory:         commands.CategoryFun,
+			Name:                "SoundboardStop",
+			Aliases:             []string{"sbstop"},
+			Description:         "Stops the sound playing and clears the queue",
+			SlashCommandEnabled: true,
+			DefaultEnabled:      true,
+			RunFunc: func(data *dcmd.Data) (interface{}, error) {
+				return stopResponse(stopSounds(data.GuildData.GS.ID)), nil
+			},
+		},
+
+		&commands.YAGCommand{
+			CmdCategory:         commands.CategoryFun,
+			Name:                "SoundboardPanel",
+			Aliases:             []string{"sbpanel"},
+			Description:         "Posts a message with buttons to play the soundboard sounds",
+			RequireDiscordPerms: []int64{discordgo.PermissionManageMessages},
+			SlashCommandEnabled: true,
+			DefaultEnabled:      false,
+			RunFunc: func(data *dcmd.Data) (interface{}, error) {
+				sounds, err := GetSoundboardSounds(data.GuildData.GS.ID, data.Context())
+				if err != nil {
+					return nil, errors.WithMessage(err, "GetSoundboardSounds")
+				}
+
+				components := panelComponents(sounds)
+				if len(components) < 2 {
+					return "There are no sounds ready to be played, upload some in the control panel first.", nil
+				}
+
+				return &discordgo.MessageSend{
+					Embeds: []*discordgo.MessageEmbed{{
+						Title:       "Soundboard",
+						Description: "Click a sound to play it in your voice channel.",
+						Color:       panelColor,
+					}},
+					Components: components,
+				}, nil
+			},
 		})
 }
 
+// playSoundFor checks that the member can play the sound right now and queues it up, returns the response to show them
+func playSoundFor(gs *dstate.GuildSet, userID int64, roles []int64, channelRanFrom int64, sound *models.SoundboardSound) (string, error) {
+	if !CanPlaySound(sound, roles) {
+		return "You can't play that sound, either you have a blacklisted role or missing a required role for this sound", nil
+	}
+
+	status := TranscodingStatus(sound.Status)
+	if status != TranscodingStatusReady {
+		switch status {
+		case TranscodingStatusQueued:
+			return "This sound has yet to be transcoded, if it appear to be stuck in this state then contact support", nil
+		case TranscodingStatusFailedLong:
+			return "This sound is too long", nil
+		case TranscodingStatusFailedOther:
+			return "This sound failed transcoding, which means you linked or uploaded a invalid media file. You cannot link youtube videos or web pages, has to be direct links to a media file.", nil
+		case TranscodingStatusTranscoding:
+			return "This sound is in the process of being converted, please try again in a couple seconds...", nil
+		}
+	}
+
+	var voiceChannel int64
+	vs := gs.GetVoiceState(userID)
+	if vs != nil {
+		voiceChannel = vs.ChannelID
+	}
+
+	if voiceChannel == 0 {
+		return "You're not in a voice channel", nil
+	}
+
+	left, err := CheckSetCooldowns(sound, userID)
+	if err != nil {
+		return "", err
+	}
+	if left > 0 {
+		return fmt.Sprintf("`%s` is on cooldown, try again in %s", sound.Name, common.HumanizeDuration(common.DurationPrecisionSeconds, left)), nil
+	}
+
+	go analytics.RecordActiveUnit(gs.ID, &Plugin{}, "playing sound")
+
+	queued, err := RequestPlaySound(gs.ID, voiceChannel, channelRanFrom, sound, userID)
+	if err != nil {
+		// the sound wasn't played, so it shouldn't be on cooldown
+		ClearCooldowns(sound, userID)
+
+		if err == ErrQueueFull {
+			return fmt.Sprintf("The queue is full (max %d sounds), wait for some of them to finish playing", MaxQueueLength), nil
+		}
+		return "", err
+	}
+
+	if queued {
+		return "Queued up", nil
+	}
+
+	return "Playing it now", nil
+}
+
+func describeQueue(current *PlayRequest, queue []*PlayRequest) string {
+	if current == nil && len(queue) < 1 {
+		return "Nothing is playing."
+	}
+
+	var out strings.Builder
+	if current != nil {
+		out.WriteString(fmt.Sprintf("Now playing: `%s` (requested by <@%d>)\n", current.SoundName, current.RequestedBy))
+	}
+
+	if len(queue) > 0 {
+		out.WriteString("\nUp next:\n")
+		for i, v := range queue {
+			out.WriteString(fmt.Sprintf("%d. `%s` (requested by <@%d>)\n", i+1, v.SoundName, v.RequestedBy))
+		}
+	}
+
+	return out.String()
+}
+
+func skipResponse(skipped *PlayRequest) string {
+	if skipped == nil {
+		return "Nothing is playing."
+	}
+
+	return fmt.Sprintf("Skipped `%s`", skipped.SoundName)
+}
+
+func stopResponse(stopped bool) string {
+	if !stopped {
+		return "Nothing is playing."
+	}
+
+	return "Stopped playing and cleared the queue."
+}
+
 func ListSounds(sounds []*models.SoundboardSound, ms *dstate.MemberState) string {
 	canPlay := ""
 	restricted := ""
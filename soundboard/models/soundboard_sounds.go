@@ -33,6 +33,8 @@ type SoundboardSound struct {
 	Status           int              `boil:"status" json:"status" toml:"status" yaml:"status"`
 	RequiredRoles    types.Int64Array `boil:"required_roles" json:"required_roles,omitempty" toml:"required_roles" yaml:"required_roles,omitempty"`
 	BlacklistedRoles types.Int64Array `boil:"blacklisted_roles" json:"blacklisted_roles,omitempty" toml:"blacklisted_roles" yaml:"blacklisted_roles,omitempty"`
+	Cooldown         int              `boil:"cooldown" json:"cooldown" toml:"cooldown" yaml:"cooldown"`
+	UserCooldown     int              `boil:"user_cooldown" json:"user_cooldown" toml:"user_cooldown" yaml:"user_cooldown"`
 
 	R *soundboardSoundR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L soundboardSoundL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -48,6 +50,8 @@ var SoundboardSoundColumns = struct {
 	Status           string
 	RequiredRoles    string
 	BlacklistedRoles string
+	Cooldown         string
+	UserCooldown     string
 }{
 	ID:               "id",
 	CreatedAt:        "created_at",
@@ -58,6 +62,8 @@ var SoundboardSoundColumns = struct {
 	Status:           "status",
 	RequiredRoles:    "required_roles",
 	BlacklistedRoles: "blacklisted_roles",
+	Cooldown:         "cooldown",
+	UserCooldown:     "user_cooldown",
 }
 
 var SoundboardSoundTableColumns = struct {
@@ -70,6 +76,8 @@ var SoundboardSoundTableColumns = struct {
 	Status           string
 	RequiredRoles    string
 	BlacklistedRoles string
+	Cooldown         string
+	UserCooldown     string
 }{
 	ID:               "soundboard_sounds.id",
 	CreatedAt:        "soundboard_sounds.created_at",
@@ -80,6 +88,8 @@ var SoundboardSoundTableColumns = struct {
 	Status:           "soundboard_sounds.status",
 	RequiredRoles:    "soundboard_sounds.required_roles",
 	BlacklistedRoles: "soundboard_sounds.blacklisted_roles",
+	Cooldown:         "soundboard_sounds.cooldown",
+	UserCooldown:     "soundboard_sounds.user_cooldown",
 }
 
 // Generated where
@@ -212,6 +222,8 @@ var SoundboardSoundWhere = struct {
 	Status           whereHelperint
 	RequiredRoles    whereHelpertypes_Int64Array
 	BlacklistedRoles whereHelpertypes_Int64Array
+	Cooldown         whereHelperint
+	UserCooldown     whereHelperint
 }{
 	ID:               whereHelperint{field: "\"soundboard_sounds\".\"id\""},
 	CreatedAt:        whereHelpertime_Time{field: "\"soundboard_sounds\".\"created_at\""},
@@ -222,6 +234,8 @@ var SoundboardSoundWhere = struct {
 	Status:           whereHelperint{field: "\"soundboard_sounds\".\"status\""},
 	RequiredRoles:    whereHelpertypes_Int64Array{field: "\"soundboard_sounds\".\"required_roles\""},
 	BlacklistedRoles: whereHelpertypes_Int64Array{field: "\"soundboard_sounds\".\"blacklisted_roles\""},
+	Cooldown:         whereHelperint{field: "\"soundboard_sounds\".\"cooldown\""},
+	UserCooldown:     whereHelperint{field: "\"soundboard_sounds\".\"user_cooldown\""},
 }
 
 // SoundboardSoundRels is where relationship names are stored.
@@ -241,9 +255,9 @@ func (*soundboardSoundR) NewStruct() *soundboardSoundR {
 type soundboardSoundL struct{}
 
 var (
-	soundboardSoundAllColumns            = []string{"id", "created_at", "updated_at", "guild_id", "required_role", "name", "status", "required_roles", "blacklisted_roles"}
+	soundboardSoundAllColumns            = []string{"id", "created_at", "updated_at", "guild_id", "required_role", "name", "status", "required_roles", "blacklisted_roles", "cooldown", "user_cooldown"}
 	soundboardSoundColumnsWithoutDefault = []string{"created_at", "updated_at", "guild_id", "required_role", "name", "status"}
-	soundboardSoundColumnsWithDefault    = []string{"id", "required_roles", "blacklisted_roles"}
+	soundboardSoundColumnsWithDefault    = []string{"id", "required_roles", "blacklisted_roles", "cooldown", "user_cooldown"}
 	soundboardSoundPrimaryKeyColumns     = []string{"id"}
 	soundboardSoundGeneratedColumns      = []string{}
 )
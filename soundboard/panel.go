@@ -0,0 +1,188 @@
+package soundboard
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/botlabs-gg/yagpdb/v2/bot"
+	"github.com/botlabs-gg/yagpdb/v2/bot/eventsystem"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+	"github.com/botlabs-gg/yagpdb/v2/soundboard/models"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const panelColor = 0x7289da
+
+// Custom ids of the panel components, play buttons are followed by the sound id
+const (
+	customIDPlay   = "soundboard_play"
+	customIDSelect = "soundboard_select"
+	customIDQueue  = "soundboard_queue"
+	customIDSkip   = "soundboard_skip"
+	customIDStop   = "soundboard_stop"
+)
+
+const (
+	// Up to this many sounds get their own button, more than that are put in select menus
+	maxPanelButtons = 20
+	// 4 select menus, leaving a row for the controls
+	maxPanelSounds = 100
+)
+
+var _ bot.BotInitHandler = (*Plugin)(nil)
+
+func (p *Plugin) BotInit() {
+	eventsystem.AddHandlerAsyncLastLegacy(p, p.handleInteractionCreate, eventsystem.EventInteractionCreate)
+}
+
+// panelComponents returns the rows of the panel, the ready sounds followed by the queue controls
+func panelComponents(sounds []*models.SoundboardSound) []discordgo.MessageComponent {
+	var ready []*models.SoundboardSound
+	for _, v := range sounds {
+		if TranscodingStatus(v.Status) == TranscodingStatusReady {
+			ready = append(ready, v)
+		}
+	}
+
+	if len(ready) > maxPanelSounds {
+		ready = ready[:maxPanelSounds]
+	}
+
+	var rows []discordgo.MessageComponent
+	if len(ready) <= maxPanelButtons {
+		var row []discordgo.MessageComponent
+		for _, v := range ready {
+			row = append(row, discordgo.Button{
+				Label:    common.CutStringShort(v.Name, 80),
+				Style:    discordgo.SecondaryButton,
+				CustomID: customIDPlay + ":" + strconv.Itoa(v.ID),
+			})
+
+			if len(row) == 5 {
+				rows = append(rows, discordgo.ActionsRow{Components: row})
+				row = nil
+			}
+		}
+
+		if len(row) > 0 {
+			rows = append(rows, discordgo.ActionsRow{Components: row})
+		}
+	} else {
+		for i := 0; i < len(ready); i += 25 {
+			end := i + 25
+			if end > len(ready) {
+				end = len(ready)
+			}
+
+			menu := discordgo.SelectMenu{
+				CustomID:    customIDSelect + ":" + strconv.Itoa(i/25),
+				Placeholder: "Play a sound (" + strconv.Itoa(i+1) + "-" + strconv.Itoa(end) + ")",
+			}
+			for _, v := range ready[i:end] {
+				menu.Options = append(menu.Options, &discordgo.SelectMenuOption{
+					Label: common.CutStringShort(v.Name, 100),
+					Value: strconv.Itoa(v.ID),
+				})
+			}
+
+			rows = append(rows, discordgo.ActionsRow{Components: []discordgo.MessageComponent{menu}})
+		}
+	}
+
+	rows = append(rows, discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+		discordgo.Button{Label: "Queue", Style: discordgo.PrimaryButton, CustomID: customIDQueue},
+		discordgo.Button{Label: "Skip", Style: discordgo.SecondaryButton, CustomID: customIDSkip},
+		discordgo.Button{Label: "Stop", Style: discordgo.DangerButton, CustomID: customIDStop},
+	}})
+
+	return rows
+}
+
+// parsePanelCustomID returns the action of a panel component, and the sound id for play buttons
+func parsePanelCustomID(customID string) (action string, soundID int, ok bool) {
+	action, param, _ := strings.Cut(customID, ":")
+	switch action {
+	case customIDPlay:
+		soundID, err := strconv.Atoi(param)
+		if err != nil {
+			return "", 0, false
+		}
+		return action, soundID, true
+	case customIDSelect, customIDQueue, customIDSkip, customIDStop:
+		return action, 0, true
+	}
+
+	return "", 0, false
+}
+
+func (p *Plugin) handleInteractionCreate(evt *eventsystem.EventData) {
+	ic := evt.InteractionCreate()
+	if ic.Type != discordgo.InteractionMessageComponent || ic.GuildID == 0 || ic.Member == nil {
+		return
+	}
+
+	data := ic.MessageComponentData()
+	action, soundID, ok := parsePanelCustomID(data.CustomID)
+	if !ok {
+		return
+	}
+
+	reply := func(content string) {
+		common.BotSession.CreateInteractionResponse(ic.ID, ic.Token, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content:         content,
+				Flags:           uint64(discordgo.MessageFlagsEphemeral),
+				AllowedMentions: &discordgo.AllowedMentions{},
+			},
+		})
+	}
+
+	switch action {
+	case customIDQueue:
+		reply(describeQueue(GetQueue(ic.GuildID)))
+		return
+	case customIDSkip:
+		reply(skipResponse(skipSound(ic.GuildID)))
+		return
+	case customIDStop:
+		reply(stopResponse(stopSounds(ic.GuildID)))
+		return
+	case customIDSelect:
+		if len(data.Values) != 1 {
+			return
+		}
+
+		var err error
+		soundID, err = strconv.Atoi(data.Values[0])
+		if err != nil {
+			return
+		}
+	}
+
+	gs := bot.State.GetGuild(ic.GuildID)
+	if gs == nil {
+		return
+	}
+
+	sound, err := models.SoundboardSounds(qm.Where("guild_id = ? AND id = ?", ic.GuildID, soundID)).OneG(context.Background())
+	if err != nil {
+		if err != sql.ErrNoRows {
+			logger.WithError(err).WithField("guild", ic.GuildID).Error("failed retrieving sound")
+		}
+		reply("That sound doesn't exist anymore.")
+		return
+	}
+
+	resp, err := playSoundFor(gs, ic.Member.User.ID, ic.Member.Roles, ic.ChannelID, sound)
+	if err != nil {
+		logger.WithError(err).WithField("guild", ic.GuildID).Error("failed playing sound from panel")
+		reply("Something went wrong, try again later.")
+		return
+	}
+
+	reply(resp)
+}
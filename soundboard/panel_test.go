@@ -0,0 +1,86 @@
+package soundboard
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+	"github.com/botlabs-gg/yagpdb/v2/soundboard/models"
+)
+
+func testSounds(n int) []*models.SoundboardSound {
+	sounds := make([]*models.SoundboardSound, n)
+	for i := range sounds {
+		sounds[i] = &models.SoundboardSound{ID: i + 1, Name: "sound" + strconv.Itoa(i+1), Status: int(TranscodingStatusReady)}
+	}
+
+	return sounds
+}
+
+func TestPanelComponents(t *testing.T) {
+	sounds := testSounds(7)
+	sounds[2].Status = int(TranscodingStatusQueued)
+
+	// 6 ready sounds make 2 rows of buttons, then the controls
+	rows := panelComponents(sounds)
+	if len(rows) != 3 {
+		t.Fatalf("got %d rows, expected 3", len(rows))
+	}
+
+	first := rows[0].(discordgo.ActionsRow).Components
+	if len(first) != 5 || first[2].(discordgo.Button).CustomID != "soundboard_play:4" {
+		t.Errorf("unexpected first row %+v", first)
+	}
+
+	// 60 sounds make 3 select menus, then the controls
+	rows = panelComponents(testSounds(60))
+	if len(rows) != 4 {
+		t.Fatalf("got %d rows, expected 4", len(rows))
+	}
+
+	menu := rows[2].(discordgo.ActionsRow).Components[0].(discordgo.SelectMenu)
+	if len(menu.Options) != 10 || menu.Options[0].Value != "51" {
+		t.Errorf("unexpected last menu %+v", menu)
+	}
+
+	// sounds past the max are left out
+	rows = panelComponents(testSounds(150))
+	if len(rows) != 5 {
+		t.Errorf("got %d rows, expected 5", len(rows))
+	}
+
+	rows = panelComponents(nil)
+	if len(rows) != 1 {
+		t.Errorf("expected only the controls without sounds, got %d rows", len(rows))
+	}
+}
+
+func TestParsePanelCustomID(t *testing.T) {
+	action, soundID, ok := parsePanelCustomID("soundboard_play:12")
+	if !ok || action != customIDPlay || soundID != 12 {
+		t.Errorf("got %s %d %v", action, soundID, ok)
+	}
+
+	action, _, ok = parsePanelCustomID("soundboard_select:1")
+	if !ok || action != customIDSelect {
+		t.Errorf("got %s %v", action, ok)
+	}
+
+	for _, v := range []string{"", "soundboard_play", "soundboard_play:abc", "polls_vote:1:2"} {
+		if _, _, ok := parsePanelCustomID(v); ok {
+			t.Errorf("expected %q to be invalid", v)
+		}
+	}
+}
+
+func TestDescribeQueue(t *testing.T) {
+	if describeQueue(nil, nil) != "Nothing is playing." {
+		t.Error("expected nothing to be playing")
+	}
+
+	out := describeQueue(&PlayRequest{SoundName: "airhorn", RequestedBy: 1}, []*PlayRequest{{SoundName: "bruh", RequestedBy: 2}})
+	expected := "Now playing: `airhorn` (requested by <@1>)\n\nUp next:\n1. `bruh` (requested by <@2>)\n"
+	if out != expected {
+		t.Errorf("got %q, expected %q", out, expected)
+	}
+}
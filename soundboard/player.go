@@ -10,6 +10,7 @@ import (
 	"github.com/botlabs-gg/yagpdb/v2/common"
 	"github.com/botlabs-gg/yagpdb/v2/lib/dca"
 	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+	"github.com/botlabs-gg/yagpdb/v2/soundboard/models"
 )
 
 type PlayRequest struct {
@@ -17,8 +18,17 @@ type PlayRequest struct {
 	GuildID        int64
 	CommandRanFrom int64
 	Sound          int
+	SoundName      string
+	RequestedBy    int64
 }
 
+// MaxQueueLength is the max number of sounds waiting to be played in a server
+const MaxQueueLength = 10
+
+var (
+	ErrQueueFull = errors.New("queue is full")
+)
+
 var (
 	playQueues      = make(map[int64][]*PlayRequest)
 	playQueuesMutex sync.Mutex
@@ -29,16 +39,23 @@ var (
 )
 
 // RequestPlaySound either queues up a sound to be played in an existing player or creates a new one
-func RequestPlaySound(guildID int64, channelID, channelRanFrom int64, soundID int) (queued bool) {
+func RequestPlaySound(guildID int64, channelID, channelRanFrom int64, sound *models.SoundboardSound, requestedBy int64) (queued bool, err error) {
 	item := &PlayRequest{
 		ChannelID:      channelID,
 		GuildID:        guildID,
-		Sound:          soundID,
+		Sound:          sound.ID,
+		SoundName:      sound.Name,
 		CommandRanFrom: channelRanFrom,
+		RequestedBy:    requestedBy,
 	}
 
 	playersmu.L.Lock()
 	if p, ok := players[guildID]; ok {
+		if len(p.queue) >= MaxQueueLength {
+			playersmu.L.Unlock()
+			return false, ErrQueueFull
+		}
+
 		// add to existing player queue
 		p.queue = append(p.queue, item)
 		queued = true
@@ -75,6 +92,55 @@ func resetPlayerServer(guildID int64) string {
 	return "No active Player, nothing to reset."
 }
 
+// GetQueue returns the sound currently playing in the server, if any, and the ones queued up after it
+func GetQueue(guildID int64) (current *PlayRequest, queue []*PlayRequest) {
+	playersmu.L.Lock()
+	defer playersmu.L.Unlock()
+
+	p, ok := players[guildID]
+	if !ok {
+		return nil, nil
+	}
+
+	if p.playing {
+		current = p.current
+	}
+
+	return current, append([]*PlayRequest{}, p.queue...)
+}
+
+// skipSound stops the sound currently playing, the player moves on to the next one in the queue
+func skipSound(guildID int64) (skipped *PlayRequest) {
+	playersmu.L.Lock()
+	defer playersmu.L.Unlock()
+
+	p, ok := players[guildID]
+	if !ok || !p.playing || p.skip {
+		return nil
+	}
+
+	p.skip = true
+	return p.current
+}
+
+// stopSounds clears the queue and stops the sound currently playing, unlike resetPlayerServer it stays connected
+func stopSounds(guildID int64) bool {
+	playersmu.L.Lock()
+	defer playersmu.L.Unlock()
+
+	p, ok := players[guildID]
+	if !ok || (!p.playing && len(p.queue) < 1) {
+		return false
+	}
+
+	p.queue = nil
+	if p.playing {
+		p.skip = true
+	}
+
+	return true
+}
+
 // Player represends a voice connection playing a soundbaord file (or waiting for one)
 type Player struct {
 	GuildID int64
@@ -82,9 +148,12 @@ type Player struct {
 	// below fields are safe to access with playersmu
 	ChannelID    int64
 	queue        []*PlayRequest
+	current      *PlayRequest
 	timeLastPlay time.Time
 	playing      bool
 	stop         bool
+	// set to stop the current sound without stopping the player
+	skip bool
 
 	// below fields are only safe to deal with in the main run goroutine
 	vc *discordgo.VoiceConnection
@@ -111,6 +180,8 @@ func (p *Player) Run() {
 			p.ChannelID = item.ChannelID
 		}
 
+		p.current = item
+		p.skip = false
 		p.playing = true
 		p.timeLastPlay = time.Now()
 		playersmu.L.Unlock()
@@ -215,8 +286,13 @@ func playSound(p *Player, vc *discordgo.VoiceConnection, session *discordgo.Sess
 			playersmu.L.Unlock()
 			return vc, nil
 		}
+		skip := p.skip
 		playersmu.L.Unlock()
 
+		if skip {
+			break
+		}
+
 		frame, err := decoder.OpusFrame()
 		if err != nil {
 			if err != io.EOF {
@@ -49,4 +49,8 @@ BEGIN
 		UPDATE soundboard_sounds SET required_roles=ARRAY[required_role]::BIGINT[] WHERE required_role IS NOT NULL AND required_role != '';
 	END IF;
 END $$;
+`, `
+ALTER TABLE soundboard_sounds ADD COLUMN IF NOT EXISTS cooldown INT NOT NULL DEFAULT 0;
+`, `
+ALTER TABLE soundboard_sounds ADD COLUMN IF NOT EXISTS user_cooldown INT NOT NULL DEFAULT 0;
 `}
@@ -5,10 +5,12 @@ package soundboard
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/botlabs-gg/yagpdb/v2/common"
 	"github.com/botlabs-gg/yagpdb/v2/premium"
 	"github.com/botlabs-gg/yagpdb/v2/soundboard/models"
+	"github.com/mediocregopher/radix/v3"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 	"golang.org/x/net/context"
 )
@@ -86,6 +88,82 @@ func KeySoundLock(id int) string {
 	return fmt.Sprintf("soundboard_soundlock:%d", id)
 }
 
+func KeySoundCooldown(guildID int64, soundID int) string {
+	return fmt.Sprintf("soundboard_cooldown:%d:%d", guildID, soundID)
+}
+
+func KeyUserSoundCooldown(guildID, userID int64, soundID int) string {
+	return fmt.Sprintf("soundboard_user_cooldown:%d:%d:%d", guildID, userID, soundID)
+}
+
+type soundCooldown struct {
+	key     string
+	seconds int
+}
+
+func soundCooldowns(sound *models.SoundboardSound, userID int64) []soundCooldown {
+	var result []soundCooldown
+	if sound.Cooldown > 0 {
+		result = append(result, soundCooldown{KeySoundCooldown(sound.GuildID, sound.ID), sound.Cooldown})
+	}
+	if sound.UserCooldown > 0 {
+		result = append(result, soundCooldown{KeyUserSoundCooldown(sound.GuildID, userID, sound.ID), sound.UserCooldown})
+	}
+
+	return result
+}
+
+// CheckSetCooldowns returns how long is left if the sound or the user is on cooldown for the sound,
+// otherwise it puts both on cooldown and returns 0. The cooldowns are set with NX so concurrent requests can't both play the sound
+func CheckSetCooldowns(sound *models.SoundboardSound, userID int64) (time.Duration, error) {
+	cooldowns := soundCooldowns(sound, userID)
+	for i, v := range cooldowns {
+		var resp string
+		err := common.RedisPool.Do(radix.FlatCmd(&resp, "SET", v.key, 1, "EX", v.seconds, "NX"))
+		if err != nil {
+			releaseCooldowns(cooldowns[:i])
+			return 0, err
+		}
+
+		if resp == "OK" {
+			continue
+		}
+
+		// already on cooldown, the ones set before this one were not used
+		releaseCooldowns(cooldowns[:i])
+
+		var ttl int64
+		err = common.RedisPool.Do(radix.Cmd(&ttl, "PTTL", v.key))
+		if err != nil {
+			return 0, err
+		}
+
+		left := time.Duration(ttl) * time.Millisecond
+		if left < time.Second {
+			// expired in between, it's still treated as being on cooldown
+			left = time.Second
+		}
+
+		return left, nil
+	}
+
+	return 0, nil
+}
+
+// ClearCooldowns removes the cooldowns set by CheckSetCooldowns, for when the sound ended up not being played
+func ClearCooldowns(sound *models.SoundboardSound, userID int64) {
+	releaseCooldowns(soundCooldowns(sound, userID))
+}
+
+func releaseCooldowns(cooldowns []soundCooldown) {
+	for _, v := range cooldowns {
+		err := common.RedisPool.Do(radix.Cmd(nil, "DEL", v.key))
+		if err != nil {
+			logger.WithError(err).Error("failed clearing soundboard cooldown")
+		}
+	}
+}
+
 func SoundFilePath(id int, status TranscodingStatus) string {
 	if status == TranscodingStatusReady {
 		return fmt.Sprintf("soundboard/ready/%d.dca", id)
@@ -29,6 +29,8 @@ func init() {
 	cp := *dca.StdEncodeOptions
 	transcoderOptions = &cp
 	transcoderOptions.Bitrate = 100
+	// Some uploads are a lot louder than others, bring them all to the same loudness
+	transcoderOptions.Loudnorm = dca.StdLoudnormOptions
 }
 
 var _ commands.CommandProvider = (*Plugin)(nil)
@@ -31,6 +31,10 @@ type PostForm struct {
 
 	RequiredRoles    []int64 `valid:"role"`
 	BlacklistedRoles []int64 `valid:"role"`
+
+	// In seconds, how often the sound can be played and how often each member can play it
+	Cooldown     int `valid:"0,3600"`
+	UserCooldown int `valid:"0,3600"`
 }
 
 func (pf *PostForm) ToDBModel() *models.SoundboardSound {
@@ -40,6 +44,8 @@ func (pf *PostForm) ToDBModel() *models.SoundboardSound {
 		Name:             pf.Name,
 		RequiredRoles:    pf.RequiredRoles,
 		BlacklistedRoles: pf.BlacklistedRoles,
+		Cooldown:         pf.Cooldown,
+		UserCooldown:     pf.UserCooldown,
 	}
 }
 
@@ -248,8 +254,10 @@ func HandleUpdate(w http.ResponseWriter, r *http.Request) (web.TemplateData, err
 	dbModel.Name = data.Name
 	dbModel.RequiredRoles = data.RequiredRoles
 	dbModel.BlacklistedRoles = data.BlacklistedRoles
+	dbModel.Cooldown = data.Cooldown
+	dbModel.UserCooldown = data.UserCooldown
 
-	_, err = dbModel.UpdateG(ctx, boil.Whitelist("name", "required_roles", "blacklisted_roles", "updated_at"))
+	_, err = dbModel.UpdateG(ctx, boil.Whitelist("name", "required_roles", "blacklisted_roles", "cooldown", "user_cooldown", "updated_at"))
 	if err == nil {
 		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyUpdatedSound, &cplogs.Param{Type: cplogs.ParamTypeString, Value: data.Name}))
 	}
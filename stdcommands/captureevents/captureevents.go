@@ -0,0 +1,75 @@
+package captureevents
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/bot"
+	"github.com/botlabs-gg/yagpdb/v2/bot/eventcapture"
+	"github.com/botlabs-gg/yagpdb/v2/commands"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/lib/dcmd"
+	"github.com/botlabs-gg/yagpdb/v2/stdcommands/util"
+)
+
+var Command = &commands.YAGCommand{
+	CmdCategory:          commands.CategoryDebug,
+	HideFromCommandsPage: true,
+	Name:                 "captureevents",
+	Description:          "Starts or stops capturing the gateway events of a server to a file for replaying with cmd/replayevents. Bot Owner Only",
+	HideFromHelp:         true,
+	RequiredArgs:         1,
+	Arguments: []*dcmd.ArgDef{
+		{Name: "action", Type: dcmd.String, Help: "Allowed values are 'start', 'stop' and 'status'"},
+		{Name: "server", Type: dcmd.BigInt, Default: int64(0), Help: "Defaults to the current server"},
+		{Name: "duration", Type: &commands.DurationArg{}, Default: time.Minute * 10},
+	},
+	RunFunc: util.RequireOwner(func(data *dcmd.Data) (interface{}, error) {
+		switch strings.ToLower(data.Args[0].Str()) {
+		case "start":
+		case "stop":
+			r := eventcapture.Stop()
+			if r == nil {
+				return "No capture running", nil
+			}
+
+			return fmt.Sprintf("Stopped capture, %d events written to `%s`", r.Written(), r.Path), nil
+		case "status":
+			r := eventcapture.Active()
+			if r == nil {
+				return "No capture running", nil
+			}
+
+			return fmt.Sprintf("Capturing %v to `%s`, %d events written, stops in %s", r.Guilds, r.Path, r.Written(),
+				common.HumanizeDuration(common.DurationPrecisionSeconds, time.Until(r.StopsAt))), nil
+		default:
+			return "Unknown action, allowed values are 'start', 'stop' and 'status'", nil
+		}
+
+		guildID := data.Args[1].Int64()
+		if guildID == 0 {
+			guildID = data.GuildData.GS.ID
+		}
+
+		// events are only captured on the process the server is on
+		if bot.State.GetGuild(guildID) == nil {
+			return "That server isn't on this node", nil
+		}
+
+		g, err := eventcapture.SnapshotGuild(common.BotSession, guildID, common.BotUser.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		r, err := eventcapture.Start([]int64{guildID}, data.Args[2].Value.(time.Duration), &eventcapture.GuildSnapshot{
+			ShardID: bot.GuildShardID(int64(bot.ShardManager.GetNumShards()), guildID),
+			Guild:   g,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return fmt.Sprintf("Capturing events of %d to `%s` until <t:%d:T>", guildID, r.Path, r.StopsAt.Unix()), nil
+	}),
+}
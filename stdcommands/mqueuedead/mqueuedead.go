@@ -0,0 +1,74 @@
+package mqueuedead
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/commands"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/mqueue"
+	"github.com/botlabs-gg/yagpdb/v2/lib/dcmd"
+	"github.com/botlabs-gg/yagpdb/v2/stdcommands/util"
+)
+
+var Command = &commands.YAGCommand{
+	CmdCategory:          commands.CategoryDebug,
+	HideFromCommandsPage: true,
+	Name:                 "mqueuedead",
+	Description:          "Lists, requeues or purges mqueue items that failed to be sent. Use 0 as the id for all items. Bot Owner Only",
+	HideFromHelp:         true,
+	RequiredArgs:         1,
+	Arguments: []*dcmd.ArgDef{
+		{Name: "action", Type: dcmd.String, Help: "Allowed values are 'list', 'requeue' and 'purge'"},
+		{Name: "id", Type: dcmd.BigInt, Default: int64(0)},
+	},
+	RunFunc: util.RequireOwner(func(data *dcmd.Data) (interface{}, error) {
+		id := data.Args[1].Int64()
+
+		switch strings.ToLower(data.Args[0].Str()) {
+		case "requeue":
+			n, err := mqueue.RequeueDeadItems(id)
+			if err != nil {
+				return nil, err
+			}
+
+			return fmt.Sprintf("Requeued %d items", n), nil
+		case "purge":
+			n, err := mqueue.PurgeDeadItems(id)
+			if err != nil {
+				return nil, err
+			}
+
+			return fmt.Sprintf("Purged %d items", n), nil
+		case "list":
+		default:
+			return "Unknown action, allowed values are 'list', 'requeue' and 'purge'", nil
+		}
+
+		items, err := mqueue.GetDeadItems("", 10)
+		if err != nil {
+			return nil, err
+		}
+
+		total, err := mqueue.CountDeadItems()
+		if err != nil {
+			return nil, err
+		}
+
+		var out strings.Builder
+		fmt.Fprintf(&out, "%d dead items, most recent:\n```\n", total)
+		for _, v := range items {
+			lastErr := v.LastError
+			if len(lastErr) > 100 {
+				lastErr = lastErr[:100] + "..."
+			}
+
+			fmt.Fprintf(&out, "#%d %s:%s g:%d c:%d attempts:%d died %s ago\n  %s\n", v.Elem.ID, v.Elem.Source, v.Elem.SourceItemID,
+				v.Elem.GuildID, v.Elem.ChannelID, v.Attempts, common.HumanizeDuration(common.DurationPrecisionMinutes, time.Since(v.DiedAt)), lastErr)
+		}
+		out.WriteString("```")
+
+		return out.String(), nil
+	}),
+}
@@ -14,6 +14,7 @@ var (
 		CmdCategory:         commands.CategoryTool,
 		Name:                "Poll",
 		Description:         "Create very simple reaction poll. Example: `poll \"favorite color?\" blue red pink`",
+		LongDescription:     "See `polls create` for polls with vote tallying, ranked choice and closing times.",
 		RequiredArgs:        3,
 		SlashCommandEnabled: true,
 		Arguments: []*dcmd.ArgDef{
@@ -0,0 +1,138 @@
+package scheduledevents
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/commands"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/scheduledevents2"
+	"github.com/botlabs-gg/yagpdb/v2/lib/dcmd"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+)
+
+const listedEvents = 15
+
+var Command = &commands.YAGCommand{
+	CmdCategory: commands.CategoryTool,
+	Name:        "ScheduledEvents",
+	Aliases:     []string{"schedevents"},
+	Description: "Lists the upcoming scheduled events on this server such as unbans, unmutes and delayed custom command runs, or cancels and reschedules them.",
+	LongDescription: "Examples:\n`scheduledevents` lists the upcoming events\n`scheduledevents list cc_delayed_run` only lists delayed custom command runs\n" +
+		"`scheduledevents cancel 123` cancels the event with the id 123\n`scheduledevents cancelall cc_delayed_run` cancels all delayed custom command runs\n" +
+		"`scheduledevents reschedule 123 2h` makes the event with the id 123 trigger in 2 hours",
+	RequireDiscordPerms:      []int64{discordgo.PermissionManageGuild},
+	RequiredDiscordPermsHelp: "ManageServer",
+	Arguments: []*dcmd.ArgDef{
+		{Name: "Action", Type: dcmd.String, Default: "list", Help: "list, cancel, cancelall or reschedule"},
+		{Name: "Target", Type: dcmd.String, Default: "", Help: "The id of the event, or the event type for list and cancelall"},
+		{Name: "In", Type: &commands.DurationArg{}, Help: "When the rescheduled event should trigger"},
+	},
+	RunFunc: func(data *dcmd.Data) (interface{}, error) {
+		guildID := data.GuildData.GS.ID
+		target := data.Args[1].Str()
+
+		switch strings.ToLower(data.Args[0].Str()) {
+		case "list":
+			return listEvents(data.Context(), guildID, target)
+		case "cancel":
+			id, err := strconv.ParseInt(target, 10, 64)
+			if err != nil {
+				return "Provide the id of the event to cancel", nil
+			}
+
+			err = scheduledevents2.CancelEvent(data.Context(), guildID, id)
+			if err == scheduledevents2.ErrEventNotFound {
+				return "No upcoming event with that id", nil
+			} else if err != nil {
+				return nil, err
+			}
+
+			return fmt.Sprintf("Cancelled event #%d", id), nil
+		case "cancelall":
+			if target == "" {
+				return "Provide the type of events to cancel, for example `cc_delayed_run`", nil
+			}
+
+			n, err := scheduledevents2.CancelEventsByName(data.Context(), guildID, target)
+			if err != nil {
+				return nil, err
+			}
+
+			return fmt.Sprintf("Cancelled %d `%s` events", n, target), nil
+		case "reschedule":
+			id, err := strconv.ParseInt(target, 10, 64)
+			if err != nil {
+				return "Provide the id of the event to reschedule", nil
+			}
+
+			in := data.Args[2].Value
+			if in == nil {
+				return "Provide when the event should trigger, for example `2h`", nil
+			}
+
+			evt, err := scheduledevents2.RescheduleEvent(data.Context(), guildID, id, time.Now().Add(in.(time.Duration)))
+			if err == scheduledevents2.ErrEventNotFound {
+				return "No upcoming event with that id", nil
+			} else if err != nil {
+				return nil, err
+			}
+
+			return fmt.Sprintf("Event #%d now triggers in %s", id, common.HumanizeDuration(common.DurationPrecisionSeconds, time.Until(evt.TriggersAt))), nil
+		}
+
+		return "Unknown action, use list, cancel, cancelall or reschedule", nil
+	},
+}
+
+func listEvents(ctx context.Context, guildID int64, eventName string) (interface{}, error) {
+	events, err := scheduledevents2.GetUpcomingEvents(ctx, guildID, eventName, listedEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(events) < 1 {
+		return "No upcoming scheduled events", nil
+	}
+
+	counts, err := scheduledevents2.CountUpcomingEvents(ctx, guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	var out strings.Builder
+	out.WriteString("```\n")
+	now := time.Now()
+	for _, v := range events {
+		until := "now"
+		if v.TriggersAt.After(now) {
+			until = "in " + common.HumanizeDuration(common.DurationPrecisionSeconds, v.TriggersAt.Sub(now))
+		}
+
+		fmt.Fprintf(&out, "#%d %s: %s\n", v.ID, until, common.CutStringShort(scheduledevents2.DescribeEvent(v), 150))
+	}
+	out.WriteString("```")
+
+	names := make([]string, 0, len(counts))
+	for k := range counts {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	totals := make([]string, 0, len(names))
+	for _, v := range names {
+		totals = append(totals, fmt.Sprintf("%s: %d", v, counts[v]))
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       "Upcoming scheduled events",
+		Description: out.String(),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: common.CutStringShort(strings.Join(totals, ", "), 2000),
+		},
+	}, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/botlabs-gg/yagpdb/v2/stdcommands/allocstat"
 	"github.com/botlabs-gg/yagpdb/v2/stdcommands/banserver"
 	"github.com/botlabs-gg/yagpdb/v2/stdcommands/calc"
+	"github.com/botlabs-gg/yagpdb/v2/stdcommands/captureevents"
 	"github.com/botlabs-gg/yagpdb/v2/stdcommands/catfact"
 	"github.com/botlabs-gg/yagpdb/v2/stdcommands/ccreqs"
 	"github.com/botlabs-gg/yagpdb/v2/stdcommands/cleardm"
@@ -34,10 +35,12 @@ import (
 	"github.com/botlabs-gg/yagpdb/v2/stdcommands/listflags"
 	"github.com/botlabs-gg/yagpdb/v2/stdcommands/listroles"
 	"github.com/botlabs-gg/yagpdb/v2/stdcommands/memstats"
+	"github.com/botlabs-gg/yagpdb/v2/stdcommands/mqueuedead"
 	"github.com/botlabs-gg/yagpdb/v2/stdcommands/ping"
 	"github.com/botlabs-gg/yagpdb/v2/stdcommands/poll"
 	"github.com/botlabs-gg/yagpdb/v2/stdcommands/roast"
 	"github.com/botlabs-gg/yagpdb/v2/stdcommands/roll"
+	"github.com/botlabs-gg/yagpdb/v2/stdcommands/scheduledevents"
 	"github.com/botlabs-gg/yagpdb/v2/stdcommands/setstatus"
 	"github.com/botlabs-gg/yagpdb/v2/stdcommands/simpleembed"
 	"github.com/botlabs-gg/yagpdb/v2/stdcommands/sleep"
@@ -101,6 +104,7 @@ func (p *Plugin) AddCommands() {
 		poll.Command,
 		undelete.Command,
 		viewperms.Command,
+		scheduledevents.Command,
 		topgames.Command,
 		xkcd.Command,
 		howlongtobeat.Command,
@@ -131,6 +135,8 @@ func (p *Plugin) AddCommands() {
 		toggledbg.Command,
 		globalrl.Command,
 		listflags.Command,
+		mqueuedead.Command,
+		captureevents.Command,
 	)
 
 	statedbg.Commands()
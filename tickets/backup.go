@@ -0,0 +1,74 @@
+package tickets
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/botlabs-gg/yagpdb/v2/commands"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/tickets/models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+var _ common.PluginWithBackup = (*Plugin)(nil)
+
+func (p *Plugin) BackupVersion() int {
+	return 1
+}
+
+func (p *Plugin) ExportBackup(ctx context.Context, guildID int64) (interface{}, error) {
+	conf, err := models.FindTicketConfigG(ctx, guildID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return conf, err
+}
+
+func (p *Plugin) RestoreBackup(ctx context.Context, guildID int64, data json.RawMessage, restore *common.BackupRestore) error {
+	var conf *models.TicketConfig
+	err := json.Unmarshal(data, &conf)
+	if err != nil {
+		return err
+	}
+
+	tx, err := common.PQ.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = models.TicketConfigs(qm.Where("guild_id = ?", guildID)).DeleteAll(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if conf != nil {
+		conf.GuildID = guildID
+		conf.TicketsChannelCategory = restore.Channel(conf.TicketsChannelCategory)
+		conf.StatusChannel = restore.Channel(conf.StatusChannel)
+		conf.TicketsTranscriptsChannel = restore.Channel(conf.TicketsTranscriptsChannel)
+		conf.TicketsTranscriptsChannelAdminOnly = restore.Channel(conf.TicketsTranscriptsChannelAdminOnly)
+		conf.ModRoles = restore.Roles(conf.ModRoles)
+		conf.AdminRoles = restore.Roles(conf.AdminRoles)
+
+		if conf.Enabled && conf.TicketsChannelCategory == 0 {
+			restore.Note("The ticket category was not found, tickets will be created without one")
+		}
+
+		err = conf.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	err = restore.Finish(tx)
+	if err == nil && !restore.DryRun {
+		commands.PubsubSendUpdateSlashCommandsPermissions(guildID)
+	}
+
+	return err
+}
@@ -51,6 +51,8 @@ func (p *Plugin) InitWeb() {
 	web.CPMux.Handle(pat.Get("/tickets/settings/"), getHandler)
 
 	web.CPMux.Handle(pat.Post("/tickets/settings"), postHandler)
+
+	cplogs.RegisterConfigSource(cplogs.BackupConfigSource(p, "tickets", "Ticket system", "tickets/settings"))
 }
 
 func (p *Plugin) handleGetSettings(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
@@ -0,0 +1,408 @@
+package timezonecompanion
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/botlabs-gg/yagpdb/v2/bot"
+	"github.com/botlabs-gg/yagpdb/v2/commands"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/lib/dcmd"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+	"github.com/botlabs-gg/yagpdb/v2/lib/dstate"
+	"github.com/botlabs-gg/yagpdb/v2/timezonecompanion/models"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const (
+	// Max members mentioned per timezone in the team times embed
+	maxMembersPerZone = 15
+
+	// Discord rejects embeds with more characters than this in total, some room is kept for the notes added at the end
+	maxEmbedLength  = 6000
+	embedLengthNote = 100
+
+	// Windows the meeting calculator looks at, starting from the next half hour
+	meetingWindowStep  = 30 * time.Minute
+	meetingWindowRange = 24 * time.Hour
+)
+
+// ZoneGroup is a timezone and the members in it
+type ZoneGroup struct {
+	Location *time.Location
+	Members  []int64
+}
+
+// GroupByZone groups the users by their timezone, sorted by their offset at the given time, then by name
+func GroupByZone(timezones map[int64]*time.Location, at time.Time) []*ZoneGroup {
+	byName := make(map[string]*ZoneGroup)
+	for userID, loc := range timezones {
+		g, ok := byName[loc.String()]
+		if !ok {
+			g = &ZoneGroup{Location: loc}
+			byName[loc.String()] = g
+		}
+
+		g.Members = append(g.Members, userID)
+	}
+
+	result := make([]*ZoneGroup, 0, len(byName))
+	for _, g := range byName {
+		sort.Slice(g.Members, func(i, j int) bool { return g.Members[i] < g.Members[j] })
+		result = append(result, g)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		_, oi := at.In(result[i].Location).Zone()
+		_, oj := at.In(result[j].Location).Zone()
+		if oi != oj {
+			return oi < oj
+		}
+
+		return result[i].Location.String() < result[j].Location.String()
+	})
+
+	return result
+}
+
+// MeetingWindow is a candidate meeting time, and the groups that have it within their working hours
+type MeetingWindow struct {
+	Start     time.Time
+	Available []*ZoneGroup
+	Members   int
+}
+
+// BestMeetingWindow looks at every half hour in the next day and returns the window of the given length that's within the
+// working hours (workStart to workEnd, local hours) of the most members. Ties go to the earliest window.
+func BestMeetingWindow(groups []*ZoneGroup, from time.Time, length time.Duration, workStart, workEnd int) *MeetingWindow {
+	start := from.Truncate(meetingWindowStep)
+	if start.Before(from) {
+		start = start.Add(meetingWindowStep)
+	}
+
+	var best *MeetingWindow
+	for t := start; t.Before(start.Add(meetingWindowRange)); t = t.Add(meetingWindowStep) {
+		window := &MeetingWindow{Start: t}
+		for _, g := range groups {
+			if withinWorkingHours(t.In(g.Location), length, workStart, workEnd) {
+				window.Available = append(window.Available, g)
+				window.Members += len(g.Members)
+			}
+		}
+
+		if best == nil || window.Members > best.Members {
+			best = window
+		}
+	}
+
+	return best
+}
+
+func withinWorkingHours(localStart time.Time, length time.Duration, workStart, workEnd int) bool {
+	startMinute := localStart.Hour()*60 + localStart.Minute()
+	endMinute := startMinute + int(length/time.Minute)
+	return startMinute >= workStart*60 && endMinute <= workEnd*60
+}
+
+// GetUserTimezones returns the registered timezones of the users, users without one are left out
+func GetUserTimezones(ctx context.Context, userIDs []int64) (map[int64]*time.Location, error) {
+	result := make(map[int64]*time.Location)
+	if len(userIDs) < 1 {
+		return result, nil
+	}
+
+	args := make([]interface{}, len(userIDs))
+	for i, v := range userIDs {
+		args[i] = v
+	}
+
+	rows, err := models.UserTimezones(qm.WhereIn("user_id in ?", args...)).AllG(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range rows {
+		loc, err := time.LoadLocation(v.TimezoneName)
+		if err != nil {
+			continue
+		}
+
+		result[v.UserID] = loc
+	}
+
+	return result, nil
+}
+
+// membersWithRole returns the ids of the members in the state that have the role
+func membersWithRole(guildID, roleID int64) []int64 {
+	var result []int64
+	bot.State.IterateMembers(guildID, func(chunk []*dstate.MemberState) bool {
+		for _, ms := range chunk {
+			if ms.Member != nil && !ms.User.Bot && common.ContainsInt64Slice(ms.Member.Roles, roleID) {
+				result = append(result, ms.User.ID)
+			}
+		}
+
+		return true
+	})
+
+	return result
+}
+
+// FormatOffset formats a zone offset in seconds like "+2" or "+5:30"
+func FormatOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+
+	result := sign + strconv.Itoa(offset/3600)
+	if minutes := offset % 3600 / 60; minutes != 0 {
+		result += fmt.Sprintf(":%02d", minutes)
+	}
+
+	return result
+}
+
+// describeLocalTime returns something like "Mon 15:04 (CEST, UTC+2)"
+func describeLocalTime(t time.Time) string {
+	name, offset := t.Zone()
+	if strings.HasPrefix(name, "+") || strings.HasPrefix(name, "-") {
+		return fmt.Sprintf("%s (UTC%s)", t.Format("Mon 15:04"), FormatOffset(offset))
+	}
+
+	return fmt.Sprintf("%s (%s, UTC%s)", t.Format("Mon 15:04"), name, FormatOffset(offset))
+}
+
+// mentionMembers mentions up to max of the members within maxLen characters, the ones left out are counted at the end
+func mentionMembers(members []int64, max int, maxLen int) string {
+	// room for the count of the ones left out
+	countLen := len(" +") + len(strconv.Itoa(len(members)))
+
+	var b strings.Builder
+	for i, v := range members {
+		mention := "<@" + strconv.FormatInt(v, 10) + ">"
+		if i >= max || b.Len()+len(mention)+1+countLen > maxLen {
+			if b.Len() > 0 {
+				b.WriteString(" ")
+			}
+			b.WriteString("+" + strconv.Itoa(len(members)-i))
+			break
+		}
+
+		if b.Len() > 0 {
+			b.WriteString(" ")
+		}
+		b.WriteString(mention)
+	}
+
+	return b.String()
+}
+
+// embedLength returns the number of characters in the embed that count towards discords limit
+func embedLength(e *discordgo.MessageEmbed) int {
+	n := utf8.RuneCountInString(e.Title) + utf8.RuneCountInString(e.Description)
+	if e.Footer != nil {
+		n += utf8.RuneCountInString(e.Footer.Text)
+	}
+
+	for _, v := range e.Fields {
+		n += utf8.RuneCountInString(v.Name) + utf8.RuneCountInString(v.Value)
+	}
+
+	return n
+}
+
+// zoneFieldFits returns the max length of the value of a field with the name, or false if it doesn't fit in the embed
+func zoneFieldFits(e *discordgo.MessageEmbed, name string) (int, bool) {
+	remaining := maxEmbedLength - embedLengthNote - embedLength(e) - utf8.RuneCountInString(name)
+	if remaining > 1024 {
+		remaining = 1024
+	}
+
+	// there should be room for at least one mention
+	return remaining, remaining >= 32
+}
+
+var cmdTime = &commands.YAGCommand{
+	CmdCategory:         commands.CategoryTool,
+	Name:                "Time",
+	Aliases:             []string{"timefor", "usertime"},
+	Description:         "Shows the current local time of a member, based on the timezone they set with settimezone",
+	RequiredArgs:        1,
+	SlashCommandEnabled: true,
+	DefaultEnabled:      true,
+	Arguments: []*dcmd.ArgDef{
+		{Name: "User", Type: dcmd.User},
+	},
+	RunFunc: func(parsed *dcmd.Data) (interface{}, error) {
+		user := parsed.Args[0].Value.(*discordgo.User)
+
+		loc := GetUserTimezone(user.ID)
+		if loc == nil {
+			return fmt.Sprintf("%s hasn't set their timezone, they can do so with the `settimezone` command.", user.Username), nil
+		}
+
+		return fmt.Sprintf("It's **%s** for %s (`%s`)", describeLocalTime(time.Now().In(loc)), user.Username, loc), nil
+	},
+}
+
+var cmdTeamTimes = &commands.YAGCommand{
+	CmdCategory:         commands.CategoryTool,
+	Name:                "TeamTimes",
+	Aliases:             []string{"teamtime", "roletimes"},
+	Description:         "Lists the current local time of the members with the role, grouped by timezone",
+	RequiredArgs:        1,
+	SlashCommandEnabled: true,
+	DefaultEnabled:      true,
+	Arguments: []*dcmd.ArgDef{
+		{Name: "Role", Type: &commands.RoleArg{}},
+	},
+	RunFunc: func(parsed *dcmd.Data) (interface{}, error) {
+		role := parsed.Args[0].Value.(*discordgo.Role)
+
+		members := membersWithRole(parsed.GuildData.GS.ID, role.ID)
+		timezones, err := GetUserTimezones(parsed.Context(), members)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(timezones) < 1 {
+			return fmt.Sprintf("None of the %d members with the role have set their timezone with the `settimezone` command.", len(members)), nil
+		}
+
+		now := time.Now()
+		groups := GroupByZone(timezones, now)
+
+		embed := &discordgo.MessageEmbed{
+			Title:       "Local times",
+			Description: fmt.Sprintf("Members with the <@&%d> role, in %d timezones", role.ID, len(groups)),
+			Color:       role.Color,
+		}
+
+		if missing := len(members) - len(timezones); missing > 0 {
+			embed.Footer = &discordgo.MessageEmbedFooter{
+				Text: fmt.Sprintf("%d members without a timezone, set yours with settimezone", missing),
+			}
+		}
+
+		for i, g := range groups {
+			name := fmt.Sprintf("%s: %s", g.Location, describeLocalTime(now.In(g.Location)))
+			maxLen, fits := zoneFieldFits(embed, name)
+			if i >= 25 || !fits {
+				embed.Description += fmt.Sprintf("\n%d timezones left out", len(groups)-i)
+				break
+			}
+
+			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+				Name:  name,
+				Value: mentionMembers(g.Members, maxMembersPerZone, maxLen),
+			})
+		}
+
+		return embed, nil
+	},
+}
+
+var cmdMeetingTime = &commands.YAGCommand{
+	CmdCategory:         commands.CategoryTool,
+	Name:                "MeetingTime",
+	Aliases:             []string{"meetingwindow", "bestmeeting"},
+	Description:         "Finds the time in the next 24 hours that's within the working hours of the most members with the role",
+	LongDescription:     "Working hours are in the local time of each member, 9 to 17 unless given with the `start` and `end` switches.",
+	RequiredArgs:        1,
+	SlashCommandEnabled: true,
+	DefaultEnabled:      true,
+	Arguments: []*dcmd.ArgDef{
+		{Name: "Role", Type: &commands.RoleArg{}},
+	},
+	ArgSwitches: []*dcmd.ArgDef{
+		{Name: "length", Help: "Length of the meeting", Type: &commands.DurationArg{Min: 15 * time.Minute, Max: 12 * time.Hour}},
+		{Name: "start", Help: "Start of working hours", Type: &dcmd.IntArg{Min: 0, Max: 23}, Default: 9},
+		{Name: "end", Help: "End of working hours", Type: &dcmd.IntArg{Min: 1, Max: 24}, Default: 17},
+	},
+	RunFunc: func(parsed *dcmd.Data) (interface{}, error) {
+		role := parsed.Args[0].Value.(*discordgo.Role)
+
+		length := time.Hour
+		if parsed.Switch("length").Value != nil {
+			length = parsed.Switch("length").Value.(time.Duration)
+		}
+
+		workStart := parsed.Switch("start").Int()
+		workEnd := parsed.Switch("end").Int()
+		if workEnd <= workStart || time.Duration(workEnd-workStart)*time.Hour < length {
+			return "The meeting doesn't fit within the working hours.", nil
+		}
+
+		members := membersWithRole(parsed.GuildData.GS.ID, role.ID)
+		timezones, err := GetUserTimezones(parsed.Context(), members)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(timezones) < 1 {
+			return fmt.Sprintf("None of the %d members with the role have set their timezone with the `settimezone` command.", len(members)), nil
+		}
+
+		groups := GroupByZone(timezones, time.Now())
+		window := BestMeetingWindow(groups, time.Now(), length, workStart, workEnd)
+		if window.Members < 1 {
+			return fmt.Sprintf("There's no time in the next 24 hours that's within %d:00-%d:00 for any of the members.", workStart, workEnd), nil
+		}
+
+		end := window.Start.Add(length)
+		embed := &discordgo.MessageEmbed{
+			Title: "Best meeting time",
+			Description: fmt.Sprintf("<t:%d:F> - <t:%d:t>\n%d of %d members with the <@&%d> role and a timezone are within their working hours (%d:00-%d:00).",
+				window.Start.Unix(), end.Unix(), window.Members, len(timezones), role.ID, workStart, workEnd),
+			Color: role.Color,
+		}
+
+		var unavailable []string
+		for _, g := range groups {
+			available := false
+			for _, v := range window.Available {
+				if v == g {
+					available = true
+					break
+				}
+			}
+
+			if !available {
+				unavailable = append(unavailable, fmt.Sprintf("`%s` %s", g.Location, window.Start.In(g.Location).Format("15:04")))
+				continue
+			}
+
+			name := fmt.Sprintf("%s: %s - %s", g.Location, window.Start.In(g.Location).Format("15:04"), end.In(g.Location).Format("15:04"))
+			if maxLen, fits := zoneFieldFits(embed, name); fits && len(embed.Fields) < 24 {
+				embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+					Name:  name,
+					Value: mentionMembers(g.Members, maxMembersPerZone, maxLen),
+				})
+			}
+		}
+
+		if len(unavailable) > 0 {
+			maxLen := maxEmbedLength - embedLength(embed) - len("Outside working hours")
+			if maxLen > 1024 {
+				maxLen = 1024
+			}
+
+			if maxLen >= 32 {
+				embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+					Name:  "Outside working hours",
+					Value: common.CutStringShort(strings.Join(unavailable, "\n"), maxLen),
+				})
+			}
+		}
+
+		return embed, nil
+	},
+}
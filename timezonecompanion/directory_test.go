@@ -0,0 +1,148 @@
+package timezonecompanion
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return loc
+}
+
+func TestGroupByZone(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+	newYork := mustLoadLocation(t, "America/New_York")
+	kolkata := mustLoadLocation(t, "Asia/Kolkata")
+
+	groups := GroupByZone(map[int64]*time.Location{
+		3: berlin,
+		1: kolkata,
+		2: newYork,
+		4: berlin,
+	}, time.Date(2016, time.January, 6, 12, 0, 0, 0, time.UTC))
+
+	var names []string
+	for _, g := range groups {
+		names = append(names, g.Location.String())
+	}
+
+	if !reflect.DeepEqual(names, []string{"America/New_York", "Europe/Berlin", "Asia/Kolkata"}) {
+		t.Fatalf("unexpected order %v", names)
+	}
+
+	if !reflect.DeepEqual(groups[1].Members, []int64{3, 4}) {
+		t.Errorf("got members %v, expected [3 4]", groups[1].Members)
+	}
+}
+
+func TestBestMeetingWindow(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+	newYork := mustLoadLocation(t, "America/New_York")
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+
+	groups := GroupByZone(map[int64]*time.Location{
+		1: berlin,
+		2: berlin,
+		3: newYork,
+		4: tokyo,
+	}, time.Now())
+
+	// 00:10 UTC, berlin is UTC+1 and new york UTC-5 in january
+	from := time.Date(2016, time.January, 6, 0, 10, 0, 0, time.UTC)
+	window := BestMeetingWindow(groups, from, time.Hour, 9, 17)
+
+	// berlin and new york overlap from 14:00 to 16:00 UTC, the earliest one hour window is at 14:00
+	expected := time.Date(2016, time.January, 6, 14, 0, 0, 0, time.UTC)
+	if !window.Start.Equal(expected) || window.Members != 3 || len(window.Available) != 2 {
+		t.Errorf("got %s with %d members, expected %s with 3", window.Start, window.Members, expected)
+	}
+
+	// a window starting at the next half hour
+	window = BestMeetingWindow([]*ZoneGroup{{Location: time.UTC, Members: []int64{1}}}, from, 30*time.Minute, 0, 1)
+	if !window.Start.Equal(time.Date(2016, time.January, 6, 0, 30, 0, 0, time.UTC)) {
+		t.Errorf("got %s, expected the window to start at 00:30", window.Start)
+	}
+
+	window = BestMeetingWindow(groups, from, 9*time.Hour, 9, 17)
+	if window.Members != 0 {
+		t.Errorf("expected no one to be available for a meeting longer than the working hours, got %d", window.Members)
+	}
+}
+
+func TestFormatOffset(t *testing.T) {
+	for offset, expected := range map[int]string{
+		0:                 "+0",
+		2 * 3600:          "+2",
+		-5 * 3600:         "-5",
+		5*3600 + 30*60:    "+5:30",
+		-(3*3600 + 30*60): "-3:30",
+		12*3600 + 45*60:   "+12:45",
+	} {
+		if got := FormatOffset(offset); got != expected {
+			t.Errorf("FormatOffset(%d) = %q, expected %q", offset, got, expected)
+		}
+	}
+}
+
+func TestMentionMembers(t *testing.T) {
+	var members []int64
+	for i := 0; i < 20; i++ {
+		members = append(members, 100000000000000000+int64(i))
+	}
+
+	got := mentionMembers(members[:3], maxMembersPerZone, 1024)
+	if got != "<@100000000000000000> <@100000000000000001> <@100000000000000002>" {
+		t.Errorf("unexpected mentions: %q", got)
+	}
+
+	got = mentionMembers(members, maxMembersPerZone, 1024)
+	if strings.Count(got, "<@") != maxMembersPerZone || !strings.HasSuffix(got, " +5") {
+		t.Errorf("expected %d mentions and +5, got %q", maxMembersPerZone, got)
+	}
+
+	got = mentionMembers(members, maxMembersPerZone, 100)
+	if len(got) > 100 || strings.Count(got, "<@") != 4 || !strings.HasSuffix(got, " +16") {
+		t.Errorf("expected 4 mentions and +16 within 100 characters, got %q", got)
+	}
+}
+
+func TestZoneFieldsFitEmbed(t *testing.T) {
+	var members []int64
+	for i := 0; i < 20; i++ {
+		members = append(members, 100000000000000000+int64(i))
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "Local times",
+		Description: "Members with the <@&100000000000000000> role, in 25 timezones",
+		Footer:      &discordgo.MessageEmbedFooter{Text: "10 members without a timezone, set yours with settimezone"},
+	}
+
+	for i := 0; i < 25; i++ {
+		name := fmt.Sprintf("America/Argentina/ComodRivadavia: Mon 15:04 (-03, UTC-3) %d", i)
+		maxLen, fits := zoneFieldFits(embed, name)
+		if !fits {
+			break
+		}
+
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: name, Value: mentionMembers(members, maxMembersPerZone, maxLen)})
+	}
+
+	if len(embed.Fields) == 0 || len(embed.Fields) == 25 {
+		t.Errorf("expected some but not all fields to fit, got %d", len(embed.Fields))
+	}
+
+	if n := embedLength(embed); n > maxEmbedLength-embedLengthNote {
+		t.Errorf("embed is %d characters long", n)
+	}
+}
@@ -52,12 +52,7 @@ func (p *Plugin) AddCommands() {
 				tzState = "registered to"
 			}
 
-			humanizeOffset := fmt.Sprintf("%+d", userOffset/3600)
-			if (userOffset % 3600 / 60) != 0 {
-				humanizeOffset += fmt.Sprintf(":%d", int(math.Abs(float64(userOffset%3600/60))))
-			}
-
-			userTZ := fmt.Sprintf("Your current time zone is %s: `%s` %s (UTC%s)", tzState, localTZ, userZone, humanizeOffset)
+			userTZ := fmt.Sprintf("Your current time zone is %s: `%s` %s (UTC%s)", tzState, localTZ, userZone, FormatOffset(userOffset))
 
 			if parsed.Switches["u"].Value != nil && parsed.Switches["u"].Value.(bool) {
 				return userTZ, nil
@@ -230,7 +225,7 @@ func (p *Plugin) AddCommands() {
 
 			return resp, nil
 		},
-	})
+	}, cmdTime, cmdTeamTimes, cmdMeetingTime)
 }
 
 func StrZone(zone string) string {
@@ -0,0 +1,56 @@
+package timezonecompanion
+
+import (
+	"errors"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/common/templates"
+)
+
+func init() {
+	templates.RegisterSetupFunc(func(ctx *templates.Context) {
+		ctx.ContextFuncs["userTimezone"] = tmplUserTimezone(ctx)
+		ctx.ContextFuncs["userTime"] = tmplUserTime(ctx)
+	})
+}
+
+// userTimezone returns the name of the timezone the user set with settimezone, e.g. "Europe/Berlin", or an empty string if they haven't.
+func tmplUserTimezone(ctx *templates.Context) interface{} {
+	return func(target interface{}) (string, error) {
+		loc, err := tmplLookupTimezone(ctx, target)
+		if err != nil || loc == nil {
+			return "", err
+		}
+
+		return loc.String(), nil
+	}
+}
+
+// userTime returns the current time in the timezone of the user, UTC if they haven't set one.
+func tmplUserTime(ctx *templates.Context) interface{} {
+	return func(target interface{}) (time.Time, error) {
+		loc, err := tmplLookupTimezone(ctx, target)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if loc == nil {
+			loc = time.UTC
+		}
+
+		return time.Now().In(loc), nil
+	}
+}
+
+func tmplLookupTimezone(ctx *templates.Context, target interface{}) (*time.Location, error) {
+	if ctx.IncreaseCheckCallCounterPremium("user_timezone", 10, 25) {
+		return nil, templates.ErrTooManyCalls
+	}
+
+	targetID := templates.TargetUserID(target)
+	if targetID == 0 {
+		return nil, errors.New("invalid user")
+	}
+
+	return GetUserTimezone(targetID), nil
+}
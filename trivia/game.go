@@ -0,0 +1,113 @@
+package trivia
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultRoundDuration = time.Second * 30
+	MinRoundSeconds      = 10
+	MaxRoundSeconds      = 120
+
+	// Time between the end of a round and the start of the next one
+	RoundPause = time.Second * 5
+
+	MaxRounds = 10
+)
+
+var Difficulties = []string{"easy", "medium", "hard"}
+
+// ValidDifficulty returns true if d is empty or one of the known difficulties
+func ValidDifficulty(d string) bool {
+	if d == "" {
+		return true
+	}
+
+	for _, v := range Difficulties {
+		if v == d {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PointsForDifficulty returns the points given for a correct answer, harder questions are worth more
+func PointsForDifficulty(difficulty string) int64 {
+	switch strings.ToLower(difficulty) {
+	case "medium":
+		return 2
+	case "hard":
+		return 3
+	default:
+		return 1
+	}
+}
+
+// PlayerScore is the result of a player in a single game
+type PlayerScore struct {
+	UserID   int64
+	Points   int64
+	Correct  int
+	Answered int
+}
+
+// gameScores keeps track of the scores of everyone that answered during a game
+type gameScores map[int64]*PlayerScore
+
+// addAnswer records the answer of a user to a question
+func (g gameScores) addAnswer(userID int64, q *TriviaQuestion, option int) {
+	score, ok := g[userID]
+	if !ok {
+		score = &PlayerScore{UserID: userID}
+		g[userID] = score
+	}
+
+	score.Answered++
+	if option >= 0 && option < len(q.Options) && q.Options[option] == q.Answer {
+		score.Correct++
+		score.Points += PointsForDifficulty(q.Difficulty)
+	}
+}
+
+// Ranked returns the scores ordered by points, then correct answers
+func (g gameScores) Ranked() []*PlayerScore {
+	result := make([]*PlayerScore, 0, len(g))
+	for _, v := range g {
+		result = append(result, v)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Points != b.Points {
+			return a.Points > b.Points
+		}
+
+		if a.Correct != b.Correct {
+			return a.Correct > b.Correct
+		}
+
+		return a.UserID < b.UserID
+	})
+
+	return result
+}
+
+// NextScheduleRun returns the first run of a repeating schedule after now, or the zero time if it doesn't repeat
+func NextScheduleRun(last time.Time, intervalHours int, now time.Time) time.Time {
+	if intervalHours < 1 {
+		return time.Time{}
+	}
+
+	interval := time.Duration(intervalHours) * time.Hour
+	next := last.Add(interval)
+	if next.After(now) {
+		return next
+	}
+
+	// skip the runs that were missed
+	missed := now.Sub(next)/interval + 1
+	return next.Add(missed * interval)
+}
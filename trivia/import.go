@@ -0,0 +1,182 @@
+package trivia
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"emperror.dev/errors"
+)
+
+const (
+	MaxGuildQuestions = 1000
+
+	MaxQuestionLength = 300
+	// The options are used as button ids, which can't be longer than 100 characters
+	MaxAnswerLength   = 80
+	MaxCategoryLength = 50
+	MaxIncorrect      = 3
+)
+
+type importedQuestion struct {
+	Question         string   `json:"question"`
+	Answer           string   `json:"answer"`
+	CorrectAnswer    string   `json:"correct_answer"`
+	IncorrectAnswers []string `json:"incorrect_answers"`
+	Category         string   `json:"category"`
+	Difficulty       string   `json:"difficulty"`
+}
+
+// ParseImport parses questions in the JSON or CSV import format.
+//
+// JSON is a list of objects with the question, answer, incorrect_answers, category and difficulty fields,
+// or an object with the list in the results field like opentdb responses.
+//
+// CSV has one question per row with the columns question, answer, category, difficulty and then 1 to 3 incorrect answers,
+// a header row starting with "question" is skipped.
+func ParseImport(data []byte) ([]*BankQuestion, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) < 1 {
+		return nil, errors.New("Nothing to import")
+	}
+
+	var imported []*importedQuestion
+	var err error
+	switch data[0] {
+	case '[':
+		err = json.Unmarshal(data, &imported)
+	case '{':
+		var wrapped struct {
+			Results []*importedQuestion `json:"results"`
+		}
+		err = json.Unmarshal(data, &wrapped)
+		imported = wrapped.Results
+	default:
+		imported, err = parseCSV(data)
+	}
+
+	if err != nil {
+		return nil, errors.WrapIf(err, "Invalid format")
+	}
+
+	if len(imported) < 1 {
+		return nil, errors.New("No questions found")
+	}
+
+	result := make([]*BankQuestion, 0, len(imported))
+	for i, v := range imported {
+		q, err := v.toBankQuestion()
+		if err != nil {
+			return nil, fmt.Errorf("Question #%d: %s", i+1, err.Error())
+		}
+
+		result = append(result, q)
+	}
+
+	return result, nil
+}
+
+func parseCSV(data []byte) ([]*importedQuestion, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	var result []*importedQuestion
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(result) == 0 && strings.EqualFold(strings.TrimSpace(record[0]), "question") {
+			// header
+			continue
+		}
+
+		if len(record) < 5 {
+			line, _ := r.FieldPos(0)
+			return nil, fmt.Errorf("line %d: expected atleast 5 columns: question, answer, category, difficulty and an incorrect answer", line)
+		}
+
+		result = append(result, &importedQuestion{
+			Question:         record[0],
+			Answer:           record[1],
+			Category:         record[2],
+			Difficulty:       record[3],
+			IncorrectAnswers: record[4:],
+		})
+	}
+
+	return result, nil
+}
+
+func (q *importedQuestion) toBankQuestion() (*BankQuestion, error) {
+	answer := q.Answer
+	if answer == "" {
+		answer = q.CorrectAnswer
+	}
+
+	result := &BankQuestion{
+		Question:   strings.TrimSpace(q.Question),
+		Answer:     strings.TrimSpace(answer),
+		Category:   strings.TrimSpace(q.Category),
+		Difficulty: strings.ToLower(strings.TrimSpace(q.Difficulty)),
+	}
+
+	for _, v := range q.IncorrectAnswers {
+		v = strings.TrimSpace(v)
+		if v != "" {
+			result.IncorrectAnswers = append(result.IncorrectAnswers, v)
+		}
+	}
+
+	return result, result.Validate()
+}
+
+// Validate checks the question can be asked
+func (q *BankQuestion) Validate() error {
+	if q.Question == "" {
+		return errors.New("the question is empty")
+	}
+
+	if len(q.Question) > MaxQuestionLength {
+		return fmt.Errorf("the question is longer than %d characters", MaxQuestionLength)
+	}
+
+	if len(q.Category) > MaxCategoryLength {
+		return fmt.Errorf("the category is longer than %d characters", MaxCategoryLength)
+	}
+
+	if !ValidDifficulty(q.Difficulty) {
+		return fmt.Errorf("unknown difficulty %q, should be one of %s", q.Difficulty, strings.Join(Difficulties, ", "))
+	}
+
+	if len(q.IncorrectAnswers) < 1 || len(q.IncorrectAnswers) > MaxIncorrect {
+		return fmt.Errorf("should have between 1 and %d incorrect answers", MaxIncorrect)
+	}
+
+	options := append([]string{q.Answer}, q.IncorrectAnswers...)
+	for i, v := range options {
+		if v == "" {
+			return errors.New("the answer is empty")
+		}
+
+		if len(v) > MaxAnswerLength {
+			return fmt.Errorf("the answer %q is longer than %d characters", v, MaxAnswerLength)
+		}
+
+		for _, other := range options[:i] {
+			if strings.EqualFold(v, other) {
+				return fmt.Errorf("the answer %q is listed more than once", v)
+			}
+		}
+	}
+
+	return nil
+}
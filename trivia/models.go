@@ -0,0 +1,386 @@
+package trivia
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/lib/pq"
+)
+
+// Config is the trivia config of a guild
+type Config struct {
+	GuildID        int64
+	QuestionSource int
+	RoundSeconds   int
+}
+
+func DefaultConfig(guildID int64) *Config {
+	return &Config{
+		GuildID:        guildID,
+		QuestionSource: QuestionSourceOpenTDB,
+		RoundSeconds:   int(DefaultRoundDuration / time.Second),
+	}
+}
+
+func (c *Config) RoundDuration() time.Duration {
+	if c.RoundSeconds < 1 {
+		return DefaultRoundDuration
+	}
+
+	return time.Duration(c.RoundSeconds) * time.Second
+}
+
+// GetConfig returns the config of the guild, or the default config if it has none
+func GetConfig(ctx context.Context, guildID int64) (*Config, error) {
+	c := &Config{}
+	err := common.PQ.QueryRowContext(ctx, `SELECT guild_id, question_source, round_seconds FROM trivia_configs WHERE guild_id = $1`, guildID).Scan(
+		&c.GuildID, &c.QuestionSource, &c.RoundSeconds)
+	if err == sql.ErrNoRows {
+		return DefaultConfig(guildID), nil
+	} else if err != nil {
+		return nil, errors.WrapIf(err, "trivia.GetConfig")
+	}
+
+	return c, nil
+}
+
+func SaveConfig(ctx context.Context, c *Config) error {
+	const q = `INSERT INTO trivia_configs (guild_id, question_source, round_seconds) VALUES ($1, $2, $3)
+ON CONFLICT (guild_id) DO UPDATE SET question_source = $2, round_seconds = $3`
+
+	_, err := common.PQ.ExecContext(ctx, q, c.GuildID, c.QuestionSource, c.RoundSeconds)
+	return err
+}
+
+// BankQuestion is a question in the question bank of a guild
+type BankQuestion struct {
+	ID        int64     `json:"-"`
+	GuildID   int64     `json:"-"`
+	CreatedAt time.Time `json:"-"`
+
+	Question         string         `json:"question"`
+	Answer           string         `json:"answer"`
+	IncorrectAnswers pq.StringArray `json:"incorrect_answers"`
+	Category         string         `json:"category"`
+	Difficulty       string         `json:"difficulty"`
+}
+
+// IsBoolean returns true for true or false questions
+func (q *BankQuestion) IsBoolean() bool {
+	if len(q.IncorrectAnswers) != 1 {
+		return false
+	}
+
+	a, b := strings.ToLower(q.Answer), strings.ToLower(q.IncorrectAnswers[0])
+	return (a == "true" && b == "false") || (a == "false" && b == "true")
+}
+
+// TriviaQuestion returns the question ready to be asked, with the options in a random order
+func (q *BankQuestion) TriviaQuestion() *TriviaQuestion {
+	tq := &TriviaQuestion{
+		Question:   q.Question,
+		Answer:     q.Answer,
+		Category:   q.Category,
+		Difficulty: q.Difficulty,
+		Type:       "multiple",
+		Options:    append([]string{}, q.IncorrectAnswers...),
+	}
+
+	if tq.Category == "" {
+		tq.Category = "General"
+	}
+
+	if q.IsBoolean() {
+		tq.Type = "boolean"
+		tq.Options = []string{"True", "False"}
+		if strings.EqualFold(q.Answer, "true") {
+			tq.Answer = "True"
+		} else {
+			tq.Answer = "False"
+		}
+	} else {
+		tq.RandomizeOptionOrder()
+	}
+
+	return tq
+}
+
+const bankQuestionColumns = `id, guild_id, created_at, question, answer, incorrect_answers, category, difficulty`
+
+func scanBankQuestions(rows *sql.Rows) ([]*BankQuestion, error) {
+	defer rows.Close()
+
+	result := make([]*BankQuestion, 0)
+	for rows.Next() {
+		q := &BankQuestion{}
+		err := rows.Scan(&q.ID, &q.GuildID, &q.CreatedAt, &q.Question, &q.Answer, &q.IncorrectAnswers, &q.Category, &q.Difficulty)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, q)
+	}
+
+	return result, rows.Err()
+}
+
+// GuildQuestions returns the newest questions in the question bank of the guild
+func GuildQuestions(ctx context.Context, guildID int64, limit int) ([]*BankQuestion, error) {
+	rows, err := common.PQ.QueryContext(ctx, `SELECT `+bankQuestionColumns+` FROM trivia_questions WHERE guild_id = $1 ORDER BY id DESC LIMIT $2`, guildID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanBankQuestions(rows)
+}
+
+// RandomGuildQuestions returns up to amount random questions from the question bank of the guild matching the filter
+func RandomGuildQuestions(ctx context.Context, guildID int64, amount int, filter QuestionFilter) ([]*BankQuestion, error) {
+	const q = `SELECT ` + bankQuestionColumns + ` FROM trivia_questions
+WHERE guild_id = $1 AND ($2 = '' OR lower(category) = lower($2)) AND ($3 = '' OR difficulty = lower($3))
+ORDER BY random()
+LIMIT $4`
+
+	rows, err := common.PQ.QueryContext(ctx, q, guildID, filter.Category, filter.Difficulty, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanBankQuestions(rows)
+}
+
+func CountGuildQuestions(ctx context.Context, guildID int64) (int, error) {
+	var count int
+	err := common.PQ.QueryRowContext(ctx, `SELECT count(*) FROM trivia_questions WHERE guild_id = $1`, guildID).Scan(&count)
+	return count, err
+}
+
+type CategoryCount struct {
+	Category string
+	Count    int
+}
+
+// GuildCategories returns the categories in the question bank of the guild and how many questions they have
+func GuildCategories(ctx context.Context, guildID int64) ([]*CategoryCount, error) {
+	rows, err := common.PQ.QueryContext(ctx, `SELECT category, count(*) FROM trivia_questions WHERE guild_id = $1 GROUP BY category ORDER BY category ASC`, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*CategoryCount, 0)
+	for rows.Next() {
+		c := &CategoryCount{}
+		if err = rows.Scan(&c.Category, &c.Count); err != nil {
+			return nil, err
+		}
+
+		result = append(result, c)
+	}
+
+	return result, rows.Err()
+}
+
+// InsertQuestions adds the questions to the question bank of the guild
+func InsertQuestions(ctx context.Context, guildID int64, questions []*BankQuestion) error {
+	tx, err := common.PQ.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	const q = `INSERT INTO trivia_questions (guild_id, created_at, question, answer, incorrect_answers, category, difficulty)
+VALUES ($1, now(), $2, $3, $4, $5, $6)`
+
+	for _, v := range questions {
+		_, err = tx.ExecContext(ctx, q, guildID, v.Question, v.Answer, v.IncorrectAnswers, v.Category, v.Difficulty)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func DeleteQuestion(ctx context.Context, guildID, id int64) error {
+	_, err := common.PQ.ExecContext(ctx, `DELETE FROM trivia_questions WHERE guild_id = $1 AND id = $2`, guildID, id)
+	return err
+}
+
+func DeleteAllQuestions(ctx context.Context, guildID int64) error {
+	_, err := common.PQ.ExecContext(ctx, `DELETE FROM trivia_questions WHERE guild_id = $1`, guildID)
+	return err
+}
+
+// UserScore is the trivia score of a member over all games
+type UserScore struct {
+	GuildID int64
+	UserID  int64
+
+	Points         int64
+	CorrectAnswers int
+	Answers        int
+	Games          int
+	UpdatedAt      time.Time
+}
+
+var ErrUserNotFound = errors.New("User not found")
+
+// SaveGameScores adds the results of a game to the scores of the players
+func SaveGameScores(ctx context.Context, guildID int64, players []*PlayerScore) error {
+	tx, err := common.PQ.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	const q = `INSERT INTO trivia_scores (guild_id, user_id, points, correct_answers, answers, games, updated_at)
+VALUES ($1, $2, $3, $4, $5, 1, now())
+ON CONFLICT (guild_id, user_id) DO UPDATE SET
+points = trivia_scores.points + $3,
+correct_answers = trivia_scores.correct_answers + $4,
+answers = trivia_scores.answers + $5,
+games = trivia_scores.games + 1,
+updated_at = now()`
+
+	for _, v := range players {
+		_, err = tx.ExecContext(ctx, q, guildID, v.UserID, v.Points, v.Correct, v.Answered)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetUserScore returns the score of the user and their position on the leaderboard
+func GetUserScore(ctx context.Context, guildID, userID int64) (score *UserScore, rank int, err error) {
+	const q = `SELECT points, correct_answers, answers, games, updated_at, position FROM
+(
+	SELECT user_id, points, correct_answers, answers, games, updated_at,
+	RANK() OVER(ORDER BY points DESC) AS position
+	FROM trivia_scores WHERE guild_id = $1
+) AS w
+WHERE user_id = $2`
+
+	score = &UserScore{GuildID: guildID, UserID: userID}
+	err = common.PQ.QueryRowContext(ctx, q, guildID, userID).Scan(&score.Points, &score.CorrectAnswers, &score.Answers, &score.Games, &score.UpdatedAt, &rank)
+	if err == sql.ErrNoRows {
+		err = ErrUserNotFound
+	}
+
+	return
+}
+
+type RankEntry struct {
+	Rank           int
+	UserID         int64
+	Points         int64
+	CorrectAnswers int
+}
+
+// TopScores returns a page of the trivia leaderboard
+func TopScores(ctx context.Context, guildID int64, offset, limit int) ([]*RankEntry, error) {
+	const q = `SELECT user_id, points, correct_answers, RANK() OVER(ORDER BY points DESC) AS position
+FROM trivia_scores WHERE guild_id = $1
+ORDER BY points DESC, user_id ASC
+LIMIT $2 OFFSET $3`
+
+	rows, err := common.PQ.QueryContext(ctx, q, guildID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*RankEntry, 0, limit)
+	for rows.Next() {
+		e := &RankEntry{}
+		err = rows.Scan(&e.UserID, &e.Points, &e.CorrectAnswers, &e.Rank)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, e)
+	}
+
+	return result, rows.Err()
+}
+
+// ResetScores deletes the trivia scores of all members in the guild
+func ResetScores(ctx context.Context, guildID int64) error {
+	_, err := common.PQ.ExecContext(ctx, `DELETE FROM trivia_scores WHERE guild_id = $1`, guildID)
+	return err
+}
+
+// Schedule is a trivia night started automatically in a channel
+type Schedule struct {
+	ID        int64
+	GuildID   int64
+	ChannelID int64
+
+	NextRun time.Time
+	// 0 to only run once
+	IntervalHours int
+
+	Rounds     int
+	Category   string
+	Difficulty string
+}
+
+const scheduleColumns = `id, guild_id, channel_id, next_run, interval_hours, rounds, category, difficulty`
+
+func GuildSchedules(ctx context.Context, guildID int64) ([]*Schedule, error) {
+	rows, err := common.PQ.QueryContext(ctx, `SELECT `+scheduleColumns+` FROM trivia_schedules WHERE guild_id = $1 ORDER BY next_run ASC`, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*Schedule, 0)
+	for rows.Next() {
+		s := &Schedule{}
+		err = rows.Scan(&s.ID, &s.GuildID, &s.ChannelID, &s.NextRun, &s.IntervalHours, &s.Rounds, &s.Category, &s.Difficulty)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, s)
+	}
+
+	return result, rows.Err()
+}
+
+var ErrScheduleNotFound = errors.New("Trivia schedule not found")
+
+func GetSchedule(ctx context.Context, guildID, id int64) (*Schedule, error) {
+	s := &Schedule{}
+	err := common.PQ.QueryRowContext(ctx, `SELECT `+scheduleColumns+` FROM trivia_schedules WHERE guild_id = $1 AND id = $2`, guildID, id).Scan(
+		&s.ID, &s.GuildID, &s.ChannelID, &s.NextRun, &s.IntervalHours, &s.Rounds, &s.Category, &s.Difficulty)
+	if err == sql.ErrNoRows {
+		return nil, ErrScheduleNotFound
+	}
+
+	return s, err
+}
+
+func (s *Schedule) Insert(ctx context.Context) error {
+	const q = `INSERT INTO trivia_schedules (guild_id, channel_id, next_run, interval_hours, rounds, category, difficulty)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id`
+
+	return common.PQ.QueryRowContext(ctx, q, s.GuildID, s.ChannelID, s.NextRun, s.IntervalHours, s.Rounds, s.Category, s.Difficulty).Scan(&s.ID)
+}
+
+func (s *Schedule) UpdateNextRun(ctx context.Context) error {
+	_, err := common.PQ.ExecContext(ctx, `UPDATE trivia_schedules SET next_run = $3 WHERE guild_id = $1 AND id = $2`, s.GuildID, s.ID, s.NextRun)
+	return err
+}
+
+func DeleteSchedule(ctx context.Context, guildID, id int64) error {
+	_, err := common.PQ.ExecContext(ctx, `DELETE FROM trivia_schedules WHERE guild_id = $1 AND id = $2`, guildID, id)
+	return err
+}
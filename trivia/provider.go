@@ -0,0 +1,113 @@
+package trivia
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+
+	"github.com/botlabs-gg/yagpdb/v2/commands"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+)
+
+// QuestionFilter limits the questions a provider returns, empty fields match everything
+type QuestionFilter struct {
+	Category   string
+	Difficulty string
+}
+
+func (f QuestionFilter) Matches(q *BankQuestion) bool {
+	if f.Category != "" && !strings.EqualFold(f.Category, q.Category) {
+		return false
+	}
+
+	if f.Difficulty != "" && !strings.EqualFold(f.Difficulty, q.Difficulty) {
+		return false
+	}
+
+	return true
+}
+
+// QuestionProvider is a source of trivia questions
+type QuestionProvider interface {
+	// FetchQuestions returns up to amount questions matching the filter, with the options in the order they should be shown
+	FetchQuestions(ctx context.Context, amount int, filter QuestionFilter) ([]*TriviaQuestion, error)
+
+	// Attribution is shown in the footer of the trivia messages, nil for none
+	Attribution() *discordgo.MessageEmbedFooter
+}
+
+var ErrNoQuestions = commands.NewPublicError("No questions found, add some to the question bank or try another category or difficulty")
+
+const (
+	QuestionSourceOpenTDB    = 0
+	QuestionSourceServerBank = 1
+)
+
+// ProviderForConfig returns the question provider selected in the config
+func ProviderForConfig(conf *Config) QuestionProvider {
+	if conf.QuestionSource == QuestionSourceServerBank {
+		return &GuildBankProvider{GuildID: conf.GuildID}
+	}
+
+	return &OpenTDBProvider{}
+}
+
+// LocalBank is a provider with a fixed set of questions kept in memory
+type LocalBank struct {
+	Questions []*BankQuestion
+}
+
+var _ QuestionProvider = (*LocalBank)(nil)
+
+func (b *LocalBank) Attribution() *discordgo.MessageEmbedFooter {
+	return nil
+}
+
+func (b *LocalBank) FetchQuestions(ctx context.Context, amount int, filter QuestionFilter) ([]*TriviaQuestion, error) {
+	matching := make([]*BankQuestion, 0, len(b.Questions))
+	for _, v := range b.Questions {
+		if filter.Matches(v) {
+			matching = append(matching, v)
+		}
+	}
+
+	if len(matching) < 1 {
+		return nil, ErrNoQuestions
+	}
+
+	rand.Shuffle(len(matching), func(i, j int) {
+		matching[i], matching[j] = matching[j], matching[i]
+	})
+
+	if len(matching) > amount {
+		matching = matching[:amount]
+	}
+
+	result := make([]*TriviaQuestion, 0, len(matching))
+	for _, v := range matching {
+		result = append(result, v.TriviaQuestion())
+	}
+
+	return result, nil
+}
+
+// GuildBankProvider picks questions from the question bank of a server
+type GuildBankProvider struct {
+	GuildID int64
+}
+
+var _ QuestionProvider = (*GuildBankProvider)(nil)
+
+func (p *GuildBankProvider) Attribution() *discordgo.MessageEmbedFooter {
+	return nil
+}
+
+func (p *GuildBankProvider) FetchQuestions(ctx context.Context, amount int, filter QuestionFilter) ([]*TriviaQuestion, error) {
+	questions, err := RandomGuildQuestions(ctx, p.GuildID, amount, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	bank := &LocalBank{Questions: questions}
+	return bank.FetchQuestions(ctx, amount, filter)
+}
@@ -1,6 +1,7 @@
 package trivia
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/rand"
@@ -10,14 +11,16 @@ import (
 
 	"github.com/botlabs-gg/yagpdb/v2/commands"
 	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
 )
 
 type TriviaQuestion struct {
-	Question string   `json:"question"`
-	Answer   string   `json:"correct_answer"`
-	Category string   `json:"category"`
-	Type     string   `json:"type"`
-	Options  []string `json:"incorrect_answers"`
+	Question   string   `json:"question"`
+	Answer     string   `json:"correct_answer"`
+	Category   string   `json:"category"`
+	Difficulty string   `json:"difficulty"`
+	Type       string   `json:"type"`
+	Options    []string `json:"incorrect_answers"`
 }
 
 type TriviaResponse struct {
@@ -25,7 +28,23 @@ type TriviaResponse struct {
 	Questions []*TriviaQuestion `json:"results"`
 }
 
-func FetchQuestions(amount int) ([]*TriviaQuestion, error) {
+// OpenTDBProvider fetches questions from opentdb.com, categories are not supported
+type OpenTDBProvider struct{}
+
+var _ QuestionProvider = (*OpenTDBProvider)(nil)
+
+func (p *OpenTDBProvider) Attribution() *discordgo.MessageEmbedFooter {
+	return &discordgo.MessageEmbedFooter{
+		Text:    "Powered by Opentdb.com",
+		IconURL: "https://opentdb.com/images/logo-banner.png",
+	}
+}
+
+func (p *OpenTDBProvider) FetchQuestions(ctx context.Context, amount int, filter QuestionFilter) ([]*TriviaQuestion, error) {
+	if filter.Category != "" {
+		return nil, commands.NewPublicError("Categories can only be picked when using the server's question bank")
+	}
+
 	client := &http.Client{}
 	proxy := common.ConfHttpProxy.GetString()
 	if len(proxy) > 0 {
@@ -40,7 +59,11 @@ func FetchQuestions(amount int) ([]*TriviaQuestion, error) {
 	}
 
 	url := fmt.Sprintf("https://opentdb.com/api.php?amount=%d&encode=base64", amount)
-	req, err := http.NewRequest("GET", url, nil)
+	if filter.Difficulty != "" {
+		url += "&difficulty=" + filter.Difficulty
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -82,6 +105,7 @@ func (q *TriviaQuestion) Decode() {
 	q.Question, _ = common.Base64DecodeToString(q.Question)
 	q.Answer, _ = common.Base64DecodeToString(q.Answer)
 	q.Category, _ = common.Base64DecodeToString(q.Category)
+	q.Difficulty, _ = common.Base64DecodeToString(q.Difficulty)
 	q.Type, _ = common.Base64DecodeToString(q.Type)
 	for index, option := range q.Options {
 		q.Options[index], _ = common.Base64DecodeToString(option)
@@ -0,0 +1,91 @@
+package trivia
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/bot"
+	"github.com/botlabs-gg/yagpdb/v2/commands"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/scheduledevents2"
+	seventsmodels "github.com/botlabs-gg/yagpdb/v2/common/scheduledevents2/models"
+)
+
+const MaxSchedules = 10
+
+// ScheduleTriviaNight schedules the next run of the trivia night
+func ScheduleTriviaNight(s *Schedule) error {
+	return scheduledevents2.ScheduleEvent("trivia_night", s.GuildID, s.NextRun, s.ID)
+}
+
+func describeTriviaNightEvent(evt *seventsmodels.ScheduledEvent, data interface{}) string {
+	return fmt.Sprintf("Start trivia night #%d", *data.(*int64))
+}
+
+func handleTriviaNightEvent(evt *seventsmodels.ScheduledEvent, data interface{}) (retry bool, err error) {
+	ctx := context.Background()
+
+	s, err := GetSchedule(ctx, evt.GuildID, *data.(*int64))
+	if err == ErrScheduleNotFound {
+		// deleted
+		return false, nil
+	} else if err != nil {
+		return true, err
+	}
+
+	if time.Until(s.NextRun) > time.Second*5 {
+		// old event from before the schedule was changed
+		return false, nil
+	}
+
+	gs := bot.State.GetGuild(evt.GuildID)
+	if gs == nil {
+		if onGuild, err := common.BotIsOnGuild(evt.GuildID); !onGuild && err == nil {
+			return false, DeleteSchedule(ctx, s.GuildID, s.ID)
+		} else if err != nil {
+			logger.WithError(err).Error("failed checking if bot is on guild")
+		}
+
+		return true, nil
+	}
+
+	// schedule the next run first so a failed game doesn't stop the schedule
+	next := NextScheduleRun(s.NextRun, s.IntervalHours, time.Now())
+	if next.IsZero() {
+		err = DeleteSchedule(ctx, s.GuildID, s.ID)
+	} else {
+		s.NextRun = next
+		err = s.UpdateNextRun(ctx)
+		if err == nil {
+			err = ScheduleTriviaNight(s)
+		}
+	}
+	if err != nil {
+		return true, err
+	}
+
+	if gs.GetChannelOrThread(s.ChannelID) == nil {
+		return false, nil
+	}
+
+	conf, err := GetConfig(ctx, s.GuildID)
+	if err != nil {
+		return false, err
+	}
+
+	err = manager.NewTrivia(ctx, s.GuildID, s.ChannelID, &GameOptions{
+		Provider:      ProviderForConfig(conf),
+		Filter:        QuestionFilter{Category: s.Category, Difficulty: s.Difficulty},
+		Rounds:        s.Rounds,
+		RoundDuration: conf.RoundDuration(),
+		Title:         "Trivia night results",
+	})
+
+	if _, ok := err.(commands.PublicError); ok || err == ErrSessionInChannel {
+		common.BotSession.ChannelMessageSend(s.ChannelID, "Failed starting the trivia night: "+err.Error())
+		return false, nil
+	}
+
+	return false, err
+}
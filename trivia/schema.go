@@ -0,0 +1,53 @@
+package trivia
+
+var DBSchemas = []string{`
+CREATE TABLE IF NOT EXISTS trivia_configs (
+	guild_id BIGINT PRIMARY KEY,
+	question_source SMALLINT NOT NULL,
+	round_seconds INT NOT NULL
+);
+`, `
+CREATE TABLE IF NOT EXISTS trivia_questions (
+	id BIGSERIAL PRIMARY KEY,
+	guild_id BIGINT NOT NULL,
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	question TEXT NOT NULL,
+	answer TEXT NOT NULL,
+	incorrect_answers TEXT[] NOT NULL,
+	category TEXT NOT NULL,
+	difficulty TEXT NOT NULL
+);
+`, `
+CREATE INDEX IF NOT EXISTS trivia_questions_guild_idx ON trivia_questions(guild_id);
+`, `
+CREATE TABLE IF NOT EXISTS trivia_scores (
+	guild_id BIGINT NOT NULL,
+	user_id BIGINT NOT NULL,
+
+	points BIGINT NOT NULL,
+	correct_answers INT NOT NULL,
+	answers INT NOT NULL,
+	games INT NOT NULL,
+	updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	PRIMARY KEY(guild_id, user_id)
+);
+`, `
+CREATE INDEX IF NOT EXISTS trivia_scores_guild_points_idx ON trivia_scores(guild_id, points DESC);
+`, `
+CREATE TABLE IF NOT EXISTS trivia_schedules (
+	id BIGSERIAL PRIMARY KEY,
+	guild_id BIGINT NOT NULL,
+	channel_id BIGINT NOT NULL,
+
+	next_run TIMESTAMP WITH TIME ZONE NOT NULL,
+	interval_hours INT NOT NULL,
+
+	rounds INT NOT NULL,
+	category TEXT NOT NULL,
+	difficulty TEXT NOT NULL
+);
+`, `
+CREATE INDEX IF NOT EXISTS trivia_schedules_guild_idx ON trivia_schedules(guild_id);
+`}
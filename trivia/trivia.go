@@ -1,6 +1,9 @@
 package trivia
 
-import "github.com/botlabs-gg/yagpdb/v2/common"
+import (
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/scheduledevents2"
+)
 
 type Plugin struct{}
 
@@ -15,5 +18,9 @@ func (p *Plugin) PluginInfo() *common.PluginInfo {
 var logger = common.GetPluginLogger(&Plugin{})
 
 func RegisterPlugin() {
+	common.InitSchemas("trivia", DBSchemas...)
+
 	common.RegisterPlugin(&Plugin{})
+
+	scheduledevents2.RegisterDescriber("trivia_night", int64(0), describeTriviaNightEvent)
 }
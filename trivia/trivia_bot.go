@@ -1,21 +1,23 @@
 package trivia
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/botlabs-gg/yagpdb/v2/bot"
 	"github.com/botlabs-gg/yagpdb/v2/bot/eventsystem"
 	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/scheduledevents2"
 	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
 )
 
-var TriviaDuration = time.Second * 30
-
 func (p *Plugin) BotInit() {
 	eventsystem.AddHandlerAsyncLastLegacy(p, p.handleInteractionCreate, eventsystem.EventInteractionCreate)
+	scheduledevents2.RegisterHandler("trivia_night", int64(0), handleTriviaNightEvent)
 }
 
 func (p *Plugin) handleInteractionCreate(evt *eventsystem.EventData) {
@@ -39,15 +41,33 @@ type pickedOption struct {
 	Option int
 }
 
+// GameOptions are the settings of a new trivia game
+type GameOptions struct {
+	Provider      QuestionProvider
+	Filter        QuestionFilter
+	Rounds        int
+	RoundDuration time.Duration
+
+	// Shown above the results at the end of games with multiple rounds
+	Title string
+}
+
 type triviaSession struct {
 	Manager         *triviaSessionManager
 	GuildID         int64
 	ChannelID       int64
 	MessageID       int64
+	Questions       []*TriviaQuestion
+	Round           int
 	Question        *TriviaQuestion
 	SelectedOptions []*pickedOption
+	Scores          gameScores
+	Attribution     *discordgo.MessageEmbedFooter
+	Title           string
+	RoundDuration   time.Duration
 	createdAt       time.Time
 	startedAt       time.Time
+	endedAt         time.Time
 	ended           bool
 	optionEmojis    []string
 
@@ -56,7 +76,7 @@ type triviaSession struct {
 
 var ErrSessionInChannel = errors.New("a trivia session already exists in this channel")
 
-func (tm *triviaSessionManager) NewTrivia(guildID int64, channelID int64) error {
+func (tm *triviaSessionManager) NewTrivia(ctx context.Context, guildID int64, channelID int64, opts *GameOptions) error {
 	tm.mu.Lock()
 	defer tm.mu.Unlock()
 	for _, v := range tm.sessions {
@@ -65,35 +85,35 @@ func (tm *triviaSessionManager) NewTrivia(guildID int64, channelID int64) error
 		}
 	}
 
-	triviaQuestions, err := FetchQuestions(1)
+	if opts.Rounds < 1 {
+		opts.Rounds = 1
+	}
+
+	if opts.RoundDuration <= 0 {
+		opts.RoundDuration = DefaultRoundDuration
+	}
+
+	triviaQuestions, err := opts.Provider.FetchQuestions(ctx, opts.Rounds, opts.Filter)
 	if err != nil {
 		return err
 	}
 
-	question := triviaQuestions[0]
-	var optionEmojis []string
-	if question.Type == "boolean" {
-		optionEmojis = []string{
-			"\U0001F1F9", // Regional ind. T
-			"\U0001F1EB", // Regional ind. F
-		}
-	} else {
-		optionEmojis = []string{
-			"\U0001F1E6", // Regional ind. A
-			"\U0001F1E7", // Regional ind. B
-			"\U0001F1E8", // Regional ind. C
-			"\U0001F1E9", // Regional ind. D
-		}
+	if len(triviaQuestions) < 1 {
+		return ErrNoQuestions
 	}
 
 	session := &triviaSession{
-		Manager:      tm,
-		createdAt:    time.Now(),
-		GuildID:      guildID,
-		ChannelID:    channelID,
-		Question:     question,
-		optionEmojis: optionEmojis,
+		Manager:       tm,
+		createdAt:     time.Now(),
+		GuildID:       guildID,
+		ChannelID:     channelID,
+		Questions:     triviaQuestions,
+		Scores:        make(gameScores),
+		Attribution:   opts.Provider.Attribution(),
+		Title:         opts.Title,
+		RoundDuration: opts.RoundDuration,
 	}
+	session.setRound(0)
 
 	tm.sessions = append(tm.sessions, session)
 
@@ -134,18 +154,48 @@ func (tm *triviaSessionManager) handleInteractionCreate(evt *eventsystem.EventDa
 	tm.mu.Unlock()
 }
 
+// setRound resets the session for the question of the round
+func (t *triviaSession) setRound(round int) {
+	t.Round = round
+	t.Question = t.Questions[round]
+	t.MessageID = 0
+	t.SelectedOptions = nil
+	t.startedAt = time.Time{}
+	t.endedAt = time.Time{}
+	t.ended = false
+
+	if t.Question.Type == "boolean" {
+		t.optionEmojis = []string{
+			"\U0001F1F9", // Regional ind. T
+			"\U0001F1EB", // Regional ind. F
+		}
+	} else {
+		t.optionEmojis = []string{
+			"\U0001F1E6", // Regional ind. A
+			"\U0001F1E7", // Regional ind. B
+			"\U0001F1E8", // Regional ind. C
+			"\U0001F1E9", // Regional ind. D
+		}
+	}
+}
+
+func (t *triviaSession) maxDuration() time.Duration {
+	return time.Duration(len(t.Questions))*(t.RoundDuration+RoundPause) + time.Minute
+}
+
 func (t *triviaSession) tickLoop() {
 	for {
-		ended := t.tick()
-		if ended || time.Since(t.createdAt) > 1*time.Minute {
+		finished := t.tick()
+		if finished || time.Since(t.createdAt) > t.maxDuration() {
 			t.Manager.removeSession(t)
+			t.finish()
 			return
 		}
 		time.Sleep(time.Second)
 	}
 }
 
-func (t *triviaSession) tick() (ended bool) {
+func (t *triviaSession) tick() (finished bool) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
@@ -154,12 +204,83 @@ func (t *triviaSession) tick() (ended bool) {
 		t.updateMessage()
 	}
 
-	if time.Since(t.startedAt) > TriviaDuration {
+	if !t.ended && time.Since(t.startedAt) > t.RoundDuration {
 		t.ended = true
+		t.endedAt = time.Now()
+		for _, v := range t.SelectedOptions {
+			t.Scores.addAnswer(v.User.ID, t.Question, v.Option)
+		}
 		t.updateMessage()
 	}
 
-	return t.ended
+	if !t.ended {
+		return false
+	}
+
+	if t.Round >= len(t.Questions)-1 {
+		return true
+	}
+
+	if time.Since(t.endedAt) > RoundPause {
+		t.setRound(t.Round + 1)
+	}
+
+	return false
+}
+
+// finish saves the scores of the game and posts the results if there was more than one round
+func (t *triviaSession) finish() {
+	t.mu.Lock()
+	ranked := t.Scores.Ranked()
+	rounds := len(t.Questions)
+	t.mu.Unlock()
+
+	if len(ranked) > 0 {
+		err := SaveGameScores(context.Background(), t.GuildID, ranked)
+		if err != nil {
+			logger.WithError(err).WithField("guild", t.GuildID).Error("failed saving trivia scores")
+		}
+	}
+
+	if rounds < 2 {
+		return
+	}
+
+	_, err := common.BotSession.ChannelMessageSendEmbed(t.ChannelID, t.buildResultsEmbed(ranked))
+	if err != nil {
+		logger.WithError(err).WithField("guild", t.GuildID).WithField("channel", t.ChannelID).Error("failed sending trivia results")
+	}
+}
+
+func (t *triviaSession) buildResultsEmbed(ranked []*PlayerScore) *discordgo.MessageEmbed {
+	title := t.Title
+	if title == "" {
+		title = "Trivia results"
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title: title,
+		Color: 0x4cb7e4,
+	}
+
+	if len(ranked) < 1 {
+		embed.Description = "No one participated :("
+		return embed
+	}
+
+	var out strings.Builder
+	for i, v := range ranked {
+		if i >= 10 {
+			out.WriteString(fmt.Sprintf("...and %d more", len(ranked)-i))
+			break
+		}
+
+		out.WriteString(fmt.Sprintf("`#%d` <@%d> - **%d** points (%d/%d correct)\n", i+1, v.UserID, v.Points, v.Correct, len(t.Questions)))
+	}
+
+	embed.Description = out.String()
+	embed.Footer = &discordgo.MessageEmbedFooter{Text: "See the all time leaderboard with the triviatop command"}
+	return embed
 }
 
 func (t *triviaSession) updateMessage() {
@@ -239,12 +360,15 @@ func (t *triviaSession) buildButtons() []discordgo.MessageComponent {
 func (t *triviaSession) buildEmbed() *discordgo.MessageEmbed {
 	embed := &discordgo.MessageEmbed{}
 	embed.Title = fmt.Sprintf("Trivia Category: %s ", t.Question.Category)
+	if t.Question.Difficulty != "" {
+		embed.Title += fmt.Sprintf("(%s) ", t.Question.Difficulty)
+	}
+	if len(t.Questions) > 1 {
+		embed.Title = fmt.Sprintf("Round %d/%d | %s", t.Round+1, len(t.Questions), embed.Title)
+	}
 	embed.Description += fmt.Sprintf("\n ## %s \n", t.Question.Question)
 
-	embed.Footer = &discordgo.MessageEmbedFooter{
-		Text:    "Powered by Opentdb.com",
-		IconURL: "https://opentdb.com/images/logo-banner.png",
-	}
+	embed.Footer = t.Attribution
 
 	optionsField := &discordgo.MessageEmbedField{
 		Name:  "Options",
@@ -261,7 +385,7 @@ func (t *triviaSession) buildEmbed() *discordgo.MessageEmbed {
 
 	embed.Fields = append(embed.Fields, optionsField)
 	if !t.ended {
-		timeLeft := t.startedAt.Add(TriviaDuration)
+		timeLeft := t.startedAt.Add(t.RoundDuration)
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
 			Name:  "Timer",
 			Value: fmt.Sprintf("**Trivia ends <t:%d:R> \n**", timeLeft.Unix()),
@@ -296,6 +420,10 @@ func (t *triviaSession) buildEmbed() *discordgo.MessageEmbed {
 				field.Value += fmt.Sprintf("%s\n", v.User.Mention())
 			}
 		}
+
+		if t.Round < len(t.Questions)-1 {
+			field.Value += fmt.Sprintf("\nNext question <t:%d:R>", t.endedAt.Add(RoundPause).Unix())
+		}
 		embed.Fields = append(embed.Fields, field)
 	} else if !t.ended && len(t.SelectedOptions) > 0 {
 		field := &discordgo.MessageEmbedField{
@@ -331,7 +459,7 @@ func (t *triviaSession) handleInteractionAdd(evt *eventsystem.EventData) {
 	}
 
 	// Editing the embed can sometime get ratelimited
-	if t.ended || time.Since(t.startedAt) > TriviaDuration {
+	if t.ended || time.Since(t.startedAt) > t.RoundDuration {
 		response.Data.Content = "You're too slow, trivia has already ended."
 		err = evt.Session.CreateInteractionResponse(ic.ID, ic.Token, &response)
 		if err != nil {
@@ -1,27 +1,153 @@
 package trivia
 
 import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/botlabs-gg/yagpdb/v2/bot/paginatedmessages"
 	"github.com/botlabs-gg/yagpdb/v2/commands"
 	"github.com/botlabs-gg/yagpdb/v2/lib/dcmd"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
 )
 
+const leaderboardPageSize = 15
+
 func (p *Plugin) AddCommands() {
 	commands.AddRootCommands(p, &commands.YAGCommand{
-		Name:                "Trivia",
-		Description:         "Asks a random question, you have got 30 seconds to answer!",
+		Name:        "Trivia",
+		Description: "Asks a random question, you have got 30 seconds to answer! Play more rounds to compete for the most points.",
+		LongDescription: "Questions come from opentdb.com or the server's question bank, depending on the trivia settings in the control panel. " +
+			"Correct answers give 1, 2 or 3 points for easy, medium and hard questions.",
+		Arguments: []*dcmd.ArgDef{
+			{Name: "Rounds", Type: &dcmd.IntArg{Min: 1, Max: MaxRounds}, Default: 1},
+		},
+		ArgSwitches: []*dcmd.ArgDef{
+			{Name: "category", Help: "Only ask questions from this category of the server's question bank", Type: dcmd.String},
+			{Name: "difficulty", Help: "Only ask easy, medium or hard questions", Type: dcmd.String},
+		},
 		RunInDM:             false,
 		CmdCategory:         commands.CategoryFun,
 		SlashCommandEnabled: true,
 		RunFunc: func(parsed *dcmd.Data) (interface{}, error) {
-			err := manager.NewTrivia(parsed.GuildData.GS.ID, parsed.ChannelID)
+			difficulty := strings.ToLower(parsed.Switch("difficulty").Str())
+			if !ValidDifficulty(difficulty) {
+				return "Unknown difficulty, use one of: " + strings.Join(Difficulties, ", "), nil
+			}
+
+			conf, err := GetConfig(parsed.Context(), parsed.GuildData.GS.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			err = manager.NewTrivia(parsed.Context(), parsed.GuildData.GS.ID, parsed.ChannelID, &GameOptions{
+				Provider:      ProviderForConfig(conf),
+				Filter:        QuestionFilter{Category: parsed.Switch("category").Str(), Difficulty: difficulty},
+				Rounds:        parsed.Args[0].Int(),
+				RoundDuration: conf.RoundDuration(),
+			})
 			if err != nil {
-				logger.WithError(err).Error("Failed to create new trivia")
 				if err == ErrSessionInChannel {
 					return "There's already a trivia session in this channel", nil
 				}
+				if _, ok := err.(commands.PublicError); ok {
+					return nil, err
+				}
+				logger.WithError(err).Error("Failed to create new trivia")
 				return "Failed Running Trivia, unknown error", nil
 			}
 			return nil, nil
 		},
+	}, &commands.YAGCommand{
+		Name:                "TriviaTop",
+		Aliases:             []string{"trivialeaderboard", "ttop"},
+		Description:         "Shows the trivia leaderboard on the server",
+		CmdCategory:         commands.CategoryFun,
+		SlashCommandEnabled: true,
+		DefaultEnabled:      true,
+		Arguments: []*dcmd.ArgDef{
+			{Name: "Page", Type: dcmd.Int, Default: 0},
+		},
+		RunFunc: func(parsed *dcmd.Data) (interface{}, error) {
+			guildID := parsed.GuildData.GS.ID
+			page := parsed.Args[0].Int()
+			if page < 1 {
+				page = 1
+			}
+
+			if parsed.Context().Value(paginatedmessages.CtxKeyNoPagination) != nil {
+				return leaderboardPager(guildID, nil, page)
+			}
+
+			_, err := paginatedmessages.CreatePaginatedMessage(guildID, parsed.ChannelID, page, 0, func(p *paginatedmessages.PaginatedMessage, page int) (*discordgo.MessageEmbed, error) {
+				return leaderboardPager(guildID, p, page)
+			})
+
+			return nil, err
+		},
+	}, &commands.YAGCommand{
+		Name:                "TriviaScore",
+		Aliases:             []string{"tscore"},
+		Description:         "Shows yours or the specified users trivia score and rank",
+		CmdCategory:         commands.CategoryFun,
+		SlashCommandEnabled: true,
+		DefaultEnabled:      true,
+		Arguments: []*dcmd.ArgDef{
+			{Name: "User", Type: dcmd.User},
+		},
+		RunFunc: func(parsed *dcmd.Data) (interface{}, error) {
+			target := parsed.Author
+			if parsed.Args[0].Value != nil {
+				target = parsed.Args[0].Value.(*discordgo.User)
+			}
+
+			score, rank, err := GetUserScore(parsed.Context(), parsed.GuildData.GS.ID, target.ID)
+			if err == ErrUserNotFound {
+				return fmt.Sprintf("%s hasn't played trivia on this server yet", target.String()), nil
+			} else if err != nil {
+				return nil, err
+			}
+
+			return &discordgo.MessageEmbed{
+				Author: &discordgo.MessageEmbedAuthor{
+					Name:    target.String(),
+					IconURL: target.AvatarURL("256"),
+				},
+				Color: 0x4cb7e4,
+				Fields: []*discordgo.MessageEmbedField{
+					{Name: "Points", Value: strconv.FormatInt(score.Points, 10), Inline: true},
+					{Name: "Rank", Value: "#" + strconv.Itoa(rank), Inline: true},
+					{Name: "Correct answers", Value: fmt.Sprintf("%d/%d", score.CorrectAnswers, score.Answers), Inline: true},
+					{Name: "Games", Value: strconv.Itoa(score.Games), Inline: true},
+				},
+			}, nil
+		},
 	})
 }
+
+func leaderboardPager(guildID int64, p *paginatedmessages.PaginatedMessage, page int) (*discordgo.MessageEmbed, error) {
+	offset := (page - 1) * leaderboardPageSize
+	entries, err := TopScores(context.Background(), guildID, offset, leaderboardPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entries) < 1 && p != nil && p.LastResponse != nil { //Dont send No Results error on first execution
+		return nil, paginatedmessages.ErrNoResults
+	}
+
+	var out strings.Builder
+	for _, v := range entries {
+		out.WriteString(fmt.Sprintf("`#%d` <@%d> - **%d** points, %d correct answers\n", v.Rank, v.UserID, v.Points, v.CorrectAnswers))
+	}
+
+	if len(entries) < 1 {
+		out.WriteString("No one has played trivia yet")
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       "Trivia leaderboard",
+		Description: out.String(),
+	}, nil
+}
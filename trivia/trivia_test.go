@@ -0,0 +1,162 @@
+package trivia
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestParseImport(t *testing.T) {
+	csvData := `question,answer,category,difficulty,incorrect
+What is 2+2?,4,Math,easy,3,5,22
+"Is the sky blue, usually?",True,Nature,,False
+`
+
+	questions, err := ParseImport([]byte(csvData))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(questions) != 2 {
+		t.Fatalf("expected 2 questions, got %d", len(questions))
+	}
+
+	if questions[0].Answer != "4" || len(questions[0].IncorrectAnswers) != 3 || questions[0].Category != "Math" || questions[0].Difficulty != "easy" {
+		t.Errorf("unexpected first question: %+v", questions[0])
+	}
+
+	if questions[1].Question != "Is the sky blue, usually?" || !questions[1].IsBoolean() {
+		t.Errorf("expected a true or false question: %+v", questions[1])
+	}
+
+	jsonData := `[{"question": "Capital of France?", "answer": "Paris", "incorrect_answers": ["Lyon", "Nice"], "category": "Geography", "difficulty": "Medium"}]`
+	questions, err = ParseImport([]byte(jsonData))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(questions) != 1 || questions[0].Answer != "Paris" || questions[0].Difficulty != "medium" {
+		t.Errorf("unexpected json import: %+v", questions[0])
+	}
+
+	opentdbData := `{"response_code": 0, "results": [{"question": "Q?", "correct_answer": "A", "incorrect_answers": ["B"]}]}`
+	questions, err = ParseImport([]byte(opentdbData))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(questions) != 1 || questions[0].Answer != "A" {
+		t.Errorf("unexpected opentdb import: %+v", questions)
+	}
+
+	invalid := []string{
+		"",
+		"Q?,A,General,easy",
+		"Q?,A,General,extreme,B",
+		"Q?,A,General,easy,a",
+		`[{"question": "", "answer": "A", "incorrect_answers": ["B"]}]`,
+		`[{"question": "Q?", "answer": "A", "incorrect_answers": ["B", "C", "D", "E"]}]`,
+	}
+
+	for _, v := range invalid {
+		_, err := ParseImport([]byte(v))
+		if err == nil {
+			t.Errorf("expected an error importing %q", v)
+		}
+	}
+}
+
+func TestLocalBank(t *testing.T) {
+	bank := &LocalBank{Questions: []*BankQuestion{
+		{Question: "1", Answer: "a", IncorrectAnswers: []string{"b", "c"}, Category: "Math", Difficulty: "easy"},
+		{Question: "2", Answer: "a", IncorrectAnswers: []string{"b"}, Category: "Math", Difficulty: "hard"},
+		{Question: "3", Answer: "false", IncorrectAnswers: []string{"true"}, Category: "History", Difficulty: "hard"},
+	}}
+
+	questions, err := bank.FetchQuestions(context.Background(), 10, QuestionFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(questions) != 3 {
+		t.Errorf("expected all 3 questions, got %d", len(questions))
+	}
+
+	questions, err = bank.FetchQuestions(context.Background(), 10, QuestionFilter{Category: "math", Difficulty: "hard"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(questions) != 1 || questions[0].Question != "2" || len(questions[0].Options) != 2 {
+		t.Errorf("unexpected filtered questions: %+v", questions)
+	}
+
+	questions, err = bank.FetchQuestions(context.Background(), 1, QuestionFilter{Category: "History"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q := questions[0]
+	if q.Type != "boolean" || q.Answer != "False" || q.Options[0] != "True" || q.Options[1] != "False" {
+		t.Errorf("unexpected true or false question: %+v", q)
+	}
+
+	_, err = bank.FetchQuestions(context.Background(), 1, QuestionFilter{Category: "Music"})
+	if err != ErrNoQuestions {
+		t.Errorf("expected ErrNoQuestions, got %v", err)
+	}
+}
+
+func TestGameScores(t *testing.T) {
+	easy := &TriviaQuestion{Answer: "a", Difficulty: "easy", Options: []string{"a", "b"}}
+	hard := &TriviaQuestion{Answer: "b", Difficulty: "hard", Options: []string{"a", "b"}}
+
+	scores := make(gameScores)
+	scores.addAnswer(1, easy, 0)
+	scores.addAnswer(2, easy, 0)
+	scores.addAnswer(3, easy, 1)
+	scores.addAnswer(1, hard, 0)
+	scores.addAnswer(2, hard, 1)
+	scores.addAnswer(3, hard, -1)
+
+	ranked := scores.Ranked()
+	if len(ranked) != 3 {
+		t.Fatalf("expected 3 players, got %d", len(ranked))
+	}
+
+	expected := []PlayerScore{
+		{UserID: 2, Points: 4, Correct: 2, Answered: 2},
+		{UserID: 1, Points: 1, Correct: 1, Answered: 2},
+		{UserID: 3, Points: 0, Correct: 0, Answered: 2},
+	}
+
+	for i, v := range expected {
+		if *ranked[i] != v {
+			t.Errorf("rank %d: expected %+v, got %+v", i+1, v, *ranked[i])
+		}
+	}
+}
+
+func TestNextScheduleRun(t *testing.T) {
+	last := time.Date(2023, 5, 1, 20, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		interval int
+		now      time.Time
+		expected time.Time
+	}{
+		{0, last, time.Time{}},
+		{24, last.Add(time.Minute), time.Date(2023, 5, 2, 20, 0, 0, 0, time.UTC)},
+		{168, last.Add(time.Minute), time.Date(2023, 5, 8, 20, 0, 0, 0, time.UTC)},
+		// missed runs are skipped
+		{24, time.Date(2023, 5, 4, 21, 0, 0, 0, time.UTC), time.Date(2023, 5, 5, 20, 0, 0, 0, time.UTC)},
+		{24, time.Date(2023, 5, 4, 20, 0, 0, 0, time.UTC), time.Date(2023, 5, 5, 20, 0, 0, 0, time.UTC)},
+	}
+
+	for i, c := range cases {
+		next := NextScheduleRun(last, c.interval, c.now)
+		if !next.Equal(c.expected) {
+			t.Errorf("case %d: expected %s, got %s", i, c.expected, next)
+		}
+	}
+}
@@ -0,0 +1,309 @@
+package trivia
+
+import (
+	_ "embed"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/cplogs"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+	"github.com/botlabs-gg/yagpdb/v2/web"
+	"goji.io"
+	"goji.io/pat"
+)
+
+//go:embed assets/trivia.html
+var PageHTML string
+
+// Max size of a uploaded import file
+const MaxImportSize = 1000000
+
+type SettingsForm struct {
+	QuestionSource int `valid:"0,1"`
+	RoundSeconds   int `valid:"10,120"`
+}
+
+type ImportForm struct {
+	Questions string `valid:",1000000"`
+}
+
+type ScheduleForm struct {
+	Channel       int64  `valid:"channel,false"`
+	StartsAt      string `valid:",1,50"`
+	IntervalHours int    `valid:"0,720"`
+	Rounds        int    `valid:"1,10"`
+	Category      string `valid:",50"`
+	Difficulty    string `valid:",10"`
+}
+
+var (
+	panelLogKeyUpdatedSettings   = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "trivia_settings_updated", FormatString: "Updated trivia settings"})
+	panelLogKeyImportedQuestions = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "trivia_imported_questions", FormatString: "Imported %d trivia questions"})
+	panelLogKeyRemovedQuestion   = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "trivia_removed_question", FormatString: "Removed a trivia question"})
+	panelLogKeyClearedQuestions  = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "trivia_cleared_questions", FormatString: "Removed all trivia questions"})
+	panelLogKeyAddedSchedule     = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "trivia_added_schedule", FormatString: "Scheduled a trivia night"})
+	panelLogKeyRemovedSchedule   = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "trivia_removed_schedule", FormatString: "Removed a scheduled trivia night"})
+	panelLogKeyResetScores       = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "trivia_reset_scores", FormatString: "Reset the trivia scores of all members"})
+)
+
+func (p *Plugin) InitWeb() {
+	web.AddHTMLTemplate("trivia/assets/trivia.html", PageHTML)
+	web.AddSidebarItem(web.SidebarCategoryFun, &web.SidebarItem{
+		Name: "Trivia",
+		URL:  "trivia",
+		Icon: "fas fa-question-circle",
+	})
+
+	subMux := goji.SubMux()
+	web.CPMux.Handle(pat.New("/trivia"), subMux)
+	web.CPMux.Handle(pat.New("/trivia/*"), subMux)
+
+	mainGetHandler := web.RenderHandler(HandleGetSettings, "cp_trivia")
+
+	subMux.Handle(pat.Get(""), mainGetHandler)
+	subMux.Handle(pat.Get("/"), mainGetHandler)
+	subMux.Handle(pat.Post(""), web.ControllerPostHandler(HandlePostSettings, mainGetHandler, SettingsForm{}))
+	subMux.Handle(pat.Post("/"), web.ControllerPostHandler(HandlePostSettings, mainGetHandler, SettingsForm{}))
+	subMux.Handle(pat.Post("/questions/import"), web.ControllerPostHandler(HandleImportQuestions, mainGetHandler, ImportForm{}))
+	subMux.Handle(pat.Post("/questions/clear"), web.ControllerPostHandler(HandleClearQuestions, mainGetHandler, nil))
+	subMux.Handle(pat.Post("/questions/:item/delete"), web.ControllerPostHandler(HandleRemoveQuestion, mainGetHandler, nil))
+	subMux.Handle(pat.Post("/schedules"), web.ControllerPostHandler(HandleAddSchedule, mainGetHandler, ScheduleForm{}))
+	subMux.Handle(pat.Post("/schedules/:item/delete"), web.ControllerPostHandler(HandleRemoveSchedule, mainGetHandler, nil))
+	subMux.Handle(pat.Post("/reset_scores"), web.ControllerPostHandler(HandleResetScores, mainGetHandler, nil))
+}
+
+func HandleGetSettings(w http.ResponseWriter, r *http.Request) interface{} {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+
+	templateData["MaxGuildQuestions"] = MaxGuildQuestions
+	templateData["MaxSchedules"] = MaxSchedules
+	templateData["MaxRounds"] = MaxRounds
+	templateData["Difficulties"] = Difficulties
+
+	if _, ok := templateData["TriviaConfig"]; !ok {
+		conf, err := GetConfig(ctx, activeGuild.ID)
+		if web.CheckErr(templateData, err, "Failed retrieving settings", web.CtxLogger(ctx).Error) {
+			return templateData
+		}
+		templateData["TriviaConfig"] = conf
+	}
+
+	count, err := CountGuildQuestions(ctx, activeGuild.ID)
+	if web.CheckErr(templateData, err, "Failed retrieving questions", web.CtxLogger(ctx).Error) {
+		return templateData
+	}
+	templateData["QuestionCount"] = count
+
+	categories, err := GuildCategories(ctx, activeGuild.ID)
+	if web.CheckErr(templateData, err, "Failed retrieving categories", web.CtxLogger(ctx).Error) {
+		return templateData
+	}
+	templateData["Categories"] = categories
+
+	questions, err := GuildQuestions(ctx, activeGuild.ID, 100)
+	if web.CheckErr(templateData, err, "Failed retrieving questions", web.CtxLogger(ctx).Error) {
+		return templateData
+	}
+	templateData["Questions"] = questions
+
+	schedules, err := GuildSchedules(ctx, activeGuild.ID)
+	if web.CheckErr(templateData, err, "Failed retrieving scheduled trivia nights", web.CtxLogger(ctx).Error) {
+		return templateData
+	}
+	templateData["Schedules"] = schedules
+
+	return templateData
+}
+
+func HandlePostSettings(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+	templateData["VisibleURL"] = "/manage/" + discordgo.StrID(activeGuild.ID) + "/trivia"
+
+	form := ctx.Value(common.ContextKeyParsedForm).(*SettingsForm)
+
+	conf := &Config{
+		GuildID:        activeGuild.ID,
+		QuestionSource: form.QuestionSource,
+		RoundSeconds:   form.RoundSeconds,
+	}
+	templateData["TriviaConfig"] = conf
+
+	err := SaveConfig(ctx, conf)
+	if err == nil {
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(ctx, panelLogKeyUpdatedSettings))
+	}
+
+	return templateData, err
+}
+
+func HandleImportQuestions(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+	templateData["VisibleURL"] = "/manage/" + discordgo.StrID(activeGuild.ID) + "/trivia"
+
+	form := ctx.Value(common.ContextKeyParsedForm).(*ImportForm)
+
+	data := []byte(form.Questions)
+	if strings.TrimSpace(form.Questions) == "" {
+		f, _, err := r.FormFile("File")
+		if err != nil {
+			return templateData.AddAlerts(web.ErrorAlert("Paste the questions or pick a file to import")), nil
+		}
+		defer f.Close()
+
+		data, err = io.ReadAll(io.LimitReader(f, MaxImportSize+1))
+		if err != nil {
+			return templateData, err
+		}
+
+		if len(data) > MaxImportSize {
+			return templateData.AddAlerts(web.ErrorAlert("The file is too big")), nil
+		}
+	}
+
+	questions, err := ParseImport(data)
+	if err != nil {
+		return templateData.AddAlerts(web.ErrorAlert("Failed importing questions: ", err)), nil
+	}
+
+	count, err := CountGuildQuestions(ctx, activeGuild.ID)
+	if err != nil {
+		return templateData, err
+	}
+
+	if count+len(questions) > MaxGuildQuestions {
+		return templateData.AddAlerts(web.ErrorAlert(fmt.Sprintf("Max %d questions allowed, there's room for %d more", MaxGuildQuestions, MaxGuildQuestions-count))), nil
+	}
+
+	err = InsertQuestions(ctx, activeGuild.ID, questions)
+	if err == nil {
+		templateData.AddAlerts(web.SucessAlert(fmt.Sprintf("Imported %d questions", len(questions))))
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(ctx, panelLogKeyImportedQuestions, &cplogs.Param{Type: cplogs.ParamTypeInt, Value: int64(len(questions))}))
+	}
+
+	return templateData, err
+}
+
+func HandleRemoveQuestion(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+	templateData["VisibleURL"] = "/manage/" + discordgo.StrID(activeGuild.ID) + "/trivia"
+
+	id, err := strconv.ParseInt(pat.Param(r, "item"), 10, 64)
+	if err != nil {
+		return templateData.AddAlerts(web.ErrorAlert("Unknown id")), nil
+	}
+
+	err = DeleteQuestion(ctx, activeGuild.ID, id)
+	if err == nil {
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(ctx, panelLogKeyRemovedQuestion))
+	}
+
+	return templateData, err
+}
+
+func HandleClearQuestions(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+	templateData["VisibleURL"] = "/manage/" + discordgo.StrID(activeGuild.ID) + "/trivia"
+
+	err := DeleteAllQuestions(ctx, activeGuild.ID)
+	if err == nil {
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(ctx, panelLogKeyClearedQuestions))
+	}
+
+	return templateData, err
+}
+
+func HandleAddSchedule(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+	templateData["VisibleURL"] = "/manage/" + discordgo.StrID(activeGuild.ID) + "/trivia"
+
+	form := ctx.Value(common.ContextKeyParsedForm).(*ScheduleForm)
+
+	// datetime-local inputs have no timezone, the dashboard asks for UTC
+	startsAt, err := time.Parse("2006-01-02T15:04", form.StartsAt)
+	if err != nil {
+		return templateData.AddAlerts(web.ErrorAlert("Invalid start time")), nil
+	}
+
+	if startsAt.Before(time.Now()) {
+		return templateData.AddAlerts(web.ErrorAlert("The start time is in the past")), nil
+	}
+
+	difficulty := strings.ToLower(form.Difficulty)
+	if !ValidDifficulty(difficulty) {
+		return templateData.AddAlerts(web.ErrorAlert("Unknown difficulty")), nil
+	}
+
+	current, err := GuildSchedules(ctx, activeGuild.ID)
+	if err != nil {
+		return templateData, err
+	}
+
+	if len(current) >= MaxSchedules {
+		return templateData.AddAlerts(web.ErrorAlert(fmt.Sprintf("Max %d scheduled trivia nights allowed", MaxSchedules))), nil
+	}
+
+	s := &Schedule{
+		GuildID:       activeGuild.ID,
+		ChannelID:     form.Channel,
+		NextRun:       startsAt,
+		IntervalHours: form.IntervalHours,
+		Rounds:        form.Rounds,
+		Category:      strings.TrimSpace(form.Category),
+		Difficulty:    difficulty,
+	}
+
+	err = s.Insert(ctx)
+	if err != nil {
+		return templateData, err
+	}
+
+	err = ScheduleTriviaNight(s)
+	if err == nil {
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(ctx, panelLogKeyAddedSchedule))
+	}
+
+	return templateData, err
+}
+
+func HandleRemoveSchedule(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+	templateData["VisibleURL"] = "/manage/" + discordgo.StrID(activeGuild.ID) + "/trivia"
+
+	id, err := strconv.ParseInt(pat.Param(r, "item"), 10, 64)
+	if err != nil {
+		return templateData.AddAlerts(web.ErrorAlert("Unknown id")), nil
+	}
+
+	// the scheduled event does nothing once the schedule is gone
+	err = DeleteSchedule(ctx, activeGuild.ID, id)
+	if err == nil {
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(ctx, panelLogKeyRemovedSchedule))
+	}
+
+	return templateData, err
+}
+
+func HandleResetScores(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+	templateData["VisibleURL"] = "/manage/" + discordgo.StrID(activeGuild.ID) + "/trivia"
+
+	err := ResetScores(ctx, activeGuild.ID)
+	if err == nil {
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(ctx, panelLogKeyResetScores))
+	}
+
+	return templateData, err
+}
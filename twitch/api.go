@@ -0,0 +1,307 @@
+package twitch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"emperror.dev/errors"
+)
+
+const (
+	helixBaseURL = "https://api.twitch.tv/helix"
+	oauthURL     = "https://id.twitch.tv/oauth2/token"
+)
+
+var ErrNotFound = errors.New("not found")
+
+// Client is a minimal twitch helix api client using a app access token
+type Client struct {
+	clientID     string
+	clientSecret string
+	http         *http.Client
+
+	tokenLock    sync.Mutex
+	token        string
+	tokenExpires time.Time
+}
+
+func NewClient(clientID, clientSecret string) *Client {
+	return &Client{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		http: &http.Client{
+			Timeout: time.Second * 10,
+		},
+	}
+}
+
+type User struct {
+	ID              string `json:"id"`
+	Login           string `json:"login"`
+	DisplayName     string `json:"display_name"`
+	ProfileImageURL string `json:"profile_image_url"`
+}
+
+type Stream struct {
+	ID           string    `json:"id"`
+	UserID       string    `json:"user_id"`
+	UserLogin    string    `json:"user_login"`
+	UserName     string    `json:"user_name"`
+	GameName     string    `json:"game_name"`
+	Type         string    `json:"type"`
+	Title        string    `json:"title"`
+	ViewerCount  int       `json:"viewer_count"`
+	StartedAt    time.Time `json:"started_at"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+}
+
+// Thumbnail returns the thumbnail url with the size filled in
+func (s *Stream) Thumbnail(width, height int) string {
+	return strings.NewReplacer("{width}", strconv.Itoa(width), "{height}", strconv.Itoa(height)).Replace(s.ThumbnailURL)
+}
+
+type Video struct {
+	ID        string    `json:"id"`
+	StreamID  string    `json:"stream_id"`
+	Title     string    `json:"title"`
+	URL       string    `json:"url"`
+	Duration  string    `json:"duration"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type EventSubSubscription struct {
+	ID        string            `json:"id"`
+	Status    string            `json:"status"`
+	Type      string            `json:"type"`
+	Version   string            `json:"version"`
+	Condition map[string]string `json:"condition"`
+	Transport struct {
+		Method   string `json:"method"`
+		Callback string `json:"callback"`
+	} `json:"transport"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type apiError struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("twitch api error %d: %s", e.Status, e.Message)
+}
+
+func (c *Client) getToken(ctx context.Context, forceRefresh bool) (string, error) {
+	c.tokenLock.Lock()
+	defer c.tokenLock.Unlock()
+
+	if !forceRefresh && c.token != "" && time.Now().Before(c.tokenExpires) {
+		return c.token, nil
+	}
+
+	values := url.Values{
+		"client_id":     {c.clientID},
+		"client_secret": {c.clientSecret},
+		"grant_type":    {"client_credentials"},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", oauthURL+"?"+values.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 0xffff))
+		return "", fmt.Errorf("failed retrieving twitch app token: %d %s", resp.StatusCode, string(body))
+	}
+
+	var parsed struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&parsed)
+	if err != nil {
+		return "", err
+	}
+
+	c.token = parsed.AccessToken
+	// refresh a bit before it actually expires
+	c.tokenExpires = time.Now().Add(time.Duration(parsed.ExpiresIn)*time.Second - time.Minute)
+	return c.token, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body interface{}, dst interface{}) error {
+	var encodedBody []byte
+	if body != nil {
+		var err error
+		encodedBody, err = json.Marshal(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	u := helixBaseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	for i := 0; i < 2; i++ {
+		token, err := c.getToken(ctx, i > 0)
+		if err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(encodedBody))
+		if err != nil {
+			return err
+		}
+
+		req.Header.Set("Client-Id", c.clientID)
+		req.Header.Set("Authorization", "Bearer "+token)
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		resp, err := c.http.Do(req)
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode == http.StatusUnauthorized && i == 0 {
+			// token was probably revoked, get a new one and try again
+			resp.Body.Close()
+			continue
+		}
+
+		err = decodeResponse(resp, dst)
+		resp.Body.Close()
+		return err
+	}
+
+	return errors.New("unreachable")
+}
+
+func decodeResponse(resp *http.Response, dst interface{}) error {
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &apiError{Status: resp.StatusCode}
+		json.NewDecoder(io.LimitReader(resp.Body, 0xffff)).Decode(apiErr)
+		if resp.StatusCode == http.StatusNotFound {
+			return errors.WithStack(ErrNotFound)
+		}
+		return apiErr
+	}
+
+	if dst == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(dst)
+}
+
+// GetUserByLogin returns the twitch user with the login name
+func (c *Client) GetUserByLogin(ctx context.Context, login string) (*User, error) {
+	var resp struct {
+		Data []*User `json:"data"`
+	}
+
+	err := c.do(ctx, "GET", "/users", url.Values{"login": {login}}, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Data) < 1 {
+		return nil, ErrNotFound
+	}
+
+	return resp.Data[0], nil
+}
+
+// GetStream returns the current stream of the user, or ErrNotFound if they're offline
+func (c *Client) GetStream(ctx context.Context, userID string) (*Stream, error) {
+	var resp struct {
+		Data []*Stream `json:"data"`
+	}
+
+	err := c.do(ctx, "GET", "/streams", url.Values{"user_id": {userID}}, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Data) < 1 {
+		return nil, ErrNotFound
+	}
+
+	return resp.Data[0], nil
+}
+
+// GetLatestArchive returns the latest vod of the user, or ErrNotFound if there is none
+func (c *Client) GetLatestArchive(ctx context.Context, userID string) (*Video, error) {
+	var resp struct {
+		Data []*Video `json:"data"`
+	}
+
+	err := c.do(ctx, "GET", "/videos", url.Values{"user_id": {userID}, "type": {"archive"}, "first": {"1"}}, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Data) < 1 {
+		return nil, ErrNotFound
+	}
+
+	return resp.Data[0], nil
+}
+
+// CreateEventSubSubscription creates a webhook eventsub subscription for the event type on the broadcaster
+func (c *Client) CreateEventSubSubscription(ctx context.Context, eventType, broadcasterID, callback, secret string) (*EventSubSubscription, error) {
+	body := map[string]interface{}{
+		"type":      eventType,
+		"version":   "1",
+		"condition": map[string]string{"broadcaster_user_id": broadcasterID},
+		"transport": map[string]string{
+			"method":   "webhook",
+			"callback": callback,
+			"secret":   secret,
+		},
+	}
+
+	var resp struct {
+		Data []*EventSubSubscription `json:"data"`
+	}
+
+	err := c.do(ctx, "POST", "/eventsub/subscriptions", nil, body, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Data) < 1 {
+		return nil, errors.New("empty eventsub subscription response")
+	}
+
+	return resp.Data[0], nil
+}
+
+// DeleteEventSubSubscription removes a eventsub subscription
+func (c *Client) DeleteEventSubSubscription(ctx context.Context, id string) error {
+	err := c.do(ctx, "DELETE", "/eventsub/subscriptions", url.Values{"id": {id}}, nil, nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,90 @@
+package twitch
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+)
+
+var _ common.PluginWithBackup = (*Plugin)(nil)
+
+func (p *Plugin) BackupVersion() int {
+	return 1
+}
+
+func (p *Plugin) ExportBackup(ctx context.Context, guildID int64) (interface{}, error) {
+	return GuildSubscriptions(ctx, guildID)
+}
+
+func (p *Plugin) RestoreBackup(ctx context.Context, guildID int64, data json.RawMessage, restore *common.BackupRestore) error {
+	var subs []*Subscription
+	err := json.Unmarshal(data, &subs)
+	if err != nil {
+		return err
+	}
+
+	maxFeeds := MaxFeedsForCtx(ctx)
+	if len(subs) > maxFeeds {
+		restore.Note("Only the first %d of %d feeds were restored", maxFeeds, len(subs))
+		subs = subs[:maxFeeds]
+	}
+
+	oldSubs, err := GuildSubscriptions(ctx, guildID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := common.PQ.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM twitch_channel_subscriptions WHERE guild_id = $1`, guildID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for _, v := range subs {
+		v.ID = 0
+		v.GuildID = guildID
+		v.ChannelID = restore.Channel(v.ChannelID)
+		v.MentionRoles = restore.Roles(v.MentionRoles)
+
+		if v.ChannelID == 0 && v.Enabled {
+			restore.Note("The channel of the %s feed was not found, it was disabled", v.TwitchDisplayName)
+			v.Enabled = false
+		}
+
+		err = v.InsertExec(ctx, tx)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	err = restore.Finish(tx)
+	if err != nil || restore.DryRun {
+		return err
+	}
+
+	for _, v := range subs {
+		err = p.ensureEventSubs(ctx, v.TwitchUserID)
+		if err != nil {
+			restore.Note("Failed subscribing to %s on twitch, re-add the feed later", v.TwitchDisplayName)
+			logger.WithError(err).WithField("twitch_user", v.TwitchUserID).Error("failed creating twitch eventsub subscriptions")
+		}
+	}
+
+	go func() {
+		for _, v := range oldSubs {
+			err := p.maybeRemoveEventSubs(context.Background(), v.TwitchUserID)
+			if err != nil {
+				logger.WithError(err).WithField("twitch_user", v.TwitchUserID).Error("failed removing twitch eventsub subscriptions")
+			}
+		}
+	}()
+
+	return nil
+}
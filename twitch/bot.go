@@ -0,0 +1,46 @@
+package twitch
+
+import (
+	"context"
+	"fmt"
+
+	"emperror.dev/errors"
+	"github.com/botlabs-gg/yagpdb/v2/bot"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+)
+
+var _ bot.RemoveGuildHandler = (*Plugin)(nil)
+
+func (p *Plugin) RemoveGuild(g int64) error {
+	err := DisableGuildSubscriptions(context.Background(), g)
+	if err != nil {
+		return errors.WrapIf(err, "failed removing twitch feeds")
+	}
+
+	return nil
+}
+
+func (p *Plugin) Status() (string, string) {
+	var unique, total int
+	err := common.PQ.QueryRow(`SELECT count(DISTINCT twitch_user_id), count(*) FROM twitch_channel_subscriptions WHERE enabled = true`).Scan(&unique, &total)
+	if err != nil {
+		logger.WithError(err).Error("failed counting twitch feeds")
+	}
+
+	return "Unique/Total", fmt.Sprintf("%d/%d", unique, total)
+}
+
+func (p *Plugin) OnRemovedPremiumGuild(guildID int64) error {
+	logger.WithField("guild_id", guildID).Infof("Removed Excess Twitch Feeds")
+
+	const q = `UPDATE twitch_channel_subscriptions SET enabled = false WHERE id IN (
+	SELECT id FROM twitch_channel_subscriptions WHERE guild_id = $1 AND enabled = true ORDER BY id ASC OFFSET $2
+)`
+
+	_, err := common.PQ.Exec(q, guildID, GuildMaxFeedsNormal)
+	if err != nil {
+		return errors.WrapIf(err, "failed disabling twitch feeds on premium removal")
+	}
+
+	return nil
+}
@@ -0,0 +1,472 @@
+package twitch
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/botlabs-gg/yagpdb/v2/analytics"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/mqueue"
+	"github.com/botlabs-gg/yagpdb/v2/common/templates"
+	"github.com/botlabs-gg/yagpdb/v2/feeds"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+	"github.com/botlabs-gg/yagpdb/v2/web"
+	"github.com/botlabs-gg/yagpdb/v2/web/discorddata"
+	"github.com/mediocregopher/radix/v3"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	EventStreamOnline  = "stream.online"
+	EventStreamOffline = "stream.offline"
+
+	// Notifications older than this are rejected to prevent replay attacks
+	MaxNotificationAge = time.Minute * 10
+
+	headerMessageID        = "Twitch-Eventsub-Message-Id"
+	headerMessageTimestamp = "Twitch-Eventsub-Message-Timestamp"
+	headerMessageSignature = "Twitch-Eventsub-Message-Signature"
+	headerMessageType      = "Twitch-Eventsub-Message-Type"
+
+	messageTypeVerification = "webhook_callback_verification"
+	messageTypeNotification = "notification"
+	messageTypeRevocation   = "revocation"
+)
+
+var eventTypes = []string{EventStreamOnline, EventStreamOffline}
+
+func KeyHandledMessage(id string) string { return "twitch_eventsub_handled:" + id }
+
+func eventSubCallbackURL() string {
+	return "https://" + common.ConfHost.GetString() + "/twitch/eventsub"
+}
+
+// verifySignature checks the hmac signature twitch sends along with every eventsub message
+func verifySignature(secret, messageID, timestamp string, body []byte, signature string) bool {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(messageID))
+	mac.Write([]byte(timestamp))
+	mac.Write(body)
+
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+type eventSubMessage struct {
+	Challenge    string               `json:"challenge"`
+	Subscription EventSubSubscription `json:"subscription"`
+	Event        json.RawMessage      `json:"event"`
+}
+
+type streamEvent struct {
+	ID                   string    `json:"id"`
+	BroadcasterUserID    string    `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string    `json:"broadcaster_user_login"`
+	BroadcasterUserName  string    `json:"broadcaster_user_name"`
+	Type                 string    `json:"type"`
+	StartedAt            time.Time `json:"started_at"`
+}
+
+func (p *Plugin) HandleEventSub(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	defer r.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(r.Body, 0xffff))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	messageID := r.Header.Get(headerMessageID)
+	timestamp := r.Header.Get(headerMessageTimestamp)
+	if !verifySignature(confEventSubSecret.GetString(), messageID, timestamp, body, r.Header.Get(headerMessageSignature)) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	parsedTS, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil || time.Since(parsedTS) > MaxNotificationAge {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// twitch may deliver the same message multiple times
+	var resp string
+	err = common.RedisPool.Do(radix.FlatCmd(&resp, "SET", KeyHandledMessage(messageID), true, "EX", int(MaxNotificationAge.Seconds()), "NX"))
+	if err != nil {
+		web.CtxLogger(ctx).WithError(err).Error("failed checking twitch eventsub message id")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	isNew := resp == "OK"
+
+	var msg eventSubMessage
+	err = json.Unmarshal(body, &msg)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	switch r.Header.Get(headerMessageType) {
+	case messageTypeVerification:
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(msg.Challenge))
+		return
+	case messageTypeRevocation:
+		if isNew {
+			go p.handleRevocation(&msg.Subscription)
+		}
+	case messageTypeNotification:
+		if isNew {
+			go p.handleNotification(msg.Subscription.Type, msg.Event)
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (p *Plugin) handleRevocation(sub *EventSubSubscription) {
+	userID := sub.Condition["broadcaster_user_id"]
+	logger.WithField("twitch_user", userID).WithField("status", sub.Status).Info("twitch eventsub subscription revoked")
+
+	err := removeEventSubID(context.Background(), sub.ID)
+	if err != nil {
+		logger.WithError(err).Error("failed removing revoked eventsub subscription")
+	}
+
+	if sub.Status == "user_removed" {
+		err = DisableUserSubscriptions(context.Background(), userID)
+		if err != nil {
+			logger.WithError(err).Error("failed disabling feeds for removed twitch user")
+		}
+	}
+}
+
+func (p *Plugin) handleNotification(eventType string, rawEvent json.RawMessage) {
+	var evt streamEvent
+	err := json.Unmarshal(rawEvent, &evt)
+	if err != nil {
+		logger.WithError(err).Error("failed decoding twitch stream event")
+		return
+	}
+
+	switch eventType {
+	case EventStreamOnline:
+		err = p.handleStreamOnline(&evt)
+	case EventStreamOffline:
+		err = p.handleStreamOffline(&evt)
+	}
+
+	if err != nil {
+		logger.WithError(err).WithField("twitch_user", evt.BroadcasterUserID).Errorf("failed handling %s", eventType)
+	}
+}
+
+func (p *Plugin) handleStreamOnline(evt *streamEvent) error {
+	if evt.Type != "" && evt.Type != "live" {
+		// reruns and premieres
+		return nil
+	}
+
+	ctx := context.Background()
+	subs, err := EnabledSubscriptionsForUser(ctx, evt.BroadcasterUserID)
+	if err != nil || len(subs) < 1 {
+		return err
+	}
+
+	stream, err := p.api.GetStream(ctx, evt.BroadcasterUserID)
+	if err != nil || stream.ID != evt.ID {
+		// the stream info may not be available yet, we still have enough to make a announcement
+		logger.WithError(err).WithField("twitch_user", evt.BroadcasterUserID).Debug("failed retrieving stream info")
+		stream = &Stream{
+			ID:        evt.ID,
+			UserID:    evt.BroadcasterUserID,
+			UserLogin: evt.BroadcasterUserLogin,
+			UserName:  evt.BroadcasterUserName,
+			StartedAt: evt.StartedAt,
+		}
+	}
+
+	for _, sub := range subs {
+		isNew, err := startAnnouncement(ctx, sub.ID, evt.ID, sub.ChannelID, evt.StartedAt)
+		if err != nil {
+			logger.WithError(err).WithField("sub_id", sub.ID).Error("failed recording twitch announcement")
+			continue
+		}
+
+		if isNew {
+			p.sendAnnouncement(sub, stream)
+		}
+	}
+
+	return nil
+}
+
+func streamURL(login string) string {
+	return "https://twitch.tv/" + login
+}
+
+func (p *Plugin) sendAnnouncement(sub *Subscription, stream *Stream) {
+	qm := &mqueue.QueuedElement{
+		GuildID:      sub.GuildID,
+		ChannelID:    sub.ChannelID,
+		Source:       "twitch",
+		SourceItemID: sourceItemID(sub.ID, stream.ID),
+		MessageEmbed: onlineEmbed(sub, stream),
+		Priority:     3,
+		TrackMessage: sub.EditOnOffline,
+	}
+
+	if sub.MessageTemplate != "" {
+		guildState, err := discorddata.GetFullGuild(sub.GuildID)
+		if err != nil {
+			logger.WithError(err).WithField("guild", sub.GuildID).Error("failed retrieving guild state for twitch feed")
+			return
+		}
+
+		if guildState == nil {
+			logger.WithField("guild", sub.GuildID).Info("guild not found in state for twitch feed, disabling feeds")
+			DisableGuildSubscriptions(context.Background(), sub.GuildID)
+			return
+		}
+
+		channelState := guildState.GetChannel(sub.ChannelID)
+		if channelState == nil {
+			logger.WithField("guild", sub.GuildID).WithField("channel", sub.ChannelID).Info("channel not found in state for twitch feed, disabling feed")
+			DisableSubscription(context.Background(), sub.ID)
+			return
+		}
+
+		ctx := templates.NewContext(guildState, channelState, nil)
+		ctx.Data["URL"] = streamURL(sub.TwitchLogin)
+		ctx.Data["TwitchLogin"] = sub.TwitchLogin
+		ctx.Data["TwitchName"] = sub.TwitchDisplayName
+		ctx.Data["StreamTitle"] = stream.Title
+		ctx.Data["GameName"] = stream.GameName
+		ctx.Data["Thumbnail"] = stream.Thumbnail(1280, 720)
+		ctx.Data["StartedAt"] = stream.StartedAt
+		ctx.Data["Stream"] = stream
+
+		content, err := ctx.Execute(sub.MessageTemplate)
+		if err != nil {
+			logger.WithError(err).WithField("guild", sub.GuildID).Warn("twitch announcement template execution failed")
+			return
+		}
+
+		if strings.TrimSpace(content) == "" {
+			return
+		}
+
+		qm.MessageStr = content
+		qm.PublishAnnouncement = ctx.CurrentFrame.PublishResponse
+		qm.AllowedMentions = discordgo.AllowedMentions{
+			Parse: []discordgo.AllowedMentionType{discordgo.AllowedMentionTypeRoles, discordgo.AllowedMentionTypeEveryone},
+		}
+	} else {
+		content := fmt.Sprintf("**%s** is now live on twitch!\n%s", sub.TwitchDisplayName, streamURL(sub.TwitchLogin))
+		if len(sub.MentionRoles) > 0 {
+			mentions := "Hey"
+			for _, v := range sub.MentionRoles {
+				mentions += fmt.Sprintf(" <@&%d>", v)
+			}
+			content = mentions + " " + content
+		}
+
+		qm.MessageStr = content
+		qm.AllowedMentions = discordgo.AllowedMentions{
+			Roles: discordgo.IDSlice(sub.MentionRoles),
+		}
+	}
+
+	go analytics.RecordActiveUnit(sub.GuildID, p, "posted_twitch_message")
+	feeds.MetricPostedMessages.With(prometheus.Labels{"source": "twitch"}).Inc()
+	mqueue.QueueMessage(qm)
+}
+
+func onlineEmbed(sub *Subscription, stream *Stream) *discordgo.MessageEmbed {
+	title := stream.Title
+	if title == "" {
+		title = sub.TwitchDisplayName + " is live"
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title: common.CutStringShort(title, 250),
+		URL:   streamURL(sub.TwitchLogin),
+		Color: 0x9146ff,
+		Author: &discordgo.MessageEmbedAuthor{
+			Name: sub.TwitchDisplayName,
+			URL:  streamURL(sub.TwitchLogin),
+		},
+	}
+
+	if stream.GameName != "" {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: "Playing", Value: stream.GameName, Inline: true})
+	}
+
+	if stream.ThumbnailURL != "" {
+		// bust discord's image cache, the thumbnail url is the same for every stream
+		embed.Image = &discordgo.MessageEmbedImage{URL: stream.Thumbnail(1280, 720) + "?t=" + fmt.Sprint(time.Now().Unix())}
+	}
+
+	if !stream.StartedAt.IsZero() {
+		embed.Timestamp = stream.StartedAt.Format(time.RFC3339)
+	}
+
+	return embed
+}
+
+func offlineEmbed(sub *Subscription, ann *Announcement, vod *Video) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title: sub.TwitchDisplayName + " was live",
+		URL:   streamURL(sub.TwitchLogin),
+		Color: 0x6e6e6e,
+		Author: &discordgo.MessageEmbedAuthor{
+			Name: sub.TwitchDisplayName,
+			URL:  streamURL(sub.TwitchLogin),
+		},
+	}
+
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "Streamed for",
+		Value:  common.HumanizeDuration(common.DurationPrecisionMinutes, time.Since(ann.StartedAt)),
+		Inline: true,
+	})
+
+	if vod != nil {
+		embed.Title = common.CutStringShort(vod.Title, 250)
+		embed.URL = vod.URL
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "VOD",
+			Value:  fmt.Sprintf("[Watch the VOD](%s) (%s)", vod.URL, vod.Duration),
+			Inline: true,
+		})
+	}
+
+	return embed
+}
+
+func (p *Plugin) handleStreamOffline(evt *streamEvent) error {
+	ctx := context.Background()
+
+	anns, err := endAnnouncements(ctx, evt.BroadcasterUserID)
+	if err != nil || len(anns) < 1 {
+		return err
+	}
+
+	subs, err := EnabledSubscriptionsForUser(ctx, evt.BroadcasterUserID)
+	if err != nil {
+		return err
+	}
+
+	subsByID := make(map[int64]*Subscription)
+	for _, v := range subs {
+		subsByID[v.ID] = v
+	}
+
+	var vod *Video
+	vodFetched := false
+
+	for _, ann := range anns {
+		sub, ok := subsByID[ann.SubscriptionID]
+		if !ok || !sub.EditOnOffline || ann.MessageID == 0 || ann.ChannelID != sub.ChannelID {
+			continue
+		}
+
+		if !vodFetched {
+			vodFetched = true
+			vod, err = p.api.GetLatestArchive(ctx, evt.BroadcasterUserID)
+			if err != nil && !errors.Is(err, ErrNotFound) {
+				logger.WithError(err).WithField("twitch_user", evt.BroadcasterUserID).Error("failed retrieving twitch vod")
+			}
+
+			if vod != nil && vod.StreamID != "" && vod.StreamID != ann.StreamID {
+				// the latest vod is from another stream, vods may be disabled
+				vod = nil
+			}
+		}
+
+		// the announcement was sent with TrackMessage set, so the queue knows which message to edit
+		err = mqueue.QueueEdit(&mqueue.QueuedElement{
+			GuildID:      sub.GuildID,
+			ChannelID:    ann.ChannelID,
+			Source:       "twitch",
+			SourceItemID: sourceItemID(sub.ID, ann.StreamID),
+			MessageEmbed: offlineEmbed(sub, ann, vod),
+			Priority:     3,
+		})
+		if err != nil {
+			logger.WithError(err).WithField("sub_id", sub.ID).Error("failed queueing twitch announcement edit")
+		}
+	}
+
+	return nil
+}
+
+// ensureEventSubs makes sure we're subscribed to the stream events of the twitch user
+func (p *Plugin) ensureEventSubs(ctx context.Context, twitchUserID string) error {
+	existing, err := eventSubIDs(ctx, twitchUserID)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range eventTypes {
+		if _, ok := existing[t]; ok {
+			continue
+		}
+
+		sub, err := p.api.CreateEventSubSubscription(ctx, t, twitchUserID, eventSubCallbackURL(), confEventSubSecret.GetString())
+		if err != nil {
+			var apiErr *apiError
+			if errors.As(err, &apiErr) && apiErr.Status == http.StatusConflict {
+				// already subscribed, but we lost track of it somehow
+				continue
+			}
+
+			return err
+		}
+
+		err = setEventSubID(ctx, twitchUserID, t, sub.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// maybeRemoveEventSubs removes the eventsub subscriptions for the twitch user if no feeds follow them anymore
+func (p *Plugin) maybeRemoveEventSubs(ctx context.Context, twitchUserID string) error {
+	count, err := CountSubscriptionsForUser(ctx, twitchUserID)
+	if err != nil || count > 0 {
+		return err
+	}
+
+	existing, err := eventSubIDs(ctx, twitchUserID)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range existing {
+		err = p.api.DeleteEventSubSubscription(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		err = removeEventSubID(ctx, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package twitch
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestVerifySignature(t *testing.T) {
+	const (
+		secret    = "s3cre77890ab"
+		messageID = "e76c6bd4-55c9-4987-8304-da1588d8988b"
+		timestamp = "2019-11-16T10:11:12.634234626Z"
+		body      = `{"subscription":{"type":"stream.online"}}`
+	)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(messageID + timestamp + body))
+	valid := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	if !verifySignature(secret, messageID, timestamp, []byte(body), valid) {
+		t.Error("valid signature rejected")
+	}
+
+	cases := []struct {
+		name      string
+		secret    string
+		body      string
+		signature string
+	}{
+		{"wrong secret", "other", body, valid},
+		{"tampered body", secret, body + " ", valid},
+		{"bogus signature", secret, body, "sha256=" + strings.Repeat("0", 64)},
+		{"missing prefix", secret, body, valid[len("sha256="):]},
+		{"empty", secret, body, ""},
+	}
+
+	for _, c := range cases {
+		if verifySignature(c.secret, messageID, timestamp, []byte(c.body), c.signature) {
+			t.Errorf("%s: signature should be rejected", c.name)
+		}
+	}
+}
+
+func TestParseSourceItemID(t *testing.T) {
+	subID, streamID, ok := parseSourceItemID(sourceItemID(15, "40078987165"))
+	if !ok || subID != 15 || streamID != "40078987165" {
+		t.Errorf("unexpected result: %d %q %v", subID, streamID, ok)
+	}
+
+	for _, v := range []string{"", "15", "15:", "abc:123"} {
+		if _, _, ok := parseSourceItemID(v); ok {
+			t.Errorf("%q should not parse", v)
+		}
+	}
+}
+
+func TestParseTwitchLogin(t *testing.T) {
+	cases := map[string]string{
+		"botlabs":                           "botlabs",
+		" BotLabs ":                         "botlabs",
+		"https://twitch.tv/botlabs":         "botlabs",
+		"https://www.twitch.tv/BotLabs/":    "botlabs",
+		"twitch.tv/botlabs?ref=abc":         "botlabs",
+		"https://m.twitch.tv/botlabs/about": "botlabs",
+	}
+
+	for in, expected := range cases {
+		if got := parseTwitchLogin(in); got != expected {
+			t.Errorf("%q: got %q, expected %q", in, got, expected)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package twitch
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/lib/pq"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// Subscription is a twitch channel being announced in a discord channel
+type Subscription struct {
+	ID        int64
+	GuildID   int64
+	ChannelID int64
+	CreatedAt time.Time
+
+	TwitchUserID      string
+	TwitchLogin       string
+	TwitchDisplayName string
+
+	MentionRoles    pq.Int64Array
+	MessageTemplate string
+	EditOnOffline   bool
+	Enabled         bool
+}
+
+const subscriptionColumns = `id, guild_id, channel_id, created_at, twitch_user_id, twitch_login, twitch_display_name, mention_roles, message_template, edit_on_offline, enabled`
+
+func scanSubscriptions(rows *sql.Rows) ([]*Subscription, error) {
+	defer rows.Close()
+
+	result := make([]*Subscription, 0)
+	for rows.Next() {
+		s := &Subscription{}
+		err := rows.Scan(&s.ID, &s.GuildID, &s.ChannelID, &s.CreatedAt, &s.TwitchUserID, &s.TwitchLogin, &s.TwitchDisplayName,
+			&s.MentionRoles, &s.MessageTemplate, &s.EditOnOffline, &s.Enabled)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, s)
+	}
+
+	return result, rows.Err()
+}
+
+// GuildSubscriptions returns all the twitch feeds in a guild
+func GuildSubscriptions(ctx context.Context, guildID int64) ([]*Subscription, error) {
+	rows, err := common.PQ.QueryContext(ctx, `SELECT `+subscriptionColumns+` FROM twitch_channel_subscriptions WHERE guild_id = $1 ORDER BY id ASC`, guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanSubscriptions(rows)
+}
+
+// EnabledSubscriptionsForUser returns all enabled feeds for the twitch user
+func EnabledSubscriptionsForUser(ctx context.Context, twitchUserID string) ([]*Subscription, error) {
+	rows, err := common.PQ.QueryContext(ctx, `SELECT `+subscriptionColumns+` FROM twitch_channel_subscriptions WHERE twitch_user_id = $1 AND enabled = true`, twitchUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanSubscriptions(rows)
+}
+
+// CountSubscriptionsForUser returns the number of feeds following the twitch user, across all guilds
+func CountSubscriptionsForUser(ctx context.Context, twitchUserID string) (int, error) {
+	var count int
+	err := common.PQ.QueryRowContext(ctx, `SELECT count(*) FROM twitch_channel_subscriptions WHERE twitch_user_id = $1`, twitchUserID).Scan(&count)
+	return count, err
+}
+
+func (s *Subscription) Insert(ctx context.Context) error {
+	return s.InsertExec(ctx, common.PQ)
+}
+
+// InsertExec is the same as Insert but runs the query using exec, such as a transaction
+func (s *Subscription) InsertExec(ctx context.Context, exec boil.ContextExecutor) error {
+	if s.CreatedAt.IsZero() {
+		s.CreatedAt = time.Now()
+	}
+
+	const q = `INSERT INTO twitch_channel_subscriptions (guild_id, channel_id, created_at, twitch_user_id, twitch_login, twitch_display_name, mention_roles, message_template, edit_on_offline, enabled)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING id`
+
+	return exec.QueryRowContext(ctx, q, s.GuildID, s.ChannelID, s.CreatedAt, s.TwitchUserID, s.TwitchLogin, s.TwitchDisplayName,
+		s.MentionRoles, s.MessageTemplate, s.EditOnOffline, s.Enabled).Scan(&s.ID)
+}
+
+func (s *Subscription) Update(ctx context.Context) error {
+	const q = `UPDATE twitch_channel_subscriptions SET channel_id = $3, mention_roles = $4, message_template = $5, edit_on_offline = $6, enabled = $7
+WHERE id = $1 AND guild_id = $2`
+
+	_, err := common.PQ.ExecContext(ctx, q, s.ID, s.GuildID, s.ChannelID, s.MentionRoles, s.MessageTemplate, s.EditOnOffline, s.Enabled)
+	return err
+}
+
+func (s *Subscription) Delete(ctx context.Context) error {
+	_, err := common.PQ.ExecContext(ctx, `DELETE FROM twitch_channel_subscriptions WHERE id = $1 AND guild_id = $2`, s.ID, s.GuildID)
+	return err
+}
+
+// DisableSubscription disables a single feed
+func DisableSubscription(ctx context.Context, id int64) error {
+	_, err := common.PQ.ExecContext(ctx, `UPDATE twitch_channel_subscriptions SET enabled = false WHERE id = $1`, id)
+	return err
+}
+
+// DisableGuildSubscriptions disables all the feeds in a guild
+func DisableGuildSubscriptions(ctx context.Context, guildID int64) error {
+	_, err := common.PQ.ExecContext(ctx, `UPDATE twitch_channel_subscriptions SET enabled = false WHERE guild_id = $1`, guildID)
+	return err
+}
+
+// DisableUserSubscriptions disables all feeds for the twitch user, used when twitch revokes our eventsub subscription
+func DisableUserSubscriptions(ctx context.Context, twitchUserID string) error {
+	_, err := common.PQ.ExecContext(ctx, `UPDATE twitch_channel_subscriptions SET enabled = false WHERE twitch_user_id = $1`, twitchUserID)
+	return err
+}
+
+// eventSubIDs returns the eventsub subscription ids for the twitch user, keyed by event type
+func eventSubIDs(ctx context.Context, twitchUserID string) (map[string]string, error) {
+	rows, err := common.PQ.QueryContext(ctx, `SELECT event_type, eventsub_id FROM twitch_eventsub_subscriptions WHERE twitch_user_id = $1`, twitchUserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[string]string)
+	for rows.Next() {
+		var eventType, id string
+		err = rows.Scan(&eventType, &id)
+		if err != nil {
+			return nil, err
+		}
+
+		result[eventType] = id
+	}
+
+	return result, rows.Err()
+}
+
+func setEventSubID(ctx context.Context, twitchUserID, eventType, id string) error {
+	const q = `INSERT INTO twitch_eventsub_subscriptions (twitch_user_id, event_type, eventsub_id, created_at)
+VALUES ($1, $2, $3, now())
+ON CONFLICT (twitch_user_id, event_type) DO UPDATE SET eventsub_id = $3, created_at = now()`
+
+	_, err := common.PQ.ExecContext(ctx, q, twitchUserID, eventType, id)
+	return err
+}
+
+func removeEventSubID(ctx context.Context, id string) error {
+	_, err := common.PQ.ExecContext(ctx, `DELETE FROM twitch_eventsub_subscriptions WHERE eventsub_id = $1`, id)
+	return err
+}
+
+// Announcement is the latest stream announcement made by a subscription
+type Announcement struct {
+	SubscriptionID int64
+	StreamID       string
+	ChannelID      int64
+	MessageID      int64
+	StartedAt      time.Time
+	Ended          bool
+}
+
+// startAnnouncement records a new stream for the subscription, returns false if it was already announced
+func startAnnouncement(ctx context.Context, subID int64, streamID string, channelID int64, startedAt time.Time) (bool, error) {
+	const q = `INSERT INTO twitch_stream_announcements (subscription_id, stream_id, channel_id, message_id, started_at, ended)
+VALUES ($1, $2, $3, 0, $4, false)
+ON CONFLICT (subscription_id) DO UPDATE SET stream_id = $2, channel_id = $3, message_id = 0, started_at = $4, ended = false
+WHERE twitch_stream_announcements.stream_id != $2`
+
+	res, err := common.PQ.ExecContext(ctx, q, subID, streamID, channelID, startedAt)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func setAnnouncementMessage(ctx context.Context, subID int64, streamID string, messageID int64) error {
+	_, err := common.PQ.ExecContext(ctx, `UPDATE twitch_stream_announcements SET message_id = $3 WHERE subscription_id = $1 AND stream_id = $2`, subID, streamID, messageID)
+	return err
+}
+
+// endAnnouncements marks the ongoing announcements for the twitch user as ended and returns them
+func endAnnouncements(ctx context.Context, twitchUserID string) ([]*Announcement, error) {
+	const q = `UPDATE twitch_stream_announcements a SET ended = true
+FROM twitch_channel_subscriptions s
+WHERE a.subscription_id = s.id AND s.twitch_user_id = $1 AND a.ended = false
+RETURNING a.subscription_id, a.stream_id, a.channel_id, a.message_id, a.started_at, a.ended`
+
+	rows, err := common.PQ.QueryContext(ctx, q, twitchUserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*Announcement
+	for rows.Next() {
+		a := &Announcement{}
+		err = rows.Scan(&a.SubscriptionID, &a.StreamID, &a.ChannelID, &a.MessageID, &a.StartedAt, &a.Ended)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, a)
+	}
+
+	return result, rows.Err()
+}
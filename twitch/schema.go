@@ -0,0 +1,41 @@
+package twitch
+
+var DBSchemas = []string{`
+CREATE TABLE IF NOT EXISTS twitch_channel_subscriptions (
+	id BIGSERIAL PRIMARY KEY,
+	guild_id BIGINT NOT NULL,
+	channel_id BIGINT NOT NULL,
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	twitch_user_id TEXT NOT NULL,
+	twitch_login TEXT NOT NULL,
+	twitch_display_name TEXT NOT NULL,
+
+	mention_roles BIGINT[],
+	message_template TEXT NOT NULL DEFAULT '',
+	edit_on_offline BOOLEAN NOT NULL DEFAULT true,
+	enabled BOOLEAN NOT NULL
+);
+`, `
+CREATE INDEX IF NOT EXISTS twitch_channel_subscriptions_guild_id_idx ON twitch_channel_subscriptions(guild_id);
+`, `
+CREATE INDEX IF NOT EXISTS twitch_channel_subscriptions_twitch_user_id_idx ON twitch_channel_subscriptions(twitch_user_id);
+`, `
+CREATE TABLE IF NOT EXISTS twitch_eventsub_subscriptions (
+	twitch_user_id TEXT NOT NULL,
+	event_type TEXT NOT NULL,
+	eventsub_id TEXT NOT NULL,
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	PRIMARY KEY(twitch_user_id, event_type)
+);
+`, `
+CREATE TABLE IF NOT EXISTS twitch_stream_announcements (
+	subscription_id BIGINT PRIMARY KEY REFERENCES twitch_channel_subscriptions(id) ON DELETE CASCADE,
+	stream_id TEXT NOT NULL,
+	channel_id BIGINT NOT NULL,
+	message_id BIGINT NOT NULL DEFAULT 0,
+	started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+	ended BOOLEAN NOT NULL DEFAULT false
+);
+`}
@@ -0,0 +1,116 @@
+package twitch
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/config"
+	"github.com/botlabs-gg/yagpdb/v2/common/mqueue"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+	"github.com/botlabs-gg/yagpdb/v2/premium"
+)
+
+var (
+	confClientID       = config.RegisterOption("yagpdb.twitch.client_id", "Twitch application client id", "")
+	confClientSecret   = config.RegisterOption("yagpdb.twitch.client_secret", "Twitch application client secret", "")
+	confEventSubSecret = config.RegisterOption("yagpdb.twitch.eventsub_secret", "Secret used to sign twitch eventsub notifications, set it to a random string (10-100 characters) and never change it", "")
+
+	logger = common.GetPluginLogger(&Plugin{})
+)
+
+const (
+	// Max feeds per guild
+	GuildMaxFeedsNormal  = 10
+	GuildMaxFeedsPremium = 100
+)
+
+type Plugin struct {
+	api *Client
+}
+
+func (p *Plugin) PluginInfo() *common.PluginInfo {
+	return &common.PluginInfo{
+		Name:     "Twitch",
+		SysName:  "twitch",
+		Category: common.PluginCategoryFeeds,
+	}
+}
+
+func RegisterPlugin() {
+	if confClientID.GetString() == "" || confClientSecret.GetString() == "" || confEventSubSecret.GetString() == "" {
+		logger.Warn("Missing twitch config, not enabling plugin")
+		return
+	}
+
+	common.InitSchemas("twitch", DBSchemas...)
+
+	p := &Plugin{
+		api: NewClient(confClientID.GetString(), confClientSecret.GetString()),
+	}
+
+	common.RegisterPlugin(p)
+	mqueue.RegisterSource("twitch", p)
+}
+
+// the source item id of queued messages is "subscriptionID:streamID"
+func sourceItemID(subID int64, streamID string) string {
+	return strconv.FormatInt(subID, 10) + ":" + streamID
+}
+
+func parseSourceItemID(id string) (subID int64, streamID string, ok bool) {
+	split := strings.SplitN(id, ":", 2)
+	if len(split) != 2 || split[1] == "" {
+		return 0, "", false
+	}
+
+	subID, err := strconv.ParseInt(split[0], 10, 64)
+	if err != nil {
+		return 0, "", false
+	}
+
+	return subID, split[1], true
+}
+
+var _ mqueue.PluginWithSourceDisabler = (*Plugin)(nil)
+
+// Disable feeds if they don't point to a proper channel
+func (p *Plugin) DisableFeed(elem *mqueue.QueuedElement, err error) {
+	subID, _, ok := parseSourceItemID(elem.SourceItemID)
+	if !ok {
+		logger.WithField("source_id", elem.SourceItemID).Error("failed parsing sourceID")
+		return
+	}
+
+	err = DisableSubscription(context.Background(), subID)
+	if err != nil {
+		logger.WithError(err).WithField("sub_id", subID).Error("failed disabling twitch feed")
+		return
+	}
+
+	logger.WithField("sub_id", subID).WithField("channel", elem.ChannelID).Info("Disabled twitch feed to non-existant channel")
+}
+
+var _ mqueue.PluginWithMessageSentCallback = (*Plugin)(nil)
+
+// MessageSent records that the announcement was sent, so that only announcements that made it to discord are edited once the stream goes offline
+func (p *Plugin) MessageSent(elem *mqueue.QueuedElement, msg *discordgo.Message) {
+	subID, streamID, ok := parseSourceItemID(elem.SourceItemID)
+	if !ok {
+		return
+	}
+
+	err := setAnnouncementMessage(context.Background(), subID, streamID, msg.ID)
+	if err != nil {
+		logger.WithError(err).WithField("sub_id", subID).Error("failed storing twitch announcement message")
+	}
+}
+
+func MaxFeedsForCtx(ctx context.Context) int {
+	if premium.ContextPremium(ctx) {
+		return GuildMaxFeedsPremium
+	}
+
+	return GuildMaxFeedsNormal
+}
@@ -0,0 +1,316 @@
+package twitch
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/cplogs"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+	"github.com/botlabs-gg/yagpdb/v2/web"
+	"goji.io"
+	"goji.io/pat"
+)
+
+//go:embed assets/twitch.html
+var PageHTML string
+
+type CtxKey int
+
+const (
+	CurrentConfig CtxKey = iota
+)
+
+type CreateForm struct {
+	TwitchChannel   string  `schema:"twitch_channel" valid:",1,100"`
+	Channel         int64   `schema:"channel" valid:"channel,false"`
+	MentionRoles    []int64 `schema:"mention_roles" valid:"role,true"`
+	MessageTemplate string  `schema:"message_template" valid:"template,5000"`
+	EditOnOffline   bool    `schema:"edit_on_offline"`
+}
+
+type UpdateForm struct {
+	Channel         int64   `schema:"channel" valid:"channel,false"`
+	MentionRoles    []int64 `schema:"mention_roles" valid:"role,true"`
+	MessageTemplate string  `schema:"message_template" valid:"template,5000"`
+	EditOnOffline   bool    `schema:"edit_on_offline"`
+	FeedEnabled     bool    `schema:"feed_enabled"`
+}
+
+var (
+	panelLogKeyAddedFeed   = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "twitch_added_feed", FormatString: "Added twitch feed for %s"})
+	panelLogKeyUpdatedFeed = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "twitch_updated_feed", FormatString: "Updated twitch feed for %s"})
+	panelLogKeyRemovedFeed = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "twitch_removed_feed", FormatString: "Removed twitch feed for %s"})
+)
+
+var twitchLoginRegex = regexp.MustCompile(`\A[a-zA-Z0-9_]{3,25}\z`)
+
+func (p *Plugin) InitWeb() {
+	web.AddHTMLTemplate("twitch/assets/twitch.html", PageHTML)
+	web.AddSidebarItem(web.SidebarCategoryFeeds, &web.SidebarItem{
+		Name: "Twitch",
+		URL:  "twitch",
+		Icon: "fab fa-twitch",
+	})
+
+	twitchMux := goji.SubMux()
+	web.CPMux.Handle(pat.New("/twitch/*"), twitchMux)
+	web.CPMux.Handle(pat.New("/twitch"), twitchMux)
+
+	twitchMux.Use(web.RequireBotMemberMW)
+	twitchMux.Use(web.RequirePermMW(discordgo.PermissionManageWebhooks))
+	twitchMux.Use(baseData)
+
+	twitchMux.Handle(pat.Get("/"), web.RenderHandler(HandleTwitch, "cp_twitch"))
+	twitchMux.Handle(pat.Get(""), web.RenderHandler(HandleTwitch, "cp_twitch"))
+
+	twitchMux.Handle(pat.Post(""), web.FormParserMW(web.RenderHandler(p.HandleNew, "cp_twitch"), CreateForm{}))
+	twitchMux.Handle(pat.Post("/"), web.FormParserMW(web.RenderHandler(p.HandleNew, "cp_twitch"), CreateForm{}))
+	twitchMux.Handle(pat.Post("/:item/update"), web.FormParserMW(web.RenderHandler(HandleModify, "cp_twitch"), UpdateForm{}))
+	twitchMux.Handle(pat.Post("/:item/delete"), web.RenderHandler(p.HandleRemove, "cp_twitch"))
+
+	web.RootMux.Handle(pat.Post("/twitch/eventsub"), http.HandlerFunc(p.HandleEventSub))
+}
+
+// Adds the current config to the context
+func baseData(inner http.Handler) http.Handler {
+	mw := func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		activeGuild, templateData := web.GetBaseCPContextData(ctx)
+		templateData["VisibleURL"] = "/manage/" + discordgo.StrID(activeGuild.ID) + "/twitch/"
+		templateData["MaxFeeds"] = MaxFeedsForCtx(ctx)
+
+		subs, err := GuildSubscriptions(ctx, activeGuild.ID)
+		if web.CheckErr(templateData, err, "Failed retrieving config, message support in the yagpdb server", web.CtxLogger(ctx).Error) {
+			web.LogIgnoreErr(web.Templates.ExecuteTemplate(w, "cp_twitch", templateData))
+			return
+		}
+
+		inner.ServeHTTP(w, r.WithContext(context.WithValue(ctx, CurrentConfig, subs)))
+	}
+
+	return http.HandlerFunc(mw)
+}
+
+func HandleTwitch(w http.ResponseWriter, r *http.Request) interface{} {
+	ctx := r.Context()
+	_, templateData := web.GetBaseCPContextData(ctx)
+
+	templateData["TwitchFeeds"] = ctx.Value(CurrentConfig).([]*Subscription)
+
+	return templateData
+}
+
+// parseTwitchLogin accepts either a login name or a twitch channel url
+func parseTwitchLogin(s string) string {
+	s = strings.TrimSpace(s)
+	for _, prefix := range []string{"https://", "http://", "www.", "m.", "twitch.tv/"} {
+		s = strings.TrimPrefix(s, prefix)
+	}
+
+	if i := strings.IndexAny(s, "/?#"); i != -1 {
+		s = s[:i]
+	}
+
+	return strings.ToLower(s)
+}
+
+func (p *Plugin) HandleNew(w http.ResponseWriter, r *http.Request) interface{} {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+
+	currentConfig := ctx.Value(CurrentConfig).([]*Subscription)
+	templateData["TwitchFeeds"] = currentConfig
+
+	form := ctx.Value(common.ContextKeyParsedForm).(*CreateForm)
+	ok := ctx.Value(common.ContextKeyFormOk).(bool)
+	if !ok {
+		return templateData
+	}
+
+	maxFeeds := MaxFeedsForCtx(ctx)
+	if len(currentConfig) >= maxFeeds {
+		return templateData.AddAlerts(web.ErrorAlert(fmt.Sprintf("Max %d feeds allowed (or %d for premium servers)", GuildMaxFeedsNormal, GuildMaxFeedsPremium)))
+	}
+
+	login := parseTwitchLogin(form.TwitchChannel)
+	if !twitchLoginRegex.MatchString(login) {
+		return templateData.AddAlerts(web.ErrorAlert("Invalid twitch channel"))
+	}
+
+	user, err := p.api.GetUserByLogin(ctx, login)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return templateData.AddAlerts(web.ErrorAlert("Couldn't find a twitch channel named " + login))
+		}
+
+		web.CtxLogger(ctx).WithError(err).Error("failed retrieving twitch user")
+		return templateData.AddAlerts(web.ErrorAlert("Failed retrieving the twitch channel, try again later"))
+	}
+
+	for _, v := range currentConfig {
+		if v.TwitchUserID == user.ID && v.ChannelID == form.Channel {
+			return templateData.AddAlerts(web.ErrorAlert("That twitch channel is already being announced in that channel"))
+		}
+	}
+
+	sub := &Subscription{
+		GuildID:           activeGuild.ID,
+		ChannelID:         form.Channel,
+		TwitchUserID:      user.ID,
+		TwitchLogin:       user.Login,
+		TwitchDisplayName: user.DisplayName,
+		MentionRoles:      form.MentionRoles,
+		MessageTemplate:   form.MessageTemplate,
+		EditOnOffline:     form.EditOnOffline,
+		Enabled:           true,
+	}
+
+	err = sub.Insert(ctx)
+	if web.CheckErr(templateData, err, "Failed saving item :'(", web.CtxLogger(ctx).Error) {
+		return templateData
+	}
+
+	err = p.ensureEventSubs(ctx, user.ID)
+	if err != nil {
+		web.CtxLogger(ctx).WithError(err).Error("failed creating twitch eventsub subscriptions")
+		sub.Delete(ctx)
+		return templateData.AddAlerts(web.ErrorAlert("Failed subscribing to the twitch channel, try again later"))
+	}
+
+	templateData["TwitchFeeds"] = append(currentConfig, sub)
+	templateData.AddAlerts(web.SucessAlert("Sucessfully added twitch feed for " + sub.TwitchDisplayName))
+
+	go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyAddedFeed, &cplogs.Param{Type: cplogs.ParamTypeString, Value: sub.TwitchLogin}))
+
+	return templateData
+}
+
+func HandleModify(w http.ResponseWriter, r *http.Request) interface{} {
+	ctx := r.Context()
+	_, templateData := web.GetBaseCPContextData(ctx)
+
+	currentConfig := ctx.Value(CurrentConfig).([]*Subscription)
+	templateData["TwitchFeeds"] = currentConfig
+
+	form := ctx.Value(common.ContextKeyParsedForm).(*UpdateForm)
+	ok := ctx.Value(common.ContextKeyFormOk).(bool)
+	if !ok {
+		return templateData
+	}
+
+	item := findSubFromParam(r, currentConfig)
+	if item == nil {
+		return templateData.AddAlerts(web.ErrorAlert("Unknown id"))
+	}
+
+	item.ChannelID = form.Channel
+	item.MentionRoles = form.MentionRoles
+	item.MessageTemplate = form.MessageTemplate
+	item.EditOnOffline = form.EditOnOffline
+	item.Enabled = form.FeedEnabled && form.Channel != 0
+
+	err := item.Update(ctx)
+	if web.CheckErr(templateData, err, "Failed saving item :'(", web.CtxLogger(ctx).Error) {
+		return templateData
+	}
+
+	templateData.AddAlerts(web.SucessAlert("Sucessfully updated twitch feed! :D"))
+
+	go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyUpdatedFeed, &cplogs.Param{Type: cplogs.ParamTypeString, Value: item.TwitchLogin}))
+
+	return templateData
+}
+
+func (p *Plugin) HandleRemove(w http.ResponseWriter, r *http.Request) interface{} {
+	ctx := r.Context()
+	_, templateData := web.GetBaseCPContextData(ctx)
+
+	currentConfig := ctx.Value(CurrentConfig).([]*Subscription)
+	templateData["TwitchFeeds"] = currentConfig
+
+	item := findSubFromParam(r, currentConfig)
+	if item == nil {
+		return templateData.AddAlerts(web.ErrorAlert("Unknown id"))
+	}
+
+	err := item.Delete(ctx)
+	if web.CheckErr(templateData, err, "Failed removing item :'(", web.CtxLogger(ctx).Error) {
+		return templateData
+	}
+
+	templateData.AddAlerts(web.SucessAlert("Sucessfully removed twitch feed for " + item.TwitchDisplayName))
+
+	// Remove it form the displayed list
+	for k, c := range currentConfig {
+		if c.ID == item.ID {
+			currentConfig = append(currentConfig[:k], currentConfig[k+1:]...)
+			break
+		}
+	}
+
+	templateData["TwitchFeeds"] = currentConfig
+
+	go func() {
+		err := p.maybeRemoveEventSubs(context.Background(), item.TwitchUserID)
+		if err != nil {
+			logger.WithError(err).WithField("twitch_user", item.TwitchUserID).Error("failed removing twitch eventsub subscriptions")
+		}
+	}()
+
+	go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyRemovedFeed, &cplogs.Param{Type: cplogs.ParamTypeString, Value: item.TwitchLogin}))
+
+	return templateData
+}
+
+func findSubFromParam(r *http.Request, subs []*Subscription) *Subscription {
+	id, err := strconv.ParseInt(pat.Param(r, "item"), 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	for _, v := range subs {
+		if v.ID == id {
+			return v
+		}
+	}
+
+	return nil
+}
+
+var _ web.PluginWithServerHomeWidget = (*Plugin)(nil)
+
+func (p *Plugin) LoadServerHomeWidget(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ag, templateData := web.GetBaseCPContextData(r.Context())
+
+	templateData["WidgetTitle"] = "Twitch feeds"
+	templateData["SettingsPath"] = "/twitch"
+
+	var enabled, total int
+	err := common.PQ.QueryRowContext(r.Context(), `SELECT count(*) FILTER (WHERE enabled), count(*) FROM twitch_channel_subscriptions WHERE guild_id = $1`, ag.ID).Scan(&enabled, &total)
+	if err != nil {
+		return templateData, err
+	}
+
+	if enabled > 0 {
+		templateData["WidgetEnabled"] = true
+	} else {
+		templateData["WidgetDisabled"] = true
+	}
+
+	format := `<ul>
+	<li>Active feeds: <code>%d</code></li>
+	<li>Total feeds: <code>%d</code></li>
+</ul>`
+
+	templateData["WidgetBody"] = template.HTML(fmt.Sprintf(format, enabled, total))
+
+	return templateData, nil
+}
@@ -0,0 +1,664 @@
+package verification
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/botlabs-gg/yagpdb/v2/bot"
+	"github.com/botlabs-gg/yagpdb/v2/bot/eventsystem"
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+	"github.com/botlabs-gg/yagpdb/v2/lib/dstate"
+	"github.com/botlabs-gg/yagpdb/v2/lib/jarowinkler"
+	"github.com/botlabs-gg/yagpdb/v2/moderation"
+	"github.com/botlabs-gg/yagpdb/v2/verification/models"
+)
+
+// Weights of the individual alt detection signals, the final score is capped at MaxAltScore
+const (
+	MaxAltScore = 100
+
+	altScoreBannedAvatar      = 50
+	altScoreSharedIP          = 20
+	altScoreSimilarNameMax    = 35
+	altScoreSimilarNameMin    = 20
+	altScoreNewAccountMax     = 25
+	altScoreNewAccountMin     = 5
+	altScoreJoinedAfterBan    = 15
+	altScoreJoinedRightAfter  = 25
+	altSimilarNameThreshold   = 0.85
+	altJoinedAfterBanWindow   = time.Hour * 24
+	altJoinedRightAfterWindow = time.Hour
+
+	// how many of the most recent bans we compare new members against
+	altMaxBannedUsersChecked = 500
+)
+
+const (
+	altReviewActionBan   = "ban"
+	altReviewActionKick  = "kick"
+	altReviewActionAllow = "allow"
+
+	altReviewCustomIDPrefix = "verification_alt_"
+)
+
+// AltSignal is a single contributing factor to a members alt score
+type AltSignal struct {
+	Name   string `json:"name"`
+	Detail string `json:"detail"`
+	Score  int    `json:"score"`
+}
+
+// BannedUser is a snapshot of a banned user taken at the time of the ban,
+// used to compare new members against
+type BannedUser struct {
+	UserID   int64
+	Username string
+	Avatar   string
+	BannedAt time.Time
+}
+
+// AltCheckInput holds everything we know about a member when scoring them
+type AltCheckInput struct {
+	User *discordgo.User
+
+	// When the member joined, zero if it's unknown in which case the account age isn't scored
+	JoinedAt time.Time
+
+	// Minimum account age in days, accounts younger than this are considered suspicious
+	NewAccountDays int
+
+	RecentBans []*BannedUser
+
+	// Other (non banned) users that verified with the same ip
+	SharedIPWith []*discordgo.User
+}
+
+// ScoreAltSignals evaluates all the alt detection signals for the input,
+// returning the total score (capped at MaxAltScore) and the signals that contributed to it
+func ScoreAltSignals(input *AltCheckInput) (int, []*AltSignal) {
+	var signals []*AltSignal
+
+	if len(input.SharedIPWith) > 0 {
+		names := make([]string, 0, len(input.SharedIPWith))
+		for _, v := range input.SharedIPWith {
+			names = append(names, fmt.Sprintf("%s (%d)", v.String(), v.ID))
+		}
+
+		signals = append(signals, &AltSignal{
+			Name:   "Shared IP",
+			Detail: "Verified from the same IP as " + common.CutStringShort(strings.Join(names, ", "), 200),
+			Score:  altScoreSharedIP,
+		})
+	}
+
+	if input.NewAccountDays > 0 && !input.JoinedAt.IsZero() {
+		created := bot.SnowflakeToTime(input.User.ID)
+		age := input.JoinedAt.Sub(created)
+		threshold := time.Duration(input.NewAccountDays) * time.Hour * 24
+		if age < threshold {
+			score := int(float64(altScoreNewAccountMax) * (1 - float64(age)/float64(threshold)))
+			if score < altScoreNewAccountMin {
+				score = altScoreNewAccountMin
+			}
+
+			signals = append(signals, &AltSignal{
+				Name:   "New account",
+				Detail: "Account created " + common.HumanizeDuration(common.DurationPrecisionMinutes, age) + " before joining",
+				Score:  score,
+			})
+		}
+	}
+
+	if s := avatarSignal(input); s != nil {
+		signals = append(signals, s)
+	}
+
+	if s := usernameSignal(input); s != nil {
+		signals = append(signals, s)
+	}
+
+	if s := joinTimingSignal(input); s != nil {
+		signals = append(signals, s)
+	}
+
+	total := 0
+	for _, v := range signals {
+		total += v.Score
+	}
+
+	if total > MaxAltScore {
+		total = MaxAltScore
+	}
+
+	return total, signals
+}
+
+func avatarSignal(input *AltCheckInput) *AltSignal {
+	if input.User.Avatar == "" {
+		// default avatars are shared by everyone
+		return nil
+	}
+
+	for _, v := range input.RecentBans {
+		if v.UserID != input.User.ID && v.Avatar == input.User.Avatar {
+			return &AltSignal{
+				Name:   "Avatar match",
+				Detail: fmt.Sprintf("Same avatar as banned user %s (%d)", v.Username, v.UserID),
+				Score:  altScoreBannedAvatar,
+			}
+		}
+	}
+
+	return nil
+}
+
+func usernameSignal(input *AltCheckInput) *AltSignal {
+	names := []string{input.User.Username}
+	if input.User.Globalname != "" && input.User.Globalname != input.User.Username {
+		names = append(names, input.User.Globalname)
+	}
+
+	var closest *BannedUser
+	bestSimilarity := float64(0)
+	for _, v := range input.RecentBans {
+		if v.UserID == input.User.ID || v.Username == "" {
+			continue
+		}
+
+		bannedName := []rune(strings.ToLower(v.Username))
+		for _, name := range names {
+			similarity := jarowinkler.Similarity([]rune(strings.ToLower(name)), bannedName)
+			if similarity > bestSimilarity {
+				bestSimilarity = similarity
+				closest = v
+			}
+		}
+	}
+
+	if closest == nil || bestSimilarity < altSimilarNameThreshold {
+		return nil
+	}
+
+	// scale linearly between the min and max score over the range [threshold, 1]
+	scale := (bestSimilarity - altSimilarNameThreshold) / (1 - altSimilarNameThreshold)
+	score := altScoreSimilarNameMin + int(float64(altScoreSimilarNameMax-altScoreSimilarNameMin)*scale)
+
+	return &AltSignal{
+		Name:   "Similar username",
+		Detail: fmt.Sprintf("%.0f%% similar to banned user %s (%d)", bestSimilarity*100, closest.Username, closest.UserID),
+		Score:  score,
+	}
+}
+
+func joinTimingSignal(input *AltCheckInput) *AltSignal {
+	var latest *BannedUser
+	for _, v := range input.RecentBans {
+		if v.UserID == input.User.ID || v.BannedAt.After(input.JoinedAt) {
+			continue
+		}
+
+		if latest == nil || v.BannedAt.After(latest.BannedAt) {
+			latest = v
+		}
+	}
+
+	if latest == nil {
+		return nil
+	}
+
+	since := input.JoinedAt.Sub(latest.BannedAt)
+	if since > altJoinedAfterBanWindow {
+		return nil
+	}
+
+	score := altScoreJoinedAfterBan
+	if since <= altJoinedRightAfterWindow {
+		score = altScoreJoinedRightAfter
+	}
+
+	return &AltSignal{
+		Name:   "Joined after a ban",
+		Detail: fmt.Sprintf("Joined %s after %s (%d) was banned", common.HumanizeDuration(common.DurationPrecisionMinutes, since), latest.Username, latest.UserID),
+		Score:  score,
+	}
+}
+
+func recordBannedUser(guildID int64, user *discordgo.User) error {
+	const q = `INSERT INTO verification_banned_users (guild_id, user_id, banned_at, username, avatar)
+VALUES ($1, $2, now(), $3, $4)
+ON CONFLICT (guild_id, user_id) DO UPDATE SET banned_at = now(), username = $3, avatar = $4`
+
+	_, err := common.PQ.Exec(q, guildID, user.ID, user.Username, user.Avatar)
+	return err
+}
+
+func removeBannedUser(guildID int64, userID int64) error {
+	_, err := common.PQ.Exec(`DELETE FROM verification_banned_users WHERE guild_id = $1 AND user_id = $2`, guildID, userID)
+	return err
+}
+
+func recentBannedUsers(guildID int64) ([]*BannedUser, error) {
+	const q = `SELECT user_id, username, avatar, banned_at FROM verification_banned_users
+WHERE guild_id = $1
+ORDER BY banned_at DESC
+LIMIT $2`
+
+	rows, err := common.PQ.Query(q, guildID, altMaxBannedUsersChecked)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*BannedUser
+	for rows.Next() {
+		var b BannedUser
+		err = rows.Scan(&b.UserID, &b.Username, &b.Avatar, &b.BannedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, &b)
+	}
+
+	return result, rows.Err()
+}
+
+// AltReview is a member pushed into the staff review queue
+type AltReview struct {
+	ID        int64
+	GuildID   int64
+	UserID    int64
+	CreatedAt time.Time
+	Score     int
+	Signals   []*AltSignal
+
+	ChannelID int64
+	MessageID int64
+
+	ResolvedAt sql.NullTime
+	ResolvedBy int64
+	Resolution string
+}
+
+func findAltReview(id int64) (*AltReview, error) {
+	const q = `SELECT id, guild_id, user_id, created_at, score, signals, channel_id, message_id, resolved_at, resolved_by, resolution
+FROM verification_alt_reviews WHERE id = $1`
+
+	var review AltReview
+	var rawSignals []byte
+	err := common.PQ.QueryRow(q, id).Scan(&review.ID, &review.GuildID, &review.UserID, &review.CreatedAt, &review.Score, &rawSignals,
+		&review.ChannelID, &review.MessageID, &review.ResolvedAt, &review.ResolvedBy, &review.Resolution)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(rawSignals, &review.Signals)
+	return &review, err
+}
+
+func findPendingAltReview(guildID, userID int64) (*AltReview, error) {
+	var id int64
+	err := common.PQ.QueryRow(`SELECT id FROM verification_alt_reviews WHERE guild_id = $1 AND user_id = $2 AND resolved_at IS NULL ORDER BY id DESC LIMIT 1`, guildID, userID).Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return findAltReview(id)
+}
+
+// runAltDetection scores the member and pushes them into the review queue if they're over the configured threshold,
+// if the member already has a pending review it's updated if the score went up
+func (p *Plugin) runAltDetection(conf *models.VerificationConfig, user *discordgo.User, joinedAt time.Time, sharedIP []*discordgo.User) {
+	if !conf.AltDetectionEnabled || conf.AltReviewChannel == 0 || user.Bot {
+		return
+	}
+
+	l := logger.WithField("guild", conf.GuildID).WithField("user", user.ID)
+
+	bans, err := recentBannedUsers(conf.GuildID)
+	if err != nil {
+		l.WithError(err).Error("failed retrieving recently banned users")
+		return
+	}
+
+	score, signals := ScoreAltSignals(&AltCheckInput{
+		User:           user,
+		JoinedAt:       joinedAt,
+		NewAccountDays: conf.AltNewAccountDays,
+		RecentBans:     bans,
+		SharedIPWith:   sharedIP,
+	})
+
+	if score < conf.AltReviewThreshold || len(signals) < 1 {
+		return
+	}
+
+	existing, err := findPendingAltReview(conf.GuildID, user.ID)
+	if err != nil {
+		l.WithError(err).Error("failed retrieving pending alt review")
+		return
+	}
+
+	if existing != nil {
+		if existing.Score >= score {
+			return
+		}
+
+		err = p.updateAltReview(existing, user, score, signals)
+	} else {
+		err = p.createAltReview(conf, user, joinedAt, score, signals)
+	}
+
+	if err != nil {
+		if common.IsDiscordErr(err, discordgo.ErrCodeMissingPermissions, discordgo.ErrCodeUnknownChannel, discordgo.ErrCodeMissingAccess) {
+			go p.disableAltReviewChannel(conf.GuildID)
+			return
+		}
+
+		l.WithError(err).Error("failed creating alt review")
+	}
+}
+
+func (p *Plugin) createAltReview(conf *models.VerificationConfig, user *discordgo.User, joinedAt time.Time, score int, signals []*AltSignal) error {
+	serialized, err := json.Marshal(signals)
+	if err != nil {
+		return err
+	}
+
+	review := &AltReview{
+		GuildID:   conf.GuildID,
+		UserID:    user.ID,
+		CreatedAt: time.Now(),
+		Score:     score,
+		Signals:   signals,
+		ChannelID: conf.AltReviewChannel,
+	}
+
+	const q = `INSERT INTO verification_alt_reviews (guild_id, user_id, created_at, score, signals, channel_id, message_id)
+VALUES ($1, $2, $3, $4, $5, $6, 0) RETURNING id`
+	err = common.PQ.QueryRow(q, review.GuildID, review.UserID, review.CreatedAt, review.Score, serialized, review.ChannelID).Scan(&review.ID)
+	if err != nil {
+		return errors.WithStackIf(err)
+	}
+
+	msg, err := common.BotSession.ChannelMessageSendComplex(conf.AltReviewChannel, &discordgo.MessageSend{
+		Embeds:          []*discordgo.MessageEmbed{altReviewEmbed(review, user)},
+		Components:      altReviewComponents(review.ID),
+		AllowedMentions: discordgo.AllowedMentions{},
+	})
+	if err != nil {
+		common.PQ.Exec(`DELETE FROM verification_alt_reviews WHERE id = $1`, review.ID)
+		return err
+	}
+
+	_, err = common.PQ.Exec(`UPDATE verification_alt_reviews SET message_id = $2 WHERE id = $1`, review.ID, msg.ID)
+	return errors.WithStackIf(err)
+}
+
+func (p *Plugin) updateAltReview(review *AltReview, user *discordgo.User, score int, signals []*AltSignal) error {
+	serialized, err := json.Marshal(signals)
+	if err != nil {
+		return err
+	}
+
+	review.Score = score
+	review.Signals = signals
+
+	_, err = common.PQ.Exec(`UPDATE verification_alt_reviews SET score = $2, signals = $3 WHERE id = $1`, review.ID, score, serialized)
+	if err != nil {
+		return errors.WithStackIf(err)
+	}
+
+	embed := altReviewEmbed(review, user)
+	components := altReviewComponents(review.ID)
+	_, err = common.BotSession.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		ID:         review.MessageID,
+		Channel:    review.ChannelID,
+		Embeds:     []*discordgo.MessageEmbed{embed},
+		Components: components,
+	})
+	return err
+}
+
+func (p *Plugin) disableAltReviewChannel(guildID int64) {
+	logger.WithField("guild", guildID).Warnf("disabling alt review channel due to it being unavailable or missing perms")
+
+	const q = `UPDATE verification_configs SET alt_review_channel=0 WHERE guild_id=$1`
+	_, err := common.PQ.Exec(q, guildID)
+	if err != nil {
+		logger.WithField("guild", guildID).WithError(err).Error("failed disabling alt review channel")
+	}
+}
+
+func altReviewColor(score int) int {
+	switch {
+	case score >= 75:
+		return 0xef4640
+	case score >= 50:
+		return 0xff8228
+	default:
+		return 0xffd83d
+	}
+}
+
+func altReviewEmbed(review *AltReview, user *discordgo.User) *discordgo.MessageEmbed {
+	var breakdown strings.Builder
+	for _, v := range review.Signals {
+		breakdown.WriteString(fmt.Sprintf("`+%d` **%s**: %s\n", v.Score, v.Name, v.Detail))
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title: "Possible alt account",
+		Author: &discordgo.MessageEmbedAuthor{
+			IconURL: user.AvatarURL("128"),
+			Name:    fmt.Sprintf("%s (%d)", user.String(), user.ID),
+		},
+		Description: fmt.Sprintf("%s scored **%d/%d**\n\n%s", user.Mention(), review.Score, MaxAltScore, breakdown.String()),
+		Color:       altReviewColor(review.Score),
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "Account created",
+				Value:  fmt.Sprintf("<t:%d:R>", bot.SnowflakeToTime(user.ID).Unix()),
+				Inline: true,
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Review #" + strconv.FormatInt(review.ID, 10),
+		},
+		Timestamp: review.CreatedAt.Format(time.RFC3339),
+	}
+
+	if review.Resolution != "" {
+		embed.Color = 0x808080
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "Resolution",
+			Value:  fmt.Sprintf("%s by <@%d>", review.Resolution, review.ResolvedBy),
+			Inline: true,
+		})
+	}
+
+	return embed
+}
+
+func altReviewComponents(reviewID int64) []discordgo.MessageComponent {
+	id := strconv.FormatInt(reviewID, 10)
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Ban",
+					Style:    discordgo.DangerButton,
+					CustomID: altReviewCustomIDPrefix + altReviewActionBan + "_" + id,
+				},
+				discordgo.Button{
+					Label:    "Kick",
+					Style:    discordgo.PrimaryButton,
+					CustomID: altReviewCustomIDPrefix + altReviewActionKick + "_" + id,
+				},
+				discordgo.Button{
+					Label:    "Allow",
+					Style:    discordgo.SuccessButton,
+					CustomID: altReviewCustomIDPrefix + altReviewActionAllow + "_" + id,
+				},
+			},
+		},
+	}
+}
+
+// parseAltReviewCustomID returns the action and review id from a review button custom id
+func parseAltReviewCustomID(customID string) (action string, reviewID int64, ok bool) {
+	if !strings.HasPrefix(customID, altReviewCustomIDPrefix) {
+		return "", 0, false
+	}
+
+	split := strings.SplitN(strings.TrimPrefix(customID, altReviewCustomIDPrefix), "_", 2)
+	if len(split) != 2 {
+		return "", 0, false
+	}
+
+	reviewID, err := strconv.ParseInt(split[1], 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+
+	switch split[0] {
+	case altReviewActionBan, altReviewActionKick, altReviewActionAllow:
+		return split[0], reviewID, true
+	}
+
+	return "", 0, false
+}
+
+func (p *Plugin) handleAltReviewInteraction(evt *eventsystem.EventData) {
+	ic := evt.InteractionCreate()
+	if ic.Type != discordgo.InteractionMessageComponent || ic.GuildID == 0 || ic.Member == nil {
+		return
+	}
+
+	action, reviewID, ok := parseAltReviewCustomID(ic.MessageComponentData().CustomID)
+	if !ok {
+		return
+	}
+
+	l := logger.WithField("guild", ic.GuildID).WithField("review", reviewID)
+
+	review, err := findAltReview(reviewID)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			l.WithError(err).Error("failed retrieving alt review")
+		}
+		respondAltReviewEphemeral(ic, "This review no longer exists.")
+		return
+	}
+
+	if review.GuildID != ic.GuildID {
+		return
+	}
+
+	if review.ResolvedAt.Valid {
+		respondAltReviewEphemeral(ic, "This review has already been resolved.")
+		return
+	}
+
+	neededPerm := int64(discordgo.PermissionKickMembers)
+	if action == altReviewActionBan {
+		neededPerm = discordgo.PermissionBanMembers
+	}
+
+	ms := dstate.MemberStateFromMember(ic.Member)
+	hasPerms, err := bot.AdminOrPermMS(ic.GuildID, ic.ChannelID, ms, neededPerm)
+	if err != nil || !hasPerms {
+		respondAltReviewEphemeral(ic, "You don't have the required permissions to do that.")
+		return
+	}
+
+	target := bot.GetUsers(ic.GuildID, review.UserID)[0]
+	author := ic.Member.User
+
+	var resolution string
+	switch action {
+	case altReviewActionBan:
+		resolution = "Banned"
+	case altReviewActionKick:
+		resolution = "Kicked"
+	case altReviewActionAllow:
+		resolution = "Allowed"
+	}
+
+	// claim the review first so only one of multiple staff members clicking at the same time gets to act on it
+	claimed, err := claimAltReview(review.ID, author.ID, resolution)
+	if err != nil {
+		l.WithError(err).Error("failed marking alt review as resolved")
+		respondAltReviewEphemeral(ic, "Something went wrong, try again later.")
+		return
+	}
+
+	if !claimed {
+		respondAltReviewEphemeral(ic, "This review has already been resolved.")
+		return
+	}
+
+	reason := fmt.Sprintf("Alt account (review #%d, score %d)", review.ID, review.Score)
+	switch action {
+	case altReviewActionBan:
+		err = moderation.BanUser(nil, ic.GuildID, nil, nil, author, reason, target)
+	case altReviewActionKick:
+		err = moderation.KickUser(nil, ic.GuildID, nil, nil, author, reason, target, 0)
+	}
+
+	if err != nil {
+		l.WithError(err).WithField("action", action).Error("failed executing alt review action")
+		if _, unclaimErr := common.PQ.Exec(`UPDATE verification_alt_reviews SET resolved_at = NULL, resolved_by = 0, resolution = '' WHERE id = $1`, review.ID); unclaimErr != nil {
+			l.WithError(unclaimErr).Error("failed reopening alt review")
+		}
+		respondAltReviewEphemeral(ic, "Failed executing the action: "+common.CutStringShort(err.Error(), 1000))
+		return
+	}
+
+	review.Resolution = resolution
+	review.ResolvedBy = author.ID
+
+	err = common.BotSession.CreateInteractionResponse(ic.ID, ic.Token, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{altReviewEmbed(review, target)},
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+	if err != nil {
+		l.WithError(err).Error("failed updating alt review message")
+	}
+}
+
+// claimAltReview resolves the review if nobody else has yet, returns false if it was already resolved
+func claimAltReview(reviewID, resolvedBy int64, resolution string) (bool, error) {
+	res, err := common.PQ.Exec(`UPDATE verification_alt_reviews SET resolved_at = now(), resolved_by = $2, resolution = $3 WHERE id = $1 AND resolved_at IS NULL`, reviewID, resolvedBy, resolution)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	return affected == 1, err
+}
+
+func respondAltReviewEphemeral(ic *discordgo.InteractionCreate, msg string) {
+	err := common.BotSession.CreateInteractionResponse(ic.ID, ic.Token, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: msg,
+			Flags:   uint64(discordgo.MessageFlagsEphemeral),
+		},
+	})
+	if err != nil {
+		logger.WithError(err).WithField("guild", ic.GuildID).Error("failed responding to alt review interaction")
+	}
+}
@@ -0,0 +1,122 @@
+package verification
+
+import (
+	"testing"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+)
+
+// snowflakeAt creates a user id with the creation time t
+func snowflakeAt(t time.Time) int64 {
+	const discordEpoch = 1420070400000
+	return (t.UnixNano()/int64(time.Millisecond) - discordEpoch) << 22
+}
+
+func TestScoreAltSignals(t *testing.T) {
+	joined := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	oldAccount := snowflakeAt(joined.Add(-time.Hour * 24 * 365))
+
+	bans := []*BannedUser{
+		{UserID: 1, Username: "evilspammer", Avatar: "abcdef", BannedAt: joined.Add(-time.Minute * 30)},
+		{UserID: 2, Username: "someoneelse", Avatar: "", BannedAt: joined.Add(-time.Hour * 24 * 30)},
+	}
+
+	cases := []struct {
+		name    string
+		input   *AltCheckInput
+		signals []string
+		min     int
+		max     int
+	}{
+		{
+			name: "clean",
+			input: &AltCheckInput{
+				User:           &discordgo.User{ID: oldAccount, Username: "regularuser"},
+				JoinedAt:       joined.Add(time.Hour * 48),
+				NewAccountDays: 7,
+				RecentBans:     bans,
+			},
+			signals: nil,
+			min:     0,
+			max:     0,
+		},
+		{
+			name: "new account",
+			input: &AltCheckInput{
+				User:           &discordgo.User{ID: snowflakeAt(joined.Add(-time.Hour)), Username: "regularuser"},
+				JoinedAt:       joined.Add(time.Hour * 48),
+				NewAccountDays: 7,
+			},
+			signals: []string{"New account"},
+			min:     altScoreNewAccountMin,
+			max:     altScoreNewAccountMax,
+		},
+		{
+			name: "unknown join time",
+			input: &AltCheckInput{
+				User:           &discordgo.User{ID: snowflakeAt(joined.Add(-time.Hour)), Username: "regularuser"},
+				NewAccountDays: 7,
+			},
+			signals: nil,
+			min:     0,
+			max:     0,
+		},
+		{
+			name: "everything",
+			input: &AltCheckInput{
+				User:           &discordgo.User{ID: snowflakeAt(joined.Add(-time.Minute)), Username: "evilspammer2", Avatar: "abcdef"},
+				JoinedAt:       joined,
+				NewAccountDays: 7,
+				RecentBans:     bans,
+				SharedIPWith:   []*discordgo.User{{ID: 3, Username: "friend"}},
+			},
+			signals: []string{"Shared IP", "New account", "Avatar match", "Similar username", "Joined after a ban"},
+			min:     MaxAltScore,
+			max:     MaxAltScore,
+		},
+		{
+			name: "ignores self",
+			input: &AltCheckInput{
+				User:       &discordgo.User{ID: 1, Username: "evilspammer", Avatar: "abcdef"},
+				JoinedAt:   joined,
+				RecentBans: bans[:1],
+			},
+			signals: nil,
+			min:     0,
+			max:     0,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			score, signals := ScoreAltSignals(c.input)
+			if score < c.min || score > c.max {
+				t.Errorf("score %d out of expected range [%d, %d]", score, c.min, c.max)
+			}
+
+			if len(signals) != len(c.signals) {
+				t.Fatalf("got %d signals, expected %d: %v", len(signals), len(c.signals), signals)
+			}
+
+			for i, v := range signals {
+				if v.Name != c.signals[i] {
+					t.Errorf("signal %d: got %q, expected %q", i, v.Name, c.signals[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseAltReviewCustomID(t *testing.T) {
+	action, id, ok := parseAltReviewCustomID("verification_alt_ban_123")
+	if !ok || action != altReviewActionBan || id != 123 {
+		t.Errorf("unexpected result: %q %d %v", action, id, ok)
+	}
+
+	for _, v := range []string{"verification_alt_nuke_1", "verification_alt_ban_", "rsvp_accepted", "verification_alt_kick"} {
+		if _, _, ok := parseAltReviewCustomID(v); ok {
+			t.Errorf("%q should not parse", v)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package verification
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/verification/models"
+	"github.com/botlabs-gg/yagpdb/v2/web"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+var _ common.PluginWithBackup = (*Plugin)(nil)
+
+func (p *Plugin) BackupVersion() int {
+	return 1
+}
+
+func (p *Plugin) ExportBackup(ctx context.Context, guildID int64) (interface{}, error) {
+	conf, err := models.FindVerificationConfigG(ctx, guildID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return conf, err
+}
+
+func (p *Plugin) RestoreBackup(ctx context.Context, guildID int64, data json.RawMessage, restore *common.BackupRestore) error {
+	var conf *models.VerificationConfig
+	err := json.Unmarshal(data, &conf)
+	if err != nil {
+		return err
+	}
+
+	tx, err := common.PQ.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = models.VerificationConfigs(qm.Where("guild_id = ?", guildID)).DeleteAll(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if conf != nil {
+		conf.GuildID = guildID
+		conf.VerifiedRole = restore.Role(conf.VerifiedRole)
+		conf.LogChannel = restore.Channel(conf.LogChannel)
+		conf.AltReviewChannel = restore.Channel(conf.AltReviewChannel)
+
+		if conf.Enabled && conf.VerifiedRole == 0 {
+			restore.Note("The verified role was not found, verification will not work until one is set")
+		}
+
+		validateRestoredConfig(conf, restore)
+
+		err = conf.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return restore.Finish(tx)
+}
+
+// validateRestoredConfig runs the messages and page content through the same checks as the control panel form,
+// clearing the ones that don't pass
+func validateRestoredConfig(conf *models.VerificationConfig, restore *common.BackupRestore) {
+	if err := web.ValidateNormalStringField(conf.PageContent, 0, 10000); err != nil {
+		restore.Note("Skipped the verification page content: %s", err)
+		conf.PageContent = ""
+	}
+
+	if err := web.ValidateTemplateField(conf.WarnMessage, 10000); err != nil {
+		restore.Note("Skipped the warning message: %s", err)
+		conf.WarnMessage = ""
+	}
+
+	if err := web.ValidateTemplateField(conf.DMMessage, 10000); err != nil {
+		restore.Note("Skipped the DM message: %s", err)
+		conf.DMMessage = ""
+	}
+}
@@ -32,6 +32,10 @@ type VerificationConfig struct {
 	WarnMessage         string `boil:"warn_message" json:"warn_message" toml:"warn_message" yaml:"warn_message"`
 	LogChannel          int64  `boil:"log_channel" json:"log_channel" toml:"log_channel" yaml:"log_channel"`
 	DMMessage           string `boil:"dm_message" json:"dm_message" toml:"dm_message" yaml:"dm_message"`
+	AltDetectionEnabled bool   `boil:"alt_detection_enabled" json:"alt_detection_enabled" toml:"alt_detection_enabled" yaml:"alt_detection_enabled"`
+	AltReviewChannel    int64  `boil:"alt_review_channel" json:"alt_review_channel" toml:"alt_review_channel" yaml:"alt_review_channel"`
+	AltReviewThreshold  int    `boil:"alt_review_threshold" json:"alt_review_threshold" toml:"alt_review_threshold" yaml:"alt_review_threshold"`
+	AltNewAccountDays   int    `boil:"alt_new_account_days" json:"alt_new_account_days" toml:"alt_new_account_days" yaml:"alt_new_account_days"`
 
 	R *verificationConfigR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L verificationConfigL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -47,6 +51,10 @@ var VerificationConfigColumns = struct {
 	WarnMessage         string
 	LogChannel          string
 	DMMessage           string
+	AltDetectionEnabled string
+	AltReviewChannel    string
+	AltReviewThreshold  string
+	AltNewAccountDays   string
 }{
 	GuildID:             "guild_id",
 	Enabled:             "enabled",
@@ -57,6 +65,10 @@ var VerificationConfigColumns = struct {
 	WarnMessage:         "warn_message",
 	LogChannel:          "log_channel",
 	DMMessage:           "dm_message",
+	AltDetectionEnabled: "alt_detection_enabled",
+	AltReviewChannel:    "alt_review_channel",
+	AltReviewThreshold:  "alt_review_threshold",
+	AltNewAccountDays:   "alt_new_account_days",
 }
 
 var VerificationConfigTableColumns = struct {
@@ -69,6 +81,10 @@ var VerificationConfigTableColumns = struct {
 	WarnMessage         string
 	LogChannel          string
 	DMMessage           string
+	AltDetectionEnabled string
+	AltReviewChannel    string
+	AltReviewThreshold  string
+	AltNewAccountDays   string
 }{
 	GuildID:             "verification_configs.guild_id",
 	Enabled:             "verification_configs.enabled",
@@ -79,6 +95,10 @@ var VerificationConfigTableColumns = struct {
 	WarnMessage:         "verification_configs.warn_message",
 	LogChannel:          "verification_configs.log_channel",
 	DMMessage:           "verification_configs.dm_message",
+	AltDetectionEnabled: "verification_configs.alt_detection_enabled",
+	AltReviewChannel:    "verification_configs.alt_review_channel",
+	AltReviewThreshold:  "verification_configs.alt_review_threshold",
+	AltNewAccountDays:   "verification_configs.alt_new_account_days",
 }
 
 // Generated where
@@ -175,6 +195,10 @@ var VerificationConfigWhere = struct {
 	WarnMessage         whereHelperstring
 	LogChannel          whereHelperint64
 	DMMessage           whereHelperstring
+	AltDetectionEnabled whereHelperbool
+	AltReviewChannel    whereHelperint64
+	AltReviewThreshold  whereHelperint
+	AltNewAccountDays   whereHelperint
 }{
 	GuildID:             whereHelperint64{field: "\"verification_configs\".\"guild_id\""},
 	Enabled:             whereHelperbool{field: "\"verification_configs\".\"enabled\""},
@@ -185,6 +209,10 @@ var VerificationConfigWhere = struct {
 	WarnMessage:         whereHelperstring{field: "\"verification_configs\".\"warn_message\""},
 	LogChannel:          whereHelperint64{field: "\"verification_configs\".\"log_channel\""},
 	DMMessage:           whereHelperstring{field: "\"verification_configs\".\"dm_message\""},
+	AltDetectionEnabled: whereHelperbool{field: "\"verification_configs\".\"alt_detection_enabled\""},
+	AltReviewChannel:    whereHelperint64{field: "\"verification_configs\".\"alt_review_channel\""},
+	AltReviewThreshold:  whereHelperint{field: "\"verification_configs\".\"alt_review_threshold\""},
+	AltNewAccountDays:   whereHelperint{field: "\"verification_configs\".\"alt_new_account_days\""},
 }
 
 // VerificationConfigRels is where relationship names are stored.
@@ -204,9 +232,9 @@ func (*verificationConfigR) NewStruct() *verificationConfigR {
 type verificationConfigL struct{}
 
 var (
-	verificationConfigAllColumns            = []string{"guild_id", "enabled", "verified_role", "page_content", "kick_unverified_after", "warn_unverified_after", "warn_message", "log_channel", "dm_message"}
+	verificationConfigAllColumns            = []string{"guild_id", "enabled", "verified_role", "page_content", "kick_unverified_after", "warn_unverified_after", "warn_message", "log_channel", "dm_message", "alt_detection_enabled", "alt_review_channel", "alt_review_threshold", "alt_new_account_days"}
 	verificationConfigColumnsWithoutDefault = []string{"guild_id", "enabled", "verified_role", "page_content", "kick_unverified_after", "warn_unverified_after", "warn_message", "log_channel"}
-	verificationConfigColumnsWithDefault    = []string{"dm_message"}
+	verificationConfigColumnsWithDefault    = []string{"dm_message", "alt_detection_enabled", "alt_review_channel", "alt_review_threshold", "alt_new_account_days"}
 	verificationConfigPrimaryKeyColumns     = []string{"guild_id"}
 	verificationConfigGeneratedColumns      = []string{}
 )
@@ -38,4 +38,42 @@ CREATE TABLE IF NOT EXISTS verified_users (
 
 	PRIMARY KEY(guild_id, user_id)
 );
+`, `
+ALTER TABLE verification_configs ADD COLUMN IF NOT EXISTS alt_detection_enabled BOOLEAN NOT NULL DEFAULT false;
+`, `
+ALTER TABLE verification_configs ADD COLUMN IF NOT EXISTS alt_review_channel BIGINT NOT NULL DEFAULT 0;
+`, `
+ALTER TABLE verification_configs ADD COLUMN IF NOT EXISTS alt_review_threshold INT NOT NULL DEFAULT 50;
+`, `
+ALTER TABLE verification_configs ADD COLUMN IF NOT EXISTS alt_new_account_days INT NOT NULL DEFAULT 7;
+`, `
+CREATE TABLE IF NOT EXISTS verification_banned_users (
+	guild_id BIGINT NOT NULL,
+	user_id BIGINT NOT NULL,
+
+	banned_at TIMESTAMP WITH TIME ZONE NOT NULL,
+	username TEXT NOT NULL,
+	avatar TEXT NOT NULL,
+
+	PRIMARY KEY(guild_id, user_id)
+);
+`, `
+CREATE TABLE IF NOT EXISTS verification_alt_reviews (
+	id BIGSERIAL PRIMARY KEY,
+	guild_id BIGINT NOT NULL,
+	user_id BIGINT NOT NULL,
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	score INT NOT NULL,
+	signals JSONB NOT NULL,
+
+	channel_id BIGINT NOT NULL,
+	message_id BIGINT NOT NULL,
+
+	resolved_at TIMESTAMP WITH TIME ZONE,
+	resolved_by BIGINT NOT NULL DEFAULT 0,
+	resolution TEXT NOT NULL DEFAULT ''
+);
+`, `
+CREATE INDEX IF NOT EXISTS verification_alt_reviews_guild_user_idx ON verification_alt_reviews(guild_id, user_id);
 `}
@@ -5,6 +5,7 @@ package verification
 import (
 	"github.com/botlabs-gg/yagpdb/v2/common"
 	"github.com/botlabs-gg/yagpdb/v2/common/config"
+	"github.com/botlabs-gg/yagpdb/v2/common/scheduledevents2"
 )
 
 var confGoogleReCAPTCHASiteKey = config.RegisterOption("yagpdb.google.recaptcha_site_key", "Google reCAPTCHA site key", "")
@@ -24,6 +25,10 @@ func (p *Plugin) PluginInfo() *common.PluginInfo {
 var logger = common.GetPluginLogger(&Plugin{})
 
 func RegisterPlugin() {
+	// events may still be pending from when the plugin was enabled
+	scheduledevents2.RegisterDescriber("verification_user_verified", int64(0), describeUserVerified)
+	scheduledevents2.RegisterDescriber("verification_user_warn", VerificationEventData{}, describeWarnUser)
+	scheduledevents2.RegisterDescriber("verification_user_kick", VerificationEventData{}, describeKickUser)
 
 	if confGoogleReCAPTCHASecret.GetString() == "" || confGoogleReCAPTCHASiteKey.GetString() == "" {
 		logger.Warn("no YAGPDB_GOOGLE_RECAPTCHA_SECRET and/or YAGPDB_GOOGLE_RECAPTCHA_SITE_KEY provided, not enabling verification plugin")
@@ -41,6 +46,11 @@ const (
 Please solve the following reCAPTCHA to make sure you're not a robot`
 )
 
+const (
+	DefaultAltReviewThreshold = 50
+	DefaultAltNewAccountDays  = 7
+)
+
 const DefaultDMMessage = `{{sendMessage nil (cembed
 "title" "Are you a bot?"
 "description" (printf "Please solve the CAPTCHA at this link to make sure you're human, before you can enter %s: %s" .Server.Name .Link)
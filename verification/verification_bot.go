@@ -42,6 +42,8 @@ func (p *Plugin) BotInit() {
 	eventsystem.AddHandlerAsyncLastLegacy(p, p.handleMemberJoin, eventsystem.EventGuildMemberAdd)
 	eventsystem.AddHandlerAsyncLastLegacy(p, p.handleMemberUpdate, eventsystem.EventGuildMemberUpdate)
 	eventsystem.AddHandlerAsyncLastLegacy(p, p.handleBanAdd, eventsystem.EventGuildBanAdd)
+	eventsystem.AddHandlerAsyncLastLegacy(p, p.handleBanRemove, eventsystem.EventGuildBanRemove)
+	eventsystem.AddHandlerAsyncLastLegacy(p, p.handleAltReviewInteraction, eventsystem.EventInteractionCreate)
 	scheduledevents2.RegisterHandler("verification_user_verified", int64(0), ScheduledEventMW(p.handleUserVerifiedScheduledEvent))
 	scheduledevents2.RegisterHandler("verification_user_warn", VerificationEventData{}, ScheduledEventMW(p.handleWarnUserVerification))
 	scheduledevents2.RegisterHandler("verification_user_kick", VerificationEventData{}, ScheduledEventMW(p.handleKickUser))
@@ -94,6 +96,11 @@ func (p *Plugin) handleVerificationAfterScreening(member *discordgo.Member) {
 		// either no config or an error occured
 		return
 	}
+
+	p.verifyAfterScreening(conf, member)
+}
+
+func (p *Plugin) verifyAfterScreening(conf *models.VerificationConfig, member *discordgo.Member) {
 	if !conf.Enabled {
 		return
 	}
@@ -114,7 +121,7 @@ func (p *Plugin) handleVerificationAfterScreening(member *discordgo.Member) {
 
 	// Check if member is already verified, if yes then remove any scheduled events
 	if common.ContainsInt64Slice(member.Roles, conf.VerifiedRole) {
-		err = p.clearScheduledEvents(context.Background(), member.GuildID, member.User.ID)
+		err := p.clearScheduledEvents(context.Background(), member.GuildID, member.User.ID)
 		if err != nil {
 			logger.WithError(err).WithField("guild", member.GuildID).WithField("user", member.User.ID).Error("failed clearing past scheduled warn/kick events")
 		}
@@ -132,13 +139,25 @@ func (p *Plugin) handleMemberJoin(evt *eventsystem.EventData) {
 	if addEvt.User.Bot {
 		return
 	}
+
+	conf, err := models.FindVerificationConfigG(context.Background(), addEvt.GuildID)
+	if err != nil && err != sql.ErrNoRows {
+		logger.WithError(err).WithField("guild", addEvt.GuildID).WithField("user", addEvt.User.ID).Error("unable to retrieve config")
+	}
+
+	if conf != nil {
+		go p.checkAltOnJoin(conf, addEvt.Member)
+	}
+
 	if addEvt.Pending {
 		// Membership screening is pending for this member, add to pending set and return
 		addMemberToVerificationPendingSet(addEvt.GuildID, addEvt.User.ID)
 		return
 	}
 
-	p.handleVerificationAfterScreening(addEvt.Member)
+	if conf != nil {
+		p.verifyAfterScreening(conf, addEvt.Member)
+	}
 }
 
 func (p *Plugin) handleMemberUpdate(evt *eventsystem.EventData) {
@@ -283,6 +302,10 @@ func ScheduledEventMW(innerHandler func(ms *dstate.MemberState, guildID int64, c
 
 }
 
+func describeUserVerified(evt *seventsmodels.ScheduledEvent, data interface{}) string {
+	return fmt.Sprintf("Give the verified role to user %d", *data.(*int64))
+}
+
 func (p *Plugin) handleUserVerifiedScheduledEvent(ms *dstate.MemberState, guildID int64, conf *models.VerificationConfig, rawData interface{}) (retry bool, err error) {
 	err = common.BotSession.GuildMemberRoleAdd(guildID, ms.User.ID, conf.VerifiedRole)
 	if err != nil {
@@ -358,6 +381,9 @@ func (p *Plugin) handleUserVerifiedScheduledEvent(ms *dstate.MemberState, guildI
 	}
 
 	p.logAction(guildID, conf.LogChannel, &ms.User, builder.String(), 0xff8228)
+
+	joinedAt, _ := ms.Member.JoinedAt.Parse()
+	go p.runAltDetection(conf, &ms.User, joinedAt, conflicts)
 	return false, nil
 }
 
@@ -431,6 +457,10 @@ func (p *Plugin) CheckBanned(guildID int64, users []*discordgo.User) (*discordgo
 	return nil, nil
 }
 
+func describeWarnUser(evt *seventsmodels.ScheduledEvent, data interface{}) string {
+	return fmt.Sprintf("Remind user %d to verify", data.(*VerificationEventData).UserID)
+}
+
 func (p *Plugin) handleWarnUserVerification(ms *dstate.MemberState, guildID int64, conf *models.VerificationConfig, rawData interface{}) (retry bool, err error) {
 	if p.checkMemberAlreadyVerified(ms, conf) {
 		return false, nil
@@ -485,6 +515,10 @@ func (p *Plugin) sendWarning(ms *dstate.MemberState, gs *dstate.GuildSet, token
 	return nil
 }
 
+func describeKickUser(evt *seventsmodels.ScheduledEvent, data interface{}) string {
+	return fmt.Sprintf("Kick user %d if they haven't verified", data.(*VerificationEventData).UserID)
+}
+
 func (p *Plugin) handleKickUser(ms *dstate.MemberState, guildID int64, conf *models.VerificationConfig, rawData interface{}) (retry bool, err error) {
 	if p.checkMemberAlreadyVerified(ms, conf) {
 		return false, nil
@@ -623,6 +657,14 @@ func markRecentlyBannedByVerification(guildID int64, userID int64) {
 func (p *Plugin) handleBanAdd(evt *eventsystem.EventData) {
 	ban := evt.GuildBanAdd()
 
+	conf, err := models.FindVerificationConfigG(context.Background(), ban.GuildID)
+	if err == nil && conf.AltDetectionEnabled {
+		err = recordBannedUser(ban.GuildID, ban.User)
+		if err != nil {
+			logger.WithError(err).WithField("guild", ban.GuildID).Error("failed recording banned user")
+		}
+	}
+
 	if !confVerificationTrackIPs.GetBool() {
 		return
 	}
@@ -682,3 +724,21 @@ func (p *Plugin) banAlts(ban *discordgo.GuildBanAdd, alts []*discordgo.User) {
 		logger.WithError(err).Error("failed retrieving guild ban")
 	}
 }
+
+func (p *Plugin) handleBanRemove(evt *eventsystem.EventData) {
+	ban := evt.GuildBanRemove()
+
+	err := removeBannedUser(ban.GuildID, ban.User.ID)
+	if err != nil {
+		logger.WithError(err).WithField("guild", ban.GuildID).Error("failed removing banned user")
+	}
+}
+
+func (p *Plugin) checkAltOnJoin(conf *models.VerificationConfig, member *discordgo.Member) {
+	joinedAt, err := member.JoinedAt.Parse()
+	if err != nil {
+		joinedAt = time.Now()
+	}
+
+	p.runAltDetection(conf, member.User, joinedAt, nil)
+}
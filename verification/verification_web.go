@@ -40,6 +40,11 @@ type FormData struct {
 	WarnMessage         string `valid:"template,10000"`
 	DMMessage           string `valid:"template,10000"`
 	LogChannel          int64  `valid:"channel,true"`
+
+	AltDetectionEnabled bool
+	AltReviewChannel    int64 `valid:"channel,true"`
+	AltReviewThreshold  int   `valid:"1,100"`
+	AltNewAccountDays   int   `valid:"0,365"`
 }
 
 var panelLogKey = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "verification_updated_settings", FormatString: "Updated verification settings"})
@@ -66,6 +71,8 @@ func (p *Plugin) InitWeb() {
 	postVerifyPageHandler := web.ControllerPostHandler(p.handlePostVerifyPage, getVerifyPageHandler, nil)
 	web.ServerPublicMux.Handle(pat.Get("/verify/:user_id/:token"), getVerifyPageHandler)
 	web.ServerPublicMux.Handle(pat.Post("/verify/:user_id/:token"), postVerifyPageHandler)
+
+	cplogs.RegisterConfigSource(cplogs.BackupConfigSource(p, "verification", "Verification", "verification"))
 }
 
 func (p *Plugin) handleGetSettings(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
@@ -75,7 +82,9 @@ func (p *Plugin) handleGetSettings(w http.ResponseWriter, r *http.Request) (web.
 	settings, err := models.FindVerificationConfigG(ctx, g.ID)
 	if err == sql.ErrNoRows {
 		settings = &models.VerificationConfig{
-			GuildID: g.ID,
+			GuildID:            g.ID,
+			AltReviewThreshold: DefaultAltReviewThreshold,
+			AltNewAccountDays:  DefaultAltNewAccountDays,
 		}
 		err = nil
 	}
@@ -115,10 +124,16 @@ func (p *Plugin) handlePostSettings(w http.ResponseWriter, r *http.Request) (web
 		WarnMessage:         formConfig.WarnMessage,
 		LogChannel:          formConfig.LogChannel,
 		DMMessage:           formConfig.DMMessage,
+		AltDetectionEnabled: formConfig.AltDetectionEnabled,
+		AltReviewChannel:    formConfig.AltReviewChannel,
+		AltReviewThreshold:  formConfig.AltReviewThreshold,
+		AltNewAccountDays:   formConfig.AltNewAccountDays,
 	}
 
-	columns := boil.Whitelist("enabled", "verified_role", "page_content", "kick_unverified_after", "warn_unverified_after", "warn_message", "log_channel", "dm_message")
-	columnsCreate := boil.Whitelist("guild_id", "enabled", "verified_role", "page_content", "kick_unverified_after", "warn_unverified_after", "warn_message", "log_channel", "dm_message")
+	columns := boil.Whitelist("enabled", "verified_role", "page_content", "kick_unverified_after", "warn_unverified_after", "warn_message", "log_channel", "dm_message",
+		"alt_detection_enabled", "alt_review_channel", "alt_review_threshold", "alt_new_account_days")
+	columnsCreate := boil.Whitelist("guild_id", "enabled", "verified_role", "page_content", "kick_unverified_after", "warn_unverified_after", "warn_message", "log_channel", "dm_message",
+		"alt_detection_enabled", "alt_review_channel", "alt_review_threshold", "alt_new_account_days")
 	err := model.UpsertG(ctx, true, []string{"guild_id"}, columns, columnsCreate)
 	if err == nil {
 		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKey))
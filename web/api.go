@@ -0,0 +1,377 @@
+package web
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/cplogs"
+	"github.com/botlabs-gg/yagpdb/v2/common/multiratelimit"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+	"github.com/botlabs-gg/yagpdb/v2/lib/dstate"
+	"goji.io"
+	"goji.io/pattern"
+)
+
+// MaxAPIRequestBodySize is the max size of the json body of a api request
+const MaxAPIRequestBodySize = 1000000
+
+var (
+	// APIMux serves the token authenticated REST API at /api/v1/:server, plugins register their routes on it in InitWeb
+	// using APIReadHandler and APIWriteHandler
+	APIMux *goji.Mux
+
+	apiRatelimiter = multiratelimit.NewMultiRatelimiter(2, 30)
+
+	apiScopes   = make(map[string]string)
+	apiScopesMU sync.Mutex
+)
+
+// APIScope is a plugin that can be accessed through the REST API
+type APIScope struct {
+	Key  string
+	Name string
+}
+
+// APIScopes returns all the scopes tokens can be given, sorted by name
+func APIScopes() []*APIScope {
+	apiScopesMU.Lock()
+	defer apiScopesMU.Unlock()
+
+	result := make([]*APIScope, 0, len(apiScopes))
+	for k, v := range apiScopes {
+		result = append(result, &APIScope{Key: k, Name: v})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+func isAPIScope(key string) bool {
+	apiScopesMU.Lock()
+	_, ok := apiScopes[key]
+	apiScopesMU.Unlock()
+	return ok
+}
+
+func registerAPIScope(p common.Plugin) string {
+	info := p.PluginInfo()
+
+	apiScopesMU.Lock()
+	apiScopes[info.SysName] = info.Name
+	apiScopesMU.Unlock()
+
+	return info.SysName
+}
+
+func (a *APIError) Error() string {
+	return a.Message
+}
+
+func NewAPIError(status int, msg string) error {
+	return &APIError{Status: status, Message: msg}
+}
+
+// ContextAPIToken returns the api token the request was authenticated with, or nil if it's not a api request
+func ContextAPIToken(ctx context.Context) *common.APIToken {
+	if v := ctx.Value(common.ContextKeyAPIToken); v != nil {
+		return v.(*common.APIToken)
+	}
+
+	return nil
+}
+
+// bearerToken returns the token from the authorization header
+func bearerToken(header string) string {
+	const prefix = "bearer "
+	if len(header) <= len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return ""
+	}
+
+	return strings.TrimSpace(header[len(prefix):])
+}
+
+// APITokenMW authenticates the api requests, the token has to belong to the guild in the url
+func APITokenMW(inner http.Handler) http.Handler {
+	mw := func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		secret := bearerToken(r.Header.Get("Authorization"))
+		if secret == "" {
+			writeAPIError(ctx, w, NewAPIError(http.StatusUnauthorized, "Missing api token, provide it using the Authorization: Bearer <token> header"))
+			return
+		}
+
+		g, ok := ctx.Value(common.ContextKeyCurrentGuild).(*dstate.GuildSet)
+		if !ok || g == nil {
+			writeAPIError(ctx, w, NewAPIError(http.StatusNotFound, "Unknown server"))
+			return
+		}
+
+		token, err := common.GetAPITokenBySecret(ctx, secret)
+		if err != nil && err != sql.ErrNoRows {
+			writeAPIError(ctx, w, err)
+			return
+		}
+
+		if token == nil || token.GuildID != g.ID {
+			writeAPIError(ctx, w, NewAPIError(http.StatusUnauthorized, "Invalid api token"))
+			return
+		}
+
+		if !apiRatelimiter.AllowN(token.ID, time.Now(), 1) {
+			w.Header().Set("Retry-After", "1")
+			writeAPIError(ctx, w, NewAPIError(http.StatusTooManyRequests, "You're being rate limited"))
+			return
+		}
+
+		if token.LastUsedAt == nil || time.Since(*token.LastUsedAt) > time.Minute {
+			go func() {
+				err := common.MarkAPITokenUsed(context.Background(), token.ID)
+				if err != nil {
+					logger.WithError(err).Error("failed updating api token last used")
+				}
+			}()
+		}
+
+		// changes are logged in the control panel logs as made by the creator of the token
+		user := &discordgo.User{
+			ID:       token.CreatedBy,
+			Username: "API (" + token.Name + ")",
+		}
+
+		ctx = context.WithValue(ctx, common.ContextKeyAPIToken, token)
+		ctx = context.WithValue(ctx, common.ContextKeyUser, user)
+		ctx = context.WithValue(ctx, common.ContextKeyLogger, CtxLogger(ctx).WithField("api_token", token.ID))
+		inner.ServeHTTP(w, r.WithContext(ctx))
+	}
+
+	return http.HandlerFunc(mw)
+}
+
+func writeAPIJSON(w http.ResponseWriter, status int, out interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(out)
+}
+
+func writeAPIError(ctx context.Context, w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	msg := "An error occurred"
+
+	switch t := err.(type) {
+	case *APIError:
+		status = t.Status
+		msg = t.Message
+	case *PublicError:
+		status = http.StatusBadRequest
+		msg = t.msg
+	default:
+		if err == sql.ErrNoRows {
+			status = http.StatusNotFound
+			msg = "Not found"
+		} else {
+			CtxLogger(ctx).WithError(err).Error("API handler reported an error")
+		}
+	}
+
+	writeAPIJSON(w, status, map[string]interface{}{"ok": false, "error": msg})
+}
+
+// APIConfigHandler serves the current config of a config source registered with cplogs.RegisterConfigSource
+func APIConfigHandler(p common.Plugin, sourceKey string) http.Handler {
+	return APIReadHandler(p, func(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+		source := cplogs.GetConfigSource(sourceKey)
+		if source == nil {
+			return nil, NewAPIError(http.StatusNotFound, "Not found")
+		}
+
+		return source.Get(r.Context(), ContextGuild(r.Context()).ID)
+	})
+}
+
+// APIErrorHandler responds with the error, for use as the fallback handler of middlewares
+func APIErrorHandler(err error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeAPIError(r.Context(), w, err)
+	})
+}
+
+// checkAPIScope checks wether the token has access to the plugin
+func checkAPIScope(ctx context.Context, scope string, write bool) error {
+	token := ContextAPIToken(ctx)
+	if token == nil {
+		return NewAPIError(http.StatusUnauthorized, "Invalid api token")
+	}
+
+	if write && token.ReadOnly {
+		return NewAPIError(http.StatusForbidden, "This token is read only")
+	}
+
+	if !token.HasScope(scope, write) {
+		return NewAPIError(http.StatusForbidden, "This token does not have access to "+scope)
+	}
+
+	return nil
+}
+
+// APIReadHandler serves the json output of inner, the token needs access to the plugin
+func APIReadHandler(p common.Plugin, inner ControllerHandlerFuncJson) http.Handler {
+	scope := registerAPIScope(p)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if err := checkAPIScope(ctx, scope, false); err != nil {
+			writeAPIError(ctx, w, err)
+			return
+		}
+
+		out, err := inner(w, r)
+		if err != nil {
+			writeAPIError(ctx, w, err)
+			return
+		}
+
+		writeAPIJSON(w, http.StatusOK, out)
+	})
+}
+
+// APIWriteHandler decodes the json body into a new instance of formData, validates it the same way as the control panel forms
+// and then runs inner with it available through common.ContextKeyParsedForm. The token needs write access to the plugin.
+// formData can be nil for requests without a body, like deletes.
+//
+// Config changes are tracked in the control panel logs when the api path matches the control panel page of the plugin.
+func APIWriteHandler(p common.Plugin, inner ControllerHandlerFuncJson, formData interface{}) http.Handler {
+	scope := registerAPIScope(p)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if err := checkAPIScope(ctx, scope, true); err != nil {
+			writeAPIError(ctx, w, err)
+			return
+		}
+
+		if formData != nil {
+			decoded := reflect.New(reflect.TypeOf(formData)).Interface()
+			err := DecodeAPIJSON(r, decoded)
+			if err != nil {
+				writeAPIError(ctx, w, err)
+				return
+			}
+
+			guild, tmpl := GetBaseCPContextData(ctx)
+			if !ValidateForm(guild, tmpl, decoded) {
+				err = alertsAPIError(tmpl)
+				if err == nil {
+					err = NewAPIError(http.StatusBadRequest, "Invalid request body")
+				}
+
+				writeAPIError(ctx, w, err)
+				return
+			}
+
+			ctx = context.WithValue(ctx, common.ContextKeyParsedForm, decoded)
+			ctx = context.WithValue(ctx, common.ContextKeyFormOk, true)
+			r = r.WithContext(ctx)
+		}
+
+		// the form handlers expect a parsed form
+		if r.Form == nil {
+			r.Form = make(url.Values)
+		}
+
+		tracked := beginConfigChange(r)
+		out, err := inner(w, r)
+		finishConfigChange(ctx, tracked, 0)
+		if err != nil {
+			writeAPIError(ctx, w, err)
+			return
+		}
+
+		if out == nil {
+			out = map[string]interface{}{"ok": true}
+		}
+
+		writeAPIJSON(w, http.StatusOK, out)
+	})
+}
+
+// DecodeAPIJSON decodes the json body of the request into dst
+func DecodeAPIJSON(r *http.Request, dst interface{}) error {
+	dec := json.NewDecoder(io.LimitReader(r.Body, MaxAPIRequestBodySize))
+	err := dec.Decode(dst)
+	if err != nil {
+		return NewAPIError(http.StatusBadRequest, "Invalid json body: "+err.Error())
+	}
+
+	return nil
+}
+
+// APIFromController runs a control panel form handler as a api handler, the error alerts it adds are returned as a error
+func APIFromController(inner ControllerHandlerFunc) ControllerHandlerFuncJson {
+	return func(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+		_, tmpl := GetBaseCPContextData(r.Context())
+
+		// the control panel handlers render a page or redirect afterwards, which we don't want in the api
+		_, err := inner(discardResponseWriter{}, r)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := alertsAPIError(tmpl); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	}
+}
+
+// alertsAPIError returns the error alerts in tmpl as a single bad request error, or nil if there is none
+func alertsAPIError(tmpl TemplateData) error {
+	var msgs []string
+	for _, v := range tmpl.Alerts() {
+		if v.Style == AlertDanger {
+			msgs = append(msgs, v.Message)
+		}
+	}
+
+	if len(msgs) < 1 {
+		return nil
+	}
+
+	return NewAPIError(http.StatusBadRequest, strings.Join(msgs, "\n"))
+}
+
+type discardResponseWriter struct{}
+
+func (d discardResponseWriter) Header() http.Header         { return make(http.Header) }
+func (d discardResponseWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (d discardResponseWriter) WriteHeader(statusCode int)  {}
+
+// APIParamInt64 parses the url parameter as a int64, returning a not found error if it's not a number
+func APIParamInt64(r *http.Request, key string) (int64, error) {
+	v, err := strconv.ParseInt(ParamOrEmpty(r, key), 10, 64)
+	if err != nil {
+		return 0, NewAPIError(http.StatusNotFound, "Not found")
+	}
+
+	return v, nil
+}
+
+// SetContextParam sets a url parameter read by pat.Param, for running handlers on a id that was not part of the url
+func SetContextParam(ctx context.Context, key string, value int64) context.Context {
+	return context.WithValue(ctx, pattern.Variable(key), strconv.FormatInt(value, 10))
+}
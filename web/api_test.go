@@ -0,0 +1,71 @@
+package web
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/lib/dstate"
+)
+
+func TestBearerToken(t *testing.T) {
+	cases := map[string]string{
+		"Bearer yagapi_abc":   "yagapi_abc",
+		"bearer  yagapi_abc ": "yagapi_abc",
+		"Basic yagapi_abc":    "",
+		"Bearer ":             "",
+		"":                    "",
+	}
+
+	for header, want := range cases {
+		if got := bearerToken(header); got != want {
+			t.Errorf("%q: got %q, wanted %q", header, got, want)
+		}
+	}
+}
+
+func TestAPITokenHasScope(t *testing.T) {
+	token := &common.APIToken{Scopes: []string{"custom_commands"}}
+	if !token.HasScope("custom_commands", true) || token.HasScope("automod_v2", false) {
+		t.Error("incorrect scopes")
+	}
+
+	token.ReadOnly = true
+	if token.HasScope("custom_commands", true) || !token.HasScope("custom_commands", false) {
+		t.Error("read only token should only be able to read")
+	}
+}
+
+func TestCurrentCPPageAPI(t *testing.T) {
+	ctx := context.WithValue(context.Background(), common.ContextKeyCurrentGuild, &dstate.GuildSet{GuildState: dstate.GuildState{ID: 10}})
+
+	cases := map[string]string{
+		"/manage/10/moderation/":             "moderation",
+		"/api/v1/10/moderation":              "moderation",
+		"/api/v1/10/customcommands/commands": "customcommands/commands",
+		"/api/v1/11/moderation":              "",
+		"/api/10/channelperms/1":             "",
+	}
+
+	for path, want := range cases {
+		r := httptest.NewRequest("GET", path, nil)
+		if got := CurrentCPPage(ctx, r); got != want {
+			t.Errorf("%s: got %q, wanted %q", path, got, want)
+		}
+	}
+}
+
+func TestAlertsAPIError(t *testing.T) {
+	tmpl := TemplateData(make(map[string]interface{}))
+	tmpl.AddAlerts(WarningAlert("not an error"))
+	if err := alertsAPIError(tmpl); err != nil {
+		t.Errorf("warnings should not be errors: %v", err)
+	}
+
+	tmpl.AddAlerts(ErrorAlert("first"), ErrorAlert("second"))
+	err, ok := alertsAPIError(tmpl).(*APIError)
+	if !ok || err.Status != 400 || err.Message != "first\nsecond" {
+		t.Errorf("incorrect error: %#v", err)
+	}
+}
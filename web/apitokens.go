@@ -0,0 +1,120 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/cplogs"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+	"goji.io/pat"
+)
+
+var (
+	panelLogKeyCreatedAPIToken = cplogs.RegisterActionFormat(&cplogs.ActionFormat{
+		Key:          "api_token_created",
+		FormatString: "Created the api token %s",
+	})
+	panelLogKeyDeletedAPIToken = cplogs.RegisterActionFormat(&cplogs.ActionFormat{
+		Key:          "api_token_deleted",
+		FormatString: "Revoked the api token %s",
+	})
+)
+
+type CreateAPITokenForm struct {
+	Name     string `valid:",1,100,trimspace"`
+	Scopes   []string
+	ReadOnly bool
+}
+
+func HandleAPITokens(w http.ResponseWriter, r *http.Request) (TemplateData, error) {
+	ctx := r.Context()
+	g, templateData := GetBaseCPContextData(ctx)
+
+	tokens, err := common.GetAPITokens(ctx, g.ID)
+	if err != nil {
+		return templateData, err
+	}
+
+	templateData["APITokens"] = tokens
+	templateData["APIScopes"] = APIScopes()
+	templateData["MaxAPITokens"] = common.MaxAPITokens
+	return templateData, nil
+}
+
+func HandleCreateAPIToken(w http.ResponseWriter, r *http.Request) (TemplateData, error) {
+	ctx := r.Context()
+	g, templateData := GetBaseCPContextData(ctx)
+	templateData["VisibleURL"] = "/manage/" + discordgo.StrID(g.ID) + "/apitokens"
+
+	form := ctx.Value(common.ContextKeyParsedForm).(*CreateAPITokenForm)
+
+	scopes := make([]string, 0, len(form.Scopes))
+	for _, v := range form.Scopes {
+		if !isAPIScope(v) {
+			return templateData, NewPublicError("Unknown scope: ", v)
+		}
+
+		if !common.ContainsStringSlice(scopes, v) {
+			scopes = append(scopes, v)
+		}
+	}
+
+	if len(scopes) < 1 {
+		return templateData, NewPublicError("The token needs access to atleast one plugin")
+	}
+
+	existing, err := common.GetAPITokens(ctx, g.ID)
+	if err != nil {
+		return templateData, err
+	}
+
+	if len(existing) >= common.MaxAPITokens {
+		return templateData, NewPublicError("Max ", common.MaxAPITokens, " api tokens per server")
+	}
+
+	user := ContextUser(ctx)
+	token, secret, err := common.CreateAPIToken(ctx, g.ID, form.Name, scopes, form.ReadOnly, user.ID, user.Username)
+	if err != nil {
+		return templateData, err
+	}
+
+	// the token is only shown this once, we only store its hash
+	templateData["CreatedAPIToken"] = token
+	templateData["CreatedAPITokenSecret"] = secret
+
+	go cplogs.RetryAddEntry(NewLogEntryFromContext(ctx, panelLogKeyCreatedAPIToken, &cplogs.Param{Type: cplogs.ParamTypeString, Value: token.Name}))
+	return templateData, nil
+}
+
+func HandleDeleteAPIToken(w http.ResponseWriter, r *http.Request) (TemplateData, error) {
+	ctx := r.Context()
+	g, templateData := GetBaseCPContextData(ctx)
+	templateData["VisibleURL"] = "/manage/" + discordgo.StrID(g.ID) + "/apitokens"
+
+	tokenID, err := strconv.ParseInt(pat.Param(r, "token"), 10, 64)
+	if err != nil {
+		return templateData, NewPublicError("Invalid token")
+	}
+
+	tokens, err := common.GetAPITokens(ctx, g.ID)
+	if err != nil {
+		return templateData, err
+	}
+
+	for _, v := range tokens {
+		if v.ID != tokenID {
+			continue
+		}
+
+		err = common.DeleteAPIToken(ctx, g.ID, tokenID)
+		if err != nil {
+			return templateData, err
+		}
+
+		go cplogs.RetryAddEntry(NewLogEntryFromContext(ctx, panelLogKeyDeletedAPIToken, &cplogs.Param{Type: cplogs.ParamTypeString, Value: v.Name}))
+		return templateData, nil
+	}
+
+	return templateData, NewPublicError("Token not found")
+}
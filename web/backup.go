@@ -0,0 +1,223 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/cplogs"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+	"github.com/botlabs-gg/yagpdb/v2/lib/dstate"
+	"github.com/mediocregopher/radix/v3"
+)
+
+// MaxBackupArchiveSize is the max size of an uploaded backup archive
+const MaxBackupArchiveSize = 5000000
+
+var (
+	panelLogKeyDownloadedBackup = cplogs.RegisterActionFormat(&cplogs.ActionFormat{
+		Key:          "backup_downloaded",
+		FormatString: "Downloaded a server backup",
+	})
+	panelLogKeyRestoredBackup = cplogs.RegisterActionFormat(&cplogs.ActionFormat{
+		Key:          "backup_restored",
+		FormatString: "Restored a server backup from %s",
+	})
+)
+
+type RestoreBackupConfirmForm struct {
+	Plugins []string
+}
+
+func keyPendingRestore(guildID, userID int64) string {
+	return "backup_pending_restore:" + discordgo.StrID(guildID) + ":" + discordgo.StrID(userID)
+}
+
+// guildBackupEntities returns the roles and channels of the guild, used to map ids when restoring a backup
+func guildBackupEntities(g *dstate.GuildSet) (roles []*common.BackupEntity, channels []*common.BackupEntity) {
+	for _, v := range g.Roles {
+		roles = append(roles, &common.BackupEntity{ID: v.ID, Name: v.Name})
+	}
+
+	for _, v := range g.Channels {
+		channels = append(channels, &common.BackupEntity{ID: v.ID, Name: v.Name, Type: int(v.Type)})
+	}
+
+	return
+}
+
+func HandleBackup(w http.ResponseWriter, r *http.Request) (TemplateData, error) {
+	_, templateData := GetBaseCPContextData(r.Context())
+
+	var names []string
+	for _, v := range common.BackupPlugins() {
+		names = append(names, v.PluginInfo().Name)
+	}
+	templateData["BackupPlugins"] = names
+
+	return templateData, nil
+}
+
+// HandleDownloadBackup sends the backup of all the plugin settings as a json file
+func HandleDownloadBackup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	g, _ := GetBaseCPContextData(ctx)
+
+	roles, channels := guildBackupEntities(g)
+	archive, err := common.CreateBackupArchive(ctx, g.ID, g.Name, roles, channels)
+	if err != nil {
+		CtxLogger(ctx).WithError(err).Error("failed creating backup")
+		http.Error(w, "Failed creating backup", http.StatusInternalServerError)
+		return
+	}
+
+	encoded, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		CtxLogger(ctx).WithError(err).Error("failed encoding backup")
+		http.Error(w, "Failed creating backup", http.StatusInternalServerError)
+		return
+	}
+
+	filename := fmt.Sprintf("backup-%d-%s.json", g.ID, time.Now().UTC().Format("2006-01-02"))
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	w.Write(encoded)
+
+	go cplogs.RetryAddEntry(NewLogEntryFromContext(ctx, panelLogKeyDownloadedBackup))
+}
+
+// HandleUploadBackup reads the uploaded archive and does a dry run of the restore, the archive is
+// kept for a while so the restore can be confirmed
+func HandleUploadBackup(w http.ResponseWriter, r *http.Request) (TemplateData, error) {
+	ctx := r.Context()
+	g, templateData := GetBaseCPContextData(ctx)
+	templateData["VisibleURL"] = "/manage/" + discordgo.StrID(g.ID) + "/backup"
+
+	file, _, err := r.FormFile("Archive")
+	if err != nil {
+		return templateData, NewPublicError("No backup file uploaded")
+	}
+	defer file.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(file, MaxBackupArchiveSize+1))
+	if err != nil {
+		return templateData, err
+	}
+
+	if len(raw) > MaxBackupArchiveSize {
+		return templateData, NewPublicError("Backup file too big")
+	}
+
+	archive, err := decodeBackupArchive(raw)
+	if err != nil {
+		return templateData, err
+	}
+
+	user := ContextUser(ctx)
+	err = common.RedisPool.Do(radix.Cmd(nil, "SET", keyPendingRestore(g.ID, user.ID), string(raw), "EX", "3600"))
+	if err != nil {
+		return templateData, err
+	}
+
+	report := restoreBackup(ctx, g, archive, true, nil)
+	templateData["RestoreReport"] = report
+	templateData["RestoreSource"] = archive
+	return templateData, nil
+}
+
+// HandleConfirmRestoreBackup restores the plugins selected from the archive uploaded earlier
+func HandleConfirmRestoreBackup(w http.ResponseWriter, r *http.Request) (TemplateData, error) {
+	ctx := r.Context()
+	g, templateData := GetBaseCPContextData(ctx)
+	templateData["VisibleURL"] = "/manage/" + discordgo.StrID(g.ID) + "/backup"
+
+	form := ctx.Value(common.ContextKeyParsedForm).(*RestoreBackupConfirmForm)
+	if len(form.Plugins) < 1 {
+		return templateData, NewPublicError("No plugins selected")
+	}
+
+	user := ContextUser(ctx)
+	var raw string
+	err := common.RedisPool.Do(radix.Cmd(&raw, "GET", keyPendingRestore(g.ID, user.ID)))
+	if err != nil {
+		return templateData, err
+	}
+
+	if raw == "" {
+		return templateData, NewPublicError("The uploaded backup expired, upload it again")
+	}
+
+	archive, err := decodeBackupArchive([]byte(raw))
+	if err != nil {
+		return templateData, err
+	}
+
+	report := restoreBackup(ctx, g, archive, false, form.Plugins)
+	templateData["RestoreReport"] = report
+
+	common.RedisPool.Do(radix.Cmd(nil, "DEL", keyPendingRestore(g.ID, user.ID)))
+
+	source := archive.GuildName + " (" + strconv.FormatInt(archive.GuildID, 10) + ")"
+	go cplogs.RetryAddEntry(NewLogEntryFromContext(ctx, panelLogKeyRestoredBackup, &cplogs.Param{Type: cplogs.ParamTypeString, Value: source}))
+
+	return templateData, nil
+}
+
+func decodeBackupArchive(raw []byte) (*common.BackupArchive, error) {
+	var archive common.BackupArchive
+	err := json.Unmarshal(raw, &archive)
+	if err != nil || archive.Version < 1 {
+		return nil, NewPublicError("Invalid backup file")
+	}
+
+	if archive.Version > common.BackupArchiveVersion {
+		return nil, NewPublicError("The backup was made with a newer version of the bot")
+	}
+
+	return &archive, nil
+}
+
+func restoreBackup(ctx context.Context, g *dstate.GuildSet, archive *common.BackupArchive, dryRun bool, onlyPlugins []string) *common.BackupRestoreReport {
+	roles, channels := guildBackupEntities(g)
+	restore := common.NewBackupRestore(archive, g.ID, roles, channels, dryRun)
+	return common.RestoreBackupArchive(ctx, archive, restore, onlyPlugins)
+}
+
+var _ common.PluginWithBackup = (*ControlPanelPlugin)(nil)
+
+func (p *ControlPanelPlugin) BackupVersion() int {
+	return 1
+}
+
+func (p *ControlPanelPlugin) ExportBackup(ctx context.Context, guildID int64) (interface{}, error) {
+	return getCoreConfigSnapshot(ctx, guildID)
+}
+
+func (p *ControlPanelPlugin) RestoreBackup(ctx context.Context, guildID int64, data json.RawMessage, restore *common.BackupRestore) error {
+	var snapshot coreConfigSnapshot
+	err := json.Unmarshal(data, &snapshot)
+	if err != nil {
+		return err
+	}
+
+	if snapshot.Config != nil {
+		snapshot.Config.AllowedReadOnlyRoles = restore.Roles(snapshot.Config.AllowedReadOnlyRoles)
+		snapshot.Config.AllowedWriteRoles = restore.Roles(snapshot.Config.AllowedWriteRoles)
+	}
+
+	for _, v := range snapshot.PagePermissions {
+		v.ReadRoles = restore.Roles(v.ReadRoles)
+		v.WriteRoles = restore.Roles(v.WriteRoles)
+	}
+
+	if restore.DryRun {
+		return nil
+	}
+
+	return saveCoreConfigSnapshot(ctx, guildID, &snapshot)
+}
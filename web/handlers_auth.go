@@ -261,3 +261,53 @@ func HasAccesstoGuildSettings(userID int64, g *common.GuildWithConnected, config
 
 	return false
 }
+
+// restrictedPages can't be unlocked through the per page permissions, they give access to the settings of the whole server
+// (the control panel logs include the config snapshots of every plugin)
+var restrictedPages = []string{"core", "backup", "apitokens", "cplogs"}
+
+func isRestrictedPage(path string) bool {
+	for _, v := range restrictedPages {
+		if common.PageMatches(v, path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetUserPageAccessLevel returns the access a member with the provided roles has to the control panel page at path
+// through the per page permissions, the restricted pages can never be unlocked this way
+func GetUserPageAccessLevel(path string, roles []int64, perms []*common.PagePermission) (hasRead bool, hasWrite bool) {
+	if len(roles) < 1 || isRestrictedPage(path) {
+		return false, false
+	}
+
+	for _, v := range perms {
+		if !v.Matches(path) {
+			continue
+		}
+
+		if common.ContainsInt64SliceOneOf(roles, v.WriteRoles) {
+			return true, true
+		}
+
+		if common.ContainsInt64SliceOneOf(roles, v.ReadRoles) {
+			hasRead = true
+		}
+	}
+
+	return hasRead, false
+}
+
+// UserAllowedPages returns the pages a member with the provided roles has atleast read access to through the per page permissions
+func UserAllowedPages(roles []int64, perms []*common.PagePermission) []string {
+	var result []string
+	for _, v := range perms {
+		if read, _ := GetUserPageAccessLevel(v.Page, roles, perms); read {
+			result = append(result, v.Page)
+		}
+	}
+
+	return result
+}
@@ -3,6 +3,7 @@ package web
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"fmt"
 	"html/template"
 	"io"
@@ -11,6 +12,7 @@ import (
 	"net/http"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -109,9 +111,134 @@ func HandleCPLogs(w http.ResponseWriter, r *http.Request) interface{} {
 	} else {
 		templateData["entries"] = logs
 	}
+
+	changes, err := cplogs.GetConfigChanges(activeGuild.ID, 50)
+	if err != nil {
+		templateData.AddAlerts(ErrorAlert("Failed retrieving config changes", err))
+	} else {
+		templateData["ConfigChanges"] = changes
+	}
+
+	templateData["TrackedConfigs"] = cplogs.ConfigSourceNames()
 	return templateData
 }
 
+// HandleRevertConfigChange restores the config to how it was after the change
+func HandleRevertConfigChange(w http.ResponseWriter, r *http.Request) (TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := GetBaseCPContextData(ctx)
+	templateData["VisibleURL"] = "/manage/" + discordgo.StrID(activeGuild.ID) + "/cplogs"
+
+	changeID, _ := strconv.ParseInt(pat.Param(r, "change"), 10, 64)
+	change, err := cplogs.GetConfigChange(activeGuild.ID, changeID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return templateData, NewPublicError("Config change not found")
+		}
+
+		return templateData, err
+	}
+
+	source := cplogs.GetConfigSource(change.Source)
+	if source == nil || source.Revert == nil {
+		return templateData, NewPublicError("This config can't be reverted")
+	}
+
+	if !hasPageWriteAccess(ctx, activeGuild.ID, source.Page) {
+		return templateData, NewPublicError("You don't have write access to ", source.Name)
+	}
+
+	tracked := source.Begin(ctx, activeGuild.ID)
+	err = source.Revert(ctx, activeGuild.ID, change.After)
+	finishConfigChange(ctx, tracked, change.LocalID)
+	if err != nil {
+		return templateData, err
+	}
+
+	go cplogs.RetryAddEntry(NewLogEntryFromContext(ctx, panelLogKeyRevertConfig,
+		&cplogs.Param{Type: cplogs.ParamTypeString, Value: source.Name}, &cplogs.Param{Type: cplogs.ParamTypeInt, Value: change.LocalID}))
+
+	return templateData, nil
+}
+
+// hasPageWriteAccess returns true if the current member can edit the page, members that only have access to some pages
+// may have access to the page they're on but not the page passed
+func hasPageWriteAccess(ctx context.Context, guildID int64, page string) bool {
+	if ContextAllowedPages(ctx) == nil {
+		// access to the whole control panel, read only members can't make post requests
+		return true
+	}
+
+	member := ContextMember(ctx)
+	if member == nil {
+		return false
+	}
+
+	_, write := GetUserPageAccessLevel(page, member.Roles, common.GetPagePermissionsCached(guildID))
+	return write
+}
+
+type coreConfigSnapshot struct {
+	Config          *models.CoreConfig
+	PagePermissions []*common.PagePermission
+}
+
+func getCoreConfigSnapshot(ctx context.Context, guildID int64) (*coreConfigSnapshot, error) {
+	conf, err := models.FindCoreConfigG(ctx, guildID)
+	if err == sql.ErrNoRows {
+		conf = &models.CoreConfig{GuildID: guildID}
+	} else if err != nil {
+		return nil, err
+	}
+
+	perms, err := common.GetPagePermissions(ctx, guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &coreConfigSnapshot{Config: conf, PagePermissions: perms}, nil
+}
+
+func saveCoreConfigSnapshot(ctx context.Context, guildID int64, snapshot *coreConfigSnapshot) error {
+	if snapshot.Config == nil {
+		snapshot.Config = &models.CoreConfig{}
+	}
+	snapshot.Config.GuildID = guildID
+
+	err := common.CoreConfigSave(ctx, snapshot.Config)
+	if err != nil {
+		return err
+	}
+
+	err = common.SavePagePermissions(ctx, guildID, snapshot.PagePermissions)
+	if err != nil {
+		return err
+	}
+
+	pubsub.Publish("evict_core_config_cache", guildID, nil)
+	return nil
+}
+
+func init() {
+	cplogs.RegisterConfigSource(&cplogs.ConfigSource{
+		Key:  "core",
+		Name: "Control panel access",
+		Page: "core",
+		Get: func(ctx context.Context, guildID int64) (interface{}, error) {
+			return getCoreConfigSnapshot(ctx, guildID)
+		},
+		Revert: func(ctx context.Context, guildID int64, snapshot string) error {
+			var restored coreConfigSnapshot
+			err := cplogs.RestoreSnapshot(&restored, snapshot)
+			if err != nil {
+				return err
+			}
+
+			return saveCoreConfigSnapshot(ctx, guildID, &restored)
+		},
+	})
+}
+
 func HandleSelectServer(w http.ResponseWriter, r *http.Request) interface{} {
 	_, tmpl := GetCreateTemplateData(r.Context())
 
@@ -462,8 +589,77 @@ func (p *ControlPanelPlugin) ServerHomeWidgetOrder() int {
 }
 
 type CoreConfigPostForm struct {
-	AllowedReadOnlyRoles []int64 `valid:"role,true"`
-	AllowedWriteRoles    []int64 `valid:"role,true"`
+	AllowedReadOnlyRoles []int64              `valid:"role,true"`
+	AllowedWriteRoles    []int64              `valid:"role,true"`
+	PagePermissions      []PagePermissionForm `valid:"traverse"`
+}
+
+type PagePermissionForm struct {
+	Page       string
+	ReadRoles  []int64 `valid:"role,true"`
+	WriteRoles []int64 `valid:"role,true"`
+}
+
+// PagePermissionRow is a single page in the per page access table on the core settings page
+type PagePermissionRow struct {
+	Page       string
+	Name       string
+	Category   string
+	ReadRoles  []int64
+	WriteRoles []int64
+}
+
+var pagePermissionCategories = []string{SidebarCategoryTopLevel, SidebarCategoryCore, SidebarCategoryCustomCommands, SidebarCategoryModeration,
+	SidebarCategoryFeeds, SidebarCategoryTools, SidebarCategoryFun}
+
+// pagePermissionRows returns all the pages that can be given per page access to, in the same order as in the sidebar
+func pagePermissionRows(perms []*common.PagePermission) []*PagePermissionRow {
+	result := make([]*PagePermissionRow, 0)
+	for _, category := range pagePermissionCategories {
+	OUTER:
+		for _, item := range sideBarItems[category] {
+			page := strings.Trim(item.URL, "/")
+			if item.External || page == "" || isRestrictedPage(page) {
+				continue
+			}
+
+			for _, v := range result {
+				if v.Page == page {
+					continue OUTER
+				}
+			}
+
+			row := &PagePermissionRow{
+				Page:     page,
+				Name:     item.Name,
+				Category: category,
+			}
+
+			for _, v := range perms {
+				if v.Page == page {
+					row.ReadRoles = v.ReadRoles
+					row.WriteRoles = v.WriteRoles
+					break
+				}
+			}
+
+			result = append(result, row)
+		}
+	}
+
+	return result
+}
+
+func HandleGetCoreSettings(w http.ResponseWriter, r *http.Request) (TemplateData, error) {
+	g, templateData := GetBaseCPContextData(r.Context())
+
+	perms, err := common.GetPagePermissions(r.Context(), g.ID)
+	if err != nil {
+		return templateData, err
+	}
+
+	templateData["PagePermissions"] = pagePermissionRows(perms)
+	return templateData, nil
 }
 
 func HandlePostCoreSettings(w http.ResponseWriter, r *http.Request) (TemplateData, error) {
@@ -482,6 +678,28 @@ func HandlePostCoreSettings(w http.ResponseWriter, r *http.Request) (TemplateDat
 		return templateData, err
 	}
 
+	// only accept the pages that are actually listed
+	validPages := pagePermissionRows(nil)
+	pagePerms := make([]*common.PagePermission, 0, len(form.PagePermissions))
+	for _, v := range form.PagePermissions {
+		page := strings.Trim(v.Page, "/")
+		for _, row := range validPages {
+			if row.Page == page {
+				pagePerms = append(pagePerms, &common.PagePermission{
+					Page:       page,
+					ReadRoles:  v.ReadRoles,
+					WriteRoles: v.WriteRoles,
+				})
+				break
+			}
+		}
+	}
+
+	err = common.SavePagePermissions(r.Context(), g.ID, pagePerms)
+	if err != nil {
+		return templateData, err
+	}
+
 	pubsub.Publish("evict_core_config_cache", g.ID, nil)
 
 	templateData["CoreConfig"] = m
@@ -536,6 +754,11 @@ func GetUserManagedGuilds(ctx context.Context) ([]*common.GuildWithConnected, er
 			conf := common.GetCoreServerConfCached(gwc.ID)
 			if HasAccesstoGuildSettings(user.ID, gwc, conf, basicRoleProvider, false) {
 				nilled[j] = gwc
+			} else if gwc.Connected {
+				// they may still have been given access to some of the pages
+				if hasAnyPageAccessCached(gwc.ID, user.ID) {
+					nilled[j] = gwc
+				}
 			}
 			wg.Done()
 		}(i, g)
@@ -551,6 +774,23 @@ func GetUserManagedGuilds(ctx context.Context) ([]*common.GuildWithConnected, er
 	return accessibleGuilds, nil
 }
 
+// pageAccessCache caches whether users were given access to any of the pages of a server, so listing the servers
+// doesn't look up the member on every server with page permissions on every page load
+var pageAccessCache = cache.New(time.Minute, time.Minute*5)
+
+func hasAnyPageAccessCached(guildID, userID int64) bool {
+	key := discordgo.StrID(guildID) + ":" + discordgo.StrID(userID)
+	if v, ok := pageAccessCache.Get(key); ok {
+		return v.(bool)
+	}
+
+	perms := common.GetPagePermissionsCached(guildID)
+	hasAccess := len(perms) > 0 && len(UserAllowedPages(basicRoleProvider(guildID, userID), perms)) > 0
+
+	pageAccessCache.Set(key, hasAccess, cache.DefaultExpiration)
+	return hasAccess
+}
+
 func GetUserGuilds(ctx context.Context) ([]*common.GuildWithConnected, error) {
 	session := DiscordSessionFromContext(ctx)
 	user := ContextUser(ctx)
@@ -322,6 +322,13 @@ func RequireActiveServer(inner http.Handler) http.Handler {
 func RequireServerAdminMiddleware(inner http.Handler) http.Handler {
 	mw := func(w http.ResponseWriter, r *http.Request) {
 		if !ContextIsAdmin(r.Context()) {
+			if allowed := ContextAllowedPages(r.Context()); len(allowed) > 0 && strings.EqualFold(r.Method, "GET") {
+				// they only have access to some pages, send them to the first one
+				guild := ContextGuild(r.Context())
+				http.Redirect(w, r, "/manage/"+discordgo.StrID(guild.ID)+"/"+allowed[0], http.StatusTemporaryRedirect)
+				return
+			}
+
 			if DiscordSessionFromContext(r.Context()) == nil {
 				// redirect them to log in and return here afterwards
 				http.Redirect(w, r, "/login?goto="+url.QueryEscape(r.RequestURI), http.StatusTemporaryRedirect)
@@ -594,7 +601,9 @@ func SimpleConfigSaverHandler(t SimpleConfigSaver, extraHandler http.Handler, ke
 			return
 		}
 
+		tracked := beginConfigChange(r)
 		err := form.Save(g.ID)
+		finishConfigChange(ctx, tracked, 0)
 		if !CheckErr(templateData, err, "Failed saving config", CtxLogger(ctx).Error) {
 			templateData.AddAlerts(SucessAlert("Sucessfully saved! :')"))
 			go cplogs.RetryAddEntry(NewLogEntryFromContext(ctx, key))
@@ -656,7 +665,9 @@ func ControllerPostHandler(mainHandler ControllerHandlerFunc, extraHandler http.
 			}
 		}
 
+		tracked := beginConfigChange(r)
 		data, err := mainHandler(w, r)
+		finishConfigChange(ctx, tracked, 0)
 		if data == nil {
 			data = templateData
 		}
@@ -684,6 +695,31 @@ func ControllerPostHandler(mainHandler ControllerHandlerFunc, extraHandler http.
 	return handler
 }
 
+// beginConfigChange snapshots the config of the plugin the request is for, if it has its history tracked
+func beginConfigChange(r *http.Request) *cplogs.TrackedChange {
+	guild := ContextGuild(r.Context())
+	if guild == nil {
+		return nil
+	}
+
+	page := CurrentCPPage(r.Context(), r)
+	if page == "" {
+		return nil
+	}
+
+	return cplogs.BeginConfigChange(r.Context(), guild.ID, page)
+}
+
+// finishConfigChange stores the config change started with beginConfigChange if anything changed
+func finishConfigChange(ctx context.Context, tracked *cplogs.TrackedChange, revertedFrom int64) {
+	user := ContextUser(ctx)
+	if tracked == nil || user == nil {
+		return
+	}
+
+	tracked.Finish(ctx, user.ID, user.Username, revertedFrom)
+}
+
 func checkControllerError(ctx context.Context, data TemplateData, err error) {
 	if err == nil {
 		return
@@ -777,6 +813,16 @@ func SetGuildMemberMiddleware(inner http.Handler) http.Handler {
 		}
 
 		read, write := IsAdminRequest(ctx, r)
+		if !read && !write {
+			// no access to the whole control panel, but they may still have been given access to some pages
+			var allowedPages []string
+			read, write, allowedPages = IsPageAccessRequest(ctx, r)
+			if len(allowedPages) > 0 {
+				ctx = context.WithValue(ctx, common.ContextKeyAllowedPages, allowedPages)
+				ctx = SetContextTemplateData(ctx, map[string]interface{}{"SidebarItems": filterSidebarItems(allowedPages)})
+			}
+		}
+
 		ctx = SetContextTemplateData(ctx, map[string]interface{}{"IsAdmin": read || write})
 		ctx = context.WithValue(ctx, common.ContextKeyIsAdmin, read || write)
 
@@ -0,0 +1,125 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/common/cplogs"
+	"github.com/botlabs-gg/yagpdb/v2/common/scheduledevents2"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+	"goji.io/pat"
+)
+
+var (
+	panelLogKeyCancelledScheduledEvent = cplogs.RegisterActionFormat(&cplogs.ActionFormat{
+		Key:          "scheduled_event_cancelled",
+		FormatString: "Cancelled the scheduled event #%d",
+	})
+	panelLogKeyRescheduledScheduledEvent = cplogs.RegisterActionFormat(&cplogs.ActionFormat{
+		Key:          "scheduled_event_rescheduled",
+		FormatString: "Rescheduled the scheduled event #%d",
+	})
+)
+
+// ScheduledEventView is a upcoming scheduled event as shown in the control panel
+type ScheduledEventView struct {
+	ID          int64
+	EventName   string
+	Description string
+	TriggersAt  time.Time
+}
+
+type RescheduleEventForm struct {
+	In string `valid:",1,100,trimspace"`
+}
+
+func HandleScheduledEvents(w http.ResponseWriter, r *http.Request) (TemplateData, error) {
+	ctx := r.Context()
+	g, templateData := GetBaseCPContextData(ctx)
+
+	eventName := r.URL.Query().Get("type")
+	events, err := scheduledevents2.GetUpcomingEvents(ctx, g.ID, eventName, scheduledevents2.MaxListedEvents)
+	if err != nil {
+		return templateData, err
+	}
+
+	views := make([]*ScheduledEventView, 0, len(events))
+	for _, v := range events {
+		views = append(views, &ScheduledEventView{
+			ID:          v.ID,
+			EventName:   v.EventName,
+			Description: scheduledevents2.DescribeEvent(v),
+			TriggersAt:  v.TriggersAt,
+		})
+	}
+
+	counts, err := scheduledevents2.CountUpcomingEvents(ctx, g.ID)
+	if err != nil {
+		return templateData, err
+	}
+
+	templateData["ScheduledEvents"] = views
+	templateData["ScheduledEventCounts"] = counts
+	templateData["ScheduledEventsFilter"] = eventName
+	templateData["MaxListedEvents"] = scheduledevents2.MaxListedEvents
+	return templateData, nil
+}
+
+func scheduledEventParam(r *http.Request) (int64, error) {
+	id, err := strconv.ParseInt(pat.Param(r, "event"), 10, 64)
+	if err != nil {
+		return 0, NewPublicError("Invalid event")
+	}
+
+	return id, nil
+}
+
+func HandleCancelScheduledEvent(w http.ResponseWriter, r *http.Request) (TemplateData, error) {
+	ctx := r.Context()
+	g, templateData := GetBaseCPContextData(ctx)
+	templateData["VisibleURL"] = "/manage/" + discordgo.StrID(g.ID) + "/scheduledevents"
+
+	id, err := scheduledEventParam(r)
+	if err != nil {
+		return templateData, err
+	}
+
+	err = scheduledevents2.CancelEvent(ctx, g.ID, id)
+	if err == scheduledevents2.ErrEventNotFound {
+		return templateData, NewPublicError("Event not found, it may already have been triggered")
+	} else if err != nil {
+		return templateData, err
+	}
+
+	go cplogs.RetryAddEntry(NewLogEntryFromContext(ctx, panelLogKeyCancelledScheduledEvent, &cplogs.Param{Type: cplogs.ParamTypeInt, Value: id}))
+	return templateData, nil
+}
+
+func HandleRescheduleScheduledEvent(w http.ResponseWriter, r *http.Request) (TemplateData, error) {
+	ctx := r.Context()
+	g, templateData := GetBaseCPContextData(ctx)
+	templateData["VisibleURL"] = "/manage/" + discordgo.StrID(g.ID) + "/scheduledevents"
+
+	id, err := scheduledEventParam(r)
+	if err != nil {
+		return templateData, err
+	}
+
+	form := ctx.Value(common.ContextKeyParsedForm).(*RescheduleEventForm)
+	in, err := common.ParseDuration(form.In)
+	if err != nil || in <= 0 {
+		return templateData, NewPublicError("Invalid duration, use something like 1h30m")
+	}
+
+	_, err = scheduledevents2.RescheduleEvent(ctx, g.ID, id, time.Now().Add(in))
+	if err == scheduledevents2.ErrEventNotFound {
+		return templateData, NewPublicError("Event not found, it may already have been triggered")
+	} else if err != nil {
+		return templateData, err
+	}
+
+	go cplogs.RetryAddEntry(NewLogEntryFromContext(ctx, panelLogKeyRescheduledScheduledEvent, &cplogs.Param{Type: cplogs.ParamTypeInt, Value: id}))
+	return templateData, nil
+}
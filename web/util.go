@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/botlabs-gg/yagpdb/v2/bot"
@@ -26,6 +27,11 @@ var panelLogKeyCore = cplogs.RegisterActionFormat(&cplogs.ActionFormat{
 	FormatString: "Updated core config",
 })
 
+var panelLogKeyRevertConfig = cplogs.RegisterActionFormat(&cplogs.ActionFormat{
+	Key:          "revert_config_change",
+	FormatString: "Reverted %s to the version after change #%d",
+})
+
 func SetContextTemplateData(ctx context.Context, data map[string]interface{}) context.Context {
 	// Check for existing data
 	if val := ctx.Value(common.ContextKeyTemplateData); val != nil {
@@ -242,6 +248,67 @@ func IsAdminRequest(ctx context.Context, r *http.Request) (read bool, write bool
 	return false, false
 }
 
+// CurrentCPPage returns the control panel page the request is for relative to the server, e.g "customcommands/database"
+// or an empty string if this is not a control panel request
+func CurrentCPPage(ctx context.Context, r *http.Request) string {
+	g, ok := ctx.Value(common.ContextKeyCurrentGuild).(*dstate.GuildSet)
+	if !ok || g == nil {
+		return ""
+	}
+
+	// the api paths mirror the control panel pages
+	for _, prefix := range []string{"/manage/", "/api/v1/"} {
+		prefix += strconv.FormatInt(g.ID, 10) + "/"
+		if strings.HasPrefix(r.URL.Path, prefix) {
+			return strings.Trim(strings.TrimPrefix(r.URL.Path, prefix), "/")
+		}
+	}
+
+	return ""
+}
+
+// IsPageAccessRequest checks the per page permissions of the current member, returning the access they have to the requested page
+// and all the pages they can access
+func IsPageAccessRequest(ctx context.Context, r *http.Request) (read bool, write bool, allowedPages []string) {
+	g, ok := ctx.Value(common.ContextKeyCurrentGuild).(*dstate.GuildSet)
+	member := ContextMember(ctx)
+	if !ok || g == nil || member == nil {
+		return false, false, nil
+	}
+
+	perms := common.GetPagePermissionsCached(g.ID)
+	if len(perms) < 1 {
+		return false, false, nil
+	}
+
+	allowedPages = UserAllowedPages(member.Roles, perms)
+	if len(allowedPages) < 1 {
+		return false, false, nil
+	}
+
+	hasRead, hasWrite := GetUserPageAccessLevel(CurrentCPPage(ctx, r), member.Roles, perms)
+	if hasWrite {
+		return true, true, allowedPages
+	}
+
+	isReadOnlyReq := strings.EqualFold(r.Method, "GET") || strings.EqualFold(r.Method, "OPTIONS")
+	if hasRead && isReadOnlyReq {
+		return true, false, allowedPages
+	}
+
+	return false, false, allowedPages
+}
+
+// ContextAllowedPages returns the pages the member was given access to through the per page permissions,
+// only set if they don't have access to the whole control panel
+func ContextAllowedPages(ctx context.Context) []string {
+	if v := ctx.Value(common.ContextKeyAllowedPages); v != nil {
+		return v.([]string)
+	}
+
+	return nil
+}
+
 func NewLogEntryFromContext(ctx context.Context, action string, params ...*cplogs.Param) *cplogs.LogEntry {
 	user, ok := ctx.Value(common.ContextKeyUser).(*discordgo.User)
 	if !ok {
@@ -270,7 +337,9 @@ func HasPermissionCTX(ctx context.Context, aperms int64) bool {
 	return false
 }
 
+// APIError is a error with a http status code, it's sent as is to the api client
 type APIError struct {
+	Status  int
 	Message string
 }
 
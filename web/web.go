@@ -119,6 +119,7 @@ func loadTemplates() {
 		"templates/index.html", "templates/cp_main.html",
 		"templates/cp_nav.html", "templates/cp_selectserver.html", "templates/cp_logs.html",
 		"templates/status.html", "templates/cp_server_home.html", "templates/cp_core_settings.html",
+		"templates/cp_backup.html", "templates/cp_api_tokens.html",
 	}
 
 	for _, v := range coreTemplates {
@@ -282,6 +283,15 @@ func setupRoutes() *goji.Mux {
 	RootMux.Handle(pat.New("/public/:server/*"), serverPublicMux)
 	ServerPublicMux = serverPublicMux
 
+	// token authenticated REST API, has to be registered before the public api as v1 would match :server
+	APIMux = goji.SubMux()
+	APIMux.Use(ActiveServerMW)
+	APIMux.Use(APITokenMW)
+	APIMux.Use(LoadCoreConfigMiddleware)
+
+	RootMux.Handle(pat.New("/api/v1/:server"), APIMux)
+	RootMux.Handle(pat.New("/api/v1/:server/*"), APIMux)
+
 	// same as above but for API stuff
 	ServerPublicAPIMux = goji.SubMux()
 	ServerPublicAPIMux.Use(ActiveServerMW)
@@ -317,17 +327,38 @@ func setupRoutes() *goji.Mux {
 	RootMux.Handle(pat.New("/manage/:server"), CPMux)
 	RootMux.Handle(pat.New("/manage/:server/*"), CPMux)
 
-	CPMux.Handle(pat.Get("/cplogs"), RenderHandler(HandleCPLogs, "cp_action_logs"))
-	CPMux.Handle(pat.Get("/cplogs/"), RenderHandler(HandleCPLogs, "cp_action_logs"))
+	cpLogsHandler := RenderHandler(HandleCPLogs, "cp_action_logs")
+	CPMux.Handle(pat.Get("/cplogs"), cpLogsHandler)
+	CPMux.Handle(pat.Get("/cplogs/"), cpLogsHandler)
+	CPMux.Handle(pat.Post("/cplogs/changes/:change/revert"), ControllerPostHandler(HandleRevertConfigChange, cpLogsHandler, nil))
 	CPMux.Handle(pat.Get("/home"), ControllerHandler(HandleServerHome, "cp_server_home"))
 	CPMux.Handle(pat.Get("/home/"), ControllerHandler(HandleServerHome, "cp_server_home"))
 
-	coreSettingsHandler := RenderHandler(nil, "cp_core_settings")
+	coreSettingsHandler := ControllerHandler(HandleGetCoreSettings, "cp_core_settings")
 
 	CPMux.Handle(pat.Get("/core/"), coreSettingsHandler)
 	CPMux.Handle(pat.Get("/core"), coreSettingsHandler)
 	CPMux.Handle(pat.Post("/core"), ControllerPostHandler(HandlePostCoreSettings, coreSettingsHandler, CoreConfigPostForm{}))
 
+	backupHandler := ControllerHandler(HandleBackup, "cp_backup")
+	CPMux.Handle(pat.Get("/backup"), backupHandler)
+	CPMux.Handle(pat.Get("/backup/"), backupHandler)
+	CPMux.Handle(pat.Get("/backup/download"), http.HandlerFunc(HandleDownloadBackup))
+	CPMux.Handle(pat.Post("/backup/restore"), ControllerPostHandler(HandleUploadBackup, backupHandler, nil))
+	CPMux.Handle(pat.Post("/backup/restore/confirm"), ControllerPostHandler(HandleConfirmRestoreBackup, backupHandler, RestoreBackupConfirmForm{}))
+
+	apiTokensHandler := ControllerHandler(HandleAPITokens, "cp_api_tokens")
+	CPMux.Handle(pat.Get("/apitokens"), apiTokensHandler)
+	CPMux.Handle(pat.Get("/apitokens/"), apiTokensHandler)
+	CPMux.Handle(pat.Post("/apitokens/new"), ControllerPostHandler(HandleCreateAPIToken, apiTokensHandler, CreateAPITokenForm{}))
+	CPMux.Handle(pat.Post("/apitokens/:token/delete"), ControllerPostHandler(HandleDeleteAPIToken, apiTokensHandler, nil))
+
+	scheduledEventsHandler := ControllerHandler(HandleScheduledEvents, "cp_scheduled_events")
+	CPMux.Handle(pat.Get("/scheduledevents"), scheduledEventsHandler)
+	CPMux.Handle(pat.Get("/scheduledevents/"), scheduledEventsHandler)
+	CPMux.Handle(pat.Post("/scheduledevents/:event/cancel"), ControllerPostHandler(HandleCancelScheduledEvent, scheduledEventsHandler, nil))
+	CPMux.Handle(pat.Post("/scheduledevents/:event/reschedule"), ControllerPostHandler(HandleRescheduleScheduledEvent, scheduledEventsHandler, RescheduleEventForm{}))
+
 	RootMux.Handle(pat.Get("/guild_selection"), RequireSessionMiddleware(ControllerHandler(HandleGetManagedGuilds, "cp_guild_selection")))
 	CPMux.Handle(pat.Get("/guild_selection"), RequireSessionMiddleware(ControllerHandler(HandleGetManagedGuilds, "cp_guild_selection")))
 
@@ -356,6 +387,24 @@ func setupRoutes() *goji.Mux {
 		Icon: "fas fa-database",
 	})
 
+	AddSidebarItem(SidebarCategoryCore, &SidebarItem{
+		Name: "Backups",
+		URL:  "backup",
+		Icon: "fas fa-archive",
+	})
+
+	AddSidebarItem(SidebarCategoryCore, &SidebarItem{
+		Name: "Scheduled events",
+		URL:  "scheduledevents",
+		Icon: "fas fa-clock",
+	})
+
+	AddSidebarItem(SidebarCategoryCore, &SidebarItem{
+		Name: "API tokens",
+		URL:  "apitokens",
+		Icon: "fas fa-key",
+	})
+
 	for _, plugin := range common.Plugins {
 		if webPlugin, ok := plugin.(Plugin); ok {
 			webPlugin.InitWeb()
@@ -454,3 +503,24 @@ var sideBarItems = make(map[string][]*SidebarItem)
 func AddSidebarItem(category string, sItem *SidebarItem) {
 	sideBarItems[category] = append(sideBarItems[category], sItem)
 }
+
+// filterSidebarItems returns the sidebar items for the pages in allowedPages
+func filterSidebarItems(allowedPages []string) map[string][]*SidebarItem {
+	result := make(map[string][]*SidebarItem)
+	for category, items := range sideBarItems {
+		for _, item := range items {
+			if item.External {
+				continue
+			}
+
+			for _, page := range allowedPages {
+				if common.PageMatches(page, item.URL) {
+					result[category] = append(result[category], item)
+					break
+				}
+			}
+		}
+	}
+
+	return result
+}
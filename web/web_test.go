@@ -470,3 +470,49 @@ func TestHasAccesstoGuildSettings(t *testing.T) {
 	}
 
 }
+
+func TestGetUserPageAccessLevel(t *testing.T) {
+	perms := []*common.PagePermission{
+		{Page: "customcommands", WriteRoles: []int64{1}},
+		{Page: "customcommands/database", ReadRoles: []int64{2}},
+		{Page: "automod", ReadRoles: []int64{1}},
+		{Page: "core", WriteRoles: []int64{1, 2}},
+		{Page: "cplogs", ReadRoles: []int64{1, 2}},
+	}
+
+	testCases := []struct {
+		Name  string
+		Path  string
+		Roles []int64
+
+		Read  bool
+		Write bool
+	}{
+		{Name: "no roles", Path: "customcommands", Roles: nil},
+		{Name: "other role", Path: "customcommands", Roles: []int64{3}},
+		{Name: "write role", Path: "customcommands", Roles: []int64{1}, Read: true, Write: true},
+		{Name: "write role sub page", Path: "customcommands/commands/new", Roles: []int64{1}, Read: true, Write: true},
+		{Name: "write role trailing slash", Path: "/customcommands/", Roles: []int64{1}, Read: true, Write: true},
+		{Name: "similar prefix", Path: "customcommandsfoo", Roles: []int64{1}},
+		{Name: "read role sub page", Path: "customcommands/database", Roles: []int64{2}, Read: true},
+		{Name: "read role parent page", Path: "customcommands", Roles: []int64{2}},
+		{Name: "read role", Path: "automod", Roles: []int64{1}, Read: true},
+		{Name: "unlisted page", Path: "moderation", Roles: []int64{1, 2}},
+		{Name: "core never allowed", Path: "core", Roles: []int64{1, 2}},
+		{Name: "cplogs never allowed", Path: "cplogs", Roles: []int64{1, 2}},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.Name, func(it *testing.T) {
+			read, write := GetUserPageAccessLevel(v.Path, v.Roles, perms)
+			if read != v.Read || write != v.Write {
+				it.Errorf("incorrect result, got read: %t write: %t, wanted read: %t write: %t", read, write, v.Read, v.Write)
+			}
+		})
+	}
+
+	allowed := UserAllowedPages([]int64{2}, perms)
+	if len(allowed) != 1 || allowed[0] != "customcommands/database" {
+		t.Errorf("incorrect allowed pages: %v", allowed)
+	}
+}
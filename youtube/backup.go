@@ -0,0 +1,125 @@
+package youtube
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/botlabs-gg/yagpdb/v2/common"
+	"github.com/botlabs-gg/yagpdb/v2/lib/discordgo"
+	"github.com/jinzhu/gorm"
+)
+
+type youtubeBackup struct {
+	Subscriptions []*ChannelSubscription
+	Announcement  *YoutubeAnnouncements
+}
+
+var _ common.PluginWithBackup = (*Plugin)(nil)
+
+func (p *Plugin) BackupVersion() int {
+	return 1
+}
+
+func (p *Plugin) ExportBackup(ctx context.Context, guildID int64) (interface{}, error) {
+	backup := &youtubeBackup{}
+
+	err := common.GORM.Where("guild_id = ?", guildID).Order("id asc").Find(&backup.Subscriptions).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	var announcement YoutubeAnnouncements
+	err = common.GORM.Where("guild_id = ?", guildID).First(&announcement).Error
+	if err == nil {
+		backup.Announcement = &announcement
+	} else if err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	return backup, nil
+}
+
+func (p *Plugin) RestoreBackup(ctx context.Context, guildID int64, data json.RawMessage, restore *common.BackupRestore) error {
+	var backup youtubeBackup
+	err := json.Unmarshal(data, &backup)
+	if err != nil {
+		return err
+	}
+
+	maxFeeds := MaxFeedsForContext(ctx)
+	if len(backup.Subscriptions) > maxFeeds {
+		restore.Note("Only the first %d of %d feeds were restored", maxFeeds, len(backup.Subscriptions))
+		backup.Subscriptions = backup.Subscriptions[:maxFeeds]
+	}
+
+	var oldSubs []*ChannelSubscription
+	err = common.GORM.Where("guild_id = ?", guildID).Find(&oldSubs).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return err
+	}
+
+	err = common.BlockingLockRedisKey(RedisChannelsLockKey, 0, 10)
+	if err != nil {
+		return err
+	}
+	defer common.UnlockRedisKey(RedisChannelsLockKey)
+
+	tx := common.GORM.Begin()
+	err = tx.Where("guild_id = ?", guildID).Delete(ChannelSubscription{}).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for _, v := range backup.Subscriptions {
+		v.ID = 0
+		v.GuildID = discordgo.StrID(guildID)
+		v.ChannelID = restore.ChannelStr(v.ChannelID)
+		v.MentionRoles = restore.Roles(v.MentionRoles)
+
+		if v.ChannelID == "" {
+			restore.Note("The channel of the %s feed was not found, it was disabled", v.YoutubeChannelName)
+			v.Enabled = common.BoolToPointer(false)
+		}
+
+		err = tx.Create(v).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if backup.Announcement != nil {
+		backup.Announcement.GuildID = guildID
+		err = tx.Save(backup.Announcement).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if restore.DryRun {
+		return tx.Rollback().Error
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return err
+	}
+
+	for _, v := range backup.Subscriptions {
+		err = p.MaybeAddChannelWatch(false, v.YoutubeChannelID)
+		if err != nil {
+			logger.WithError(err).WithField("yt_channel", v.YoutubeChannelID).Error("failed adding channel watch for restored feed")
+		}
+	}
+
+	// MaybeRemoveChannelWatch takes the lock itself
+	go func() {
+		for _, v := range oldSubs {
+			p.MaybeRemoveChannelWatch(v.YoutubeChannelID)
+		}
+	}()
+
+	return nil
+}
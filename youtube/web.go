@@ -90,6 +90,8 @@ func (p *Plugin) InitWeb() {
 
 	// The handler from pubsubhub
 	web.RootMux.Handle(pat.New("/yt_new_upload/"+confWebsubVerifytoken.GetString()), http.HandlerFunc(p.HandleFeedUpdate))
+
+	cplogs.RegisterConfigSource(cplogs.BackupConfigSource(p, "youtube", "Youtube feeds", "youtube"))
 }
 
 func (p *Plugin) HandleYoutube(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {